@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS image_product_id_idx;
+DROP INDEX IF EXISTS favourite_product_id_idx;
@@ -0,0 +1,3 @@
+-- images and favourites of feed page are loaded by product_id = ANY($1)
+CREATE INDEX IF NOT EXISTS image_product_id_idx ON public."image" (product_id);
+CREATE INDEX IF NOT EXISTS favourite_product_id_idx ON public."favourite" (product_id);
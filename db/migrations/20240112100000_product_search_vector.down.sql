@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS product_title_trgm_idx;
+DROP INDEX IF EXISTS product_search_vector_idx;
+
+DROP TRIGGER IF EXISTS category_search_vector_check ON public."category";
+DROP FUNCTION IF EXISTS category_search_vector_update();
+
+DROP TRIGGER IF EXISTS product_search_vector_check ON public."product";
+DROP FUNCTION IF EXISTS product_search_vector_update();
+DROP FUNCTION IF EXISTS product_search_vector(TEXT, TEXT, BIGINT);
+
+ALTER TABLE public."product"
+    DROP COLUMN search_vector;
+
+DROP EXTENSION IF EXISTS pg_trgm;
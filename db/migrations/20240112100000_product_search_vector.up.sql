@@ -0,0 +1,55 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- search_vector can't be GENERATED column because it depends on name of category,
+-- so it's maintained by triggers on product and category.
+ALTER TABLE public."product"
+    ADD COLUMN search_vector TSVECTOR DEFAULT ''::tsvector NOT NULL;
+
+CREATE OR REPLACE FUNCTION product_search_vector(title TEXT, description TEXT, category_id BIGINT)
+    RETURNS TSVECTOR AS
+$$
+SELECT setweight(to_tsvector('russian', COALESCE(title, '')), 'A') ||
+       setweight(to_tsvector('russian', COALESCE(description, '')), 'B') ||
+       setweight(to_tsvector('russian', COALESCE((SELECT name FROM public."category" WHERE id = category_id), '')), 'C');
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION product_search_vector_update()
+    RETURNS TRIGGER AS
+$$
+BEGIN
+    NEW.search_vector = product_search_vector(NEW.title, NEW.description, NEW.category_id);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS product_search_vector_check ON public."product";
+CREATE TRIGGER product_search_vector_check
+    BEFORE INSERT OR UPDATE OF title, description, category_id
+    ON public."product"
+    FOR EACH ROW
+EXECUTE PROCEDURE product_search_vector_update();
+
+CREATE OR REPLACE FUNCTION category_search_vector_update()
+    RETURNS TRIGGER AS
+$$
+BEGIN
+    UPDATE public."product"
+    SET search_vector = product_search_vector(title, description, category_id)
+    WHERE category_id = NEW.id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS category_search_vector_check ON public."category";
+CREATE TRIGGER category_search_vector_check
+    AFTER UPDATE OF name
+    ON public."category"
+    FOR EACH ROW
+EXECUTE PROCEDURE category_search_vector_update();
+
+UPDATE public."product"
+SET search_vector = product_search_vector(title, description, category_id);
+
+CREATE INDEX IF NOT EXISTS product_search_vector_idx ON public."product" USING GIN (search_vector);
+-- used for typo tolerant search by title
+CREATE INDEX IF NOT EXISTS product_title_trgm_idx ON public."product" USING GIN (title gin_trgm_ops);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS product_created_at_idx;
+
+DROP TABLE IF EXISTS public."notification";
+DROP TABLE IF EXISTS public."saved_search";
+
+DROP SEQUENCE IF EXISTS notification_id_seq;
+DROP SEQUENCE IF EXISTS saved_search_id_seq;
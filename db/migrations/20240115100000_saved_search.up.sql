@@ -0,0 +1,37 @@
+CREATE SEQUENCE IF NOT EXISTS saved_search_id_seq;
+CREATE SEQUENCE IF NOT EXISTS notification_id_seq;
+
+-- zero value of filter column means that filter is not applied
+CREATE TABLE IF NOT EXISTS public."saved_search"
+(
+    id              BIGINT                   DEFAULT NEXTVAL('saved_search_id_seq'::regclass) NOT NULL PRIMARY KEY,
+    owner_id        BIGINT                                                                    NOT NULL REFERENCES public."user" (id) ON DELETE CASCADE,
+    query           TEXT                                                                      NOT NULL CHECK (query <> '')
+        CONSTRAINT max_len_query CHECK (LENGTH(query) <= 256),
+    category_id     BIGINT                   DEFAULT 0                                        NOT NULL,
+    city_id         BIGINT                   DEFAULT 0                                        NOT NULL,
+    min_price       BIGINT                   DEFAULT 0                                        NOT NULL
+        CONSTRAINT not_negative_min_price CHECK (min_price >= 0),
+    max_price       BIGINT                   DEFAULT 0                                        NOT NULL
+        CONSTRAINT not_negative_max_price CHECK (max_price >= 0),
+    delivery        BOOLEAN                  DEFAULT FALSE                                    NOT NULL,
+    safe_deal       BOOLEAN                  DEFAULT FALSE                                    NOT NULL,
+    premium_only    BOOLEAN                  DEFAULT FALSE                                    NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT NOW()                                    NOT NULL,
+    last_checked_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()                                    NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS public."notification"
+(
+    id              BIGINT                   DEFAULT NEXTVAL('notification_id_seq'::regclass) NOT NULL PRIMARY KEY,
+    owner_id        BIGINT                                                                    NOT NULL REFERENCES public."user" (id) ON DELETE CASCADE,
+    saved_search_id BIGINT                                                                    NOT NULL REFERENCES public."saved_search" (id) ON DELETE CASCADE,
+    product_id      BIGINT                                                                    NOT NULL REFERENCES public."product" (id) ON DELETE CASCADE,
+    is_read         BOOLEAN                  DEFAULT FALSE                                    NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT NOW()                                    NOT NULL,
+    CONSTRAINT uniq_together_saved_search_id_product_id unique (saved_search_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS saved_search_owner_id_idx ON public."saved_search" (owner_id);
+CREATE INDEX IF NOT EXISTS notification_owner_id_idx ON public."notification" (owner_id);
+CREATE INDEX IF NOT EXISTS product_created_at_idx ON public."product" (created_at);
@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS public."order_status_history";
+
+DROP SEQUENCE IF EXISTS order_status_history_id_seq;
+
+UPDATE public."order"
+SET status = 3
+WHERE status > 3;
+
+ALTER TABLE public."order"
+    DROP CONSTRAINT IF EXISTS status_contract;
+ALTER TABLE public."order"
+    ADD CONSTRAINT status_contract CHECK ( status BETWEEN 0 AND 3);
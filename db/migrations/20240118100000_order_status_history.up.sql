@@ -0,0 +1,22 @@
+ALTER TABLE public."order"
+    DROP CONSTRAINT IF EXISTS status_contract;
+ALTER TABLE public."order"
+    ADD CONSTRAINT status_contract CHECK ( status BETWEEN 0 AND 7);
+
+CREATE SEQUENCE IF NOT EXISTS order_status_history_id_seq;
+
+CREATE TABLE IF NOT EXISTS public."order_status_history"
+(
+    id          BIGINT                   DEFAULT NEXTVAL('order_status_history_id_seq'::regclass) NOT NULL PRIMARY KEY,
+    order_id    BIGINT                                                                            NOT NULL REFERENCES public."order" (id) ON DELETE CASCADE,
+    actor_id    BIGINT                                                                            NOT NULL REFERENCES public."user" (id),
+    actor_role  TEXT                                                                              NOT NULL
+        CONSTRAINT actor_role_contract CHECK (actor_role IN ('buyer', 'seller')),
+    from_status SMALLINT                                                                          NOT NULL
+        CONSTRAINT from_status_contract CHECK ( from_status BETWEEN 0 AND 7),
+    to_status   SMALLINT                                                                          NOT NULL
+        CONSTRAINT to_status_contract CHECK ( to_status BETWEEN 0 AND 7),
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT NOW()                                            NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS order_status_history_order_id_idx ON public."order_status_history" (order_id);
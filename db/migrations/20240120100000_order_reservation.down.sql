@@ -0,0 +1,15 @@
+DELETE
+FROM public."order_status_history"
+WHERE actor_role = 'system';
+
+ALTER TABLE public."order_status_history"
+    DROP CONSTRAINT IF EXISTS actor_role_contract;
+ALTER TABLE public."order_status_history"
+    ADD CONSTRAINT actor_role_contract CHECK (actor_role IN ('buyer', 'seller'));
+ALTER TABLE public."order_status_history"
+    ALTER COLUMN actor_id SET NOT NULL;
+
+DROP INDEX IF EXISTS order_reserved_until_idx;
+
+ALTER TABLE public."order"
+    DROP COLUMN IF EXISTS reserved_until;
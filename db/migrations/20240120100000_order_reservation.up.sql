@@ -0,0 +1,14 @@
+-- reserved_until is set while order is in processing (status 1), after that time
+-- reservation expires, order is cancelled and products return to stock
+ALTER TABLE public."order"
+    ADD COLUMN IF NOT EXISTS reserved_until TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+
+CREATE INDEX IF NOT EXISTS order_reserved_until_idx ON public."order" (reserved_until) WHERE status = 1;
+
+-- expired reservations are cancelled by system, so actor may be absent
+ALTER TABLE public."order_status_history"
+    ALTER COLUMN actor_id DROP NOT NULL;
+ALTER TABLE public."order_status_history"
+    DROP CONSTRAINT IF EXISTS actor_role_contract;
+ALTER TABLE public."order_status_history"
+    ADD CONSTRAINT actor_role_contract CHECK (actor_role IN ('buyer', 'seller', 'system'));
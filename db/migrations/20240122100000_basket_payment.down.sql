@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS order_payment_id_idx;
+
+ALTER TABLE public."order"
+    DROP COLUMN IF EXISTS price,
+    DROP COLUMN IF EXISTS payment_id;
+
+DROP TABLE IF EXISTS public."payment";
+
+DROP SEQUENCE IF EXISTS payment_id_seq;
@@ -0,0 +1,34 @@
+CREATE SEQUENCE IF NOT EXISTS payment_id_seq;
+
+-- status: 1 pending, 2 waiting_for_capture, 3 succeeded, 4 canceled like statuses of yookassa.
+-- capture = false means that money are held until orders are delivered (safe deal)
+CREATE TABLE IF NOT EXISTS public."payment"
+(
+    id              BIGINT                   DEFAULT NEXTVAL('payment_id_seq'::regclass) NOT NULL PRIMARY KEY,
+    owner_id        BIGINT                                                               NOT NULL REFERENCES public."user" (id),
+    yookassa_id     TEXT                     DEFAULT NULL UNIQUE,
+    idempotency_key TEXT                                                                 NOT NULL UNIQUE
+        CONSTRAINT max_len_idempotency_key CHECK (LENGTH(idempotency_key) <= 64),
+    amount          BIGINT                                                               NOT NULL
+        CONSTRAINT positive_amount CHECK (amount > 0),
+    capture         BOOLEAN                  DEFAULT TRUE                                NOT NULL,
+    status          SMALLINT                 DEFAULT 1                                   NOT NULL
+        CONSTRAINT payment_status_contract CHECK ( status BETWEEN 1 AND 4),
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT NOW()                               NOT NULL,
+    updated_at      TIMESTAMP WITH TIME ZONE DEFAULT NOW()                               NOT NULL
+);
+
+DROP TRIGGER IF EXISTS verify_updated_at ON public."payment";
+CREATE TRIGGER verify_updated_at
+    BEFORE UPDATE
+    ON public."payment"
+    FOR EACH ROW
+EXECUTE PROCEDURE updated_at_now();
+
+-- price of product is fixed in order at the moment of checkout
+ALTER TABLE public."order"
+    ADD COLUMN IF NOT EXISTS payment_id BIGINT DEFAULT NULL REFERENCES public."payment" (id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS price      BIGINT DEFAULT NULL
+        CONSTRAINT not_negative_order_price CHECK (price >= 0);
+
+CREATE INDEX IF NOT EXISTS order_payment_id_idx ON public."order" (payment_id);
@@ -0,0 +1,10 @@
+-- handled changes of payment statuses in yookassa, the same status of payment is handled once
+-- regardless of source: notification or polling. status like in public."payment".
+CREATE TABLE IF NOT EXISTS public."payment_event"
+(
+    yookassa_id TEXT                                   NOT NULL,
+    status      SMALLINT                               NOT NULL
+        CONSTRAINT payment_event_status_contract CHECK ( status BETWEEN 1 AND 4),
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    PRIMARY KEY (yookassa_id, status)
+);
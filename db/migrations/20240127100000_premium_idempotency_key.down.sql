@@ -0,0 +1 @@
+DROP TABLE IF EXISTS public."premium_idempotency_key";
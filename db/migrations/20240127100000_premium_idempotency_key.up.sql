@@ -0,0 +1,15 @@
+-- idempotence keys of premium payments in yookassa. Key is the same for repeated
+-- requests with the same metadata of payment, so retry doesn't create second payment.
+CREATE TABLE IF NOT EXISTS public."premium_idempotency_key"
+(
+    user_id         BIGINT                                 NOT NULL REFERENCES public."user" (id) ON DELETE CASCADE,
+    product_id      BIGINT                                 NOT NULL REFERENCES public."product" (id) ON DELETE CASCADE,
+    period_code     SMALLINT                               NOT NULL,
+    idempotency_key TEXT                                   NOT NULL
+        CONSTRAINT max_len_premium_idempotency_key CHECK (LENGTH(idempotency_key) <= 64),
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    PRIMARY KEY (user_id, product_id, period_code)
+);
+
+CREATE INDEX IF NOT EXISTS premium_idempotency_key_created_at_idx
+    ON public."premium_idempotency_key" (created_at);
@@ -0,0 +1,14 @@
+-- sessions of auth service, id is jti of jwt. Revoked session stays until expire,
+-- so token with its jti is rejected even if its signature is valid.
+CREATE TABLE IF NOT EXISTS public."session"
+(
+    id         TEXT                                   NOT NULL PRIMARY KEY
+        CONSTRAINT max_len_session_id CHECK (LENGTH(id) <= 64),
+    user_id    BIGINT                                 NOT NULL REFERENCES public."user" (id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    expire_at  TIMESTAMP WITH TIME ZONE               NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS session_user_id_idx ON public."session" (user_id);
+CREATE INDEX IF NOT EXISTS session_expire_at_idx ON public."session" (expire_at);
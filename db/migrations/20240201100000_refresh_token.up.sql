@@ -0,0 +1,12 @@
+-- refresh tokens of sessions, only sha256 of token is stored. Token is single-use:
+-- used_at is set on rotation and repeated use of it revokes the whole session.
+CREATE TABLE IF NOT EXISTS public."refresh_token"
+(
+    token_hash TEXT                                   NOT NULL PRIMARY KEY
+        CONSTRAINT max_len_token_hash CHECK (LENGTH(token_hash) <= 64),
+    session_id TEXT                                   NOT NULL REFERENCES public."session" (id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    used_at    TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS refresh_token_session_id_idx ON public."refresh_token" (session_id);
@@ -0,0 +1,13 @@
+-- failed logins by email and by ip of client for protection from brute-force of passwords.
+-- key is "email:<email>" or "ip:<ip>", failures older than period of reset are forgotten.
+CREATE TABLE IF NOT EXISTS public."login_attempt"
+(
+    key             TEXT                                   NOT NULL PRIMARY KEY
+        CONSTRAINT max_len_login_attempt_key CHECK (LENGTH(key) <= 320),
+    count_failures  INT                                    NOT NULL
+        CONSTRAINT positive_count_failures CHECK (count_failures > 0),
+    last_failure_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    locked_until    TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS login_attempt_last_failure_at_idx ON public."login_attempt" (last_failure_at);
@@ -0,0 +1,18 @@
+ALTER TABLE public."user"
+    ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+
+-- single-use tokens sent by email: verification of email and reset of password.
+-- Only sha256 of token is stored, purpose is 1 - verify email, 2 - reset password.
+CREATE TABLE IF NOT EXISTS public."user_token"
+(
+    token_hash TEXT                                   NOT NULL PRIMARY KEY
+        CONSTRAINT max_len_token_hash CHECK (LENGTH(token_hash) <= 64),
+    user_id    BIGINT                                 NOT NULL REFERENCES public."user" (id) ON DELETE CASCADE,
+    purpose    SMALLINT                               NOT NULL CHECK (purpose IN (1, 2)),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    expire_at  TIMESTAMP WITH TIME ZONE               NOT NULL,
+    used_at    TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS user_token_user_id_idx ON public."user_token" (user_id);
+CREATE INDEX IF NOT EXISTS user_token_expire_at_idx ON public."user_token" (expire_at);
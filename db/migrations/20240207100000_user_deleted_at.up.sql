@@ -0,0 +1,4 @@
+-- deleted user isn't removed, because completed orders of sellers reference him.
+-- Personal data of such user is anonymised and deleted_at is set.
+ALTER TABLE public."user"
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
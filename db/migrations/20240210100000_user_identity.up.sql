@@ -0,0 +1,16 @@
+-- accounts of user in external OAuth providers, e.g. vk or oidc.
+-- subject is id of user inside provider, it doesn't change unlike email.
+CREATE TABLE IF NOT EXISTS public."user_identity"
+(
+    provider   TEXT                                   NOT NULL
+        CONSTRAINT max_len_provider CHECK (LENGTH(provider) <= 32),
+    subject    TEXT                                   NOT NULL
+        CONSTRAINT max_len_subject CHECK (LENGTH(subject) <= 256),
+    user_id    BIGINT                                 NOT NULL REFERENCES public."user" (id) ON DELETE CASCADE,
+    email      TEXT                                   NOT NULL
+        CONSTRAINT max_len_email CHECK (LENGTH(email) <= 256),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    PRIMARY KEY (provider, subject)
+);
+
+CREATE INDEX IF NOT EXISTS user_identity_user_id_idx ON public."user_identity" (user_id);
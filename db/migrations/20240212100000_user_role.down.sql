@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS public."admin_audit";
+DROP SEQUENCE IF EXISTS admin_audit_id_seq;
+
+ALTER TABLE public."user"
+    DROP COLUMN IF EXISTS banned_at;
+ALTER TABLE public."user"
+    DROP COLUMN IF EXISTS role;
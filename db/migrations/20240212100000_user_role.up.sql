@@ -0,0 +1,25 @@
+-- role of user is one of user, moderator, admin. First admin is assigned by hand.
+-- Banned user can't log in, his sessions are revoked at ban.
+ALTER TABLE public."user"
+    ADD COLUMN IF NOT EXISTS role TEXT DEFAULT 'user' NOT NULL
+        CONSTRAINT correctness_role CHECK (role IN ('user', 'moderator', 'admin'));
+ALTER TABLE public."user"
+    ADD COLUMN IF NOT EXISTS banned_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+
+-- every action of admins and moderators, target_id is id of user, product or comment depending on action.
+CREATE SEQUENCE IF NOT EXISTS admin_audit_id_seq;
+
+CREATE TABLE IF NOT EXISTS public."admin_audit"
+(
+    id         BIGINT                   DEFAULT NEXTVAL('admin_audit_id_seq'::regclass) NOT NULL PRIMARY KEY,
+    admin_id   BIGINT                                                                   NOT NULL REFERENCES public."user" (id),
+    action     TEXT                                                                     NOT NULL
+        CONSTRAINT max_len_action CHECK (LENGTH(action) <= 32),
+    target_id  BIGINT                                                                   NOT NULL,
+    details    TEXT                     DEFAULT ''                                      NOT NULL
+        CONSTRAINT max_len_details CHECK (LENGTH(details) <= 1000),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()                                   NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS admin_audit_created_at_idx ON public."admin_audit" (created_at);
+CREATE INDEX IF NOT EXISTS admin_audit_admin_id_idx ON public."admin_audit" (admin_id);
@@ -0,0 +1,26 @@
+-- owners of images in file service, file_name is url of image without prefix img/.
+-- Garbage collector of file service deletes files without owners, which are older than grace period.
+CREATE TABLE IF NOT EXISTS public."image_reference"
+(
+    file_name  TEXT                                   NOT NULL
+        CONSTRAINT max_len_file_name CHECK (LENGTH(file_name) <= 256),
+    owner_type TEXT                                   NOT NULL
+        CONSTRAINT correctness_owner_type CHECK (owner_type IN ('product', 'user')),
+    owner_id   BIGINT                                 NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    PRIMARY KEY (owner_type, owner_id, file_name)
+);
+
+CREATE INDEX IF NOT EXISTS image_reference_file_name_idx ON public."image_reference" (file_name);
+
+-- images uploaded before tracking of references mustn't be deleted.
+INSERT INTO public."image_reference" (file_name, owner_type, owner_id)
+SELECT REGEXP_REPLACE(url, '^img/', ''), 'product', product_id
+FROM public."image"
+ON CONFLICT DO NOTHING;
+
+INSERT INTO public."image_reference" (file_name, owner_type, owner_id)
+SELECT REGEXP_REPLACE(avatar, '^img/', ''), 'user', id
+FROM public."user"
+WHERE avatar IS NOT NULL AND avatar <> ''
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,11 @@
+-- bytes and count of files uploaded by user to file service per day, limits are set in config of file service.
+CREATE TABLE IF NOT EXISTS public."upload_quota"
+(
+    user_id BIGINT            NOT NULL,
+    day     DATE              NOT NULL,
+    bytes   BIGINT DEFAULT 0  NOT NULL
+        CONSTRAINT not_negative_bytes CHECK (bytes >= 0),
+    files   INTEGER DEFAULT 0 NOT NULL
+        CONSTRAINT not_negative_files CHECK (files >= 0),
+    PRIMARY KEY (user_id, day)
+);
@@ -0,0 +1,261 @@
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/admin/usecases"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/server/delivery"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+)
+
+var _ IAdminService = (*usecases.AdminService)(nil)
+
+type IAdminService interface {
+	GetUsers(ctx context.Context, cursor *models.Cursor, count uint64) (*models.AdminUserList, error)
+	BanUser(ctx context.Context, r io.Reader, adminID uint64) error
+	UnbanUser(ctx context.Context, r io.Reader, adminID uint64) error
+	CloseProduct(ctx context.Context, r io.Reader, adminID uint64) error
+	DeleteComment(ctx context.Context, r io.Reader, adminID uint64) error
+	SetPremium(ctx context.Context, r io.Reader, adminID uint64) error
+	GetAuditLog(ctx context.Context, cursor *models.Cursor, count uint64) (*models.AuditLog, error)
+}
+
+// AdminHandler handlers must be wrapped by delivery.RequireRole,
+// which puts id of admin or moderator into context of request.
+type AdminHandler struct {
+	service IAdminService
+	logger  *mylogger.MyLogger
+}
+
+func NewAdminHandler(service IAdminService) (*AdminHandler, error) {
+	logger, err := mylogger.Get()
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return &AdminHandler{
+		service: service,
+		logger:  logger,
+	}, nil
+}
+
+// parseCursorCount returns nil cursor for the first page.
+func parseCursorCount(r *http.Request) (*models.Cursor, uint64, error) {
+	cursor, err := models.ParseCursor(utils.ParseStringFromRequest(r, "cursor"))
+	if err != nil {
+		return nil, 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	count, err := utils.ParseUint64FromRequest(r, "count")
+	if err != nil {
+		return nil, 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return cursor, count, nil
+}
+
+// handleAction calls action of admin with body of request and sends responseSuccessful.
+func (a *AdminHandler) handleAction(w http.ResponseWriter, r *http.Request,
+	action func(ctx context.Context, r io.Reader, adminID uint64) error, responseSuccessful string,
+) {
+	if r.Method != http.MethodPost {
+		http.Error(w, `Method not allowed`, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	ctx := r.Context()
+	logger := a.logger.LogReqID(ctx)
+
+	adminID, ok := delivery.GetUserIDCtx(ctx)
+	if !ok {
+		responses.HandleErr(w, r, logger, delivery.ErrForbidden)
+
+		return
+	}
+
+	err := action(ctx, r.Body, adminID)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	responses.SendResponse(w, logger, responses.NewResponseSuccessful(responseSuccessful))
+	logger.Infof("in %s: %s", r.URL.Path, responseSuccessful)
+}
+
+// GetUsersHandler godoc
+//
+//	@Summary    get users for admin
+//	@Description  get users from newest to oldest with role, time of ban and deletion. Only for admins
+//	@Tags admin
+//	@Produce    json
+//	@Param      cursor  query string false  "next_cursor from previous page, empty for the first page"
+//	@Param      count  query uint64 true  "count of users, not more than 100"
+//	@Success    200  {object} AdminUserListResponse
+//	@Failure    405  {string} string
+//	@Failure    500  {string} string
+//	@Failure    222  {object} responses.ErrorResponse "Error". Внутри body статус может быть badContent(4400), badFormat(4000), forbidden(4403)//nolint:lll
+//	@Router      /admin/user/get_list [get]
+func (a *AdminHandler) GetUsersHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, `Method not allowed`, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	ctx := r.Context()
+	logger := a.logger.LogReqID(ctx)
+
+	cursor, count, err := parseCursorCount(r)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	userList, err := a.service.GetUsers(ctx, cursor, count)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	responses.SendResponse(w, logger, NewAdminUserListResponse(userList))
+	logger.Infof("in GetUsersHandler: get users: %d", len(userList.Users))
+}
+
+// BanUserHandler godoc
+//
+//	@Summary    ban user
+//	@Description  ban user by id and revoke all his sessions. Admins can't be banned. Only for admins
+//	@Tags admin
+//	@Accept      json
+//	@Produce    json
+//	@Param      action  body models.AdminAction true  "id of user and reason"
+//	@Success    200  {object} responses.ResponseSuccessful
+//	@Failure    405  {string} string
+//	@Failure    500  {string} string
+//	@Failure    222  {object} responses.ErrorResponse "Error". Внутри body статус может быть badContent(4400), badFormat(4000), forbidden(4403)//nolint:lll
+//	@Router      /admin/user/ban [post]
+func (a *AdminHandler) BanUserHandler(w http.ResponseWriter, r *http.Request) {
+	a.handleAction(w, r, a.service.BanUser, ResponseSuccessfulBanUser)
+}
+
+// UnbanUserHandler godoc
+//
+//	@Summary    unban user
+//	@Description  unban user by id. Only for admins
+//	@Tags admin
+//	@Accept      json
+//	@Produce    json
+//	@Param      action  body models.AdminAction true  "id of user and reason"
+//	@Success    200  {object} responses.ResponseSuccessful
+//	@Failure    405  {string} string
+//	@Failure    500  {string} string
+//	@Failure    222  {object} responses.ErrorResponse "Error". Внутри body статус может быть badContent(4400), badFormat(4000), forbidden(4403)//nolint:lll
+//	@Router      /admin/user/unban [post]
+func (a *AdminHandler) UnbanUserHandler(w http.ResponseWriter, r *http.Request) {
+	a.handleAction(w, r, a.service.UnbanUser, ResponseSuccessfulUnbanUser)
+}
+
+// CloseProductHandler godoc
+//
+//	@Summary    force close product
+//	@Description  close product of any saler by id. For moderators and admins
+//	@Tags admin
+//	@Accept      json
+//	@Produce    json
+//	@Param      action  body models.AdminAction true  "id of product and reason"
+//	@Success    200  {object} responses.ResponseSuccessful
+//	@Failure    405  {string} string
+//	@Failure    500  {string} string
+//	@Failure    222  {object} responses.ErrorResponse "Error". Внутри body статус может быть badContent(4400), badFormat(4000), forbidden(4403)//nolint:lll
+//	@Router      /admin/product/close [post]
+func (a *AdminHandler) CloseProductHandler(w http.ResponseWriter, r *http.Request) {
+	a.handleAction(w, r, a.service.CloseProduct, ResponseSuccessfulCloseProduct)
+}
+
+// DeleteCommentHandler godoc
+//
+//	@Summary    delete comment
+//	@Description  delete comment of any user by id. For moderators and admins
+//	@Tags admin
+//	@Accept      json
+//	@Produce    json
+//	@Param      action  body models.AdminAction true  "id of comment and reason"
+//	@Success    200  {object} responses.ResponseSuccessful
+//	@Failure    405  {string} string
+//	@Failure    500  {string} string
+//	@Failure    222  {object} responses.ErrorResponse "Error". Внутри body статус может быть badContent(4400), badFormat(4000), forbidden(4403)//nolint:lll
+//	@Router      /admin/comment/delete [post]
+func (a *AdminHandler) DeleteCommentHandler(w http.ResponseWriter, r *http.Request) {
+	a.handleAction(w, r, a.service.DeleteComment, ResponseSuccessfulDeleteComment)
+}
+
+// SetPremiumHandler godoc
+//
+//	@Summary    set premium of product
+//	@Description  give premium to product for days since now without payment, zero days removes premium.
+//	@Description  Only for admins
+//	@Tags admin
+//	@Accept      json
+//	@Produce    json
+//	@Param      premium  body models.AdminPremium true  "id of product, days and reason"
+//	@Success    200  {object} responses.ResponseSuccessful
+//	@Failure    405  {string} string
+//	@Failure    500  {string} string
+//	@Failure    222  {object} responses.ErrorResponse "Error". Внутри body статус может быть badContent(4400), badFormat(4000), forbidden(4403)//nolint:lll
+//	@Router      /admin/product/premium [post]
+func (a *AdminHandler) SetPremiumHandler(w http.ResponseWriter, r *http.Request) {
+	a.handleAction(w, r, a.service.SetPremium, ResponseSuccessfulSetPremium)
+}
+
+// GetAuditLogHandler godoc
+//
+//	@Summary    get audit log
+//	@Description  get actions of admins and moderators from newest to oldest. Only for admins
+//	@Tags admin
+//	@Produce    json
+//	@Param      cursor  query string false  "next_cursor from previous page, empty for the first page"
+//	@Param      count  query uint64 true  "count of records, not more than 100"
+//	@Success    200  {object} AuditLogResponse
+//	@Failure    405  {string} string
+//	@Failure    500  {string} string
+//	@Failure    222  {object} responses.ErrorResponse "Error". Внутри body статус может быть badContent(4400), badFormat(4000), forbidden(4403)//nolint:lll
+//	@Router      /admin/audit/get_list [get]
+func (a *AdminHandler) GetAuditLogHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, `Method not allowed`, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	ctx := r.Context()
+	logger := a.logger.LogReqID(ctx)
+
+	cursor, count, err := parseCursorCount(r)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	auditLog, err := a.service.GetAuditLog(ctx, cursor, count)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	responses.SendResponse(w, logger, NewAuditLogResponse(auditLog))
+	logger.Infof("in GetAuditLogHandler: get audit records: %d", len(auditLog.Records))
+}
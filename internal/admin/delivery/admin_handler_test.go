@@ -0,0 +1,191 @@
+package delivery_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/admin/delivery"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/admin/mocks"
+	serverdelivery "github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/server/delivery"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/auth"
+	authmocks "github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/auth/mocks"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses/statuses"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils/test"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBanUserHandler(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	type TestCase struct {
+		name                         string
+		behaviorSessionManagerClient func(m *authmocks.MockSessionMangerClient)
+		behaviorAdminService         func(m *mocks.MockIAdminService)
+		expectedResponse             any
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			behaviorSessionManagerClient: func(m *authmocks.MockSessionMangerClient) {
+				m.EXPECT().Check(gomock.Any(), gomock.Any()).
+					Return(&auth.UserID{UserId: test.UserID, Role: auth.RoleAdmin}, nil)
+			},
+			behaviorAdminService: func(m *mocks.MockIAdminService) {
+				m.EXPECT().BanUser(gomock.Any(), gomock.Any(), test.UserID).Return(nil)
+			},
+			expectedResponse: responses.NewResponseSuccessful(delivery.ResponseSuccessfulBanUser),
+		},
+		{
+			name: "test moderator can't ban",
+			behaviorSessionManagerClient: func(m *authmocks.MockSessionMangerClient) {
+				m.EXPECT().Check(gomock.Any(), gomock.Any()).
+					Return(&auth.UserID{UserId: test.UserID, Role: auth.RoleModerator}, nil)
+			},
+			behaviorAdminService: func(m *mocks.MockIAdminService) {},
+			expectedResponse: responses.NewErrResponse(statuses.StatusForbidden,
+				"Недостаточно прав"),
+		},
+		{
+			name: "test old jwt without role",
+			behaviorSessionManagerClient: func(m *authmocks.MockSessionMangerClient) {
+				m.EXPECT().Check(gomock.Any(), gomock.Any()).
+					Return(&auth.UserID{UserId: test.UserID}, nil)
+			},
+			behaviorAdminService: func(m *mocks.MockIAdminService) {},
+			expectedResponse: responses.NewErrResponse(statuses.StatusForbidden,
+				"Недостаточно прав"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSessionManagerClient := authmocks.NewMockSessionMangerClient(ctrl)
+			testCase.behaviorSessionManagerClient(mockSessionManagerClient)
+
+			mockAdminService := mocks.NewMockIAdminService(ctrl)
+			testCase.behaviorAdminService(mockAdminService)
+
+			adminHandler, err := delivery.NewAdminHandler(mockAdminService)
+			if err != nil {
+				t.Fatalf("Failed create adminHandler %s", err.Error())
+			}
+
+			request := httptest.NewRequest(http.MethodPost, "/api/v1/admin/user/ban",
+				strings.NewReader(`{"target_id":2, "reason":"spam"}`))
+			request.AddCookie(&test.Cookie)
+
+			recorder := httptest.NewRecorder()
+
+			serverdelivery.RequireRole(mockSessionManagerClient, auth.RoleAdmin,
+				adminHandler.BanUserHandler)(recorder, request)
+
+			err = test.CompareHTTPTestResult(recorder, testCase.expectedResponse)
+			if err != nil {
+				t.Fatalf("Failed CompareHTTPTestResult %+v", err)
+			}
+		})
+	}
+}
+
+func TestGetAuditLogHandler(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	createdAt := time.Date(2024, 2, 12, 10, 0, 0, 0, time.UTC)
+	cursor := models.NewCursor(float64(createdAt.Add(time.Hour).UnixMicro()), 5)
+
+	type TestCase struct {
+		name                 string
+		behaviorAdminService func(m *mocks.MockIAdminService)
+		request              *http.Request
+		expectedResponse     any
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			behaviorAdminService: func(m *mocks.MockIAdminService) {
+				m.EXPECT().GetAuditLog(gomock.Any(), cursor, uint64(2)).Return(&models.AuditLog{
+					Records: []*models.AuditRecord{
+						{
+							ID: 1, AdminID: 1, Action: models.AuditActionBanUser,
+							TargetID: 2, Details: "spam", CreatedAt: createdAt,
+						},
+					},
+					NextCursor: "",
+				}, nil)
+			},
+			request: httptest.NewRequest(http.MethodGet,
+				"/api/v1/admin/audit/get_list?cursor="+cursor.String()+"&count=2", nil),
+			expectedResponse: delivery.NewAuditLogResponse(&models.AuditLog{
+				Records: []*models.AuditRecord{
+					{
+						ID: 1, AdminID: 1, Action: models.AuditActionBanUser,
+						TargetID: 2, Details: "spam", CreatedAt: createdAt,
+					},
+				},
+				NextCursor: "",
+			}),
+		},
+		{
+			name:                 "test wrong cursor",
+			behaviorAdminService: func(m *mocks.MockIAdminService) {},
+			request: httptest.NewRequest(http.MethodGet,
+				"/api/v1/admin/audit/get_list?cursor=wrong&count=2", nil),
+			expectedResponse: responses.NewErrResponse(statuses.StatusBadFormatRequest,
+				models.ErrWrongCursor.Error()),
+		},
+		{
+			name:                 "test wrong count",
+			behaviorAdminService: func(m *mocks.MockIAdminService) {},
+			request:              httptest.NewRequest(http.MethodGet, "/api/v1/admin/audit/get_list", nil),
+			expectedResponse: responses.NewErrResponse(statuses.StatusBadFormatRequest,
+				"Получили некорректный числовой параметр. Он должен быть целым count="),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAdminService := mocks.NewMockIAdminService(ctrl)
+			testCase.behaviorAdminService(mockAdminService)
+
+			adminHandler, err := delivery.NewAdminHandler(mockAdminService)
+			if err != nil {
+				t.Fatalf("Failed create adminHandler %s", err.Error())
+			}
+
+			recorder := httptest.NewRecorder()
+
+			adminHandler.GetAuditLogHandler(recorder, testCase.request)
+
+			err = test.CompareHTTPTestResult(recorder, testCase.expectedResponse)
+			if err != nil {
+				t.Fatalf("Failed CompareHTTPTestResult %+v", err)
+			}
+		})
+	}
+}
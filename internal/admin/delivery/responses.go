@@ -0,0 +1,40 @@
+package delivery
+
+import (
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses/statuses"
+)
+
+const (
+	ResponseSuccessfulBanUser       = "Пользователь успешно заблокирован"
+	ResponseSuccessfulUnbanUser     = "Пользователь успешно разблокирован"
+	ResponseSuccessfulCloseProduct  = "Объявление успешно закрыто"
+	ResponseSuccessfulDeleteComment = "Комментарий успешно удален"
+	ResponseSuccessfulSetPremium    = "Премиум объявления успешно изменен"
+)
+
+//easyjson:json
+type AdminUserListResponse struct {
+	Status int                   `json:"status"`
+	Body   *models.AdminUserList `json:"body"`
+}
+
+func NewAdminUserListResponse(body *models.AdminUserList) *AdminUserListResponse {
+	return &AdminUserListResponse{
+		Status: statuses.StatusResponseSuccessful,
+		Body:   body,
+	}
+}
+
+//easyjson:json
+type AuditLogResponse struct {
+	Status int              `json:"status"`
+	Body   *models.AuditLog `json:"body"`
+}
+
+func NewAuditLogResponse(body *models.AuditLog) *AuditLogResponse {
+	return &AuditLogResponse{
+		Status: statuses.StatusResponseSuccessful,
+		Body:   body,
+	}
+}
@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/admin/delivery/admin_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/admin/delivery/admin_handler.go -destination=internal/admin/mocks/admin_handler.go --package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	models "github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIAdminService is a mock of IAdminService interface.
+type MockIAdminService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAdminServiceMockRecorder
+}
+
+// MockIAdminServiceMockRecorder is the mock recorder for MockIAdminService.
+type MockIAdminServiceMockRecorder struct {
+	mock *MockIAdminService
+}
+
+// NewMockIAdminService creates a new mock instance.
+func NewMockIAdminService(ctrl *gomock.Controller) *MockIAdminService {
+	mock := &MockIAdminService{ctrl: ctrl}
+	mock.recorder = &MockIAdminServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAdminService) EXPECT() *MockIAdminServiceMockRecorder {
+	return m.recorder
+}
+
+// BanUser mocks base method.
+func (m *MockIAdminService) BanUser(ctx context.Context, r io.Reader, adminID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BanUser", ctx, r, adminID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BanUser indicates an expected call of BanUser.
+func (mr *MockIAdminServiceMockRecorder) BanUser(ctx, r, adminID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BanUser", reflect.TypeOf((*MockIAdminService)(nil).BanUser), ctx, r, adminID)
+}
+
+// CloseProduct mocks base method.
+func (m *MockIAdminService) CloseProduct(ctx context.Context, r io.Reader, adminID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseProduct", ctx, r, adminID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseProduct indicates an expected call of CloseProduct.
+func (mr *MockIAdminServiceMockRecorder) CloseProduct(ctx, r, adminID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseProduct", reflect.TypeOf((*MockIAdminService)(nil).CloseProduct), ctx, r, adminID)
+}
+
+// DeleteComment mocks base method.
+func (m *MockIAdminService) DeleteComment(ctx context.Context, r io.Reader, adminID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", ctx, r, adminID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockIAdminServiceMockRecorder) DeleteComment(ctx, r, adminID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockIAdminService)(nil).DeleteComment), ctx, r, adminID)
+}
+
+// GetAuditLog mocks base method.
+func (m *MockIAdminService) GetAuditLog(ctx context.Context, cursor *models.Cursor, count uint64) (*models.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditLog", ctx, cursor, count)
+	ret0, _ := ret[0].(*models.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditLog indicates an expected call of GetAuditLog.
+func (mr *MockIAdminServiceMockRecorder) GetAuditLog(ctx, cursor, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLog", reflect.TypeOf((*MockIAdminService)(nil).GetAuditLog), ctx, cursor, count)
+}
+
+// GetUsers mocks base method.
+func (m *MockIAdminService) GetUsers(ctx context.Context, cursor *models.Cursor, count uint64) (*models.AdminUserList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsers", ctx, cursor, count)
+	ret0, _ := ret[0].(*models.AdminUserList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockIAdminServiceMockRecorder) GetUsers(ctx, cursor, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockIAdminService)(nil).GetUsers), ctx, cursor, count)
+}
+
+// SetPremium mocks base method.
+func (m *MockIAdminService) SetPremium(ctx context.Context, r io.Reader, adminID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPremium", ctx, r, adminID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPremium indicates an expected call of SetPremium.
+func (mr *MockIAdminServiceMockRecorder) SetPremium(ctx, r, adminID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPremium", reflect.TypeOf((*MockIAdminService)(nil).SetPremium), ctx, r, adminID)
+}
+
+// UnbanUser mocks base method.
+func (m *MockIAdminService) UnbanUser(ctx context.Context, r io.Reader, adminID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnbanUser", ctx, r, adminID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnbanUser indicates an expected call of UnbanUser.
+func (mr *MockIAdminServiceMockRecorder) UnbanUser(ctx, r, adminID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnbanUser", reflect.TypeOf((*MockIAdminService)(nil).UnbanUser), ctx, r, adminID)
+}
@@ -0,0 +1,143 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/admin/usecases/admin_service.go
+//
+// Generated by this command:
+//
+//	mockgen --source=./internal/admin/usecases/admin_service.go --destination=./internal/admin/mocks/admin_service.go --package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIAdminStorage is a mock of IAdminStorage interface.
+type MockIAdminStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAdminStorageMockRecorder
+}
+
+// MockIAdminStorageMockRecorder is the mock recorder for MockIAdminStorage.
+type MockIAdminStorageMockRecorder struct {
+	mock *MockIAdminStorage
+}
+
+// NewMockIAdminStorage creates a new mock instance.
+func NewMockIAdminStorage(ctrl *gomock.Controller) *MockIAdminStorage {
+	mock := &MockIAdminStorage{ctrl: ctrl}
+	mock.recorder = &MockIAdminStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAdminStorage) EXPECT() *MockIAdminStorageMockRecorder {
+	return m.recorder
+}
+
+// BanUser mocks base method.
+func (m *MockIAdminStorage) BanUser(ctx context.Context, adminID, userID uint64, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BanUser", ctx, adminID, userID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BanUser indicates an expected call of BanUser.
+func (mr *MockIAdminStorageMockRecorder) BanUser(ctx, adminID, userID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BanUser", reflect.TypeOf((*MockIAdminStorage)(nil).BanUser), ctx, adminID, userID, reason)
+}
+
+// CloseProduct mocks base method.
+func (m *MockIAdminStorage) CloseProduct(ctx context.Context, adminID, productID uint64, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseProduct", ctx, adminID, productID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseProduct indicates an expected call of CloseProduct.
+func (mr *MockIAdminStorageMockRecorder) CloseProduct(ctx, adminID, productID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseProduct", reflect.TypeOf((*MockIAdminStorage)(nil).CloseProduct), ctx, adminID, productID, reason)
+}
+
+// DeleteComment mocks base method.
+func (m *MockIAdminStorage) DeleteComment(ctx context.Context, adminID, commentID uint64, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", ctx, adminID, commentID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockIAdminStorageMockRecorder) DeleteComment(ctx, adminID, commentID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockIAdminStorage)(nil).DeleteComment), ctx, adminID, commentID, reason)
+}
+
+// GetAuditLog mocks base method.
+func (m *MockIAdminStorage) GetAuditLog(ctx context.Context, cursor *models.Cursor, count uint64) ([]*models.AuditRecord, *models.Cursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditLog", ctx, cursor, count)
+	ret0, _ := ret[0].([]*models.AuditRecord)
+	ret1, _ := ret[1].(*models.Cursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAuditLog indicates an expected call of GetAuditLog.
+func (mr *MockIAdminStorageMockRecorder) GetAuditLog(ctx, cursor, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLog", reflect.TypeOf((*MockIAdminStorage)(nil).GetAuditLog), ctx, cursor, count)
+}
+
+// GetUsers mocks base method.
+func (m *MockIAdminStorage) GetUsers(ctx context.Context, cursor *models.Cursor, count uint64) ([]*models.AdminUser, *models.Cursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsers", ctx, cursor, count)
+	ret0, _ := ret[0].([]*models.AdminUser)
+	ret1, _ := ret[1].(*models.Cursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockIAdminStorageMockRecorder) GetUsers(ctx, cursor, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockIAdminStorage)(nil).GetUsers), ctx, cursor, count)
+}
+
+// SetPremium mocks base method.
+func (m *MockIAdminStorage) SetPremium(ctx context.Context, adminID, productID uint64, status uint8, premiumBegin, premiumExpire *time.Time, details string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPremium", ctx, adminID, productID, status, premiumBegin, premiumExpire, details)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPremium indicates an expected call of SetPremium.
+func (mr *MockIAdminStorageMockRecorder) SetPremium(ctx, adminID, productID, status, premiumBegin, premiumExpire, details any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPremium", reflect.TypeOf((*MockIAdminStorage)(nil).SetPremium), ctx, adminID, productID, status, premiumBegin, premiumExpire, details)
+}
+
+// UnbanUser mocks base method.
+func (m *MockIAdminStorage) UnbanUser(ctx context.Context, adminID, userID uint64, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnbanUser", ctx, adminID, userID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnbanUser indicates an expected call of UnbanUser.
+func (mr *MockIAdminStorageMockRecorder) UnbanUser(ctx, adminID, userID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnbanUser", reflect.TypeOf((*MockIAdminStorage)(nil).UnbanUser), ctx, adminID, userID, reason)
+}
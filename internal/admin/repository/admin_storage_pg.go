@@ -0,0 +1,317 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/auth"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/pgxpool"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrNoAffectedBanUser = myerrors.NewErrorBadContentRequest(
+		"Пользователь не найден, уже заблокирован или является администратором")
+	ErrNoAffectedUnbanUser     = myerrors.NewErrorBadContentRequest("Пользователь не найден или не заблокирован")
+	ErrNoAffectedCloseProduct  = myerrors.NewErrorBadContentRequest("Объявление не найдено или уже закрыто")
+	ErrNoAffectedDeleteComment = myerrors.NewErrorBadContentRequest("Комментарий не найден")
+	ErrNoAffectedSetPremium    = myerrors.NewErrorBadContentRequest("Объявление не найдено")
+)
+
+type AdminStorage struct {
+	pool   pgxpool.IPgxPool
+	logger *mylogger.MyLogger
+}
+
+func NewAdminStorage(pool pgxpool.IPgxPool) (*AdminStorage, error) {
+	logger, err := mylogger.Get()
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return &AdminStorage{
+		pool:   pool,
+		logger: logger,
+	}, nil
+}
+
+// addAuditRecord must be called in the same transaction as action itself,
+// so action isn't done without record about it.
+func (a *AdminStorage) addAuditRecord(ctx context.Context, tx pgx.Tx,
+	adminID uint64, action string, targetID uint64, details string,
+) error {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLInsertAuditRecord := `INSERT INTO public."admin_audit" (admin_id, action, target_id, details)
+		VALUES ($1, $2, $3, $4)`
+
+	_, err := tx.Exec(ctx, SQLInsertAuditRecord, adminID, action, targetID, details)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// execWithAudit executes SQL of action and adds record to audit log in one transaction.
+// errNoAffected is returned if SQL of action didn't affect any row.
+func (a *AdminStorage) execWithAudit(ctx context.Context, adminID uint64, action string,
+	targetID uint64, details string, errNoAffected error, SQLAction string, args ...any,
+) error {
+	logger := a.logger.LogReqID(ctx)
+
+	err := pgx.BeginFunc(ctx, a.pool, func(tx pgx.Tx) error {
+		result, err := tx.Exec(ctx, SQLAction, args...)
+		if err != nil {
+			logger.Errorln(err)
+
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		if result.RowsAffected() == 0 {
+			return fmt.Errorf(myerrors.ErrTemplate, errNoAffected)
+		}
+
+		return a.addAuditRecord(ctx, tx, adminID, action, targetID, details)
+	})
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+func nullTimeToPtr(nullTime sql.NullTime) *time.Time {
+	if !nullTime.Valid {
+		return nil
+	}
+
+	return &nullTime.Time
+}
+
+// nextCursor returns cursor of the last row if page is full, otherwise nil that means there is no next page.
+func nextCursor(lenPage int, limit uint64, lastScore float64, lastID uint64) *models.Cursor {
+	if lenPage == 0 || uint64(lenPage) < limit {
+		return nil
+	}
+
+	return models.NewCursor(lastScore, lastID)
+}
+
+// GetUsers returns users sorted by id from newest, cursor nil means the first page.
+func (a *AdminStorage) GetUsers(ctx context.Context, cursor *models.Cursor, count uint64,
+) ([]*models.AdminUser, *models.Cursor, error) {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLSelectUsers := `SELECT id, email, COALESCE(name, ''), role, created_at, banned_at, deleted_at
+		FROM public."user" WHERE $1::bigint IS NULL OR id < $1 ORDER BY id DESC LIMIT $2`
+
+	var afterID *uint64
+	if cursor != nil {
+		afterID = &cursor.ID
+	}
+
+	var users []*models.AdminUser
+
+	err := pgx.BeginFunc(ctx, a.pool, func(tx pgx.Tx) error {
+		usersRows, err := tx.Query(ctx, SQLSelectUsers, afterID, count)
+		if err != nil {
+			logger.Errorln(err)
+
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		curUser := new(models.AdminUser)
+
+		var bannedAt, deletedAt sql.NullTime
+
+		_, err = pgx.ForEachRow(usersRows, []any{
+			&curUser.ID, &curUser.Email, &curUser.Name, &curUser.Role,
+			&curUser.CreatedAt, &bannedAt, &deletedAt,
+		}, func() error {
+			user := *curUser
+			user.BannedAt = nullTimeToPtr(bannedAt)
+			user.DeletedAt = nullTimeToPtr(deletedAt)
+			users = append(users, &user)
+
+			return nil
+		})
+		if err != nil {
+			logger.Errorln(err)
+
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if len(users) == 0 {
+		return users, nil, nil
+	}
+
+	return users, nextCursor(len(users), count, 0, users[len(users)-1].ID), nil
+}
+
+// BanUser sets banned_at and revokes all sessions of user. Admins can't be banned.
+func (a *AdminStorage) BanUser(ctx context.Context, adminID uint64, userID uint64, reason string) error {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLBanUser := `UPDATE public."user" SET banned_at=NOW()
+		WHERE id=$1 AND banned_at IS NULL AND role<>$2`
+	SQLRevokeSessions := `UPDATE public."session" SET revoked_at=NOW()
+		WHERE user_id=$1 AND revoked_at IS NULL`
+
+	err := pgx.BeginFunc(ctx, a.pool, func(tx pgx.Tx) error {
+		result, err := tx.Exec(ctx, SQLBanUser, userID, auth.RoleAdmin)
+		if err != nil {
+			logger.Errorln(err)
+
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		if result.RowsAffected() == 0 {
+			return fmt.Errorf(myerrors.ErrTemplate, ErrNoAffectedBanUser)
+		}
+
+		_, err = tx.Exec(ctx, SQLRevokeSessions, userID)
+		if err != nil {
+			logger.Errorln(err)
+
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		return a.addAuditRecord(ctx, tx, adminID, models.AuditActionBanUser, userID, reason)
+	})
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+func (a *AdminStorage) UnbanUser(ctx context.Context, adminID uint64, userID uint64, reason string) error {
+	SQLUnbanUser := `UPDATE public."user" SET banned_at=NULL WHERE id=$1 AND banned_at IS NOT NULL`
+
+	err := a.execWithAudit(ctx, adminID, models.AuditActionUnbanUser, userID, reason,
+		ErrNoAffectedUnbanUser, SQLUnbanUser, userID)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+func (a *AdminStorage) CloseProduct(ctx context.Context, adminID uint64, productID uint64, reason string) error {
+	SQLCloseProduct := `UPDATE public."product" SET is_active=false WHERE id=$1 AND is_active=true`
+
+	err := a.execWithAudit(ctx, adminID, models.AuditActionCloseProduct, productID, reason,
+		ErrNoAffectedCloseProduct, SQLCloseProduct, productID)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+func (a *AdminStorage) DeleteComment(ctx context.Context, adminID uint64, commentID uint64, reason string) error {
+	SQLDeleteComment := `DELETE FROM public."comment" WHERE id=$1`
+
+	err := a.execWithAudit(ctx, adminID, models.AuditActionDeleteComment, commentID, reason,
+		ErrNoAffectedDeleteComment, SQLDeleteComment, commentID)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// SetPremium sets premium of product regardless of payments, nil premiumBegin and premiumExpire
+// are used with status statuses.IntStatusPremiumNot to remove premium.
+func (a *AdminStorage) SetPremium(ctx context.Context, adminID uint64, productID uint64,
+	status uint8, premiumBegin *time.Time, premiumExpire *time.Time, details string,
+) error {
+	SQLSetPremium := `UPDATE public."product" SET premium_status=$1, premium_begin=$2, premium_expire=$3
+		WHERE id=$4`
+
+	err := a.execWithAudit(ctx, adminID, models.AuditActionSetPremium, productID, details,
+		ErrNoAffectedSetPremium, SQLSetPremium, status, premiumBegin, premiumExpire, productID)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// GetAuditLog returns records sorted by (created_at, id) from newest, cursor nil means the first page.
+// Score of cursor is created_at in unix microseconds, it is exact in float64.
+func (a *AdminStorage) GetAuditLog(ctx context.Context, cursor *models.Cursor, count uint64,
+) ([]*models.AuditRecord, *models.Cursor, error) {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLSelectAuditLog := `SELECT id, admin_id, action, target_id, details, created_at
+		FROM public."admin_audit"
+		WHERE $1::timestamptz IS NULL OR (created_at, id) < ($1, $2::bigint)
+		ORDER BY created_at DESC, id DESC LIMIT $3`
+
+	var (
+		afterCreatedAt *time.Time
+		afterID        uint64
+	)
+
+	if cursor != nil {
+		createdAt := time.UnixMicro(int64(cursor.Score))
+		afterCreatedAt, afterID = &createdAt, cursor.ID
+	}
+
+	var records []*models.AuditRecord
+
+	err := pgx.BeginFunc(ctx, a.pool, func(tx pgx.Tx) error {
+		recordsRows, err := tx.Query(ctx, SQLSelectAuditLog, afterCreatedAt, afterID, count)
+		if err != nil {
+			logger.Errorln(err)
+
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		curRecord := new(models.AuditRecord)
+
+		_, err = pgx.ForEachRow(recordsRows, []any{
+			&curRecord.ID, &curRecord.AdminID, &curRecord.Action,
+			&curRecord.TargetID, &curRecord.Details, &curRecord.CreatedAt,
+		}, func() error {
+			record := *curRecord
+			records = append(records, &record)
+
+			return nil
+		})
+		if err != nil {
+			logger.Errorln(err)
+
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if len(records) == 0 {
+		return records, nil, nil
+	}
+
+	lastRecord := records[len(records)-1]
+
+	return records, nextCursor(len(records), count,
+		float64(lastRecord.CreatedAt.UnixMicro()), lastRecord.ID), nil
+}
@@ -0,0 +1,292 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/admin/repository"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/auth"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses/statuses"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+	"github.com/pashagolub/pgxmock/v3"
+)
+
+func TestBanUser(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	type TestCase struct {
+		name             string
+		behaviorMockPool func(mockPool pgxmock.PgxPoolIface)
+		expectedError    error
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+				mockPool.ExpectExec(`UPDATE public."user" SET banned_at=NOW\(\)`).
+					WithArgs(uint64(2), auth.RoleAdmin).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				mockPool.ExpectExec(`UPDATE public."session" SET revoked_at=NOW\(\)`).
+					WithArgs(uint64(2)).WillReturnResult(pgxmock.NewResult("UPDATE", 3))
+				mockPool.ExpectExec(`INSERT INTO public."admin_audit"`).
+					WithArgs(uint64(1), models.AuditActionBanUser, uint64(2), "spam").
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			expectedError: nil,
+		},
+		{
+			name: "test admin or already banned",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+				mockPool.ExpectExec(`UPDATE public."user" SET banned_at=NOW\(\)`).
+					WithArgs(uint64(2), auth.RoleAdmin).WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+				mockPool.ExpectRollback()
+				mockPool.ExpectRollback()
+			},
+			expectedError: repository.ErrNoAffectedBanUser,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			adminStorage, err := repository.NewAdminStorage(mockPool)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			testCase.behaviorMockPool(mockPool)
+
+			err = adminStorage.BanUser(context.Background(), 1, 2, "spam")
+			if errInner := utils.EqualError(err, testCase.expectedError); errInner != nil {
+				t.Fatalf("Failed EqualError: %+v", errInner)
+			}
+
+			if err := mockPool.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestActionsWithAudit(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	premiumBegin := time.Date(2024, 2, 12, 10, 0, 0, 0, time.UTC)
+	premiumExpire := premiumBegin.AddDate(0, 0, 7)
+
+	type TestCase struct {
+		name             string
+		behaviorMockPool func(mockPool pgxmock.PgxPoolIface)
+		action           func(adminStorage *repository.AdminStorage) error
+		expectedError    error
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test close product",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+				mockPool.ExpectExec(`UPDATE public."product" SET is_active=false`).
+					WithArgs(uint64(5)).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				mockPool.ExpectExec(`INSERT INTO public."admin_audit"`).
+					WithArgs(uint64(1), models.AuditActionCloseProduct, uint64(5), "fraud").
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			action: func(adminStorage *repository.AdminStorage) error {
+				return adminStorage.CloseProduct(context.Background(), 1, 5, "fraud")
+			},
+			expectedError: nil,
+		},
+		{
+			name: "test delete not existing comment",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+				mockPool.ExpectExec(`DELETE FROM public."comment" WHERE id=\$1`).
+					WithArgs(uint64(5)).WillReturnResult(pgxmock.NewResult("DELETE", 0))
+				mockPool.ExpectRollback()
+				mockPool.ExpectRollback()
+			},
+			action: func(adminStorage *repository.AdminStorage) error {
+				return adminStorage.DeleteComment(context.Background(), 1, 5, "insult")
+			},
+			expectedError: repository.ErrNoAffectedDeleteComment,
+		},
+		{
+			name: "test set premium",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+				mockPool.ExpectExec(`UPDATE public."product" SET premium_status=\$1`).
+					WithArgs(statuses.IntStatusPremiumSucceeded, &premiumBegin, &premiumExpire, uint64(5)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				mockPool.ExpectExec(`INSERT INTO public."admin_audit"`).
+					WithArgs(uint64(1), models.AuditActionSetPremium, uint64(5), "days=7: gift").
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			action: func(adminStorage *repository.AdminStorage) error {
+				return adminStorage.SetPremium(context.Background(), 1, 5,
+					statuses.IntStatusPremiumSucceeded, &premiumBegin, &premiumExpire, "days=7: gift")
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			adminStorage, err := repository.NewAdminStorage(mockPool)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			testCase.behaviorMockPool(mockPool)
+
+			err = testCase.action(adminStorage)
+			if errInner := utils.EqualError(err, testCase.expectedError); errInner != nil {
+				t.Fatalf("Failed EqualError: %+v", errInner)
+			}
+
+			if err := mockPool.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestGetUsers(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	createdAt := time.Date(2024, 2, 12, 10, 0, 0, 0, time.UTC)
+	bannedAt := createdAt.Add(time.Hour)
+	afterID := uint64(3)
+
+	mockPool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	adminStorage, err := repository.NewAdminStorage(mockPool)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	mockPool.ExpectBegin()
+	mockPool.ExpectQuery(`SELECT id, email, COALESCE\(name, ''\), role, created_at, banned_at, deleted_at`).
+		WithArgs(&afterID, uint64(2)).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "email", "name", "role", "created_at", "banned_at", "deleted_at",
+		}).
+			AddRow(uint64(2), "spammer@mail.ru", "", auth.RoleUser, createdAt,
+				sql.NullTime{Time: bannedAt, Valid: true}, sql.NullTime{}).
+			AddRow(uint64(1), "admin@mail.ru", "admin", auth.RoleAdmin, createdAt,
+				sql.NullTime{}, sql.NullTime{}))
+	mockPool.ExpectCommit()
+	mockPool.ExpectRollback()
+
+	users, next, err := adminStorage.GetUsers(context.Background(), models.NewCursor(0, afterID), 2)
+	if err != nil {
+		t.Fatalf("unexpected err=%+v", err)
+	}
+
+	expectedUsers := []*models.AdminUser{
+		{
+			ID: 2, Email: "spammer@mail.ru", Name: "", Role: auth.RoleUser, CreatedAt: createdAt,
+			BannedAt: &bannedAt,
+		},
+		{ID: 1, Email: "admin@mail.ru", Name: "admin", Role: auth.RoleAdmin, CreatedAt: createdAt},
+	}
+
+	if !reflect.DeepEqual(users, expectedUsers) {
+		t.Fatalf("got users %+v, expected %+v", users, expectedUsers)
+	}
+
+	if !reflect.DeepEqual(next, models.NewCursor(0, 1)) {
+		t.Fatalf("got next cursor %+v", next)
+	}
+
+	if err := mockPool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetAuditLog(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	createdAt := time.Date(2024, 2, 12, 10, 0, 0, 123456000, time.UTC)
+	afterCreatedAt := createdAt.Add(time.Second)
+
+	mockPool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	adminStorage, err := repository.NewAdminStorage(mockPool)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	mockPool.ExpectBegin()
+	mockPool.ExpectQuery(`SELECT id, admin_id, action, target_id, details, created_at`).
+		WithArgs(pgxmock.AnyArg(), uint64(7), uint64(1)).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "admin_id", "action", "target_id", "details", "created_at",
+		}).AddRow(uint64(6), uint64(1), models.AuditActionBanUser, uint64(2), "spam", createdAt))
+	mockPool.ExpectCommit()
+	mockPool.ExpectRollback()
+
+	records, next, err := adminStorage.GetAuditLog(context.Background(),
+		models.NewCursor(float64(afterCreatedAt.UnixMicro()), 7), 1)
+	if err != nil {
+		t.Fatalf("unexpected err=%+v", err)
+	}
+
+	if len(records) != 1 || records[0].ID != 6 {
+		t.Fatalf("got records %+v", records)
+	}
+
+	// the next page starts strictly after created_at of the last record with microseconds
+	nextCreatedAt := time.UnixMicro(int64(next.Score))
+	if !nextCreatedAt.Equal(createdAt) || next.ID != 6 {
+		t.Fatalf("got next cursor %+v", next)
+	}
+
+	if err := mockPool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
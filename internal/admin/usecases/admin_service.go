@@ -0,0 +1,208 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/admin/repository"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses/statuses"
+)
+
+const MaxCountList = 100
+
+var (
+	ErrBanYourself  = myerrors.NewErrorBadContentRequest("Нельзя заблокировать самого себя")
+	ErrTooBigCount  = myerrors.NewErrorBadContentRequest("Количество не может быть больше %d", MaxCountList)
+	ErrZeroCountGet = myerrors.NewErrorBadContentRequest("Количество должно быть больше нуля")
+)
+
+var _ IAdminStorage = (*repository.AdminStorage)(nil)
+
+type IAdminStorage interface {
+	GetUsers(ctx context.Context, cursor *models.Cursor, count uint64) ([]*models.AdminUser, *models.Cursor, error)
+	BanUser(ctx context.Context, adminID uint64, userID uint64, reason string) error
+	UnbanUser(ctx context.Context, adminID uint64, userID uint64, reason string) error
+	CloseProduct(ctx context.Context, adminID uint64, productID uint64, reason string) error
+	DeleteComment(ctx context.Context, adminID uint64, commentID uint64, reason string) error
+	SetPremium(ctx context.Context, adminID uint64, productID uint64,
+		status uint8, premiumBegin *time.Time, premiumExpire *time.Time, details string) error
+	GetAuditLog(ctx context.Context, cursor *models.Cursor, count uint64) ([]*models.AuditRecord, *models.Cursor, error)
+}
+
+type AdminService struct {
+	storage IAdminStorage
+	logger  *mylogger.MyLogger
+}
+
+func NewAdminService(adminStorage IAdminStorage) (*AdminService, error) {
+	logger, err := mylogger.Get()
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return &AdminService{storage: adminStorage, logger: logger}, nil
+}
+
+func validateCount(count uint64) error {
+	if count == 0 {
+		return ErrZeroCountGet
+	}
+
+	if count > MaxCountList {
+		return ErrTooBigCount
+	}
+
+	return nil
+}
+
+func (a *AdminService) GetUsers(ctx context.Context, cursor *models.Cursor, count uint64,
+) (*models.AdminUserList, error) {
+	if err := validateCount(count); err != nil {
+		return nil, err
+	}
+
+	users, next, err := a.storage.GetUsers(ctx, cursor, count)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	for _, user := range users {
+		user.Sanitize()
+	}
+
+	return &models.AdminUserList{Users: users, NextCursor: next.String()}, nil
+}
+
+func (a *AdminService) BanUser(ctx context.Context, r io.Reader, adminID uint64) error {
+	logger := a.logger.LogReqID(ctx)
+
+	adminAction, err := ValidateAdminAction(r)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if adminAction.TargetID == adminID {
+		return ErrBanYourself
+	}
+
+	err = a.storage.BanUser(ctx, adminID, adminAction.TargetID, adminAction.Reason)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	logger.Infof("admin id=%d banned user id=%d", adminID, adminAction.TargetID)
+
+	return nil
+}
+
+func (a *AdminService) UnbanUser(ctx context.Context, r io.Reader, adminID uint64) error {
+	logger := a.logger.LogReqID(ctx)
+
+	adminAction, err := ValidateAdminAction(r)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	err = a.storage.UnbanUser(ctx, adminID, adminAction.TargetID, adminAction.Reason)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	logger.Infof("admin id=%d unbanned user id=%d", adminID, adminAction.TargetID)
+
+	return nil
+}
+
+func (a *AdminService) CloseProduct(ctx context.Context, r io.Reader, adminID uint64) error {
+	logger := a.logger.LogReqID(ctx)
+
+	adminAction, err := ValidateAdminAction(r)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	err = a.storage.CloseProduct(ctx, adminID, adminAction.TargetID, adminAction.Reason)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	logger.Infof("moderator id=%d closed product id=%d", adminID, adminAction.TargetID)
+
+	return nil
+}
+
+func (a *AdminService) DeleteComment(ctx context.Context, r io.Reader, adminID uint64) error {
+	logger := a.logger.LogReqID(ctx)
+
+	adminAction, err := ValidateAdminAction(r)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	err = a.storage.DeleteComment(ctx, adminID, adminAction.TargetID, adminAction.Reason)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	logger.Infof("moderator id=%d deleted comment id=%d", adminID, adminAction.TargetID)
+
+	return nil
+}
+
+// SetPremium gives premium to product for adminPremium.Days since now,
+// zero days removes premium. Count of days is written to audit log with reason.
+func (a *AdminService) SetPremium(ctx context.Context, r io.Reader, adminID uint64) error {
+	logger := a.logger.LogReqID(ctx)
+
+	adminPremium, err := ValidateAdminPremium(r)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	status := statuses.IntStatusPremiumNot
+
+	var premiumBegin, premiumExpire *time.Time
+
+	if adminPremium.Days != 0 {
+		status = statuses.IntStatusPremiumSucceeded
+		begin := time.Now()
+		expire := begin.AddDate(0, 0, int(adminPremium.Days))
+		premiumBegin, premiumExpire = &begin, &expire
+	}
+
+	details := fmt.Sprintf("days=%d: %s", adminPremium.Days, adminPremium.Reason)
+
+	err = a.storage.SetPremium(ctx, adminID, adminPremium.ProductID,
+		status, premiumBegin, premiumExpire, details)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	logger.Infof("admin id=%d set premium of product id=%d for %d days",
+		adminID, adminPremium.ProductID, adminPremium.Days)
+
+	return nil
+}
+
+func (a *AdminService) GetAuditLog(ctx context.Context, cursor *models.Cursor, count uint64,
+) (*models.AuditLog, error) {
+	if err := validateCount(count); err != nil {
+		return nil, err
+	}
+
+	records, next, err := a.storage.GetAuditLog(ctx, cursor, count)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	for _, record := range records {
+		record.Sanitize()
+	}
+
+	return &models.AuditLog{Records: records, NextCursor: next.String()}, nil
+}
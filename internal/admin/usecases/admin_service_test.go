@@ -0,0 +1,164 @@
+package usecases_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/admin/mocks"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/admin/usecases"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses/statuses"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBanUser(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	baseCtx := context.Background()
+
+	type TestCase struct {
+		name                 string
+		behaviorAdminStorage func(m *mocks.MockIAdminStorage)
+		inputReader          io.Reader
+		expectedError        error
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			behaviorAdminStorage: func(m *mocks.MockIAdminStorage) {
+				m.EXPECT().BanUser(baseCtx, uint64(1), uint64(2), "spam").Return(nil)
+			},
+			inputReader:   strings.NewReader(`{"target_id":2, "reason":"  spam "}`),
+			expectedError: nil,
+		},
+		{
+			name:                 "test ban yourself",
+			behaviorAdminStorage: func(m *mocks.MockIAdminStorage) {},
+			inputReader:          strings.NewReader(`{"target_id":1, "reason":"spam"}`),
+			expectedError:        usecases.ErrBanYourself,
+		},
+		{
+			name:                 "test wrong json",
+			behaviorAdminStorage: func(m *mocks.MockIAdminStorage) {},
+			inputReader:          strings.NewReader(`{"target_id":"2"}`),
+			expectedError:        usecases.ErrDecodeAdminAction,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAdminStorage := mocks.NewMockIAdminStorage(ctrl)
+			testCase.behaviorAdminStorage(mockAdminStorage)
+
+			adminService, err := usecases.NewAdminService(mockAdminStorage)
+			if err != nil {
+				t.Fatalf("unexpected err=%+v", err)
+			}
+
+			err = adminService.BanUser(baseCtx, testCase.inputReader, 1)
+			if errInner := utils.EqualError(err, testCase.expectedError); errInner != nil {
+				t.Fatalf("Failed EqualError: %+v", errInner)
+			}
+		})
+	}
+}
+
+func TestSetPremium(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	baseCtx := context.Background()
+
+	type TestCase struct {
+		name                 string
+		behaviorAdminStorage func(m *mocks.MockIAdminStorage)
+		inputReader          io.Reader
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test give premium",
+			behaviorAdminStorage: func(m *mocks.MockIAdminStorage) {
+				m.EXPECT().SetPremium(baseCtx, uint64(1), uint64(5), statuses.IntStatusPremiumSucceeded,
+					gomock.Any(), gomock.Any(), "days=7: gift").DoAndReturn(
+					func(_ context.Context, _ uint64, _ uint64, _ uint8,
+						premiumBegin *time.Time, premiumExpire *time.Time, _ string,
+					) error {
+						if premiumBegin == nil || premiumExpire == nil ||
+							!premiumExpire.Equal(premiumBegin.AddDate(0, 0, 7)) {
+							t.Errorf("wrong premium period %v - %v", premiumBegin, premiumExpire)
+						}
+
+						return nil
+					})
+			},
+			inputReader: strings.NewReader(`{"product_id":5, "days":7, "reason":"gift"}`),
+		},
+		{
+			name: "test remove premium",
+			behaviorAdminStorage: func(m *mocks.MockIAdminStorage) {
+				m.EXPECT().SetPremium(baseCtx, uint64(1), uint64(5), statuses.IntStatusPremiumNot,
+					nil, nil, "days=0: complaint").Return(nil)
+			},
+			inputReader: strings.NewReader(`{"product_id":5, "days":0, "reason":"complaint"}`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAdminStorage := mocks.NewMockIAdminStorage(ctrl)
+			testCase.behaviorAdminStorage(mockAdminStorage)
+
+			adminService, err := usecases.NewAdminService(mockAdminStorage)
+			if err != nil {
+				t.Fatalf("unexpected err=%+v", err)
+			}
+
+			err = adminService.SetPremium(baseCtx, testCase.inputReader, 1)
+			if err != nil {
+				t.Fatalf("unexpected err=%+v", err)
+			}
+		})
+	}
+}
+
+func TestGetAuditLogCount(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	adminService, err := usecases.NewAdminService(mocks.NewMockIAdminStorage(ctrl))
+	if err != nil {
+		t.Fatalf("unexpected err=%+v", err)
+	}
+
+	_, err = adminService.GetAuditLog(context.Background(), nil, usecases.MaxCountList+1)
+	if errInner := utils.EqualError(err, usecases.ErrTooBigCount); errInner != nil {
+		t.Fatalf("Failed EqualError: %+v", errInner)
+	}
+}
@@ -0,0 +1,115 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+)
+
+var (
+	ErrDecodeAdminAction    = myerrors.NewErrorBadFormatRequest("Некорректный json действия")
+	ErrDecodeAdminPremium   = myerrors.NewErrorBadFormatRequest("Некорректный json премиума")
+	ErrValidateAdminAction  = myerrors.NewErrorBadContentRequest("Ошибка валидации действия: ")
+	ErrValidateAdminPremium = myerrors.NewErrorBadContentRequest("Ошибка валидации премиума: ")
+)
+
+func validateAdminAction(r io.Reader) (*models.AdminAction, error) {
+	logger, err := mylogger.Get()
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	adminAction := new(models.AdminAction)
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, ErrDecodeAdminAction)
+	}
+
+	if err := adminAction.UnmarshalJSON(data); err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, ErrDecodeAdminAction)
+	}
+
+	adminAction.Trim()
+
+	_, err = govalidator.ValidateStruct(adminAction)
+	if err != nil {
+		logger.Errorln(err)
+
+		// In this place  return non wrapped error because later it should be use in govalidator.ErrorsByField(err)
+		return adminAction, err //nolint:wrapcheck
+	}
+
+	return adminAction, nil
+}
+
+func ValidateAdminAction(r io.Reader) (*models.AdminAction, error) {
+	adminAction, err := validateAdminAction(r)
+	if err != nil {
+		myErr := &myerrors.Error{}
+		if errors.As(err, &myErr) {
+			return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		return nil, fmt.Errorf("%w %w", ErrValidateAdminAction, err)
+	}
+
+	return adminAction, nil
+}
+
+func validateAdminPremium(r io.Reader) (*models.AdminPremium, error) {
+	logger, err := mylogger.Get()
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	adminPremium := new(models.AdminPremium)
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, ErrDecodeAdminPremium)
+	}
+
+	if err := adminPremium.UnmarshalJSON(data); err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, ErrDecodeAdminPremium)
+	}
+
+	adminPremium.Trim()
+
+	_, err = govalidator.ValidateStruct(adminPremium)
+	if err != nil {
+		logger.Errorln(err)
+
+		// In this place  return non wrapped error because later it should be use in govalidator.ErrorsByField(err)
+		return adminPremium, err //nolint:wrapcheck
+	}
+
+	return adminPremium, nil
+}
+
+func ValidateAdminPremium(r io.Reader) (*models.AdminPremium, error) {
+	adminPremium, err := validateAdminPremium(r)
+	if err != nil {
+		myErr := &myerrors.Error{}
+		if errors.As(err, &myErr) {
+			return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		return nil, fmt.Errorf("%w %w", ErrValidateAdminPremium, err)
+	}
+
+	return adminPremium, nil
+}
@@ -1,32 +1,45 @@
 package config
 
 import (
+	"time"
+
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/config"
 )
 
 const (
-	EnvPremiumShopID     = "PREMIUM_SHOP_ID"
-	EnvPremiumShopSecret = "PREMIUM_SHOP_SECRET" //nolint:gosec
+	EnvPremiumShopID           = "PREMIUM_SHOP_ID"
+	EnvPremiumShopSecret       = "PREMIUM_SHOP_SECRET" //nolint:gosec
+	EnvOrderReservationTimeout = "ORDER_RESERVATION_TIMEOUT"
+	EnvPaymentsURL             = "YOOKASSA_PAYMENTS_URL"
+	EnvNotificationIPs         = "YOOKASSA_NOTIFICATION_IPS"
 
-	StandardPremiumShopID     = "297668"
-	StandardPremiumShopSecret = "test_qlRvNM1Btl6h3upjYaWEJSxfzjqyI6CdsrbcPsFS_3M" //nolint:gosec
+	StandardPremiumShopID           = "297668"
+	StandardPremiumShopSecret       = "test_qlRvNM1Btl6h3upjYaWEJSxfzjqyI6CdsrbcPsFS_3M" //nolint:gosec
+	StandardOrderReservationTimeout = 30 * time.Minute
+	StandardPaymentsURL             = "https://api.yookassa.ru/v3/payments"
+	// StandardNotificationIPs is list of ips from which yookassa sends notifications
+	StandardNotificationIPs = "185.71.76.0/27,185.71.77.0/27,77.75.153.0/25,77.75.156.11," +
+		"77.75.156.35,77.75.154.128/25,2a02:5180::/32"
 )
 
 type Config struct {
-	ProductionMode         bool
-	MainServiceName        string
-	AllowOrigin            string
-	Schema                 string
-	PortServer             string
-	URLDataBase            string
-	AddressFileServiceGrpc string
-	AddressAuthServiceGrpc string
-	PremiumShopID          string
-	PremiumShopSecret      string
-	PathCertFile           string
-	PathKeyFile            string
-	OutputLogPath          string
-	ErrorOutputLogPath     string
+	ProductionMode          bool
+	MainServiceName         string
+	AllowOrigin             string
+	Schema                  string
+	PortServer              string
+	URLDataBase             string
+	AddressFileServiceGrpc  string
+	AddressAuthServiceGrpc  string
+	PremiumShopID           string
+	PremiumShopSecret       string
+	PaymentsURL             string
+	NotificationIPs         string
+	OrderReservationTimeout time.Duration
+	PathCertFile            string
+	PathKeyFile             string
+	OutputLogPath           string
+	ErrorOutputLogPath      string
 }
 
 func New() *Config {
@@ -48,7 +61,11 @@ func New() *Config {
 		AddressAuthServiceGrpc: config.GetEnvStr(config.EnvAddressAuthServiceGrpc, config.StandardAddressAuthGrpc),
 		PremiumShopID:          config.GetEnvStr(EnvPremiumShopID, StandardPremiumShopID),
 		PremiumShopSecret:      config.GetEnvStr(EnvPremiumShopSecret, StandardPremiumShopSecret),
-		OutputLogPath:          config.GetEnvStr(config.EnvOutputLogPath, config.StandardOutputLogPath),
-		ErrorOutputLogPath:     config.GetEnvStr(config.EnvErrorOutputLogPath, config.StandardErrorOutputLogPath),
+		PaymentsURL:            config.GetEnvStr(EnvPaymentsURL, StandardPaymentsURL),
+		NotificationIPs:        config.GetEnvStr(EnvNotificationIPs, StandardNotificationIPs),
+		OrderReservationTimeout: config.GetEnvDuration(EnvOrderReservationTimeout,
+			StandardOrderReservationTimeout),
+		OutputLogPath:      config.GetEnvStr(config.EnvOutputLogPath, config.StandardOutputLogPath),
+		ErrorOutputLogPath: config.GetEnvStr(config.EnvErrorOutputLogPath, config.StandardErrorOutputLogPath),
 	}
 }
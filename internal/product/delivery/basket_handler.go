@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"time"
 
 	productusecases "github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/usecases"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/server/delivery"
@@ -12,6 +13,8 @@ import (
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
 )
 
+const periodCancelExpiredReservations = time.Minute
+
 var _ IBasketService = (*productusecases.BasketService)(nil)
 
 type IBasketService interface {
@@ -21,8 +24,50 @@ type IBasketService interface {
 	GetOrdersSoldByUserID(ctx context.Context, userID uint64) ([]*models.OrderNotInBasket, error)
 	UpdateOrderCount(ctx context.Context, r io.Reader, userID uint64) error
 	UpdateOrderStatus(ctx context.Context, r io.Reader, userID uint64) error
-	BuyFullBasket(ctx context.Context, userID uint64) error
+	GetOrderStatusHistory(ctx context.Context, orderID uint64, userID uint64) ([]*models.OrderStatusHistory, error)
+	BuyFullBasket(ctx context.Context, userID uint64) (*models.BasketPayment, error)
+	SetPaymentYookassaID(ctx context.Context, paymentID uint64, yookassaID string) error
+	ConfirmBasketPayment(ctx context.Context, paymentID uint64, status uint8, event *models.PaymentEvent) error
+	CancelBasketPayment(ctx context.Context, paymentID uint64, status uint8, event *models.PaymentEvent) error
+	UpdatePaymentStatus(ctx context.Context, paymentID uint64, status uint8, event *models.PaymentEvent) error
+	GetHeldPayments(ctx context.Context) ([]*models.HeldPayment, error)
 	DeleteOrder(ctx context.Context, orderID uint64, ownerID uint64) error
+	CancelExpiredReservations(ctx context.Context) (uint64, error)
+}
+
+// waitOrderReservations periodically cancels not paid orders with expired reservation,
+// so reserved products return to stock.
+func (p *ProductHandler) waitOrderReservations(ctx context.Context,
+	chClose <-chan struct{}, periodCheck time.Duration,
+) {
+	logger := p.logger.LogReqID(ctx)
+
+	go func() {
+		ticker := time.NewTicker(periodCheck)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-chClose:
+				logger.Infof("успешно отключили проверку резервов заказов")
+
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				countCancelled, err := p.service.CancelExpiredReservations(ctx)
+				if err != nil {
+					logger.Errorf("error cancel expired reservations: %+v", err)
+
+					continue
+				}
+
+				if countCancelled != 0 {
+					logger.Infof("cancelled orders with expired reservation: %d", countCancelled)
+				}
+			}
+		}
+	}()
 }
 
 // AddOrderHandler godoc
@@ -188,6 +233,55 @@ func (p *ProductHandler) GetOrdersSoldHandler(w http.ResponseWriter, r *http.Req
 	logger.Infof("in GetOrdersSoldHandler: get orders: %+v\n", orders)
 }
 
+// GetOrderStatusHistoryHandler godoc
+//
+//	@Summary    get history of order status
+//	@Description  get transitions of order status from the oldest with actor and time.
+//	@Description  Available for buyer and seller of order with user id from cookie\jwt token
+//	@Tags order
+//	@Accept     json
+//	@Produce    json
+//	@Param      order_id  query uint64 true  "order id"
+//	@Success    200  {object} OrderStatusHistoryResponse
+//	@Failure    405  {string} string
+//	@Failure    500  {string} string
+//	@Failure    222  {object} responses.ErrorResponse "Error". Внутри body статус может быть badContent(4400), badFormat(4000)
+//	@Router      /order/history [get]
+func (p *ProductHandler) GetOrderStatusHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, `Method not allowed`, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	ctx := r.Context()
+	logger := p.logger.LogReqID(ctx)
+
+	userID, err := delivery.GetUserID(ctx, r, p.sessionManagerClient)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	orderID, err := utils.ParseUint64FromRequest(r, "order_id")
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	history, err := p.service.GetOrderStatusHistory(ctx, orderID, userID)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	responses.SendResponse(w, logger, NewOrderStatusHistoryResponse(history))
+	logger.Infof("in GetOrderStatusHistoryHandler: get history of order id=%d: %+v\n", orderID, history)
+}
+
 // UpdateOrderCountHandler godoc
 //
 //	@Summary    update order count
@@ -235,7 +329,8 @@ func (p *ProductHandler) UpdateOrderCountHandler(w http.ResponseWriter, r *http.
 // UpdateOrderStatusHandler godoc
 //
 //	@Summary    update order status
-//	@Description  update order status using user id from cookie\jwt token
+//	@Description  update order status using user id from cookie\jwt token.
+//	@Description  Buyer may move order: 0->1, 1->2, 1->6, 4->5, 5->3. Seller may move order: 1->6, 2->4, 2->7, 5->7
 //	@Tags order
 //	@Accept      json
 //	@Produce    json
@@ -279,11 +374,15 @@ func (p *ProductHandler) UpdateOrderStatusHandler(w http.ResponseWriter, r *http
 // BuyFullBasketHandler godoc
 //
 //	@Summary    buy all orders from basket
-//	@Description   buy all orders from basket
+//	@Description   buy all orders from basket. Products are reserved for orders until payment or reservation timeout.
+//	@Description   If some products are not enough, nothing is bought and error lists all of them.
+//	@Description   Creates one payment in yoomany for all orders and returns url for redirect to it.
+//	@Description   Orders become paid after successful payment, cancelled payment returns orders to basket.
+//	@Description   If basket contains products with safe deal, money are held until orders are delivered
 //	@Tags order
 //	@Accept      json
 //	@Produce    json
-//	@Success    200  {object} responses.ResponseSuccessful
+//	@Success    200  {object} responses.ResponseRedirect
 //	@Failure    405  {string} string
 //	@Failure    500  {string} string
 //	@Failure    222  {object} responses.ErrorResponse "Error". Внутри body статус может быть badContent(4400)
@@ -305,15 +404,14 @@ func (p *ProductHandler) BuyFullBasketHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	err = p.service.BuyFullBasket(ctx, userID)
+	redirectURL, err := p.createBasketPayment(ctx, userID)
 	if err != nil {
 		responses.HandleErr(w, r, logger, err)
 
 		return
 	}
 
-	responses.SendResponse(w, logger,
-		responses.NewResponseSuccessful(ResponseSuccessfulBuyFullBasket))
+	responses.SendResponse(w, logger, responses.NewResponseRedirect(redirectURL))
 	logger.Infof("in BuyFullBasketHandler: buy full basket for userID=%d\n", userID)
 }
 
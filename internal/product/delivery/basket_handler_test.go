@@ -2,14 +2,17 @@ package delivery_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/delivery"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/mocks"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/usecases"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
@@ -570,13 +573,14 @@ func TestUpdateOrderStatusBasket(t *testing.T) {
 	}
 }
 
-func TestBuyFullBasket(t *testing.T) {
+func TestDeleteOrderBasket(t *testing.T) {
 	t.Parallel()
 
 	_ = mylogger.NewNop()
 
 	type TestCase struct {
 		name                   string
+		queryID                string
 		behaviorProductService func(m *mocks.MockIProductService)
 		request                *http.Request
 		expectedResponse       any
@@ -585,15 +589,19 @@ func TestBuyFullBasket(t *testing.T) {
 	testCases := [...]TestCase{
 		{
 			name:    "test basic work",
-			request: httptest.NewRequest(http.MethodPatch, "/api/v1/order/buy_full_basket", nil),
+			queryID: "1",
+			request: httptest.NewRequest(http.MethodDelete, "/api/v1/order/delete", nil),
 			behaviorProductService: func(m *mocks.MockIProductService) {
-				m.EXPECT().BuyFullBasket(gomock.Any(), test.UserID).Return(nil)
+				m.EXPECT().DeleteOrder(gomock.Any(), uint64(1), test.UserID).Return(nil)
+			},
+			expectedResponse: responses.ResponseSuccessful{
+				Status: statuses.StatusResponseSuccessful,
+				Body:   responses.ResponseBody{Message: delivery.ResponseSuccessfulDeleteProduct},
 			},
-			expectedResponse: responses.NewResponseSuccessful(delivery.ResponseSuccessfulBuyFullBasket),
 		},
 	}
 
-	for _, testCase := range testCases { //nolint:dupl
+	for _, testCase := range testCases {
 		testCase := testCase
 
 		t.Run(testCase.name, func(t *testing.T) {
@@ -607,10 +615,12 @@ func TestBuyFullBasket(t *testing.T) {
 				t.Fatalf("UnExpected err=%+v\n", err)
 			}
 
+			utils.AddQueryParamsToRequest(testCase.request, map[string]string{"id": testCase.queryID})
+
 			w := httptest.NewRecorder()
 
 			testCase.request.AddCookie(&test.Cookie)
-			productHandler.BuyFullBasketHandler(w, testCase.request)
+			productHandler.DeleteOrderHandler(w, testCase.request)
 
 			resp := w.Result()
 			defer resp.Body.Close()
@@ -633,31 +643,61 @@ func TestBuyFullBasket(t *testing.T) {
 	}
 }
 
-func TestDeleteOrderBasket(t *testing.T) {
+func TestGetOrderStatusHistory(t *testing.T) {
 	t.Parallel()
 
 	_ = mylogger.NewNop()
 
+	testTime := time.Date(2024, 1, 18, 10, 0, 0, 0, time.UTC)
+
 	type TestCase struct {
 		name                   string
-		queryID                string
+		queryOrderID           string
 		behaviorProductService func(m *mocks.MockIProductService)
-		request                *http.Request
 		expectedResponse       any
 	}
 
 	testCases := [...]TestCase{
 		{
-			name:    "test basic work",
-			queryID: "1",
-			request: httptest.NewRequest(http.MethodDelete, "/api/v1/order/delete", nil),
+			name:         "test basic work",
+			queryOrderID: "1",
 			behaviorProductService: func(m *mocks.MockIProductService) {
-				m.EXPECT().DeleteOrder(gomock.Any(), uint64(1), test.UserID).Return(nil)
+				m.EXPECT().GetOrderStatusHistory(gomock.Any(), uint64(1), test.UserID).
+					Return([]*models.OrderStatusHistory{
+						{
+							ID: 1, OrderID: 1, ActorID: test.UserID, ActorRole: models.OrderActorBuyer,
+							FromStatus: models.OrderStatusInBasket, ToStatus: models.OrderStatusInProcessing,
+							CreatedAt: testTime,
+						},
+					}, nil)
 			},
-			expectedResponse: responses.ResponseSuccessful{
+			expectedResponse: delivery.OrderStatusHistoryResponse{
 				Status: statuses.StatusResponseSuccessful,
-				Body:   responses.ResponseBody{Message: delivery.ResponseSuccessfulDeleteProduct},
+				Body: []*models.OrderStatusHistory{
+					{
+						ID: 1, OrderID: 1, ActorID: test.UserID, ActorRole: models.OrderActorBuyer,
+						FromStatus: models.OrderStatusInBasket, ToStatus: models.OrderStatusInProcessing,
+						CreatedAt: testTime,
+					},
+				},
+			},
+		},
+		{
+			name:                   "test bad order id",
+			queryOrderID:           "bad",
+			behaviorProductService: func(m *mocks.MockIProductService) {},
+			expectedResponse: responses.NewErrResponse(statuses.StatusBadFormatRequest,
+				fmt.Sprintf("%s order_id=bad", utils.MessageErrWrongNumberParam)),
+		},
+		{
+			name:         "test not participant",
+			queryOrderID: "1",
+			behaviorProductService: func(m *mocks.MockIProductService) {
+				m.EXPECT().GetOrderStatusHistory(gomock.Any(), uint64(1), test.UserID).
+					Return(nil, usecases.ErrNotOrderParticipant)
 			},
+			expectedResponse: responses.NewErrResponse(statuses.StatusBadContentRequest,
+				usecases.ErrNotOrderParticipant.Error()),
 		},
 	}
 
@@ -672,32 +712,19 @@ func TestDeleteOrderBasket(t *testing.T) {
 
 			productHandler, err := NewProductHandler(ctrl, testCase.behaviorProductService)
 			if err != nil {
-				t.Fatalf("UnExpected err=%+v\n", err)
+				t.Fatalf("Failed create productHandler %+v", err)
 			}
 
-			utils.AddQueryParamsToRequest(testCase.request, map[string]string{"id": testCase.queryID})
+			recorder := httptest.NewRecorder()
 
-			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/order/history", nil)
+			utils.AddQueryParamsToRequest(req, map[string]string{"order_id": testCase.queryOrderID})
+			req.AddCookie(&test.Cookie)
+			productHandler.GetOrderStatusHistoryHandler(recorder, req)
 
-			testCase.request.AddCookie(&test.Cookie)
-			productHandler.DeleteOrderHandler(w, testCase.request)
-
-			resp := w.Result()
-			defer resp.Body.Close()
-
-			receivedResponse, err := io.ReadAll(resp.Body)
-			if err != nil {
-				t.Fatalf("Failed to ReadAll resp.Body: %v", err)
-			}
-
-			expectedResponseRaw, err := json.Marshal(testCase.expectedResponse)
+			err = test.CompareHTTPTestResult(recorder, testCase.expectedResponse)
 			if err != nil {
-				t.Fatalf("Failed to json.Marshal testCase.expectedResponse: %v", err)
-			}
-
-			err = utils.EqualTest(receivedResponse, expectedResponseRaw)
-			if err != nil {
-				t.Fatal(err)
+				t.Fatalf("Failed CompareHTTPTestResult %+v", err)
 			}
 		})
 	}
@@ -0,0 +1,230 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	productrepo "github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/repository"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses/statuses"
+)
+
+const (
+	pathRedirectURLBasket    = "/profile/orders"
+	descriptionBasketPayment = "Оплата заказов из корзины №%d"
+
+	suffixCaptureKeyIdempotency = "-capture"
+	suffixCancelKeyIdempotency  = "-cancel"
+	suffixRefundKeyIdempotency  = "-refund"
+
+	pathPaymentsAPIYoomany = "payments"
+	pathRefundsAPIYoomany  = "refunds"
+)
+
+func NewMetadataBasketPayment(userID uint64, paymentID uint64) *MetadataPayment {
+	return &MetadataPayment{UserID: userID, ProductID: 0, PeriodCode: 0, PaymentID: paymentID}
+}
+
+// NewBasketPayment payment with safe deal isn't captured automatically,
+// money are held until orders are delivered.
+func NewBasketPayment(frontendURL string, basketPayment *models.BasketPayment) *Payment {
+	return &Payment{
+		Amount:       NewAmountPayment(fmt.Sprintf("%d.00", basketPayment.Amount)),
+		Capture:      basketPayment.Capture,
+		Confirmation: NewConfirmationReturnPayment("https://" + frontendURL + pathRedirectURLBasket),
+		Description:  fmt.Sprintf(descriptionBasketPayment, basketPayment.ID),
+		Metadata:     NewMetadataBasketPayment(basketPayment.OwnerID, basketPayment.ID),
+	}
+}
+
+//easyjson:json
+type CapturePayment struct {
+	Amount AmountPayment `json:"amount"`
+}
+
+//easyjson:json
+type RefundPayment struct {
+	Amount    AmountPayment `json:"amount"`
+	PaymentID string        `json:"payment_id"`
+}
+
+// createBasketPayment reserves products of basket and creates payment in yoomany for them.
+// If yoomany didn't create payment, orders return to basket.
+func (p *ProductHandler) createBasketPayment(ctx context.Context, userID uint64) (string, error) {
+	logger := p.logger.LogReqID(ctx)
+
+	basketPayment, err := p.service.BuyFullBasket(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	responsePayment, err := p.postPaymentAPIYoomany(ctx,
+		NewBasketPayment(p.frontendPaymentURL, basketPayment), basketPayment.IdempotencyKey)
+	if err != nil {
+		errCancel := p.service.CancelBasketPayment(ctx, basketPayment.ID, statuses.IntStatusPaymentCanceled, nil)
+		if errCancel != nil {
+			logger.Errorf("error cancel basket payment id=%d: %+v", basketPayment.ID, errCancel)
+		}
+
+		return "", err
+	}
+
+	// payment is still found by metadata, so error here doesn't break checkout
+	err = p.service.SetPaymentYookassaID(ctx, basketPayment.ID, responsePayment.ID)
+	if err != nil {
+		logger.Errorf("error set yookassa id=%s for payment id=%d: %+v",
+			responsePayment.ID, basketPayment.ID, err)
+	}
+
+	logger.Infof("created basket payment id=%d yookassa id=%s amount=%d capture=%t",
+		basketPayment.ID, responsePayment.ID, basketPayment.Amount, basketPayment.Capture)
+
+	return responsePayment.Confirmation.ConfirmationURL, nil
+}
+
+func (p *ProductHandler) handleBasketPayment(ctx context.Context,
+	item ResponseGetPaymentsItemAPIYoomany, event *models.PaymentEvent,
+) error {
+	var err error
+
+	switch {
+	case statuses.IsStatusPaymentSuccessful(item.Status):
+		err = p.service.ConfirmBasketPayment(ctx,
+			item.Metadata.PaymentID, statuses.ConvertToIntStatus(item.Status), event)
+		if errors.Is(err, productrepo.ErrPaymentWithoutOrders) {
+			err = p.returnBasketPayment(ctx, item, event)
+		}
+	case item.Status == statuses.StatusPaymentCanceled:
+		err = p.service.CancelBasketPayment(ctx,
+			item.Metadata.PaymentID, statuses.ConvertToIntStatus(item.Status), event)
+	case item.Status == statuses.StatusPaymentPending:
+		return nil
+	default:
+		return fmt.Errorf(myerrors.ErrTemplate, ErrResponseWrongStatusAPIYoomany)
+	}
+
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// returnBasketPayment returns money of payment which orders were cancelled before it succeeded:
+// held money are released, captured money are refunded. Payment is saved as canceled together with event.
+func (p *ProductHandler) returnBasketPayment(ctx context.Context,
+	item ResponseGetPaymentsItemAPIYoomany, event *models.PaymentEvent,
+) error {
+	logger := p.logger.LogReqID(ctx)
+
+	var (
+		url            string
+		body           []byte
+		keyIdempotency string
+		err            error
+	)
+
+	if item.Status == statuses.StatusPaymentWaiting {
+		url = fmt.Sprintf("%s/%s/cancel", p.paymentsURL, item.ID)
+		body = []byte("{}")
+		keyIdempotency = item.ID + suffixCancelKeyIdempotency
+	} else {
+		url = strings.TrimSuffix(p.paymentsURL, pathPaymentsAPIYoomany) + pathRefundsAPIYoomany
+		keyIdempotency = item.ID + suffixRefundKeyIdempotency
+
+		body, err = json.Marshal(&RefundPayment{Amount: item.Amount, PaymentID: item.ID})
+		if err != nil {
+			return fmt.Errorf("%w error:%v", ErrMarshallPayment, err.Error())
+		}
+	}
+
+	_, err = p.requestAPIYoomany(ctx, http.MethodPost, url, body, keyIdempotency)
+	if err != nil {
+		return err
+	}
+
+	err = p.service.UpdatePaymentStatus(ctx, item.Metadata.PaymentID, statuses.IntStatusPaymentCanceled, event)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	logger.Infof("returned payment id=%d yookassa id=%s without orders amount=%s",
+		item.Metadata.PaymentID, item.ID, item.Amount.Value)
+
+	return nil
+}
+
+// captureHeldPayment captures money for delivered orders or cancels payment
+// if all orders were cancelled or refunded.
+func (p *ProductHandler) captureHeldPayment(ctx context.Context, heldPayment *models.HeldPayment) error {
+	var (
+		url            string
+		body           []byte
+		keyIdempotency string
+		err            error
+	)
+
+	if heldPayment.CaptureAmount == 0 {
+		url = fmt.Sprintf("%s/%s/cancel", p.paymentsURL, heldPayment.YookassaID)
+		body = []byte("{}")
+		keyIdempotency = heldPayment.IdempotencyKey + suffixCancelKeyIdempotency
+	} else {
+		url = fmt.Sprintf("%s/%s/capture", p.paymentsURL, heldPayment.YookassaID)
+		keyIdempotency = heldPayment.IdempotencyKey + suffixCaptureKeyIdempotency
+
+		body, err = json.Marshal(&CapturePayment{
+			Amount: NewAmountPayment(fmt.Sprintf("%d.00", heldPayment.CaptureAmount)),
+		})
+		if err != nil {
+			return fmt.Errorf("%w error:%v", ErrMarshallPayment, err.Error())
+		}
+	}
+
+	bodyResp, err := p.requestAPIYoomany(ctx, http.MethodPost, url, body, keyIdempotency)
+	if err != nil {
+		return err
+	}
+
+	var responsePayment ResponsePostPaymentAPIYoomany
+
+	err = json.Unmarshal(bodyResp, &responsePayment)
+	if err != nil {
+		return fmt.Errorf("%w error:%+v response: %s", ErrUnmarshallAPIYoomany, err.Error(), bodyResp)
+	}
+
+	err = p.service.UpdatePaymentStatus(ctx, heldPayment.ID,
+		statuses.ConvertToIntStatus(responsePayment.Status), nil)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+func (p *ProductHandler) captureHeldPayments(ctx context.Context) {
+	logger := p.logger.LogReqID(ctx)
+
+	heldPayments, err := p.service.GetHeldPayments(ctx)
+	if err != nil {
+		logger.Errorf("error get held payments: %+v", err)
+
+		return
+	}
+
+	for _, heldPayment := range heldPayments {
+		err = p.captureHeldPayment(ctx, heldPayment)
+		if err != nil {
+			logger.Errorf("error capture held payment id=%d: %+v", heldPayment.ID, err)
+
+			continue
+		}
+
+		logger.Infof("finished held payment id=%d capture amount=%d",
+			heldPayment.ID, heldPayment.CaptureAmount)
+	}
+}
@@ -0,0 +1,158 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package delivery
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson4199e7b9DecodeGithubComGoParkMailRu20232RabotyagiInternalProductDelivery(in *jlexer.Lexer, out *RefundPayment) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "amount":
+			(out.Amount).UnmarshalEasyJSON(in)
+		case "payment_id":
+			out.PaymentID = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4199e7b9EncodeGithubComGoParkMailRu20232RabotyagiInternalProductDelivery(out *jwriter.Writer, in RefundPayment) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"amount\":"
+		out.RawString(prefix[1:])
+		(in.Amount).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"payment_id\":"
+		out.RawString(prefix)
+		out.String(string(in.PaymentID))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RefundPayment) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4199e7b9EncodeGithubComGoParkMailRu20232RabotyagiInternalProductDelivery(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RefundPayment) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4199e7b9EncodeGithubComGoParkMailRu20232RabotyagiInternalProductDelivery(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RefundPayment) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4199e7b9DecodeGithubComGoParkMailRu20232RabotyagiInternalProductDelivery(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RefundPayment) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4199e7b9DecodeGithubComGoParkMailRu20232RabotyagiInternalProductDelivery(l, v)
+}
+func easyjson4199e7b9DecodeGithubComGoParkMailRu20232RabotyagiInternalProductDelivery1(in *jlexer.Lexer, out *CapturePayment) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "amount":
+			(out.Amount).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4199e7b9EncodeGithubComGoParkMailRu20232RabotyagiInternalProductDelivery1(out *jwriter.Writer, in CapturePayment) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"amount\":"
+		out.RawString(prefix[1:])
+		(in.Amount).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CapturePayment) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4199e7b9EncodeGithubComGoParkMailRu20232RabotyagiInternalProductDelivery1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CapturePayment) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4199e7b9EncodeGithubComGoParkMailRu20232RabotyagiInternalProductDelivery1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CapturePayment) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4199e7b9DecodeGithubComGoParkMailRu20232RabotyagiInternalProductDelivery1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CapturePayment) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4199e7b9DecodeGithubComGoParkMailRu20232RabotyagiInternalProductDelivery1(l, v)
+}
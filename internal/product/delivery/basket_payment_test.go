@@ -0,0 +1,437 @@
+package delivery_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/delivery"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/mocks"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/repository"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses/statuses"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils/test"
+	"go.uber.org/mock/gomock"
+)
+
+const testBasketPaymentID = uint64(7)
+
+func newTestBasketPayment(capture bool) *models.BasketPayment {
+	return &models.BasketPayment{
+		ID:             testBasketPaymentID,
+		OwnerID:        test.UserID,
+		YookassaID:     "",
+		IdempotencyKey: "test_key",
+		Amount:         1500,
+		Capture:        capture,
+		Status:         statuses.IntStatusPaymentPending,
+	}
+}
+
+func newTestPaymentEvent(yookassaID string, status uint8) *models.PaymentEvent {
+	return &models.PaymentEvent{YookassaID: yookassaID, Status: status}
+}
+
+func TestBuyFullBasket(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	type TestCase struct {
+		name                   string
+		failCreatePayment      bool
+		behaviorProductService func(m *mocks.MockIProductService)
+		expectedResponse       any
+		expectedCountPayments  int
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			behaviorProductService: func(m *mocks.MockIProductService) {
+				m.EXPECT().BuyFullBasket(gomock.Any(), test.UserID).Return(newTestBasketPayment(false), nil)
+				m.EXPECT().SetPaymentYookassaID(gomock.Any(), testBasketPaymentID, "fake-payment-1").Return(nil)
+			},
+			expectedResponse:      responses.NewResponseRedirect("https://yoomoney.test/checkout?orderId=fake-payment-1"),
+			expectedCountPayments: 1,
+		},
+		{
+			name: "test not enough products",
+			behaviorProductService: func(m *mocks.MockIProductService) {
+				m.EXPECT().BuyFullBasket(gomock.Any(), test.UserID).Return(nil, repository.ErrNotEnoughProductsForOrder)
+			},
+			expectedResponse: responses.NewErrResponse(statuses.StatusBadContentRequest,
+				repository.ErrNotEnoughProductsForOrder.Error()),
+			expectedCountPayments: 0,
+		},
+		{
+			name:              "test yookassa error returns orders to basket",
+			failCreatePayment: true,
+			behaviorProductService: func(m *mocks.MockIProductService) {
+				m.EXPECT().BuyFullBasket(gomock.Any(), test.UserID).Return(newTestBasketPayment(true), nil)
+				m.EXPECT().CancelBasketPayment(gomock.Any(), testBasketPaymentID,
+					statuses.IntStatusPaymentCanceled, nil).Return(nil)
+			},
+			expectedResponse:      responses.NewErrResponse(statuses.StatusInternalServer, responses.ErrInternalServer),
+			expectedCountPayments: 0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fakeYookassa := delivery.NewFakeYookassa()
+			defer fakeYookassa.Close()
+
+			fakeYookassa.SetFailCreate(testCase.failCreatePayment)
+
+			productHandler, err := NewProductHandlerWithPaymentsURL(ctrl,
+				testCase.behaviorProductService, fakeYookassa.PaymentsURL())
+			if err != nil {
+				t.Fatalf("UnExpected err=%+v\n", err)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodPatch, "/api/v1/order/buy_full_basket", nil)
+			req.AddCookie(&test.Cookie)
+			productHandler.BuyFullBasketHandler(recorder, req)
+
+			err = test.CompareHTTPTestResult(recorder, testCase.expectedResponse)
+			if err != nil {
+				t.Fatalf("Failed CompareHTTPTestResult %+v", err)
+			}
+
+			if countPayments := fakeYookassa.CountPayments(); countPayments != testCase.expectedCountPayments {
+				t.Fatalf("count payments in yookassa: got %d, expected %d",
+					countPayments, testCase.expectedCountPayments)
+			}
+		})
+	}
+}
+
+func TestBuyFullBasketPaymentData(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fakeYookassa := delivery.NewFakeYookassa()
+	defer fakeYookassa.Close()
+
+	productHandler, err := NewProductHandlerWithPaymentsURL(ctrl, func(m *mocks.MockIProductService) {
+		m.EXPECT().BuyFullBasket(gomock.Any(), test.UserID).Return(newTestBasketPayment(false), nil)
+		m.EXPECT().SetPaymentYookassaID(gomock.Any(), testBasketPaymentID, "fake-payment-1").Return(nil)
+	}, fakeYookassa.PaymentsURL())
+	if err != nil {
+		t.Fatalf("UnExpected err=%+v\n", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/order/buy_full_basket", nil)
+	req.AddCookie(&test.Cookie)
+	productHandler.BuyFullBasketHandler(httptest.NewRecorder(), req)
+
+	payment, ok := fakeYookassa.Payment("fake-payment-1")
+	if !ok {
+		t.Fatal("payment wasn't created in yookassa")
+	}
+
+	if payment.Amount.Value != "1500.00" || payment.Capture ||
+		payment.Metadata.PaymentID != "7" || payment.Metadata.UserID != "1" {
+		t.Fatalf("wrong payment in yookassa: %+v", payment)
+	}
+}
+
+func TestHandleBasketPayments(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	type TestCase struct {
+		name                   string
+		statusPayment          string
+		behaviorProductService func(m *mocks.MockIProductService, yookassaID string)
+	}
+
+	// the same status isn't handled twice, repeated event is rejected by storage
+	testCases := [...]TestCase{
+		{
+			name:          "test succeeded payment",
+			statusPayment: statuses.StatusPaymentSucceeded,
+			behaviorProductService: func(m *mocks.MockIProductService, yookassaID string) {
+				event := newTestPaymentEvent(yookassaID, statuses.IntStatusPaymentSucceeded)
+				gomock.InOrder(
+					m.EXPECT().ConfirmBasketPayment(gomock.Any(), testBasketPaymentID,
+						statuses.IntStatusPaymentSucceeded, event).Return(nil),
+					m.EXPECT().ConfirmBasketPayment(gomock.Any(), testBasketPaymentID,
+						statuses.IntStatusPaymentSucceeded, event).Return(repository.ErrPaymentEventHandled),
+				)
+			},
+		},
+		{
+			name:          "test held payment of safe deal",
+			statusPayment: statuses.StatusPaymentWaiting,
+			behaviorProductService: func(m *mocks.MockIProductService, yookassaID string) {
+				event := newTestPaymentEvent(yookassaID, statuses.IntStatusPaymentWaiting)
+				gomock.InOrder(
+					m.EXPECT().ConfirmBasketPayment(gomock.Any(), testBasketPaymentID,
+						statuses.IntStatusPaymentWaiting, event).Return(nil),
+					m.EXPECT().ConfirmBasketPayment(gomock.Any(), testBasketPaymentID,
+						statuses.IntStatusPaymentWaiting, event).Return(repository.ErrPaymentEventHandled),
+				)
+			},
+		},
+		{
+			name:          "test canceled payment",
+			statusPayment: statuses.StatusPaymentCanceled,
+			behaviorProductService: func(m *mocks.MockIProductService, yookassaID string) {
+				event := newTestPaymentEvent(yookassaID, statuses.IntStatusPaymentCanceled)
+				gomock.InOrder(
+					m.EXPECT().CancelBasketPayment(gomock.Any(), testBasketPaymentID,
+						statuses.IntStatusPaymentCanceled, event).Return(nil),
+					m.EXPECT().CancelBasketPayment(gomock.Any(), testBasketPaymentID,
+						statuses.IntStatusPaymentCanceled, event).Return(repository.ErrPaymentEventHandled),
+				)
+			},
+		},
+		{
+			name:                   "test pending payment",
+			statusPayment:          statuses.StatusPaymentPending,
+			behaviorProductService: func(m *mocks.MockIProductService, yookassaID string) {},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fakeYookassa := delivery.NewFakeYookassa()
+			defer fakeYookassa.Close()
+
+			yookassaID := fakeYookassa.AddPayment(testCase.statusPayment, "1500.00", false,
+				*delivery.NewMetadataBasketPayment(test.UserID, testBasketPaymentID))
+
+			productHandler, err := NewProductHandlerWithPaymentsURL(ctrl, func(m *mocks.MockIProductService) {
+				testCase.behaviorProductService(m, yookassaID)
+			}, fakeYookassa.PaymentsURL())
+			if err != nil {
+				t.Fatalf("UnExpected err=%+v\n", err)
+			}
+
+			ctx := context.Background()
+
+			err = productHandler.ReconcilePayments(ctx)
+			if err != nil {
+				t.Fatalf("UnExpected err=%+v\n", err)
+			}
+
+			err = productHandler.ReconcilePayments(ctx)
+			if err != nil {
+				t.Fatalf("UnExpected err=%+v\n", err)
+			}
+		})
+	}
+}
+
+func TestHandleBasketPaymentsError(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fakeYookassa := delivery.NewFakeYookassa()
+	defer fakeYookassa.Close()
+
+	yookassaID := fakeYookassa.AddPayment(statuses.StatusPaymentSucceeded, "1500.00", true,
+		*delivery.NewMetadataBasketPayment(test.UserID, testBasketPaymentID))
+
+	// event isn't saved with failed status, so it will be handled again by the next notification
+	// or reconciliation
+	productHandler, err := NewProductHandlerWithPaymentsURL(ctrl, func(m *mocks.MockIProductService) {
+		event := newTestPaymentEvent(yookassaID, statuses.IntStatusPaymentSucceeded)
+		m.EXPECT().ConfirmBasketPayment(gomock.Any(), testBasketPaymentID,
+			statuses.IntStatusPaymentSucceeded, event).Return(repository.ErrNotFoundPayment).Times(2)
+	}, fakeYookassa.PaymentsURL())
+	if err != nil {
+		t.Fatalf("UnExpected err=%+v\n", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		err = productHandler.ReconcilePayments(context.Background())
+		if err != nil {
+			t.Fatalf("UnExpected err=%+v\n", err)
+		}
+	}
+}
+
+func TestHandleBasketPaymentWithoutOrders(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	type TestCase struct {
+		name            string
+		statusPayment   string
+		expectedStatus  string
+		expectedRefunds int
+	}
+
+	testCases := [...]TestCase{
+		{
+			name:            "test succeeded payment is refunded",
+			statusPayment:   statuses.StatusPaymentSucceeded,
+			expectedStatus:  statuses.StatusPaymentSucceeded,
+			expectedRefunds: 1,
+		},
+		{
+			name:            "test held payment is cancelled",
+			statusPayment:   statuses.StatusPaymentWaiting,
+			expectedStatus:  statuses.StatusPaymentCanceled,
+			expectedRefunds: 0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fakeYookassa := delivery.NewFakeYookassa()
+			defer fakeYookassa.Close()
+
+			yookassaID := fakeYookassa.AddPayment(testCase.statusPayment, "1500.00", false,
+				*delivery.NewMetadataBasketPayment(test.UserID, testBasketPaymentID))
+
+			productHandler, err := NewProductHandlerWithPaymentsURL(ctrl, func(m *mocks.MockIProductService) {
+				event := newTestPaymentEvent(yookassaID, statuses.ConvertToIntStatus(testCase.statusPayment))
+
+				m.EXPECT().ConfirmBasketPayment(gomock.Any(), testBasketPaymentID,
+					event.Status, event).Return(repository.ErrPaymentWithoutOrders)
+				m.EXPECT().UpdatePaymentStatus(gomock.Any(), testBasketPaymentID,
+					statuses.IntStatusPaymentCanceled, event).Return(nil)
+			}, fakeYookassa.PaymentsURL())
+			if err != nil {
+				t.Fatalf("UnExpected err=%+v\n", err)
+			}
+
+			err = productHandler.ReconcilePayments(context.Background())
+			if err != nil {
+				t.Fatalf("UnExpected err=%+v\n", err)
+			}
+
+			payment, _ := fakeYookassa.Payment(yookassaID)
+			if payment.Status != testCase.expectedStatus {
+				t.Fatalf("status of payment: got %s, expected %s", payment.Status, testCase.expectedStatus)
+			}
+
+			refunds := fakeYookassa.Refunds()
+			if len(refunds) != testCase.expectedRefunds {
+				t.Fatalf("count refunds: got %d, expected %d", len(refunds), testCase.expectedRefunds)
+			}
+
+			if len(refunds) != 0 && (refunds[0].PaymentID != yookassaID || refunds[0].Amount.Value != "1500.00") {
+				t.Fatalf("wrong refund %+v", refunds[0])
+			}
+		})
+	}
+}
+
+func TestCaptureHeldPayments(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	type TestCase struct {
+		name                   string
+		captureAmount          uint64
+		expectedStatus         string
+		expectedCapturedAmount string
+		expectedIntStatus      uint8
+	}
+
+	testCases := [...]TestCase{
+		{
+			name:                   "test capture delivered orders",
+			captureAmount:          1000,
+			expectedStatus:         statuses.StatusPaymentSucceeded,
+			expectedCapturedAmount: "1000.00",
+			expectedIntStatus:      statuses.IntStatusPaymentSucceeded,
+		},
+		{
+			name:                   "test cancel payment without delivered orders",
+			captureAmount:          0,
+			expectedStatus:         statuses.StatusPaymentCanceled,
+			expectedCapturedAmount: "",
+			expectedIntStatus:      statuses.IntStatusPaymentCanceled,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fakeYookassa := delivery.NewFakeYookassa()
+			defer fakeYookassa.Close()
+
+			yookassaID := fakeYookassa.AddPayment(statuses.StatusPaymentWaiting, "1500.00", false,
+				*delivery.NewMetadataBasketPayment(test.UserID, testBasketPaymentID))
+
+			productHandler, err := NewProductHandlerWithPaymentsURL(ctrl, func(m *mocks.MockIProductService) {
+				m.EXPECT().GetHeldPayments(gomock.Any()).Return([]*models.HeldPayment{{
+					ID:             testBasketPaymentID,
+					YookassaID:     yookassaID,
+					IdempotencyKey: "test_key",
+					CaptureAmount:  testCase.captureAmount,
+				}}, nil)
+				m.EXPECT().UpdatePaymentStatus(gomock.Any(), testBasketPaymentID,
+					testCase.expectedIntStatus, nil).Return(nil)
+			}, fakeYookassa.PaymentsURL())
+			if err != nil {
+				t.Fatalf("UnExpected err=%+v\n", err)
+			}
+
+			productHandler.CaptureHeldPayments(context.Background())
+
+			payment, _ := fakeYookassa.Payment(yookassaID)
+			if payment.Status != testCase.expectedStatus {
+				t.Fatalf("status of payment: got %s, expected %s", payment.Status, testCase.expectedStatus)
+			}
+
+			capturedAmount := ""
+			if payment.CapturedAmount != nil {
+				capturedAmount = payment.CapturedAmount.Value
+			}
+
+			if capturedAmount != testCase.expectedCapturedAmount {
+				t.Fatalf("captured amount: got %q, expected %q", capturedAmount, testCase.expectedCapturedAmount)
+			}
+		})
+	}
+}
@@ -15,8 +15,8 @@ import (
 var _ ICommentService = (*productusecases.CommentService)(nil)
 
 type ICommentService interface {
-	GetCommentList(ctx context.Context, offset uint64, count uint64, recipientID uint64,
-		senderID uint64) ([]*models.CommentInFeed, error)
+	GetCommentList(ctx context.Context, cursor *models.Cursor, count uint64, recipientID uint64,
+		senderID uint64) (*models.CommentList, error)
 	AddComment(ctx context.Context, r io.Reader, userID uint64) (commentID uint64, err error)
 	DeleteComment(ctx context.Context, commentID uint64, senderID uint64) error
 	UpdateComment(ctx context.Context, r io.Reader, userID uint64, commentID uint64) error
@@ -169,14 +169,14 @@ func (p *ProductHandler) UpdateCommentHandler(w http.ResponseWriter, r *http.Req
 // GetCommentListHandler godoc
 //
 //	@Summary    get comment list
-//	@Description  get comment by count and offset and user id
+//	@Description  get comment by count and cursor and user id, comment of current user is the first
 //	@Tags comment
 //	@Accept      json
 //	@Produce    json
 //	@Param      count  query uint64 true  "count comments"
-//	@Param      offset  query uint64 true  "offset of comments"
+//	@Param      cursor  query string false  "next_cursor from previous page, empty for the first page"
 //	@Param      user_id  query uint64 true  "user"
-//	@Success    200  {object} CommentListResponse
+//	@Success    200  {object} CommentPageResponse
 //	@Failure    405  {string} string
 //	@Failure    500  {string} string
 //	@Failure    222  {object} responses.ErrorResponse "Error" Это Http ответ 200, внутри body статус может быть badFormat(4000)//nolint:lll//nolint:lll
@@ -205,7 +205,7 @@ func (p *ProductHandler) GetCommentListHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	offset, err := utils.ParseUint64FromRequest(r, "offset")
+	cursor, err := parseCursor(r)
 	if err != nil {
 		responses.HandleErr(w, r, logger, err)
 
@@ -219,13 +219,13 @@ func (p *ProductHandler) GetCommentListHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	comments, err := p.service.GetCommentList(ctx, offset, count, recipientID, senderID)
+	commentList, err := p.service.GetCommentList(ctx, cursor, count, recipientID, senderID)
 	if err != nil {
 		responses.HandleErr(w, r, logger, err)
 
 		return
 	}
 
-	responses.SendResponse(w, logger, NewCommentListResponse(comments))
-	logger.Infof("in GetCommentListHandler: get product list: %+v", comments)
+	responses.SendResponse(w, logger, NewCommentPageResponse(commentList))
+	logger.Infof("in GetCommentListHandler: get product list: %+v", commentList.Comments)
 }
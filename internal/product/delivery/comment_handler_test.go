@@ -194,10 +194,11 @@ func TestGetCommentList(t *testing.T) {
 	testCases := [...]TestCase{
 		{
 			name:        "test basic work",
-			queryParams: map[string]string{"count": "2", "offset": "1", "user_id": "1"},
+			queryParams: map[string]string{"count": "2", "cursor": models.NewCursor(1, 1).String(), "user_id": "1"},
 			behaviorProductService: func(m *mocks.MockIProductService) {
-				m.EXPECT().GetCommentList(gomock.Any(), uint64(1), uint64(2), test.UserID, uint64(1)).Return(
-					[]*models.CommentInFeed{
+				m.EXPECT().GetCommentList(gomock.Any(), models.NewCursor(1, 1), uint64(2), test.UserID,
+					uint64(1)).Return(&models.CommentList{
+					Comments: []*models.CommentInFeed{
 						{
 							ID: 1, SenderID: uint64(2), SenderName: "Ivan",
 							Avatar: sql.NullString{Valid: false, String: ""}, Text: "Good", Rating: uint8(5),
@@ -208,10 +209,12 @@ func TestGetCommentList(t *testing.T) {
 							Avatar: sql.NullString{Valid: false, String: ""}, Text: "Good", Rating: uint8(5),
 							CreatedAt: time.Time{},
 						},
-					}, nil)
+					},
+					NextCursor: models.NewCursor(0, 5).String(),
+				}, nil)
 			},
-			expectedResponse: delivery.NewCommentListResponse(
-				[]*models.CommentInFeed{
+			expectedResponse: delivery.NewCommentPageResponse(&models.CommentList{
+				Comments: []*models.CommentInFeed{
 					{
 						ID: 1, SenderID: uint64(2), SenderName: "Ivan",
 						Avatar: sql.NullString{Valid: false, String: ""}, Text: "Good", Rating: uint8(5),
@@ -222,24 +225,27 @@ func TestGetCommentList(t *testing.T) {
 						Avatar: sql.NullString{Valid: false, String: ""}, Text: "Good", Rating: uint8(5),
 						CreatedAt: time.Time{},
 					},
-				}),
+				},
+				NextCursor: models.NewCursor(0, 5).String(),
+			}),
 		},
 		{
 			name:        "test zero work",
-			queryParams: map[string]string{"count": "0", "offset": "0", "user_id": "1"},
+			queryParams: map[string]string{"count": "0", "user_id": "1"},
 			behaviorProductService: func(m *mocks.MockIProductService) {
-				m.EXPECT().GetCommentList(gomock.Any(), uint64(0), uint64(0), test.UserID, uint64(1)).Return(
-					[]*models.CommentInFeed{}, nil)
+				m.EXPECT().GetCommentList(gomock.Any(), (*models.Cursor)(nil), uint64(0), test.UserID, uint64(1)).Return(
+					&models.CommentList{Comments: []*models.CommentInFeed{}, NextCursor: ""}, nil)
 			},
-			expectedResponse: delivery.NewCommentListResponse(
-				[]*models.CommentInFeed{}),
+			expectedResponse: delivery.NewCommentPageResponse(
+				&models.CommentList{Comments: []*models.CommentInFeed{}, NextCursor: ""}),
 		},
 		{
 			name:        "test a lot of count",
-			queryParams: map[string]string{"count": "5", "offset": "1", "user_id": "1"},
+			queryParams: map[string]string{"count": "5", "cursor": models.NewCursor(1, 1).String(), "user_id": "1"},
 			behaviorProductService: func(m *mocks.MockIProductService) {
-				m.EXPECT().GetCommentList(gomock.Any(), uint64(1), uint64(5), test.UserID, uint64(1)).Return(
-					[]*models.CommentInFeed{ //nolint:dupl
+				m.EXPECT().GetCommentList(gomock.Any(), models.NewCursor(1, 1), uint64(5), test.UserID,
+					uint64(1)).Return(&models.CommentList{
+					Comments: []*models.CommentInFeed{ //nolint:dupl
 						{
 							ID: 1, SenderID: uint64(2), SenderName: "Ivan", Avatar: sql.NullString{Valid: false, String: ""},
 							Text: "Good", Rating: uint8(5), CreatedAt: time.Time{},
@@ -264,10 +270,12 @@ func TestGetCommentList(t *testing.T) {
 							Avatar: sql.NullString{Valid: false, String: ""}, Text: "Good", Rating: uint8(5),
 							CreatedAt: time.Time{},
 						},
-					}, nil)
+					},
+					NextCursor: models.NewCursor(0, 5).String(),
+				}, nil)
 			},
-			expectedResponse: delivery.NewCommentListResponse(
-				[]*models.CommentInFeed{ //nolint:dupl
+			expectedResponse: delivery.NewCommentPageResponse(&models.CommentList{
+				Comments: []*models.CommentInFeed{ //nolint:dupl
 					{
 						ID: 1, SenderID: uint64(2), SenderName: "Ivan", Avatar: sql.NullString{Valid: false, String: ""},
 						Text: "Good", Rating: uint8(5), CreatedAt: time.Time{},
@@ -292,7 +300,9 @@ func TestGetCommentList(t *testing.T) {
 						Avatar: sql.NullString{Valid: false, String: ""}, Text: "Good", Rating: uint8(5),
 						CreatedAt: time.Time{},
 					},
-				}),
+				},
+				NextCursor: models.NewCursor(0, 5).String(),
+			}),
 		},
 	}
 
@@ -0,0 +1,15 @@
+package delivery
+
+import (
+	"context"
+)
+
+// ReconcilePayments runs one iteration of waitPayments without waiting:
+// requests list of recent payments and handles changes of their statuses.
+func (p *ProductHandler) ReconcilePayments(ctx context.Context) error {
+	return p.reconcilePayments(ctx)
+}
+
+func (p *ProductHandler) CaptureHeldPayments(ctx context.Context) {
+	p.captureHeldPayments(ctx)
+}
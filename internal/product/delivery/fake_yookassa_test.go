@@ -0,0 +1,331 @@
+package delivery
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses/statuses"
+)
+
+const (
+	pathPaymentsFakeYookassa = "/payments"
+	pathRefundsFakeYookassa  = "/refunds"
+)
+
+type fakePaymentYookassa struct {
+	ID             string                    `json:"id"`
+	Status         string                    `json:"status"`
+	Amount         AmountPayment             `json:"amount"`
+	CapturedAmount *AmountPayment            `json:"captured_amount,omitempty"`
+	Capture        bool                      `json:"capture"`
+	Description    string                    `json:"description"`
+	Confirmation   ConfirmationPayment       `json:"confirmation"`
+	Metadata       metadataPaymentAPIYoomany `json:"metadata"`
+}
+
+// FakeYookassa is in-memory http server with part of yookassa api used by ProductHandler:
+// creation, list, capture and cancel of payments and refunds. Like yookassa it returns
+// the same payment for repeated Idempotence-Key and metadata values as strings.
+type FakeYookassa struct {
+	Server *httptest.Server
+
+	mu            sync.Mutex
+	payments      []*fakePaymentYookassa
+	byIdempotency map[string]*fakePaymentYookassa
+	refunds       map[string]RefundPayment
+	failCreate    bool
+}
+
+func NewFakeYookassa() *FakeYookassa {
+	fake := &FakeYookassa{ //nolint:exhaustruct
+		byIdempotency: make(map[string]*fakePaymentYookassa),
+		refunds:       make(map[string]RefundPayment),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(pathPaymentsFakeYookassa, fake.handlePayments)
+	mux.HandleFunc(pathPaymentsFakeYookassa+"/", fake.handlePayment)
+	mux.HandleFunc(pathRefundsFakeYookassa, fake.handleRefunds)
+
+	fake.Server = httptest.NewServer(mux)
+
+	return fake
+}
+
+// PaymentsURL is url which should be passed to ProductHandler instead of yookassa api.
+func (f *FakeYookassa) PaymentsURL() string {
+	return f.Server.URL + pathPaymentsFakeYookassa
+}
+
+func (f *FakeYookassa) Close() {
+	f.Server.Close()
+}
+
+// SetFailCreate makes creation of payments return internal error of yookassa.
+func (f *FakeYookassa) SetFailCreate(failCreate bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.failCreate = failCreate
+}
+
+// AddPayment adds payment as if it was created earlier and returns its id.
+func (f *FakeYookassa) AddPayment(status string, amount string, capture bool, metadata MetadataPayment) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.addPayment(&fakePaymentYookassa{ //nolint:exhaustruct
+		Status:   status,
+		Amount:   NewAmountPayment(amount),
+		Capture:  capture,
+		Metadata: newMetadataPaymentAPIYoomany(metadata),
+	})
+}
+
+func (f *FakeYookassa) SetStatus(paymentID string, status string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if payment := f.findPayment(paymentID); payment != nil {
+		payment.Status = status
+	}
+}
+
+// Payment returns copy of payment and false if payment isn't found.
+func (f *FakeYookassa) Payment(paymentID string) (fakePaymentYookassa, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	payment := f.findPayment(paymentID)
+	if payment == nil {
+		return fakePaymentYookassa{}, false //nolint:exhaustruct
+	}
+
+	return *payment, true
+}
+
+// Refunds returns refunds created by ProductHandler, repeated Idempotence-Key doesn't create new refund.
+func (f *FakeYookassa) Refunds() []RefundPayment {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	refunds := make([]RefundPayment, 0, len(f.refunds))
+	for _, refund := range f.refunds {
+		refunds = append(refunds, refund)
+	}
+
+	return refunds
+}
+
+func (f *FakeYookassa) CountPayments() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.payments)
+}
+
+func (f *FakeYookassa) addPayment(payment *fakePaymentYookassa) string {
+	payment.ID = fmt.Sprintf("fake-payment-%d", len(f.payments)+1)
+	payment.Confirmation = ConfirmationPayment{
+		Type:            TypeConfirmationPayment,
+		ConfirmationURL: "https://yoomoney.test/checkout?orderId=" + payment.ID,
+	}
+	f.payments = append(f.payments, payment)
+
+	return payment.ID
+}
+
+func (f *FakeYookassa) findPayment(paymentID string) *fakePaymentYookassa {
+	for _, payment := range f.payments {
+		if payment.ID == paymentID {
+			return payment
+		}
+	}
+
+	return nil
+}
+
+func writeJSONFakeYookassa(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeErrFakeYookassa(w http.ResponseWriter, status int, description string) {
+	writeJSONFakeYookassa(w, status, map[string]string{"type": "error", "description": description})
+}
+
+func (f *FakeYookassa) checkAuth(w http.ResponseWriter, r *http.Request) bool {
+	if _, _, ok := r.BasicAuth(); !ok {
+		writeErrFakeYookassa(w, http.StatusUnauthorized, "basic auth required")
+
+		return false
+	}
+
+	return true
+}
+
+func (f *FakeYookassa) handlePayments(w http.ResponseWriter, r *http.Request) {
+	if !f.checkAuth(w, r) {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		items := make([]fakePaymentYookassa, 0, len(f.payments))
+		for _, payment := range f.payments {
+			items = append(items, *payment)
+		}
+
+		writeJSONFakeYookassa(w, http.StatusOK, map[string]any{"type": "list", "items": items})
+	case http.MethodPost:
+		f.createPayment(w, r)
+	default:
+		writeErrFakeYookassa(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (f *FakeYookassa) createPayment(w http.ResponseWriter, r *http.Request) {
+	keyIdempotency := r.Header.Get(headerKeyIdempotency)
+	if keyIdempotency == "" || len(keyIdempotency) > 64 {
+		writeErrFakeYookassa(w, http.StatusBadRequest, "wrong Idempotence-Key")
+
+		return
+	}
+
+	if payment, ok := f.byIdempotency[keyIdempotency]; ok {
+		writeJSONFakeYookassa(w, http.StatusOK, payment)
+
+		return
+	}
+
+	if f.failCreate {
+		writeErrFakeYookassa(w, http.StatusInternalServerError, "internal error")
+
+		return
+	}
+
+	var payment Payment
+
+	err := json.NewDecoder(r.Body).Decode(&payment)
+	if err != nil || payment.Metadata == nil {
+		writeErrFakeYookassa(w, http.StatusBadRequest, "wrong body")
+
+		return
+	}
+
+	fakePayment := &fakePaymentYookassa{ //nolint:exhaustruct
+		Status:      statuses.StatusPaymentPending,
+		Amount:      payment.Amount,
+		Capture:     payment.Capture,
+		Description: payment.Description,
+		Metadata:    newMetadataPaymentAPIYoomany(*payment.Metadata),
+	}
+
+	f.addPayment(fakePayment)
+	f.byIdempotency[keyIdempotency] = fakePayment
+
+	writeJSONFakeYookassa(w, http.StatusOK, fakePayment)
+}
+
+// handlePayment handles GET /payments/{id}, POST /payments/{id}/capture and POST /payments/{id}/cancel.
+func (f *FakeYookassa) handlePayment(w http.ResponseWriter, r *http.Request) {
+	if !f.checkAuth(w, r) {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	paymentID, action, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, pathPaymentsFakeYookassa+"/"), "/")
+
+	payment := f.findPayment(paymentID)
+	if payment == nil {
+		writeErrFakeYookassa(w, http.StatusNotFound, "payment not found")
+
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && action == "":
+	case r.Method == http.MethodPost && action == "capture":
+		if payment.Status != statuses.StatusPaymentWaiting {
+			writeErrFakeYookassa(w, http.StatusBadRequest, "payment isn't waiting for capture")
+
+			return
+		}
+
+		var capturePayment CapturePayment
+
+		err := json.NewDecoder(r.Body).Decode(&capturePayment)
+		if err != nil {
+			writeErrFakeYookassa(w, http.StatusBadRequest, "wrong body")
+
+			return
+		}
+
+		payment.Status = statuses.StatusPaymentSucceeded
+		payment.CapturedAmount = &capturePayment.Amount
+	case r.Method == http.MethodPost && action == "cancel":
+		if payment.Status != statuses.StatusPaymentWaiting && payment.Status != statuses.StatusPaymentPending {
+			writeErrFakeYookassa(w, http.StatusBadRequest, "payment can't be cancelled")
+
+			return
+		}
+
+		payment.Status = statuses.StatusPaymentCanceled
+	default:
+		writeErrFakeYookassa(w, http.StatusNotFound, "unknown method")
+
+		return
+	}
+
+	writeJSONFakeYookassa(w, http.StatusOK, payment)
+}
+
+// handleRefunds handles POST /refunds, refund is allowed only for succeeded payment.
+func (f *FakeYookassa) handleRefunds(w http.ResponseWriter, r *http.Request) {
+	if !f.checkAuth(w, r) {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	keyIdempotency := r.Header.Get(headerKeyIdempotency)
+	if r.Method != http.MethodPost || keyIdempotency == "" {
+		writeErrFakeYookassa(w, http.StatusBadRequest, "wrong request")
+
+		return
+	}
+
+	var refund RefundPayment
+
+	err := json.NewDecoder(r.Body).Decode(&refund)
+	if err != nil {
+		writeErrFakeYookassa(w, http.StatusBadRequest, "wrong body")
+
+		return
+	}
+
+	payment := f.findPayment(refund.PaymentID)
+	if payment == nil || payment.Status != statuses.StatusPaymentSucceeded {
+		writeErrFakeYookassa(w, http.StatusBadRequest, "payment can't be refunded")
+
+		return
+	}
+
+	f.refunds[keyIdempotency] = refund
+
+	writeJSONFakeYookassa(w, http.StatusOK, map[string]any{
+		"id": "fake-refund-" + refund.PaymentID, "status": statuses.StatusPaymentSucceeded,
+		"amount": refund.Amount, "payment_id": refund.PaymentID,
+	})
+}
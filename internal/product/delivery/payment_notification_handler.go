@@ -0,0 +1,143 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+)
+
+const (
+	TypeNotificationAPIYoomany    = "notification"
+	EventPaymentSucceeded         = "payment.succeeded"
+	EventPaymentWaitingForCapture = "payment.waiting_for_capture"
+	EventPaymentCanceled          = "payment.canceled"
+
+	maxSizeNotificationAPIYoomany = 1 << 16
+)
+
+var ErrNotificationAPIYoomany = myerrors.NewErrorBadFormatRequest("Некорректное уведомление от yoomany")
+
+//easyjson:json
+type NotificationObjectAPIYoomany struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+//easyjson:json
+type NotificationAPIYoomany struct {
+	Type   string                       `json:"type"`
+	Event  string                       `json:"event"`
+	Object NotificationObjectAPIYoomany `json:"object"`
+}
+
+func isHandledEventAPIYoomany(event string) bool {
+	return event == EventPaymentSucceeded || event == EventPaymentWaitingForCapture || event == EventPaymentCanceled
+}
+
+// getPaymentAPIYoomany requests actual state of payment by id.
+func (p *ProductHandler) getPaymentAPIYoomany(ctx context.Context,
+	yookassaID string,
+) (*ResponseGetPaymentsItemAPIYoomany, error) {
+	body, err := p.requestAPIYoomany(ctx, http.MethodGet,
+		fmt.Sprintf("%s/%s", p.paymentsURL, url.PathEscape(yookassaID)), nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var payment ResponseGetPaymentsItemAPIYoomany
+
+	err = json.Unmarshal(body, &payment)
+	if err != nil {
+		return nil, fmt.Errorf("%w error:%+v response: %s", ErrUnmarshallAPIYoomany, err.Error(), body)
+	}
+
+	return &payment, nil
+}
+
+// PaymentNotificationHandler godoc
+//
+//	@Summary    notification from yoomany
+//	@Description  receives http notifications of yoomany about payments: payment.succeeded,
+//	@Description  payment.waiting_for_capture and payment.canceled. Accepted only from ip of yoomany.
+//	@Description  Notification is used only as signal, actual payment is requested from yoomany by id.
+//	@Description  Unlike other handlers errors are returned with http status, so yoomany retries notification
+//	@Tags payment
+//	@Accept      json
+//	@Produce    json
+//	@Param      notification  body NotificationAPIYoomany true  "notification of yoomany"
+//	@Success    200  {object} responses.ResponseSuccessful
+//	@Failure    400  {string} string
+//	@Failure    403  {string} string
+//	@Failure    405  {string} string
+//	@Failure    500  {string} string
+//	@Router      /payment/notification [post]
+func (p *ProductHandler) PaymentNotificationHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, `Method not allowed`, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	ctx := r.Context()
+	logger := p.logger.LogReqID(ctx)
+
+	clientIP := utils.GetClientIP(r)
+	if !utils.ContainsIP(p.notificationAllowedIPs, clientIP) {
+		logger.Errorf("in PaymentNotificationHandler: notification from not allowed ip=%s", clientIP)
+		http.Error(w, `Forbidden`, http.StatusForbidden)
+
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxSizeNotificationAPIYoomany))
+	if err != nil {
+		logger.Errorln(err)
+		http.Error(w, ErrNotificationAPIYoomany.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	var notification NotificationAPIYoomany
+
+	err = notification.UnmarshalJSON(body)
+	if err != nil || notification.Type != TypeNotificationAPIYoomany || notification.Object.ID == "" {
+		logger.Errorf("in PaymentNotificationHandler: wrong notification %s err=%+v", body, err)
+		http.Error(w, ErrNotificationAPIYoomany.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	if !isHandledEventAPIYoomany(notification.Event) {
+		logger.Infof("in PaymentNotificationHandler: skip event %s", notification.Event)
+		responses.SendResponse(w, logger, responses.NewResponseSuccessful(ResponseSuccessfulHandleNotification))
+
+		return
+	}
+
+	payment, err := p.getPaymentAPIYoomany(ctx, notification.Object.ID)
+	if err != nil {
+		logger.Errorln(err)
+		http.Error(w, responses.ErrInternalServer, http.StatusInternalServerError)
+
+		return
+	}
+
+	err = p.handlePayment(ctx, *payment)
+	if err != nil {
+		logger.Errorln(err)
+		http.Error(w, responses.ErrInternalServer, http.StatusInternalServerError)
+
+		return
+	}
+
+	responses.SendResponse(w, logger, responses.NewResponseSuccessful(ResponseSuccessfulHandleNotification))
+	logger.Infof("in PaymentNotificationHandler: handled event %s of payment id=%s",
+		notification.Event, notification.Object.ID)
+}
@@ -0,0 +1,218 @@
+package delivery_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/delivery"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/mocks"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/repository"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses/statuses"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils/test"
+	"go.uber.org/mock/gomock"
+)
+
+func newNotificationBody(event string, yookassaID string, status string) string {
+	return fmt.Sprintf(`{"type":"notification","event":"%s","object":{"id":"%s","status":"%s"}}`,
+		event, yookassaID, status)
+}
+
+func TestPaymentNotification(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	type TestCase struct {
+		name string
+		// statusInYookassa is actual status, notification may contain outdated or forged one
+		statusInYookassa       string
+		remoteAddr             string
+		headerRealIP           string
+		method                 string
+		body                   func(yookassaID string) string
+		behaviorProductService func(m *mocks.MockIProductService, yookassaID string)
+		expectedCode           int
+	}
+
+	testCases := [...]TestCase{
+		{
+			name:             "test basic work",
+			statusInYookassa: statuses.StatusPaymentSucceeded,
+			method:           http.MethodPost,
+			body: func(yookassaID string) string {
+				return newNotificationBody(delivery.EventPaymentSucceeded, yookassaID, statuses.StatusPaymentSucceeded)
+			},
+			behaviorProductService: func(m *mocks.MockIProductService, yookassaID string) {
+				m.EXPECT().ConfirmBasketPayment(gomock.Any(), testBasketPaymentID, statuses.IntStatusPaymentSucceeded,
+					newTestPaymentEvent(yookassaID, statuses.IntStatusPaymentSucceeded)).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:             "test status is taken from yookassa instead of notification",
+			statusInYookassa: statuses.StatusPaymentCanceled,
+			method:           http.MethodPost,
+			body: func(yookassaID string) string {
+				return newNotificationBody(delivery.EventPaymentSucceeded, yookassaID, statuses.StatusPaymentSucceeded)
+			},
+			behaviorProductService: func(m *mocks.MockIProductService, yookassaID string) {
+				m.EXPECT().CancelBasketPayment(gomock.Any(), testBasketPaymentID, statuses.IntStatusPaymentCanceled,
+					newTestPaymentEvent(yookassaID, statuses.IntStatusPaymentCanceled)).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:             "test duplicate notification",
+			statusInYookassa: statuses.StatusPaymentSucceeded,
+			method:           http.MethodPost,
+			body: func(yookassaID string) string {
+				return newNotificationBody(delivery.EventPaymentSucceeded, yookassaID, statuses.StatusPaymentSucceeded)
+			},
+			behaviorProductService: func(m *mocks.MockIProductService, yookassaID string) {
+				m.EXPECT().ConfirmBasketPayment(gomock.Any(), testBasketPaymentID, statuses.IntStatusPaymentSucceeded,
+					newTestPaymentEvent(yookassaID, statuses.IntStatusPaymentSucceeded)).
+					Return(repository.ErrPaymentEventHandled)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:             "test not allowed ip",
+			statusInYookassa: statuses.StatusPaymentSucceeded,
+			remoteAddr:       "203.0.113.10:1234",
+			method:           http.MethodPost,
+			body: func(yookassaID string) string {
+				return newNotificationBody(delivery.EventPaymentSucceeded, yookassaID, statuses.StatusPaymentSucceeded)
+			},
+			behaviorProductService: func(m *mocks.MockIProductService, yookassaID string) {},
+			expectedCode:           http.StatusForbidden,
+		},
+		{
+			name:             "test forged X-Real-IP from public network",
+			statusInYookassa: statuses.StatusPaymentSucceeded,
+			remoteAddr:       "203.0.113.10:1234",
+			headerRealIP:     "192.0.2.1",
+			method:           http.MethodPost,
+			body: func(yookassaID string) string {
+				return newNotificationBody(delivery.EventPaymentSucceeded, yookassaID, statuses.StatusPaymentSucceeded)
+			},
+			behaviorProductService: func(m *mocks.MockIProductService, yookassaID string) {},
+			expectedCode:           http.StatusForbidden,
+		},
+		{
+			name:             "test X-Real-IP from nginx",
+			statusInYookassa: statuses.StatusPaymentSucceeded,
+			remoteAddr:       "172.18.0.5:1234",
+			headerRealIP:     "192.0.2.1",
+			method:           http.MethodPost,
+			body: func(yookassaID string) string {
+				return newNotificationBody(delivery.EventPaymentSucceeded, yookassaID, statuses.StatusPaymentSucceeded)
+			},
+			behaviorProductService: func(m *mocks.MockIProductService, yookassaID string) {
+				m.EXPECT().ConfirmBasketPayment(gomock.Any(), testBasketPaymentID, statuses.IntStatusPaymentSucceeded,
+					newTestPaymentEvent(yookassaID, statuses.IntStatusPaymentSucceeded)).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:             "test unknown payment",
+			statusInYookassa: statuses.StatusPaymentSucceeded,
+			method:           http.MethodPost,
+			body: func(yookassaID string) string {
+				return newNotificationBody(delivery.EventPaymentSucceeded, "unknown", statuses.StatusPaymentSucceeded)
+			},
+			behaviorProductService: func(m *mocks.MockIProductService, yookassaID string) {},
+			expectedCode:           http.StatusInternalServerError,
+		},
+		{
+			name:             "test handle error for retry of yookassa",
+			statusInYookassa: statuses.StatusPaymentSucceeded,
+			method:           http.MethodPost,
+			body: func(yookassaID string) string {
+				return newNotificationBody(delivery.EventPaymentSucceeded, yookassaID, statuses.StatusPaymentSucceeded)
+			},
+			behaviorProductService: func(m *mocks.MockIProductService, yookassaID string) {
+				m.EXPECT().ConfirmBasketPayment(gomock.Any(), testBasketPaymentID, statuses.IntStatusPaymentSucceeded,
+					newTestPaymentEvent(yookassaID, statuses.IntStatusPaymentSucceeded)).
+					Return(repository.ErrNotFoundPayment)
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name:             "test skip unknown event",
+			statusInYookassa: statuses.StatusPaymentSucceeded,
+			method:           http.MethodPost,
+			body: func(yookassaID string) string {
+				return newNotificationBody("refund.succeeded", yookassaID, statuses.StatusPaymentSucceeded)
+			},
+			behaviorProductService: func(m *mocks.MockIProductService, yookassaID string) {},
+			expectedCode:           http.StatusOK,
+		},
+		{
+			name:             "test wrong body",
+			statusInYookassa: statuses.StatusPaymentSucceeded,
+			method:           http.MethodPost,
+			body: func(yookassaID string) string {
+				return `{"type":"notification","event":`
+			},
+			behaviorProductService: func(m *mocks.MockIProductService, yookassaID string) {},
+			expectedCode:           http.StatusBadRequest,
+		},
+		{
+			name:             "test wrong method",
+			statusInYookassa: statuses.StatusPaymentSucceeded,
+			method:           http.MethodGet,
+			body: func(yookassaID string) string {
+				return ""
+			},
+			behaviorProductService: func(m *mocks.MockIProductService, yookassaID string) {},
+			expectedCode:           http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fakeYookassa := delivery.NewFakeYookassa()
+			defer fakeYookassa.Close()
+
+			yookassaID := fakeYookassa.AddPayment(testCase.statusInYookassa, "1500.00", true,
+				*delivery.NewMetadataBasketPayment(test.UserID, testBasketPaymentID))
+
+			productHandler, err := NewProductHandlerWithPaymentsURL(ctrl, func(m *mocks.MockIProductService) {
+				testCase.behaviorProductService(m, yookassaID)
+			}, fakeYookassa.PaymentsURL())
+			if err != nil {
+				t.Fatalf("UnExpected err=%+v\n", err)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			req := httptest.NewRequest(testCase.method, "/api/v1/payment/notification",
+				strings.NewReader(testCase.body(yookassaID)))
+			if testCase.remoteAddr != "" {
+				req.RemoteAddr = testCase.remoteAddr
+			}
+
+			if testCase.headerRealIP != "" {
+				req.Header.Set("X-Real-IP", testCase.headerRealIP)
+			}
+
+			productHandler.PaymentNotificationHandler(recorder, req)
+
+			if recorder.Code != testCase.expectedCode {
+				t.Fatalf("http code: got %d, expected %d body: %s",
+					recorder.Code, testCase.expectedCode, recorder.Body.String())
+			}
+		})
+	}
+}
@@ -4,13 +4,17 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"time"
 
+	productrepo "github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/repository"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/usecases"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/server/delivery"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses/statuses"
@@ -20,9 +24,14 @@ import (
 var _ IPremiumService = (*usecases.PremiumService)(nil)
 
 type IPremiumService interface {
-	AddPremium(ctx context.Context, productID uint64, userID uint64, periodCode uint64) error
+	AddPremium(ctx context.Context, productID uint64, userID uint64, periodCode uint64,
+		event *models.PaymentEvent) error
 	CheckPremiumStatus(ctx context.Context, productID uint64, userID uint64) (uint8, error)
-	UpdateStatusPremium(ctx context.Context, status uint8, productID uint64, userID uint64) error
+	UpdateStatusPremium(ctx context.Context, status uint8, productID uint64, userID uint64,
+		event *models.PaymentEvent) error
+	GetPremiumIdempotencyKey(ctx context.Context, userID uint64, productID uint64, periodCode uint64) (string, error)
+	DeletePremiumIdempotencyKey(ctx context.Context, userID uint64, productID uint64, periodCode uint64) error
+	DeleteExpiredPremiumIdempotencyKeys(ctx context.Context) (uint64, error)
 }
 
 var (
@@ -38,16 +47,81 @@ var (
 
 const (
 	headerKeyIdempotency     = "Idempotence-Key"
-	paymentsURLAPIYoomany    = "https://api.yookassa.ru/v3/payments"
 	paramCreatedAtAPIYoomany = "created_at.gte="
-	periodRequestAPIYoumany  = time.Second * 30
+	paramLimitAPIYoomany     = "limit="
+	paramCursorAPIYoomany    = "cursor="
+	limitPaymentsAPIYoomany  = 100
+
+	// periodRequestAPIYoumany statuses come with notifications, polling only reconciles lost ones.
+	periodRequestAPIYoumany = time.Minute * 5
+	periodReconcilePayments = time.Hour * 24
 )
 
-//nolint:funlen,cyclop
-func (p *ProductHandler) handlePayments(ctx context.Context,
-	mapPreviousStatus map[MetadataPayment]uint8,
-	reader io.Reader,
+// handlePayment applies status of payment from yookassa. Each status of payment is handled once,
+// regardless of whether it came from notification or from polling: event is saved in the same
+// transaction which applies status, so failed applying is retried by the next notification or polling.
+func (p *ProductHandler) handlePayment(ctx context.Context, item ResponseGetPaymentsItemAPIYoomany) error {
+	logger := p.logger.LogReqID(ctx)
+
+	status := statuses.ConvertToIntStatus(item.Status)
+	if status == statuses.IntStatusPremiumNot {
+		return fmt.Errorf("%w status=%s", ErrResponseWrongStatusAPIYoomany, item.Status)
+	}
+
+	err := p.applyPaymentStatus(ctx, item, &models.PaymentEvent{YookassaID: item.ID, Status: status})
+	if errors.Is(err, productrepo.ErrPaymentEventHandled) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Successful handle payment id=%s status:%v metadata:%+v", item.ID, item.Status, item.Metadata)
+
+	return nil
+}
+
+func (p *ProductHandler) applyPaymentStatus(ctx context.Context,
+	item ResponseGetPaymentsItemAPIYoomany, event *models.PaymentEvent,
 ) error {
+	if item.Metadata.PaymentID != 0 {
+		return p.handleBasketPayment(ctx, item, event)
+	}
+
+	switch {
+	case statuses.IsStatusPaymentSuccessful(item.Status):
+		err := p.service.AddPremium(ctx,
+			item.Metadata.ProductID, item.Metadata.UserID, item.Metadata.PeriodCode, event)
+		if err != nil {
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+	case item.Status == statuses.StatusPaymentCanceled ||
+		item.Status == statuses.StatusPaymentPending:
+		err := p.service.UpdateStatusPremium(ctx, statuses.ConvertToIntStatus(item.Status),
+			item.Metadata.ProductID, item.Metadata.UserID, event)
+		if err != nil {
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+	default:
+		return fmt.Errorf(myerrors.ErrTemplate, ErrResponseWrongStatusAPIYoomany)
+	}
+
+	// key of finished payment isn't needed, next purchase of the same premium is new payment
+	if item.Status == statuses.StatusPaymentSucceeded || item.Status == statuses.StatusPaymentCanceled {
+		err := p.service.DeletePremiumIdempotencyKey(ctx,
+			item.Metadata.UserID, item.Metadata.ProductID, item.Metadata.PeriodCode)
+		if err != nil {
+			p.logger.LogReqID(ctx).Errorf("error delete idempotency key of payment id=%s: %+v", item.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// handlePayments handles page of payments and returns cursor of the next page.
+// Error of one payment doesn't stop handling of others.
+func (p *ProductHandler) handlePayments(ctx context.Context, reader io.Reader) (string, error) {
 	logger := p.logger.LogReqID(ctx)
 
 	body, err := io.ReadAll(reader)
@@ -55,11 +129,9 @@ func (p *ProductHandler) handlePayments(ctx context.Context,
 		err = fmt.Errorf("%w %+v", ErrReadAllAPIYoomany, err) //nolint:errorlint
 		logger.Errorln(err)
 
-		return err
+		return "", err
 	}
 
-	logger.Infof("body:%s", body)
-
 	var responseGetPayments ResponseGetPaymentsAPIYoomany
 
 	err = json.Unmarshal(body, &responseGetPayments)
@@ -67,148 +139,132 @@ func (p *ProductHandler) handlePayments(ctx context.Context,
 		err = fmt.Errorf("%w %+v", ErrUnmarshallAPIYoomany, err) //nolint:errorlint
 		logger.Errorln(err)
 
-		return err
+		return "", err
 	}
 
 	for _, item := range responseGetPayments.Items {
-		logger.Infof("item:%+v\n", item)
-
-		previousStatus := mapPreviousStatus[item.Metadata]
+		err = p.handlePayment(ctx, item)
+		if err != nil {
+			logger.Errorf("error handle payment id=%s: %+v", item.ID, err)
+		}
+	}
 
-		if previousStatus != statuses.ConvertToIntStatus(item.Status) {
-			switch {
-			case statuses.IsStatusPaymentSuccessful(item.Status):
-				err = p.service.AddPremium(ctx,
-					item.Metadata.ProductID, item.Metadata.UserID, item.Metadata.PeriodCode)
-				if err != nil {
-					err = fmt.Errorf(myerrors.ErrTemplate, err)
-					logger.Errorln(err)
+	return responseGetPayments.NextCursor, nil
+}
 
-					return err
-				}
+// reconcilePayments requests payments created during periodReconcilePayments page by page
+// and handles statuses which were missed by notifications.
+func (p *ProductHandler) reconcilePayments(ctx context.Context) error {
+	createdAtGte := time.Now().Add(-periodReconcilePayments).UTC().Format(time.RFC3339)
+	cursor := ""
+
+	for {
+		urlPayments := fmt.Sprintf("%s?%s%s&%s%d", p.paymentsURL,
+			paramCreatedAtAPIYoomany, url.QueryEscape(createdAtGte), paramLimitAPIYoomany, limitPaymentsAPIYoomany)
+		if cursor != "" {
+			urlPayments += "&" + paramCursorAPIYoomany + url.QueryEscape(cursor)
+		}
 
-				mapPreviousStatus[item.Metadata] = statuses.ConvertToIntStatus(item.Status)
-				logger.Infof("Successful addPremium metadata:%+v", item.Metadata)
-			case item.Status == statuses.StatusPaymentCanceled ||
-				item.Status == statuses.StatusPaymentPending:
-				err := p.service.UpdateStatusPremium(ctx, statuses.ConvertToIntStatus(item.Status),
-					item.Metadata.ProductID, item.Metadata.UserID)
-				if err != nil {
-					return fmt.Errorf(myerrors.ErrTemplate, err)
-				}
+		body, err := p.requestAPIYoomany(ctx, http.MethodGet, urlPayments, nil, "")
+		if err != nil {
+			return err
+		}
 
-				mapPreviousStatus[item.Metadata] = statuses.ConvertToIntStatus(item.Status)
-				logger.Infof("Successful update status to:%v metadata:%+v", item.Status, item.Metadata)
-			default:
-				logger.Errorln(ErrResponseWrongStatusAPIYoomany)
+		cursor, err = p.handlePayments(ctx, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
 
-				return fmt.Errorf(myerrors.ErrTemplate, ErrResponseWrongStatusAPIYoomany)
-			}
+		if cursor == "" {
+			return nil
 		}
 	}
-
-	return nil
 }
 
+// waitPayments periodically reconciles statuses of payments with yookassa.
+// Also it captures or cancels held payments of safe deal which orders are finished
+// and deletes expired idempotency keys of premium payments.
 func (p *ProductHandler) waitPayments(ctx context.Context,
 	chClose <-chan struct{}, periodRequest time.Duration,
 ) {
 	logger := p.logger.LogReqID(ctx)
 
-	timeRequestRFC := time.Now().Format(time.RFC3339)
-
 	go func() {
-		mapPreviousStatus := make(map[MetadataPayment]uint8)
+		ticker := time.NewTicker(periodRequest)
+		defer ticker.Stop()
 
 		for {
 			select {
 			case <-chClose:
 				logger.Infof("успешно отключили ожидание платежей")
-			default:
-				time.Sleep(periodRequest)
-
-				request, err := http.NewRequestWithContext(ctx,
-					http.MethodGet,
-					fmt.Sprintf("%s?%s%s", paymentsURLAPIYoomany, paramCreatedAtAPIYoomany, timeRequestRFC),
-					nil,
-				)
-				if err != nil {
-					err = fmt.Errorf("%w %+v", ErrCreationRequestAPIYooMany, err) //nolint:errorlint
-					logger.Errorln(err)
-				}
 
-				request.SetBasicAuth(p.premiumShopID, p.premiumShopSecretKey)
-				logger.Infof("req:%+v", request)
-
-				response, err := p.httpClient.Do(request)
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				err := p.reconcilePayments(ctx)
 				if err != nil {
-					err = fmt.Errorf("%w %+v", ErrRequestAPIYoomany, err) //nolint:errorlint
-					logger.Errorln(err)
+					logger.Errorf("error reconcile payments: %+v", err)
 				}
 
-				errPayments := p.handlePayments(ctx, mapPreviousStatus, response.Body)
-				if errPayments != nil {
-					logger.Errorf("error parse payments with req: %+v\n error is: %+v", request, errPayments)
-				}
-
-				errBodyClose := response.Body.Close()
-				if errBodyClose != nil {
-					err = fmt.Errorf("%w %+v", ErrClosingResponseBody, err) //nolint:errorlint
-					logger.Errorln(err)
-				}
+				p.captureHeldPayments(ctx)
+				p.deleteExpiredIdempotencyKeys(ctx)
 			}
 		}
 	}()
 }
 
-//nolint:funlen
-func (p *ProductHandler) createPayment(ctx context.Context,
-	userID uint64, productID uint64, periodCode uint64,
-) (string, error) {
+func (p *ProductHandler) deleteExpiredIdempotencyKeys(ctx context.Context) {
 	logger := p.logger.LogReqID(ctx)
 
-	payment, err := NewPayment(ctx, p.frontendPaymentURL, NewMetadataPayment(userID, productID, periodCode))
-	if err != nil {
-		return "", fmt.Errorf(myerrors.ErrTemplate, err)
-	}
-
-	body, err := payment.MarshalJSON()
+	countDeleted, err := p.service.DeleteExpiredPremiumIdempotencyKeys(ctx)
 	if err != nil {
-		err = fmt.Errorf("%w error:%v", ErrMarshallPayment, err.Error())
-		logger.Errorln(err)
+		logger.Errorf("error delete expired idempotency keys: %+v", err)
 
-		return "", fmt.Errorf(myerrors.ErrTemplate, err)
+		return
 	}
 
-	logger.Infof("payment:%s", body)
+	if countDeleted != 0 {
+		logger.Infof("deleted %d expired idempotency keys of premium", countDeleted)
+	}
+}
 
-	keyIdempotencyPayment := p.mapIdempotencyPayment.AddPayment(payment.Metadata)
+// requestAPIYoomany sends request with basic auth of shop and returns body of response.
+// Empty keyIdempotency means that request is without Idempotence-Key header.
+func (p *ProductHandler) requestAPIYoomany(ctx context.Context,
+	method string, url string, body []byte, keyIdempotency string,
+) ([]byte, error) {
+	logger := p.logger.LogReqID(ctx)
 
-	logger.Info("after AddPayment")
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, paymentsURLAPIYoomany, bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
 	if err != nil {
 		err = fmt.Errorf("%w error:%v", ErrCreationRequestAPIYooMany, err.Error())
 		logger.Errorln(err)
 
-		return "", fmt.Errorf(myerrors.ErrTemplate, err)
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if keyIdempotency != "" {
+		req.Header.Set(headerKeyIdempotency, keyIdempotency)
 	}
 
-	req.Header.Set(headerKeyIdempotency, string(keyIdempotencyPayment))
 	req.SetBasicAuth(p.premiumShopID, p.premiumShopSecretKey)
 	req.Header.Set("Content-Type", "application/json")
-	logger.Infof("%+v", req)
+	logger.Infof("request to yookassa: %s %s", req.Method, req.URL.Path)
 
 	response, err := p.httpClient.Do(req)
 	if err != nil {
 		err = fmt.Errorf("%w error:%v", ErrRequestAPIYoomany, err.Error())
 		logger.Errorln(err)
 
-		return "", fmt.Errorf(myerrors.ErrTemplate, err)
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	logger.Infof("%+v", response)
-
 	defer response.Body.Close()
 
 	bodyResp, err := io.ReadAll(response.Body)
@@ -216,19 +272,50 @@ func (p *ProductHandler) createPayment(ctx context.Context,
 		err = fmt.Errorf("%w error:%v", ErrReadAllAPIYoomany, err.Error())
 		logger.Errorln(err)
 
-		return "", fmt.Errorf(myerrors.ErrTemplate, err)
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	var responsePayment ResponsePostPaymentAPIYoomany
-
 	logger.Infof("%s", bodyResp)
 
+	if response.StatusCode != http.StatusOK {
+		err = fmt.Errorf("%w status:%d response: %s", ErrResponseAPIYoomany, response.StatusCode, bodyResp)
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return bodyResp, nil
+}
+
+// postPaymentAPIYoomany creates payment in yoomany and checks that it has confirmation for redirect.
+func (p *ProductHandler) postPaymentAPIYoomany(ctx context.Context,
+	payment *Payment, keyIdempotency string,
+) (*ResponsePostPaymentAPIYoomany, error) {
+	logger := p.logger.LogReqID(ctx)
+
+	body, err := payment.MarshalJSON()
+	if err != nil {
+		err = fmt.Errorf("%w error:%v", ErrMarshallPayment, err.Error())
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	logger.Infof("payment:%s", body)
+
+	bodyResp, err := p.requestAPIYoomany(ctx, http.MethodPost, p.paymentsURL, body, keyIdempotency)
+	if err != nil {
+		return nil, err
+	}
+
+	var responsePayment ResponsePostPaymentAPIYoomany
+
 	err = json.Unmarshal(bodyResp, &responsePayment)
 	if err != nil {
 		err = fmt.Errorf("%w error:%+v response: %s", ErrUnmarshallAPIYoomany, err.Error(), bodyResp)
 		logger.Errorln(err)
 
-		return "", fmt.Errorf(myerrors.ErrTemplate, err)
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
 	if !responsePayment.IsCorrect() {
@@ -236,10 +323,33 @@ func (p *ProductHandler) createPayment(ctx context.Context,
 		logger.Infof("response Confirmation %+v", responsePayment.Confirmation)
 		logger.Infof("expected Confirmation %+v", payment.Confirmation)
 
+		return nil, fmt.Errorf(myerrors.ErrTemplate, ErrResponseAPIYoomany)
+	}
+
+	return &responsePayment, nil
+}
+
+func (p *ProductHandler) createPayment(ctx context.Context,
+	userID uint64, productID uint64, periodCode uint64,
+) (string, error) {
+	logger := p.logger.LogReqID(ctx)
+
+	payment, err := NewPayment(ctx, p.frontendPaymentURL, NewMetadataPayment(userID, productID, periodCode))
+	if err != nil {
 		return "", fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	err = p.service.UpdateStatusPremium(ctx, statuses.IntStatusPremiumPending, productID, userID)
+	keyIdempotencyPayment, err := p.service.GetPremiumIdempotencyKey(ctx, userID, productID, periodCode)
+	if err != nil {
+		return "", fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	responsePayment, err := p.postPaymentAPIYoomany(ctx, payment, keyIdempotencyPayment)
+	if err != nil {
+		return "", err
+	}
+
+	err = p.service.UpdateStatusPremium(ctx, statuses.IntStatusPremiumPending, productID, userID, nil)
 	if err != nil {
 		err = fmt.Errorf(myerrors.ErrTemplate, err)
 		logger.Errorln(err)
@@ -1,12 +1,16 @@
 package delivery_test
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/delivery"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/mocks"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses/statuses"
@@ -29,10 +33,24 @@ func TestAddPremium(t *testing.T) {
 
 	testCases := [...]TestCase{
 		{
-			name:                   "test basic work",
-			queryID:                "1",
-			behaviorProductService: func(m *mocks.MockIProductService) {},
-			expectedResponse:       responses.NewErrResponse(statuses.StatusInternalServer, "Ошибка на сервере"),
+			name:    "test basic work",
+			queryID: "1",
+			behaviorProductService: func(m *mocks.MockIProductService) {
+				m.EXPECT().GetPremiumIdempotencyKey(gomock.Any(), test.UserID, uint64(1), uint64(1)).
+					Return("test_key", nil)
+				m.EXPECT().UpdateStatusPremium(gomock.Any(), statuses.IntStatusPremiumPending,
+					uint64(1), test.UserID, nil).Return(nil)
+			},
+			expectedResponse: responses.NewResponseRedirect("https://yoomoney.test/checkout?orderId=fake-payment-1"),
+		},
+		{
+			name:    "test error of idempotency key",
+			queryID: "1",
+			behaviorProductService: func(m *mocks.MockIProductService) {
+				m.EXPECT().GetPremiumIdempotencyKey(gomock.Any(), test.UserID, uint64(1), uint64(1)).
+					Return("", myerrors.NewErrorInternal("test error"))
+			},
+			expectedResponse: responses.NewErrResponse(statuses.StatusInternalServer, responses.ErrInternalServer),
 		},
 		{
 			name:    "test error uncorrected query param",
@@ -54,7 +72,11 @@ func TestAddPremium(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			productHandler, err := NewProductHandler(ctrl, testCase.behaviorProductService)
+			fakeYookassa := delivery.NewFakeYookassa()
+			defer fakeYookassa.Close()
+
+			productHandler, err := NewProductHandlerWithPaymentsURL(ctrl,
+				testCase.behaviorProductService, fakeYookassa.PaymentsURL())
 			if err != nil {
 				t.Fatalf("Failed create productHandler %+v", err)
 			}
@@ -73,3 +95,115 @@ func TestAddPremium(t *testing.T) {
 		})
 	}
 }
+
+func TestAddPremiumRetryReusesPayment(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fakeYookassa := delivery.NewFakeYookassa()
+	defer fakeYookassa.Close()
+
+	// key is persisted, so retry after restart of service gets the same key
+	productHandler, err := NewProductHandlerWithPaymentsURL(ctrl, func(m *mocks.MockIProductService) {
+		m.EXPECT().GetPremiumIdempotencyKey(gomock.Any(), test.UserID, uint64(1), uint64(1)).
+			Return("test_key", nil).Times(2)
+		m.EXPECT().UpdateStatusPremium(gomock.Any(), statuses.IntStatusPremiumPending,
+			uint64(1), test.UserID, nil).Return(nil).Times(2)
+	}, fakeYookassa.PaymentsURL())
+	if err != nil {
+		t.Fatalf("Failed create productHandler %+v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		recorder := httptest.NewRecorder()
+
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/premium/add", nil)
+		utils.AddQueryParamsToRequest(req, map[string]string{"product_id": "1", "period": "1"})
+		req.AddCookie(&test.Cookie)
+		productHandler.AddPremiumHandler(recorder, req)
+
+		err = test.CompareHTTPTestResult(recorder,
+			responses.NewResponseRedirect("https://yoomoney.test/checkout?orderId=fake-payment-1"))
+		if err != nil {
+			t.Fatalf("Failed CompareHTTPTestResult %+v", err)
+		}
+	}
+
+	if countPayments := fakeYookassa.CountPayments(); countPayments != 1 {
+		t.Fatalf("count payments in yookassa: got %d, expected 1", countPayments)
+	}
+}
+
+func TestHandlePremiumPayments(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	type TestCase struct {
+		name                   string
+		statusPayment          string
+		behaviorProductService func(m *mocks.MockIProductService, event *models.PaymentEvent)
+	}
+
+	testCases := [...]TestCase{
+		{
+			name:          "test succeeded payment deletes idempotency key",
+			statusPayment: statuses.StatusPaymentSucceeded,
+			behaviorProductService: func(m *mocks.MockIProductService, event *models.PaymentEvent) {
+				m.EXPECT().AddPremium(gomock.Any(), uint64(1), test.UserID, uint64(1), event).Return(nil)
+				m.EXPECT().DeletePremiumIdempotencyKey(gomock.Any(), test.UserID, uint64(1), uint64(1)).Return(nil)
+			},
+		},
+		{
+			name:          "test canceled payment deletes idempotency key",
+			statusPayment: statuses.StatusPaymentCanceled,
+			behaviorProductService: func(m *mocks.MockIProductService, event *models.PaymentEvent) {
+				m.EXPECT().UpdateStatusPremium(gomock.Any(), statuses.IntStatusPremiumCanceled,
+					uint64(1), test.UserID, event).Return(nil)
+				m.EXPECT().DeletePremiumIdempotencyKey(gomock.Any(), test.UserID, uint64(1), uint64(1)).Return(nil)
+			},
+		},
+		{
+			name:          "test pending payment keeps idempotency key",
+			statusPayment: statuses.StatusPaymentPending,
+			behaviorProductService: func(m *mocks.MockIProductService, event *models.PaymentEvent) {
+				m.EXPECT().UpdateStatusPremium(gomock.Any(), statuses.IntStatusPremiumPending,
+					uint64(1), test.UserID, event).Return(nil)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fakeYookassa := delivery.NewFakeYookassa()
+			defer fakeYookassa.Close()
+
+			yookassaID := fakeYookassa.AddPayment(testCase.statusPayment, "100.00", true,
+				*delivery.NewMetadataPayment(test.UserID, 1, 1))
+
+			productHandler, err := NewProductHandlerWithPaymentsURL(ctrl, func(m *mocks.MockIProductService) {
+				testCase.behaviorProductService(m,
+					newTestPaymentEvent(yookassaID, statuses.ConvertToIntStatus(testCase.statusPayment)))
+			}, fakeYookassa.PaymentsURL())
+			if err != nil {
+				t.Fatalf("Failed create productHandler %+v", err)
+			}
+
+			err = productHandler.ReconcilePayments(context.Background())
+			if err != nil {
+				t.Fatalf("UnExpected err=%+v\n", err)
+			}
+		})
+	}
+}
@@ -15,10 +15,11 @@ type MetadataPayment struct {
 	UserID     uint64 `json:"user_id"`
 	ProductID  uint64 `json:"product_id"`
 	PeriodCode uint64 `json:"period_code"`
+	PaymentID  uint64 `json:"payment_id"`
 }
 
 func NewMetadataPayment(userID uint64, productID uint64, periodCode uint64) *MetadataPayment {
-	return &MetadataPayment{UserID: userID, ProductID: productID, PeriodCode: periodCode}
+	return &MetadataPayment{UserID: userID, ProductID: productID, PeriodCode: periodCode, PaymentID: 0}
 }
 
 const currencyAmountPayment = "RUB"
@@ -148,6 +148,8 @@ func easyjsonAb7eef52DecodeGithubComGoParkMailRu20232RabotyagiInternalProductDel
 			out.ProductID = uint64(in.Uint64())
 		case "period_code":
 			out.PeriodCode = uint64(in.Uint64())
+		case "payment_id":
+			out.PaymentID = uint64(in.Uint64())
 		default:
 			in.SkipRecursive()
 		}
@@ -177,6 +179,11 @@ func easyjsonAb7eef52EncodeGithubComGoParkMailRu20232RabotyagiInternalProductDel
 		out.RawString(prefix)
 		out.Uint64(uint64(in.PeriodCode))
 	}
+	{
+		const prefix string = ",\"payment_id\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.PaymentID))
+	}
 	out.RawByte('}')
 }
 
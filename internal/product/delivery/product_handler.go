@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"os"
 
@@ -26,38 +27,40 @@ var _ IProductService = (*usecases.ProductService)(nil)
 type IProductService interface { //nolint:interfacebloat
 	AddProduct(ctx context.Context, r io.Reader, userID uint64) (productID uint64, err error)
 	GetProduct(ctx context.Context, productID uint64, userID uint64) (*models.Product, error)
-	GetProductsList(ctx context.Context,
-		offset uint64, count uint64, userID uint64) ([]*models.ProductInFeed, error)
-	GetProductsOfSaler(ctx context.Context, offset uint64,
-		count uint64, userID uint64, isMy bool) ([]*models.ProductInFeed, error)
+	GetProductsList(ctx context.Context, cursor *models.Cursor, count uint64,
+		userID uint64, filter *models.ProductFilter) (*models.ProductFeed, error)
+	GetProductsOfSaler(ctx context.Context, cursor *models.Cursor,
+		count uint64, userID uint64, isMy bool) (*models.ProductList, error)
 	UpdateProduct(ctx context.Context, r io.Reader, isPartialUpdate bool, productID uint64, userAuthID uint64) error
 	CloseProduct(ctx context.Context, productID uint64, userID uint64) error
 	ActivateProduct(ctx context.Context, productID uint64, userID uint64) error
 	DeleteProduct(ctx context.Context, productID uint64, userID uint64) error
 	SearchProduct(ctx context.Context, searchInput string) ([]string, error)
 	GetSearchProductFeed(ctx context.Context,
-		searchInput string, lastNumber uint64, limit uint64, userID uint64,
-	) ([]*models.ProductInFeed, error)
+		searchInput string, cursor *models.Cursor, limit uint64, userID uint64, filter *models.ProductFilter,
+	) (*models.ProductFeed, error)
 	IBasketService
 	IFavouriteService
 	IPremiumService
 	ICommentService
+	ISavedSearchService
 }
 
 type ProductHandler struct {
-	frontendPaymentURL    string
-	premiumShopID         string
-	premiumShopSecretKey  string
-	pathCertFile          string
-	httpClient            *http.Client
-	mapIdempotencyPayment *MapIdempotencePayment
-	sessionManagerClient  auth.SessionMangerClient
-	service               IProductService
-	logger                *mylogger.MyLogger
+	frontendPaymentURL     string
+	premiumShopID          string
+	premiumShopSecretKey   string
+	paymentsURL            string
+	notificationAllowedIPs []*net.IPNet
+	pathCertFile           string
+	httpClient             *http.Client
+	sessionManagerClient   auth.SessionMangerClient
+	service                IProductService
+	logger                 *mylogger.MyLogger
 }
 
 func NewProductHandler(ctx context.Context, frontendURL,
-	premiumShopID, premiumShopSecretKey, pathCertFile string,
+	premiumShopID, premiumShopSecretKey, paymentsURL, notificationAllowedIPs, pathCertFile string,
 	productService IProductService, sessionManagerClient auth.SessionMangerClient,
 ) (*ProductHandler, error) {
 	logger, err := mylogger.Get()
@@ -65,6 +68,11 @@ func NewProductHandler(ctx context.Context, frontendURL,
 		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
+	notificationAllowedIPNets, err := utils.ParseIPNets(notificationAllowedIPs)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
 	client := &http.Client{} //nolint:exhaustruct
 
 	file, err := os.Open(pathCertFile)
@@ -92,21 +100,24 @@ func NewProductHandler(ctx context.Context, frontendURL,
 	}
 
 	productHandler := &ProductHandler{
-		frontendPaymentURL:    frontendURL,
-		premiumShopID:         premiumShopID,
-		premiumShopSecretKey:  premiumShopSecretKey,
-		pathCertFile:          pathCertFile,
-		httpClient:            client,
-		mapIdempotencyPayment: NewMapIdempotence(),
-		service:               productService,
-		logger:                logger,
-		sessionManagerClient:  sessionManagerClient,
+		frontendPaymentURL:     frontendURL,
+		premiumShopID:          premiumShopID,
+		premiumShopSecretKey:   premiumShopSecretKey,
+		paymentsURL:            paymentsURL,
+		notificationAllowedIPs: notificationAllowedIPNets,
+		pathCertFile:           pathCertFile,
+		httpClient:             client,
+		service:                productService,
+		logger:                 logger,
+		sessionManagerClient:   sessionManagerClient,
 	}
 
 	// chClose yet not used
 	chClose := make(chan struct{})
 
 	productHandler.waitPayments(ctx, chClose, periodRequestAPIYoumany)
+	productHandler.waitSavedSearches(ctx, chClose, periodCheckSavedSearches)
+	productHandler.waitOrderReservations(ctx, chClose, periodCancelExpiredReservations)
 
 	return productHandler, nil
 }
@@ -208,16 +219,25 @@ func (p *ProductHandler) GetProductHandler(w http.ResponseWriter, r *http.Reques
 // GetProductListHandler godoc
 //
 //	@Summary    get products list
-//	@Description  get products by count and last_id return old products
+//	@Description  get products by count and cursor, products may be filtered
+//	@Description  returns facets: count of products per category and per city
+//	@Description  and next_cursor, it's empty if there are no more products
 //	@Tags product
 //	@Accept      json
 //	@Produce    json
 //	@Param      count  query uint64 true  "count products"
-//	@Param      offset  query uint64 true  "offset of products"
-//	@Success    200  {object} ProductListResponse
+//	@Param      cursor  query string false  "next_cursor from previous page, empty for the first page"
+//	@Param      category_id  query uint64 false  "category id, products of subcategories are included"
+//	@Param      city_id  query uint64 false  "city id"
+//	@Param      min_price  query uint64 false  "min price"
+//	@Param      max_price  query uint64 false  "max price"
+//	@Param      delivery  query bool false  "only with delivery"
+//	@Param      safe_deal  query bool false  "only with safe deal"
+//	@Param      premium  query bool false  "only premium"
+//	@Success    200  {object} ProductFeedResponse
 //	@Failure    405  {string} string
 //	@Failure    500  {string} string
-//	@Failure    222  {object} responses.ErrorResponse "Error" Это Http ответ 200, внутри body статус может быть badFormat(4000)//nolint:lll//nolint:lll
+//	@Failure    222  {object} responses.ErrorResponse "Error" Это Http ответ 200, внутри body статус может быть badFormat(4000), badContent(4400)//nolint:lll
 //	@Router      /product/get_list [get]
 func (p *ProductHandler) GetProductListHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -236,7 +256,14 @@ func (p *ProductHandler) GetProductListHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	offset, err := utils.ParseUint64FromRequest(r, "offset")
+	cursor, err := parseCursor(r)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	filter, err := parseProductFilter(r)
 	if err != nil {
 		responses.HandleErr(w, r, logger, err)
 
@@ -254,15 +281,15 @@ func (p *ProductHandler) GetProductListHandler(w http.ResponseWriter, r *http.Re
 		}
 	}
 
-	products, err := p.service.GetProductsList(ctx, offset, count, userID)
+	productFeed, err := p.service.GetProductsList(ctx, cursor, count, userID, filter)
 	if err != nil {
 		responses.HandleErr(w, r, logger, err)
 
 		return
 	}
 
-	responses.SendResponse(w, logger, NewProductListResponse(products))
-	logger.Infof("in GetProductListHandler: get product list: %+v", products)
+	responses.SendResponse(w, logger, NewProductFeedResponse(productFeed))
+	logger.Infof("in GetProductListHandler: get product list: %+v", productFeed.Products)
 }
 
 // GetListProductOfSalerHandler godoc
@@ -273,8 +300,8 @@ func (p *ProductHandler) GetProductListHandler(w http.ResponseWriter, r *http.Re
 //	@Accept      json
 //	@Produce    json
 //	@Param      count  query uint64 true  "count products"
-//	@Param      last_id  query uint64 true  "last product id "
-//	@Success    200  {object} ProductListResponse
+//	@Param      cursor  query string false  "next_cursor from previous page, empty for the first page"
+//	@Success    200  {object} ProductPageResponse
 //	@Failure    405  {string} string
 //	@Failure    500  {string} string
 //	@Failure    222  {object} responses.ErrorResponse "Error" Это Http ответ 200, внутри body статус может быть badFormat(4000)//nolint:lll
@@ -296,7 +323,7 @@ func (p *ProductHandler) GetListProductOfSalerHandler(w http.ResponseWriter, r *
 		return
 	}
 
-	lastID, err := utils.ParseUint64FromRequest(r, "last_id")
+	cursor, err := parseCursor(r)
 	if err != nil {
 		responses.HandleErr(w, r, logger, err)
 
@@ -310,28 +337,28 @@ func (p *ProductHandler) GetListProductOfSalerHandler(w http.ResponseWriter, r *
 		return
 	}
 
-	products, err := p.service.GetProductsOfSaler(ctx, lastID, count, userID, true)
+	productList, err := p.service.GetProductsOfSaler(ctx, cursor, count, userID, true)
 	if err != nil {
 		responses.HandleErr(w, r, logger, err)
 
 		return
 	}
 
-	responses.SendResponse(w, logger, NewProductListResponse(products))
-	logger.Infof("in GetListProductOfSalerHandler: get product list: %+v", products)
+	responses.SendResponse(w, logger, NewProductPageResponse(productList))
+	logger.Infof("in GetListProductOfSalerHandler: get product list: %+v", productList.Products)
 }
 
 // GetListProductOfAnotherSalerHandler godoc
 //
 //	@Summary     get list of products for another saler
-//	@Description  get list of products for another saler using saler id, count and last product id from query
+//	@Description  get list of products for another saler using saler id, count and cursor from query
 //	@Tags product
 //	@Accept      json
 //	@Produce    json
 //	@Param      saler_id  query uint64 true  "saler id"
 //	@Param      count  query uint64 true  "count products"
-//	@Param      offset  query uint64 true  "offset of products"
-//	@Success    200  {object} ProductListResponse
+//	@Param      cursor  query string false  "next_cursor from previous page, empty for the first page"
+//	@Success    200  {object} ProductPageResponse
 //	@Failure    405  {string} string
 //	@Failure    500  {string} string
 //	@Failure    222  {object} responses.ErrorResponse "Error" Это Http ответ 200, внутри body статус может быть badFormat(4000)//nolint:lll
@@ -353,7 +380,7 @@ func (p *ProductHandler) GetListProductOfAnotherSalerHandler(w http.ResponseWrit
 		return
 	}
 
-	offset, err := utils.ParseUint64FromRequest(r, "offset")
+	cursor, err := parseCursor(r)
 	if err != nil {
 		responses.HandleErr(w, r, logger, err)
 
@@ -367,15 +394,15 @@ func (p *ProductHandler) GetListProductOfAnotherSalerHandler(w http.ResponseWrit
 		return
 	}
 
-	products, err := p.service.GetProductsOfSaler(ctx, offset, count, salerID, false)
+	productList, err := p.service.GetProductsOfSaler(ctx, cursor, count, salerID, false)
 	if err != nil {
 		responses.HandleErr(w, r, logger, err)
 
 		return
 	}
 
-	responses.SendResponse(w, logger, NewProductListResponse(products))
-	logger.Infof("in GetListProductOfAnotherSalerHandler: get product list: %+v", products)
+	responses.SendResponse(w, logger, NewProductPageResponse(productList))
+	logger.Infof("in GetListProductOfAnotherSalerHandler: get product list: %+v", productList.Products)
 }
 
 // UpdateProductHandler godoc
@@ -628,9 +655,16 @@ func (p *ProductHandler) SearchProductHandler(w http.ResponseWriter, r *http.Req
 //	@Accept      json
 //	@Produce    json
 //	@Param      count  query uint64 true  "count products"
-//	@Param      offset  query uint64 true  "last product id"
+//	@Param      cursor  query string false  "next_cursor from previous page, empty for the first page"
 //	@Param      searched  query string true  "searched string"
-//	@Success    200  {object} ProductListResponse
+//	@Param      category_id  query uint64 false  "category id, products of subcategories are included"
+//	@Param      city_id  query uint64 false  "city id"
+//	@Param      min_price  query uint64 false  "min price"
+//	@Param      max_price  query uint64 false  "max price"
+//	@Param      delivery  query bool false  "only with delivery"
+//	@Param      safe_deal  query bool false  "only with safe deal"
+//	@Param      premium  query bool false  "only premium"
+//	@Success    200  {object} ProductFeedResponse
 //	@Failure    405  {string} string
 //	@Failure    500  {string} string
 //	@Failure    222  {object} responses.ErrorResponse "Error" Это Http ответ 200, внутри body статус может быть badFormat(4000)//nolint:lll
@@ -652,7 +686,7 @@ func (p *ProductHandler) GetSearchProductFeedHandler(w http.ResponseWriter, r *h
 		return
 	}
 
-	offset, err := utils.ParseUint64FromRequest(r, "offset")
+	cursor, err := parseCursor(r)
 	if err != nil {
 		responses.HandleErr(w, r, logger, err)
 
@@ -661,6 +695,13 @@ func (p *ProductHandler) GetSearchProductFeedHandler(w http.ResponseWriter, r *h
 
 	searchInput := utils.ParseStringFromRequest(r, "searched")
 
+	filter, err := parseProductFilter(r)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
 	userID, err := delivery.GetUserID(ctx, r, p.sessionManagerClient)
 	if err != nil {
 		if errors.Is(err, responses.ErrCookieNotPresented) {
@@ -672,12 +713,65 @@ func (p *ProductHandler) GetSearchProductFeedHandler(w http.ResponseWriter, r *h
 		}
 	}
 
-	products, err := p.service.GetSearchProductFeed(ctx, searchInput, offset, count, userID)
+	productFeed, err := p.service.GetSearchProductFeed(ctx, searchInput, cursor, count, userID, filter)
 	if err != nil {
 		responses.HandleErr(w, r, logger, err)
 
 		return
 	}
 
-	responses.SendResponse(w, logger, NewProductListResponse(products))
+	responses.SendResponse(w, logger, NewProductFeedResponse(productFeed))
+}
+
+// parseCursor returns nil cursor if it's not presented in request, it means the first page.
+func parseCursor(r *http.Request) (*models.Cursor, error) {
+	cursor, err := models.ParseCursor(utils.ParseStringFromRequest(r, "cursor"))
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return cursor, nil
+}
+
+func parseProductFilter(r *http.Request) (*models.ProductFilter, error) {
+	filter := new(models.ProductFilter)
+
+	var err error
+
+	filter.CategoryID, err = utils.ParseOptionalUint64FromRequest(r, "category_id")
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	filter.CityID, err = utils.ParseOptionalUint64FromRequest(r, "city_id")
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	filter.MinPrice, err = utils.ParseOptionalUint64FromRequest(r, "min_price")
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	filter.MaxPrice, err = utils.ParseOptionalUint64FromRequest(r, "max_price")
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	filter.Delivery, err = utils.ParseBoolFromRequest(r, "delivery")
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	filter.SafeDeal, err = utils.ParseBoolFromRequest(r, "safe_deal")
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	filter.PremiumOnly, err = utils.ParseBoolFromRequest(r, "premium")
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return filter, nil
 }
@@ -31,6 +31,17 @@ var behaviorSessionManagerClientCheck = func(m *mocksauth.MockSessionMangerClien
 
 func NewProductHandler(ctrl *gomock.Controller,
 	behaviorProductService func(m *mocks.MockIProductService),
+) (*delivery.ProductHandler, error) {
+	return NewProductHandlerWithPaymentsURL(ctrl, behaviorProductService, "test")
+}
+
+// testNotificationIPs contains RemoteAddr of requests created by httptest.NewRequest.
+const testNotificationIPs = "192.0.2.0/24"
+
+// NewProductHandlerWithPaymentsURL creates handler which sends requests of payments to paymentsURL,
+// e.g. to fake yookassa.
+func NewProductHandlerWithPaymentsURL(ctrl *gomock.Controller,
+	behaviorProductService func(m *mocks.MockIProductService), paymentsURL string,
 ) (*delivery.ProductHandler, error) {
 	mockProductService := mocks.NewMockIProductService(ctrl)
 	mockSessionManagerClient := mocksauth.NewMockSessionMangerClient(ctrl)
@@ -41,7 +52,7 @@ func NewProductHandler(ctrl *gomock.Controller,
 	baseCtx := context.Background()
 
 	productHandler, err := delivery.NewProductHandler(baseCtx, "test",
-		"test", "test", "test",
+		"test", "test", paymentsURL, testNotificationIPs, "test",
 		mockProductService, mockSessionManagerClient)
 	if err != nil {
 		return nil, fmt.Errorf("unexpected err=%w", err)
@@ -318,44 +329,93 @@ func TestGetProductList(t *testing.T) {
 	testCases := [...]TestCase{
 		{
 			name:        "test basic work",
-			queryParams: map[string]string{"count": "2", "offset": "1"},
+			queryParams: map[string]string{"count": "2", "cursor": models.NewCursor(1, 1).String()},
 			behaviorProductService: func(m *mocks.MockIProductService) {
-				m.EXPECT().GetProductsList(gomock.Any(), uint64(1), uint64(2), test.UserID).Return(
-					[]*models.ProductInFeed{{ID: 1, Title: "Title"}, {ID: 2, Title: "Title2"}}, nil)
+				m.EXPECT().GetProductsList(gomock.Any(), models.NewCursor(1, 1), uint64(2), test.UserID,
+					&models.ProductFilter{}).Return(&models.ProductFeed{
+					Products:   []*models.ProductInFeed{{ID: 1, Title: "Title"}, {ID: 2, Title: "Title2"}},
+					Facets:     &models.ProductFacets{Categories: []models.FacetCount{{ID: 1, Count: 2}}},
+					NextCursor: models.NewCursor(0, 2).String(),
+				}, nil)
 			},
-			expectedResponse: delivery.NewProductListResponse(
-				[]*models.ProductInFeed{{ID: 1, Title: "Title"}, {ID: 2, Title: "Title2"}}),
+			expectedResponse: delivery.NewProductFeedResponse(&models.ProductFeed{
+				Products:   []*models.ProductInFeed{{ID: 1, Title: "Title"}, {ID: 2, Title: "Title2"}},
+				Facets:     &models.ProductFacets{Categories: []models.FacetCount{{ID: 1, Count: 2}}},
+				NextCursor: models.NewCursor(0, 2).String(),
+			}),
+		},
+		{
+			name: "test with filter",
+			queryParams: map[string]string{
+				"count": "2", "category_id": "3", "city_id": "4",
+				"min_price": "100", "max_price": "5000", "delivery": "true", "safe_deal": "false", "premium": "1",
+			},
+			behaviorProductService: func(m *mocks.MockIProductService) {
+				m.EXPECT().GetProductsList(gomock.Any(), (*models.Cursor)(nil), uint64(2), test.UserID,
+					&models.ProductFilter{
+						CategoryID: 3, CityID: 4, MinPrice: 100, MaxPrice: 5000,
+						Delivery: true, SafeDeal: false, PremiumOnly: true,
+					}).Return(&models.ProductFeed{
+					Products: []*models.ProductInFeed{{ID: 1, Title: "Title", CityID: 4, Delivery: true}},
+					Facets: &models.ProductFacets{
+						Categories: []models.FacetCount{{ID: 3, Count: 1}},
+						Cities:     []models.FacetCount{{ID: 4, Count: 1}, {ID: 5, Count: 2}},
+					},
+				}, nil)
+			},
+			expectedResponse: delivery.NewProductFeedResponse(&models.ProductFeed{
+				Products: []*models.ProductInFeed{{ID: 1, Title: "Title", CityID: 4, Delivery: true}},
+				Facets: &models.ProductFacets{
+					Categories: []models.FacetCount{{ID: 3, Count: 1}},
+					Cities:     []models.FacetCount{{ID: 4, Count: 1}, {ID: 5, Count: 2}},
+				},
+			}),
+		},
+		{
+			name:                   "test wrong filter",
+			queryParams:            map[string]string{"count": "2", "delivery": "yes"},
+			behaviorProductService: func(m *mocks.MockIProductService) {},
+			expectedResponse: responses.NewErrResponse(statuses.StatusBadFormatRequest,
+				fmt.Sprintf("%s delivery=yes", utils.MessageErrWrongBoolParam)),
+		},
+		{
+			name:                   "test wrong cursor",
+			queryParams:            map[string]string{"count": "2", "cursor": "not_cursor"},
+			behaviorProductService: func(m *mocks.MockIProductService) {},
+			expectedResponse: responses.NewErrResponse(statuses.StatusBadFormatRequest,
+				models.ErrWrongCursor.Error()),
 		},
 		{
 			name:        "test zero work",
-			queryParams: map[string]string{"count": "0", "offset": "0"},
+			queryParams: map[string]string{"count": "0"},
 			behaviorProductService: func(m *mocks.MockIProductService) {
-				m.EXPECT().GetProductsList(gomock.Any(), uint64(0), uint64(0), test.UserID).Return(
-					[]*models.ProductInFeed{}, nil)
+				m.EXPECT().GetProductsList(gomock.Any(), (*models.Cursor)(nil), uint64(0), test.UserID,
+					&models.ProductFilter{}).Return(
+					&models.ProductFeed{Products: []*models.ProductInFeed{}, Facets: &models.ProductFacets{}}, nil)
 			},
-			expectedResponse: delivery.NewProductListResponse(
-				[]*models.ProductInFeed{}),
+			expectedResponse: delivery.NewProductFeedResponse(
+				&models.ProductFeed{Products: []*models.ProductInFeed{}, Facets: &models.ProductFacets{}}),
 		},
 		{
 			name:        "test a lot of count",
-			queryParams: map[string]string{"count": "10", "offset": "1"},
+			queryParams: map[string]string{"count": "10", "cursor": models.NewCursor(1, 1).String()},
 			behaviorProductService: func(m *mocks.MockIProductService) {
-				m.EXPECT().GetProductsList(gomock.Any(), uint64(1), uint64(10), test.UserID).Return(
-					[]*models.ProductInFeed{
-						{ID: 1, Title: "Title"},
-						{ID: 2, Title: "Title2"},
-						{ID: 3, Title: "Title2"},
-						{ID: 4, Title: "Title2"},
-						{ID: 5, Title: "Title2"},
-						{ID: 6, Title: "Title2"},
-						{ID: 7, Title: "Title2"},
-						{ID: 8, Title: "Title2"},
-						{ID: 9, Title: "Title2"},
-						{ID: 10, Title: "Title2"},
-					}, nil)
+				m.EXPECT().GetProductsList(gomock.Any(), models.NewCursor(1, 1), uint64(10), test.UserID,
+					&models.ProductFilter{}).Return(&models.ProductFeed{Products: []*models.ProductInFeed{
+					{ID: 1, Title: "Title"},
+					{ID: 2, Title: "Title2"},
+					{ID: 3, Title: "Title2"},
+					{ID: 4, Title: "Title2"},
+					{ID: 5, Title: "Title2"},
+					{ID: 6, Title: "Title2"},
+					{ID: 7, Title: "Title2"},
+					{ID: 8, Title: "Title2"},
+					{ID: 9, Title: "Title2"},
+					{ID: 10, Title: "Title2"},
+				}}, nil)
 			},
-			expectedResponse: delivery.NewProductListResponse(
-				[]*models.ProductInFeed{
+			expectedResponse: delivery.NewProductFeedResponse(
+				&models.ProductFeed{Products: []*models.ProductInFeed{
 					{ID: 1, Title: "Title"},
 					{ID: 2, Title: "Title2"},
 					{ID: 3, Title: "Title2"},
@@ -366,7 +426,7 @@ func TestGetProductList(t *testing.T) {
 					{ID: 8, Title: "Title2"},
 					{ID: 9, Title: "Title2"},
 					{ID: 10, Title: "Title2"},
-				}),
+				}}),
 		},
 	}
 
@@ -414,30 +474,30 @@ func TestGetListProductOfSaler(t *testing.T) {
 	testCases := [...]TestCase{
 		{
 			name:        "test basic work",
-			queryParams: map[string]string{"count": "2", "last_id": "1"},
+			queryParams: map[string]string{"count": "2", "cursor": models.NewCursor(1, 1).String()},
 			behaviorProductService: func(m *mocks.MockIProductService) {
-				m.EXPECT().GetProductsOfSaler(gomock.Any(), uint64(1), uint64(2), test.UserID, true).Return(
-					[]*models.ProductInFeed{{ID: 1, Title: "Title"}, {ID: 2, Title: "Title2"}}, nil)
+				m.EXPECT().GetProductsOfSaler(gomock.Any(), models.NewCursor(1, 1), uint64(2), test.UserID, true).Return(
+					&models.ProductList{Products: []*models.ProductInFeed{{ID: 1, Title: "Title"}, {ID: 2, Title: "Title2"}}}, nil)
 			},
-			expectedResponse: delivery.NewProductListResponse(
-				[]*models.ProductInFeed{{ID: 1, Title: "Title"}, {ID: 2, Title: "Title2"}}),
+			expectedResponse: delivery.NewProductPageResponse(
+				&models.ProductList{Products: []*models.ProductInFeed{{ID: 1, Title: "Title"}, {ID: 2, Title: "Title2"}}}),
 		},
 		{
 			name:        "test zero work",
-			queryParams: map[string]string{"count": "0", "last_id": "0"},
+			queryParams: map[string]string{"count": "0"},
 			behaviorProductService: func(m *mocks.MockIProductService) {
-				m.EXPECT().GetProductsOfSaler(gomock.Any(), uint64(0), uint64(0), test.UserID, true).Return(
-					[]*models.ProductInFeed{}, nil)
+				m.EXPECT().GetProductsOfSaler(gomock.Any(), (*models.Cursor)(nil), uint64(0), test.UserID, true).Return(
+					&models.ProductList{Products: []*models.ProductInFeed{}}, nil)
 			},
-			expectedResponse: delivery.NewProductListResponse(
-				[]*models.ProductInFeed{}),
+			expectedResponse: delivery.NewProductPageResponse(
+				&models.ProductList{Products: []*models.ProductInFeed{}}),
 		},
 		{
 			name:        "test a lot of count",
-			queryParams: map[string]string{"count": "10", "last_id": "1"},
+			queryParams: map[string]string{"count": "10", "cursor": models.NewCursor(1, 1).String()},
 			behaviorProductService: func(m *mocks.MockIProductService) {
-				m.EXPECT().GetProductsOfSaler(gomock.Any(), uint64(1), uint64(10), test.UserID, true).Return(
-					[]*models.ProductInFeed{
+				m.EXPECT().GetProductsOfSaler(gomock.Any(), models.NewCursor(1, 1), uint64(10), test.UserID, true).Return(
+					&models.ProductList{Products: []*models.ProductInFeed{
 						{ID: 1, Title: "Title"},
 						{ID: 2, Title: "Title2"},
 						{ID: 3, Title: "Title2"},
@@ -448,10 +508,10 @@ func TestGetListProductOfSaler(t *testing.T) {
 						{ID: 8, Title: "Title2"},
 						{ID: 9, Title: "Title2"},
 						{ID: 10, Title: "Title2"},
-					}, nil)
+					}}, nil)
 			},
-			expectedResponse: delivery.NewProductListResponse(
-				[]*models.ProductInFeed{
+			expectedResponse: delivery.NewProductPageResponse(
+				&models.ProductList{Products: []*models.ProductInFeed{
 					{ID: 1, Title: "Title"},
 					{ID: 2, Title: "Title2"},
 					{ID: 3, Title: "Title2"},
@@ -462,7 +522,7 @@ func TestGetListProductOfSaler(t *testing.T) {
 					{ID: 8, Title: "Title2"},
 					{ID: 9, Title: "Title2"},
 					{ID: 10, Title: "Title2"},
-				}),
+				}}),
 		},
 	}
 
@@ -510,30 +570,30 @@ func TestGetListProductOfAnotherSaler(t *testing.T) {
 	testCases := [...]TestCase{
 		{
 			name:        "test basic work",
-			queryParams: map[string]string{"count": "2", "offset": "1", "saler_id": "1"},
+			queryParams: map[string]string{"count": "2", "cursor": models.NewCursor(1, 1).String(), "saler_id": "1"},
 			behaviorProductService: func(m *mocks.MockIProductService) {
-				m.EXPECT().GetProductsOfSaler(gomock.Any(), uint64(1), uint64(2), test.UserID, false).Return(
-					[]*models.ProductInFeed{{ID: 1, Title: "Title"}, {ID: 2, Title: "Title2"}}, nil)
+				m.EXPECT().GetProductsOfSaler(gomock.Any(), models.NewCursor(1, 1), uint64(2), test.UserID, false).Return(
+					&models.ProductList{Products: []*models.ProductInFeed{{ID: 1, Title: "Title"}, {ID: 2, Title: "Title2"}}}, nil)
 			},
-			expectedResponse: delivery.NewProductListResponse(
-				[]*models.ProductInFeed{{ID: 1, Title: "Title"}, {ID: 2, Title: "Title2"}}),
+			expectedResponse: delivery.NewProductPageResponse(
+				&models.ProductList{Products: []*models.ProductInFeed{{ID: 1, Title: "Title"}, {ID: 2, Title: "Title2"}}}),
 		},
 		{
 			name:        "test zero work",
-			queryParams: map[string]string{"count": "0", "offset": "0", "saler_id": "1"},
+			queryParams: map[string]string{"count": "0", "saler_id": "1"},
 			behaviorProductService: func(m *mocks.MockIProductService) {
-				m.EXPECT().GetProductsOfSaler(gomock.Any(), uint64(0), uint64(0), test.UserID, false).Return(
-					[]*models.ProductInFeed{}, nil)
+				m.EXPECT().GetProductsOfSaler(gomock.Any(), (*models.Cursor)(nil), uint64(0), test.UserID, false).Return(
+					&models.ProductList{Products: []*models.ProductInFeed{}}, nil)
 			},
-			expectedResponse: delivery.NewProductListResponse(
-				[]*models.ProductInFeed{}),
+			expectedResponse: delivery.NewProductPageResponse(
+				&models.ProductList{Products: []*models.ProductInFeed{}}),
 		},
 		{
 			name:        "test a lot of count",
-			queryParams: map[string]string{"count": "10", "offset": "1", "saler_id": "1"},
+			queryParams: map[string]string{"count": "10", "cursor": models.NewCursor(1, 1).String(), "saler_id": "1"},
 			behaviorProductService: func(m *mocks.MockIProductService) {
-				m.EXPECT().GetProductsOfSaler(gomock.Any(), uint64(1), uint64(10), test.UserID, false).Return(
-					[]*models.ProductInFeed{
+				m.EXPECT().GetProductsOfSaler(gomock.Any(), models.NewCursor(1, 1), uint64(10), test.UserID, false).Return(
+					&models.ProductList{Products: []*models.ProductInFeed{
 						{ID: 1, Title: "Title"},
 						{ID: 2, Title: "Title2"},
 						{ID: 3, Title: "Title2"},
@@ -544,10 +604,10 @@ func TestGetListProductOfAnotherSaler(t *testing.T) {
 						{ID: 8, Title: "Title2"},
 						{ID: 9, Title: "Title2"},
 						{ID: 10, Title: "Title2"},
-					}, nil)
+					}}, nil)
 			},
-			expectedResponse: delivery.NewProductListResponse(
-				[]*models.ProductInFeed{
+			expectedResponse: delivery.NewProductPageResponse(
+				&models.ProductList{Products: []*models.ProductInFeed{
 					{ID: 1, Title: "Title"},
 					{ID: 2, Title: "Title2"},
 					{ID: 3, Title: "Title2"},
@@ -558,7 +618,7 @@ func TestGetListProductOfAnotherSaler(t *testing.T) {
 					{ID: 8, Title: "Title2"},
 					{ID: 9, Title: "Title2"},
 					{ID: 10, Title: "Title2"},
-				}),
+				}}),
 		},
 	}
 
@@ -1006,15 +1066,42 @@ func TestGetSearchProductFeed(t *testing.T) {
 	testCases := [...]TestCase{
 		{
 			name:        "test basic work",
-			queryParams: map[string]string{"count": "2", "offset": "0", "searched": "ноутбук"},
+			queryParams: map[string]string{"count": "2", "searched": "ноутбук"},
+			behaviorProductService: func(m *mocks.MockIProductService) {
+				m.EXPECT().GetSearchProductFeed(gomock.Any(), "ноутбук",
+					(*models.Cursor)(nil), uint64(2), test.UserID, &models.ProductFilter{}).Return(
+					&models.ProductFeed{
+						Products: []*models.ProductInFeed{{ID: 1, Title: "Title"}, {ID: 2, Title: "Title2"}},
+						Facets:   &models.ProductFacets{},
+					}, nil)
+			},
+			expectedResponse: delivery.ProductFeedResponse{
+				Status: statuses.StatusResponseSuccessful,
+				Body: &models.ProductFeed{
+					Products: []*models.ProductInFeed{{ID: 1, Title: "Title"}, {ID: 2, Title: "Title2"}},
+					Facets:   &models.ProductFacets{},
+				},
+			},
+		},
+		{
+			name: "test with filter",
+			queryParams: map[string]string{
+				"count": "2", "searched": "ноутбук", "city_id": "4", "max_price": "5000",
+			},
 			behaviorProductService: func(m *mocks.MockIProductService) {
 				m.EXPECT().GetSearchProductFeed(gomock.Any(), "ноутбук",
-					uint64(0), uint64(2), test.UserID).Return(
-					[]*models.ProductInFeed{{ID: 1, Title: "Title"}, {ID: 2, Title: "Title2"}}, nil)
+					(*models.Cursor)(nil), uint64(2), test.UserID, &models.ProductFilter{CityID: 4, MaxPrice: 5000}).Return(
+					&models.ProductFeed{
+						Products: []*models.ProductInFeed{{ID: 1, Title: "Title", CityID: 4}},
+						Facets:   &models.ProductFacets{Cities: []models.FacetCount{{ID: 4, Count: 1}}},
+					}, nil)
 			},
-			expectedResponse: delivery.ProductListResponse{
+			expectedResponse: delivery.ProductFeedResponse{
 				Status: statuses.StatusResponseSuccessful,
-				Body:   []*models.ProductInFeed{{ID: 1, Title: "Title"}, {ID: 2, Title: "Title2"}},
+				Body: &models.ProductFeed{
+					Products: []*models.ProductInFeed{{ID: 1, Title: "Title", CityID: 4}},
+					Facets:   &models.ProductFacets{Cities: []models.FacetCount{{ID: 4, Count: 1}}},
+				},
 			},
 		},
 	}
@@ -11,14 +11,17 @@ import (
 )
 
 const (
-	ResponseSuccessfulUpdateCountOrder  = "Успешно изменено количество заказа"
-	ResponseSuccessfulUpdateStatusOrder = "Успешно изменен статус заказа"
-	ResponseSuccessfulBuyFullBasket     = "Успешная покупка всего из корзины"
-	ResponseSuccessfulCloseProduct      = "Объявление успешно закрыто"
-	ResponseSuccessfulDeleteProduct     = "Объявление успешно удалено"
-	ResponseSuccessfulActivateProduct   = "Объявление успешно активировано"
-	ResponseSuccessfulDeleteComment     = "Комментарий успешно удалено"
-	ResponseSuccessfulUpdateComment     = "Комментарий успешно изменен"
+	ResponseSuccessfulUpdateCountOrder   = "Успешно изменено количество заказа"
+	ResponseSuccessfulUpdateStatusOrder  = "Успешно изменен статус заказа"
+	ResponseSuccessfulCloseProduct       = "Объявление успешно закрыто"
+	ResponseSuccessfulDeleteProduct      = "Объявление успешно удалено"
+	ResponseSuccessfulActivateProduct    = "Объявление успешно активировано"
+	ResponseSuccessfulDeleteComment      = "Комментарий успешно удалено"
+	ResponseSuccessfulUpdateComment      = "Комментарий успешно изменен"
+	ResponseSuccessfulUpdateSavedSearch  = "Сохраненный поиск успешно изменен"
+	ResponseSuccessfulDeleteSavedSearch  = "Сохраненный поиск успешно удален"
+	ResponseSuccessfulReadNotifications  = "Уведомления прочитаны"
+	ResponseSuccessfulHandleNotification = "Уведомление обработано"
 )
 
 //easyjson:json
@@ -48,13 +51,65 @@ func NewProductListResponse(body []*models.ProductInFeed) *ProductListResponse {
 }
 
 //easyjson:json
-type CommentListResponse struct {
-	Status int                     `json:"status"`
-	Body   []*models.CommentInFeed `json:"body"`
+type ProductFeedResponse struct {
+	Status int                 `json:"status"`
+	Body   *models.ProductFeed `json:"body"`
+}
+
+func NewProductFeedResponse(body *models.ProductFeed) *ProductFeedResponse {
+	return &ProductFeedResponse{
+		Status: statuses.StatusResponseSuccessful,
+		Body:   body,
+	}
 }
 
-func NewCommentListResponse(body []*models.CommentInFeed) *CommentListResponse {
-	return &CommentListResponse{
+//easyjson:json
+type ProductPageResponse struct {
+	Status int                 `json:"status"`
+	Body   *models.ProductList `json:"body"`
+}
+
+func NewProductPageResponse(body *models.ProductList) *ProductPageResponse {
+	return &ProductPageResponse{
+		Status: statuses.StatusResponseSuccessful,
+		Body:   body,
+	}
+}
+
+//easyjson:json
+type CommentPageResponse struct {
+	Status int                 `json:"status"`
+	Body   *models.CommentList `json:"body"`
+}
+
+func NewCommentPageResponse(body *models.CommentList) *CommentPageResponse {
+	return &CommentPageResponse{
+		Status: statuses.StatusResponseSuccessful,
+		Body:   body,
+	}
+}
+
+//easyjson:json
+type SavedSearchListResponse struct {
+	Status int                   `json:"status"`
+	Body   []*models.SavedSearch `json:"body"`
+}
+
+func NewSavedSearchListResponse(body []*models.SavedSearch) *SavedSearchListResponse {
+	return &SavedSearchListResponse{
+		Status: statuses.StatusResponseSuccessful,
+		Body:   body,
+	}
+}
+
+//easyjson:json
+type NotificationListResponse struct {
+	Status int                    `json:"status"`
+	Body   []*models.Notification `json:"body"`
+}
+
+func NewNotificationListResponse(body []*models.Notification) *NotificationListResponse {
+	return &NotificationListResponse{
 		Status: statuses.StatusResponseSuccessful,
 		Body:   body,
 	}
@@ -107,6 +162,8 @@ type ConfirmationPayment struct {
 
 //easyjson:json
 type ResponsePostPaymentAPIYoomany struct {
+	ID           string              `json:"id"`
+	Status       string              `json:"status"`
 	Confirmation ConfirmationPayment `json:"confirmation"`
 }
 
@@ -124,15 +181,84 @@ func NewOrderNotInBasketListResponse(body []*models.OrderNotInBasket) *OrderNotI
 	return &OrderNotInBasketListResponse{Status: statuses.StatusResponseSuccessful, Body: body}
 }
 
+//easyjson:json
+type OrderStatusHistoryResponse struct {
+	Status int                          `json:"status"`
+	Body   []*models.OrderStatusHistory `json:"body"`
+}
+
+func NewOrderStatusHistoryResponse(body []*models.OrderStatusHistory) *OrderStatusHistoryResponse {
+	return &OrderStatusHistoryResponse{Status: statuses.StatusResponseSuccessful, Body: body}
+}
+
+// metadataPaymentAPIYoomany yoomany returns all values of metadata as strings.
+//
+//easyjson:json
+type metadataPaymentAPIYoomany struct {
+	UserID     string `json:"user_id"`
+	ProductID  string `json:"product_id"`
+	PeriodCode string `json:"period_code"`
+	PaymentID  string `json:"payment_id"`
+}
+
+// parseUintMetadata returns 0 for missing value, payments of premium haven't payment_id
+// and payments of basket haven't product_id and period_code.
+func parseUintMetadata(value string) (uint64, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	result, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return result, nil
+}
+
+func (m *metadataPaymentAPIYoomany) toMetadataPayment() (MetadataPayment, error) {
+	var metadata MetadataPayment
+
+	var err error
+
+	metadata.UserID, err = parseUintMetadata(m.UserID)
+	if err != nil {
+		return MetadataPayment{}, err
+	}
+
+	metadata.ProductID, err = parseUintMetadata(m.ProductID)
+	if err != nil {
+		return MetadataPayment{}, err
+	}
+
+	metadata.PeriodCode, err = parseUintMetadata(m.PeriodCode)
+	if err != nil {
+		return MetadataPayment{}, err
+	}
+
+	metadata.PaymentID, err = parseUintMetadata(m.PaymentID)
+	if err != nil {
+		return MetadataPayment{}, err
+	}
+
+	return metadata, nil
+}
+
+func newMetadataPaymentAPIYoomany(metadata MetadataPayment) metadataPaymentAPIYoomany {
+	return metadataPaymentAPIYoomany{
+		UserID:     strconv.FormatUint(metadata.UserID, 10),
+		ProductID:  strconv.FormatUint(metadata.ProductID, 10),
+		PeriodCode: strconv.FormatUint(metadata.PeriodCode, 10),
+		PaymentID:  strconv.FormatUint(metadata.PaymentID, 10),
+	}
+}
+
 //easyjson:json
 type responseGetPaymentsItemAPIYoomany struct {
-	Status   string        `json:"status"`
-	Amount   AmountPayment `json:"amount"`
-	Metadata struct {
-		UserID     string `json:"user_id"`
-		ProductID  string `json:"product_id"`
-		PeriodCode string `json:"period_code"`
-	} `json:"metadata"`
+	ID       string                    `json:"id"`
+	Status   string                    `json:"status"`
+	Amount   AmountPayment             `json:"amount"`
+	Metadata metadataPaymentAPIYoomany `json:"metadata"`
 }
 
 //easyjson:json
@@ -143,11 +269,33 @@ type responseGetPaymentsAPIYoomany struct {
 }
 
 type ResponseGetPaymentsItemAPIYoomany struct {
+	ID       string          `json:"id"`
 	Status   string          `json:"status"`
 	Amount   AmountPayment   `json:"amount"`
 	Metadata MetadataPayment `json:"metadata"`
 }
 
+func (r *ResponseGetPaymentsItemAPIYoomany) UnmarshalJSON(body []byte) error {
+	var item responseGetPaymentsItemAPIYoomany
+
+	err := json.Unmarshal(body, &item)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	metadata, err := item.Metadata.toMetadataPayment()
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	r.ID = item.ID
+	r.Status = item.Status
+	r.Amount = item.Amount
+	r.Metadata = metadata
+
+	return nil
+}
+
 type ResponseGetPaymentsAPIYoomany struct {
 	Type       string                              `json:"type"`
 	NextCursor string                              `json:"next_cursor"`
@@ -166,26 +314,16 @@ func (r *ResponseGetPaymentsAPIYoomany) UnmarshalJSON(body []byte) error {
 	r.NextCursor = responseGetPaymentsAPIYoomany.NextCursor
 
 	for _, item := range responseGetPaymentsAPIYoomany.Items {
-		userID, err := strconv.ParseUint(item.Metadata.UserID, 10, 64)
-		if err != nil {
-			return fmt.Errorf(myerrors.ErrTemplate, err)
-		}
-
-		productID, err := strconv.ParseUint(item.Metadata.ProductID, 10, 64)
-		if err != nil {
-			return fmt.Errorf(myerrors.ErrTemplate, err)
-		}
-
-		periodCode, err := strconv.ParseUint(item.Metadata.PeriodCode, 10, 64)
+		metadata, err := item.Metadata.toMetadataPayment()
 		if err != nil {
 			return fmt.Errorf(myerrors.ErrTemplate, err)
 		}
 
 		r.Items = append(r.Items, ResponseGetPaymentsItemAPIYoomany{
-			Status: item.Status,
-			Amount: item.Amount, Metadata: MetadataPayment{
-				UserID: userID, ProductID: productID, PeriodCode: periodCode,
-			},
+			ID:       item.ID,
+			Status:   item.Status,
+			Amount:   item.Amount,
+			Metadata: metadata,
 		})
 	}
 
@@ -199,20 +337,12 @@ func (r *ResponseGetPaymentsAPIYoomany) MarshalJSON() ([]byte, error) {
 	responseGetPaymentsAPIYoomany.NextCursor = r.NextCursor
 
 	for _, item := range r.Items {
-		userID := strconv.FormatUint(item.Metadata.UserID, 10)
-		productID := strconv.FormatUint(item.Metadata.ProductID, 10)
-		periodCode := strconv.FormatUint(item.Metadata.PeriodCode, 10)
-
 		responseGetPaymentsAPIYoomany.Items = append(responseGetPaymentsAPIYoomany.Items,
 			responseGetPaymentsItemAPIYoomany{
-				Status: item.Status,
-				Amount: item.Amount, Metadata: struct {
-					UserID     string `json:"user_id"`
-					ProductID  string `json:"product_id"`
-					PeriodCode string `json:"period_code"`
-				}{
-					UserID: userID, ProductID: productID, PeriodCode: periodCode,
-				},
+				ID:       item.ID,
+				Status:   item.Status,
+				Amount:   item.Amount,
+				Metadata: newMetadataPaymentAPIYoomany(item.Metadata),
 			})
 	}
 
@@ -0,0 +1,325 @@
+package delivery
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	productusecases "github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/usecases"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/server/delivery"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+)
+
+const periodCheckSavedSearches = time.Minute * 5
+
+var _ ISavedSearchService = (*productusecases.SavedSearchService)(nil)
+
+type ISavedSearchService interface {
+	AddSavedSearch(ctx context.Context, r io.Reader, userID uint64) (uint64, error)
+	GetSavedSearches(ctx context.Context, userID uint64) ([]*models.SavedSearch, error)
+	UpdateSavedSearch(ctx context.Context, r io.Reader, userID uint64, savedSearchID uint64) error
+	DeleteSavedSearch(ctx context.Context, savedSearchID uint64, userID uint64) error
+	CheckSavedSearches(ctx context.Context) (uint64, error)
+	GetNotifications(ctx context.Context, userID uint64) ([]*models.Notification, error)
+	ReadNotifications(ctx context.Context, userID uint64) error
+}
+
+// waitSavedSearches periodically creates notifications about new products
+// which match saved searches of users.
+func (p *ProductHandler) waitSavedSearches(ctx context.Context,
+	chClose <-chan struct{}, periodCheck time.Duration,
+) {
+	logger := p.logger.LogReqID(ctx)
+
+	go func() {
+		ticker := time.NewTicker(periodCheck)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-chClose:
+				logger.Infof("успешно отключили проверку сохраненных поисков")
+
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				countNotifications, err := p.service.CheckSavedSearches(ctx)
+				if err != nil {
+					logger.Errorf("error check saved searches: %+v", err)
+
+					continue
+				}
+
+				logger.Infof("checked saved searches, new notifications: %d", countNotifications)
+			}
+		}
+	}()
+}
+
+// AddSavedSearchHandler godoc
+//
+//	@Summary    add saved search
+//	@Description  save query and filters of search feed for user from cookies\jwt.
+//	@Description  New products matching saved search appear in /notification/get_list
+//	@Tags saved_search
+//
+//	@Accept      json
+//	@Produce    json
+//	@Param      saved_search  body models.PreSavedSearch true  "saved search data for adding"
+//	@Success    200  {object} responses.ResponseID
+//	@Failure    405  {string} string
+//	@Failure    500  {string} string
+//	@Failure    222  {object} responses.ErrorResponse "Error". Это Http ответ 200, внутри body статус может быть badContent(4400), badFormat(4000)//nolint:lll
+//	@Router      /saved_search/add [post]
+func (p *ProductHandler) AddSavedSearchHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, `Method not allowed`, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	ctx := r.Context()
+	logger := p.logger.LogReqID(ctx)
+
+	userID, err := delivery.GetUserID(ctx, r, p.sessionManagerClient)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	savedSearchID, err := p.service.AddSavedSearch(ctx, r.Body, userID)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	responses.SendResponse(w, logger, responses.NewResponseIDRedirect(savedSearchID))
+	logger.Infof("in AddSavedSearchHandler: added saved search id= %+v", savedSearchID)
+}
+
+// GetSavedSearchesHandler godoc
+//
+//	@Summary    get saved searches
+//	@Description  get saved searches of user from cookies\jwt.
+//	@Tags saved_search
+//	@Accept      json
+//	@Produce    json
+//	@Success    200  {object} SavedSearchListResponse
+//	@Failure    405  {string} string
+//	@Failure    500  {string} string
+//	@Failure    222  {object} responses.ErrorResponse "Error". Внутри body статус может быть badFormat(4000)
+//	@Router      /saved_search/get_list [get]
+func (p *ProductHandler) GetSavedSearchesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, `Method not allowed`, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	ctx := r.Context()
+	logger := p.logger.LogReqID(ctx)
+
+	userID, err := delivery.GetUserID(ctx, r, p.sessionManagerClient)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	savedSearches, err := p.service.GetSavedSearches(ctx, userID)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	responses.SendResponse(w, logger, NewSavedSearchListResponse(savedSearches))
+	logger.Infof("in GetSavedSearchesHandler: get saved searches: %+v\n", savedSearches)
+}
+
+// UpdateSavedSearchHandler godoc
+//
+//	@Summary    update saved search
+//	@Description  fully replace query and filters of saved search by id
+//	@Tags saved_search
+//	@Accept      json
+//	@Produce    json
+//	@Param      saved_search_id  query uint64 true  "saved search id"
+//	@Param      saved_search  body models.PreSavedSearch true  "new saved search data"
+//	@Success    200  {object} responses.ResponseSuccessful
+//	@Failure    405  {string} string
+//	@Failure    500  {string} string
+//	@Failure    222  {object} responses.ErrorResponse "Error". Это Http ответ 200, внутри body статус может быть badContent(4400), badFormat(4000)//nolint:lll
+//	@Router      /saved_search/update [put]
+func (p *ProductHandler) UpdateSavedSearchHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, `Method not allowed`, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	ctx := r.Context()
+	logger := p.logger.LogReqID(ctx)
+
+	userID, err := delivery.GetUserID(ctx, r, p.sessionManagerClient)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	savedSearchID, err := utils.ParseUint64FromRequest(r, "saved_search_id")
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	err = p.service.UpdateSavedSearch(ctx, r.Body, userID, savedSearchID)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	responses.SendResponse(w, logger,
+		responses.NewResponseSuccessful(ResponseSuccessfulUpdateSavedSearch))
+	logger.Infof("in UpdateSavedSearchHandler: updated saved search id=%d", savedSearchID)
+}
+
+// DeleteSavedSearchHandler godoc
+//
+//	@Summary     delete saved search
+//	@Description  delete saved search of user from cookies\jwt with all its notifications
+//	@Tags saved_search
+//	@Accept      json
+//	@Produce    json
+//	@Param      saved_search_id  query uint64 true  "saved search id"
+//	@Success    200  {object} responses.ResponseSuccessful
+//	@Failure    405  {string} string
+//	@Failure    500  {string} string
+//	@Failure    222  {object} responses.ErrorResponse "Error". Это Http ответ 200, внутри body статус может быть badFormat(4000)//nolint:lll
+//	@Router      /saved_search/delete [delete]
+func (p *ProductHandler) DeleteSavedSearchHandler(w http.ResponseWriter, r *http.Request) { //nolint:dupl
+	if r.Method != http.MethodDelete {
+		http.Error(w, `Method not allowed`, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	ctx := r.Context()
+	logger := p.logger.LogReqID(ctx)
+
+	userID, err := delivery.GetUserID(ctx, r, p.sessionManagerClient)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	savedSearchID, err := utils.ParseUint64FromRequest(r, "saved_search_id")
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	err = p.service.DeleteSavedSearch(ctx, savedSearchID, userID)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	responses.SendResponse(w, logger,
+		responses.NewResponseSuccessful(ResponseSuccessfulDeleteSavedSearch))
+	logger.Infof("in DeleteSavedSearchHandler: delete saved search id=%d", savedSearchID)
+}
+
+// GetNotificationsHandler godoc
+//
+//	@Summary    get notifications
+//	@Description  get notifications about new products matching saved searches of user from cookies\jwt.
+//	@Description  The newest notifications are the first
+//	@Tags saved_search
+//	@Accept      json
+//	@Produce    json
+//	@Success    200  {object} NotificationListResponse
+//	@Failure    405  {string} string
+//	@Failure    500  {string} string
+//	@Failure    222  {object} responses.ErrorResponse "Error". Внутри body статус может быть badFormat(4000)
+//	@Router      /notification/get_list [get]
+func (p *ProductHandler) GetNotificationsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, `Method not allowed`, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	ctx := r.Context()
+	logger := p.logger.LogReqID(ctx)
+
+	userID, err := delivery.GetUserID(ctx, r, p.sessionManagerClient)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	notifications, err := p.service.GetNotifications(ctx, userID)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	responses.SendResponse(w, logger, NewNotificationListResponse(notifications))
+	logger.Infof("in GetNotificationsHandler: get notifications: %+v\n", notifications)
+}
+
+// ReadNotificationsHandler godoc
+//
+//	@Summary    read notifications
+//	@Description  mark all notifications of user from cookies\jwt as read
+//	@Tags saved_search
+//	@Accept      json
+//	@Produce    json
+//	@Success    200  {object} responses.ResponseSuccessful
+//	@Failure    405  {string} string
+//	@Failure    500  {string} string
+//	@Failure    222  {object} responses.ErrorResponse "Error". Внутри body статус может быть badFormat(4000)
+//	@Router      /notification/read [patch]
+func (p *ProductHandler) ReadNotificationsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		http.Error(w, `Method not allowed`, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	ctx := r.Context()
+	logger := p.logger.LogReqID(ctx)
+
+	userID, err := delivery.GetUserID(ctx, r, p.sessionManagerClient)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	err = p.service.ReadNotifications(ctx, userID)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	responses.SendResponse(w, logger,
+		responses.NewResponseSuccessful(ResponseSuccessfulReadNotifications))
+	logger.Infof("in ReadNotificationsHandler: read notifications of user id=%d", userID)
+}
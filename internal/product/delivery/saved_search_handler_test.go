@@ -0,0 +1,224 @@
+package delivery_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/delivery"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/mocks"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses/statuses"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils/test"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAddSavedSearch(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	type TestCase struct {
+		name                   string
+		behaviorProductService func(m *mocks.MockIProductService)
+		request                *http.Request
+		expectedResponse       any
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			request: httptest.NewRequest(http.MethodPost, "/api/v1/saved_search/add", strings.NewReader(
+				`{"query": "iphone", "filter": {"city_id": 1}}`)),
+			behaviorProductService: func(m *mocks.MockIProductService) {
+				m.EXPECT().AddSavedSearch(gomock.Any(), io.NopCloser(strings.NewReader(
+					`{"query": "iphone", "filter": {"city_id": 1}}`)), test.UserID).Return(uint64(1), nil)
+			},
+			expectedResponse: responses.ResponseID{
+				Status: statuses.StatusRedirectAfterSuccessful,
+				Body:   responses.ResponseBodyID{ID: 1},
+			},
+		},
+		{
+			name: "test error in internal",
+			request: httptest.NewRequest(http.MethodPost, "/api/v1/saved_search/add", strings.NewReader(
+				`{"query": "iphone"}`)),
+			behaviorProductService: func(m *mocks.MockIProductService) {
+				m.EXPECT().AddSavedSearch(gomock.Any(), io.NopCloser(strings.NewReader(
+					`{"query": "iphone"}`)), test.UserID).Return(uint64(0),
+					myerrors.NewErrorInternal("Test Error Internal"))
+			},
+			expectedResponse: responses.NewErrResponse(statuses.StatusInternalServer, responses.ErrInternalServer),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			productHandler, err := NewProductHandler(ctrl, testCase.behaviorProductService)
+			if err != nil {
+				t.Fatalf("Failed create productHandler %+v", err)
+			}
+
+			w := httptest.NewRecorder()
+
+			testCase.request.AddCookie(&test.Cookie)
+			productHandler.AddSavedSearchHandler(w, testCase.request)
+
+			err = test.CompareHTTPTestResult(w, testCase.expectedResponse)
+			if err != nil {
+				t.Fatalf("Failed CompareHTTPTestResult %+v", err)
+			}
+		})
+	}
+}
+
+func TestDeleteSavedSearch(t *testing.T) { //nolint:dupl
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	type TestCase struct {
+		name                   string
+		queryID                string
+		behaviorProductService func(m *mocks.MockIProductService)
+		expectedResponse       any
+	}
+
+	testCases := [...]TestCase{
+		{
+			name:    "test basic work",
+			queryID: "1",
+			behaviorProductService: func(m *mocks.MockIProductService) {
+				m.EXPECT().DeleteSavedSearch(gomock.Any(), uint64(1), test.UserID).Return(nil)
+			},
+			expectedResponse: responses.ResponseSuccessful{
+				Status: statuses.StatusResponseSuccessful,
+				Body:   responses.ResponseBody{Message: delivery.ResponseSuccessfulDeleteSavedSearch},
+			},
+		},
+		{
+			name:    "test error uncorrected query param",
+			queryID: "wrong type",
+			behaviorProductService: func(m *mocks.MockIProductService) {
+				m.EXPECT()
+			},
+			expectedResponse: responses.NewErrResponse(statuses.StatusBadFormatRequest,
+				fmt.Sprintf("%s saved_search_id=wrong type", utils.MessageErrWrongNumberParam)),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			productHandler, err := NewProductHandler(ctrl, testCase.behaviorProductService)
+			if err != nil {
+				t.Fatalf("Failed create productHandler %+v", err)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/saved_search/delete", nil)
+			utils.AddQueryParamsToRequest(req, map[string]string{"saved_search_id": testCase.queryID})
+			req.AddCookie(&test.Cookie)
+			productHandler.DeleteSavedSearchHandler(recorder, req)
+
+			err = test.CompareHTTPTestResult(recorder, testCase.expectedResponse)
+			if err != nil {
+				t.Fatalf("Failed CompareHTTPTestResult %+v", err)
+			}
+		})
+	}
+}
+
+func TestGetNotifications(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	testTime := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	type TestCase struct {
+		name                   string
+		behaviorProductService func(m *mocks.MockIProductService)
+		expectedResponse       any
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			behaviorProductService: func(m *mocks.MockIProductService) {
+				m.EXPECT().GetNotifications(gomock.Any(), test.UserID).Return([]*models.Notification{
+					{
+						ID: 1, SavedSearchID: 1, Query: "iphone", ProductID: 2,
+						Title: "iphone 13", Price: 1000, IsRead: false, CreatedAt: testTime,
+					},
+				}, nil)
+			},
+			expectedResponse: delivery.NotificationListResponse{
+				Status: statuses.StatusResponseSuccessful,
+				Body: []*models.Notification{
+					{
+						ID: 1, SavedSearchID: 1, Query: "iphone", ProductID: 2,
+						Title: "iphone 13", Price: 1000, IsRead: false, CreatedAt: testTime,
+					},
+				},
+			},
+		},
+		{
+			name: "test error in internal",
+			behaviorProductService: func(m *mocks.MockIProductService) {
+				m.EXPECT().GetNotifications(gomock.Any(), test.UserID).Return(nil,
+					myerrors.NewErrorInternal("Test Error Internal"))
+			},
+			expectedResponse: responses.NewErrResponse(statuses.StatusInternalServer, responses.ErrInternalServer),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			productHandler, err := NewProductHandler(ctrl, testCase.behaviorProductService)
+			if err != nil {
+				t.Fatalf("Failed create productHandler %+v", err)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/notification/get_list", nil)
+			req.AddCookie(&test.Cookie)
+			productHandler.GetNotificationsHandler(recorder, req)
+
+			err = test.CompareHTTPTestResult(recorder, testCase.expectedResponse)
+			if err != nil {
+				t.Fatalf("Failed CompareHTTPTestResult %+v", err)
+			}
+		})
+	}
+}
@@ -56,11 +56,12 @@ func (mr *MockIBasketServiceMockRecorder) AddOrder(ctx, r, userID any) *gomock.C
 }
 
 // BuyFullBasket mocks base method.
-func (m *MockIBasketService) BuyFullBasket(ctx context.Context, userID uint64) error {
+func (m *MockIBasketService) BuyFullBasket(ctx context.Context, userID uint64) (*models.BasketPayment, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "BuyFullBasket", ctx, userID)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(*models.BasketPayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // BuyFullBasket indicates an expected call of BuyFullBasket.
@@ -69,6 +70,49 @@ func (mr *MockIBasketServiceMockRecorder) BuyFullBasket(ctx, userID any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyFullBasket", reflect.TypeOf((*MockIBasketService)(nil).BuyFullBasket), ctx, userID)
 }
 
+// CancelBasketPayment mocks base method.
+func (m *MockIBasketService) CancelBasketPayment(ctx context.Context, paymentID uint64, status uint8, event *models.PaymentEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelBasketPayment", ctx, paymentID, status, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelBasketPayment indicates an expected call of CancelBasketPayment.
+func (mr *MockIBasketServiceMockRecorder) CancelBasketPayment(ctx, paymentID, status, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelBasketPayment", reflect.TypeOf((*MockIBasketService)(nil).CancelBasketPayment), ctx, paymentID, status, event)
+}
+
+// CancelExpiredReservations mocks base method.
+func (m *MockIBasketService) CancelExpiredReservations(ctx context.Context) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelExpiredReservations", ctx)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelExpiredReservations indicates an expected call of CancelExpiredReservations.
+func (mr *MockIBasketServiceMockRecorder) CancelExpiredReservations(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelExpiredReservations", reflect.TypeOf((*MockIBasketService)(nil).CancelExpiredReservations), ctx)
+}
+
+// ConfirmBasketPayment mocks base method.
+func (m *MockIBasketService) ConfirmBasketPayment(ctx context.Context, paymentID uint64, status uint8, event *models.PaymentEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmBasketPayment", ctx, paymentID, status, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmBasketPayment indicates an expected call of ConfirmBasketPayment.
+func (mr *MockIBasketServiceMockRecorder) ConfirmBasketPayment(ctx, paymentID, status, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmBasketPayment", reflect.TypeOf((*MockIBasketService)(nil).ConfirmBasketPayment), ctx, paymentID, status, event)
+}
+
 // DeleteOrder mocks base method.
 func (m *MockIBasketService) DeleteOrder(ctx context.Context, orderID, ownerID uint64) error {
 	m.ctrl.T.Helper()
@@ -83,6 +127,36 @@ func (mr *MockIBasketServiceMockRecorder) DeleteOrder(ctx, orderID, ownerID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrder", reflect.TypeOf((*MockIBasketService)(nil).DeleteOrder), ctx, orderID, ownerID)
 }
 
+// GetHeldPayments mocks base method.
+func (m *MockIBasketService) GetHeldPayments(ctx context.Context) ([]*models.HeldPayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeldPayments", ctx)
+	ret0, _ := ret[0].([]*models.HeldPayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeldPayments indicates an expected call of GetHeldPayments.
+func (mr *MockIBasketServiceMockRecorder) GetHeldPayments(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeldPayments", reflect.TypeOf((*MockIBasketService)(nil).GetHeldPayments), ctx)
+}
+
+// GetOrderStatusHistory mocks base method.
+func (m *MockIBasketService) GetOrderStatusHistory(ctx context.Context, orderID, userID uint64) ([]*models.OrderStatusHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderStatusHistory", ctx, orderID, userID)
+	ret0, _ := ret[0].([]*models.OrderStatusHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderStatusHistory indicates an expected call of GetOrderStatusHistory.
+func (mr *MockIBasketServiceMockRecorder) GetOrderStatusHistory(ctx, orderID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderStatusHistory", reflect.TypeOf((*MockIBasketService)(nil).GetOrderStatusHistory), ctx, orderID, userID)
+}
+
 // GetOrdersByUserID mocks base method.
 func (m *MockIBasketService) GetOrdersByUserID(ctx context.Context, userID uint64) ([]*models.OrderInBasket, error) {
 	m.ctrl.T.Helper()
@@ -99,10 +173,10 @@ func (mr *MockIBasketServiceMockRecorder) GetOrdersByUserID(ctx, userID any) *go
 }
 
 // GetOrdersNotInBasketByUserID mocks base method.
-func (m *MockIBasketService) GetOrdersNotInBasketByUserID(ctx context.Context, userID uint64) ([]*models.OrderInBasket, error) {
+func (m *MockIBasketService) GetOrdersNotInBasketByUserID(ctx context.Context, userID uint64) ([]*models.OrderNotInBasket, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetOrdersNotInBasketByUserID", ctx, userID)
-	ret0, _ := ret[0].([]*models.OrderInBasket)
+	ret0, _ := ret[0].([]*models.OrderNotInBasket)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -114,10 +188,10 @@ func (mr *MockIBasketServiceMockRecorder) GetOrdersNotInBasketByUserID(ctx, user
 }
 
 // GetOrdersSoldByUserID mocks base method.
-func (m *MockIBasketService) GetOrdersSoldByUserID(ctx context.Context, userID uint64) ([]*models.OrderInBasket, error) {
+func (m *MockIBasketService) GetOrdersSoldByUserID(ctx context.Context, userID uint64) ([]*models.OrderNotInBasket, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetOrdersSoldByUserID", ctx, userID)
-	ret0, _ := ret[0].([]*models.OrderInBasket)
+	ret0, _ := ret[0].([]*models.OrderNotInBasket)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -128,6 +202,20 @@ func (mr *MockIBasketServiceMockRecorder) GetOrdersSoldByUserID(ctx, userID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersSoldByUserID", reflect.TypeOf((*MockIBasketService)(nil).GetOrdersSoldByUserID), ctx, userID)
 }
 
+// SetPaymentYookassaID mocks base method.
+func (m *MockIBasketService) SetPaymentYookassaID(ctx context.Context, paymentID uint64, yookassaID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPaymentYookassaID", ctx, paymentID, yookassaID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPaymentYookassaID indicates an expected call of SetPaymentYookassaID.
+func (mr *MockIBasketServiceMockRecorder) SetPaymentYookassaID(ctx, paymentID, yookassaID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPaymentYookassaID", reflect.TypeOf((*MockIBasketService)(nil).SetPaymentYookassaID), ctx, paymentID, yookassaID)
+}
+
 // UpdateOrderCount mocks base method.
 func (m *MockIBasketService) UpdateOrderCount(ctx context.Context, r io.Reader, userID uint64) error {
 	m.ctrl.T.Helper()
@@ -155,3 +243,17 @@ func (mr *MockIBasketServiceMockRecorder) UpdateOrderStatus(ctx, r, userID any)
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*MockIBasketService)(nil).UpdateOrderStatus), ctx, r, userID)
 }
+
+// UpdatePaymentStatus mocks base method.
+func (m *MockIBasketService) UpdatePaymentStatus(ctx context.Context, paymentID uint64, status uint8, event *models.PaymentEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentStatus", ctx, paymentID, status, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePaymentStatus indicates an expected call of UpdatePaymentStatus.
+func (mr *MockIBasketServiceMockRecorder) UpdatePaymentStatus(ctx, paymentID, status, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentStatus", reflect.TypeOf((*MockIBasketService)(nil).UpdatePaymentStatus), ctx, paymentID, status, event)
+}
@@ -11,6 +11,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
 	gomock "go.uber.org/mock/gomock"
@@ -55,17 +56,61 @@ func (mr *MockIBasketStorageMockRecorder) AddOrderInBasket(ctx, userID, productI
 }
 
 // BuyFullBasket mocks base method.
-func (m *MockIBasketStorage) BuyFullBasket(ctx context.Context, userID uint64) error {
+func (m *MockIBasketStorage) BuyFullBasket(ctx context.Context, userID uint64, reservedUntil time.Time, idempotencyKey string) (*models.BasketPayment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BuyFullBasket", ctx, userID)
+	ret := m.ctrl.Call(m, "BuyFullBasket", ctx, userID, reservedUntil, idempotencyKey)
+	ret0, _ := ret[0].(*models.BasketPayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BuyFullBasket indicates an expected call of BuyFullBasket.
+func (mr *MockIBasketStorageMockRecorder) BuyFullBasket(ctx, userID, reservedUntil, idempotencyKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyFullBasket", reflect.TypeOf((*MockIBasketStorage)(nil).BuyFullBasket), ctx, userID, reservedUntil, idempotencyKey)
+}
+
+// CancelBasketPayment mocks base method.
+func (m *MockIBasketStorage) CancelBasketPayment(ctx context.Context, paymentID uint64, status uint8, event *models.PaymentEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelBasketPayment", ctx, paymentID, status, event)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// BuyFullBasket indicates an expected call of BuyFullBasket.
-func (mr *MockIBasketStorageMockRecorder) BuyFullBasket(ctx, userID any) *gomock.Call {
+// CancelBasketPayment indicates an expected call of CancelBasketPayment.
+func (mr *MockIBasketStorageMockRecorder) CancelBasketPayment(ctx, paymentID, status, event any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyFullBasket", reflect.TypeOf((*MockIBasketStorage)(nil).BuyFullBasket), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelBasketPayment", reflect.TypeOf((*MockIBasketStorage)(nil).CancelBasketPayment), ctx, paymentID, status, event)
+}
+
+// CancelExpiredReservations mocks base method.
+func (m *MockIBasketStorage) CancelExpiredReservations(ctx context.Context, now, deadlinePendingPayment time.Time) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelExpiredReservations", ctx, now, deadlinePendingPayment)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelExpiredReservations indicates an expected call of CancelExpiredReservations.
+func (mr *MockIBasketStorageMockRecorder) CancelExpiredReservations(ctx, now, deadlinePendingPayment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelExpiredReservations", reflect.TypeOf((*MockIBasketStorage)(nil).CancelExpiredReservations), ctx, now, deadlinePendingPayment)
+}
+
+// ConfirmBasketPayment mocks base method.
+func (m *MockIBasketStorage) ConfirmBasketPayment(ctx context.Context, paymentID uint64, status uint8, event *models.PaymentEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmBasketPayment", ctx, paymentID, status, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmBasketPayment indicates an expected call of ConfirmBasketPayment.
+func (mr *MockIBasketStorageMockRecorder) ConfirmBasketPayment(ctx, paymentID, status, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmBasketPayment", reflect.TypeOf((*MockIBasketStorage)(nil).ConfirmBasketPayment), ctx, paymentID, status, event)
 }
 
 // DeleteOrder mocks base method.
@@ -82,6 +127,51 @@ func (mr *MockIBasketStorageMockRecorder) DeleteOrder(ctx, orderID, ownerID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrder", reflect.TypeOf((*MockIBasketStorage)(nil).DeleteOrder), ctx, orderID, ownerID)
 }
 
+// GetHeldPayments mocks base method.
+func (m *MockIBasketStorage) GetHeldPayments(ctx context.Context) ([]*models.HeldPayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeldPayments", ctx)
+	ret0, _ := ret[0].([]*models.HeldPayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeldPayments indicates an expected call of GetHeldPayments.
+func (mr *MockIBasketStorageMockRecorder) GetHeldPayments(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeldPayments", reflect.TypeOf((*MockIBasketStorage)(nil).GetHeldPayments), ctx)
+}
+
+// GetOrderParticipants mocks base method.
+func (m *MockIBasketStorage) GetOrderParticipants(ctx context.Context, orderID uint64) (*models.OrderParticipants, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderParticipants", ctx, orderID)
+	ret0, _ := ret[0].(*models.OrderParticipants)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderParticipants indicates an expected call of GetOrderParticipants.
+func (mr *MockIBasketStorageMockRecorder) GetOrderParticipants(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderParticipants", reflect.TypeOf((*MockIBasketStorage)(nil).GetOrderParticipants), ctx, orderID)
+}
+
+// GetOrderStatusHistory mocks base method.
+func (m *MockIBasketStorage) GetOrderStatusHistory(ctx context.Context, orderID uint64) ([]*models.OrderStatusHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderStatusHistory", ctx, orderID)
+	ret0, _ := ret[0].([]*models.OrderStatusHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderStatusHistory indicates an expected call of GetOrderStatusHistory.
+func (mr *MockIBasketStorageMockRecorder) GetOrderStatusHistory(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderStatusHistory", reflect.TypeOf((*MockIBasketStorage)(nil).GetOrderStatusHistory), ctx, orderID)
+}
+
 // GetOrdersInBasketByUserID mocks base method.
 func (m *MockIBasketStorage) GetOrdersInBasketByUserID(ctx context.Context, userID uint64) ([]*models.OrderInBasket, error) {
 	m.ctrl.T.Helper()
@@ -127,6 +217,20 @@ func (mr *MockIBasketStorageMockRecorder) GetOrdersSoldByUserID(ctx, userID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersSoldByUserID", reflect.TypeOf((*MockIBasketStorage)(nil).GetOrdersSoldByUserID), ctx, userID)
 }
 
+// SetPaymentYookassaID mocks base method.
+func (m *MockIBasketStorage) SetPaymentYookassaID(ctx context.Context, paymentID uint64, yookassaID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPaymentYookassaID", ctx, paymentID, yookassaID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPaymentYookassaID indicates an expected call of SetPaymentYookassaID.
+func (mr *MockIBasketStorageMockRecorder) SetPaymentYookassaID(ctx, paymentID, yookassaID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPaymentYookassaID", reflect.TypeOf((*MockIBasketStorage)(nil).SetPaymentYookassaID), ctx, paymentID, yookassaID)
+}
+
 // UpdateOrderCount mocks base method.
 func (m *MockIBasketStorage) UpdateOrderCount(ctx context.Context, userID, orderID uint64, newCount uint32) error {
 	m.ctrl.T.Helper()
@@ -142,15 +246,29 @@ func (mr *MockIBasketStorageMockRecorder) UpdateOrderCount(ctx, userID, orderID,
 }
 
 // UpdateOrderStatus mocks base method.
-func (m *MockIBasketStorage) UpdateOrderStatus(ctx context.Context, userID, orderID uint64, newStatus uint8) error {
+func (m *MockIBasketStorage) UpdateOrderStatus(ctx context.Context, preHistory *models.PreOrderStatusHistory, reservedUntil time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateOrderStatus", ctx, userID, orderID, newStatus)
+	ret := m.ctrl.Call(m, "UpdateOrderStatus", ctx, preHistory, reservedUntil)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateOrderStatus indicates an expected call of UpdateOrderStatus.
-func (mr *MockIBasketStorageMockRecorder) UpdateOrderStatus(ctx, userID, orderID, newStatus any) *gomock.Call {
+func (mr *MockIBasketStorageMockRecorder) UpdateOrderStatus(ctx, preHistory, reservedUntil any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*MockIBasketStorage)(nil).UpdateOrderStatus), ctx, preHistory, reservedUntil)
+}
+
+// UpdatePaymentStatus mocks base method.
+func (m *MockIBasketStorage) UpdatePaymentStatus(ctx context.Context, paymentID uint64, status uint8, event *models.PaymentEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentStatus", ctx, paymentID, status, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePaymentStatus indicates an expected call of UpdatePaymentStatus.
+func (mr *MockIBasketStorageMockRecorder) UpdatePaymentStatus(ctx, paymentID, status, event any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*MockIBasketStorage)(nil).UpdateOrderStatus), ctx, userID, orderID, newStatus)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentStatus", reflect.TypeOf((*MockIBasketStorage)(nil).UpdatePaymentStatus), ctx, paymentID, status, event)
 }
@@ -70,18 +70,18 @@ func (mr *MockICommentServiceMockRecorder) DeleteComment(ctx, commentID, senderI
 }
 
 // GetCommentList mocks base method.
-func (m *MockICommentService) GetCommentList(ctx context.Context, offset, count, recipientID, senderID uint64) ([]*models.CommentInFeed, error) {
+func (m *MockICommentService) GetCommentList(ctx context.Context, cursor *models.Cursor, count, recipientID, senderID uint64) (*models.CommentList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCommentList", ctx, offset, count, recipientID, senderID)
-	ret0, _ := ret[0].([]*models.CommentInFeed)
+	ret := m.ctrl.Call(m, "GetCommentList", ctx, cursor, count, recipientID, senderID)
+	ret0, _ := ret[0].(*models.CommentList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCommentList indicates an expected call of GetCommentList.
-func (mr *MockICommentServiceMockRecorder) GetCommentList(ctx, offset, count, recipientID, senderID any) *gomock.Call {
+func (mr *MockICommentServiceMockRecorder) GetCommentList(ctx, cursor, count, recipientID, senderID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentList", reflect.TypeOf((*MockICommentService)(nil).GetCommentList), ctx, offset, count, recipientID, senderID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentList", reflect.TypeOf((*MockICommentService)(nil).GetCommentList), ctx, cursor, count, recipientID, senderID)
 }
 
 // UpdateComment mocks base method.
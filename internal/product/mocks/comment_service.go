@@ -69,18 +69,19 @@ func (mr *MockICommentStorageMockRecorder) DeleteComment(ctx, commentID, senderI
 }
 
 // GetCommentList mocks base method.
-func (m *MockICommentStorage) GetCommentList(ctx context.Context, offset, count, recipientID, senderID uint64) ([]*models.CommentInFeed, error) {
+func (m *MockICommentStorage) GetCommentList(ctx context.Context, cursor *models.Cursor, count, recipientID, senderID uint64) ([]*models.CommentInFeed, *models.Cursor, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCommentList", ctx, offset, count, recipientID, senderID)
+	ret := m.ctrl.Call(m, "GetCommentList", ctx, cursor, count, recipientID, senderID)
 	ret0, _ := ret[0].([]*models.CommentInFeed)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(*models.Cursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetCommentList indicates an expected call of GetCommentList.
-func (mr *MockICommentStorageMockRecorder) GetCommentList(ctx, offset, count, recipientID, senderID any) *gomock.Call {
+func (mr *MockICommentStorageMockRecorder) GetCommentList(ctx, cursor, count, recipientID, senderID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentList", reflect.TypeOf((*MockICommentStorage)(nil).GetCommentList), ctx, offset, count, recipientID, senderID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentList", reflect.TypeOf((*MockICommentStorage)(nil).GetCommentList), ctx, cursor, count, recipientID, senderID)
 }
 
 // UpdateComment mocks base method.
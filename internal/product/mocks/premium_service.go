@@ -13,6 +13,7 @@ import (
 	reflect "reflect"
 	time "time"
 
+	models "github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,17 +41,17 @@ func (m *MockIPremiumStorage) EXPECT() *MockIPremiumStorageMockRecorder {
 }
 
 // AddPremium mocks base method.
-func (m *MockIPremiumStorage) AddPremium(ctx context.Context, productID, userID uint64, premiumBegin, premiumExpire time.Time) error {
+func (m *MockIPremiumStorage) AddPremium(ctx context.Context, productID, userID uint64, premiumBegin, premiumExpire time.Time, event *models.PaymentEvent) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddPremium", ctx, productID, userID, premiumBegin, premiumExpire)
+	ret := m.ctrl.Call(m, "AddPremium", ctx, productID, userID, premiumBegin, premiumExpire, event)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddPremium indicates an expected call of AddPremium.
-func (mr *MockIPremiumStorageMockRecorder) AddPremium(ctx, productID, userID, premiumBegin, premiumExpire any) *gomock.Call {
+func (mr *MockIPremiumStorageMockRecorder) AddPremium(ctx, productID, userID, premiumBegin, premiumExpire, event any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPremium", reflect.TypeOf((*MockIPremiumStorage)(nil).AddPremium), ctx, productID, userID, premiumBegin, premiumExpire)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPremium", reflect.TypeOf((*MockIPremiumStorage)(nil).AddPremium), ctx, productID, userID, premiumBegin, premiumExpire, event)
 }
 
 // CheckPremiumStatus mocks base method.
@@ -68,16 +69,60 @@ func (mr *MockIPremiumStorageMockRecorder) CheckPremiumStatus(ctx, productID, us
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPremiumStatus", reflect.TypeOf((*MockIPremiumStorage)(nil).CheckPremiumStatus), ctx, productID, userID)
 }
 
+// DeleteExpiredPremiumIdempotencyKeys mocks base method.
+func (m *MockIPremiumStorage) DeleteExpiredPremiumIdempotencyKeys(ctx context.Context, expiredBefore time.Time) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredPremiumIdempotencyKeys", ctx, expiredBefore)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredPremiumIdempotencyKeys indicates an expected call of DeleteExpiredPremiumIdempotencyKeys.
+func (mr *MockIPremiumStorageMockRecorder) DeleteExpiredPremiumIdempotencyKeys(ctx, expiredBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredPremiumIdempotencyKeys", reflect.TypeOf((*MockIPremiumStorage)(nil).DeleteExpiredPremiumIdempotencyKeys), ctx, expiredBefore)
+}
+
+// DeletePremiumIdempotencyKey mocks base method.
+func (m *MockIPremiumStorage) DeletePremiumIdempotencyKey(ctx context.Context, userID, productID, periodCode uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePremiumIdempotencyKey", ctx, userID, productID, periodCode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePremiumIdempotencyKey indicates an expected call of DeletePremiumIdempotencyKey.
+func (mr *MockIPremiumStorageMockRecorder) DeletePremiumIdempotencyKey(ctx, userID, productID, periodCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePremiumIdempotencyKey", reflect.TypeOf((*MockIPremiumStorage)(nil).DeletePremiumIdempotencyKey), ctx, userID, productID, periodCode)
+}
+
+// GetOrAddPremiumIdempotencyKey mocks base method.
+func (m *MockIPremiumStorage) GetOrAddPremiumIdempotencyKey(ctx context.Context, userID, productID, periodCode uint64, newKey string, expiredBefore time.Time) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrAddPremiumIdempotencyKey", ctx, userID, productID, periodCode, newKey, expiredBefore)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrAddPremiumIdempotencyKey indicates an expected call of GetOrAddPremiumIdempotencyKey.
+func (mr *MockIPremiumStorageMockRecorder) GetOrAddPremiumIdempotencyKey(ctx, userID, productID, periodCode, newKey, expiredBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrAddPremiumIdempotencyKey", reflect.TypeOf((*MockIPremiumStorage)(nil).GetOrAddPremiumIdempotencyKey), ctx, userID, productID, periodCode, newKey, expiredBefore)
+}
+
 // UpdateStatusPremium mocks base method.
-func (m *MockIPremiumStorage) UpdateStatusPremium(ctx context.Context, status uint8, productID, userID uint64) error {
+func (m *MockIPremiumStorage) UpdateStatusPremium(ctx context.Context, status uint8, productID, userID uint64, event *models.PaymentEvent) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateStatusPremium", ctx, status, productID, userID)
+	ret := m.ctrl.Call(m, "UpdateStatusPremium", ctx, status, productID, userID, event)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateStatusPremium indicates an expected call of UpdateStatusPremium.
-func (mr *MockIPremiumStorageMockRecorder) UpdateStatusPremium(ctx, status, productID, userID any) *gomock.Call {
+func (mr *MockIPremiumStorageMockRecorder) UpdateStatusPremium(ctx, status, productID, userID, event any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusPremium", reflect.TypeOf((*MockIPremiumStorage)(nil).UpdateStatusPremium), ctx, status, productID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusPremium", reflect.TypeOf((*MockIPremiumStorage)(nil).UpdateStatusPremium), ctx, status, productID, userID, event)
 }
@@ -85,17 +85,17 @@ func (mr *MockIProductServiceMockRecorder) AddOrder(ctx, r, userID any) *gomock.
 }
 
 // AddPremium mocks base method.
-func (m *MockIProductService) AddPremium(ctx context.Context, productID, userID, periodCode uint64) error {
+func (m *MockIProductService) AddPremium(ctx context.Context, productID, userID, periodCode uint64, event *models.PaymentEvent) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddPremium", ctx, productID, userID, periodCode)
+	ret := m.ctrl.Call(m, "AddPremium", ctx, productID, userID, periodCode, event)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddPremium indicates an expected call of AddPremium.
-func (mr *MockIProductServiceMockRecorder) AddPremium(ctx, productID, userID, periodCode any) *gomock.Call {
+func (mr *MockIProductServiceMockRecorder) AddPremium(ctx, productID, userID, periodCode, event any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPremium", reflect.TypeOf((*MockIProductService)(nil).AddPremium), ctx, productID, userID, periodCode)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPremium", reflect.TypeOf((*MockIProductService)(nil).AddPremium), ctx, productID, userID, periodCode, event)
 }
 
 // AddProduct mocks base method.
@@ -113,6 +113,21 @@ func (mr *MockIProductServiceMockRecorder) AddProduct(ctx, r, userID any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProduct", reflect.TypeOf((*MockIProductService)(nil).AddProduct), ctx, r, userID)
 }
 
+// AddSavedSearch mocks base method.
+func (m *MockIProductService) AddSavedSearch(ctx context.Context, r io.Reader, userID uint64) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSavedSearch", ctx, r, userID)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddSavedSearch indicates an expected call of AddSavedSearch.
+func (mr *MockIProductServiceMockRecorder) AddSavedSearch(ctx, r, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSavedSearch", reflect.TypeOf((*MockIProductService)(nil).AddSavedSearch), ctx, r, userID)
+}
+
 // AddToFavourites mocks base method.
 func (m *MockIProductService) AddToFavourites(ctx context.Context, userID uint64, r io.Reader) error {
 	m.ctrl.T.Helper()
@@ -128,11 +143,12 @@ func (mr *MockIProductServiceMockRecorder) AddToFavourites(ctx, userID, r any) *
 }
 
 // BuyFullBasket mocks base method.
-func (m *MockIProductService) BuyFullBasket(ctx context.Context, userID uint64) error {
+func (m *MockIProductService) BuyFullBasket(ctx context.Context, userID uint64) (*models.BasketPayment, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "BuyFullBasket", ctx, userID)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(*models.BasketPayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // BuyFullBasket indicates an expected call of BuyFullBasket.
@@ -141,6 +157,35 @@ func (mr *MockIProductServiceMockRecorder) BuyFullBasket(ctx, userID any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyFullBasket", reflect.TypeOf((*MockIProductService)(nil).BuyFullBasket), ctx, userID)
 }
 
+// CancelBasketPayment mocks base method.
+func (m *MockIProductService) CancelBasketPayment(ctx context.Context, paymentID uint64, status uint8, event *models.PaymentEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelBasketPayment", ctx, paymentID, status, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelBasketPayment indicates an expected call of CancelBasketPayment.
+func (mr *MockIProductServiceMockRecorder) CancelBasketPayment(ctx, paymentID, status, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelBasketPayment", reflect.TypeOf((*MockIProductService)(nil).CancelBasketPayment), ctx, paymentID, status, event)
+}
+
+// CancelExpiredReservations mocks base method.
+func (m *MockIProductService) CancelExpiredReservations(ctx context.Context) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelExpiredReservations", ctx)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelExpiredReservations indicates an expected call of CancelExpiredReservations.
+func (mr *MockIProductServiceMockRecorder) CancelExpiredReservations(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelExpiredReservations", reflect.TypeOf((*MockIProductService)(nil).CancelExpiredReservations), ctx)
+}
+
 // CheckPremiumStatus mocks base method.
 func (m *MockIProductService) CheckPremiumStatus(ctx context.Context, productID, userID uint64) (uint8, error) {
 	m.ctrl.T.Helper()
@@ -156,6 +201,21 @@ func (mr *MockIProductServiceMockRecorder) CheckPremiumStatus(ctx, productID, us
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPremiumStatus", reflect.TypeOf((*MockIProductService)(nil).CheckPremiumStatus), ctx, productID, userID)
 }
 
+// CheckSavedSearches mocks base method.
+func (m *MockIProductService) CheckSavedSearches(ctx context.Context) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSavedSearches", ctx)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckSavedSearches indicates an expected call of CheckSavedSearches.
+func (mr *MockIProductServiceMockRecorder) CheckSavedSearches(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSavedSearches", reflect.TypeOf((*MockIProductService)(nil).CheckSavedSearches), ctx)
+}
+
 // CloseProduct mocks base method.
 func (m *MockIProductService) CloseProduct(ctx context.Context, productID, userID uint64) error {
 	m.ctrl.T.Helper()
@@ -170,6 +230,20 @@ func (mr *MockIProductServiceMockRecorder) CloseProduct(ctx, productID, userID a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseProduct", reflect.TypeOf((*MockIProductService)(nil).CloseProduct), ctx, productID, userID)
 }
 
+// ConfirmBasketPayment mocks base method.
+func (m *MockIProductService) ConfirmBasketPayment(ctx context.Context, paymentID uint64, status uint8, event *models.PaymentEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmBasketPayment", ctx, paymentID, status, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmBasketPayment indicates an expected call of ConfirmBasketPayment.
+func (mr *MockIProductServiceMockRecorder) ConfirmBasketPayment(ctx, paymentID, status, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmBasketPayment", reflect.TypeOf((*MockIProductService)(nil).ConfirmBasketPayment), ctx, paymentID, status, event)
+}
+
 // DeleteComment mocks base method.
 func (m *MockIProductService) DeleteComment(ctx context.Context, commentID, senderID uint64) error {
 	m.ctrl.T.Helper()
@@ -184,6 +258,21 @@ func (mr *MockIProductServiceMockRecorder) DeleteComment(ctx, commentID, senderI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockIProductService)(nil).DeleteComment), ctx, commentID, senderID)
 }
 
+// DeleteExpiredPremiumIdempotencyKeys mocks base method.
+func (m *MockIProductService) DeleteExpiredPremiumIdempotencyKeys(ctx context.Context) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredPremiumIdempotencyKeys", ctx)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredPremiumIdempotencyKeys indicates an expected call of DeleteExpiredPremiumIdempotencyKeys.
+func (mr *MockIProductServiceMockRecorder) DeleteExpiredPremiumIdempotencyKeys(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredPremiumIdempotencyKeys", reflect.TypeOf((*MockIProductService)(nil).DeleteExpiredPremiumIdempotencyKeys), ctx)
+}
+
 // DeleteFromFavourites mocks base method.
 func (m *MockIProductService) DeleteFromFavourites(ctx context.Context, userID, productID uint64) error {
 	m.ctrl.T.Helper()
@@ -212,6 +301,20 @@ func (mr *MockIProductServiceMockRecorder) DeleteOrder(ctx, orderID, ownerID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrder", reflect.TypeOf((*MockIProductService)(nil).DeleteOrder), ctx, orderID, ownerID)
 }
 
+// DeletePremiumIdempotencyKey mocks base method.
+func (m *MockIProductService) DeletePremiumIdempotencyKey(ctx context.Context, userID, productID, periodCode uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePremiumIdempotencyKey", ctx, userID, productID, periodCode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePremiumIdempotencyKey indicates an expected call of DeletePremiumIdempotencyKey.
+func (mr *MockIProductServiceMockRecorder) DeletePremiumIdempotencyKey(ctx, userID, productID, periodCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePremiumIdempotencyKey", reflect.TypeOf((*MockIProductService)(nil).DeletePremiumIdempotencyKey), ctx, userID, productID, periodCode)
+}
+
 // DeleteProduct mocks base method.
 func (m *MockIProductService) DeleteProduct(ctx context.Context, productID, userID uint64) error {
 	m.ctrl.T.Helper()
@@ -226,19 +329,78 @@ func (mr *MockIProductServiceMockRecorder) DeleteProduct(ctx, productID, userID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockIProductService)(nil).DeleteProduct), ctx, productID, userID)
 }
 
+// DeleteSavedSearch mocks base method.
+func (m *MockIProductService) DeleteSavedSearch(ctx context.Context, savedSearchID, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSavedSearch", ctx, savedSearchID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSavedSearch indicates an expected call of DeleteSavedSearch.
+func (mr *MockIProductServiceMockRecorder) DeleteSavedSearch(ctx, savedSearchID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSavedSearch", reflect.TypeOf((*MockIProductService)(nil).DeleteSavedSearch), ctx, savedSearchID, userID)
+}
+
 // GetCommentList mocks base method.
-func (m *MockIProductService) GetCommentList(ctx context.Context, offset, count, recipientID, senderID uint64) ([]*models.CommentInFeed, error) {
+func (m *MockIProductService) GetCommentList(ctx context.Context, cursor *models.Cursor, count, recipientID, senderID uint64) (*models.CommentList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCommentList", ctx, offset, count, recipientID, senderID)
-	ret0, _ := ret[0].([]*models.CommentInFeed)
+	ret := m.ctrl.Call(m, "GetCommentList", ctx, cursor, count, recipientID, senderID)
+	ret0, _ := ret[0].(*models.CommentList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCommentList indicates an expected call of GetCommentList.
-func (mr *MockIProductServiceMockRecorder) GetCommentList(ctx, offset, count, recipientID, senderID any) *gomock.Call {
+func (mr *MockIProductServiceMockRecorder) GetCommentList(ctx, cursor, count, recipientID, senderID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentList", reflect.TypeOf((*MockIProductService)(nil).GetCommentList), ctx, offset, count, recipientID, senderID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentList", reflect.TypeOf((*MockIProductService)(nil).GetCommentList), ctx, cursor, count, recipientID, senderID)
+}
+
+// GetHeldPayments mocks base method.
+func (m *MockIProductService) GetHeldPayments(ctx context.Context) ([]*models.HeldPayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeldPayments", ctx)
+	ret0, _ := ret[0].([]*models.HeldPayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeldPayments indicates an expected call of GetHeldPayments.
+func (mr *MockIProductServiceMockRecorder) GetHeldPayments(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeldPayments", reflect.TypeOf((*MockIProductService)(nil).GetHeldPayments), ctx)
+}
+
+// GetNotifications mocks base method.
+func (m *MockIProductService) GetNotifications(ctx context.Context, userID uint64) ([]*models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", ctx, userID)
+	ret0, _ := ret[0].([]*models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockIProductServiceMockRecorder) GetNotifications(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockIProductService)(nil).GetNotifications), ctx, userID)
+}
+
+// GetOrderStatusHistory mocks base method.
+func (m *MockIProductService) GetOrderStatusHistory(ctx context.Context, orderID, userID uint64) ([]*models.OrderStatusHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderStatusHistory", ctx, orderID, userID)
+	ret0, _ := ret[0].([]*models.OrderStatusHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderStatusHistory indicates an expected call of GetOrderStatusHistory.
+func (mr *MockIProductServiceMockRecorder) GetOrderStatusHistory(ctx, orderID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderStatusHistory", reflect.TypeOf((*MockIProductService)(nil).GetOrderStatusHistory), ctx, orderID, userID)
 }
 
 // GetOrdersByUserID mocks base method.
@@ -286,6 +448,21 @@ func (mr *MockIProductServiceMockRecorder) GetOrdersSoldByUserID(ctx, userID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersSoldByUserID", reflect.TypeOf((*MockIProductService)(nil).GetOrdersSoldByUserID), ctx, userID)
 }
 
+// GetPremiumIdempotencyKey mocks base method.
+func (m *MockIProductService) GetPremiumIdempotencyKey(ctx context.Context, userID, productID, periodCode uint64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPremiumIdempotencyKey", ctx, userID, productID, periodCode)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPremiumIdempotencyKey indicates an expected call of GetPremiumIdempotencyKey.
+func (mr *MockIProductServiceMockRecorder) GetPremiumIdempotencyKey(ctx, userID, productID, periodCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPremiumIdempotencyKey", reflect.TypeOf((*MockIProductService)(nil).GetPremiumIdempotencyKey), ctx, userID, productID, periodCode)
+}
+
 // GetProduct mocks base method.
 func (m *MockIProductService) GetProduct(ctx context.Context, productID, userID uint64) (*models.Product, error) {
 	m.ctrl.T.Helper()
@@ -302,48 +479,63 @@ func (mr *MockIProductServiceMockRecorder) GetProduct(ctx, productID, userID any
 }
 
 // GetProductsList mocks base method.
-func (m *MockIProductService) GetProductsList(ctx context.Context, offset, count, userID uint64) ([]*models.ProductInFeed, error) {
+func (m *MockIProductService) GetProductsList(ctx context.Context, cursor *models.Cursor, count, userID uint64, filter *models.ProductFilter) (*models.ProductFeed, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProductsList", ctx, offset, count, userID)
-	ret0, _ := ret[0].([]*models.ProductInFeed)
+	ret := m.ctrl.Call(m, "GetProductsList", ctx, cursor, count, userID, filter)
+	ret0, _ := ret[0].(*models.ProductFeed)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetProductsList indicates an expected call of GetProductsList.
-func (mr *MockIProductServiceMockRecorder) GetProductsList(ctx, offset, count, userID any) *gomock.Call {
+func (mr *MockIProductServiceMockRecorder) GetProductsList(ctx, cursor, count, userID, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductsList", reflect.TypeOf((*MockIProductService)(nil).GetProductsList), ctx, offset, count, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductsList", reflect.TypeOf((*MockIProductService)(nil).GetProductsList), ctx, cursor, count, userID, filter)
 }
 
 // GetProductsOfSaler mocks base method.
-func (m *MockIProductService) GetProductsOfSaler(ctx context.Context, offset, count, userID uint64, isMy bool) ([]*models.ProductInFeed, error) {
+func (m *MockIProductService) GetProductsOfSaler(ctx context.Context, cursor *models.Cursor, count, userID uint64, isMy bool) (*models.ProductList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProductsOfSaler", ctx, offset, count, userID, isMy)
-	ret0, _ := ret[0].([]*models.ProductInFeed)
+	ret := m.ctrl.Call(m, "GetProductsOfSaler", ctx, cursor, count, userID, isMy)
+	ret0, _ := ret[0].(*models.ProductList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetProductsOfSaler indicates an expected call of GetProductsOfSaler.
-func (mr *MockIProductServiceMockRecorder) GetProductsOfSaler(ctx, offset, count, userID, isMy any) *gomock.Call {
+func (mr *MockIProductServiceMockRecorder) GetProductsOfSaler(ctx, cursor, count, userID, isMy any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductsOfSaler", reflect.TypeOf((*MockIProductService)(nil).GetProductsOfSaler), ctx, offset, count, userID, isMy)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductsOfSaler", reflect.TypeOf((*MockIProductService)(nil).GetProductsOfSaler), ctx, cursor, count, userID, isMy)
+}
+
+// GetSavedSearches mocks base method.
+func (m *MockIProductService) GetSavedSearches(ctx context.Context, userID uint64) ([]*models.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSavedSearches", ctx, userID)
+	ret0, _ := ret[0].([]*models.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSavedSearches indicates an expected call of GetSavedSearches.
+func (mr *MockIProductServiceMockRecorder) GetSavedSearches(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSavedSearches", reflect.TypeOf((*MockIProductService)(nil).GetSavedSearches), ctx, userID)
 }
 
 // GetSearchProductFeed mocks base method.
-func (m *MockIProductService) GetSearchProductFeed(ctx context.Context, searchInput string, lastNumber, limit, userID uint64) ([]*models.ProductInFeed, error) {
+func (m *MockIProductService) GetSearchProductFeed(ctx context.Context, searchInput string, cursor *models.Cursor, limit, userID uint64, filter *models.ProductFilter) (*models.ProductFeed, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSearchProductFeed", ctx, searchInput, lastNumber, limit, userID)
-	ret0, _ := ret[0].([]*models.ProductInFeed)
+	ret := m.ctrl.Call(m, "GetSearchProductFeed", ctx, searchInput, cursor, limit, userID, filter)
+	ret0, _ := ret[0].(*models.ProductFeed)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetSearchProductFeed indicates an expected call of GetSearchProductFeed.
-func (mr *MockIProductServiceMockRecorder) GetSearchProductFeed(ctx, searchInput, lastNumber, limit, userID any) *gomock.Call {
+func (mr *MockIProductServiceMockRecorder) GetSearchProductFeed(ctx, searchInput, cursor, limit, userID, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSearchProductFeed", reflect.TypeOf((*MockIProductService)(nil).GetSearchProductFeed), ctx, searchInput, lastNumber, limit, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSearchProductFeed", reflect.TypeOf((*MockIProductService)(nil).GetSearchProductFeed), ctx, searchInput, cursor, limit, userID, filter)
 }
 
 // GetUserFavourites mocks base method.
@@ -361,6 +553,20 @@ func (mr *MockIProductServiceMockRecorder) GetUserFavourites(ctx, userID any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserFavourites", reflect.TypeOf((*MockIProductService)(nil).GetUserFavourites), ctx, userID)
 }
 
+// ReadNotifications mocks base method.
+func (m *MockIProductService) ReadNotifications(ctx context.Context, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadNotifications", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReadNotifications indicates an expected call of ReadNotifications.
+func (mr *MockIProductServiceMockRecorder) ReadNotifications(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadNotifications", reflect.TypeOf((*MockIProductService)(nil).ReadNotifications), ctx, userID)
+}
+
 // SearchProduct mocks base method.
 func (m *MockIProductService) SearchProduct(ctx context.Context, searchInput string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -376,6 +582,20 @@ func (mr *MockIProductServiceMockRecorder) SearchProduct(ctx, searchInput any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchProduct", reflect.TypeOf((*MockIProductService)(nil).SearchProduct), ctx, searchInput)
 }
 
+// SetPaymentYookassaID mocks base method.
+func (m *MockIProductService) SetPaymentYookassaID(ctx context.Context, paymentID uint64, yookassaID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPaymentYookassaID", ctx, paymentID, yookassaID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPaymentYookassaID indicates an expected call of SetPaymentYookassaID.
+func (mr *MockIProductServiceMockRecorder) SetPaymentYookassaID(ctx, paymentID, yookassaID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPaymentYookassaID", reflect.TypeOf((*MockIProductService)(nil).SetPaymentYookassaID), ctx, paymentID, yookassaID)
+}
+
 // UpdateComment mocks base method.
 func (m *MockIProductService) UpdateComment(ctx context.Context, r io.Reader, userID, commentID uint64) error {
 	m.ctrl.T.Helper()
@@ -418,6 +638,20 @@ func (mr *MockIProductServiceMockRecorder) UpdateOrderStatus(ctx, r, userID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*MockIProductService)(nil).UpdateOrderStatus), ctx, r, userID)
 }
 
+// UpdatePaymentStatus mocks base method.
+func (m *MockIProductService) UpdatePaymentStatus(ctx context.Context, paymentID uint64, status uint8, event *models.PaymentEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentStatus", ctx, paymentID, status, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePaymentStatus indicates an expected call of UpdatePaymentStatus.
+func (mr *MockIProductServiceMockRecorder) UpdatePaymentStatus(ctx, paymentID, status, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentStatus", reflect.TypeOf((*MockIProductService)(nil).UpdatePaymentStatus), ctx, paymentID, status, event)
+}
+
 // UpdateProduct mocks base method.
 func (m *MockIProductService) UpdateProduct(ctx context.Context, r io.Reader, isPartialUpdate bool, productID, userAuthID uint64) error {
 	m.ctrl.T.Helper()
@@ -432,16 +666,30 @@ func (mr *MockIProductServiceMockRecorder) UpdateProduct(ctx, r, isPartialUpdate
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockIProductService)(nil).UpdateProduct), ctx, r, isPartialUpdate, productID, userAuthID)
 }
 
+// UpdateSavedSearch mocks base method.
+func (m *MockIProductService) UpdateSavedSearch(ctx context.Context, r io.Reader, userID, savedSearchID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSavedSearch", ctx, r, userID, savedSearchID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSavedSearch indicates an expected call of UpdateSavedSearch.
+func (mr *MockIProductServiceMockRecorder) UpdateSavedSearch(ctx, r, userID, savedSearchID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSavedSearch", reflect.TypeOf((*MockIProductService)(nil).UpdateSavedSearch), ctx, r, userID, savedSearchID)
+}
+
 // UpdateStatusPremium mocks base method.
-func (m *MockIProductService) UpdateStatusPremium(ctx context.Context, status uint8, productID, userID uint64) error {
+func (m *MockIProductService) UpdateStatusPremium(ctx context.Context, status uint8, productID, userID uint64, event *models.PaymentEvent) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateStatusPremium", ctx, status, productID, userID)
+	ret := m.ctrl.Call(m, "UpdateStatusPremium", ctx, status, productID, userID, event)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateStatusPremium indicates an expected call of UpdateStatusPremium.
-func (mr *MockIProductServiceMockRecorder) UpdateStatusPremium(ctx, status, productID, userID any) *gomock.Call {
+func (mr *MockIProductServiceMockRecorder) UpdateStatusPremium(ctx, status, productID, userID, event any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusPremium", reflect.TypeOf((*MockIProductService)(nil).UpdateStatusPremium), ctx, status, productID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusPremium", reflect.TypeOf((*MockIProductService)(nil).UpdateStatusPremium), ctx, status, productID, userID, event)
 }
@@ -85,17 +85,17 @@ func (mr *MockIProductStorageMockRecorder) AddOrderInBasket(ctx, userID, product
 }
 
 // AddPremium mocks base method.
-func (m *MockIProductStorage) AddPremium(ctx context.Context, productID, userID uint64, premiumBegin, premiumExpire time.Time) error {
+func (m *MockIProductStorage) AddPremium(ctx context.Context, productID, userID uint64, premiumBegin, premiumExpire time.Time, event *models.PaymentEvent) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddPremium", ctx, productID, userID, premiumBegin, premiumExpire)
+	ret := m.ctrl.Call(m, "AddPremium", ctx, productID, userID, premiumBegin, premiumExpire, event)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddPremium indicates an expected call of AddPremium.
-func (mr *MockIProductStorageMockRecorder) AddPremium(ctx, productID, userID, premiumBegin, premiumExpire any) *gomock.Call {
+func (mr *MockIProductStorageMockRecorder) AddPremium(ctx, productID, userID, premiumBegin, premiumExpire, event any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPremium", reflect.TypeOf((*MockIProductStorage)(nil).AddPremium), ctx, productID, userID, premiumBegin, premiumExpire)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPremium", reflect.TypeOf((*MockIProductStorage)(nil).AddPremium), ctx, productID, userID, premiumBegin, premiumExpire, event)
 }
 
 // AddProduct mocks base method.
@@ -113,6 +113,21 @@ func (mr *MockIProductStorageMockRecorder) AddProduct(ctx, preProduct any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProduct", reflect.TypeOf((*MockIProductStorage)(nil).AddProduct), ctx, preProduct)
 }
 
+// AddSavedSearch mocks base method.
+func (m *MockIProductStorage) AddSavedSearch(ctx context.Context, preSavedSearch *models.PreSavedSearch) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSavedSearch", ctx, preSavedSearch)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddSavedSearch indicates an expected call of AddSavedSearch.
+func (mr *MockIProductStorageMockRecorder) AddSavedSearch(ctx, preSavedSearch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSavedSearch", reflect.TypeOf((*MockIProductStorage)(nil).AddSavedSearch), ctx, preSavedSearch)
+}
+
 // AddToFavourites mocks base method.
 func (m *MockIProductStorage) AddToFavourites(ctx context.Context, userID, productID uint64) error {
 	m.ctrl.T.Helper()
@@ -128,17 +143,47 @@ func (mr *MockIProductStorageMockRecorder) AddToFavourites(ctx, userID, productI
 }
 
 // BuyFullBasket mocks base method.
-func (m *MockIProductStorage) BuyFullBasket(ctx context.Context, userID uint64) error {
+func (m *MockIProductStorage) BuyFullBasket(ctx context.Context, userID uint64, reservedUntil time.Time, idempotencyKey string) (*models.BasketPayment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BuyFullBasket", ctx, userID)
+	ret := m.ctrl.Call(m, "BuyFullBasket", ctx, userID, reservedUntil, idempotencyKey)
+	ret0, _ := ret[0].(*models.BasketPayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BuyFullBasket indicates an expected call of BuyFullBasket.
+func (mr *MockIProductStorageMockRecorder) BuyFullBasket(ctx, userID, reservedUntil, idempotencyKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyFullBasket", reflect.TypeOf((*MockIProductStorage)(nil).BuyFullBasket), ctx, userID, reservedUntil, idempotencyKey)
+}
+
+// CancelBasketPayment mocks base method.
+func (m *MockIProductStorage) CancelBasketPayment(ctx context.Context, paymentID uint64, status uint8, event *models.PaymentEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelBasketPayment", ctx, paymentID, status, event)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// BuyFullBasket indicates an expected call of BuyFullBasket.
-func (mr *MockIProductStorageMockRecorder) BuyFullBasket(ctx, userID any) *gomock.Call {
+// CancelBasketPayment indicates an expected call of CancelBasketPayment.
+func (mr *MockIProductStorageMockRecorder) CancelBasketPayment(ctx, paymentID, status, event any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyFullBasket", reflect.TypeOf((*MockIProductStorage)(nil).BuyFullBasket), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelBasketPayment", reflect.TypeOf((*MockIProductStorage)(nil).CancelBasketPayment), ctx, paymentID, status, event)
+}
+
+// CancelExpiredReservations mocks base method.
+func (m *MockIProductStorage) CancelExpiredReservations(ctx context.Context, now, deadlinePendingPayment time.Time) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelExpiredReservations", ctx, now, deadlinePendingPayment)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelExpiredReservations indicates an expected call of CancelExpiredReservations.
+func (mr *MockIProductStorageMockRecorder) CancelExpiredReservations(ctx, now, deadlinePendingPayment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelExpiredReservations", reflect.TypeOf((*MockIProductStorage)(nil).CancelExpiredReservations), ctx, now, deadlinePendingPayment)
 }
 
 // CheckPremiumStatus mocks base method.
@@ -156,6 +201,21 @@ func (mr *MockIProductStorageMockRecorder) CheckPremiumStatus(ctx, productID, us
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPremiumStatus", reflect.TypeOf((*MockIProductStorage)(nil).CheckPremiumStatus), ctx, productID, userID)
 }
 
+// CheckSavedSearches mocks base method.
+func (m *MockIProductStorage) CheckSavedSearches(ctx context.Context, checkedAt time.Time) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSavedSearches", ctx, checkedAt)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckSavedSearches indicates an expected call of CheckSavedSearches.
+func (mr *MockIProductStorageMockRecorder) CheckSavedSearches(ctx, checkedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSavedSearches", reflect.TypeOf((*MockIProductStorage)(nil).CheckSavedSearches), ctx, checkedAt)
+}
+
 // CloseProduct mocks base method.
 func (m *MockIProductStorage) CloseProduct(ctx context.Context, productID, userID uint64) error {
 	m.ctrl.T.Helper()
@@ -170,6 +230,20 @@ func (mr *MockIProductStorageMockRecorder) CloseProduct(ctx, productID, userID a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseProduct", reflect.TypeOf((*MockIProductStorage)(nil).CloseProduct), ctx, productID, userID)
 }
 
+// ConfirmBasketPayment mocks base method.
+func (m *MockIProductStorage) ConfirmBasketPayment(ctx context.Context, paymentID uint64, status uint8, event *models.PaymentEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmBasketPayment", ctx, paymentID, status, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmBasketPayment indicates an expected call of ConfirmBasketPayment.
+func (mr *MockIProductStorageMockRecorder) ConfirmBasketPayment(ctx, paymentID, status, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmBasketPayment", reflect.TypeOf((*MockIProductStorage)(nil).ConfirmBasketPayment), ctx, paymentID, status, event)
+}
+
 // DeleteComment mocks base method.
 func (m *MockIProductStorage) DeleteComment(ctx context.Context, commentID, senderID uint64) error {
 	m.ctrl.T.Helper()
@@ -184,6 +258,21 @@ func (mr *MockIProductStorageMockRecorder) DeleteComment(ctx, commentID, senderI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockIProductStorage)(nil).DeleteComment), ctx, commentID, senderID)
 }
 
+// DeleteExpiredPremiumIdempotencyKeys mocks base method.
+func (m *MockIProductStorage) DeleteExpiredPremiumIdempotencyKeys(ctx context.Context, expiredBefore time.Time) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredPremiumIdempotencyKeys", ctx, expiredBefore)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredPremiumIdempotencyKeys indicates an expected call of DeleteExpiredPremiumIdempotencyKeys.
+func (mr *MockIProductStorageMockRecorder) DeleteExpiredPremiumIdempotencyKeys(ctx, expiredBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredPremiumIdempotencyKeys", reflect.TypeOf((*MockIProductStorage)(nil).DeleteExpiredPremiumIdempotencyKeys), ctx, expiredBefore)
+}
+
 // DeleteFromFavourites mocks base method.
 func (m *MockIProductStorage) DeleteFromFavourites(ctx context.Context, userID, productID uint64) error {
 	m.ctrl.T.Helper()
@@ -212,6 +301,20 @@ func (mr *MockIProductStorageMockRecorder) DeleteOrder(ctx, orderID, ownerID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrder", reflect.TypeOf((*MockIProductStorage)(nil).DeleteOrder), ctx, orderID, ownerID)
 }
 
+// DeletePremiumIdempotencyKey mocks base method.
+func (m *MockIProductStorage) DeletePremiumIdempotencyKey(ctx context.Context, userID, productID, periodCode uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePremiumIdempotencyKey", ctx, userID, productID, periodCode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePremiumIdempotencyKey indicates an expected call of DeletePremiumIdempotencyKey.
+func (mr *MockIProductStorageMockRecorder) DeletePremiumIdempotencyKey(ctx, userID, productID, periodCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePremiumIdempotencyKey", reflect.TypeOf((*MockIProductStorage)(nil).DeletePremiumIdempotencyKey), ctx, userID, productID, periodCode)
+}
+
 // DeleteProduct mocks base method.
 func (m *MockIProductStorage) DeleteProduct(ctx context.Context, productID, userID uint64) error {
 	m.ctrl.T.Helper()
@@ -226,19 +329,109 @@ func (mr *MockIProductStorageMockRecorder) DeleteProduct(ctx, productID, userID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockIProductStorage)(nil).DeleteProduct), ctx, productID, userID)
 }
 
+// DeleteSavedSearch mocks base method.
+func (m *MockIProductStorage) DeleteSavedSearch(ctx context.Context, savedSearchID, ownerID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSavedSearch", ctx, savedSearchID, ownerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSavedSearch indicates an expected call of DeleteSavedSearch.
+func (mr *MockIProductStorageMockRecorder) DeleteSavedSearch(ctx, savedSearchID, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSavedSearch", reflect.TypeOf((*MockIProductStorage)(nil).DeleteSavedSearch), ctx, savedSearchID, ownerID)
+}
+
 // GetCommentList mocks base method.
-func (m *MockIProductStorage) GetCommentList(ctx context.Context, offset, count, recipientID, senderID uint64) ([]*models.CommentInFeed, error) {
+func (m *MockIProductStorage) GetCommentList(ctx context.Context, cursor *models.Cursor, count, recipientID, senderID uint64) ([]*models.CommentInFeed, *models.Cursor, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCommentList", ctx, offset, count, recipientID, senderID)
+	ret := m.ctrl.Call(m, "GetCommentList", ctx, cursor, count, recipientID, senderID)
 	ret0, _ := ret[0].([]*models.CommentInFeed)
+	ret1, _ := ret[1].(*models.Cursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetCommentList indicates an expected call of GetCommentList.
+func (mr *MockIProductStorageMockRecorder) GetCommentList(ctx, cursor, count, recipientID, senderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentList", reflect.TypeOf((*MockIProductStorage)(nil).GetCommentList), ctx, cursor, count, recipientID, senderID)
+}
+
+// GetHeldPayments mocks base method.
+func (m *MockIProductStorage) GetHeldPayments(ctx context.Context) ([]*models.HeldPayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeldPayments", ctx)
+	ret0, _ := ret[0].([]*models.HeldPayment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCommentList indicates an expected call of GetCommentList.
-func (mr *MockIProductStorageMockRecorder) GetCommentList(ctx, offset, count, recipientID, senderID any) *gomock.Call {
+// GetHeldPayments indicates an expected call of GetHeldPayments.
+func (mr *MockIProductStorageMockRecorder) GetHeldPayments(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeldPayments", reflect.TypeOf((*MockIProductStorage)(nil).GetHeldPayments), ctx)
+}
+
+// GetNotifications mocks base method.
+func (m *MockIProductStorage) GetNotifications(ctx context.Context, ownerID uint64) ([]*models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", ctx, ownerID)
+	ret0, _ := ret[0].([]*models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockIProductStorageMockRecorder) GetNotifications(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockIProductStorage)(nil).GetNotifications), ctx, ownerID)
+}
+
+// GetOrAddPremiumIdempotencyKey mocks base method.
+func (m *MockIProductStorage) GetOrAddPremiumIdempotencyKey(ctx context.Context, userID, productID, periodCode uint64, newKey string, expiredBefore time.Time) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrAddPremiumIdempotencyKey", ctx, userID, productID, periodCode, newKey, expiredBefore)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrAddPremiumIdempotencyKey indicates an expected call of GetOrAddPremiumIdempotencyKey.
+func (mr *MockIProductStorageMockRecorder) GetOrAddPremiumIdempotencyKey(ctx, userID, productID, periodCode, newKey, expiredBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrAddPremiumIdempotencyKey", reflect.TypeOf((*MockIProductStorage)(nil).GetOrAddPremiumIdempotencyKey), ctx, userID, productID, periodCode, newKey, expiredBefore)
+}
+
+// GetOrderParticipants mocks base method.
+func (m *MockIProductStorage) GetOrderParticipants(ctx context.Context, orderID uint64) (*models.OrderParticipants, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderParticipants", ctx, orderID)
+	ret0, _ := ret[0].(*models.OrderParticipants)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderParticipants indicates an expected call of GetOrderParticipants.
+func (mr *MockIProductStorageMockRecorder) GetOrderParticipants(ctx, orderID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentList", reflect.TypeOf((*MockIProductStorage)(nil).GetCommentList), ctx, offset, count, recipientID, senderID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderParticipants", reflect.TypeOf((*MockIProductStorage)(nil).GetOrderParticipants), ctx, orderID)
+}
+
+// GetOrderStatusHistory mocks base method.
+func (m *MockIProductStorage) GetOrderStatusHistory(ctx context.Context, orderID uint64) ([]*models.OrderStatusHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderStatusHistory", ctx, orderID)
+	ret0, _ := ret[0].([]*models.OrderStatusHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderStatusHistory indicates an expected call of GetOrderStatusHistory.
+func (mr *MockIProductStorageMockRecorder) GetOrderStatusHistory(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderStatusHistory", reflect.TypeOf((*MockIProductStorage)(nil).GetOrderStatusHistory), ctx, orderID)
 }
 
 // GetOrdersInBasketByUserID mocks base method.
@@ -287,18 +480,19 @@ func (mr *MockIProductStorageMockRecorder) GetOrdersSoldByUserID(ctx, userID any
 }
 
 // GetPopularProducts mocks base method.
-func (m *MockIProductStorage) GetPopularProducts(ctx context.Context, offset, count, userID uint64) ([]*models.ProductInFeed, error) {
+func (m *MockIProductStorage) GetPopularProducts(ctx context.Context, cursor *models.Cursor, count, userID uint64, filter *models.ProductFilter) ([]*models.ProductInFeed, *models.Cursor, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPopularProducts", ctx, offset, count, userID)
+	ret := m.ctrl.Call(m, "GetPopularProducts", ctx, cursor, count, userID, filter)
 	ret0, _ := ret[0].([]*models.ProductInFeed)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(*models.Cursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetPopularProducts indicates an expected call of GetPopularProducts.
-func (mr *MockIProductStorageMockRecorder) GetPopularProducts(ctx, offset, count, userID any) *gomock.Call {
+func (mr *MockIProductStorageMockRecorder) GetPopularProducts(ctx, cursor, count, userID, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPopularProducts", reflect.TypeOf((*MockIProductStorage)(nil).GetPopularProducts), ctx, offset, count, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPopularProducts", reflect.TypeOf((*MockIProductStorage)(nil).GetPopularProducts), ctx, cursor, count, userID, filter)
 }
 
 // GetProduct mocks base method.
@@ -316,34 +510,66 @@ func (mr *MockIProductStorageMockRecorder) GetProduct(ctx, productID, userID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockIProductStorage)(nil).GetProduct), ctx, productID, userID)
 }
 
+// GetProductFacets mocks base method.
+func (m *MockIProductStorage) GetProductFacets(ctx context.Context, searchInput string, filter *models.ProductFilter) (*models.ProductFacets, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductFacets", ctx, searchInput, filter)
+	ret0, _ := ret[0].(*models.ProductFacets)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductFacets indicates an expected call of GetProductFacets.
+func (mr *MockIProductStorageMockRecorder) GetProductFacets(ctx, searchInput, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductFacets", reflect.TypeOf((*MockIProductStorage)(nil).GetProductFacets), ctx, searchInput, filter)
+}
+
 // GetProductsOfSaler mocks base method.
-func (m *MockIProductStorage) GetProductsOfSaler(ctx context.Context, lastProductID, count, userID uint64, isMy bool) ([]*models.ProductInFeed, error) {
+func (m *MockIProductStorage) GetProductsOfSaler(ctx context.Context, cursor *models.Cursor, count, userID uint64, isMy bool) ([]*models.ProductInFeed, *models.Cursor, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProductsOfSaler", ctx, lastProductID, count, userID, isMy)
+	ret := m.ctrl.Call(m, "GetProductsOfSaler", ctx, cursor, count, userID, isMy)
 	ret0, _ := ret[0].([]*models.ProductInFeed)
+	ret1, _ := ret[1].(*models.Cursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetProductsOfSaler indicates an expected call of GetProductsOfSaler.
+func (mr *MockIProductStorageMockRecorder) GetProductsOfSaler(ctx, cursor, count, userID, isMy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductsOfSaler", reflect.TypeOf((*MockIProductStorage)(nil).GetProductsOfSaler), ctx, cursor, count, userID, isMy)
+}
+
+// GetSavedSearches mocks base method.
+func (m *MockIProductStorage) GetSavedSearches(ctx context.Context, ownerID uint64) ([]*models.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSavedSearches", ctx, ownerID)
+	ret0, _ := ret[0].([]*models.SavedSearch)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetProductsOfSaler indicates an expected call of GetProductsOfSaler.
-func (mr *MockIProductStorageMockRecorder) GetProductsOfSaler(ctx, lastProductID, count, userID, isMy any) *gomock.Call {
+// GetSavedSearches indicates an expected call of GetSavedSearches.
+func (mr *MockIProductStorageMockRecorder) GetSavedSearches(ctx, ownerID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductsOfSaler", reflect.TypeOf((*MockIProductStorage)(nil).GetProductsOfSaler), ctx, lastProductID, count, userID, isMy)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSavedSearches", reflect.TypeOf((*MockIProductStorage)(nil).GetSavedSearches), ctx, ownerID)
 }
 
 // GetSearchProductFeed mocks base method.
-func (m *MockIProductStorage) GetSearchProductFeed(ctx context.Context, searchInput string, lastNumber, limit, userID uint64) ([]*models.ProductInFeed, error) {
+func (m *MockIProductStorage) GetSearchProductFeed(ctx context.Context, searchInput string, cursor *models.Cursor, limit, userID uint64, filter *models.ProductFilter) ([]*models.ProductInFeed, *models.Cursor, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSearchProductFeed", ctx, searchInput, lastNumber, limit, userID)
+	ret := m.ctrl.Call(m, "GetSearchProductFeed", ctx, searchInput, cursor, limit, userID, filter)
 	ret0, _ := ret[0].([]*models.ProductInFeed)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(*models.Cursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetSearchProductFeed indicates an expected call of GetSearchProductFeed.
-func (mr *MockIProductStorageMockRecorder) GetSearchProductFeed(ctx, searchInput, lastNumber, limit, userID any) *gomock.Call {
+func (mr *MockIProductStorageMockRecorder) GetSearchProductFeed(ctx, searchInput, cursor, limit, userID, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSearchProductFeed", reflect.TypeOf((*MockIProductStorage)(nil).GetSearchProductFeed), ctx, searchInput, lastNumber, limit, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSearchProductFeed", reflect.TypeOf((*MockIProductStorage)(nil).GetSearchProductFeed), ctx, searchInput, cursor, limit, userID, filter)
 }
 
 // GetUserFavourites mocks base method.
@@ -361,6 +587,20 @@ func (mr *MockIProductStorageMockRecorder) GetUserFavourites(ctx, userID any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserFavourites", reflect.TypeOf((*MockIProductStorage)(nil).GetUserFavourites), ctx, userID)
 }
 
+// ReadNotifications mocks base method.
+func (m *MockIProductStorage) ReadNotifications(ctx context.Context, ownerID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadNotifications", ctx, ownerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReadNotifications indicates an expected call of ReadNotifications.
+func (mr *MockIProductStorageMockRecorder) ReadNotifications(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadNotifications", reflect.TypeOf((*MockIProductStorage)(nil).ReadNotifications), ctx, ownerID)
+}
+
 // SearchProduct mocks base method.
 func (m *MockIProductStorage) SearchProduct(ctx context.Context, searchInput string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -376,6 +616,20 @@ func (mr *MockIProductStorageMockRecorder) SearchProduct(ctx, searchInput any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchProduct", reflect.TypeOf((*MockIProductStorage)(nil).SearchProduct), ctx, searchInput)
 }
 
+// SetPaymentYookassaID mocks base method.
+func (m *MockIProductStorage) SetPaymentYookassaID(ctx context.Context, paymentID uint64, yookassaID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPaymentYookassaID", ctx, paymentID, yookassaID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPaymentYookassaID indicates an expected call of SetPaymentYookassaID.
+func (mr *MockIProductStorageMockRecorder) SetPaymentYookassaID(ctx, paymentID, yookassaID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPaymentYookassaID", reflect.TypeOf((*MockIProductStorage)(nil).SetPaymentYookassaID), ctx, paymentID, yookassaID)
+}
+
 // UpdateComment mocks base method.
 func (m *MockIProductStorage) UpdateComment(ctx context.Context, userID, commentID uint64, updateFields map[string]any) error {
 	m.ctrl.T.Helper()
@@ -405,17 +659,31 @@ func (mr *MockIProductStorageMockRecorder) UpdateOrderCount(ctx, userID, orderID
 }
 
 // UpdateOrderStatus mocks base method.
-func (m *MockIProductStorage) UpdateOrderStatus(ctx context.Context, userID, orderID uint64, newStatus uint8) error {
+func (m *MockIProductStorage) UpdateOrderStatus(ctx context.Context, preHistory *models.PreOrderStatusHistory, reservedUntil time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateOrderStatus", ctx, userID, orderID, newStatus)
+	ret := m.ctrl.Call(m, "UpdateOrderStatus", ctx, preHistory, reservedUntil)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateOrderStatus indicates an expected call of UpdateOrderStatus.
-func (mr *MockIProductStorageMockRecorder) UpdateOrderStatus(ctx, userID, orderID, newStatus any) *gomock.Call {
+func (mr *MockIProductStorageMockRecorder) UpdateOrderStatus(ctx, preHistory, reservedUntil any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*MockIProductStorage)(nil).UpdateOrderStatus), ctx, preHistory, reservedUntil)
+}
+
+// UpdatePaymentStatus mocks base method.
+func (m *MockIProductStorage) UpdatePaymentStatus(ctx context.Context, paymentID uint64, status uint8, event *models.PaymentEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentStatus", ctx, paymentID, status, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePaymentStatus indicates an expected call of UpdatePaymentStatus.
+func (mr *MockIProductStorageMockRecorder) UpdatePaymentStatus(ctx, paymentID, status, event any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*MockIProductStorage)(nil).UpdateOrderStatus), ctx, userID, orderID, newStatus)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentStatus", reflect.TypeOf((*MockIProductStorage)(nil).UpdatePaymentStatus), ctx, paymentID, status, event)
 }
 
 // UpdateProduct mocks base method.
@@ -432,16 +700,30 @@ func (mr *MockIProductStorageMockRecorder) UpdateProduct(ctx, productID, updateF
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockIProductStorage)(nil).UpdateProduct), ctx, productID, updateFields)
 }
 
+// UpdateSavedSearch mocks base method.
+func (m *MockIProductStorage) UpdateSavedSearch(ctx context.Context, savedSearchID uint64, preSavedSearch *models.PreSavedSearch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSavedSearch", ctx, savedSearchID, preSavedSearch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSavedSearch indicates an expected call of UpdateSavedSearch.
+func (mr *MockIProductStorageMockRecorder) UpdateSavedSearch(ctx, savedSearchID, preSavedSearch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSavedSearch", reflect.TypeOf((*MockIProductStorage)(nil).UpdateSavedSearch), ctx, savedSearchID, preSavedSearch)
+}
+
 // UpdateStatusPremium mocks base method.
-func (m *MockIProductStorage) UpdateStatusPremium(ctx context.Context, status uint8, productID, userID uint64) error {
+func (m *MockIProductStorage) UpdateStatusPremium(ctx context.Context, status uint8, productID, userID uint64, event *models.PaymentEvent) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateStatusPremium", ctx, status, productID, userID)
+	ret := m.ctrl.Call(m, "UpdateStatusPremium", ctx, status, productID, userID, event)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateStatusPremium indicates an expected call of UpdateStatusPremium.
-func (mr *MockIProductStorageMockRecorder) UpdateStatusPremium(ctx, status, productID, userID any) *gomock.Call {
+func (mr *MockIProductStorageMockRecorder) UpdateStatusPremium(ctx, status, productID, userID, event any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusPremium", reflect.TypeOf((*MockIProductStorage)(nil).UpdateStatusPremium), ctx, status, productID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusPremium", reflect.TypeOf((*MockIProductStorage)(nil).UpdateStatusPremium), ctx, status, productID, userID, event)
 }
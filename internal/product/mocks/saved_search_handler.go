@@ -0,0 +1,143 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/product/delivery/saved_search_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/product/delivery/saved_search_handler.go -destination=internal/product/mocks/saved_search_handler.go -package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	models "github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockISavedSearchService is a mock of ISavedSearchService interface.
+type MockISavedSearchService struct {
+	ctrl     *gomock.Controller
+	recorder *MockISavedSearchServiceMockRecorder
+}
+
+// MockISavedSearchServiceMockRecorder is the mock recorder for MockISavedSearchService.
+type MockISavedSearchServiceMockRecorder struct {
+	mock *MockISavedSearchService
+}
+
+// NewMockISavedSearchService creates a new mock instance.
+func NewMockISavedSearchService(ctrl *gomock.Controller) *MockISavedSearchService {
+	mock := &MockISavedSearchService{ctrl: ctrl}
+	mock.recorder = &MockISavedSearchServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISavedSearchService) EXPECT() *MockISavedSearchServiceMockRecorder {
+	return m.recorder
+}
+
+// AddSavedSearch mocks base method.
+func (m *MockISavedSearchService) AddSavedSearch(ctx context.Context, r io.Reader, userID uint64) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSavedSearch", ctx, r, userID)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddSavedSearch indicates an expected call of AddSavedSearch.
+func (mr *MockISavedSearchServiceMockRecorder) AddSavedSearch(ctx, r, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSavedSearch", reflect.TypeOf((*MockISavedSearchService)(nil).AddSavedSearch), ctx, r, userID)
+}
+
+// CheckSavedSearches mocks base method.
+func (m *MockISavedSearchService) CheckSavedSearches(ctx context.Context) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSavedSearches", ctx)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckSavedSearches indicates an expected call of CheckSavedSearches.
+func (mr *MockISavedSearchServiceMockRecorder) CheckSavedSearches(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSavedSearches", reflect.TypeOf((*MockISavedSearchService)(nil).CheckSavedSearches), ctx)
+}
+
+// DeleteSavedSearch mocks base method.
+func (m *MockISavedSearchService) DeleteSavedSearch(ctx context.Context, savedSearchID, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSavedSearch", ctx, savedSearchID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSavedSearch indicates an expected call of DeleteSavedSearch.
+func (mr *MockISavedSearchServiceMockRecorder) DeleteSavedSearch(ctx, savedSearchID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSavedSearch", reflect.TypeOf((*MockISavedSearchService)(nil).DeleteSavedSearch), ctx, savedSearchID, userID)
+}
+
+// GetNotifications mocks base method.
+func (m *MockISavedSearchService) GetNotifications(ctx context.Context, userID uint64) ([]*models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", ctx, userID)
+	ret0, _ := ret[0].([]*models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockISavedSearchServiceMockRecorder) GetNotifications(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockISavedSearchService)(nil).GetNotifications), ctx, userID)
+}
+
+// GetSavedSearches mocks base method.
+func (m *MockISavedSearchService) GetSavedSearches(ctx context.Context, userID uint64) ([]*models.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSavedSearches", ctx, userID)
+	ret0, _ := ret[0].([]*models.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSavedSearches indicates an expected call of GetSavedSearches.
+func (mr *MockISavedSearchServiceMockRecorder) GetSavedSearches(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSavedSearches", reflect.TypeOf((*MockISavedSearchService)(nil).GetSavedSearches), ctx, userID)
+}
+
+// ReadNotifications mocks base method.
+func (m *MockISavedSearchService) ReadNotifications(ctx context.Context, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadNotifications", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReadNotifications indicates an expected call of ReadNotifications.
+func (mr *MockISavedSearchServiceMockRecorder) ReadNotifications(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadNotifications", reflect.TypeOf((*MockISavedSearchService)(nil).ReadNotifications), ctx, userID)
+}
+
+// UpdateSavedSearch mocks base method.
+func (m *MockISavedSearchService) UpdateSavedSearch(ctx context.Context, r io.Reader, userID, savedSearchID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSavedSearch", ctx, r, userID, savedSearchID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSavedSearch indicates an expected call of UpdateSavedSearch.
+func (mr *MockISavedSearchServiceMockRecorder) UpdateSavedSearch(ctx, r, userID, savedSearchID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSavedSearch", reflect.TypeOf((*MockISavedSearchService)(nil).UpdateSavedSearch), ctx, r, userID, savedSearchID)
+}
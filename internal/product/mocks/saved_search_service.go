@@ -0,0 +1,143 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/product/usecases/saved_search_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/product/usecases/saved_search_service.go -destination=internal/product/mocks/saved_search_service.go --package=mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockISavedSearchStorage is a mock of ISavedSearchStorage interface.
+type MockISavedSearchStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockISavedSearchStorageMockRecorder
+}
+
+// MockISavedSearchStorageMockRecorder is the mock recorder for MockISavedSearchStorage.
+type MockISavedSearchStorageMockRecorder struct {
+	mock *MockISavedSearchStorage
+}
+
+// NewMockISavedSearchStorage creates a new mock instance.
+func NewMockISavedSearchStorage(ctrl *gomock.Controller) *MockISavedSearchStorage {
+	mock := &MockISavedSearchStorage{ctrl: ctrl}
+	mock.recorder = &MockISavedSearchStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISavedSearchStorage) EXPECT() *MockISavedSearchStorageMockRecorder {
+	return m.recorder
+}
+
+// AddSavedSearch mocks base method.
+func (m *MockISavedSearchStorage) AddSavedSearch(ctx context.Context, preSavedSearch *models.PreSavedSearch) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSavedSearch", ctx, preSavedSearch)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddSavedSearch indicates an expected call of AddSavedSearch.
+func (mr *MockISavedSearchStorageMockRecorder) AddSavedSearch(ctx, preSavedSearch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSavedSearch", reflect.TypeOf((*MockISavedSearchStorage)(nil).AddSavedSearch), ctx, preSavedSearch)
+}
+
+// CheckSavedSearches mocks base method.
+func (m *MockISavedSearchStorage) CheckSavedSearches(ctx context.Context, checkedAt time.Time) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSavedSearches", ctx, checkedAt)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckSavedSearches indicates an expected call of CheckSavedSearches.
+func (mr *MockISavedSearchStorageMockRecorder) CheckSavedSearches(ctx, checkedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSavedSearches", reflect.TypeOf((*MockISavedSearchStorage)(nil).CheckSavedSearches), ctx, checkedAt)
+}
+
+// DeleteSavedSearch mocks base method.
+func (m *MockISavedSearchStorage) DeleteSavedSearch(ctx context.Context, savedSearchID, ownerID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSavedSearch", ctx, savedSearchID, ownerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSavedSearch indicates an expected call of DeleteSavedSearch.
+func (mr *MockISavedSearchStorageMockRecorder) DeleteSavedSearch(ctx, savedSearchID, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSavedSearch", reflect.TypeOf((*MockISavedSearchStorage)(nil).DeleteSavedSearch), ctx, savedSearchID, ownerID)
+}
+
+// GetNotifications mocks base method.
+func (m *MockISavedSearchStorage) GetNotifications(ctx context.Context, ownerID uint64) ([]*models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", ctx, ownerID)
+	ret0, _ := ret[0].([]*models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockISavedSearchStorageMockRecorder) GetNotifications(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockISavedSearchStorage)(nil).GetNotifications), ctx, ownerID)
+}
+
+// GetSavedSearches mocks base method.
+func (m *MockISavedSearchStorage) GetSavedSearches(ctx context.Context, ownerID uint64) ([]*models.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSavedSearches", ctx, ownerID)
+	ret0, _ := ret[0].([]*models.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSavedSearches indicates an expected call of GetSavedSearches.
+func (mr *MockISavedSearchStorageMockRecorder) GetSavedSearches(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSavedSearches", reflect.TypeOf((*MockISavedSearchStorage)(nil).GetSavedSearches), ctx, ownerID)
+}
+
+// ReadNotifications mocks base method.
+func (m *MockISavedSearchStorage) ReadNotifications(ctx context.Context, ownerID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadNotifications", ctx, ownerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReadNotifications indicates an expected call of ReadNotifications.
+func (mr *MockISavedSearchStorageMockRecorder) ReadNotifications(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadNotifications", reflect.TypeOf((*MockISavedSearchStorage)(nil).ReadNotifications), ctx, ownerID)
+}
+
+// UpdateSavedSearch mocks base method.
+func (m *MockISavedSearchStorage) UpdateSavedSearch(ctx context.Context, savedSearchID uint64, preSavedSearch *models.PreSavedSearch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSavedSearch", ctx, savedSearchID, preSavedSearch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSavedSearch indicates an expected call of UpdateSavedSearch.
+func (mr *MockISavedSearchStorageMockRecorder) UpdateSavedSearch(ctx, savedSearchID, preSavedSearch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSavedSearch", reflect.TypeOf((*MockISavedSearchStorage)(nil).UpdateSavedSearch), ctx, savedSearchID, preSavedSearch)
+}
@@ -0,0 +1,456 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/repository"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses/statuses"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	NameSeqPayment = pgx.Identifier{"public", "payment_id_seq"} //nolint:gochecknoglobals
+
+	ErrZeroAmountPayment = myerrors.NewErrorBadContentRequest(
+		"Нельзя оплатить заказы с нулевой стоимостью")
+	ErrNotFoundPayment      = myerrors.NewErrorBadContentRequest("Не получилось найти такой платеж")
+	ErrPaymentWithoutOrders = myerrors.NewErrorInternal("Заказы платежа уже отменены, платеж нужно вернуть")
+)
+
+type sumOrders struct {
+	amount       uint64
+	withSafeDeal bool
+}
+
+func (p *ProductStorage) selectSumOrdersByOrderIDs(ctx context.Context,
+	tx pgx.Tx, orderIDs []uint64,
+) (*sumOrders, error) {
+	logger := p.logger.LogReqID(ctx)
+
+	SQLSelectSumOrders := `SELECT COALESCE(SUM("product".price * "order".count), 0),
+		 COALESCE(BOOL_OR("product".safe_deal), FALSE)
+		 FROM public."order" INNER JOIN "product" ON "order".product_id = "product".id
+		 WHERE "order".id = ANY($1)`
+
+	sumRow := tx.QueryRow(ctx, SQLSelectSumOrders, orderIDs)
+
+	sum := new(sumOrders)
+
+	err := sumRow.Scan(&sum.amount, &sum.withSafeDeal)
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return sum, nil
+}
+
+func (p *ProductStorage) insertPayment(ctx context.Context,
+	tx pgx.Tx, basketPayment *models.BasketPayment,
+) error {
+	logger := p.logger.LogReqID(ctx)
+
+	SQLInsertPayment := `INSERT INTO public."payment"
+		 (owner_id, idempotency_key, amount, capture, status) VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := tx.Exec(ctx, SQLInsertPayment, basketPayment.OwnerID, basketPayment.IdempotencyKey,
+		basketPayment.Amount, basketPayment.Capture, basketPayment.Status)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// attachOrdersToPayment also fixes price of product in orders,
+// so later changes of product don't affect paid amount.
+func (p *ProductStorage) attachOrdersToPayment(ctx context.Context,
+	tx pgx.Tx, orderIDs []uint64, paymentID uint64,
+) error {
+	logger := p.logger.LogReqID(ctx)
+
+	SQLAttachOrdersToPayment := `UPDATE public."order"
+		 SET payment_id=$1, price="product".price
+		 FROM public."product"
+		 WHERE "order".product_id = "product".id AND "order".id = ANY($2)`
+
+	_, err := tx.Exec(ctx, SQLAttachOrdersToPayment, paymentID, orderIDs)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// createPaymentForOrders creates one payment for all orders. If some product has safe deal,
+// payment is two-stage: money are held and captured only for delivered orders.
+func (p *ProductStorage) createPaymentForOrders(ctx context.Context,
+	tx pgx.Tx, userID uint64, orderIDs []uint64, idempotencyKey string,
+) (*models.BasketPayment, error) {
+	logger := p.logger.LogReqID(ctx)
+
+	sum, err := p.selectSumOrdersByOrderIDs(ctx, tx, orderIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if sum.amount == 0 {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, ErrZeroAmountPayment)
+	}
+
+	basketPayment := &models.BasketPayment{
+		ID:             0,
+		OwnerID:        userID,
+		YookassaID:     "",
+		IdempotencyKey: idempotencyKey,
+		Amount:         sum.amount,
+		Capture:        !sum.withSafeDeal,
+		Status:         statuses.IntStatusPaymentPending,
+	}
+
+	err = p.insertPayment(ctx, tx, basketPayment)
+	if err != nil {
+		return nil, err
+	}
+
+	basketPayment.ID, err = repository.GetLastValSeq(ctx, tx, logger, NameSeqPayment)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	err = p.attachOrdersToPayment(ctx, tx, orderIDs, basketPayment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return basketPayment, nil
+}
+
+func (p *ProductStorage) updatePaymentYookassaID(ctx context.Context,
+	tx pgx.Tx, paymentID uint64, yookassaID string,
+) error {
+	logger := p.logger.LogReqID(ctx)
+
+	SQLUpdatePaymentYookassaID := `UPDATE public."payment" SET yookassa_id=$1 WHERE id=$2`
+
+	result, err := tx.Exec(ctx, SQLUpdatePaymentYookassaID, yookassaID, paymentID)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf(myerrors.ErrTemplate, ErrNotFoundPayment)
+	}
+
+	return nil
+}
+
+func (p *ProductStorage) SetPaymentYookassaID(ctx context.Context, paymentID uint64, yookassaID string) error {
+	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
+		return p.updatePaymentYookassaID(ctx, tx, paymentID, yookassaID)
+	})
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+func (p *ProductStorage) selectPaymentStatusForUpdate(ctx context.Context,
+	tx pgx.Tx, paymentID uint64,
+) (uint8, error) {
+	logger := p.logger.LogReqID(ctx)
+
+	SQLSelectPaymentStatus := `SELECT status FROM public."payment" WHERE id=$1 FOR UPDATE`
+
+	paymentRow := tx.QueryRow(ctx, SQLSelectPaymentStatus, paymentID)
+
+	var status uint8
+
+	err := paymentRow.Scan(&status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf(myerrors.ErrTemplate, ErrNotFoundPayment)
+		}
+
+		logger.Errorln(err)
+
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return status, nil
+}
+
+func (p *ProductStorage) updatePaymentStatus(ctx context.Context,
+	tx pgx.Tx, paymentID uint64, status uint8,
+) (uint64, error) {
+	logger := p.logger.LogReqID(ctx)
+
+	SQLUpdatePaymentStatus := `UPDATE public."payment" SET status=$1 WHERE id=$2 RETURNING owner_id`
+
+	paymentRow := tx.QueryRow(ctx, SQLUpdatePaymentStatus, status, paymentID)
+
+	var ownerID uint64
+
+	err := paymentRow.Scan(&ownerID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf(myerrors.ErrTemplate, ErrNotFoundPayment)
+		}
+
+		logger.Errorln(err)
+
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return ownerID, nil
+}
+
+// UpdatePaymentStatus event is status of payment from yookassa, which is applied, nil if there is no one.
+func (p *ProductStorage) UpdatePaymentStatus(ctx context.Context, paymentID uint64, status uint8,
+	event *models.PaymentEvent,
+) error {
+	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
+		err := p.claimPaymentEvent(ctx, tx, event)
+		if err != nil {
+			return err
+		}
+
+		_, err = p.updatePaymentStatus(ctx, tx, paymentID, status)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+type paidOrder struct {
+	id    uint64
+	count uint32
+}
+
+// updateOrdersOfPayment moves orders of payment which are still in processing to toStatus.
+// Orders of cancelled payment are returned to basket, so they are detached from payment.
+func (p *ProductStorage) updateOrdersOfPayment(ctx context.Context,
+	tx pgx.Tx, paymentID uint64, toStatus uint8,
+) ([]paidOrder, error) {
+	logger := p.logger.LogReqID(ctx)
+
+	SQLUpdateOrdersOfPayment := `UPDATE public."order"
+		 SET status=$1, reserved_until=NULL,
+		     payment_id=CASE WHEN $1 = 0 THEN NULL ELSE payment_id END,
+		     price=CASE WHEN $1 = 0 THEN NULL ELSE price END
+		 WHERE payment_id=$2 AND status=$3
+		 RETURNING id, count`
+
+	ordersRows, err := tx.Query(ctx, SQLUpdateOrdersOfPayment,
+		toStatus, paymentID, models.OrderStatusInProcessing)
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	var orders []paidOrder
+
+	var curOrder paidOrder
+
+	_, err = pgx.ForEachRow(ordersRows, []any{&curOrder.id, &curOrder.count}, func() error {
+		orders = append(orders, curOrder)
+
+		return nil
+	})
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return orders, nil
+}
+
+// ConfirmBasketPayment saves status of payment and moves its orders to paid.
+// Repeated confirmation (e.g. held payment was captured) only saves status.
+// If no order is left in processing, money can't be kept: ErrPaymentWithoutOrders
+// is returned and nothing is saved, so caller should return payment to buyer.
+func (p *ProductStorage) ConfirmBasketPayment(ctx context.Context, paymentID uint64, status uint8,
+	event *models.PaymentEvent,
+) error {
+	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
+		err := p.claimPaymentEvent(ctx, tx, event)
+		if err != nil {
+			return err
+		}
+
+		prevStatus, err := p.selectPaymentStatusForUpdate(ctx, tx, paymentID)
+		if err != nil {
+			return err
+		}
+
+		ownerID, err := p.updatePaymentStatus(ctx, tx, paymentID, status)
+		if err != nil {
+			return err
+		}
+
+		if statuses.IsIntStatusPremiumSuccessful(prevStatus) {
+			return nil
+		}
+
+		orders, err := p.updateOrdersOfPayment(ctx, tx, paymentID, models.OrderStatusPaid)
+		if err != nil {
+			return err
+		}
+
+		if len(orders) == 0 {
+			return fmt.Errorf("%w payment id=%d", ErrPaymentWithoutOrders, paymentID)
+		}
+
+		for _, order := range orders {
+			err = p.insertOrderStatusHistory(ctx, tx, &models.PreOrderStatusHistory{
+				OrderID:    order.id,
+				ActorID:    ownerID,
+				ActorRole:  models.OrderActorBuyer,
+				FromStatus: models.OrderStatusInProcessing,
+				ToStatus:   models.OrderStatusPaid,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// CancelBasketPayment saves status of payment, returns its orders in processing to basket
+// and their products to stock.
+func (p *ProductStorage) CancelBasketPayment(ctx context.Context, paymentID uint64, status uint8,
+	event *models.PaymentEvent,
+) error {
+	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
+		err := p.claimPaymentEvent(ctx, tx, event)
+		if err != nil {
+			return err
+		}
+
+		_, err = p.updatePaymentStatus(ctx, tx, paymentID, status)
+		if err != nil {
+			return err
+		}
+
+		orders, err := p.updateOrdersOfPayment(ctx, tx, paymentID, models.OrderStatusInBasket)
+		if err != nil {
+			return err
+		}
+
+		for _, order := range orders {
+			err = p.returnProductsByOrderID(ctx, tx, order.id, order.count)
+			if err != nil {
+				return err
+			}
+
+			err = p.insertOrderStatusHistory(ctx, tx, &models.PreOrderStatusHistory{
+				OrderID:    order.id,
+				ActorID:    0,
+				ActorRole:  models.OrderActorSystem,
+				FromStatus: models.OrderStatusInProcessing,
+				ToStatus:   models.OrderStatusInBasket,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+func (p *ProductStorage) selectHeldPayments(ctx context.Context, tx pgx.Tx) ([]*models.HeldPayment, error) {
+	logger := p.logger.LogReqID(ctx)
+
+	var heldPayments []*models.HeldPayment
+
+	SQLSelectHeldPayments := `SELECT "payment".id, "payment".yookassa_id, "payment".idempotency_key,
+		 COALESCE(SUM("order".price * "order".count) FILTER (WHERE "order".status IN ($1, $2)), 0)
+		 FROM public."payment" INNER JOIN public."order" ON "order".payment_id = "payment".id
+		 WHERE "payment".status=$3 AND "payment".capture=FALSE AND "payment".yookassa_id IS NOT NULL
+		 GROUP BY "payment".id
+		 HAVING BOOL_AND("order".status IN ($1, $2, $4, $5))`
+
+	heldPaymentsRows, err := tx.Query(ctx, SQLSelectHeldPayments,
+		models.OrderStatusDelivered, models.OrderStatusClosed, statuses.IntStatusPaymentWaiting,
+		models.OrderStatusCancelled, models.OrderStatusRefunded)
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	curHeldPayment := new(models.HeldPayment)
+
+	_, err = pgx.ForEachRow(heldPaymentsRows, []any{&curHeldPayment.ID, &curHeldPayment.YookassaID,
+		&curHeldPayment.IdempotencyKey, &curHeldPayment.CaptureAmount}, func() error {
+		heldPayments = append(heldPayments, &models.HeldPayment{
+			ID:             curHeldPayment.ID,
+			YookassaID:     curHeldPayment.YookassaID,
+			IdempotencyKey: curHeldPayment.IdempotencyKey,
+			CaptureAmount:  curHeldPayment.CaptureAmount,
+		})
+
+		return nil
+	})
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return heldPayments, nil
+}
+
+// GetHeldPayments returns two-stage payments waiting for capture which orders are all finished.
+// Only delivered and closed orders are counted in capture amount, money for cancelled
+// and refunded orders are released.
+func (p *ProductStorage) GetHeldPayments(ctx context.Context) ([]*models.HeldPayment, error) {
+	var heldPayments []*models.HeldPayment
+
+	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
+		heldPaymentsInner, err := p.selectHeldPayments(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		heldPayments = heldPaymentsInner
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return heldPayments, nil
+}
@@ -0,0 +1,308 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/repository"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses/statuses"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+	"github.com/pashagolub/pgxmock/v3"
+)
+
+func TestConfirmBasketPayment(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	type TestCase struct {
+		name                   string
+		behaviorProductStorage func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface)
+		expectedError          error
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectQuery(`SELECT status FROM public."payment"`).WithArgs(uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"status"}).AddRow(statuses.IntStatusPaymentPending))
+
+				mockPool.ExpectQuery(`UPDATE public."payment" SET status=\$1 WHERE id=\$2 RETURNING owner_id`).
+					WithArgs(statuses.IntStatusPaymentSucceeded, uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"owner_id"}).AddRow(uint64(2)))
+
+				mockPool.ExpectQuery(`UPDATE public."order"`).
+					WithArgs(uint8(models.OrderStatusPaid), uint64(1), models.OrderStatusInProcessing).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "count"}).
+						AddRow(uint64(1), uint32(2)).AddRow(uint64(3), uint32(1)))
+
+				mockPool.ExpectExec(`INSERT INTO public."order_status_history"`).
+					WithArgs(uint64(1), uint64(2), models.OrderActorBuyer, uint8(1), uint8(2)).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+				mockPool.ExpectExec(`INSERT INTO public."order_status_history"`).
+					WithArgs(uint64(3), uint64(2), models.OrderActorBuyer, uint8(1), uint8(2)).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			expectedError: nil,
+		},
+		{
+			name: "test repeated confirmation",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectQuery(`SELECT status FROM public."payment"`).WithArgs(uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"status"}).AddRow(statuses.IntStatusPaymentWaiting))
+
+				mockPool.ExpectQuery(`UPDATE public."payment" SET status=\$1 WHERE id=\$2 RETURNING owner_id`).
+					WithArgs(statuses.IntStatusPaymentSucceeded, uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"owner_id"}).AddRow(uint64(2)))
+
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			expectedError: nil,
+		},
+		{
+			name: "test orders of payment already cancelled",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectQuery(`SELECT status FROM public."payment"`).WithArgs(uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"status"}).AddRow(statuses.IntStatusPaymentCanceled))
+
+				mockPool.ExpectQuery(`UPDATE public."payment" SET status=\$1 WHERE id=\$2 RETURNING owner_id`).
+					WithArgs(statuses.IntStatusPaymentSucceeded, uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"owner_id"}).AddRow(uint64(2)))
+
+				mockPool.ExpectQuery(`UPDATE public."order"`).
+					WithArgs(uint8(models.OrderStatusPaid), uint64(1), models.OrderStatusInProcessing).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "count"}))
+
+				mockPool.ExpectRollback()
+				mockPool.ExpectRollback()
+			},
+			expectedError: repository.ErrPaymentWithoutOrders,
+		},
+		{
+			name: "test not found payment",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectQuery(`SELECT status FROM public."payment"`).WithArgs(uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"status"}))
+
+				mockPool.ExpectRollback()
+				mockPool.ExpectRollback()
+			},
+			expectedError: repository.ErrNotFoundPayment,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			ctx := context.Background()
+
+			productStorage, err := repository.NewProductStorage(mockPool)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			testCase.behaviorProductStorage(productStorage, mockPool)
+
+			errActual := productStorage.ConfirmBasketPayment(ctx, 1, statuses.IntStatusPaymentSucceeded, nil)
+
+			if err := mockPool.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+
+			err = utils.EqualError(errActual, testCase.expectedError)
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestCancelBasketPayment(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	type TestCase struct {
+		name                   string
+		behaviorProductStorage func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface)
+		expectedError          error
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectQuery(`UPDATE public."payment" SET status=\$1 WHERE id=\$2 RETURNING owner_id`).
+					WithArgs(statuses.IntStatusPaymentCanceled, uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"owner_id"}).AddRow(uint64(2)))
+
+				mockPool.ExpectQuery(`UPDATE public."order"`).
+					WithArgs(uint8(models.OrderStatusInBasket), uint64(1), models.OrderStatusInProcessing).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "count"}).AddRow(uint64(1), uint32(2)))
+
+				mockPool.ExpectExec(`UPDATE public."product"`).WithArgs(uint32(2), uint64(1)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+				mockPool.ExpectExec(`INSERT INTO public."order_status_history"`).
+					WithArgs(uint64(1), uint64(0), models.OrderActorSystem, uint8(1), uint8(0)).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			expectedError: nil,
+		},
+		{
+			name: "test internal error",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectQuery(`UPDATE public."payment" SET status=\$1 WHERE id=\$2 RETURNING owner_id`).
+					WithArgs(statuses.IntStatusPaymentCanceled, uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"owner_id"}).AddRow(uint64(2)))
+
+				mockPool.ExpectQuery(`UPDATE public."order"`).
+					WithArgs(uint8(models.OrderStatusInBasket), uint64(1), models.OrderStatusInProcessing).
+					WillReturnError(repository.ErrNoAffectedOrderRows)
+
+				mockPool.ExpectRollback()
+				mockPool.ExpectRollback()
+			},
+			expectedError: repository.ErrNoAffectedOrderRows,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			ctx := context.Background()
+
+			productStorage, err := repository.NewProductStorage(mockPool)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			testCase.behaviorProductStorage(productStorage, mockPool)
+
+			errActual := productStorage.CancelBasketPayment(ctx, 1, statuses.IntStatusPaymentCanceled, nil)
+
+			if err := mockPool.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+
+			err = utils.EqualError(errActual, testCase.expectedError)
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestGetHeldPayments(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	type TestCase struct {
+		name                   string
+		behaviorProductStorage func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface)
+		expectedHeldPayments   []*models.HeldPayment
+		expectedError          error
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectQuery(`SELECT "payment".id, "payment".yookassa_id, "payment".idempotency_key`).
+					WithArgs(models.OrderStatusDelivered, models.OrderStatusClosed, statuses.IntStatusPaymentWaiting,
+						models.OrderStatusCancelled, models.OrderStatusRefunded).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "yookassa_id", "idempotency_key", "coalesce"}).
+						AddRow(uint64(1), "yookassa_1", "key_1", uint64(1500)).
+						AddRow(uint64(2), "yookassa_2", "key_2", uint64(0)))
+
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			expectedHeldPayments: []*models.HeldPayment{
+				{ID: 1, YookassaID: "yookassa_1", IdempotencyKey: "key_1", CaptureAmount: 1500},
+				{ID: 2, YookassaID: "yookassa_2", IdempotencyKey: "key_2", CaptureAmount: 0},
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			ctx := context.Background()
+
+			productStorage, err := repository.NewProductStorage(mockPool)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			testCase.behaviorProductStorage(productStorage, mockPool)
+
+			heldPayments, errActual := productStorage.GetHeldPayments(ctx)
+
+			if err := mockPool.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+
+			err = utils.EqualError(errActual, testCase.expectedError)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = utils.EqualTest(heldPayments, testCase.expectedHeldPayments)
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
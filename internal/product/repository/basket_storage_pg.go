@@ -4,22 +4,27 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/repository"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses/statuses"
 	"github.com/jackc/pgx/v5"
 )
 
 var (
 	NameSeqOrder = pgx.Identifier{"public", "order_id_seq"} //nolint:gochecknoglobals
 
-	ErrLessStatus              = myerrors.NewErrorBadContentRequest("Статус заказа должен только увеличиваться")
 	ErrNotFoundOrder           = myerrors.NewErrorBadContentRequest("Не получилось найти такой заказ для изменения")
 	ErrNotFoundOrdersInBasket  = myerrors.NewErrorBadContentRequest("Не получилось найти заказы для покупки")
 	ErrNoAffectedOrderRows     = myerrors.NewErrorBadContentRequest("Не получилось обновить данные заказа")
 	ErrAvailableCountNotEnough = myerrors.NewErrorBadContentRequest(
 		"Товара доступно меньше, чем вы пытаетесь довавить в корзину")
+	ErrOrderStatusChanged = myerrors.NewErrorBadContentRequest(
+		"Статус заказа уже был изменен, обновите страницу")
+	ErrOrderPaymentPending = myerrors.NewErrorBadContentRequest(
+		"Заказ ожидает оплаты, отменить его можно после отмены платежа")
 )
 
 func (p *ProductStorage) selectOrdersInBasketByUserID(ctx context.Context,
@@ -350,106 +355,155 @@ func (p *ProductStorage) UpdateOrderCount(ctx context.Context, userID uint64, or
 	return nil
 }
 
+// updateOrderStatusByOrderID changes status only if it still equals fromStatus,
+// so concurrent transitions of the same order can't both succeed.
+// Reservation of order is replaced with reservedUntil, nil means no reservation.
 func (p *ProductStorage) updateOrderStatusByOrderID(ctx context.Context,
-	tx pgx.Tx, orderID uint64, newStatus uint8,
-) error {
+	tx pgx.Tx, orderID uint64, fromStatus uint8, toStatus uint8, reservedUntil *time.Time,
+) (uint32, error) {
 	logger := p.logger.LogReqID(ctx)
 
-	SQLUpdateOrderCountByOrderID := `UPDATE public."order"
-		 SET status=$1
-		 WHERE id=$2`
+	SQLUpdateOrderStatusByOrderID := `UPDATE public."order"
+		 SET status=$1, reserved_until=$4
+		 WHERE id=$2 AND status=$3
+		 RETURNING count`
+
+	orderRow := tx.QueryRow(ctx, SQLUpdateOrderStatusByOrderID, toStatus, orderID, fromStatus, reservedUntil)
 
-	result, err := tx.Exec(ctx, SQLUpdateOrderCountByOrderID, newStatus, orderID)
+	var count uint32
+
+	err := orderRow.Scan(&count)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf(myerrors.ErrTemplate, ErrOrderStatusChanged)
+		}
+
 		logger.Errorln(err)
 
-		return fmt.Errorf(myerrors.ErrTemplate, err)
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	rowsAffected := result.RowsAffected()
-	if rowsAffected == 0 {
-		return fmt.Errorf(myerrors.ErrTemplate, ErrNoAffectedOrderRows)
-	}
-
-	return nil
+	return count, nil
 }
 
-func (p *ProductStorage) getStatusAndCountByOrderID(ctx context.Context,
-	tx pgx.Tx, userID uint64, orderID uint64,
-) (uint8, uint32, error) {
+func (p *ProductStorage) selectOrderParticipantsByOrderID(ctx context.Context,
+	tx pgx.Tx, orderID uint64,
+) (*models.OrderParticipants, error) {
 	logger := p.logger.LogReqID(ctx)
 
-	SQLGetOrderByID := `SELECT status, count
-		 FROM public."order" WHERE owner_id=$1 AND id=$2`
-
-	orderRow := tx.QueryRow(ctx, SQLGetOrderByID, userID, orderID)
+	SQLSelectOrderParticipants := `SELECT "order".id, "order".owner_id, "product".saler_id, "order".status
+		 FROM public."order" INNER JOIN "product" ON "order".product_id = "product".id
+		 WHERE "order".id=$1`
 
-	var status uint8
+	orderRow := tx.QueryRow(ctx, SQLSelectOrderParticipants, orderID)
 
-	var count uint32
+	orderParticipants := new(models.OrderParticipants)
 
-	err := orderRow.Scan(&status, &count)
+	err := orderRow.Scan(&orderParticipants.OrderID, &orderParticipants.OwnerID,
+		&orderParticipants.SalerID, &orderParticipants.Status)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf(myerrors.ErrTemplate, ErrNotFoundOrder)
+		}
+
 		logger.Errorln(err)
 
-		return models.OrderStatusError, 0, fmt.Errorf(myerrors.ErrTemplate, err)
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	return status, count, nil
+	return orderParticipants, nil
 }
 
-func (p *ProductStorage) decreaseAvailableCountByOrderID(ctx context.Context,
-	tx pgx.Tx, orderID uint64, count uint32,
-) error {
+func (p *ProductStorage) GetOrderParticipants(ctx context.Context, orderID uint64) (*models.OrderParticipants, error) {
+	var orderParticipants *models.OrderParticipants
+
+	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
+		orderParticipantsInner, err := p.selectOrderParticipantsByOrderID(ctx, tx, orderID)
+		if err != nil {
+			return err
+		}
+
+		orderParticipants = orderParticipantsInner
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return orderParticipants, nil
+}
+
+// checkOrderPaymentNotPending locks payment of order, so payment can't succeed
+// while order is cancelled. Order without payment can be cancelled.
+func (p *ProductStorage) checkOrderPaymentNotPending(ctx context.Context, tx pgx.Tx, orderID uint64) error {
 	logger := p.logger.LogReqID(ctx)
 
-	SQLDecreaseAvailableCountByOrderID := `UPDATE public."product"
-		 SET available_count = available_count - $1
-		 WHERE id = (
-			SELECT product_id
-			FROM public."order"
-			WHERE id = $2
-		 )`
+	SQLSelectPaymentStatusByOrderID := `SELECT "payment".status
+		 FROM public."order" INNER JOIN public."payment" ON "order".payment_id = "payment".id
+		 WHERE "order".id=$1
+		 FOR UPDATE OF "payment"`
+
+	paymentRow := tx.QueryRow(ctx, SQLSelectPaymentStatusByOrderID, orderID)
 
-	result, err := tx.Exec(ctx, SQLDecreaseAvailableCountByOrderID, count, orderID)
+	var status uint8
+
+	err := paymentRow.Scan(&status)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+
 		logger.Errorln(err)
 
 		return fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	rowsAffected := result.RowsAffected()
-	if rowsAffected == 0 {
-		return fmt.Errorf(myerrors.ErrTemplate, ErrNoAffectedOrderRows)
+	if status == statuses.IntStatusPaymentPending {
+		return fmt.Errorf(myerrors.ErrTemplate, ErrOrderPaymentPending)
 	}
 
 	return nil
 }
 
+// updateOrderStatus expects that transition is already checked by state machine.
+// Products are reserved when order leaves basket until reservedUntil and return to stock
+// when order is cancelled or refunded. Order of pending payment can't be cancelled,
+// otherwise later success of payment would be lost.
 func (p *ProductStorage) updateOrderStatus(ctx context.Context,
-	tx pgx.Tx, userID uint64, orderID uint64, newStatus uint8,
+	tx pgx.Tx, preHistory *models.PreOrderStatusHistory, reservedUntil time.Time,
 ) error {
-	curStatus, count, err := p.getStatusAndCountByOrderID(ctx, tx, userID, orderID)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return fmt.Errorf(myerrors.ErrTemplate, ErrNotFoundOrder)
+	var orderReservedUntil *time.Time
+
+	if preHistory.ToStatus == models.OrderStatusInProcessing {
+		orderReservedUntil = &reservedUntil
+	}
+
+	if preHistory.ToStatus == models.OrderStatusCancelled {
+		err := p.checkOrderPaymentNotPending(ctx, tx, preHistory.OrderID)
+		if err != nil {
+			return err
 		}
+	}
 
+	count, err := p.updateOrderStatusByOrderID(ctx, tx,
+		preHistory.OrderID, preHistory.FromStatus, preHistory.ToStatus, orderReservedUntil)
+	if err != nil {
 		return err
 	}
 
-	if newStatus <= curStatus {
-		return fmt.Errorf(myerrors.ErrTemplate, ErrLessStatus)
+	switch {
+	case preHistory.FromStatus == models.OrderStatusInBasket:
+		err = p.reserveProductsByOrderID(ctx, tx, preHistory.OrderID, count)
+	case preHistory.ToStatus == models.OrderStatusCancelled || preHistory.ToStatus == models.OrderStatusRefunded:
+		err = p.returnProductsByOrderID(ctx, tx, preHistory.OrderID, count)
 	}
 
-	if curStatus == 0 {
-		err = p.decreaseAvailableCountByOrderID(ctx, tx, orderID, count)
-		if err != nil {
-			return err
-		}
+	if err != nil {
+		return err
 	}
 
-	err = p.updateOrderStatusByOrderID(ctx, tx, orderID, newStatus)
+	err = p.insertOrderStatusHistory(ctx, tx, preHistory)
 	if err != nil {
 		return err
 	}
@@ -458,10 +512,10 @@ func (p *ProductStorage) updateOrderStatus(ctx context.Context,
 }
 
 func (p *ProductStorage) UpdateOrderStatus(ctx context.Context,
-	userID uint64, orderID uint64, newStatus uint8,
+	preHistory *models.PreOrderStatusHistory, reservedUntil time.Time,
 ) error {
 	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
-		err := p.updateOrderStatus(ctx, tx, userID, orderID, newStatus)
+		err := p.updateOrderStatus(ctx, tx, preHistory, reservedUntil)
 		if err != nil {
 			return err
 		}
@@ -542,20 +596,25 @@ func (p *ProductStorage) AddOrderInBasket(ctx context.Context,
 	return orderInBasket, nil
 }
 
-func (p *ProductStorage) updateStatusFullBasket(ctx context.Context, tx pgx.Tx, userID uint64) error {
+// updateStatusFullBasket reserves products of all orders in basket. Orders are sorted by product
+// so concurrent checkouts lock products in the same order. If some products are not enough,
+// error contains all of them. Returns ids of reserved orders.
+func (p *ProductStorage) updateStatusFullBasket(ctx context.Context,
+	tx pgx.Tx, userID uint64, reservedUntil time.Time,
+) ([]uint64, error) {
 	logger := p.logger.LogReqID(ctx)
 
-	SQLSelectFullBasket := `SELECT id FROM public."order" WHERE owner_id=$1 AND status=0`
+	SQLSelectFullBasket := `SELECT id FROM public."order" WHERE owner_id=$1 AND status=0 ORDER BY product_id`
 
 	rows, err := tx.Query(ctx, SQLSelectFullBasket, userID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return ErrNotFoundOrdersInBasket
+			return nil, ErrNotFoundOrdersInBasket
 		}
 
 		logger.Errorln(err)
 
-		return fmt.Errorf(myerrors.ErrTemplate, err)
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
 	var orderID uint64
@@ -570,35 +629,70 @@ func (p *ProductStorage) updateStatusFullBasket(ctx context.Context, tx pgx.Tx,
 	if err != nil {
 		logger.Errorln(err)
 
-		return fmt.Errorf(myerrors.ErrTemplate, err)
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if len(slOrderID) == 0 {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, ErrNotFoundOrdersInBasket)
 	}
 
+	var errsNotEnoughProducts []error
+
 	for _, val := range slOrderID {
-		err = p.updateOrderStatus(ctx, tx, userID, val, models.OrderStatusInProcessing)
+		err = p.updateOrderStatus(ctx, tx, &models.PreOrderStatusHistory{
+			OrderID:    val,
+			ActorID:    userID,
+			ActorRole:  models.OrderActorBuyer,
+			FromStatus: models.OrderStatusInBasket,
+			ToStatus:   models.OrderStatusInProcessing,
+		}, reservedUntil)
 		if err != nil {
+			if errors.Is(err, ErrNotEnoughProductsForOrder) {
+				errsNotEnoughProducts = append(errsNotEnoughProducts, err)
+
+				continue
+			}
+
 			logger.Errorln(err)
 
-			return fmt.Errorf(myerrors.ErrTemplate, err)
+			return nil, fmt.Errorf(myerrors.ErrTemplate, err)
 		}
 	}
 
-	return nil
+	if len(errsNotEnoughProducts) != 0 {
+		return nil, errors.Join(errsNotEnoughProducts...)
+	}
+
+	return slOrderID, nil
 }
 
-func (p *ProductStorage) BuyFullBasket(ctx context.Context, userID uint64) error {
+// BuyFullBasket reserves products of all orders in basket and creates one payment for them.
+// Orders stay in processing until payment is confirmed or cancelled.
+func (p *ProductStorage) BuyFullBasket(ctx context.Context,
+	userID uint64, reservedUntil time.Time, idempotencyKey string,
+) (*models.BasketPayment, error) {
+	var basketPayment *models.BasketPayment
+
 	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
-		err := p.updateStatusFullBasket(ctx, tx, userID)
+		orderIDs, err := p.updateStatusFullBasket(ctx, tx, userID, reservedUntil)
+		if err != nil {
+			return err
+		}
+
+		basketPaymentInner, err := p.createPaymentForOrders(ctx, tx, userID, orderIDs, idempotencyKey)
 		if err != nil {
 			return err
 		}
 
+		basketPayment = basketPaymentInner
+
 		return nil
 	})
 	if err != nil {
-		return fmt.Errorf(myerrors.ErrTemplate, err)
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	return nil
+	return basketPayment, nil
 }
 
 func (p *ProductStorage) deleteOrderByOrderIDAndOwnerID(ctx context.Context,
@@ -184,12 +184,12 @@ func TestUpdateOrderStatus(t *testing.T) {
 
 	_ = mylogger.NewNop()
 
+	testReservedUntil := time.Now()
+
 	type TestCase struct {
 		name                   string
 		behaviorProductStorage func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface)
-		ownerID                uint64
-		orderID                uint64
-		newStatus              uint8
+		preHistory             *models.PreOrderStatusHistory
 		expectedError          error
 	}
 
@@ -199,85 +199,151 @@ func TestUpdateOrderStatus(t *testing.T) {
 			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
 				mockPool.ExpectBegin()
 
-				mockPool.ExpectQuery(`SELECT status, count FROM public."order"`).WithArgs(uint64(1), uint64(1)).
-					WillReturnRows(pgxmock.NewRows([]string{"status", "count"}).
-						AddRow(uint8(1), uint32(1)))
+				mockPool.ExpectQuery(`UPDATE public."order"`).
+					WithArgs(uint8(2), uint64(1), uint8(1), (*time.Time)(nil)).
+					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(uint32(1)))
 
-				mockPool.ExpectExec(`UPDATE public."order"`).WithArgs(uint8(2), uint64(1)).
-					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				mockPool.ExpectExec(`INSERT INTO public."order_status_history"`).
+					WithArgs(uint64(1), uint64(1), models.OrderActorBuyer, uint8(1), uint8(2)).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
 				mockPool.ExpectCommit()
 				mockPool.ExpectRollback()
 			},
-			ownerID:       1,
-			orderID:       1,
-			newStatus:     2,
+			preHistory: &models.PreOrderStatusHistory{
+				OrderID: 1, ActorID: 1, ActorRole: models.OrderActorBuyer, FromStatus: 1, ToStatus: 2,
+			},
 			expectedError: nil,
 		},
 		{
-			name: "test status default not 0",
+			name: "test order leaves basket",
 			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
 				mockPool.ExpectBegin()
 
-				mockPool.ExpectQuery(`SELECT status, count FROM public."order"`).WithArgs(uint64(1), uint64(1)).
-					WillReturnRows(pgxmock.NewRows([]string{"status", "count"}).
-						AddRow(uint8(0), uint32(1)))
+				mockPool.ExpectQuery(`UPDATE public."order"`).
+					WithArgs(uint8(1), uint64(1), uint8(0), &testReservedUntil).
+					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(uint32(3)))
 
-				mockPool.ExpectExec(`UPDATE public."product"`).WithArgs(uint32(1), uint64(1)).
-					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				mockPool.ExpectQuery(`SELECT "product".id, "product".title, "product".available_count`).
+					WithArgs(uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "title", "available_count"}).
+						AddRow(uint64(2), "Телефон", uint32(3)))
 
-				mockPool.ExpectExec(`UPDATE public."order"`).WithArgs(uint8(2), uint64(1)).
+				mockPool.ExpectExec(`UPDATE public."product"`).WithArgs(uint32(3), uint64(2)).
 					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
 
+				mockPool.ExpectExec(`INSERT INTO public."order_status_history"`).
+					WithArgs(uint64(1), uint64(1), models.OrderActorBuyer, uint8(0), uint8(1)).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
 				mockPool.ExpectCommit()
 				mockPool.ExpectRollback()
 			},
-			ownerID:       1,
-			orderID:       1,
-			newStatus:     2,
+			preHistory: &models.PreOrderStatusHistory{
+				OrderID: 1, ActorID: 1, ActorRole: models.OrderActorBuyer, FromStatus: 0, ToStatus: 1,
+			},
 			expectedError: nil,
 		},
 		{
-			name: "test status default not 0",
+			name: "test not enough products for order",
 			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
 				mockPool.ExpectBegin()
 
-				mockPool.ExpectQuery(`SELECT status, count FROM public."order"`).WithArgs(uint64(1), uint64(1)).
-					WillReturnRows(pgxmock.NewRows([]string{"status", "count"}).
-						AddRow(uint8(0), uint32(1)))
+				mockPool.ExpectQuery(`UPDATE public."order"`).
+					WithArgs(uint8(1), uint64(1), uint8(0), &testReservedUntil).
+					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(uint32(3)))
 
-				mockPool.ExpectExec(`UPDATE public."product"`).WithArgs(uint32(1), uint64(1)).
-					WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+				mockPool.ExpectQuery(`SELECT "product".id, "product".title, "product".available_count`).
+					WithArgs(uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "title", "available_count"}).
+						AddRow(uint64(2), "Телефон", uint32(1)))
 
 				mockPool.ExpectRollback()
 				mockPool.ExpectRollback()
 			},
-			ownerID:       1,
-			orderID:       1,
-			newStatus:     2,
-			expectedError: repository.ErrNoAffectedOrderRows,
+			preHistory: &models.PreOrderStatusHistory{
+				OrderID: 1, ActorID: 1, ActorRole: models.OrderActorBuyer, FromStatus: 0, ToStatus: 1,
+			},
+			expectedError: repository.ErrNotEnoughProductsForOrder,
 		},
 		{
-			name: "test status default not 0",
+			name: "test cancelled order returns products",
 			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
 				mockPool.ExpectBegin()
 
-				mockPool.ExpectQuery(`SELECT status, count FROM public."order"`).WithArgs(uint64(1), uint64(1)).
-					WillReturnRows(pgxmock.NewRows([]string{"status", "count"}).
-						AddRow(uint8(0), uint32(1)))
+				mockPool.ExpectQuery(`SELECT "payment".status`).WithArgs(uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"status"}))
 
-				mockPool.ExpectExec(`UPDATE public."product"`).WithArgs(uint32(1), uint64(1)).
+				mockPool.ExpectQuery(`UPDATE public."order"`).
+					WithArgs(uint8(6), uint64(1), uint8(1), (*time.Time)(nil)).
+					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(uint32(3)))
+
+				mockPool.ExpectExec(`UPDATE public."product"`).WithArgs(uint32(3), uint64(1)).
 					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
 
-				mockPool.ExpectExec(`UPDATE public."order"`).WithArgs(uint8(2), uint64(1)).
+				mockPool.ExpectExec(`INSERT INTO public."order_status_history"`).
+					WithArgs(uint64(1), uint64(2), models.OrderActorSeller, uint8(1), uint8(6)).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			preHistory: &models.PreOrderStatusHistory{
+				OrderID: 1, ActorID: 2, ActorRole: models.OrderActorSeller, FromStatus: 1, ToStatus: 6,
+			},
+			expectedError: nil,
+		},
+		{
+			name: "test order of pending payment isn't cancelled",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectQuery(`SELECT "payment".status`).WithArgs(uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"status"}).AddRow(statuses.IntStatusPaymentPending))
+
+				mockPool.ExpectRollback()
+				mockPool.ExpectRollback()
+			},
+			preHistory: &models.PreOrderStatusHistory{
+				OrderID: 1, ActorID: 1, ActorRole: models.OrderActorBuyer, FromStatus: 1, ToStatus: 6,
+			},
+			expectedError: repository.ErrOrderPaymentPending,
+		},
+		{
+			name: "test status already changed",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectQuery(`UPDATE public."order"`).
+					WithArgs(uint8(2), uint64(1), uint8(1), (*time.Time)(nil)).
+					WillReturnRows(pgxmock.NewRows([]string{"count"}))
+
+				mockPool.ExpectRollback()
+				mockPool.ExpectRollback()
+			},
+			preHistory: &models.PreOrderStatusHistory{
+				OrderID: 1, ActorID: 1, ActorRole: models.OrderActorBuyer, FromStatus: 1, ToStatus: 2,
+			},
+			expectedError: repository.ErrOrderStatusChanged,
+		},
+		{
+			name: "test product not affected",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectQuery(`UPDATE public."order"`).
+					WithArgs(uint8(7), uint64(1), uint8(2), (*time.Time)(nil)).
+					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(uint32(1)))
+
+				mockPool.ExpectExec(`UPDATE public."product"`).WithArgs(uint32(1), uint64(1)).
 					WillReturnResult(pgxmock.NewResult("UPDATE", 0))
 
 				mockPool.ExpectRollback()
 				mockPool.ExpectRollback()
 			},
-			ownerID:       1,
-			orderID:       1,
-			newStatus:     2,
+			preHistory: &models.PreOrderStatusHistory{
+				OrderID: 1, ActorID: 2, ActorRole: models.OrderActorSeller, FromStatus: 2, ToStatus: 7,
+			},
 			expectedError: repository.ErrNoAffectedOrderRows,
 		},
 	}
@@ -302,10 +368,90 @@ func TestUpdateOrderStatus(t *testing.T) {
 
 			testCase.behaviorProductStorage(catStorage, mockPool)
 
-			errActual := catStorage.UpdateOrderStatus(ctx, testCase.ownerID, testCase.orderID, testCase.newStatus)
+			errActual := catStorage.UpdateOrderStatus(ctx, testCase.preHistory, testReservedUntil)
+
+			if err := mockPool.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+
+			err = utils.EqualError(errActual, testCase.expectedError)
 			if err != nil {
 				t.Fatal(err)
 			}
+		})
+	}
+}
+
+func TestGetOrderParticipants(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	type TestCase struct {
+		name                   string
+		behaviorProductStorage func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface)
+		orderID                uint64
+		expectedResponse       *models.OrderParticipants
+		expectedError          error
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectQuery(`SELECT "order".id, "order".owner_id, "product".saler_id, "order".status`).
+					WithArgs(uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "owner_id", "saler_id", "status"}).
+						AddRow(uint64(1), uint64(1), uint64(2), uint8(2)))
+
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			orderID:          1,
+			expectedResponse: &models.OrderParticipants{OrderID: 1, OwnerID: 1, SalerID: 2, Status: 2},
+			expectedError:    nil,
+		},
+		{
+			name: "test not found order",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectQuery(`SELECT "order".id, "order".owner_id, "product".saler_id, "order".status`).
+					WithArgs(uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "owner_id", "saler_id", "status"}))
+
+				mockPool.ExpectRollback()
+				mockPool.ExpectRollback()
+			},
+			orderID:          1,
+			expectedResponse: nil,
+			expectedError:    repository.ErrNotFoundOrder,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			ctx := context.Background()
+
+			catStorage, err := repository.NewProductStorage(mockPool)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			testCase.behaviorProductStorage(catStorage, mockPool)
+
+			orderParticipants, errActual := catStorage.GetOrderParticipants(ctx, testCase.orderID)
 
 			if err := mockPool.ExpectationsWereMet(); err != nil {
 				t.Errorf("there were unfulfilled expectations: %s", err)
@@ -315,6 +461,11 @@ func TestUpdateOrderStatus(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
+
+			err = utils.EqualTest(orderParticipants, testCase.expectedResponse)
+			if err != nil {
+				t.Fatal(err)
+			}
 		})
 	}
 }
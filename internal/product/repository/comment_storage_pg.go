@@ -17,38 +17,47 @@ var (
 	NameSeqComment = pgx.Identifier{"public", "comment_id_seq"} //nolint:gochecknoglobals
 )
 
+// getCommentList returns comments of recipient, comment of sender is placed first.
+// Comments are sorted by (score DESC, id DESC) where score is 1 for comment of sender and 0 for others.
 func (p *ProductStorage) getCommentList(ctx context.Context,
-	tx pgx.Tx, offset uint64, count uint64, recipientID uint64, senderID uint64,
-) ([]*models.CommentInFeed, error) {
+	tx pgx.Tx, cursor *models.Cursor, count uint64, recipientID uint64, senderID uint64,
+) ([]*models.CommentInFeed, *models.Cursor, error) {
 	logger := p.logger.LogReqID(ctx)
 
 	var comments []*models.CommentInFeed
 
-	SQLGetCommentList := `SELECT c.id AS comment_id, c.sender_id,
-       CASE WHEN u.name IS NOT NULL THEN u.name ELSE u.email END,
-       u.avatar,
-       c.text,
-       c.rating,
-       c.created_at
-FROM public."comment" c
-         JOIN public."user" u ON u.id = c.sender_id
-WHERE c.recipient_id = $1
-ORDER BY (c.sender_id = $2) DESC
-LIMIT $3
-OFFSET $4;`
-
-	commentsRows, err := tx.Query(ctx, SQLGetCommentList, recipientID, senderID, count, offset)
+	score := squirrel.Expr("(c.sender_id = ?)::int::float8", senderID)
+
+	query := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).
+		Select("c.id AS comment_id, c.sender_id",
+			"CASE WHEN u.name IS NOT NULL THEN u.name ELSE u.email END",
+			"u.avatar, c.text, c.rating, c.created_at").
+		Column(squirrel.Alias(score, "score")).
+		From(`public."comment" c`).Join(`public."user" u ON u.id = c.sender_id`).
+		Where(squirrel.Eq{"c.recipient_id": recipientID}).Where(whereClauseAfterCursor(score, "c.id", cursor)).
+		OrderBy("score DESC", "c.id DESC").Limit(count)
+
+	SQLGetCommentList, args, err := query.ToSql()
 	if err != nil {
 		logger.Errorln(err)
 
-		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+		return nil, nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	commentsRows, err := tx.Query(ctx, SQLGetCommentList, args...)
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
 	curComment := new(models.CommentInFeed)
 
+	var curScore float64
+
 	_, err = pgx.ForEachRow(commentsRows, []any{
 		&curComment.ID, &curComment.SenderID, &curComment.SenderName, &curComment.Avatar,
-		&curComment.Text, &curComment.Rating, &curComment.CreatedAt,
+		&curComment.Text, &curComment.Rating, &curComment.CreatedAt, &curScore,
 	}, func() error {
 		comments = append(comments, &models.CommentInFeed{
 			ID:         curComment.ID,
@@ -65,36 +74,39 @@ OFFSET $4;`
 	if err != nil {
 		logger.Errorln(err)
 
-		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+		return nil, nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	return comments, nil
+	return comments, nextCursor(len(comments), count, curScore, curComment.ID), nil
 }
 
 func (p *ProductStorage) GetCommentList(ctx context.Context,
-	offset uint64, count uint64, recipientID uint64, senderID uint64,
-) ([]*models.CommentInFeed, error) {
+	cursor *models.Cursor, count uint64, recipientID uint64, senderID uint64,
+) ([]*models.CommentInFeed, *models.Cursor, error) {
 	logger := p.logger.LogReqID(ctx)
 
 	var slComments []*models.CommentInFeed
 
+	var next *models.Cursor
+
 	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
-		slCommentsInner, err := p.getCommentList(ctx, tx, offset, count, recipientID, senderID)
+		slCommentsInner, nextInner, err := p.getCommentList(ctx, tx, cursor, count, recipientID, senderID)
 		if err != nil {
 			return err
 		}
 
 		slComments = slCommentsInner
+		next = nextInner
 
 		return nil
 	})
 	if err != nil {
 		logger.Errorln(err)
 
-		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+		return nil, nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	return slComments, nil
+	return slComments, next, nil
 }
 
 func (p *ProductStorage) insertComment(ctx context.Context, tx pgx.Tx, preComment *models.PreComment) error {
@@ -196,9 +196,10 @@ func TestGetCommentList(t *testing.T) {
 		behaviorProductStorage func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface)
 		resID                  uint64
 		senderID               uint64
-		offset                 uint64
+		cursor                 *models.Cursor
 		count                  uint64
 		expectedResponse       []*models.CommentInFeed
+		expectedNextCursor     *models.Cursor
 	}
 
 	testCases := [...]TestCase{
@@ -207,25 +208,25 @@ func TestGetCommentList(t *testing.T) {
 			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
 				mockPool.ExpectBegin()
 
-				mockPool.ExpectQuery(`SELECT c.id AS comment_id, c.sender_id, 
-       CASE WHEN u.name IS NOT NULL THEN u.name ELSE u.email END,
-       u.avatar,
-       c.text,
-       c.rating,
-       c.created_at
-FROM public."comment" c`).WithArgs(uint64(1), uint64(2), uint64(1), uint64(1)).
+				mockPool.ExpectQuery(`SELECT c.id AS comment_id, c.sender_id, `+
+					`CASE WHEN u.name IS NOT NULL THEN u.name ELSE u.email END, `+
+					`u.avatar, c.text, c.rating, c.created_at, \(\(c.sender_id = \$1\)::int::float8\) AS score `+
+					`FROM public."comment" c JOIN public."user" u ON u.id = c.sender_id `+
+					`WHERE c.recipient_id = \$2 AND true ORDER BY score DESC, c.id DESC LIMIT 1`).
+					WithArgs(uint64(2), uint64(1)).
 					WillReturnRows(pgxmock.NewRows([]string{
 						"comment_id", "sender_id", "name", "avatar",
-						"text", "rating", "created_at",
+						"text", "rating", "created_at", "score",
 					}).
-						AddRow(uint64(1), uint64(2), "Ivan", sql.NullString{Valid: false, String: ""}, "good", uint8(5), time.Time{}))
+						AddRow(uint64(1), uint64(2), "Ivan", sql.NullString{Valid: false, String: ""}, "good", uint8(5),
+							time.Time{}, float64(1)))
 
 				mockPool.ExpectCommit()
 				mockPool.ExpectRollback()
 			},
 			resID:    1,
 			senderID: 2,
-			offset:   1,
+			cursor:   nil,
 			count:    1,
 			expectedResponse: []*models.CommentInFeed{
 				{
@@ -233,71 +234,66 @@ FROM public."comment" c`).WithArgs(uint64(1), uint64(2), uint64(1), uint64(1)).
 					Text: "good", Rating: 5, CreatedAt: time.Time{},
 				},
 			},
+			expectedNextCursor: models.NewCursor(1, 1),
 		},
 		{
 			name: "test empty",
 			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
 				mockPool.ExpectBegin()
 
-				mockPool.ExpectQuery(`SELECT c.id AS comment_id, c.sender_id, 
-       CASE WHEN u.name IS NOT NULL THEN u.name ELSE u.email END,
-       u.avatar,
-       c.text,
-       c.rating,
-       c.created_at
-FROM public."comment" c`).WithArgs(uint64(1), uint64(2), uint64(1), uint64(1)).
+				mockPool.ExpectQuery(`SELECT c.id AS comment_id, c.sender_id, `+
+					`CASE WHEN u.name IS NOT NULL THEN u.name ELSE u.email END, `+
+					`u.avatar, c.text, c.rating, c.created_at, \(\(c.sender_id = \$1\)::int::float8\) AS score `+
+					`FROM public."comment" c JOIN public."user" u ON u.id = c.sender_id `+
+					`WHERE c.recipient_id = \$2 AND \(\(c.sender_id = \$3\)::int::float8, c.id\) `+
+					`< \(\$4::float8, \$5::bigint\) ORDER BY score DESC, c.id DESC LIMIT 1`).
+					WithArgs(uint64(2), uint64(1), uint64(2), float64(0), uint64(4)).
 					WillReturnRows(pgxmock.NewRows([]string{}))
 
 				mockPool.ExpectCommit()
 				mockPool.ExpectRollback()
 			},
-			resID:            1,
-			senderID:         2,
-			offset:           1,
-			count:            1,
-			expectedResponse: nil,
+			resID:              1,
+			senderID:           2,
+			cursor:             models.NewCursor(0, 4),
+			count:              1,
+			expectedResponse:   nil,
+			expectedNextCursor: nil,
 		},
 		{
 			name: "test more rows",
 			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
 				mockPool.ExpectBegin()
 
-				mockPool.ExpectQuery(`SELECT c.id AS comment_id, c.sender_id, 
-       CASE WHEN u.name IS NOT NULL THEN u.name ELSE u.email END,
-       u.avatar,
-       c.text,
-       c.rating,
-       c.created_at
-FROM public."comment" c`).WithArgs(uint64(1), uint64(2), uint64(1), uint64(1)).
+				mockPool.ExpectQuery(`SELECT c.id AS comment_id, c.sender_id`).
+					WithArgs(uint64(2), uint64(1), uint64(2), float64(1), uint64(9)).
 					WillReturnRows(pgxmock.NewRows([]string{
 						"comment_id", "sender_id", "name", "avatar",
-						"text", "rating", "created_at",
+						"text", "rating", "created_at", "score",
 					}).
-						AddRow(uint64(1), uint64(2), "Ivan", sql.NullString{Valid: false, String: ""}, "good", uint8(5), time.Time{}).
-						AddRow(uint64(2), uint64(3), "Petr", sql.NullString{Valid: false, String: ""}, "bad", uint8(2), time.Time{}).
-						AddRow(uint64(3), uint64(4), "Mark", sql.NullString{Valid: false, String: ""}, "not bad", uint8(3), time.Time{}))
+						AddRow(uint64(3), uint64(4), "Mark", sql.NullString{Valid: false, String: ""}, "not bad", uint8(3),
+							time.Time{}, float64(0)).
+						AddRow(uint64(2), uint64(3), "Petr", sql.NullString{Valid: false, String: ""}, "bad", uint8(2),
+							time.Time{}, float64(0)))
 
 				mockPool.ExpectCommit()
 				mockPool.ExpectRollback()
 			},
 			resID:    1,
 			senderID: 2,
-			offset:   1,
-			count:    1,
+			cursor:   models.NewCursor(1, 9),
+			count:    3,
 			expectedResponse: []*models.CommentInFeed{
 				{
-					ID: 1, SenderID: 2, SenderName: "Ivan", Avatar: sql.NullString{Valid: false, String: ""},
-					Text: "good", Rating: 5, CreatedAt: time.Time{},
+					ID: 3, SenderID: 4, SenderName: "Mark", Avatar: sql.NullString{Valid: false, String: ""},
+					Text: "not bad", Rating: 3, CreatedAt: time.Time{},
 				},
 				{
 					ID: 2, SenderID: 3, SenderName: "Petr", Avatar: sql.NullString{Valid: false, String: ""},
 					Text: "bad", Rating: 2, CreatedAt: time.Time{},
 				},
-				{
-					ID: 3, SenderID: 4, SenderName: "Mark", Avatar: sql.NullString{Valid: false, String: ""},
-					Text: "not bad", Rating: 3, CreatedAt: time.Time{},
-				},
 			},
+			expectedNextCursor: nil,
 		},
 	}
 
@@ -321,7 +317,7 @@ FROM public."comment" c`).WithArgs(uint64(1), uint64(2), uint64(1), uint64(1)).
 
 			testCase.behaviorProductStorage(commentStorage, mockPool)
 
-			response, err := commentStorage.GetCommentList(ctx, testCase.offset, testCase.count,
+			response, nextCursor, err := commentStorage.GetCommentList(ctx, testCase.cursor, testCase.count,
 				testCase.resID, testCase.senderID)
 			if err != nil {
 				t.Fatal(err)
@@ -335,6 +331,11 @@ FROM public."comment" c`).WithArgs(uint64(1), uint64(2), uint64(1), uint64(1)).
 			if err != nil {
 				t.Fatal(err)
 			}
+
+			err = utils.EqualTest(nextCursor, testCase.expectedNextCursor)
+			if err != nil {
+				t.Fatal(err)
+			}
 		})
 	}
 }
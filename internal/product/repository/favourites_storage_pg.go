@@ -71,19 +71,13 @@ func (p *ProductStorage) GetUserFavourites(ctx context.Context, userID uint64) (
 			return err
 		}
 
-		for _, product := range slProductInner {
-			productAdditionInner, err := p.getProductAddition(ctx, tx, product.ID, userID)
-			if err != nil {
-				return err
-			}
-
-			product.Images = productAdditionInner.images
-			product.Favourites = productAdditionInner.favourites
-			product.InFavourites = productAdditionInner.inFavourite
-
-			slProduct = append(slProduct, product)
+		err = p.fillProductsAddition(ctx, tx, slProductInner, userID)
+		if err != nil {
+			return err
 		}
 
+		slProduct = slProductInner
+
 		return nil
 	})
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/repository"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
 	"github.com/pashagolub/pgxmock/v3"
@@ -154,3 +155,112 @@ func TestAddToFavourites(t *testing.T) {
 		})
 	}
 }
+
+func TestGetUserFavourites(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	type TestCase struct {
+		name                   string
+		behaviorProductStorage func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface)
+		userID                 uint64
+		expectedResponse       []*models.ProductInFeed
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectQuery(`SELECT p.id, p.title, p.price, p.city_id`).WithArgs(uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{
+						"id", "title", "price", "city_id",
+						"delivery", "safe_deal", "is_active", "available_count",
+					}).
+						AddRow(uint64(2), "Car", uint64(100), uint64(6), true, true, true, uint32(1)).
+						AddRow(uint64(5), "Cat", uint64(200), uint64(6), false, false, true, uint32(3)))
+
+				mockPool.ExpectQuery(`SELECT product_id, url FROM public."image" WHERE product_id = ANY`).
+					WithArgs([]uint64{2, 5}).
+					WillReturnRows(pgxmock.NewRows([]string{"product_id", "url"}).
+						AddRow(uint64(2), "car1").AddRow(uint64(2), "car2"))
+
+				mockPool.ExpectQuery(`SELECT product_id, COUNT\(id\) FROM public."favourite"`).
+					WithArgs([]uint64{2, 5}).
+					WillReturnRows(pgxmock.NewRows([]string{"product_id", "count"}).
+						AddRow(uint64(2), uint64(1)).AddRow(uint64(5), uint64(4)))
+
+				mockPool.ExpectQuery(`SELECT product_id FROM public."favourite" WHERE owner_id`).
+					WithArgs(uint64(1), []uint64{2, 5}).
+					WillReturnRows(pgxmock.NewRows([]string{"product_id"}).
+						AddRow(uint64(2)).AddRow(uint64(5)))
+
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			userID: 1,
+			expectedResponse: []*models.ProductInFeed{
+				{
+					ID: 2, Title: "Car", Price: 100, CityID: 6, Delivery: true, SafeDeal: true, IsActive: true,
+					AvailableCount: 1, Images: []models.Image{{URL: "car1"}, {URL: "car2"}},
+					Favourites: 1, InFavourites: true,
+				},
+				{
+					ID: 5, Title: "Cat", Price: 200, CityID: 6, IsActive: true,
+					AvailableCount: 3, Favourites: 4, InFavourites: true,
+				},
+			},
+		},
+		{
+			name: "test empty",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectQuery(`SELECT p.id, p.title, p.price, p.city_id`).WithArgs(uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{}))
+
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			userID:           1,
+			expectedResponse: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			mockPool, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			prodStorage, err := repository.NewProductStorage(mockPool)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			testCase.behaviorProductStorage(prodStorage, mockPool)
+
+			response, err := prodStorage.GetUserFavourites(ctx, testCase.userID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := utils.EqualTest(response, testCase.expectedResponse); err != nil {
+				t.Fatalf("Failed EqualTest %+v", err)
+			}
+
+			if err := mockPool.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -3,16 +3,39 @@ package repository
 import (
 	"fmt"
 
+	"github.com/Masterminds/squirrel"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses/statuses"
 )
 
-func OrderByClauseForProductList(premiumCoefficient, nonPremiumCoefficient,
+// ScoreForProductList returns expression of product popularity. It's casted to float8
+// because score is stored in models.Cursor for keyset pagination.
+func ScoreForProductList(premiumCoefficient, nonPremiumCoefficient,
 	soldByUserCoefficient, viewsCoefficient uint16) string { //nolint: gofumpt
-	return fmt.Sprintf(`CASE
+	return fmt.Sprintf(`(CASE
 		WHEN premium_status = %d THEN ((%d * views + %d * (SELECT COUNT(*) FROM product p2
-		WHERE p2.saler_id = product.saler_id)) * %d) 
+		WHERE p2.saler_id = product.saler_id)) * %d)
 		ELSE ((%d * views + %d * (SELECT COUNT(*) FROM product p2
-		WHERE p2.saler_id = product.saler_id)) * %d) 
-		END DESC`, statuses.IntStatusPremiumSucceeded, premiumCoefficient, soldByUserCoefficient, premiumCoefficient,
+		WHERE p2.saler_id = product.saler_id)) * %d)
+		END)::float8`, statuses.IntStatusPremiumSucceeded, premiumCoefficient, soldByUserCoefficient, premiumCoefficient,
 		viewsCoefficient, soldByUserCoefficient, nonPremiumCoefficient)
 }
+
+// whereClauseAfterCursor returns condition for rows which are placed after cursor
+// in order (score DESC, idColumn DESC). Nil cursor means the first page.
+func whereClauseAfterCursor(score squirrel.Sqlizer, idColumn string, cursor *models.Cursor) squirrel.Sqlizer {
+	if cursor == nil {
+		return squirrel.Expr("true")
+	}
+
+	return squirrel.Expr("(?, "+idColumn+") < (?::float8, ?::bigint)", score, cursor.Score, cursor.ID)
+}
+
+// nextCursor returns cursor of the last row if page is full, otherwise nil that means there is no next page.
+func nextCursor(lenPage int, limit uint64, lastScore float64, lastID uint64) *models.Cursor {
+	if lenPage == 0 || uint64(lenPage) < limit {
+		return nil
+	}
+
+	return models.NewCursor(lastScore, lastID)
+}
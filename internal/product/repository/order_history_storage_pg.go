@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/jackc/pgx/v5"
+)
+
+// insertOrderStatusHistory saves ActorID = 0 as NULL, it is used for transitions made by system.
+func (p *ProductStorage) insertOrderStatusHistory(ctx context.Context,
+	tx pgx.Tx, preHistory *models.PreOrderStatusHistory,
+) error {
+	logger := p.logger.LogReqID(ctx)
+
+	SQLInsertOrderStatusHistory := `INSERT INTO public."order_status_history"
+		 (order_id, actor_id, actor_role, from_status, to_status) VALUES ($1, NULLIF($2::bigint, 0), $3, $4, $5)`
+
+	_, err := tx.Exec(ctx, SQLInsertOrderStatusHistory, preHistory.OrderID, preHistory.ActorID,
+		preHistory.ActorRole, preHistory.FromStatus, preHistory.ToStatus)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+func (p *ProductStorage) selectOrderStatusHistoryByOrderID(ctx context.Context,
+	tx pgx.Tx, orderID uint64,
+) ([]*models.OrderStatusHistory, error) {
+	logger := p.logger.LogReqID(ctx)
+
+	var history []*models.OrderStatusHistory
+
+	SQLSelectOrderStatusHistory := `SELECT id, order_id, COALESCE(actor_id, 0), actor_role, from_status, to_status, created_at
+		 FROM public."order_status_history" WHERE order_id=$1 ORDER BY created_at, id`
+
+	historyRows, err := tx.Query(ctx, SQLSelectOrderStatusHistory, orderID)
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	curHistory := new(models.OrderStatusHistory)
+
+	_, err = pgx.ForEachRow(historyRows, []any{
+		&curHistory.ID, &curHistory.OrderID, &curHistory.ActorID, &curHistory.ActorRole,
+		&curHistory.FromStatus, &curHistory.ToStatus, &curHistory.CreatedAt,
+	}, func() error {
+		history = append(history, &models.OrderStatusHistory{
+			ID:         curHistory.ID,
+			OrderID:    curHistory.OrderID,
+			ActorID:    curHistory.ActorID,
+			ActorRole:  curHistory.ActorRole,
+			FromStatus: curHistory.FromStatus,
+			ToStatus:   curHistory.ToStatus,
+			CreatedAt:  curHistory.CreatedAt,
+		})
+
+		return nil
+	})
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return history, nil
+}
+
+func (p *ProductStorage) GetOrderStatusHistory(ctx context.Context, orderID uint64,
+) ([]*models.OrderStatusHistory, error) {
+	var history []*models.OrderStatusHistory
+
+	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
+		historyInner, err := p.selectOrderStatusHistoryByOrderID(ctx, tx, orderID)
+		if err != nil {
+			return err
+		}
+
+		history = historyInner
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return history, nil
+}
@@ -0,0 +1,95 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/repository"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+	"github.com/pashagolub/pgxmock/v3"
+)
+
+func TestGetOrderStatusHistory(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	testTime := time.Now()
+
+	type TestCase struct {
+		name                   string
+		behaviorProductStorage func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface)
+		orderID                uint64
+		expectedResponse       []*models.OrderStatusHistory
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectQuery(`SELECT id, order_id, COALESCE\(actor_id, 0\), actor_role, from_status, to_status, created_at`).
+					WithArgs(uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{
+						"id", "order_id", "actor_id", "actor_role", "from_status", "to_status", "created_at",
+					}).
+						AddRow(uint64(1), uint64(1), uint64(1), models.OrderActorBuyer, uint8(0), uint8(1), testTime).
+						AddRow(uint64(2), uint64(1), uint64(2), models.OrderActorSeller, uint8(1), uint8(6), testTime))
+
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			orderID: 1,
+			expectedResponse: []*models.OrderStatusHistory{
+				{
+					ID: 1, OrderID: 1, ActorID: 1, ActorRole: models.OrderActorBuyer,
+					FromStatus: 0, ToStatus: 1, CreatedAt: testTime,
+				},
+				{
+					ID: 2, OrderID: 1, ActorID: 2, ActorRole: models.OrderActorSeller,
+					FromStatus: 1, ToStatus: 6, CreatedAt: testTime,
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			ctx := context.Background()
+
+			productStorage, err := repository.NewProductStorage(mockPool)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			testCase.behaviorProductStorage(productStorage, mockPool)
+
+			history, err := productStorage.GetOrderStatusHistory(ctx, testCase.orderID)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			if err := mockPool.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+
+			err = utils.EqualTest(history, testCase.expectedResponse)
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
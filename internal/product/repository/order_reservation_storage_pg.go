@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses/statuses"
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrNotEnoughProductsForOrder = myerrors.NewErrorBadContentRequest(
+	"Недостаточно товара для оформления заказа")
+
+// reserveProductsByOrderID locks product of order with FOR UPDATE, so concurrent checkouts
+// of the same product wait each other and can't both take the last units.
+func (p *ProductStorage) reserveProductsByOrderID(ctx context.Context,
+	tx pgx.Tx, orderID uint64, count uint32,
+) error {
+	logger := p.logger.LogReqID(ctx)
+
+	SQLSelectProductForUpdate := `SELECT "product".id, "product".title, "product".available_count
+		 FROM public."product" INNER JOIN public."order" ON "order".product_id = "product".id
+		 WHERE "order".id=$1
+		 FOR UPDATE OF "product"`
+
+	productRow := tx.QueryRow(ctx, SQLSelectProductForUpdate, orderID)
+
+	var productID uint64
+
+	var title string
+
+	var availableCount uint32
+
+	err := productRow.Scan(&productID, &title, &availableCount)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if availableCount < count {
+		return fmt.Errorf("%w: «%s» доступно %d шт., в заказе %d шт.",
+			ErrNotEnoughProductsForOrder, title, availableCount, count)
+	}
+
+	SQLDecreaseAvailableCount := `UPDATE public."product"
+		 SET available_count = available_count - $1
+		 WHERE id=$2`
+
+	_, err = tx.Exec(ctx, SQLDecreaseAvailableCount, count, productID)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+func (p *ProductStorage) returnProductsByOrderID(ctx context.Context,
+	tx pgx.Tx, orderID uint64, count uint32,
+) error {
+	logger := p.logger.LogReqID(ctx)
+
+	SQLIncreaseAvailableCountByOrderID := `UPDATE public."product"
+		 SET available_count = available_count + $1
+		 WHERE id = (
+			SELECT product_id
+			FROM public."order"
+			WHERE id = $2
+		 )`
+
+	result, err := tx.Exec(ctx, SQLIncreaseAvailableCountByOrderID, count, orderID)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	rowsAffected := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf(myerrors.ErrTemplate, ErrNoAffectedOrderRows)
+	}
+
+	return nil
+}
+
+type expiredOrder struct {
+	id    uint64
+	count uint32
+}
+
+// cancelExpiredOrders skips orders locked by other transactions,
+// so it doesn't wait for payment of order which is in progress.
+// Orders of pending payment aren't cancelled until deadlinePendingPayment: buyer may still pay them,
+// they are returned to basket when yookassa cancels payment. After deadline they are cancelled anyway,
+// because yookassa may never report about payment, e.g. it wasn't created there.
+func (p *ProductStorage) cancelExpiredOrders(ctx context.Context,
+	tx pgx.Tx, now time.Time, deadlinePendingPayment time.Time,
+) ([]expiredOrder, error) {
+	logger := p.logger.LogReqID(ctx)
+
+	SQLCancelExpiredOrders := `UPDATE public."order"
+		 SET status=$1, reserved_until=NULL
+		 WHERE id IN (
+			SELECT id
+			FROM public."order"
+			WHERE status=$2 AND reserved_until < $3 AND (reserved_until < $5 OR NOT EXISTS (
+				SELECT 1
+				FROM public."payment"
+				WHERE "payment".id = "order".payment_id AND "payment".status=$4
+			))
+			FOR UPDATE SKIP LOCKED
+		 )
+		 RETURNING id, count`
+
+	expiredRows, err := tx.Query(ctx, SQLCancelExpiredOrders,
+		models.OrderStatusCancelled, models.OrderStatusInProcessing, now, statuses.IntStatusPaymentPending,
+		deadlinePendingPayment)
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	var expiredOrders []expiredOrder
+
+	var curOrder expiredOrder
+
+	_, err = pgx.ForEachRow(expiredRows, []any{&curOrder.id, &curOrder.count}, func() error {
+		expiredOrders = append(expiredOrders, curOrder)
+
+		return nil
+	})
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return expiredOrders, nil
+}
+
+// cancelPendingPaymentsOfOrders marks pending payments of cancelled orders canceled, if they have
+// no order left in processing. If buyer pays such payment later, money is returned,
+// because payment has no orders.
+func (p *ProductStorage) cancelPendingPaymentsOfOrders(ctx context.Context,
+	tx pgx.Tx, orderIDs []uint64,
+) error {
+	logger := p.logger.LogReqID(ctx)
+
+	SQLCancelPendingPayments := `UPDATE public."payment"
+		 SET status=$1
+		 WHERE status=$2 AND id IN (
+			SELECT payment_id FROM public."order" WHERE id = ANY($3)
+		 ) AND NOT EXISTS (
+			SELECT 1 FROM public."order" WHERE "order".payment_id = "payment".id AND "order".status=$4
+		 )`
+
+	_, err := tx.Exec(ctx, SQLCancelPendingPayments, statuses.IntStatusPaymentCanceled,
+		statuses.IntStatusPaymentPending, orderIDs, models.OrderStatusInProcessing)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// CancelExpiredReservations cancels orders in processing with reservation expired before now,
+// returns their products to stock and returns count of cancelled orders.
+// Orders of pending payment are cancelled with their payment only after deadlinePendingPayment.
+func (p *ProductStorage) CancelExpiredReservations(ctx context.Context,
+	now time.Time, deadlinePendingPayment time.Time,
+) (uint64, error) {
+	var countCancelled uint64
+
+	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
+		expiredOrders, err := p.cancelExpiredOrders(ctx, tx, now, deadlinePendingPayment)
+		if err != nil {
+			return err
+		}
+
+		if len(expiredOrders) == 0 {
+			return nil
+		}
+
+		orderIDs := make([]uint64, len(expiredOrders))
+		for i, order := range expiredOrders {
+			orderIDs[i] = order.id
+		}
+
+		err = p.cancelPendingPaymentsOfOrders(ctx, tx, orderIDs)
+		if err != nil {
+			return err
+		}
+
+		for _, order := range expiredOrders {
+			err = p.returnProductsByOrderID(ctx, tx, order.id, order.count)
+			if err != nil {
+				return err
+			}
+
+			err = p.insertOrderStatusHistory(ctx, tx, &models.PreOrderStatusHistory{
+				OrderID:    order.id,
+				ActorID:    0,
+				ActorRole:  models.OrderActorSystem,
+				FromStatus: models.OrderStatusInProcessing,
+				ToStatus:   models.OrderStatusCancelled,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		countCancelled = uint64(len(expiredOrders))
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return countCancelled, nil
+}
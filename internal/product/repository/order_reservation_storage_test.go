@@ -0,0 +1,353 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/repository"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses/statuses"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+	"github.com/pashagolub/pgxmock/v3"
+)
+
+func TestBuyFullBasket(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	testReservedUntil := time.Now()
+
+	const testIdempotencyKey = "test_key"
+
+	type TestCase struct {
+		name                   string
+		behaviorProductStorage func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface)
+		expectedPayment        *models.BasketPayment
+		expectedError          error
+		expectedErrorMessage   string
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectQuery(`SELECT id FROM public."order" WHERE owner_id=\$1 AND status=0 ORDER BY product_id`).
+					WithArgs(uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint64(1)))
+
+				mockPool.ExpectQuery(`UPDATE public."order"`).
+					WithArgs(uint8(1), uint64(1), uint8(0), &testReservedUntil).
+					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(uint32(1)))
+
+				mockPool.ExpectQuery(`SELECT "product".id, "product".title, "product".available_count`).
+					WithArgs(uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "title", "available_count"}).
+						AddRow(uint64(1), "Телефон", uint32(1)))
+
+				mockPool.ExpectExec(`UPDATE public."product"`).WithArgs(uint32(1), uint64(1)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+				mockPool.ExpectExec(`INSERT INTO public."order_status_history"`).
+					WithArgs(uint64(1), uint64(1), models.OrderActorBuyer, uint8(0), uint8(1)).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+				mockPool.ExpectQuery(`SELECT COALESCE\(SUM\("product".price \* "order".count\), 0\)`).
+					WithArgs([]uint64{1}).
+					WillReturnRows(pgxmock.NewRows([]string{"sum", "bool_or"}).AddRow(uint64(1500), true))
+
+				mockPool.ExpectExec(`INSERT INTO public."payment"`).
+					WithArgs(uint64(1), testIdempotencyKey, uint64(1500), false, uint8(1)).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+				mockPool.ExpectQuery(`SELECT last_value FROM "public"."payment_id_seq";`).
+					WillReturnRows(pgxmock.NewRows([]string{"last_value"}).AddRow(uint64(1)))
+
+				mockPool.ExpectExec(`UPDATE public."order"`).WithArgs(uint64(1), []uint64{1}).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			expectedPayment: &models.BasketPayment{
+				ID:             1,
+				OwnerID:        1,
+				YookassaID:     "",
+				IdempotencyKey: testIdempotencyKey,
+				Amount:         1500,
+				Capture:        false,
+				Status:         1,
+			},
+			expectedError: nil,
+		},
+		{
+			name: "test empty basket",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectQuery(`SELECT id FROM public."order" WHERE owner_id=\$1 AND status=0 ORDER BY product_id`).
+					WithArgs(uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}))
+
+				mockPool.ExpectRollback()
+				mockPool.ExpectRollback()
+			},
+			expectedPayment: nil,
+			expectedError:   repository.ErrNotFoundOrdersInBasket,
+		},
+		{
+			name: "test zero amount",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectQuery(`SELECT id FROM public."order" WHERE owner_id=\$1 AND status=0 ORDER BY product_id`).
+					WithArgs(uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint64(1)))
+
+				mockPool.ExpectQuery(`UPDATE public."order"`).
+					WithArgs(uint8(1), uint64(1), uint8(0), &testReservedUntil).
+					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(uint32(1)))
+
+				mockPool.ExpectQuery(`SELECT "product".id, "product".title, "product".available_count`).
+					WithArgs(uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "title", "available_count"}).
+						AddRow(uint64(1), "Телефон", uint32(1)))
+
+				mockPool.ExpectExec(`UPDATE public."product"`).WithArgs(uint32(1), uint64(1)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+				mockPool.ExpectExec(`INSERT INTO public."order_status_history"`).
+					WithArgs(uint64(1), uint64(1), models.OrderActorBuyer, uint8(0), uint8(1)).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+				mockPool.ExpectQuery(`SELECT COALESCE\(SUM\("product".price \* "order".count\), 0\)`).
+					WithArgs([]uint64{1}).
+					WillReturnRows(pgxmock.NewRows([]string{"sum", "bool_or"}).AddRow(uint64(0), false))
+
+				mockPool.ExpectRollback()
+				mockPool.ExpectRollback()
+			},
+			expectedPayment: nil,
+			expectedError:   repository.ErrZeroAmountPayment,
+		},
+		{
+			name: "test error lists all not enough products",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectQuery(`SELECT id FROM public."order" WHERE owner_id=\$1 AND status=0 ORDER BY product_id`).
+					WithArgs(uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint64(1)).AddRow(uint64(2)))
+
+				mockPool.ExpectQuery(`UPDATE public."order"`).
+					WithArgs(uint8(1), uint64(1), uint8(0), &testReservedUntil).
+					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(uint32(2)))
+
+				mockPool.ExpectQuery(`SELECT "product".id, "product".title, "product".available_count`).
+					WithArgs(uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "title", "available_count"}).
+						AddRow(uint64(1), "Телефон", uint32(1)))
+
+				mockPool.ExpectQuery(`UPDATE public."order"`).
+					WithArgs(uint8(1), uint64(2), uint8(0), &testReservedUntil).
+					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(uint32(1)))
+
+				mockPool.ExpectQuery(`SELECT "product".id, "product".title, "product".available_count`).
+					WithArgs(uint64(2)).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "title", "available_count"}).
+						AddRow(uint64(2), "Чехол", uint32(0)))
+
+				mockPool.ExpectRollback()
+				mockPool.ExpectRollback()
+			},
+			expectedPayment: nil,
+			expectedError:   repository.ErrNotEnoughProductsForOrder,
+			expectedErrorMessage: "Недостаточно товара для оформления заказа: «Телефон» доступно 1 шт., в заказе 2 шт.\n" +
+				"Недостаточно товара для оформления заказа: «Чехол» доступно 0 шт., в заказе 1 шт.",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			ctx := context.Background()
+
+			productStorage, err := repository.NewProductStorage(mockPool)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			testCase.behaviorProductStorage(productStorage, mockPool)
+
+			basketPayment, errActual := productStorage.BuyFullBasket(ctx, 1, testReservedUntil, testIdempotencyKey)
+
+			if err := mockPool.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+
+			err = utils.EqualError(errActual, testCase.expectedError)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = utils.EqualTest(basketPayment, testCase.expectedPayment)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if testCase.expectedErrorMessage != "" && errActual.Error() != testCase.expectedErrorMessage {
+				t.Fatalf("error message: got %q, expected %q", errActual.Error(), testCase.expectedErrorMessage)
+			}
+		})
+	}
+}
+
+func TestCancelExpiredReservations(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	testNow := time.Now()
+	testDeadline := testNow.Add(-time.Hour)
+
+	type TestCase struct {
+		name                   string
+		behaviorProductStorage func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface)
+		expectedCount          uint64
+		expectedError          error
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectQuery(`UPDATE public."order"`).
+					WithArgs(models.OrderStatusCancelled, models.OrderStatusInProcessing, testNow,
+						statuses.IntStatusPaymentPending, testDeadline).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "count"}).
+						AddRow(uint64(1), uint32(2)).AddRow(uint64(3), uint32(1)))
+
+				mockPool.ExpectExec(`UPDATE public."payment"`).
+					WithArgs(statuses.IntStatusPaymentCanceled, statuses.IntStatusPaymentPending,
+						[]uint64{1, 3}, models.OrderStatusInProcessing).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+				mockPool.ExpectExec(`UPDATE public."product"`).WithArgs(uint32(2), uint64(1)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+				mockPool.ExpectExec(`INSERT INTO public."order_status_history"`).
+					WithArgs(uint64(1), uint64(0), models.OrderActorSystem, uint8(1), uint8(6)).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+				mockPool.ExpectExec(`UPDATE public."product"`).WithArgs(uint32(1), uint64(3)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+				mockPool.ExpectExec(`INSERT INTO public."order_status_history"`).
+					WithArgs(uint64(3), uint64(0), models.OrderActorSystem, uint8(1), uint8(6)).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			expectedCount: 2,
+			expectedError: nil,
+		},
+		{
+			name: "test no expired reservations",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectQuery(`UPDATE public."order"`).
+					WithArgs(models.OrderStatusCancelled, models.OrderStatusInProcessing, testNow,
+						statuses.IntStatusPaymentPending, testDeadline).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "count"}))
+
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			expectedCount: 0,
+			expectedError: nil,
+		},
+		{
+			name: "test pending payment past deadline",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectQuery(`reserved_until < \$3 AND \(reserved_until < \$5 OR NOT EXISTS`).
+					WithArgs(models.OrderStatusCancelled, models.OrderStatusInProcessing, testNow,
+						statuses.IntStatusPaymentPending, testDeadline).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "count"}).AddRow(uint64(5), uint32(1)))
+
+				// payment is canceled, so it can't move orders to paid, money paid later is returned
+				mockPool.ExpectExec(`UPDATE public."payment"`).
+					WithArgs(statuses.IntStatusPaymentCanceled, statuses.IntStatusPaymentPending,
+						[]uint64{5}, models.OrderStatusInProcessing).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+				mockPool.ExpectExec(`UPDATE public."product"`).WithArgs(uint32(1), uint64(5)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+				mockPool.ExpectExec(`INSERT INTO public."order_status_history"`).
+					WithArgs(uint64(5), uint64(0), models.OrderActorSystem, uint8(1), uint8(6)).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			expectedCount: 1,
+			expectedError: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			ctx := context.Background()
+
+			productStorage, err := repository.NewProductStorage(mockPool)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			testCase.behaviorProductStorage(productStorage, mockPool)
+
+			countCancelled, errActual := productStorage.CancelExpiredReservations(ctx, testNow, testDeadline)
+
+			if err := mockPool.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+
+			err = utils.EqualError(errActual, testCase.expectedError)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = utils.EqualTest(countCancelled, testCase.expectedCount)
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
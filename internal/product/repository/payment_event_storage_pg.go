@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrPaymentEventHandled = myerrors.NewErrorInternal("Этот статус платежа уже обработан")
+
+// claimPaymentEvent marks status of payment as handled in tx which applies it, nil event isn't marked.
+// If the status was already handled by notification or by polling, ErrPaymentEventHandled is returned
+// and tx should be rolled back. Concurrent claim of the same status waits until tx is finished.
+func (p *ProductStorage) claimPaymentEvent(ctx context.Context, tx pgx.Tx, event *models.PaymentEvent) error {
+	if event == nil {
+		return nil
+	}
+
+	logger := p.logger.LogReqID(ctx)
+
+	SQLInsertPaymentEvent := `INSERT INTO public."payment_event" (yookassa_id, status) VALUES ($1, $2)
+		 ON CONFLICT DO NOTHING`
+
+	result, err := tx.Exec(ctx, SQLInsertPaymentEvent, event.YookassaID, event.Status)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf(myerrors.ErrTemplate, ErrPaymentEventHandled)
+	}
+
+	return nil
+}
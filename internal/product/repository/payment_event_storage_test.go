@@ -0,0 +1,132 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/repository"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses/statuses"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+	"github.com/pashagolub/pgxmock/v3"
+)
+
+func TestUpdatePaymentStatusWithEvent(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	testError := myerrors.NewErrorInternal("test error")
+	testEvent := &models.PaymentEvent{YookassaID: "test_id", Status: statuses.IntStatusPaymentCanceled}
+
+	type TestCase struct {
+		name                   string
+		behaviorProductStorage func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface)
+		inputEvent             *models.PaymentEvent
+		expectedError          error
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectExec(`INSERT INTO public."payment_event" \(yookassa_id, status\) VALUES \(\$1, \$2\)
+		 ON CONFLICT DO NOTHING`).WithArgs("test_id", statuses.IntStatusPaymentCanceled).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+				mockPool.ExpectQuery(`UPDATE public."payment" SET status=\$1 WHERE id=\$2 RETURNING owner_id`).
+					WithArgs(statuses.IntStatusPaymentCanceled, uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"owner_id"}).AddRow(uint64(2)))
+
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			inputEvent:    testEvent,
+			expectedError: nil,
+		},
+		{
+			name: "test event already handled",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectExec(`INSERT INTO public."payment_event" \(yookassa_id, status\) VALUES \(\$1, \$2\)
+		 ON CONFLICT DO NOTHING`).WithArgs("test_id", statuses.IntStatusPaymentCanceled).
+					WillReturnResult(pgxmock.NewResult("INSERT", 0))
+
+				mockPool.ExpectRollback()
+				mockPool.ExpectRollback()
+			},
+			inputEvent:    testEvent,
+			expectedError: repository.ErrPaymentEventHandled,
+		},
+		{
+			name: "test event is released if status isn't applied",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectExec(`INSERT INTO public."payment_event" \(yookassa_id, status\) VALUES \(\$1, \$2\)
+		 ON CONFLICT DO NOTHING`).WithArgs("test_id", statuses.IntStatusPaymentCanceled).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+				mockPool.ExpectQuery(`UPDATE public."payment" SET status=\$1 WHERE id=\$2 RETURNING owner_id`).
+					WithArgs(statuses.IntStatusPaymentCanceled, uint64(1)).
+					WillReturnError(testError)
+
+				mockPool.ExpectRollback()
+				mockPool.ExpectRollback()
+			},
+			inputEvent:    testEvent,
+			expectedError: testError,
+		},
+		{
+			name: "test without event",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectQuery(`UPDATE public."payment" SET status=\$1 WHERE id=\$2 RETURNING owner_id`).
+					WithArgs(statuses.IntStatusPaymentCanceled, uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"owner_id"}).AddRow(uint64(2)))
+
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			inputEvent:    nil,
+			expectedError: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			mockPool, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			productStorage, err := repository.NewProductStorage(mockPool)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			testCase.behaviorProductStorage(productStorage, mockPool)
+
+			err = productStorage.UpdatePaymentStatus(ctx, 1, statuses.IntStatusPaymentCanceled, testCase.inputEvent)
+			if errInner := utils.EqualError(err, testCase.expectedError); errInner != nil {
+				t.Fatalf("Failed EqualError: %+v", errInner)
+			}
+
+			if err := mockPool.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
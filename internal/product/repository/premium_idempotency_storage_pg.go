@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+)
+
+// GetOrAddPremiumIdempotencyKey returns key saved for metadata of premium payment.
+// If there is no key or saved key was created before expiredBefore, newKey is saved and returned.
+func (p *ProductStorage) GetOrAddPremiumIdempotencyKey(ctx context.Context, userID uint64, productID uint64,
+	periodCode uint64, newKey string, expiredBefore time.Time,
+) (string, error) {
+	logger := p.logger.LogReqID(ctx)
+
+	SQLUpsertPremiumIdempotencyKey := `INSERT INTO public."premium_idempotency_key"
+		 (user_id, product_id, period_code, idempotency_key) VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (user_id, product_id, period_code) DO UPDATE SET
+		 idempotency_key = CASE WHEN premium_idempotency_key.created_at < $5
+		     THEN EXCLUDED.idempotency_key ELSE premium_idempotency_key.idempotency_key END,
+		 created_at = CASE WHEN premium_idempotency_key.created_at < $5
+		     THEN EXCLUDED.created_at ELSE premium_idempotency_key.created_at END
+		 RETURNING idempotency_key`
+
+	var key string
+
+	err := p.pool.QueryRow(ctx, SQLUpsertPremiumIdempotencyKey,
+		userID, productID, periodCode, newKey, expiredBefore).Scan(&key)
+	if err != nil {
+		logger.Errorln(err)
+
+		return "", fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return key, nil
+}
+
+// DeletePremiumIdempotencyKey removes key of finished payment, so next purchase
+// of premium with the same metadata creates new payment.
+func (p *ProductStorage) DeletePremiumIdempotencyKey(ctx context.Context,
+	userID uint64, productID uint64, periodCode uint64,
+) error {
+	logger := p.logger.LogReqID(ctx)
+
+	SQLDeletePremiumIdempotencyKey := `DELETE FROM public."premium_idempotency_key"
+		 WHERE user_id=$1 AND product_id=$2 AND period_code=$3`
+
+	_, err := p.pool.Exec(ctx, SQLDeletePremiumIdempotencyKey, userID, productID, periodCode)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// DeleteExpiredPremiumIdempotencyKeys removes keys created before expiredBefore
+// and returns count of removed keys.
+func (p *ProductStorage) DeleteExpiredPremiumIdempotencyKeys(ctx context.Context,
+	expiredBefore time.Time,
+) (uint64, error) {
+	logger := p.logger.LogReqID(ctx)
+
+	SQLDeleteExpiredPremiumIdempotencyKeys := `DELETE FROM public."premium_idempotency_key" WHERE created_at < $1`
+
+	result, err := p.pool.Exec(ctx, SQLDeleteExpiredPremiumIdempotencyKeys, expiredBefore)
+	if err != nil {
+		logger.Errorln(err)
+
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return uint64(result.RowsAffected()), nil
+}
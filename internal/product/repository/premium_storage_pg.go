@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses/statuses"
 	"github.com/jackc/pgx/v5"
@@ -18,11 +19,13 @@ var (
 		"Не получилось обновить статус премиума объявления")
 )
 
-func (p *ProductStorage) UpdateStatusPremium(ctx context.Context, status uint8, productID uint64, userID uint64) error {
+func (p *ProductStorage) updateStatusPremium(ctx context.Context,
+	tx pgx.Tx, status uint8, productID uint64, userID uint64,
+) error {
 	SQLAddPremium := `UPDATE public."product" 
 SET premium_status=$1 WHERE id=$2 AND saler_id=$3`
 
-	result, err := p.pool.Exec(ctx, SQLAddPremium, status, productID, userID)
+	result, err := tx.Exec(ctx, SQLAddPremium, status, productID, userID)
 	if err != nil {
 		p.logger.Errorln(err)
 
@@ -40,6 +43,25 @@ SET premium_status=$1 WHERE id=$2 AND saler_id=$3`
 	return nil
 }
 
+// UpdateStatusPremium event is status of payment from yookassa, which is applied, nil if there is no one.
+func (p *ProductStorage) UpdateStatusPremium(ctx context.Context, status uint8, productID uint64, userID uint64,
+	event *models.PaymentEvent,
+) error {
+	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
+		err := p.claimPaymentEvent(ctx, tx, event)
+		if err != nil {
+			return err
+		}
+
+		return p.updateStatusPremium(ctx, tx, status, productID, userID)
+	})
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
 func (p *ProductStorage) addPremium(ctx context.Context, tx pgx.Tx, productID uint64, userID uint64,
 	premiumBegin time.Time, premiumExpire time.Time,
 ) error {
@@ -62,11 +84,17 @@ SET premium_status=$1, premium_begin=$2, premium_expire=$3 WHERE id=$4 AND saler
 	return nil
 }
 
+// AddPremium event is status of payment from yookassa, which is applied, nil if there is no one.
 func (p *ProductStorage) AddPremium(ctx context.Context, productID uint64, userID uint64,
-	premiumBegin time.Time, premiumExpire time.Time,
+	premiumBegin time.Time, premiumExpire time.Time, event *models.PaymentEvent,
 ) error {
 	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
-		err := p.addPremium(ctx, tx, productID, userID, premiumBegin, premiumExpire)
+		err := p.claimPaymentEvent(ctx, tx, event)
+		if err != nil {
+			return err
+		}
+
+		err = p.addPremium(ctx, tx, productID, userID, premiumBegin, premiumExpire)
 		if err != nil {
 			return err
 		}
@@ -105,7 +105,7 @@ func TestAddPremium(t *testing.T) {
 			testCase.behaviorProductStorage(catStorage, mockPool)
 
 			err = catStorage.AddPremium(ctx, testCase.productID, testCase.userID,
-				beginPremium, beginPremium.AddDate(0, 0, 7))
+				beginPremium, beginPremium.AddDate(0, 0, 7), nil)
 			if errInner := utils.EqualError(err, testCase.expectedError); errInner != nil {
 				t.Fatalf("Failed EqualError: %+v", errInner)
 			}
@@ -205,3 +205,111 @@ func TestCheckPremium(t *testing.T) {
 		})
 	}
 }
+
+func TestGetOrAddPremiumIdempotencyKey(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	expiredBefore := time.Now().Add(-24 * time.Hour)
+	testError := myerrors.NewErrorInternal("test error")
+
+	type TestCase struct {
+		name                   string
+		behaviorProductStorage func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface)
+		expectedKey            string
+		expectedError          error
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test saved key is returned",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectQuery(`INSERT INTO public."premium_idempotency_key"`).
+					WithArgs(uint64(1), uint64(1), uint64(1), "new_key", expiredBefore).
+					WillReturnRows(pgxmock.NewRows([]string{"idempotency_key"}).AddRow("saved_key"))
+			},
+			expectedKey:   "saved_key",
+			expectedError: nil,
+		},
+		{
+			name: "test internal error",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectQuery(`INSERT INTO public."premium_idempotency_key"`).
+					WithArgs(uint64(1), uint64(1), uint64(1), "new_key", expiredBefore).
+					WillReturnError(testError)
+			},
+			expectedKey:   "",
+			expectedError: testError,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			mockPool, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			productStorage, err := repository.NewProductStorage(mockPool)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			testCase.behaviorProductStorage(productStorage, mockPool)
+
+			key, err := productStorage.GetOrAddPremiumIdempotencyKey(ctx, 1, 1, 1, "new_key", expiredBefore)
+			if errInner := utils.EqualError(err, testCase.expectedError); errInner != nil {
+				t.Fatalf("Failed EqualError: %+v", errInner)
+			}
+
+			if key != testCase.expectedKey {
+				t.Fatalf("key: got %q, expected %q", key, testCase.expectedKey)
+			}
+
+			if err := mockPool.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestDeleteExpiredPremiumIdempotencyKeys(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	expiredBefore := time.Now().Add(-24 * time.Hour)
+
+	mockPool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	productStorage, err := repository.NewProductStorage(mockPool)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	mockPool.ExpectExec(`DELETE FROM public."premium_idempotency_key" WHERE created_at < \$1`).
+		WithArgs(expiredBefore).WillReturnResult(pgxmock.NewResult("DELETE", 3))
+
+	countDeleted, err := productStorage.DeleteExpiredPremiumIdempotencyKeys(context.Background(), expiredBefore)
+	if err != nil {
+		t.Fatalf("unexpected err=%+v", err)
+	}
+
+	if countDeleted != 3 {
+		t.Fatalf("count deleted: got %d, expected 3", countDeleted)
+	}
+
+	if err := mockPool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
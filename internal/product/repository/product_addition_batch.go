@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/jackc/pgx/v5"
+)
+
+func productIDsOf(products []*models.ProductInFeed) []uint64 {
+	productIDs := make([]uint64, len(products))
+
+	for i, product := range products {
+		productIDs[i] = product.ID
+	}
+
+	return productIDs
+}
+
+func (p *ProductStorage) selectImagesByProductIDs(ctx context.Context,
+	tx pgx.Tx, productIDs []uint64,
+) (map[uint64][]models.Image, error) {
+	logger := p.logger.LogReqID(ctx)
+
+	SQLSelectImages := `SELECT product_id, url FROM public."image" WHERE product_id = ANY($1) ORDER BY id`
+
+	imagesRows, err := tx.Query(ctx, SQLSelectImages, productIDs)
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	mapImages := make(map[uint64][]models.Image, len(productIDs))
+
+	var curProductID uint64
+
+	var curURL string
+
+	_, err = pgx.ForEachRow(imagesRows, []any{&curProductID, &curURL}, func() error {
+		mapImages[curProductID] = append(mapImages[curProductID], models.Image{URL: curURL})
+
+		return nil
+	})
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return mapImages, nil
+}
+
+func (p *ProductStorage) selectCountFavouritesByProductIDs(ctx context.Context,
+	tx pgx.Tx, productIDs []uint64,
+) (map[uint64]uint64, error) {
+	logger := p.logger.LogReqID(ctx)
+
+	SQLCountFavourites := `SELECT product_id, COUNT(id) FROM public."favourite"
+		WHERE product_id = ANY($1) GROUP BY product_id`
+
+	countFavouritesRows, err := tx.Query(ctx, SQLCountFavourites, productIDs)
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	mapFavouritesCount := make(map[uint64]uint64, len(productIDs))
+
+	var curProductID, curCount uint64
+
+	_, err = pgx.ForEachRow(countFavouritesRows, []any{&curProductID, &curCount}, func() error {
+		mapFavouritesCount[curProductID] = curCount
+
+		return nil
+	})
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return mapFavouritesCount, nil
+}
+
+func (p *ProductStorage) selectUserFavouritesByProductIDs(ctx context.Context,
+	tx pgx.Tx, productIDs []uint64, userID uint64,
+) (map[uint64]bool, error) {
+	logger := p.logger.LogReqID(ctx)
+
+	SQLSelectUserFavourites := `SELECT product_id FROM public."favourite" WHERE owner_id=$1 AND product_id = ANY($2)`
+
+	userFavouritesRows, err := tx.Query(ctx, SQLSelectUserFavourites, userID, productIDs)
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	mapInFavourites := make(map[uint64]bool, len(productIDs))
+
+	var curProductID uint64
+
+	_, err = pgx.ForEachRow(userFavouritesRows, []any{&curProductID}, func() error {
+		mapInFavourites[curProductID] = true
+
+		return nil
+	})
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return mapInFavourites, nil
+}
+
+// fillProductsAddition sets images, favourites count and favourite flag of user
+// for whole page of products. It makes three queries regardless of count of products,
+// unlike getProductAddition which makes three queries for every product.
+func (p *ProductStorage) fillProductsAddition(ctx context.Context,
+	tx pgx.Tx, products []*models.ProductInFeed, userID uint64,
+) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	productIDs := productIDsOf(products)
+
+	mapImages, err := p.selectImagesByProductIDs(ctx, tx, productIDs)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	mapFavouritesCount, err := p.selectCountFavouritesByProductIDs(ctx, tx, productIDs)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	mapInFavourites, err := p.selectUserFavouritesByProductIDs(ctx, tx, productIDs, userID)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	for _, product := range products {
+		product.Images = mapImages[product.ID]
+		product.Favourites = mapFavouritesCount[product.ID]
+		product.InFavourites = mapInFavourites[product.ID]
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v3"
+)
+
+const (
+	benchPageSize = 30
+	benchUserID   = uint64(1)
+	// benchRoundTrip simulates network latency of one query to database.
+	benchRoundTrip = 50 * time.Microsecond
+)
+
+func newBenchProductStorage(b *testing.B) (*ProductStorage, pgxmock.PgxPoolIface) {
+	b.Helper()
+
+	_ = mylogger.NewNop()
+
+	mockPool, err := pgxmock.NewPool()
+	if err != nil {
+		b.Fatalf("%v", err)
+	}
+
+	productStorage, err := NewProductStorage(mockPool)
+	if err != nil {
+		b.Fatalf("%v", err)
+	}
+
+	return productStorage, mockPool
+}
+
+func newBenchPage() []*models.ProductInFeed {
+	products := make([]*models.ProductInFeed, benchPageSize)
+
+	for i := range products {
+		products[i] = &models.ProductInFeed{ID: uint64(i + 1)} //nolint:exhaustruct
+	}
+
+	return products
+}
+
+func expectPerProductAddition(mockPool pgxmock.PgxPoolIface, products []*models.ProductInFeed) {
+	mockPool.ExpectBegin()
+
+	for _, product := range products {
+		mockPool.ExpectQuery(`SELECT url FROM public."image"`).WithArgs(product.ID).
+			WillReturnRows(pgxmock.NewRows([]string{"url"}).AddRow("img.png")).
+			WillDelayFor(benchRoundTrip)
+
+		mockPool.ExpectQuery(`SELECT COUNT\(id\) FROM public."favourite"`).WithArgs(product.ID).
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(uint64(1))).
+			WillDelayFor(benchRoundTrip)
+
+		mockPool.ExpectQuery(`SELECT id FROM public.favourite`).WithArgs(product.ID, benchUserID).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow("1")).
+			WillDelayFor(benchRoundTrip)
+	}
+
+	mockPool.ExpectCommit()
+	mockPool.ExpectRollback()
+}
+
+func expectBatchAddition(mockPool pgxmock.PgxPoolIface, products []*models.ProductInFeed) {
+	productIDs := productIDsOf(products)
+	rowsImages := pgxmock.NewRows([]string{"product_id", "url"})
+	rowsCount := pgxmock.NewRows([]string{"product_id", "count"})
+	rowsInFavourites := pgxmock.NewRows([]string{"product_id"})
+
+	for _, productID := range productIDs {
+		rowsImages.AddRow(productID, "img.png")
+		rowsCount.AddRow(productID, uint64(1))
+		rowsInFavourites.AddRow(productID)
+	}
+
+	mockPool.ExpectBegin()
+
+	mockPool.ExpectQuery(`SELECT product_id, url FROM public."image"`).WithArgs(productIDs).
+		WillReturnRows(rowsImages).WillDelayFor(benchRoundTrip)
+
+	mockPool.ExpectQuery(`SELECT product_id, COUNT\(id\) FROM public."favourite"`).WithArgs(productIDs).
+		WillReturnRows(rowsCount).WillDelayFor(benchRoundTrip)
+
+	mockPool.ExpectQuery(`SELECT product_id FROM public."favourite"`).WithArgs(benchUserID, productIDs).
+		WillReturnRows(rowsInFavourites).WillDelayFor(benchRoundTrip)
+
+	mockPool.ExpectCommit()
+	mockPool.ExpectRollback()
+}
+
+// BenchmarkProductAdditionPerProduct measures the previous way of loading feed page:
+// three queries for every product.
+func BenchmarkProductAdditionPerProduct(b *testing.B) {
+	ctx := context.Background()
+	productStorage, mockPool := newBenchProductStorage(b)
+	products := newBenchPage()
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		expectPerProductAddition(mockPool, products)
+		b.StartTimer()
+
+		err := pgx.BeginFunc(ctx, mockPool, func(tx pgx.Tx) error {
+			for _, product := range products {
+				productAdditionInner, err := productStorage.getProductAddition(ctx, tx, product.ID, benchUserID)
+				if err != nil {
+					return err
+				}
+
+				product.Images = productAdditionInner.images
+				product.Favourites = productAdditionInner.favourites
+				product.InFavourites = productAdditionInner.inFavourite
+			}
+
+			return nil
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkProductAdditionBatch measures loading of the same page with fillProductsAddition.
+func BenchmarkProductAdditionBatch(b *testing.B) {
+	ctx := context.Background()
+	productStorage, mockPool := newBenchProductStorage(b)
+	products := newBenchPage()
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		expectBatchAddition(mockPool, products)
+		b.StartTimer()
+
+		err := pgx.BeginFunc(ctx, mockPool, func(tx pgx.Tx) error {
+			return productStorage.fillProductsAddition(ctx, tx, products, benchUserID)
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses/statuses"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	facetColumnCategory = "category_id"
+	facetColumnCity     = "city_id"
+)
+
+// sqlCategorySubtree selects id of category with id=? and ids of all its descendants.
+const sqlCategorySubtree = `WITH RECURSIVE subtree AS (
+	SELECT id FROM public."category" WHERE id = ?
+	UNION
+	SELECT c.id FROM public."category" c JOIN subtree s ON c.parent_id = s.id)
+SELECT id FROM subtree`
+
+// whereClauseForProductFilter returns conditions for product filter.
+// Condition on column with name equal to skipColumn is not added,
+// it's used for facets counting where own dimension should be ignored.
+func whereClauseForProductFilter(filter *models.ProductFilter, skipColumn string) squirrel.And {
+	whereClause := squirrel.And{}
+
+	if filter == nil {
+		return whereClause
+	}
+
+	if filter.CategoryID != 0 && skipColumn != facetColumnCategory {
+		whereClause = append(whereClause,
+			squirrel.Expr("category_id IN ("+sqlCategorySubtree+")", filter.CategoryID))
+	}
+
+	if filter.CityID != 0 && skipColumn != facetColumnCity {
+		whereClause = append(whereClause, squirrel.Eq{"city_id": filter.CityID})
+	}
+
+	if filter.MinPrice != 0 {
+		whereClause = append(whereClause, squirrel.GtOrEq{"price": filter.MinPrice})
+	}
+
+	if filter.MaxPrice != 0 {
+		whereClause = append(whereClause, squirrel.LtOrEq{"price": filter.MaxPrice})
+	}
+
+	if filter.Delivery {
+		whereClause = append(whereClause, squirrel.Eq{"delivery": true})
+	}
+
+	if filter.SafeDeal {
+		whereClause = append(whereClause, squirrel.Eq{"safe_deal": true})
+	}
+
+	if filter.PremiumOnly {
+		whereClause = append(whereClause, squirrel.Eq{"premium_status": []any{
+			statuses.IntStatusPremiumWaiting, statuses.IntStatusPremiumSucceeded,
+		}})
+	}
+
+	return whereClause
+}
+
+func (p *ProductStorage) selectFacetCounts(ctx context.Context, tx pgx.Tx,
+	column string, whereClause squirrel.Sqlizer,
+) ([]models.FacetCount, error) {
+	logger := p.logger.LogReqID(ctx)
+
+	query := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).
+		Select(column+", COUNT(id)").From(`public."product"`).
+		Where(whereClause).GroupBy(column).OrderBy("COUNT(id) DESC", column)
+
+	SQLQuery, args, err := query.ToSql()
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	facetsRows, err := tx.Query(ctx, SQLQuery, args...)
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	var curFacet models.FacetCount
+
+	facets := make([]models.FacetCount, 0)
+
+	_, err = pgx.ForEachRow(facetsRows, []any{&curFacet.ID, &curFacet.Count}, func() error {
+		facets = append(facets, curFacet)
+
+		return nil
+	})
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return facets, nil
+}
+
+// GetProductFacets counts active products per category and per city.
+// Every facet is counted with the filter applied except the filter of its own dimension.
+// searchInput is ignored if it's empty.
+func (p *ProductStorage) GetProductFacets(ctx context.Context,
+	searchInput string, filter *models.ProductFilter,
+) (*models.ProductFacets, error) {
+	logger := p.logger.LogReqID(ctx)
+
+	facets := new(models.ProductFacets)
+
+	baseWhereClause := squirrel.And{squirrel.Expr("is_active = true")}
+	if searchInput != "" {
+		baseWhereClause = append(baseWhereClause, whereClauseForSearch(searchInput))
+	}
+
+	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
+		categories, err := p.selectFacetCounts(ctx, tx, facetColumnCategory,
+			squirrel.And{baseWhereClause, whereClauseForProductFilter(filter, facetColumnCategory)})
+		if err != nil {
+			return err
+		}
+
+		cities, err := p.selectFacetCounts(ctx, tx, facetColumnCity,
+			squirrel.And{baseWhereClause, whereClauseForProductFilter(filter, facetColumnCity)})
+		if err != nil {
+			return err
+		}
+
+		facets.Categories = categories
+		facets.Cities = cities
+
+		return nil
+	})
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return facets, nil
+}
@@ -328,7 +328,7 @@ func (p *ProductStorage) GetProduct(ctx context.Context, productID uint64, userI
 	return product, nil
 }
 
-// selectProductsInFeedWithWhereOrderLimitOffset accepts arguments in the appropriate format:
+// selectProductsInFeedAfterCursor accepts arguments in the appropriate format:
 //
 // whereClause can be:
 // nil - ignored.
@@ -338,33 +338,35 @@ func (p *ProductStorage) GetProduct(ctx context.Context, productID uint64, userI
 // nil, the expression will be "<key> IS NULL". If the value is an array or slice, the expression will be
 // "<key> IN (?,?,...)", with one placeholder for each item in the value.
 //
-// orderByClause:
-// nil - ignored.
+// score is float8 expression, products are sorted by (score DESC, id DESC).
+// This order is stable, so pages don't contain duplicates and don't skip products.
 //
-// another cases add ORDER BY expressions to the query
+// cursor is the last product of previous page, nil means the first page.
 //
 // limit sets a LIMIT clause on the query.
-func (p *ProductStorage) selectProductsInFeedWithWhereOrderLimitOffset(ctx context.Context, tx pgx.Tx,
-	limit uint64, whereClause any, orderByClause []string, offset uint64,
-) ([]*models.ProductInFeed, error) {
+func (p *ProductStorage) selectProductsInFeedAfterCursor(ctx context.Context, tx pgx.Tx,
+	limit uint64, whereClause any, score squirrel.Sqlizer, cursor *models.Cursor,
+) ([]*models.ProductInFeed, *models.Cursor, error) {
 	logger := p.logger.LogReqID(ctx)
 
-	query := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).Select("id, title," +
-		"price, city_id, delivery, safe_deal, is_active, available_count, premium_status").From(`public."product"`).
-		Where(whereClause).OrderBy(orderByClause...).Limit(limit).Offset(offset)
+	query := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).Select("id, title,"+
+		"price, city_id, delivery, safe_deal, is_active, available_count, premium_status").
+		Column(squirrel.Alias(score, "score")).From(`public."product"`).
+		Where(whereClause).Where(whereClauseAfterCursor(score, "id", cursor)).
+		OrderBy("score DESC", "id DESC").Limit(limit)
 
 	SQLQuery, args, err := query.ToSql()
 	if err != nil {
 		logger.Errorln(err)
 
-		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+		return nil, nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
 	rowsProducts, err := tx.Query(ctx, SQLQuery, args...)
 	if err != nil {
 		logger.Errorln(err)
 
-		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+		return nil, nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
 	curProduct := new(models.ProductInFeed)
@@ -373,10 +375,13 @@ func (p *ProductStorage) selectProductsInFeedWithWhereOrderLimitOffset(ctx conte
 
 	var premiumStatus uint8
 
+	var curScore float64
+
 	_, err = pgx.ForEachRow(rowsProducts, []any{
 		&curProduct.ID, &curProduct.Title,
 		&curProduct.Price, &curProduct.CityID,
 		&curProduct.Delivery, &curProduct.SafeDeal, &curProduct.IsActive, &curProduct.AvailableCount, &premiumStatus,
+		&curScore,
 	}, func() error {
 		slProduct = append(slProduct, &models.ProductInFeed{ //nolint:exhaustruct
 			ID:             curProduct.ID,
@@ -395,98 +400,102 @@ func (p *ProductStorage) selectProductsInFeedWithWhereOrderLimitOffset(ctx conte
 	if err != nil {
 		logger.Errorln(err)
 
-		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+		return nil, nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return slProduct, nextCursor(len(slProduct), limit, curScore, curProduct.ID), nil
+}
+
+// getProductsInFeed selects page of products and adds images and favourites to them.
+func (p *ProductStorage) getProductsInFeed(ctx context.Context, tx pgx.Tx, limit uint64,
+	whereClause any, score squirrel.Sqlizer, cursor *models.Cursor, userID uint64,
+) ([]*models.ProductInFeed, *models.Cursor, error) {
+	slProduct, next, err := p.selectProductsInFeedAfterCursor(ctx, tx, limit, whereClause, score, cursor)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return slProduct, nil
+	err = p.fillProductsAddition(ctx, tx, slProduct, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return slProduct, next, nil
+}
+
+func scoreForPopularProducts() squirrel.Sqlizer {
+	return squirrel.Expr(ScoreForProductList(PremiumCoefficient,
+		NonPremiumCoefficient, SoldByUserCoefficient, ViewsCoefficient))
 }
 
 func (p *ProductStorage) GetPopularProducts(ctx context.Context,
-	offset uint64, count uint64, userID uint64,
-) ([]*models.ProductInFeed, error) {
+	cursor *models.Cursor, count uint64, userID uint64, filter *models.ProductFilter,
+) ([]*models.ProductInFeed, *models.Cursor, error) {
 	logger := p.logger.LogReqID(ctx)
 
 	var slProduct []*models.ProductInFeed
 
+	var next *models.Cursor
+
 	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
-		whereClause := "is_active = true"
+		whereClause := append(squirrel.And{squirrel.Expr("is_active = true")},
+			whereClauseForProductFilter(filter, "")...)
 
-		slProductInner, err := p.selectProductsInFeedWithWhereOrderLimitOffset(ctx,
-			tx, count, whereClause, []string{OrderByClauseForProductList(PremiumCoefficient,
-				NonPremiumCoefficient, SoldByUserCoefficient, ViewsCoefficient)}, offset)
+		slProductInner, nextInner, err := p.getProductsInFeed(ctx,
+			tx, count, whereClause, scoreForPopularProducts(), cursor, userID)
 		if err != nil {
 			return err
 		}
 
-		for _, product := range slProductInner {
-			productAdditionInner, err := p.getProductAddition(ctx, tx, product.ID, userID)
-			if err != nil {
-				return err
-			}
-
-			product.Images = productAdditionInner.images
-			product.Favourites = productAdditionInner.favourites
-			product.InFavourites = productAdditionInner.inFavourite
-
-			slProduct = append(slProduct, product)
-		}
+		slProduct = slProductInner
+		next = nextInner
 
 		return nil
 	})
 	if err != nil {
 		logger.Errorln(err)
 
-		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+		return nil, nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	return slProduct, nil
+	return slProduct, next, nil
 }
 
 func (p *ProductStorage) GetProductsOfSaler(ctx context.Context,
-	offset uint64, count uint64, userID uint64, isMy bool,
-) ([]*models.ProductInFeed, error) {
+	cursor *models.Cursor, count uint64, userID uint64, isMy bool,
+) ([]*models.ProductInFeed, *models.Cursor, error) {
 	logger := p.logger.LogReqID(ctx)
 
 	var slProduct []*models.ProductInFeed
 
+	var next *models.Cursor
+
 	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
-		var whereClause string
+		var whereClause squirrel.Eq
 		if isMy {
-			whereClause = fmt.Sprintf("saler_id = %d", userID)
+			whereClause = squirrel.Eq{"saler_id": userID}
 		} else {
-			whereClause = fmt.Sprintf("saler_id = %d AND is_active = true",
-				userID)
+			whereClause = squirrel.Eq{"saler_id": userID, "is_active": true}
 		}
 
-		slProductInner, err := p.selectProductsInFeedWithWhereOrderLimitOffset(ctx,
-			tx, count, whereClause, []string{OrderByClauseForProductList(PremiumCoefficient,
-				NonPremiumCoefficient, SoldByUserCoefficient, ViewsCoefficient)}, offset)
+		slProductInner, nextInner, err := p.getProductsInFeed(ctx,
+			tx, count, whereClause, scoreForPopularProducts(), cursor, userID)
 		if err != nil {
 			return err
 		}
 
-		for _, product := range slProductInner {
-			productAdditionInner, err := p.getProductAddition(ctx, tx, product.ID, userID)
-			if err != nil {
-				return err
-			}
-
-			product.Images = productAdditionInner.images
-			product.Favourites = productAdditionInner.favourites
-			product.InFavourites = productAdditionInner.inFavourite
-
-			slProduct = append(slProduct, product)
-		}
+		slProduct = slProductInner
+		next = nextInner
 
 		return nil
 	})
 	if err != nil {
 		logger.Errorln(err)
 
-		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+		return nil, nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	return slProduct, nil
+	return slProduct, next, nil
 }
 
 func (p *ProductStorage) deleteAllImagesOfProduct(ctx context.Context, tx pgx.Tx, productID uint64) error {
@@ -856,18 +865,21 @@ func (p *ProductStorage) incViews(ctx context.Context, tx pgx.Tx, productID uint
 	return nil
 }
 
+// searchProduct returns suggestions of titles. Trigram similarity lets to find
+// titles by unfinished or misspelled input.
 func (p *ProductStorage) searchProduct(ctx context.Context, tx pgx.Tx, searchInput string) ([]string, error) {
 	logger := p.logger.LogReqID(ctx)
 
 	SQLSearchProduct := `SELECT title
 FROM (
-  SELECT DISTINCT ON (title) title
-  FROM product
-  WHERE to_tsvector(title) @@ to_tsquery(replace($1 || ':*', ' ', ' | '))
-    AND ts_rank(to_tsvector(title), to_tsquery(replace($1 || ':*', ' ', ' | '))) > 0
+  SELECT DISTINCT ON (title) title,
+    ts_rank(search_vector, plainto_tsquery('russian', $1)) + word_similarity($1, title) AS rank
+  FROM public."product"
+  WHERE (search_vector @@ plainto_tsquery('russian', $1) OR $1 <% title)
     AND is_active = true
+  ORDER BY title, rank DESC
 ) AS t
-ORDER BY ts_rank(to_tsvector(title), to_tsquery(replace($1 || ':*', ' ', ' | '))) DESC
+ORDER BY rank DESC
 LIMIT 5;`
 
 	var products []string
@@ -921,96 +933,48 @@ func (p *ProductStorage) SearchProduct(ctx context.Context, searchInput string)
 	return products, nil
 }
 
-func (p *ProductStorage) searchProductFeed(ctx context.Context, tx pgx.Tx,
-	searchInput string, lastNumber uint64, limit uint64,
-) ([]*models.ProductInFeed, error) {
-	logger := p.logger.LogReqID(ctx)
-
-	var premiumStatus uint8
-
-	SQLSearchProduct := `SELECT id, title, price, city_id, delivery, safe_deal, is_active, available_count, premium_status
-	FROM product
-	WHERE (to_tsvector(title) @@ to_tsquery(replace($1 || ':*', ' ', ' | '))
-	   OR to_tsvector(description) @@ to_tsquery(replace($1 || ':*', ' ', ' | ')))
-	   AND is_active = true
-	ORDER BY ts_rank(to_tsvector(title), to_tsquery(replace($1 || ':*', ' ', ' | '))) DESC,
-			 ts_rank(to_tsvector(description), to_tsquery(replace($1 || ':*', ' ', ' | '))) DESC
-	OFFSET $2
-	LIMIT $3;`
-
-	rowsProducts, err := tx.Query(ctx, SQLSearchProduct, searchInput, lastNumber, limit)
-	if err != nil {
-		logger.Errorln(err)
-
-		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
-	}
-
-	curProduct := new(models.ProductInFeed)
-
-	var slProduct []*models.ProductInFeed
-
-	_, err = pgx.ForEachRow(rowsProducts, []any{
-		&curProduct.ID, &curProduct.Title,
-		&curProduct.Price, &curProduct.CityID,
-		&curProduct.Delivery, &curProduct.SafeDeal, &curProduct.IsActive, &curProduct.AvailableCount, &premiumStatus,
-	}, func() error {
-		slProduct = append(slProduct, &models.ProductInFeed{ //nolint:exhaustruct
-			ID:             curProduct.ID,
-			Title:          curProduct.Title,
-			Price:          curProduct.Price,
-			CityID:         curProduct.CityID,
-			Delivery:       curProduct.Delivery,
-			SafeDeal:       curProduct.SafeDeal,
-			IsActive:       curProduct.IsActive,
-			AvailableCount: curProduct.AvailableCount,
-			Premium:        statuses.IsIntStatusPremiumSuccessful(premiumStatus),
-		})
-
-		return nil
-	})
-	if err != nil {
-		logger.Errorln(err)
-
-		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
-	}
+// whereClauseForSearch matches products by weighted search_vector (title, description, category)
+// and falls back to trigram similarity of title, so typos like "айфонн" still find "айфон".
+// websearch_to_tsquery never fails on user input, unlike to_tsquery.
+func whereClauseForSearch(searchInput string) squirrel.Sqlizer {
+	return squirrel.Expr(`(search_vector @@ websearch_to_tsquery('russian', ?) OR ? <% title)`,
+		searchInput, searchInput)
+}
 
-	return slProduct, nil
+func scoreForSearch(searchInput string) squirrel.Sqlizer {
+	return squirrel.Expr(`(ts_rank(search_vector, websearch_to_tsquery('russian', ?)) + word_similarity(?, title))::float8`,
+		searchInput, searchInput)
 }
 
 func (p *ProductStorage) GetSearchProductFeed(ctx context.Context,
-	searchInput string, lastNumber uint64, limit uint64, userID uint64,
-) ([]*models.ProductInFeed, error) {
+	searchInput string, cursor *models.Cursor, limit uint64, userID uint64, filter *models.ProductFilter,
+) ([]*models.ProductInFeed, *models.Cursor, error) {
 	var slProduct []*models.ProductInFeed
 
+	var next *models.Cursor
+
 	logger := p.logger.LogReqID(ctx)
 
 	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
-		slProductInner, err := p.searchProductFeed(ctx,
-			tx, searchInput, lastNumber, limit)
+		whereClause := squirrel.And{whereClauseForSearch(searchInput), squirrel.Expr("is_active = true"),
+			whereClauseForProductFilter(filter, "")}
+
+		slProductInner, nextInner, err := p.getProductsInFeed(ctx,
+			tx, limit, whereClause, scoreForSearch(searchInput), cursor, userID)
 		if err != nil {
 			return err
 		}
 
-		for _, product := range slProductInner {
-			productAdditionInner, err := p.getProductAddition(ctx, tx, product.ID, userID)
-			if err != nil {
-				return err
-			}
-
-			product.Images = productAdditionInner.images
-			product.Favourites = productAdditionInner.favourites
-			product.InFavourites = productAdditionInner.inFavourite
-
-			slProduct = append(slProduct, product)
-		}
+		slProduct = slProductInner
+		next = nextInner
 
 		return nil
 	})
 	if err != nil {
 		logger.Errorln(err)
 
-		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+		return nil, nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	return slProduct, nil
+	return slProduct, next, nil
 }
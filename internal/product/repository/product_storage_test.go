@@ -272,7 +272,7 @@ func TestSearchProduct(t *testing.T) {
 			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
 				mockPool.ExpectBegin()
 
-				mockPool.ExpectQuery(`SELECT title`).WithArgs("Ca").
+				mockPool.ExpectQuery(`SELECT title[\s\S]+plainto_tsquery\('russian', \$1\)[\s\S]+\$1 <% title`).WithArgs("Ca").
 					WillReturnRows(pgxmock.NewRows([]string{"title"}).
 						AddRow("Car"))
 
@@ -287,7 +287,7 @@ func TestSearchProduct(t *testing.T) {
 			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
 				mockPool.ExpectBegin()
 
-				mockPool.ExpectQuery(`SELECT title`).WithArgs("Ca").
+				mockPool.ExpectQuery(`SELECT title[\s\S]+plainto_tsquery\('russian', \$1\)[\s\S]+\$1 <% title`).WithArgs("Ca").
 					WillReturnRows(pgxmock.NewRows([]string{"title"}).
 						AddRow("Car").AddRow("Cat").AddRow("Carrot"))
 
@@ -302,7 +302,7 @@ func TestSearchProduct(t *testing.T) {
 			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
 				mockPool.ExpectBegin()
 
-				mockPool.ExpectQuery(`SELECT title`).WithArgs("Ca").
+				mockPool.ExpectQuery(`SELECT title[\s\S]+plainto_tsquery\('russian', \$1\)[\s\S]+\$1 <% title`).WithArgs("Ca").
 					WillReturnRows(pgxmock.NewRows([]string{}))
 
 				mockPool.ExpectCommit()
@@ -358,10 +358,12 @@ func TestGetSearchProductFeed(t *testing.T) {
 		name                   string
 		behaviorProductStorage func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface)
 		searchInput            string
-		lastNumber             uint64
+		cursor                 *models.Cursor
 		limit                  uint64
 		userID                 uint64
+		filter                 *models.ProductFilter
 		expectedResponse       []*models.ProductInFeed
+		expectedNextCursor     *models.Cursor
 	}
 
 	testCases := [...]TestCase{
@@ -370,34 +372,36 @@ func TestGetSearchProductFeed(t *testing.T) {
 			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
 				mockPool.ExpectBegin()
 
-				mockPool.ExpectQuery(`SELECT id, title, price, city_id, 
-       delivery, safe_deal, is_active, available_count, premium_status FROM product`).
-					WithArgs("Ca", uint64(0), uint64(1)).
+				mockPool.ExpectQuery(`SELECT id, title,price, city_id, delivery, safe_deal, is_active, available_count, premium_status, `+
+					`\(\(ts_rank\(search_vector, websearch_to_tsquery\('russian', \$1\)\)[\s\S]+\)::float8\) AS score FROM public."product" WHERE`).
+					WithArgs("Ca", "Ca", "Ca", "Ca").
 					WillReturnRows(pgxmock.NewRows([]string{
 						"id", "title", "price", "city_id",
-						"delivery", "safe_deal", "is_active", "available_count", "premium",
+						"delivery", "safe_deal", "is_active", "available_count", "premium", "score",
 					}).
-						AddRow(uint64(1), "Car", uint64(1212), uint64(6), true, true, true, uint32(2), statuses.IntStatusPremiumNot))
+						AddRow(uint64(1), "Car", uint64(1212), uint64(6), true, true, true, uint32(2),
+							statuses.IntStatusPremiumNot, float64(0.8)))
 
-				mockPool.ExpectQuery(`SELECT url FROM public."image"`).WithArgs(uint64(1)).
-					WillReturnRows(pgxmock.NewRows([]string{"url"}).
-						AddRow("safsafddasf"))
+				mockPool.ExpectQuery(`SELECT product_id, url FROM public."image"`).WithArgs([]uint64{1}).
+					WillReturnRows(pgxmock.NewRows([]string{"product_id", "url"}).
+						AddRow(uint64(1), "safsafddasf"))
 
-				mockPool.ExpectQuery(`SELECT COUNT`).WithArgs(uint64(1)).
-					WillReturnRows(pgxmock.NewRows([]string{`count`}).
-						AddRow(uint64(1)))
+				mockPool.ExpectQuery(`SELECT product_id, COUNT\(id\) FROM public."favourite"`).WithArgs([]uint64{1}).
+					WillReturnRows(pgxmock.NewRows([]string{"product_id", "count"}).
+						AddRow(uint64(1), uint64(1)))
 
-				mockPool.ExpectQuery(`SELECT id FROM public.favourite`).WithArgs(uint64(1), uint64(1)).
-					WillReturnRows(pgxmock.NewRows([]string{"id"}).
-						AddRow("1"))
+				mockPool.ExpectQuery(`SELECT product_id FROM public."favourite"`).WithArgs(uint64(1), []uint64{1}).
+					WillReturnRows(pgxmock.NewRows([]string{"product_id"}).
+						AddRow(uint64(1)))
 
 				mockPool.ExpectCommit()
 				mockPool.ExpectRollback()
 			},
-			searchInput: "Ca",
-			lastNumber:  0,
-			limit:       1,
-			userID:      1,
+			searchInput:        "Ca",
+			cursor:             nil,
+			limit:              1,
+			userID:             1,
+			expectedNextCursor: models.NewCursor(0.8, 1),
 			expectedResponse: []*models.ProductInFeed{
 				{
 					ID: 1, Title: "Car", Price: 1212, CityID: 6, Delivery: true, IsActive: true,
@@ -411,60 +415,46 @@ func TestGetSearchProductFeed(t *testing.T) {
 			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
 				mockPool.ExpectBegin()
 
-				mockPool.ExpectQuery(`SELECT id, title, price, city_id, 
-       delivery, safe_deal, is_active, available_count, premium_status FROM product`).
-					WithArgs("Ca", uint64(0), uint64(1)).
+				mockPool.ExpectQuery(`SELECT id, title,price, city_id, delivery, safe_deal, is_active, available_count, premium_status, `+
+					`\(\(ts_rank\(search_vector, websearch_to_tsquery\('russian', \$1\)\)[\s\S]+\)::float8\) AS score FROM public."product" WHERE`).
+					WithArgs("Ca", "Ca", "Ca", "Ca").
 					WillReturnRows(pgxmock.NewRows([]string{
 						"id", "title", "price", "city_id",
-						"delivery", "safe_deal", "is_active", "available_count", "premium",
+						"delivery", "safe_deal", "is_active", "available_count", "premium", "score",
 					}).
-						AddRow(uint64(1), "Car", uint64(1212), uint64(6), true, true, true, uint32(2), statuses.IntStatusPremiumNot).
-						AddRow(uint64(2), "Cat", uint64(1212), uint64(6), true, true, true, uint32(2), statuses.IntStatusPremiumNot).
-						AddRow(uint64(3), "Carrot", uint64(1212), uint64(6), true, true, true, uint32(2), statuses.IntStatusPremiumNot))
-
-				mockPool.ExpectQuery(`SELECT url FROM public."image"`).WithArgs(uint64(1)).
-					WillReturnRows(pgxmock.NewRows([]string{"url"}).
-						AddRow("safsafddasf"))
-
-				mockPool.ExpectQuery(`SELECT COUNT`).WithArgs(uint64(1)).
-					WillReturnRows(pgxmock.NewRows([]string{`count`}).
-						AddRow(uint64(1)))
-
-				mockPool.ExpectQuery(`SELECT id FROM public.favourite`).WithArgs(uint64(1), uint64(1)).
-					WillReturnRows(pgxmock.NewRows([]string{"id"}).
-						AddRow("1"))
-
-				mockPool.ExpectQuery(`SELECT url FROM public."image"`).WithArgs(uint64(2)).
-					WillReturnRows(pgxmock.NewRows([]string{"url"}).
-						AddRow("safsafddasf"))
-
-				mockPool.ExpectQuery(`SELECT COUNT`).WithArgs(uint64(2)).
-					WillReturnRows(pgxmock.NewRows([]string{`count`}).
-						AddRow(uint64(1)))
-
-				mockPool.ExpectQuery(`SELECT id FROM public.favourite`).WithArgs(uint64(2), uint64(1)).
-					WillReturnRows(pgxmock.NewRows([]string{"id"}).
-						AddRow("1"))
-
-				mockPool.ExpectQuery(`SELECT url FROM public."image"`).WithArgs(uint64(3)).
-					WillReturnRows(pgxmock.NewRows([]string{"url"}).
-						AddRow("safsafddasf"))
-
-				mockPool.ExpectQuery(`SELECT COUNT`).WithArgs(uint64(3)).
-					WillReturnRows(pgxmock.NewRows([]string{`count`}).
-						AddRow(uint64(1)))
-
-				mockPool.ExpectQuery(`SELECT id FROM public.favourite`).WithArgs(uint64(3), uint64(1)).
-					WillReturnRows(pgxmock.NewRows([]string{"id"}).
-						AddRow("1"))
+						AddRow(uint64(1), "Car", uint64(1212), uint64(6), true, true, true, uint32(2),
+							statuses.IntStatusPremiumNot, float64(0.8)).
+						AddRow(uint64(2), "Cat", uint64(1212), uint64(6), true, true, true, uint32(2),
+							statuses.IntStatusPremiumNot, float64(0.7)).
+						AddRow(uint64(3), "Carrot", uint64(1212), uint64(6), true, true, true, uint32(2),
+							statuses.IntStatusPremiumNot, float64(0.6)))
+
+				mockPool.ExpectQuery(`SELECT product_id, url FROM public."image"`).WithArgs([]uint64{1, 2, 3}).
+					WillReturnRows(pgxmock.NewRows([]string{"product_id", "url"}).
+						AddRow(uint64(1), "safsafddasf").
+						AddRow(uint64(2), "safsafddasf").
+						AddRow(uint64(3), "safsafddasf"))
+
+				mockPool.ExpectQuery(`SELECT product_id, COUNT\(id\) FROM public."favourite"`).WithArgs([]uint64{1, 2, 3}).
+					WillReturnRows(pgxmock.NewRows([]string{"product_id", "count"}).
+						AddRow(uint64(1), uint64(1)).
+						AddRow(uint64(2), uint64(1)).
+						AddRow(uint64(3), uint64(1)))
+
+				mockPool.ExpectQuery(`SELECT product_id FROM public."favourite"`).WithArgs(uint64(1), []uint64{1, 2, 3}).
+					WillReturnRows(pgxmock.NewRows([]string{"product_id"}).
+						AddRow(uint64(1)).
+						AddRow(uint64(2)).
+						AddRow(uint64(3)))
 
 				mockPool.ExpectCommit()
 				mockPool.ExpectRollback()
 			},
-			searchInput: "Ca",
-			lastNumber:  0,
-			limit:       1,
-			userID:      1,
+			searchInput:        "Ca",
+			cursor:             nil,
+			limit:              1,
+			userID:             1,
+			expectedNextCursor: models.NewCursor(0.6, 3),
 			expectedResponse: []*models.ProductInFeed{
 				{
 					ID: 1, Title: "Car", Price: 1212, CityID: 6, Delivery: true, IsActive: true, SafeDeal: true,
@@ -480,24 +470,179 @@ func TestGetSearchProductFeed(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "test with filter",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectQuery(`SELECT id, title,price, city_id, delivery, safe_deal, is_active, available_count, premium_status, `+
+					`\(\(ts_rank[\s\S]+\)::float8\) AS score FROM public."product" WHERE \([\s\S]+ AND is_active = true `+
+					`AND \(category_id IN \(WITH RECURSIVE subtree [\s\S]+ AND city_id = \$6 AND price <= \$7 `+
+					`AND delivery = \$8\)\) AND \(\(ts_rank[\s\S]+\)::float8, id\) < \(\$11::float8, \$12::bigint\) `+
+					`ORDER BY score DESC, id DESC LIMIT 1`).
+					WithArgs("Ca", "Ca", "Ca", "Ca", uint64(2), uint64(6), uint64(5000), true, "Ca", "Ca",
+						float64(0.5), uint64(7)).
+					WillReturnRows(pgxmock.NewRows([]string{
+						"id", "title", "price", "city_id",
+						"delivery", "safe_deal", "is_active", "available_count", "premium", "score",
+					}).
+						AddRow(uint64(1), "Car", uint64(1212), uint64(6), true, true, true, uint32(2),
+							statuses.IntStatusPremiumNot, float64(0.8)))
+
+				mockPool.ExpectQuery(`SELECT product_id, url FROM public."image"`).WithArgs([]uint64{1}).
+					WillReturnRows(pgxmock.NewRows([]string{"product_id", "url"}).
+						AddRow(uint64(1), "safsafddasf"))
+
+				mockPool.ExpectQuery(`SELECT product_id, COUNT\(id\) FROM public."favourite"`).WithArgs([]uint64{1}).
+					WillReturnRows(pgxmock.NewRows([]string{"product_id", "count"}).
+						AddRow(uint64(1), uint64(1)))
+
+				mockPool.ExpectQuery(`SELECT product_id FROM public."favourite"`).WithArgs(uint64(1), []uint64{1}).
+					WillReturnRows(pgxmock.NewRows([]string{"product_id"}))
+
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			searchInput:        "Ca",
+			cursor:             models.NewCursor(0.5, 7),
+			limit:              1,
+			userID:             1,
+			filter:             &models.ProductFilter{CategoryID: 2, CityID: 6, MaxPrice: 5000, Delivery: true},
+			expectedNextCursor: models.NewCursor(0.8, 1),
+			expectedResponse: []*models.ProductInFeed{
+				{
+					ID: 1, Title: "Car", Price: 1212, CityID: 6, Delivery: true, IsActive: true,
+					SafeDeal: true, AvailableCount: 2, Premium: false,
+					Images: []models.Image{{URL: "safsafddasf"}}, InFavourites: false, Favourites: 1,
+				},
+			},
+		},
 		{
 			name: "test empty",
 			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
 				mockPool.ExpectBegin()
 
-				mockPool.ExpectQuery(`SELECT id, title, price, city_id, 
-       delivery, safe_deal, is_active, available_count, premium_status FROM product`).
-					WithArgs("Ca", uint64(0), uint64(1)).
+				mockPool.ExpectQuery(`SELECT id, title,price, city_id, delivery, safe_deal, is_active, available_count, premium_status, `+
+					`\(\(ts_rank\(search_vector, websearch_to_tsquery\('russian', \$1\)\)[\s\S]+\)::float8\) AS score FROM public."product" WHERE`).
+					WithArgs("Ca", "Ca", "Ca", "Ca").
 					WillReturnRows(pgxmock.NewRows([]string{}))
 
 				mockPool.ExpectCommit()
 				mockPool.ExpectRollback()
 			},
-			searchInput:      "Ca",
-			lastNumber:       0,
-			limit:            1,
-			userID:           1,
-			expectedResponse: nil,
+			searchInput:        "Ca",
+			cursor:             nil,
+			limit:              1,
+			userID:             1,
+			expectedNextCursor: nil,
+			expectedResponse:   nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			mockPool, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			prodStorage, err := repository.NewProductStorage(mockPool)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			testCase.behaviorProductStorage(prodStorage, mockPool)
+
+			response, nextCursor, err := prodStorage.GetSearchProductFeed(ctx, testCase.searchInput,
+				testCase.cursor, testCase.limit, testCase.userID, testCase.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := utils.EqualTest(response, testCase.expectedResponse); err != nil {
+				t.Fatalf("Failed EqualTest %+v", err)
+			}
+
+			if err := utils.EqualTest(nextCursor, testCase.expectedNextCursor); err != nil {
+				t.Fatalf("Failed EqualTest %+v", err)
+			}
+
+			if err := mockPool.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestGetProductFacets(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	type TestCase struct {
+		name                   string
+		behaviorProductStorage func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface)
+		searchInput            string
+		filter                 *models.ProductFilter
+		expectedResponse       *models.ProductFacets
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectQuery(`SELECT category_id, COUNT\(id\) FROM public."product" WHERE \(\(is_active = true\) ` +
+					`AND \(1=1\)\) GROUP BY category_id`).
+					WillReturnRows(pgxmock.NewRows([]string{"category_id", "count"}).
+						AddRow(uint64(1), uint64(3)).AddRow(uint64(2), uint64(1)))
+
+				mockPool.ExpectQuery(`SELECT city_id, COUNT\(id\) FROM public."product" WHERE \(\(is_active = true\) ` +
+					`AND \(1=1\)\) GROUP BY city_id`).
+					WillReturnRows(pgxmock.NewRows([]string{"city_id", "count"}).
+						AddRow(uint64(6), uint64(4)))
+
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			filter: nil,
+			expectedResponse: &models.ProductFacets{
+				Categories: []models.FacetCount{{ID: 1, Count: 3}, {ID: 2, Count: 1}},
+				Cities:     []models.FacetCount{{ID: 6, Count: 4}},
+			},
+		},
+		{
+			name: "test own dimension ignored",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectQuery(`SELECT category_id, COUNT\(id\) FROM public."product" ` +
+					`WHERE \(\(is_active = true\) AND \(city_id = \$1\)\) GROUP BY category_id`).
+					WithArgs(uint64(6)).
+					WillReturnRows(pgxmock.NewRows([]string{"category_id", "count"}).
+						AddRow(uint64(2), uint64(1)))
+
+				mockPool.ExpectQuery(`SELECT city_id, COUNT\(id\) FROM public."product" ` +
+					`WHERE \(\(is_active = true\) AND \(category_id IN \(WITH RECURSIVE subtree [\s\S]+\)\)\) GROUP BY city_id`).
+					WithArgs(uint64(2)).
+					WillReturnRows(pgxmock.NewRows([]string{"city_id", "count"}).
+						AddRow(uint64(6), uint64(1)).AddRow(uint64(7), uint64(1)))
+
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			filter: &models.ProductFilter{CategoryID: 2, CityID: 6},
+			expectedResponse: &models.ProductFacets{
+				Categories: []models.FacetCount{{ID: 2, Count: 1}},
+				Cities:     []models.FacetCount{{ID: 6, Count: 1}, {ID: 7, Count: 1}},
+			},
 		},
 	}
 
@@ -521,8 +666,7 @@ func TestGetSearchProductFeed(t *testing.T) {
 
 			testCase.behaviorProductStorage(prodStorage, mockPool)
 
-			response, err := prodStorage.GetSearchProductFeed(ctx, testCase.searchInput,
-				testCase.lastNumber, testCase.limit, testCase.userID)
+			response, err := prodStorage.GetProductFacets(ctx, testCase.searchInput, testCase.filter)
 			if err != nil {
 				t.Fatal(err)
 			}
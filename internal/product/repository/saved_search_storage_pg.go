@@ -0,0 +1,418 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/repository"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrNoAffectedSavedSearchRows = myerrors.NewErrorBadFormatRequest("Не получилось изменить сохраненный поиск")
+
+	NameSeqSavedSearch = pgx.Identifier{"public", "saved_search_id_seq"} //nolint:gochecknoglobals
+)
+
+func (p *ProductStorage) insertSavedSearch(ctx context.Context, tx pgx.Tx,
+	preSavedSearch *models.PreSavedSearch,
+) error {
+	logger := p.logger.LogReqID(ctx)
+
+	SQLInsertSavedSearch := `INSERT INTO public."saved_search"(owner_id, query, category_id, city_id,
+		min_price, max_price, delivery, safe_deal, premium_only) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := tx.Exec(ctx, SQLInsertSavedSearch, preSavedSearch.OwnerID, preSavedSearch.Query,
+		preSavedSearch.Filter.CategoryID, preSavedSearch.Filter.CityID,
+		preSavedSearch.Filter.MinPrice, preSavedSearch.Filter.MaxPrice,
+		preSavedSearch.Filter.Delivery, preSavedSearch.Filter.SafeDeal, preSavedSearch.Filter.PremiumOnly)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+func (p *ProductStorage) AddSavedSearch(ctx context.Context, preSavedSearch *models.PreSavedSearch) (uint64, error) {
+	logger := p.logger.LogReqID(ctx)
+
+	var savedSearchID uint64
+
+	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
+		err := p.insertSavedSearch(ctx, tx, preSavedSearch)
+		if err != nil {
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		lastSavedSearchID, err := repository.GetLastValSeq(ctx, tx, logger, NameSeqSavedSearch)
+		if err != nil {
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		savedSearchID = lastSavedSearchID
+
+		return nil
+	})
+	if err != nil {
+		logger.Errorln(err)
+
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return savedSearchID, nil
+}
+
+func (p *ProductStorage) selectSavedSearches(ctx context.Context, tx pgx.Tx,
+	SQLSelectSavedSearches string, args ...any,
+) ([]*models.SavedSearch, error) {
+	logger := p.logger.LogReqID(ctx)
+
+	savedSearchesRows, err := tx.Query(ctx, SQLSelectSavedSearches, args...)
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	curSavedSearch := new(models.SavedSearch)
+
+	var savedSearches []*models.SavedSearch
+
+	_, err = pgx.ForEachRow(savedSearchesRows, []any{
+		&curSavedSearch.ID, &curSavedSearch.OwnerID, &curSavedSearch.Query,
+		&curSavedSearch.Filter.CategoryID, &curSavedSearch.Filter.CityID,
+		&curSavedSearch.Filter.MinPrice, &curSavedSearch.Filter.MaxPrice,
+		&curSavedSearch.Filter.Delivery, &curSavedSearch.Filter.SafeDeal, &curSavedSearch.Filter.PremiumOnly,
+		&curSavedSearch.CreatedAt, &curSavedSearch.LastCheckedAt,
+	}, func() error {
+		savedSearch := *curSavedSearch
+		savedSearches = append(savedSearches, &savedSearch)
+
+		return nil
+	})
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return savedSearches, nil
+}
+
+func (p *ProductStorage) GetSavedSearches(ctx context.Context, ownerID uint64) ([]*models.SavedSearch, error) {
+	logger := p.logger.LogReqID(ctx)
+
+	var savedSearches []*models.SavedSearch
+
+	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
+		SQLSelectSavedSearches := `SELECT id, owner_id, query, category_id, city_id, min_price, max_price,
+		delivery, safe_deal, premium_only, created_at, last_checked_at
+		FROM public."saved_search" WHERE owner_id=$1 ORDER BY id DESC`
+
+		savedSearchesInner, err := p.selectSavedSearches(ctx, tx, SQLSelectSavedSearches, ownerID)
+		if err != nil {
+			return err
+		}
+
+		savedSearches = savedSearchesInner
+
+		return nil
+	})
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return savedSearches, nil
+}
+
+func (p *ProductStorage) updateSavedSearch(ctx context.Context, tx pgx.Tx,
+	savedSearchID uint64, preSavedSearch *models.PreSavedSearch,
+) error {
+	logger := p.logger.LogReqID(ctx)
+
+	SQLUpdateSavedSearch := `UPDATE public."saved_search" SET query=$1, category_id=$2, city_id=$3,
+		min_price=$4, max_price=$5, delivery=$6, safe_deal=$7, premium_only=$8
+		WHERE id=$9 AND owner_id=$10`
+
+	result, err := tx.Exec(ctx, SQLUpdateSavedSearch, preSavedSearch.Query,
+		preSavedSearch.Filter.CategoryID, preSavedSearch.Filter.CityID,
+		preSavedSearch.Filter.MinPrice, preSavedSearch.Filter.MaxPrice,
+		preSavedSearch.Filter.Delivery, preSavedSearch.Filter.SafeDeal, preSavedSearch.Filter.PremiumOnly,
+		savedSearchID, preSavedSearch.OwnerID)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	rowsAffected := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf(myerrors.ErrTemplate, ErrNoAffectedSavedSearchRows)
+	}
+
+	return nil
+}
+
+func (p *ProductStorage) UpdateSavedSearch(ctx context.Context,
+	savedSearchID uint64, preSavedSearch *models.PreSavedSearch,
+) error {
+	logger := p.logger.LogReqID(ctx)
+
+	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
+		err := p.updateSavedSearch(ctx, tx, savedSearchID, preSavedSearch)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+func (p *ProductStorage) deleteSavedSearch(ctx context.Context, tx pgx.Tx,
+	savedSearchID uint64, ownerID uint64,
+) error {
+	logger := p.logger.LogReqID(ctx)
+
+	SQLDeleteSavedSearch := `DELETE FROM public."saved_search" WHERE id=$1 AND owner_id=$2`
+
+	result, err := tx.Exec(ctx, SQLDeleteSavedSearch, savedSearchID, ownerID)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	rowsAffected := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf(myerrors.ErrTemplate, ErrNoAffectedSavedSearchRows)
+	}
+
+	return nil
+}
+
+func (p *ProductStorage) DeleteSavedSearch(ctx context.Context, savedSearchID uint64, ownerID uint64) error {
+	logger := p.logger.LogReqID(ctx)
+
+	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
+		err := p.deleteSavedSearch(ctx, tx, savedSearchID, ownerID)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// insertNotificationsOfSavedSearch adds notifications about products which match saved search
+// and were created in (savedSearch.LastCheckedAt, checkedAt]. Own products of user are skipped.
+func (p *ProductStorage) insertNotificationsOfSavedSearch(ctx context.Context, tx pgx.Tx,
+	savedSearch *models.SavedSearch, checkedAt time.Time,
+) (uint64, error) {
+	logger := p.logger.LogReqID(ctx)
+
+	selectNewProducts := squirrel.Select().
+		Column("?::bigint", savedSearch.OwnerID).Column("?::bigint", savedSearch.ID).Column("id").
+		From(`public."product"`).
+		Where(squirrel.And{
+			whereClauseForSearch(savedSearch.Query),
+			squirrel.Expr("is_active = true"),
+			squirrel.NotEq{"saler_id": savedSearch.OwnerID},
+			squirrel.Gt{"created_at": savedSearch.LastCheckedAt},
+			squirrel.LtOrEq{"created_at": checkedAt},
+			whereClauseForProductFilter(&savedSearch.Filter, ""),
+		})
+
+	query := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).
+		Insert(`public."notification"`).Columns("owner_id", "saved_search_id", "product_id").
+		Select(selectNewProducts).Suffix("ON CONFLICT DO NOTHING")
+
+	SQLInsertNotifications, args, err := query.ToSql()
+	if err != nil {
+		logger.Errorln(err)
+
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	result, err := tx.Exec(ctx, SQLInsertNotifications, args...)
+	if err != nil {
+		logger.Errorln(err)
+
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return uint64(result.RowsAffected()), nil
+}
+
+func (p *ProductStorage) updateLastCheckedAtOfSavedSearches(ctx context.Context, tx pgx.Tx,
+	savedSearchIDs []uint64, checkedAt time.Time,
+) error {
+	logger := p.logger.LogReqID(ctx)
+
+	SQLUpdateLastCheckedAt := `UPDATE public."saved_search" SET last_checked_at=$1 WHERE id = ANY($2)`
+
+	_, err := tx.Exec(ctx, SQLUpdateLastCheckedAt, checkedAt, savedSearchIDs)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// CheckSavedSearches creates notifications for all saved searches checked before checkedAt
+// and returns count of new notifications. Saved searches are locked with SKIP LOCKED,
+// so several instances of worker don't check the same search simultaneously.
+func (p *ProductStorage) CheckSavedSearches(ctx context.Context, checkedAt time.Time) (uint64, error) {
+	logger := p.logger.LogReqID(ctx)
+
+	var countNotifications uint64
+
+	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
+		SQLSelectSavedSearches := `SELECT id, owner_id, query, category_id, city_id, min_price, max_price,
+		delivery, safe_deal, premium_only, created_at, last_checked_at
+		FROM public."saved_search" WHERE last_checked_at < $1 FOR UPDATE SKIP LOCKED`
+
+		savedSearches, err := p.selectSavedSearches(ctx, tx, SQLSelectSavedSearches, checkedAt)
+		if err != nil {
+			return err
+		}
+
+		if len(savedSearches) == 0 {
+			return nil
+		}
+
+		savedSearchIDs := make([]uint64, len(savedSearches))
+
+		for i, savedSearch := range savedSearches {
+			countInner, err := p.insertNotificationsOfSavedSearch(ctx, tx, savedSearch, checkedAt)
+			if err != nil {
+				return err
+			}
+
+			countNotifications += countInner
+			savedSearchIDs[i] = savedSearch.ID
+		}
+
+		return p.updateLastCheckedAtOfSavedSearches(ctx, tx, savedSearchIDs, checkedAt)
+	})
+	if err != nil {
+		logger.Errorln(err)
+
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return countNotifications, nil
+}
+
+func (p *ProductStorage) selectNotifications(ctx context.Context, tx pgx.Tx,
+	ownerID uint64,
+) ([]*models.Notification, error) {
+	logger := p.logger.LogReqID(ctx)
+
+	SQLSelectNotifications := `SELECT n.id, n.saved_search_id, s.query, n.product_id, p.title, p.price,
+		n.is_read, n.created_at
+		FROM public."notification" n
+		JOIN public."saved_search" s ON s.id = n.saved_search_id
+		JOIN public."product" p ON p.id = n.product_id
+		WHERE n.owner_id=$1 ORDER BY n.id DESC`
+
+	notificationsRows, err := tx.Query(ctx, SQLSelectNotifications, ownerID)
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	curNotification := new(models.Notification)
+
+	var notifications []*models.Notification
+
+	_, err = pgx.ForEachRow(notificationsRows, []any{
+		&curNotification.ID, &curNotification.SavedSearchID, &curNotification.Query,
+		&curNotification.ProductID, &curNotification.Title, &curNotification.Price,
+		&curNotification.IsRead, &curNotification.CreatedAt,
+	}, func() error {
+		notification := *curNotification
+		notifications = append(notifications, &notification)
+
+		return nil
+	})
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return notifications, nil
+}
+
+func (p *ProductStorage) GetNotifications(ctx context.Context, ownerID uint64) ([]*models.Notification, error) {
+	logger := p.logger.LogReqID(ctx)
+
+	var notifications []*models.Notification
+
+	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
+		notificationsInner, err := p.selectNotifications(ctx, tx, ownerID)
+		if err != nil {
+			return err
+		}
+
+		notifications = notificationsInner
+
+		return nil
+	})
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return notifications, nil
+}
+
+func (p *ProductStorage) ReadNotifications(ctx context.Context, ownerID uint64) error {
+	logger := p.logger.LogReqID(ctx)
+
+	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
+		SQLReadNotifications := `UPDATE public."notification" SET is_read=true WHERE owner_id=$1 AND is_read=false`
+
+		_, err := tx.Exec(ctx, SQLReadNotifications, ownerID)
+		if err != nil {
+			logger.Errorln(err)
+
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
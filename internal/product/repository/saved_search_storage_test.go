@@ -0,0 +1,191 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/repository"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+	"github.com/pashagolub/pgxmock/v3"
+)
+
+func TestAddSavedSearch(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	type TestCase struct {
+		name                   string
+		behaviorProductStorage func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface)
+		preSavedSearch         *models.PreSavedSearch
+		expectedResponse       uint64
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectExec(`INSERT INTO public."saved_search"`).WithArgs(uint64(1), "iphone",
+					uint64(0), uint64(2), uint64(0), uint64(1000), true, false, false).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+				mockPool.ExpectQuery(`SELECT last_value FROM "public"."saved_search_id_seq";`).
+					WillReturnRows(pgxmock.NewRows([]string{"last_value"}).
+						AddRow(uint64(3)))
+
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			preSavedSearch: &models.PreSavedSearch{
+				OwnerID: 1, Query: "iphone",
+				Filter: models.ProductFilter{CityID: 2, MaxPrice: 1000, Delivery: true}, //nolint:exhaustruct
+			},
+			expectedResponse: uint64(3),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			mockPool, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			productStorage, err := repository.NewProductStorage(mockPool)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			testCase.behaviorProductStorage(productStorage, mockPool)
+
+			response, err := productStorage.AddSavedSearch(ctx, testCase.preSavedSearch)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := mockPool.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+
+			err = utils.EqualTest(response, testCase.expectedResponse)
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestCheckSavedSearches(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	checkedAt := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	lastCheckedAt := checkedAt.Add(-time.Minute * 5)
+
+	savedSearchColumns := []string{
+		"id", "owner_id", "query", "category_id", "city_id", "min_price", "max_price",
+		"delivery", "safe_deal", "premium_only", "created_at", "last_checked_at",
+	}
+
+	type TestCase struct {
+		name                   string
+		behaviorProductStorage func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface)
+		expectedResponse       uint64
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectQuery(`SELECT id, owner_id, query[\s\S]+FROM public."saved_search" ` +
+					`WHERE last_checked_at < \$1 FOR UPDATE SKIP LOCKED`).WithArgs(checkedAt).
+					WillReturnRows(pgxmock.NewRows(savedSearchColumns).
+						AddRow(uint64(1), uint64(2), "iphone", uint64(0), uint64(3), uint64(0), uint64(0),
+							false, false, false, lastCheckedAt, lastCheckedAt).
+						AddRow(uint64(4), uint64(5), "car", uint64(0), uint64(0), uint64(0), uint64(0),
+							false, false, false, lastCheckedAt, lastCheckedAt))
+
+				mockPool.ExpectExec(`INSERT INTO public."notification" \(owner_id,saved_search_id,product_id\) `+
+					`SELECT \$1::bigint, \$2::bigint, id FROM public."product" WHERE [\s\S]+ AND \(city_id = \$8\)\) `+
+					`ON CONFLICT DO NOTHING`).
+					WithArgs(uint64(2), uint64(1), "iphone", "iphone", uint64(2), lastCheckedAt, checkedAt, uint64(3)).
+					WillReturnResult(pgxmock.NewResult("INSERT", 2))
+
+				mockPool.ExpectExec(`INSERT INTO public."notification"`).
+					WithArgs(uint64(5), uint64(4), "car", "car", uint64(5), lastCheckedAt, checkedAt).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+				mockPool.ExpectExec(`UPDATE public."saved_search" SET last_checked_at=\$1 WHERE id = ANY\(\$2\)`).
+					WithArgs(checkedAt, []uint64{1, 4}).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			expectedResponse: uint64(3),
+		},
+		{
+			name: "test no saved searches",
+			behaviorProductStorage: func(m *repository.ProductStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectQuery(`SELECT id, owner_id, query`).WithArgs(checkedAt).
+					WillReturnRows(pgxmock.NewRows(savedSearchColumns))
+
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			expectedResponse: uint64(0),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			mockPool, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			productStorage, err := repository.NewProductStorage(mockPool)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			testCase.behaviorProductStorage(productStorage, mockPool)
+
+			response, err := productStorage.CheckSavedSearches(ctx, checkedAt)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := mockPool.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+
+			err = utils.EqualTest(response, testCase.expectedResponse)
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+)
+
+// lenIdempotencyKeyBytes gives key of 48 hex symbols, so suffixes of capture
+// and cancel requests still fit into 64 symbols allowed by yookassa.
+const lenIdempotencyKeyBytes = 24
+
+func GenerateIdempotencyKey() (string, error) {
+	keyBytes := make([]byte, lenIdempotencyKeyBytes)
+
+	_, err := rand.Read(keyBytes)
+	if err != nil {
+		return "", fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return hex.EncodeToString(keyBytes), nil
+}
+
+func (b BasketService) SetPaymentYookassaID(ctx context.Context, paymentID uint64, yookassaID string) error {
+	err := b.storage.SetPaymentYookassaID(ctx, paymentID, yookassaID)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// ConfirmBasketPayment moves orders of payment to paid, status is status of payment in yookassa.
+// event is saved together with the status, so repeated event returns productrepo.ErrPaymentEventHandled.
+func (b BasketService) ConfirmBasketPayment(ctx context.Context,
+	paymentID uint64, status uint8, event *models.PaymentEvent,
+) error {
+	err := b.storage.ConfirmBasketPayment(ctx, paymentID, status, event)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// CancelBasketPayment returns not paid orders of payment to basket and releases their products.
+func (b BasketService) CancelBasketPayment(ctx context.Context,
+	paymentID uint64, status uint8, event *models.PaymentEvent,
+) error {
+	err := b.storage.CancelBasketPayment(ctx, paymentID, status, event)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+func (b BasketService) UpdatePaymentStatus(ctx context.Context,
+	paymentID uint64, status uint8, event *models.PaymentEvent,
+) error {
+	err := b.storage.UpdatePaymentStatus(ctx, paymentID, status, event)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// GetHeldPayments returns safe deal payments which can be captured or cancelled.
+func (b BasketService) GetHeldPayments(ctx context.Context) ([]*models.HeldPayment, error) {
+	heldPayments, err := b.storage.GetHeldPayments(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return heldPayments, nil
+}
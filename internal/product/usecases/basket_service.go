@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	productrepo "github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/repository"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
@@ -11,6 +12,10 @@ import (
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
 )
 
+// GracePendingPayment is time after end of reservation, during which orders of pending payment
+// wait for yookassa. After it they are cancelled with their payment and products return to stock.
+const GracePendingPayment = time.Hour
+
 var _ IBasketStorage = (*productrepo.ProductStorage)(nil)
 
 type IBasketStorage interface {
@@ -19,23 +24,35 @@ type IBasketStorage interface {
 	GetOrdersNotInBasketByUserID(ctx context.Context, userID uint64) ([]*models.OrderNotInBasket, error)
 	GetOrdersSoldByUserID(ctx context.Context, userID uint64) ([]*models.OrderNotInBasket, error)
 	UpdateOrderCount(ctx context.Context, userID uint64, orderID uint64, newCount uint32) error
-	UpdateOrderStatus(ctx context.Context, userID uint64, orderID uint64, newStatus uint8) error
-	BuyFullBasket(ctx context.Context, userID uint64) error
+	GetOrderParticipants(ctx context.Context, orderID uint64) (*models.OrderParticipants, error)
+	UpdateOrderStatus(ctx context.Context, preHistory *models.PreOrderStatusHistory, reservedUntil time.Time) error
+	GetOrderStatusHistory(ctx context.Context, orderID uint64) ([]*models.OrderStatusHistory, error)
+	BuyFullBasket(ctx context.Context, userID uint64, reservedUntil time.Time,
+		idempotencyKey string) (*models.BasketPayment, error)
+	SetPaymentYookassaID(ctx context.Context, paymentID uint64, yookassaID string) error
+	ConfirmBasketPayment(ctx context.Context, paymentID uint64, status uint8, event *models.PaymentEvent) error
+	CancelBasketPayment(ctx context.Context, paymentID uint64, status uint8, event *models.PaymentEvent) error
+	UpdatePaymentStatus(ctx context.Context, paymentID uint64, status uint8, event *models.PaymentEvent) error
+	GetHeldPayments(ctx context.Context) ([]*models.HeldPayment, error)
+	CancelExpiredReservations(ctx context.Context, now time.Time, deadlinePendingPayment time.Time) (uint64, error)
 	DeleteOrder(ctx context.Context, orderID uint64, ownerID uint64) error
 }
 
 type BasketService struct {
-	storage IBasketStorage
-	logger  *mylogger.MyLogger
+	storage            IBasketStorage
+	reservationTimeout time.Duration
+	logger             *mylogger.MyLogger
 }
 
-func NewBasketService(basketStorage IBasketStorage) (*BasketService, error) {
+// NewBasketService reservationTimeout is time for which products are reserved
+// when order moves to processing. After that not paid order is cancelled.
+func NewBasketService(basketStorage IBasketStorage, reservationTimeout time.Duration) (*BasketService, error) {
 	logger, err := mylogger.Get()
 	if err != nil {
 		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	return &BasketService{storage: basketStorage, logger: logger}, nil
+	return &BasketService{storage: basketStorage, reservationTimeout: reservationTimeout, logger: logger}, nil
 }
 
 func (b BasketService) AddOrder(ctx context.Context, r io.Reader, userID uint64) (*models.OrderInBasket, error) {
@@ -112,16 +129,23 @@ func (b BasketService) UpdateOrderStatus(ctx context.Context, r io.Reader, userI
 		return fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	err = b.storage.UpdateOrderStatus(ctx, userID, orderChanges.ID, orderChanges.Status)
+	order, err := b.storage.GetOrderParticipants(ctx, orderChanges.ID)
 	if err != nil {
 		return fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	return nil
-}
+	actorRole, err := CheckOrderTransition(order, userID, orderChanges.Status)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
 
-func (b BasketService) BuyFullBasket(ctx context.Context, userID uint64) error {
-	err := b.storage.BuyFullBasket(ctx, userID)
+	err = b.storage.UpdateOrderStatus(ctx, &models.PreOrderStatusHistory{
+		OrderID:    order.OrderID,
+		ActorID:    userID,
+		ActorRole:  actorRole,
+		FromStatus: order.Status,
+		ToStatus:   orderChanges.Status,
+	}, time.Now().Add(b.reservationTimeout))
 	if err != nil {
 		return fmt.Errorf(myerrors.ErrTemplate, err)
 	}
@@ -129,6 +153,57 @@ func (b BasketService) BuyFullBasket(ctx context.Context, userID uint64) error {
 	return nil
 }
 
+// GetOrderStatusHistory returns transitions of order status from the oldest.
+// History is available only for buyer and seller of order.
+func (b BasketService) GetOrderStatusHistory(ctx context.Context,
+	orderID uint64, userID uint64,
+) ([]*models.OrderStatusHistory, error) {
+	order, err := b.storage.GetOrderParticipants(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if len(OrderActorRoles(order, userID)) == 0 {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, ErrNotOrderParticipant)
+	}
+
+	history, err := b.storage.GetOrderStatusHistory(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return history, nil
+}
+
+// BuyFullBasket reserves products of basket and creates payment for all its orders.
+// Orders become paid only after confirmation of payment.
+func (b BasketService) BuyFullBasket(ctx context.Context, userID uint64) (*models.BasketPayment, error) {
+	idempotencyKey, err := GenerateIdempotencyKey()
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	basketPayment, err := b.storage.BuyFullBasket(ctx, userID, time.Now().Add(b.reservationTimeout), idempotencyKey)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return basketPayment, nil
+}
+
+// CancelExpiredReservations cancels not paid orders with expired reservation
+// and returns count of cancelled orders.
+func (b BasketService) CancelExpiredReservations(ctx context.Context) (uint64, error) {
+	now := time.Now()
+
+	countCancelled, err := b.storage.CancelExpiredReservations(ctx, now, now.Add(-GracePendingPayment))
+	if err != nil {
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return countCancelled, nil
+}
+
 func (b BasketService) DeleteOrder(ctx context.Context, orderID uint64, ownerID uint64) error {
 	err := b.storage.DeleteOrder(ctx, orderID, ownerID)
 	if err != nil {
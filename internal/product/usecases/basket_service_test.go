@@ -6,6 +6,7 @@ import (
 	"io"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/mocks"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/usecases"
@@ -26,7 +27,7 @@ func NewBasketService(ctrl *gomock.Controller,
 
 	behaviorBasketStorage(mockBasketService)
 
-	basketService, err := usecases.NewBasketService(mockBasketService)
+	basketService, err := usecases.NewBasketService(mockBasketService, time.Minute)
 	if err != nil {
 		return nil, fmt.Errorf("unexpected err=%w", err)
 	}
@@ -400,17 +401,19 @@ func TestUpdateOrderCount(t *testing.T) { //nolint:dupl
 	}
 }
 
-func TestUpdateOrderStatus(t *testing.T) { //nolint:dupl
+func TestUpdateOrderStatus(t *testing.T) {
 	t.Parallel()
 
 	_ = mylogger.NewNop()
 
 	baseCtx := context.Background()
 	testInternalErr := myerrors.NewErrorInternal("Test error")
+	testSalerID := uint64(2)
 
 	type TestCase struct {
 		name                  string
 		inputReader           io.Reader
+		userID                uint64
 		behaviorBasketStorage func(m *mocks.MockIBasketStorage)
 		expectedError         error
 	}
@@ -421,15 +424,72 @@ func TestUpdateOrderStatus(t *testing.T) { //nolint:dupl
 			inputReader: strings.NewReader(
 				`{"id": 1, 
 					"status": 1 }`),
+			userID: test.UserID,
 			behaviorBasketStorage: func(m *mocks.MockIBasketStorage) {
-				m.EXPECT().UpdateOrderStatus(baseCtx, test.UserID, test.ProductID, uint8(1)).Return(nil)
+				m.EXPECT().GetOrderParticipants(baseCtx, uint64(1)).Return(&models.OrderParticipants{
+					OrderID: 1, OwnerID: test.UserID, SalerID: testSalerID, Status: models.OrderStatusInBasket,
+				}, nil)
+				m.EXPECT().UpdateOrderStatus(baseCtx, &models.PreOrderStatusHistory{
+					OrderID:    1,
+					ActorID:    test.UserID,
+					ActorRole:  models.OrderActorBuyer,
+					FromStatus: models.OrderStatusInBasket,
+					ToStatus:   models.OrderStatusInProcessing,
+				}, gomock.Any()).Return(nil)
 			},
 			expectedError: nil,
 		},
+		{
+			name: "test seller ships order",
+			inputReader: strings.NewReader(
+				`{"id": 1, 
+					"status": 4 }`),
+			userID: testSalerID,
+			behaviorBasketStorage: func(m *mocks.MockIBasketStorage) {
+				m.EXPECT().GetOrderParticipants(baseCtx, uint64(1)).Return(&models.OrderParticipants{
+					OrderID: 1, OwnerID: test.UserID, SalerID: testSalerID, Status: models.OrderStatusPaid,
+				}, nil)
+				m.EXPECT().UpdateOrderStatus(baseCtx, &models.PreOrderStatusHistory{
+					OrderID:    1,
+					ActorID:    testSalerID,
+					ActorRole:  models.OrderActorSeller,
+					FromStatus: models.OrderStatusPaid,
+					ToStatus:   models.OrderStatusShipped,
+				}, gomock.Any()).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "test buyer can't ship order",
+			inputReader: strings.NewReader(
+				`{"id": 1, 
+					"status": 4 }`),
+			userID: test.UserID,
+			behaviorBasketStorage: func(m *mocks.MockIBasketStorage) {
+				m.EXPECT().GetOrderParticipants(baseCtx, uint64(1)).Return(&models.OrderParticipants{
+					OrderID: 1, OwnerID: test.UserID, SalerID: testSalerID, Status: models.OrderStatusPaid,
+				}, nil)
+			},
+			expectedError: usecases.ErrOrderTransitionNotAllowed,
+		},
+		{
+			name: "test not participant",
+			inputReader: strings.NewReader(
+				`{"id": 1, 
+					"status": 1 }`),
+			userID: 3,
+			behaviorBasketStorage: func(m *mocks.MockIBasketStorage) {
+				m.EXPECT().GetOrderParticipants(baseCtx, uint64(1)).Return(&models.OrderParticipants{
+					OrderID: 1, OwnerID: test.UserID, SalerID: testSalerID, Status: models.OrderStatusInBasket,
+				}, nil)
+			},
+			expectedError: usecases.ErrNotOrderParticipant,
+		},
 		{
 			name: "test validation error",
 			inputReader: strings.NewReader(
 				`{"id": 1}`),
+			userID:                test.UserID,
 			behaviorBasketStorage: func(m *mocks.MockIBasketStorage) {},
 			expectedError:         usecases.ErrValidateOrderChangesStatus,
 		},
@@ -438,8 +498,9 @@ func TestUpdateOrderStatus(t *testing.T) { //nolint:dupl
 			inputReader: strings.NewReader(
 				`{"id": 1, 
 					"status": 1 }`),
+			userID: test.UserID,
 			behaviorBasketStorage: func(m *mocks.MockIBasketStorage) {
-				m.EXPECT().UpdateOrderStatus(baseCtx, test.UserID, test.ProductID, uint8(1)).Return(testInternalErr)
+				m.EXPECT().GetOrderParticipants(baseCtx, uint64(1)).Return(nil, testInternalErr)
 			},
 			expectedError: testInternalErr,
 		},
@@ -459,7 +520,7 @@ func TestUpdateOrderStatus(t *testing.T) { //nolint:dupl
 				t.Fatalf("Failed create productService %+v", err)
 			}
 
-			err = productService.UpdateOrderStatus(baseCtx, testCase.inputReader, test.UserID)
+			err = productService.UpdateOrderStatus(baseCtx, testCase.inputReader, testCase.userID)
 			if errInner := utils.EqualError(err, testCase.expectedError); errInner != nil {
 				t.Fatalf("Failed EqualError: %+v", errInner)
 			}
@@ -467,6 +528,87 @@ func TestUpdateOrderStatus(t *testing.T) { //nolint:dupl
 	}
 }
 
+func TestGetOrderStatusHistory(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	baseCtx := context.Background()
+	testInternalErr := myerrors.NewErrorInternal("Test error")
+	testOrderParticipants := &models.OrderParticipants{
+		OrderID: 1, OwnerID: test.UserID, SalerID: 2, Status: models.OrderStatusInProcessing,
+	}
+	testHistory := []*models.OrderStatusHistory{{ //nolint:exhaustruct
+		ID: 1, OrderID: 1, ActorID: test.UserID, ActorRole: models.OrderActorBuyer,
+		FromStatus: models.OrderStatusInBasket, ToStatus: models.OrderStatusInProcessing,
+	}}
+
+	type TestCase struct {
+		name                  string
+		userID                uint64
+		behaviorBasketStorage func(m *mocks.MockIBasketStorage)
+		expectedHistory       []*models.OrderStatusHistory
+		expectedError         error
+	}
+
+	testCases := [...]TestCase{
+		{
+			name:   "test basic work",
+			userID: test.UserID,
+			behaviorBasketStorage: func(m *mocks.MockIBasketStorage) {
+				m.EXPECT().GetOrderParticipants(baseCtx, uint64(1)).Return(testOrderParticipants, nil)
+				m.EXPECT().GetOrderStatusHistory(baseCtx, uint64(1)).Return(testHistory, nil)
+			},
+			expectedHistory: testHistory,
+			expectedError:   nil,
+		},
+		{
+			name:   "test not participant",
+			userID: 3,
+			behaviorBasketStorage: func(m *mocks.MockIBasketStorage) {
+				m.EXPECT().GetOrderParticipants(baseCtx, uint64(1)).Return(testOrderParticipants, nil)
+			},
+			expectedHistory: nil,
+			expectedError:   usecases.ErrNotOrderParticipant,
+		},
+		{
+			name:   "test internal error",
+			userID: test.UserID,
+			behaviorBasketStorage: func(m *mocks.MockIBasketStorage) {
+				m.EXPECT().GetOrderParticipants(baseCtx, uint64(1)).Return(testOrderParticipants, nil)
+				m.EXPECT().GetOrderStatusHistory(baseCtx, uint64(1)).Return(nil, testInternalErr)
+			},
+			expectedHistory: nil,
+			expectedError:   testInternalErr,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			productService, err := NewBasketService(ctrl, testCase.behaviorBasketStorage)
+			if err != nil {
+				t.Fatalf("Failed create productService %+v", err)
+			}
+
+			history, err := productService.GetOrderStatusHistory(baseCtx, 1, testCase.userID)
+			if errInner := utils.EqualError(err, testCase.expectedError); errInner != nil {
+				t.Fatalf("Failed EqualError: %+v", errInner)
+			}
+
+			if err = utils.EqualTest(history, testCase.expectedHistory); err != nil {
+				t.Fatalf("Failed EqualTest: %+v", err)
+			}
+		})
+	}
+}
+
 func TestBuyFullBasket(t *testing.T) {
 	t.Parallel()
 
@@ -474,10 +616,14 @@ func TestBuyFullBasket(t *testing.T) {
 
 	baseCtx := context.Background()
 	testInternalErr := myerrors.NewErrorInternal("Test error")
+	testPayment := &models.BasketPayment{
+		ID: 1, OwnerID: test.UserID, YookassaID: "", IdempotencyKey: "key", Amount: 1500, Capture: true, Status: 1,
+	}
 
 	type testCase struct {
 		name                  string
 		behaviorBasketStorage func(m *mocks.MockIBasketStorage)
+		expectedPayment       *models.BasketPayment
 		expectedError         error
 	}
 
@@ -485,16 +631,25 @@ func TestBuyFullBasket(t *testing.T) {
 		{
 			name: "test basic work",
 			behaviorBasketStorage: func(m *mocks.MockIBasketStorage) {
-				m.EXPECT().BuyFullBasket(baseCtx, test.UserID).Return(nil)
+				m.EXPECT().BuyFullBasket(baseCtx, test.UserID, gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ uint64, _ time.Time, idempotencyKey string) (*models.BasketPayment, error) {
+						if len(idempotencyKey) != 48 {
+							return nil, fmt.Errorf("wrong idempotency key %q", idempotencyKey) //nolint:goerr113
+						}
+
+						return testPayment, nil
+					})
 			},
-			expectedError: nil,
+			expectedPayment: testPayment,
+			expectedError:   nil,
 		},
 		{
 			name: "test internal error",
 			behaviorBasketStorage: func(m *mocks.MockIBasketStorage) {
-				m.EXPECT().BuyFullBasket(baseCtx, test.UserID).Return(testInternalErr)
+				m.EXPECT().BuyFullBasket(baseCtx, test.UserID, gomock.Any(), gomock.Any()).Return(nil, testInternalErr)
 			},
-			expectedError: testInternalErr,
+			expectedPayment: nil,
+			expectedError:   testInternalErr,
 		},
 	}
 
@@ -512,14 +667,36 @@ func TestBuyFullBasket(t *testing.T) {
 				t.Fatalf("Failed create productService %+v", err)
 			}
 
-			err = productService.BuyFullBasket(baseCtx, test.UserID)
+			basketPayment, err := productService.BuyFullBasket(baseCtx, test.UserID)
 			if errInner := utils.EqualError(err, testCase.expectedError); errInner != nil {
 				t.Fatalf("Failed EqualError: %+v", errInner)
 			}
+
+			if err = utils.EqualTest(basketPayment, testCase.expectedPayment); err != nil {
+				t.Fatalf("Failed EqualTest: %+v", err)
+			}
 		})
 	}
 }
 
+func TestGenerateIdempotencyKey(t *testing.T) {
+	t.Parallel()
+
+	firstKey, err := usecases.GenerateIdempotencyKey()
+	if err != nil {
+		t.Fatalf("unexpected err=%+v", err)
+	}
+
+	secondKey, err := usecases.GenerateIdempotencyKey()
+	if err != nil {
+		t.Fatalf("unexpected err=%+v", err)
+	}
+
+	if len(firstKey) != 48 || firstKey == secondKey {
+		t.Fatalf("wrong idempotency keys %q %q", firstKey, secondKey)
+	}
+}
+
 func TestDeleteOrder(t *testing.T) { //nolint:dupl
 	t.Parallel()
 
@@ -575,3 +752,64 @@ func TestDeleteOrder(t *testing.T) { //nolint:dupl
 		})
 	}
 }
+
+func TestCancelExpiredReservations(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	baseCtx := context.Background()
+	testInternalErr := myerrors.NewErrorInternal("Test error")
+
+	type TestCase struct {
+		name                  string
+		behaviorBasketStorage func(m *mocks.MockIBasketStorage)
+		expectedCount         uint64
+		expectedError         error
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			behaviorBasketStorage: func(m *mocks.MockIBasketStorage) {
+				m.EXPECT().CancelExpiredReservations(baseCtx, gomock.Any(), gomock.Any()).Return(uint64(2), nil)
+			},
+			expectedCount: 2,
+			expectedError: nil,
+		},
+		{
+			name: "test internal error",
+			behaviorBasketStorage: func(m *mocks.MockIBasketStorage) {
+				m.EXPECT().CancelExpiredReservations(baseCtx, gomock.Any(), gomock.Any()).
+					Return(uint64(0), testInternalErr)
+			},
+			expectedCount: 0,
+			expectedError: testInternalErr,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			productService, err := NewBasketService(ctrl, testCase.behaviorBasketStorage)
+			if err != nil {
+				t.Fatalf("Failed create productService %+v", err)
+			}
+
+			countCancelled, err := productService.CancelExpiredReservations(baseCtx)
+			if errInner := utils.EqualError(err, testCase.expectedError); errInner != nil {
+				t.Fatalf("Failed EqualError: %+v", errInner)
+			}
+
+			if err = utils.EqualTest(countCancelled, testCase.expectedCount); err != nil {
+				t.Fatalf("Failed EqualTest: %+v", err)
+			}
+		})
+	}
+}
@@ -15,8 +15,8 @@ import (
 var _ ICommentStorage = (*productrepo.ProductStorage)(nil)
 
 type ICommentStorage interface {
-	GetCommentList(ctx context.Context, offset uint64, count uint64, recipientID uint64,
-		senderID uint64) ([]*models.CommentInFeed, error)
+	GetCommentList(ctx context.Context, cursor *models.Cursor, count uint64, recipientID uint64,
+		senderID uint64) ([]*models.CommentInFeed, *models.Cursor, error)
 	AddComment(ctx context.Context, preComment *models.PreComment) (uint64, error)
 	DeleteComment(ctx context.Context, commentID uint64, senderID uint64) error
 	UpdateComment(ctx context.Context, userID uint64, commentID uint64, updateFields map[string]interface{}) error
@@ -36,10 +36,10 @@ func NewCommentService(commentStorage ICommentStorage) (*CommentService, error)
 	return &CommentService{storage: commentStorage, logger: logger}, nil
 }
 
-func (c CommentService) GetCommentList(ctx context.Context, offset uint64, count uint64,
+func (c CommentService) GetCommentList(ctx context.Context, cursor *models.Cursor, count uint64,
 	recipientID uint64, senderID uint64,
-) ([]*models.CommentInFeed, error) {
-	comments, err := c.storage.GetCommentList(ctx, offset, count, recipientID, senderID)
+) (*models.CommentList, error) {
+	comments, next, err := c.storage.GetCommentList(ctx, cursor, count, recipientID, senderID)
 	if err != nil {
 		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
@@ -48,7 +48,7 @@ func (c CommentService) GetCommentList(ctx context.Context, offset uint64, count
 		product.Sanitize()
 	}
 
-	return comments, nil
+	return &models.CommentList{Comments: comments, NextCursor: next.String()}, nil
 }
 
 func (c CommentService) AddComment(ctx context.Context, r io.Reader, userID uint64) (uint64, error) {
@@ -265,7 +265,7 @@ func TestGetCommentList(t *testing.T) {
 	type TestCase struct {
 		name                   string
 		behaviorCommentStorage func(m *mocks.MockICommentStorage)
-		expectedCommentInFeed  []*models.CommentInFeed
+		expectedCommentList    *models.CommentList
 		expectedError          error
 		senderID               uint64
 	}
@@ -274,19 +274,22 @@ func TestGetCommentList(t *testing.T) {
 		{
 			name: "test basic work",
 			behaviorCommentStorage: func(m *mocks.MockICommentStorage) {
-				m.EXPECT().GetCommentList(baseCtx, uint64(1), uint64(2), test.UserID, uint64(2)).Return(
+				m.EXPECT().GetCommentList(baseCtx, models.NewCursor(1, 1), uint64(2), test.UserID, uint64(2)).Return(
 					[]*models.CommentInFeed{
 						{
 							ID: test.CommentID, SenderName: "Ivan", Avatar: sql.NullString{Valid: false, String: ""},
 							Text: "good", Rating: 5, CreatedAt: time.Time{},
 						},
-					}, nil)
+					}, models.NewCursor(0, test.CommentID), nil)
 			},
-			expectedCommentInFeed: []*models.CommentInFeed{
-				{
-					ID: test.CommentID, SenderName: "Ivan", Avatar: sql.NullString{Valid: false, String: ""},
-					Text: "good", Rating: 5, CreatedAt: time.Time{},
+			expectedCommentList: &models.CommentList{
+				Comments: []*models.CommentInFeed{
+					{
+						ID: test.CommentID, SenderName: "Ivan", Avatar: sql.NullString{Valid: false, String: ""},
+						Text: "good", Rating: 5, CreatedAt: time.Time{},
+					},
 				},
+				NextCursor: models.NewCursor(0, test.CommentID).String(),
 			},
 			expectedError: nil,
 			senderID:      uint64(2),
@@ -294,12 +297,12 @@ func TestGetCommentList(t *testing.T) {
 		{
 			name: "test internal error",
 			behaviorCommentStorage: func(m *mocks.MockICommentStorage) {
-				m.EXPECT().GetCommentList(baseCtx, uint64(1), uint64(2), test.UserID, uint64(2)).Return(
-					nil, testInternalErr)
+				m.EXPECT().GetCommentList(baseCtx, models.NewCursor(1, 1), uint64(2), test.UserID, uint64(2)).Return(
+					nil, nil, testInternalErr)
 			},
-			expectedCommentInFeed: nil,
-			expectedError:         testInternalErr,
-			senderID:              uint64(2),
+			expectedCommentList: nil,
+			expectedError:       testInternalErr,
+			senderID:            uint64(2),
 		},
 	}
 
@@ -317,13 +320,13 @@ func TestGetCommentList(t *testing.T) {
 				t.Fatalf("Failed create productService %+v", err)
 			}
 
-			ordersInBasket, err := productService.GetCommentList(baseCtx, uint64(1), test.CountComment,
+			commentList, err := productService.GetCommentList(baseCtx, models.NewCursor(1, 1), test.CountComment,
 				test.UserID, testCase.senderID)
 			if errInner := utils.EqualError(err, testCase.expectedError); errInner != nil {
 				t.Fatalf("Failed EqualError: %+v", errInner)
 			}
 
-			if err := utils.EqualTest(ordersInBasket, testCase.expectedCommentInFeed); err != nil {
+			if err := utils.EqualTest(commentList, testCase.expectedCommentList); err != nil {
 				t.Fatalf("Failed EqualTest %+v", err)
 			}
 		})
@@ -0,0 +1,69 @@
+package usecases
+
+import (
+	"fmt"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+)
+
+var (
+	ErrNotOrderParticipant = myerrors.NewErrorBadContentRequest(
+		"Вы не являетесь покупателем или продавцом этого заказа")
+	ErrOrderTransitionNotAllowed = myerrors.NewErrorBadContentRequest(
+		"Нельзя перевести заказ в этот статус")
+)
+
+type orderTransition struct {
+	from uint8
+	to   uint8
+}
+
+// orderTransitions is state machine of order: allowed transitions of status
+// and actors which may trigger them. Statuses Closed, Cancelled and Refunded are final.
+// Nobody may set Paid, it's set only by confirmed payment of basket.
+var orderTransitions = map[orderTransition][]string{ //nolint:gochecknoglobals
+	{from: models.OrderStatusInBasket, to: models.OrderStatusInProcessing}:  {models.OrderActorBuyer},
+	{from: models.OrderStatusInProcessing, to: models.OrderStatusCancelled}: {models.OrderActorBuyer, models.OrderActorSeller},
+	{from: models.OrderStatusPaid, to: models.OrderStatusShipped}:           {models.OrderActorSeller},
+	{from: models.OrderStatusPaid, to: models.OrderStatusRefunded}:          {models.OrderActorSeller},
+	{from: models.OrderStatusShipped, to: models.OrderStatusDelivered}:      {models.OrderActorBuyer},
+	{from: models.OrderStatusDelivered, to: models.OrderStatusClosed}:       {models.OrderActorBuyer},
+	{from: models.OrderStatusDelivered, to: models.OrderStatusRefunded}:     {models.OrderActorSeller},
+}
+
+// OrderActorRoles returns roles of user in order. Slice is empty if user isn't participant of order.
+func OrderActorRoles(order *models.OrderParticipants, userID uint64) []string {
+	var roles []string
+
+	if order.OwnerID == userID {
+		roles = append(roles, models.OrderActorBuyer)
+	}
+
+	if order.SalerID == userID {
+		roles = append(roles, models.OrderActorSeller)
+	}
+
+	return roles
+}
+
+// CheckOrderTransition checks that user may move order to newStatus
+// and returns role in which user does it.
+func CheckOrderTransition(order *models.OrderParticipants, userID uint64, newStatus uint8) (string, error) {
+	userRoles := OrderActorRoles(order, userID)
+	if len(userRoles) == 0 {
+		return "", fmt.Errorf(myerrors.ErrTemplate, ErrNotOrderParticipant)
+	}
+
+	allowedRoles := orderTransitions[orderTransition{from: order.Status, to: newStatus}]
+
+	for _, userRole := range userRoles {
+		for _, allowedRole := range allowedRoles {
+			if userRole == allowedRole {
+				return userRole, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf(myerrors.ErrTemplate, ErrOrderTransitionNotAllowed)
+}
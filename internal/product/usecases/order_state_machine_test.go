@@ -0,0 +1,112 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/usecases"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+)
+
+func TestCheckOrderTransition(t *testing.T) {
+	t.Parallel()
+
+	const (
+		buyerID    uint64 = 1
+		salerID    uint64 = 2
+		strangerID uint64 = 3
+	)
+
+	type TestCase struct {
+		name          string
+		status        uint8
+		userID        uint64
+		newStatus     uint8
+		expectedRole  string
+		expectedError error
+	}
+
+	testCases := [...]TestCase{
+		{
+			name:   "test buyer buys order",
+			status: models.OrderStatusInBasket, userID: buyerID, newStatus: models.OrderStatusInProcessing,
+			expectedRole: models.OrderActorBuyer, expectedError: nil,
+		},
+		{
+			name:   "test seller cancels order",
+			status: models.OrderStatusInProcessing, userID: salerID, newStatus: models.OrderStatusCancelled,
+			expectedRole: models.OrderActorSeller, expectedError: nil,
+		},
+		{
+			name:   "test buyer cancels order",
+			status: models.OrderStatusInProcessing, userID: buyerID, newStatus: models.OrderStatusCancelled,
+			expectedRole: models.OrderActorBuyer, expectedError: nil,
+		},
+		{
+			name:   "test seller ships order",
+			status: models.OrderStatusPaid, userID: salerID, newStatus: models.OrderStatusShipped,
+			expectedRole: models.OrderActorSeller, expectedError: nil,
+		},
+		{
+			name:   "test buyer confirms delivery",
+			status: models.OrderStatusShipped, userID: buyerID, newStatus: models.OrderStatusDelivered,
+			expectedRole: models.OrderActorBuyer, expectedError: nil,
+		},
+		{
+			name:   "test seller refunds delivered order",
+			status: models.OrderStatusDelivered, userID: salerID, newStatus: models.OrderStatusRefunded,
+			expectedRole: models.OrderActorSeller, expectedError: nil,
+		},
+		{
+			name:   "test buyer can't refund order",
+			status: models.OrderStatusPaid, userID: buyerID, newStatus: models.OrderStatusRefunded,
+			expectedRole: "", expectedError: usecases.ErrOrderTransitionNotAllowed,
+		},
+		{
+			name:   "test buyer can't mark order paid without payment",
+			status: models.OrderStatusInProcessing, userID: buyerID, newStatus: models.OrderStatusPaid,
+			expectedRole: "", expectedError: usecases.ErrOrderTransitionNotAllowed,
+		},
+		{
+			name:   "test seller can't mark order paid",
+			status: models.OrderStatusInProcessing, userID: salerID, newStatus: models.OrderStatusPaid,
+			expectedRole: "", expectedError: usecases.ErrOrderTransitionNotAllowed,
+		},
+		{
+			name:   "test closed order is final",
+			status: models.OrderStatusClosed, userID: salerID, newStatus: models.OrderStatusRefunded,
+			expectedRole: "", expectedError: usecases.ErrOrderTransitionNotAllowed,
+		},
+		{
+			name:   "test order can't skip shipping",
+			status: models.OrderStatusPaid, userID: buyerID, newStatus: models.OrderStatusDelivered,
+			expectedRole: "", expectedError: usecases.ErrOrderTransitionNotAllowed,
+		},
+		{
+			name:   "test stranger can't change order",
+			status: models.OrderStatusInProcessing, userID: strangerID, newStatus: models.OrderStatusCancelled,
+			expectedRole: "", expectedError: usecases.ErrNotOrderParticipant,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			order := &models.OrderParticipants{
+				OrderID: 1, OwnerID: buyerID, SalerID: salerID, Status: testCase.status,
+			}
+
+			role, err := usecases.CheckOrderTransition(order, testCase.userID, testCase.newStatus)
+			if errInner := utils.EqualError(err, testCase.expectedError); errInner != nil {
+				t.Fatalf("Failed EqualError: %+v", errInner)
+			}
+
+			if err = utils.EqualTest(role, testCase.expectedRole); err != nil {
+				t.Fatalf("Failed EqualTest: %+v", err)
+			}
+		})
+	}
+}
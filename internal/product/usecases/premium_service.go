@@ -6,6 +6,7 @@ import (
 	"time"
 
 	productrepo "github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/repository"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
 )
@@ -22,15 +23,23 @@ const (
 	DaysInWeek      = 7
 	MonthInSeason   = 3
 	MonthInHalfYear = 6
+
+	// TTLPremiumIdempotencyKey is period while yookassa returns the same payment for repeated key
+	TTLPremiumIdempotencyKey = 24 * time.Hour
 )
 
 var _ IPremiumStorage = (*productrepo.ProductStorage)(nil)
 
 type IPremiumStorage interface {
 	AddPremium(ctx context.Context, productID uint64, userID uint64,
-		premiumBegin time.Time, premiumExpire time.Time) error
+		premiumBegin time.Time, premiumExpire time.Time, event *models.PaymentEvent) error
 	CheckPremiumStatus(ctx context.Context, productID uint64, userID uint64) (uint8, error)
-	UpdateStatusPremium(ctx context.Context, status uint8, productID uint64, userID uint64) error
+	UpdateStatusPremium(ctx context.Context, status uint8, productID uint64, userID uint64,
+		event *models.PaymentEvent) error
+	GetOrAddPremiumIdempotencyKey(ctx context.Context, userID uint64, productID uint64,
+		periodCode uint64, newKey string, expiredBefore time.Time) (string, error)
+	DeletePremiumIdempotencyKey(ctx context.Context, userID uint64, productID uint64, periodCode uint64) error
+	DeleteExpiredPremiumIdempotencyKeys(ctx context.Context, expiredBefore time.Time) (uint64, error)
 }
 
 type PremiumService struct {
@@ -38,8 +47,10 @@ type PremiumService struct {
 	logger  *mylogger.MyLogger
 }
 
-func (p PremiumService) UpdateStatusPremium(ctx context.Context, status uint8, productID uint64, userID uint64) error {
-	err := p.storage.UpdateStatusPremium(ctx, status, productID, userID)
+func (p PremiumService) UpdateStatusPremium(ctx context.Context, status uint8, productID uint64, userID uint64,
+	event *models.PaymentEvent,
+) error {
+	err := p.storage.UpdateStatusPremium(ctx, status, productID, userID, event)
 	if err != nil {
 		return fmt.Errorf(myerrors.ErrTemplate, err)
 	}
@@ -65,8 +76,10 @@ func NewPremiumService(premiumStorage IPremiumStorage) (*PremiumService, error)
 	return &PremiumService{storage: premiumStorage, logger: logger}, nil
 }
 
+// AddPremium event is status of payment from yookassa, it's saved together with premium,
+// so repeated event returns productrepo.ErrPaymentEventHandled.
 func (p PremiumService) AddPremium(ctx context.Context, productID uint64,
-	userID uint64, periodCode uint64,
+	userID uint64, periodCode uint64, event *models.PaymentEvent,
 ) error {
 	var premiumExpire time.Time
 
@@ -90,7 +103,7 @@ func (p PremiumService) AddPremium(ctx context.Context, productID uint64,
 		return fmt.Errorf(myerrors.ErrTemplate, ErrPremiumCode)
 	}
 
-	err := p.storage.AddPremium(ctx, productID, userID, premiumBegin, premiumExpire)
+	err := p.storage.AddPremium(ctx, productID, userID, premiumBegin, premiumExpire, event)
 	if err != nil {
 		logger.Error(err)
 
@@ -99,3 +112,44 @@ func (p PremiumService) AddPremium(ctx context.Context, productID uint64,
 
 	return nil
 }
+
+// GetPremiumIdempotencyKey returns the same key for repeated requests of premium with the same
+// metadata during TTLPremiumIdempotencyKey, even after restart of service.
+func (p PremiumService) GetPremiumIdempotencyKey(ctx context.Context,
+	userID uint64, productID uint64, periodCode uint64,
+) (string, error) {
+	newKey, err := GenerateIdempotencyKey()
+	if err != nil {
+		return "", fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	key, err := p.storage.GetOrAddPremiumIdempotencyKey(ctx, userID, productID, periodCode,
+		newKey, time.Now().Add(-TTLPremiumIdempotencyKey))
+	if err != nil {
+		return "", fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return key, nil
+}
+
+func (p PremiumService) DeletePremiumIdempotencyKey(ctx context.Context,
+	userID uint64, productID uint64, periodCode uint64,
+) error {
+	err := p.storage.DeletePremiumIdempotencyKey(ctx, userID, productID, periodCode)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// DeleteExpiredPremiumIdempotencyKeys returns count of deleted keys.
+func (p PremiumService) DeleteExpiredPremiumIdempotencyKeys(ctx context.Context) (uint64, error) {
+	countDeleted, err := p.storage.DeleteExpiredPremiumIdempotencyKeys(ctx,
+		time.Now().Add(-TTLPremiumIdempotencyKey))
+	if err != nil {
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return countDeleted, nil
+}
@@ -0,0 +1,93 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/mocks"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/usecases"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils/test"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetPremiumIdempotencyKey(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	baseCtx := context.Background()
+	testInternalErr := myerrors.NewErrorInternal("Test error")
+
+	type TestCase struct {
+		name                   string
+		behaviorPremiumStorage func(m *mocks.MockIPremiumStorage)
+		expectedKey            string
+		expectedError          error
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test saved key is returned",
+			behaviorPremiumStorage: func(m *mocks.MockIPremiumStorage) {
+				m.EXPECT().GetOrAddPremiumIdempotencyKey(baseCtx, test.UserID, test.ProductID, usecases.Week,
+					gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ uint64, _ uint64, _ uint64,
+						newKey string, expiredBefore time.Time,
+					) (string, error) {
+						if len(newKey) != 48 {
+							t.Errorf("wrong new key %q", newKey)
+						}
+
+						if time.Since(expiredBefore) < usecases.TTLPremiumIdempotencyKey {
+							t.Errorf("wrong expiredBefore %v", expiredBefore)
+						}
+
+						return "saved_key", nil
+					})
+			},
+			expectedKey:   "saved_key",
+			expectedError: nil,
+		},
+		{
+			name: "test internal error",
+			behaviorPremiumStorage: func(m *mocks.MockIPremiumStorage) {
+				m.EXPECT().GetOrAddPremiumIdempotencyKey(baseCtx, test.UserID, test.ProductID, usecases.Week,
+					gomock.Any(), gomock.Any()).Return("", testInternalErr)
+			},
+			expectedKey:   "",
+			expectedError: testInternalErr,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPremiumStorage := mocks.NewMockIPremiumStorage(ctrl)
+			testCase.behaviorPremiumStorage(mockPremiumStorage)
+
+			premiumService, err := usecases.NewPremiumService(mockPremiumStorage)
+			if err != nil {
+				t.Fatalf("unexpected err=%+v", err)
+			}
+
+			key, err := premiumService.GetPremiumIdempotencyKey(baseCtx, test.UserID, test.ProductID, usecases.Week)
+			if errInner := utils.EqualError(err, testCase.expectedError); errInner != nil {
+				t.Fatalf("Failed EqualError: %+v", errInner)
+			}
+
+			if key != testCase.expectedKey {
+				t.Fatalf("key: got %q, expected %q", key, testCase.expectedKey)
+			}
+		})
+	}
+}
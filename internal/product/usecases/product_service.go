@@ -27,22 +27,25 @@ var _ IProductStorage = (*productrepo.ProductStorage)(nil)
 type IProductStorage interface { //nolint:interfacebloat
 	AddProduct(ctx context.Context, preProduct *models.PreProduct) (uint64, error)
 	GetProduct(ctx context.Context, productID uint64, userID uint64) (*models.Product, error)
-	GetPopularProducts(ctx context.Context, offset uint64, count uint64,
-		userID uint64) ([]*models.ProductInFeed, error)
-	GetProductsOfSaler(ctx context.Context, lastProductID uint64,
-		count uint64, userID uint64, isMy bool) ([]*models.ProductInFeed, error)
+	GetPopularProducts(ctx context.Context, cursor *models.Cursor, count uint64,
+		userID uint64, filter *models.ProductFilter) ([]*models.ProductInFeed, *models.Cursor, error)
+	GetProductsOfSaler(ctx context.Context, cursor *models.Cursor,
+		count uint64, userID uint64, isMy bool) ([]*models.ProductInFeed, *models.Cursor, error)
 	UpdateProduct(ctx context.Context, productID uint64, updateFields map[string]interface{}) error
 	CloseProduct(ctx context.Context, productID uint64, userID uint64) error
 	ActivateProduct(ctx context.Context, productID uint64, userID uint64) error
 	DeleteProduct(ctx context.Context, productID uint64, userID uint64) error
 	SearchProduct(ctx context.Context, searchInput string) ([]string, error)
 	GetSearchProductFeed(ctx context.Context,
-		searchInput string, lastNumber uint64, limit uint64, userID uint64,
-	) ([]*models.ProductInFeed, error)
+		searchInput string, cursor *models.Cursor, limit uint64, userID uint64, filter *models.ProductFilter,
+	) ([]*models.ProductInFeed, *models.Cursor, error)
+	GetProductFacets(ctx context.Context, searchInput string,
+		filter *models.ProductFilter) (*models.ProductFacets, error)
 	IBasketStorage
 	IFavouriteStorage
 	IPremiumStorage
 	ICommentStorage
+	ISavedSearchStorage
 }
 
 type ProductService struct {
@@ -50,6 +53,7 @@ type ProductService struct {
 	BasketService
 	PremiumService
 	CommentService
+	SavedSearchService
 	fileServiceClient fileservice.FileServiceClient
 	storage           IProductStorage
 	logger            *mylogger.MyLogger
@@ -57,7 +61,7 @@ type ProductService struct {
 
 func NewProductService(productStorage IProductStorage, basketService *BasketService,
 	favouriteService *FavouriteService, premiumService *PremiumService, commentService *CommentService,
-	fileServiceClient fileservice.FileServiceClient,
+	savedSearchService *SavedSearchService, fileServiceClient fileservice.FileServiceClient,
 ) (*ProductService, error) {
 	logger, err := mylogger.Get()
 	if err != nil {
@@ -65,13 +69,14 @@ func NewProductService(productStorage IProductStorage, basketService *BasketServ
 	}
 
 	return &ProductService{
-		FavouriteService:  *favouriteService,
-		BasketService:     *basketService,
-		PremiumService:    *premiumService,
-		CommentService:    *commentService,
-		fileServiceClient: fileServiceClient,
-		storage:           productStorage,
-		logger:            logger,
+		FavouriteService:   *favouriteService,
+		BasketService:      *basketService,
+		PremiumService:     *premiumService,
+		CommentService:     *commentService,
+		SavedSearchService: *savedSearchService,
+		fileServiceClient:  fileServiceClient,
+		storage:            productStorage,
+		logger:             logger,
 	}, nil
 }
 
@@ -119,6 +124,69 @@ func (p *ProductService) checkCorrectnessUrlsImg(ctx context.Context, slImg []mo
 	return nil
 }
 
+// fillImageVariants sets urls of reduced copies of images for feed. Feed is shown
+// with urls of originals if file service is unavailable, so error is only logged.
+func (p *ProductService) fillImageVariants(ctx context.Context, products []*models.ProductInFeed) {
+	logger := p.logger.LogReqID(ctx)
+
+	var urls []string
+
+	for _, product := range products {
+		urls = append(urls, convertImagesToSl(product.Images)...)
+	}
+
+	if len(urls) == 0 {
+		return
+	}
+
+	variantsList, err := p.fileServiceClient.GetVariants(ctx, &fileservice.ImgURLs{Url: urls})
+	if err != nil {
+		logger.Errorln(err)
+
+		return
+	}
+
+	variants := variantsList.GetVariants()
+	if len(variants) != len(urls) {
+		logger.Errorf("%+v: of variants and urls %d != %d", ErrDifUrls, len(variants), len(urls))
+
+		return
+	}
+
+	idxVariant := 0
+
+	for _, product := range products {
+		for i := range product.Images {
+			product.Images[i].Thumbnail = variants[idxVariant].GetThumbnail()
+			product.Images[i].Card = variants[idxVariant].GetCard()
+			idxVariant++
+		}
+	}
+}
+
+// attachImages reports images of product to file service, images without owners are deleted by it.
+// Product is already saved, so error is only logged: images are kept during grace period of garbage collector.
+func (p *ProductService) attachImages(ctx context.Context, productID uint64, images []models.Image) {
+	logger := p.logger.LogReqID(ctx)
+
+	_, err := p.fileServiceClient.Attach(ctx, &fileservice.ImgRefs{
+		Owner: &fileservice.ImgOwner{Type: fileservice.OwnerProduct, Id: productID},
+		Url:   convertImagesToSl(images),
+	})
+	if err != nil {
+		logger.Errorf("failed attach images of product id=%d: %+v", productID, err)
+	}
+}
+
+func (p *ProductService) detachImages(ctx context.Context, productID uint64) {
+	logger := p.logger.LogReqID(ctx)
+
+	_, err := p.fileServiceClient.Detach(ctx, &fileservice.ImgOwner{Type: fileservice.OwnerProduct, Id: productID})
+	if err != nil {
+		logger.Errorf("failed detach images of product id=%d: %+v", productID, err)
+	}
+}
+
 func (p *ProductService) AddProduct(ctx context.Context, r io.Reader, userID uint64) (uint64, error) {
 	preProduct, err := ValidatePreProduct(r, userID)
 	if err != nil {
@@ -135,6 +203,10 @@ func (p *ProductService) AddProduct(ctx context.Context, r io.Reader, userID uin
 		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
+	if len(preProduct.Images) != 0 {
+		p.attachImages(ctx, productID, preProduct.Images)
+	}
+
 	return productID, nil
 }
 
@@ -152,9 +224,14 @@ func (p *ProductService) GetProduct(ctx context.Context,
 }
 
 func (p *ProductService) GetProductsList(ctx context.Context,
-	offset uint64, count uint64, userID uint64,
-) ([]*models.ProductInFeed, error) {
-	products, err := p.storage.GetPopularProducts(ctx, offset, count, userID)
+	cursor *models.Cursor, count uint64, userID uint64, filter *models.ProductFilter,
+) (*models.ProductFeed, error) {
+	err := ValidateProductFilter(filter)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	products, next, err := p.storage.GetPopularProducts(ctx, cursor, count, userID, filter)
 	if err != nil {
 		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
@@ -163,13 +240,20 @@ func (p *ProductService) GetProductsList(ctx context.Context,
 		product.Sanitize()
 	}
 
-	return products, nil
+	p.fillImageVariants(ctx, products)
+
+	facets, err := p.storage.GetProductFacets(ctx, "", filter)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return &models.ProductFeed{Products: products, Facets: facets, NextCursor: next.String()}, nil
 }
 
 func (p *ProductService) GetProductsOfSaler(ctx context.Context,
-	offset uint64, count uint64, userID uint64, isMy bool,
-) ([]*models.ProductInFeed, error) {
-	products, err := p.storage.GetProductsOfSaler(ctx, offset, count, userID, isMy)
+	cursor *models.Cursor, count uint64, userID uint64, isMy bool,
+) (*models.ProductList, error) {
+	products, next, err := p.storage.GetProductsOfSaler(ctx, cursor, count, userID, isMy)
 	if err != nil {
 		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
@@ -178,7 +262,9 @@ func (p *ProductService) GetProductsOfSaler(ctx context.Context,
 		product.Sanitize()
 	}
 
-	return products, nil
+	p.fillImageVariants(ctx, products)
+
+	return &models.ProductList{Products: products, NextCursor: next.String()}, nil
 }
 
 func (p *ProductService) UpdateProduct(ctx context.Context,
@@ -212,6 +298,11 @@ func (p *ProductService) UpdateProduct(ctx context.Context,
 		return fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
+	// images of product are replaced by storage only if they are passed
+	if preProduct.Images != nil {
+		p.attachImages(ctx, productID, preProduct.Images)
+	}
+
 	return nil
 }
 
@@ -239,6 +330,8 @@ func (p *ProductService) DeleteProduct(ctx context.Context, productID uint64, us
 		return fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
+	p.detachImages(ctx, productID)
+
 	return nil
 }
 
@@ -265,8 +358,13 @@ func (p *ProductService) SearchProduct(ctx context.Context, searchInput string)
 }
 
 func (p *ProductService) GetSearchProductFeed(ctx context.Context,
-	searchInput string, lastNumber uint64, limit uint64, userID uint64,
-) ([]*models.ProductInFeed, error) {
+	searchInput string, cursor *models.Cursor, limit uint64, userID uint64, filter *models.ProductFilter,
+) (*models.ProductFeed, error) {
+	err := ValidateProductFilter(filter)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
 	regex := regexp.MustCompile(`[^a-zA-Zа-яА-Я0-9\s]+`)
 	searchInput = regex.ReplaceAllString(searchInput, "")
 	regex = regexp.MustCompile(`\s+`)
@@ -274,7 +372,7 @@ func (p *ProductService) GetSearchProductFeed(ctx context.Context,
 
 	searchInput = strings.TrimSpace(searchInput)
 
-	products, err := p.storage.GetSearchProductFeed(ctx, searchInput, lastNumber, limit, userID)
+	products, next, err := p.storage.GetSearchProductFeed(ctx, searchInput, cursor, limit, userID, filter)
 	if err != nil {
 		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
@@ -283,7 +381,14 @@ func (p *ProductService) GetSearchProductFeed(ctx context.Context,
 		product.Sanitize()
 	}
 
-	return products, nil
+	p.fillImageVariants(ctx, products)
+
+	facets, err := p.storage.GetProductFacets(ctx, searchInput, filter)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return &models.ProductFeed{Products: products, Facets: facets, NextCursor: next.String()}, nil
 }
 
 func convertImagesToSl(images []models.Image) []string {
@@ -6,6 +6,7 @@ import (
 	"io"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/mocks"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/usecases"
@@ -29,11 +30,12 @@ func NewProductService(ctrl *gomock.Controller,
 	mockFavouriteStorage := mocks.NewMockIFavouriteStorage(ctrl)
 	mockPremiumStorage := mocks.NewMockIPremiumStorage(ctrl)
 	mockCommentStorage := mocks.NewMockICommentStorage(ctrl)
+	mockSavedSearchStorage := mocks.NewMockISavedSearchStorage(ctrl)
 
 	behaviorProductStorage(mockProductStorage)
 	behaviorFileService(mockFileService)
 
-	basketService, err := usecases.NewBasketService(mockBasketStorage)
+	basketService, err := usecases.NewBasketService(mockBasketStorage, time.Minute)
 	if err != nil {
 		return nil, fmt.Errorf("unexpected err=%w", err)
 	}
@@ -53,8 +55,13 @@ func NewProductService(ctrl *gomock.Controller,
 		return nil, fmt.Errorf("unexpected err=%w", err)
 	}
 
+	savedSearchService, err := usecases.NewSavedSearchService(mockSavedSearchStorage)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected err=%w", err)
+	}
+
 	productService, err := usecases.NewProductService(mockProductStorage,
-		basketService, favouriteService, premiumService, commentService, mockFileService)
+		basketService, favouriteService, premiumService, commentService, savedSearchService, mockFileService)
 	if err != nil {
 		return nil, fmt.Errorf("unexpected err=%w", err)
 	}
@@ -199,6 +206,10 @@ func TestAddProduct(t *testing.T) {
 			behaviorFileServiceClient: func(m *mocksfileservice.MockFileServiceClient) {
 				m.EXPECT().Check(baseCtx, &fileservice.ImgURLs{Url: []string{"test_url"}}).Return(
 					&fileservice.CheckedURLs{Correct: []bool{true}}, nil)
+				m.EXPECT().Attach(baseCtx, &fileservice.ImgRefs{
+					Owner: &fileservice.ImgOwner{Type: fileservice.OwnerProduct, Id: test.ProductID},
+					Url:   []string{"test_url"},
+				}).Return(&fileservice.Nothing{}, nil)
 			},
 			expectedProductID: test.ProductID,
 			expectedError:     nil,
@@ -370,38 +381,76 @@ func TestGetProductList(t *testing.T) {
 	type TestCase struct {
 		name                   string
 		behaviorProductStorage func(m *mocks.MockIProductStorage)
-		inputLastProductID     uint64
+		inputCursor            *models.Cursor
 		inputCount             uint64
-		expectedProductID      []*models.ProductInFeed
+		inputFilter            *models.ProductFilter
+		expectedProductFeed    *models.ProductFeed
 		expectedError          error
 	}
 
 	testCases := [...]TestCase{
 		{
-			name:               "test basic work",
-			inputLastProductID: test.ProductID,
-			inputCount:         test.CountProduct,
+			name:        "test basic work",
+			inputCursor: models.NewCursor(10, test.ProductID),
+			inputCount:  test.CountProduct,
+			inputFilter: &models.ProductFilter{},
 			behaviorProductStorage: func(m *mocks.MockIProductStorage) {
-				m.EXPECT().GetPopularProducts(baseCtx, test.ProductID, test.CountProduct, test.UserID).Return(
+				m.EXPECT().GetPopularProducts(baseCtx, models.NewCursor(10, test.ProductID), test.CountProduct, test.UserID,
+					&models.ProductFilter{}).Return(
 					[]*models.ProductInFeed{
 						{ID: test.ProductID, Title: "Title"}, {ID: test.ProductID + 1, Title: "Title"},
-					}, nil)
+					}, models.NewCursor(8, test.ProductID+1), nil)
+				m.EXPECT().GetProductFacets(baseCtx, "", &models.ProductFilter{}).Return(
+					&models.ProductFacets{Cities: []models.FacetCount{{ID: 1, Count: 2}}}, nil)
 			},
-			expectedProductID: []*models.ProductInFeed{
-				{ID: test.ProductID, Title: "Title"}, {ID: test.ProductID + 1, Title: "Title"},
+			expectedProductFeed: &models.ProductFeed{
+				Products: []*models.ProductInFeed{
+					{ID: test.ProductID, Title: "Title"}, {ID: test.ProductID + 1, Title: "Title"},
+				},
+				Facets:     &models.ProductFacets{Cities: []models.FacetCount{{ID: 1, Count: 2}}},
+				NextCursor: models.NewCursor(8, test.ProductID+1).String(),
 			},
 			expectedError: nil,
 		},
 		{
-			name:               "test internal error",
-			inputLastProductID: test.ProductID,
-			inputCount:         test.CountProduct,
+			name:        "test with filter",
+			inputCursor: models.NewCursor(10, test.ProductID),
+			inputCount:  test.CountProduct,
+			inputFilter: &models.ProductFilter{CategoryID: 2, MinPrice: 10, MaxPrice: 100, Delivery: true},
 			behaviorProductStorage: func(m *mocks.MockIProductStorage) {
-				m.EXPECT().GetPopularProducts(baseCtx, test.ProductID, test.CountProduct, test.UserID).Return(
-					nil, testInternalErr)
+				m.EXPECT().GetPopularProducts(baseCtx, models.NewCursor(10, test.ProductID), test.CountProduct, test.UserID,
+					&models.ProductFilter{CategoryID: 2, MinPrice: 10, MaxPrice: 100, Delivery: true}).Return(
+					[]*models.ProductInFeed{{ID: test.ProductID, Title: "Title", Price: 50, Delivery: true}}, nil, nil)
+				m.EXPECT().GetProductFacets(baseCtx, "",
+					&models.ProductFilter{CategoryID: 2, MinPrice: 10, MaxPrice: 100, Delivery: true}).Return(
+					&models.ProductFacets{Categories: []models.FacetCount{{ID: 2, Count: 1}}}, nil)
 			},
-			expectedProductID: nil,
-			expectedError:     testInternalErr,
+			expectedProductFeed: &models.ProductFeed{
+				Products: []*models.ProductInFeed{{ID: test.ProductID, Title: "Title", Price: 50, Delivery: true}},
+				Facets:   &models.ProductFacets{Categories: []models.FacetCount{{ID: 2, Count: 1}}},
+			},
+			expectedError: nil,
+		},
+		{
+			name:                   "test min price greater max price",
+			inputCursor:            models.NewCursor(10, test.ProductID),
+			inputCount:             test.CountProduct,
+			inputFilter:            &models.ProductFilter{MinPrice: 100, MaxPrice: 10},
+			behaviorProductStorage: func(m *mocks.MockIProductStorage) {},
+			expectedProductFeed:    nil,
+			expectedError:          usecases.ErrMinPriceGreaterMaxPrice,
+		},
+		{
+			name:        "test internal error",
+			inputCursor: models.NewCursor(10, test.ProductID),
+			inputCount:  test.CountProduct,
+			inputFilter: &models.ProductFilter{},
+			behaviorProductStorage: func(m *mocks.MockIProductStorage) {
+				m.EXPECT().GetPopularProducts(baseCtx, models.NewCursor(10, test.ProductID), test.CountProduct, test.UserID,
+					&models.ProductFilter{}).Return(nil, nil, testInternalErr)
+			},
+			expectedProductFeed: nil,
+			expectedError:       testInternalErr,
 		},
 	}
 
@@ -420,13 +469,13 @@ func TestGetProductList(t *testing.T) {
 				t.Fatalf("Failed create productService %+v", err)
 			}
 
-			product, err := productService.GetProductsList(baseCtx,
-				testCase.inputLastProductID, test.CountProduct, test.UserID)
+			productFeed, err := productService.GetProductsList(baseCtx,
+				testCase.inputCursor, test.CountProduct, test.UserID, testCase.inputFilter)
 			if errInner := utils.EqualError(err, testCase.expectedError); errInner != nil {
 				t.Fatalf("Failed EqualError: %+v", errInner)
 			}
 
-			if err := utils.EqualTest(product, testCase.expectedProductID); err != nil {
+			if err := utils.EqualTest(productFeed, testCase.expectedProductFeed); err != nil {
 				t.Fatalf("Failed EqualTest %+v", err)
 			}
 		})
@@ -442,40 +491,93 @@ func TestGetProductsOfSaler(t *testing.T) {
 	testInternalErr := myerrors.NewErrorInternal("Test error")
 
 	type TestCase struct {
-		name                   string
-		behaviorProductStorage func(m *mocks.MockIProductStorage)
-		inputLastProductID     uint64
-		inputCount             uint64
-		expectedProductID      []*models.ProductInFeed
-		expectedError          error
+		name                      string
+		behaviorProductStorage    func(m *mocks.MockIProductStorage)
+		behaviorFileServiceClient func(m *mocksfileservice.MockFileServiceClient)
+		inputCursor               *models.Cursor
+		inputCount                uint64
+		expectedProductList       *models.ProductList
+		expectedError             error
 	}
 
 	testCases := [...]TestCase{
 		{
-			name:               "test basic work",
-			inputLastProductID: test.ProductID,
-			inputCount:         test.CountProduct,
+			name:        "test basic work",
+			inputCursor: models.NewCursor(10, test.ProductID),
+			inputCount:  test.CountProduct,
 			behaviorProductStorage: func(m *mocks.MockIProductStorage) {
-				m.EXPECT().GetProductsOfSaler(baseCtx, test.ProductID, test.CountProduct, test.UserID, true).Return(
+				m.EXPECT().GetProductsOfSaler(baseCtx, models.NewCursor(10, test.ProductID), test.CountProduct, test.UserID, true).Return(
 					[]*models.ProductInFeed{
 						{ID: test.ProductID, Title: "Title"}, {ID: test.ProductID + 1, Title: "Title"},
-					}, nil)
+					}, models.NewCursor(8, test.ProductID+1), nil)
 			},
-			expectedProductID: []*models.ProductInFeed{
-				{ID: test.ProductID, Title: "Title"}, {ID: test.ProductID + 1, Title: "Title"},
+			behaviorFileServiceClient: func(m *mocksfileservice.MockFileServiceClient) {},
+			expectedProductList: &models.ProductList{
+				Products: []*models.ProductInFeed{
+					{ID: test.ProductID, Title: "Title"}, {ID: test.ProductID + 1, Title: "Title"},
+				},
+				NextCursor: models.NewCursor(8, test.ProductID+1).String(),
 			},
 			expectedError: nil,
 		},
 		{
-			name:               "test internal error",
-			inputLastProductID: test.ProductID,
-			inputCount:         test.CountProduct,
+			name:        "test internal error",
+			inputCursor: models.NewCursor(10, test.ProductID),
+			inputCount:  test.CountProduct,
 			behaviorProductStorage: func(m *mocks.MockIProductStorage) {
-				m.EXPECT().GetProductsOfSaler(baseCtx, test.ProductID, test.CountProduct, test.UserID, true).Return(
-					nil, testInternalErr)
+				m.EXPECT().GetProductsOfSaler(baseCtx, models.NewCursor(10, test.ProductID), test.CountProduct, test.UserID, true).Return(
+					nil, nil, testInternalErr)
 			},
-			expectedProductID: nil,
-			expectedError:     testInternalErr,
+			behaviorFileServiceClient: func(m *mocksfileservice.MockFileServiceClient) {},
+			expectedProductList:       nil,
+			expectedError:             testInternalErr,
+		},
+		{
+			name:        "test image variants",
+			inputCursor: models.NewCursor(10, test.ProductID),
+			inputCount:  test.CountProduct,
+			behaviorProductStorage: func(m *mocks.MockIProductStorage) {
+				m.EXPECT().GetProductsOfSaler(baseCtx, models.NewCursor(10, test.ProductID), test.CountProduct, test.UserID, true).Return(
+					[]*models.ProductInFeed{
+						{ID: test.ProductID, Title: "Title", Images: []models.Image{{URL: "img/new"}, {URL: "img/old"}}},
+					}, nil, nil)
+			},
+			behaviorFileServiceClient: func(m *mocksfileservice.MockFileServiceClient) {
+				m.EXPECT().GetVariants(gomock.Any(), &fileservice.ImgURLs{Url: []string{"img/new", "img/old"}}).Return(
+					&fileservice.ImgVariantsList{Variants: []*fileservice.ImgVariants{
+						{Thumbnail: "img/new_thumbnail", Card: "img/new_card", Full: "img/new"},
+						{Thumbnail: "img/old", Card: "img/old", Full: "img/old"},
+					}}, nil)
+			},
+			expectedProductList: &models.ProductList{
+				Products: []*models.ProductInFeed{
+					{ID: test.ProductID, Title: "Title", Images: []models.Image{
+						{URL: "img/new", Thumbnail: "img/new_thumbnail", Card: "img/new_card"},
+						{URL: "img/old", Thumbnail: "img/old", Card: "img/old"},
+					}},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			name:        "test file service unavailable",
+			inputCursor: models.NewCursor(10, test.ProductID),
+			inputCount:  test.CountProduct,
+			behaviorProductStorage: func(m *mocks.MockIProductStorage) {
+				m.EXPECT().GetProductsOfSaler(baseCtx, models.NewCursor(10, test.ProductID), test.CountProduct, test.UserID, true).Return(
+					[]*models.ProductInFeed{
+						{ID: test.ProductID, Title: "Title", Images: []models.Image{{URL: "img/new"}}},
+					}, nil, nil)
+			},
+			behaviorFileServiceClient: func(m *mocksfileservice.MockFileServiceClient) {
+				m.EXPECT().GetVariants(gomock.Any(), gomock.Any()).Return(nil, testInternalErr)
+			},
+			expectedProductList: &models.ProductList{
+				Products: []*models.ProductInFeed{
+					{ID: test.ProductID, Title: "Title", Images: []models.Image{{URL: "img/new"}}},
+				},
+			},
+			expectedError: nil,
 		},
 	}
 
@@ -489,18 +591,18 @@ func TestGetProductsOfSaler(t *testing.T) {
 			defer ctrl.Finish()
 
 			productService, err := NewProductService(ctrl, testCase.behaviorProductStorage,
-				func(m *mocksfileservice.MockFileServiceClient) {})
+				testCase.behaviorFileServiceClient)
 			if err != nil {
 				t.Fatalf("Failed create productService %+v", err)
 			}
 
-			product, err := productService.GetProductsOfSaler(baseCtx,
-				testCase.inputLastProductID, test.CountProduct, test.UserID, true)
+			productList, err := productService.GetProductsOfSaler(baseCtx,
+				testCase.inputCursor, test.CountProduct, test.UserID, true)
 			if errInner := utils.EqualError(err, testCase.expectedError); errInner != nil {
 				t.Fatalf("Failed EqualError: %+v", errInner)
 			}
 
-			if err := utils.EqualTest(product, testCase.expectedProductID); err != nil {
+			if err := utils.EqualTest(productList, testCase.expectedProductList); err != nil {
 				t.Fatalf("Failed EqualTest %+v", err)
 			}
 		})
@@ -564,6 +666,10 @@ func TestUpdateProduct(t *testing.T) {
 			behaviorFileServiceClient: func(m *mocksfileservice.MockFileServiceClient) {
 				m.EXPECT().Check(baseCtx, &fileservice.ImgURLs{Url: []string{"test_url"}}).Return(
 					&fileservice.CheckedURLs{Correct: []bool{true}}, nil)
+				m.EXPECT().Attach(baseCtx, &fileservice.ImgRefs{
+					Owner: &fileservice.ImgOwner{Type: fileservice.OwnerProduct, Id: test.ProductID},
+					Url:   []string{"test_url"},
+				}).Return(nil, testInternalErr)
 			},
 			expectedError: nil,
 		},
@@ -777,7 +883,7 @@ func TestActivateProduct(t *testing.T) { //nolint:dupl
 	}
 }
 
-func TestDeleteProduct(t *testing.T) { //nolint:dupl
+func TestDeleteProduct(t *testing.T) {
 	t.Parallel()
 
 	_ = mylogger.NewNop()
@@ -786,10 +892,11 @@ func TestDeleteProduct(t *testing.T) { //nolint:dupl
 	testInternalErr := myerrors.NewErrorInternal("Test error")
 
 	type TestCase struct {
-		name                   string
-		inputProductID         uint64
-		behaviorProductStorage func(m *mocks.MockIProductStorage)
-		expectedError          error
+		name                      string
+		inputProductID            uint64
+		behaviorProductStorage    func(m *mocks.MockIProductStorage)
+		behaviorFileServiceClient func(m *mocksfileservice.MockFileServiceClient)
+		expectedError             error
 	}
 
 	testCases := [...]TestCase{
@@ -799,6 +906,10 @@ func TestDeleteProduct(t *testing.T) { //nolint:dupl
 			behaviorProductStorage: func(m *mocks.MockIProductStorage) {
 				m.EXPECT().DeleteProduct(baseCtx, test.ProductID, test.UserID).Return(nil)
 			},
+			behaviorFileServiceClient: func(m *mocksfileservice.MockFileServiceClient) {
+				m.EXPECT().Detach(baseCtx, &fileservice.ImgOwner{Type: fileservice.OwnerProduct, Id: test.ProductID}).
+					Return(&fileservice.Nothing{}, nil)
+			},
 			expectedError: nil,
 		},
 		{
@@ -807,7 +918,8 @@ func TestDeleteProduct(t *testing.T) { //nolint:dupl
 			behaviorProductStorage: func(m *mocks.MockIProductStorage) {
 				m.EXPECT().DeleteProduct(baseCtx, test.ProductID, test.UserID).Return(testInternalErr)
 			},
-			expectedError: testInternalErr,
+			behaviorFileServiceClient: func(m *mocksfileservice.MockFileServiceClient) {},
+			expectedError:             testInternalErr,
 		},
 	}
 
@@ -821,7 +933,7 @@ func TestDeleteProduct(t *testing.T) { //nolint:dupl
 			defer ctrl.Finish()
 
 			productService, err := NewProductService(ctrl, testCase.behaviorProductStorage,
-				func(m *mocksfileservice.MockFileServiceClient) {})
+				testCase.behaviorFileServiceClient)
 			if err != nil {
 				t.Fatalf("Failed create productService %+v", err)
 			}
@@ -911,43 +1023,51 @@ func TestSearchProductFeed(t *testing.T) {
 	type TestCase struct {
 		name                   string
 		inputSearch            string
-		inputLastNumber        uint64
+		inputCursor            *models.Cursor
 		inputLimit             uint64
 		behaviorProductStorage func(m *mocks.MockIProductStorage)
-		expectedProducts       []*models.ProductInFeed
+		expectedProductFeed    *models.ProductFeed
 		expectedError          error
 	}
 
 	testCases := [...]TestCase{
 		{
-			name:            "test basic work",
-			inputSearch:     "ноутбук",
-			inputLastNumber: 0,
-			inputLimit:      2,
+			name:        "test basic work",
+			inputSearch: "ноутбук",
+			inputCursor: models.NewCursor(0.5, test.ProductID),
+			inputLimit:  2,
 			behaviorProductStorage: func(m *mocks.MockIProductStorage) {
-				m.EXPECT().GetSearchProductFeed(baseCtx, "ноутбук", uint64(0), uint64(2), test.UserID).Return(
+				m.EXPECT().GetSearchProductFeed(baseCtx, "ноутбук", models.NewCursor(0.5, test.ProductID), uint64(2),
+					test.UserID,
+					&models.ProductFilter{}).Return(
 					[]*models.ProductInFeed{
 						{ID: test.ProductID, Title: "ноутбук Mac"},
 						{ID: test.ProductID, Title: "ноутбук Hp"},
-					}, nil)
+					}, nil, nil)
+				m.EXPECT().GetProductFacets(baseCtx, "ноутбук", &models.ProductFilter{}).Return(
+					&models.ProductFacets{Categories: []models.FacetCount{{ID: 1, Count: 2}}}, nil)
 			},
-			expectedProducts: []*models.ProductInFeed{
-				{ID: test.ProductID, Title: "ноутбук Mac"},
-				{ID: test.ProductID, Title: "ноутбук Hp"},
+			expectedProductFeed: &models.ProductFeed{
+				Products: []*models.ProductInFeed{
+					{ID: test.ProductID, Title: "ноутбук Mac"},
+					{ID: test.ProductID, Title: "ноутбук Hp"},
+				},
+				Facets: &models.ProductFacets{Categories: []models.FacetCount{{ID: 1, Count: 2}}},
 			},
 			expectedError: nil,
 		},
 		{
-			name:            "test basic work",
-			inputSearch:     "ноутбук",
-			inputLastNumber: 0,
-			inputLimit:      2,
+			name:        "test internal error",
+			inputSearch: "ноутбук",
+			inputCursor: models.NewCursor(0.5, test.ProductID),
+			inputLimit:  2,
 			behaviorProductStorage: func(m *mocks.MockIProductStorage) {
-				m.EXPECT().GetSearchProductFeed(baseCtx, "ноутбук", uint64(0), uint64(2), test.UserID).Return(
-					nil, testInternalErr)
+				m.EXPECT().GetSearchProductFeed(baseCtx, "ноутбук", models.NewCursor(0.5, test.ProductID), uint64(2),
+					test.UserID,
+					&models.ProductFilter{}).Return(nil, nil, testInternalErr)
 			},
-			expectedProducts: nil,
-			expectedError:    testInternalErr,
+			expectedProductFeed: nil,
+			expectedError:       testInternalErr,
 		},
 	}
 
@@ -966,13 +1086,13 @@ func TestSearchProductFeed(t *testing.T) {
 				t.Fatalf("Failed create productService %+v", err)
 			}
 
-			productsInFeed, err := productService.GetSearchProductFeed(baseCtx, testCase.inputSearch,
-				testCase.inputLastNumber, testCase.inputLimit, test.UserID)
+			productFeed, err := productService.GetSearchProductFeed(baseCtx, testCase.inputSearch,
+				testCase.inputCursor, testCase.inputLimit, test.UserID, &models.ProductFilter{})
 			if errInner := utils.EqualError(err, testCase.expectedError); errInner != nil {
 				t.Fatalf("Failed EqualError: %+v", errInner)
 			}
 
-			if err := utils.EqualTest(productsInFeed, testCase.expectedProducts); err != nil {
+			if err := utils.EqualTest(productFeed, testCase.expectedProductFeed); err != nil {
 				t.Fatalf("Failed EqualTest %+v", err)
 			}
 		})
@@ -0,0 +1,124 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	productrepo "github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/repository"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+)
+
+var _ ISavedSearchStorage = (*productrepo.ProductStorage)(nil)
+
+type ISavedSearchStorage interface {
+	AddSavedSearch(ctx context.Context, preSavedSearch *models.PreSavedSearch) (uint64, error)
+	GetSavedSearches(ctx context.Context, ownerID uint64) ([]*models.SavedSearch, error)
+	UpdateSavedSearch(ctx context.Context, savedSearchID uint64, preSavedSearch *models.PreSavedSearch) error
+	DeleteSavedSearch(ctx context.Context, savedSearchID uint64, ownerID uint64) error
+	CheckSavedSearches(ctx context.Context, checkedAt time.Time) (uint64, error)
+	GetNotifications(ctx context.Context, ownerID uint64) ([]*models.Notification, error)
+	ReadNotifications(ctx context.Context, ownerID uint64) error
+}
+
+type SavedSearchService struct {
+	storage ISavedSearchStorage
+	logger  *mylogger.MyLogger
+}
+
+func NewSavedSearchService(savedSearchStorage ISavedSearchStorage) (*SavedSearchService, error) {
+	logger, err := mylogger.Get()
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return &SavedSearchService{storage: savedSearchStorage, logger: logger}, nil
+}
+
+func (s SavedSearchService) AddSavedSearch(ctx context.Context, r io.Reader, userID uint64) (uint64, error) {
+	preSavedSearch, err := ValidatePreSavedSearch(r, userID)
+	if err != nil {
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	savedSearchID, err := s.storage.AddSavedSearch(ctx, preSavedSearch)
+	if err != nil {
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return savedSearchID, nil
+}
+
+func (s SavedSearchService) GetSavedSearches(ctx context.Context, userID uint64) ([]*models.SavedSearch, error) {
+	savedSearches, err := s.storage.GetSavedSearches(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	for _, savedSearch := range savedSearches {
+		savedSearch.Sanitize()
+	}
+
+	return savedSearches, nil
+}
+
+func (s SavedSearchService) UpdateSavedSearch(ctx context.Context,
+	r io.Reader, userID uint64, savedSearchID uint64,
+) error {
+	preSavedSearch, err := ValidatePreSavedSearch(r, userID)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	err = s.storage.UpdateSavedSearch(ctx, savedSearchID, preSavedSearch)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+func (s SavedSearchService) DeleteSavedSearch(ctx context.Context, savedSearchID uint64, userID uint64) error {
+	err := s.storage.DeleteSavedSearch(ctx, savedSearchID, userID)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// CheckSavedSearches creates notifications about products created since previous check
+// and returns count of new notifications.
+func (s SavedSearchService) CheckSavedSearches(ctx context.Context) (uint64, error) {
+	countNotifications, err := s.storage.CheckSavedSearches(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return countNotifications, nil
+}
+
+func (s SavedSearchService) GetNotifications(ctx context.Context, userID uint64) ([]*models.Notification, error) {
+	notifications, err := s.storage.GetNotifications(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	for _, notification := range notifications {
+		notification.Sanitize()
+	}
+
+	return notifications, nil
+}
+
+func (s SavedSearchService) ReadNotifications(ctx context.Context, userID uint64) error {
+	err := s.storage.ReadNotifications(ctx, userID)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package usecases_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/mocks"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/usecases"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils/test"
+	"go.uber.org/mock/gomock"
+)
+
+func NewSavedSearchService(ctrl *gomock.Controller,
+	behaviorSavedSearchStorage func(m *mocks.MockISavedSearchStorage),
+) (*usecases.SavedSearchService, error) {
+	_ = mylogger.NewNop()
+
+	mockSavedSearchStorage := mocks.NewMockISavedSearchStorage(ctrl)
+
+	behaviorSavedSearchStorage(mockSavedSearchStorage)
+
+	savedSearchService, err := usecases.NewSavedSearchService(mockSavedSearchStorage)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected err=%w", err)
+	}
+
+	return savedSearchService, nil
+}
+
+func TestAddSavedSearch(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	baseCtx := context.Background()
+
+	type TestCase struct {
+		name                       string
+		inputReader                io.Reader
+		behaviorSavedSearchStorage func(m *mocks.MockISavedSearchStorage)
+		expectedSavedSearchID      uint64
+		expectedError              error
+	}
+
+	testCases := [...]TestCase{
+		{
+			name:        "test basic work",
+			inputReader: strings.NewReader(`{"query": "  iphone ", "filter": {"city_id": 2, "delivery": true}}`),
+			behaviorSavedSearchStorage: func(m *mocks.MockISavedSearchStorage) {
+				m.EXPECT().AddSavedSearch(baseCtx, &models.PreSavedSearch{
+					OwnerID: test.UserID, Query: "iphone",
+					Filter: models.ProductFilter{CityID: 2, Delivery: true}, //nolint:exhaustruct
+				}).Return(uint64(1), nil)
+			},
+			expectedSavedSearchID: uint64(1),
+			expectedError:         nil,
+		},
+		{
+			name:                       "test empty query",
+			inputReader:                strings.NewReader(`{"query": "  "}`),
+			behaviorSavedSearchStorage: func(m *mocks.MockISavedSearchStorage) {},
+			expectedSavedSearchID:      uint64(0),
+			expectedError:              usecases.ErrValidatePreSavedSearch,
+		},
+		{
+			name:                       "test min price greater max price",
+			inputReader:                strings.NewReader(`{"query": "iphone", "filter": {"min_price": 2, "max_price": 1}}`),
+			behaviorSavedSearchStorage: func(m *mocks.MockISavedSearchStorage) {},
+			expectedSavedSearchID:      uint64(0),
+			expectedError:              usecases.ErrMinPriceGreaterMaxPrice,
+		},
+		{
+			name:                       "test wrong json",
+			inputReader:                strings.NewReader(`{"query": `),
+			behaviorSavedSearchStorage: func(m *mocks.MockISavedSearchStorage) {},
+			expectedSavedSearchID:      uint64(0),
+			expectedError:              usecases.ErrDecodePreSavedSearch,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			savedSearchService, err := NewSavedSearchService(ctrl, testCase.behaviorSavedSearchStorage)
+			if err != nil {
+				t.Fatalf("Failed create savedSearchService %+v", err)
+			}
+
+			savedSearchID, err := savedSearchService.AddSavedSearch(baseCtx, testCase.inputReader, test.UserID)
+			if errInner := utils.EqualError(err, testCase.expectedError); errInner != nil {
+				t.Fatalf("Failed EqualError: %+v", errInner)
+			}
+
+			if err := utils.EqualTest(savedSearchID, testCase.expectedSavedSearchID); err != nil {
+				t.Fatalf("Failed EqualTest %+v", err)
+			}
+		})
+	}
+}
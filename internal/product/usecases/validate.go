@@ -18,13 +18,17 @@ var (
 	ErrDecodeProductID    = myerrors.NewErrorBadFormatRequest("Некорректный json product_id")
 	ErrDecodeOrderChanges = myerrors.NewErrorBadFormatRequest("Некорректный json изменения заказа")
 	ErrNotExistingStatus  = myerrors.NewErrorBadFormatRequest(
-		"Статус заказа не может быть больше %d", models.OrderStatusClosed)
+		"Статус заказа не может быть больше %d", models.OrderStatusRefunded)
 	ErrCommentingYourself         = myerrors.NewErrorBadFormatRequest("Нельзя оставлять отзывы самому себе")
 	ErrValidatePreComment         = myerrors.NewErrorBadContentRequest("Ошибка валидации комментария: ")
 	ErrValidatePreProduct         = myerrors.NewErrorBadContentRequest("Ошибка валидации объявления: ")
 	ErrValidatePreOrder           = myerrors.NewErrorBadContentRequest("Ошибка валидации заказа: ")
 	ErrValidateOrderChangesCount  = myerrors.NewErrorBadFormatRequest("Ошибка валидации количества изменения заказа: ")
 	ErrValidateOrderChangesStatus = myerrors.NewErrorBadFormatRequest("Ошибка валидации статуса изменения заказа: ")
+	ErrMinPriceGreaterMaxPrice    = myerrors.NewErrorBadContentRequest(
+		"Минимальная цена не может быть больше максимальной")
+	ErrDecodePreSavedSearch   = myerrors.NewErrorBadFormatRequest("Некорректный json сохраненного поиска")
+	ErrValidatePreSavedSearch = myerrors.NewErrorBadContentRequest("Ошибка валидации сохраненного поиска: ")
 )
 
 func validatePreComment(r io.Reader, userID uint64) (*models.PreComment, error) {
@@ -336,7 +340,7 @@ func ValidateOrderChangesStatus(r io.Reader) (*models.OrderChanges, error) {
 		}
 	}
 
-	if orderChanges.Status > models.OrderStatusClosed {
+	if orderChanges.Status > models.OrderStatusRefunded {
 		errInner := fmt.Errorf(myerrors.ErrTemplate, ErrNotExistingStatus)
 		logger.Errorln(errInner)
 
@@ -345,3 +349,55 @@ func ValidateOrderChangesStatus(r io.Reader) (*models.OrderChanges, error) {
 
 	return orderChanges, nil
 }
+
+func ValidateProductFilter(filter *models.ProductFilter) error {
+	if filter == nil {
+		return nil
+	}
+
+	if filter.MaxPrice != 0 && filter.MinPrice > filter.MaxPrice {
+		return fmt.Errorf(myerrors.ErrTemplate, ErrMinPriceGreaterMaxPrice)
+	}
+
+	return nil
+}
+
+func ValidatePreSavedSearch(r io.Reader, userID uint64) (*models.PreSavedSearch, error) {
+	logger, err := mylogger.Get()
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	preSavedSearch := new(models.PreSavedSearch)
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, ErrDecodePreSavedSearch)
+	}
+
+	if err := preSavedSearch.UnmarshalJSON(data); err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, ErrDecodePreSavedSearch)
+	}
+
+	preSavedSearch.Trim()
+
+	preSavedSearch.OwnerID = userID
+
+	_, err = govalidator.ValidateStruct(preSavedSearch)
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf("%w %v", ErrValidatePreSavedSearch, err) //nolint:errorlint
+	}
+
+	err = ValidateProductFilter(&preSavedSearch.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return preSavedSearch, nil
+}
@@ -15,9 +15,18 @@ import (
 func GetUserID(ctx context.Context, r *http.Request,
 	sessionManager auth.SessionMangerClient,
 ) (uint64, error) {
+	userID, _, err := GetUserIDAndRole(ctx, r, sessionManager)
+
+	return userID, err
+}
+
+// GetUserIDAndRole role is one of auth.RoleUser, auth.RoleModerator, auth.RoleAdmin.
+func GetUserIDAndRole(ctx context.Context, r *http.Request,
+	sessionManager auth.SessionMangerClient,
+) (uint64, string, error) {
 	logger, err := mylogger.Get()
 	if err != nil {
-		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+		return 0, "", fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
 	logger = logger.LogReqID(r.Context()) //nolint:contextcheck
@@ -30,7 +39,7 @@ func GetUserID(ctx context.Context, r *http.Request,
 			err = responses.ErrCookieNotPresented
 		}
 
-		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+		return 0, "", fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
 	rawJwt := cookie.Value
@@ -40,8 +49,13 @@ func GetUserID(ctx context.Context, r *http.Request,
 	if err != nil {
 		logger.Errorln(err)
 
-		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+		return 0, "", fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	role := userID.GetRole()
+	if role == "" {
+		role = auth.RoleUser
 	}
 
-	return userID.GetUserId(), nil
+	return userID.GetUserId(), role, nil
 }
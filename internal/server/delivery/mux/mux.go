@@ -4,9 +4,11 @@ import (
 	"context"
 	"net/http"
 
+	admindelivery "github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/admin/delivery"
 	categorydelivery "github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/category/delivery"
 	citydelivery "github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/city/delivery"
 	productdelivery "github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/product/delivery"
+	serverdelivery "github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/server/delivery"
 	userdelivery "github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/user/delivery"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/auth"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/delivery"
@@ -23,11 +25,15 @@ type ConfigMux struct {
 	mainServiceName   string
 	premiumShopID     string
 	premiumShopSecret string
+	paymentsURL       string
+	notificationIPs   string
 	pathCertFile      string
+	productionMode    bool
 }
 
 func NewConfigMux(addrOrigin, schema, portServer,
-	mainServiceName, premiumShopID, premiumShopSecret, pathCertFile string,
+	mainServiceName, premiumShopID, premiumShopSecret, paymentsURL, notificationIPs, pathCertFile string,
+	productionMode bool,
 ) *ConfigMux {
 	return &ConfigMux{
 		addrOrigin:        addrOrigin,
@@ -36,19 +42,22 @@ func NewConfigMux(addrOrigin, schema, portServer,
 		mainServiceName:   mainServiceName,
 		premiumShopID:     premiumShopID,
 		premiumShopSecret: premiumShopSecret,
+		paymentsURL:       paymentsURL,
+		notificationIPs:   notificationIPs,
 		pathCertFile:      pathCertFile,
+		productionMode:    productionMode,
 	}
 }
 
 //nolint:funlen
 func NewMux(ctx context.Context, configMux *ConfigMux, userService userdelivery.IUserService,
 	productService productdelivery.IProductService, categoryService categorydelivery.ICategoryService,
-	cityService citydelivery.ICityService, authGrpcService auth.SessionMangerClient,
-	logger *mylogger.MyLogger,
+	cityService citydelivery.ICityService, adminService admindelivery.IAdminService,
+	authGrpcService auth.SessionMangerClient, logger *mylogger.MyLogger,
 ) (http.Handler, error) {
 	router := http.NewServeMux()
 
-	authHandler, err := userdelivery.NewAuthHandler(authGrpcService)
+	authHandler, err := userdelivery.NewAuthHandler(authGrpcService, configMux.productionMode)
 	if err != nil {
 		return nil, err //nolint:wrapcheck
 	}
@@ -68,8 +77,14 @@ func NewMux(ctx context.Context, configMux *ConfigMux, userService userdelivery.
 		return nil, err //nolint:wrapcheck
 	}
 
+	adminHandler, err := admindelivery.NewAdminHandler(adminService)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
 	productHandler, err := productdelivery.NewProductHandler(ctx, configMux.addrOrigin,
-		configMux.premiumShopID, configMux.premiumShopSecret, configMux.pathCertFile, productService, authGrpcService)
+		configMux.premiumShopID, configMux.premiumShopSecret, configMux.paymentsURL,
+		configMux.notificationIPs, configMux.pathCertFile, productService, authGrpcService)
 	if err != nil {
 		return nil, err //nolint:wrapcheck
 	}
@@ -79,11 +94,35 @@ func NewMux(ctx context.Context, configMux *ConfigMux, userService userdelivery.
 	router.Handle("/signin",
 		middleware.SetupCORS(authHandler.SignInHandler, configMux.addrOrigin, configMux.schema))
 	router.Handle("/logout", http.HandlerFunc(authHandler.LogOutHandler))
+	router.Handle("/refresh",
+		middleware.SetupCORS(authHandler.RefreshHandler, configMux.addrOrigin, configMux.schema))
+	router.Handle("/session/list",
+		middleware.SetupCORS(authHandler.GetSessionsHandler, configMux.addrOrigin, configMux.schema))
+	router.Handle("/session/revoke",
+		middleware.SetupCORS(authHandler.RevokeSessionHandler, configMux.addrOrigin, configMux.schema))
+	router.Handle("/session/revoke_all",
+		middleware.SetupCORS(authHandler.RevokeAllSessionsHandler, configMux.addrOrigin, configMux.schema))
+	router.Handle("/verify_email/send",
+		middleware.SetupCORS(authHandler.SendVerifyEmailHandler, configMux.addrOrigin, configMux.schema))
+	router.Handle("/verify_email",
+		middleware.SetupCORS(authHandler.VerifyEmailHandler, configMux.addrOrigin, configMux.schema))
+	router.Handle("/password/reset_request",
+		middleware.SetupCORS(authHandler.RequestPasswordResetHandler, configMux.addrOrigin, configMux.schema))
+	router.Handle("/password/reset",
+		middleware.SetupCORS(authHandler.ResetPasswordHandler, configMux.addrOrigin, configMux.schema))
+	router.Handle("/oauth/url",
+		middleware.SetupCORS(authHandler.OAuthURLHandler, configMux.addrOrigin, configMux.schema))
+	router.Handle("/oauth/login",
+		middleware.SetupCORS(authHandler.OAuthLoginHandler, configMux.addrOrigin, configMux.schema))
 
 	router.Handle("/profile/get",
 		middleware.SetupCORS(userHandler.GetUserHandler, configMux.addrOrigin, configMux.schema))
 	router.Handle("/profile/update",
 		middleware.SetupCORS(userHandler.PartiallyUpdateUserHandler, configMux.addrOrigin, configMux.schema))
+	router.Handle("/profile/change_password",
+		middleware.SetupCORS(userHandler.ChangePasswordHandler, configMux.addrOrigin, configMux.schema))
+	router.Handle("/profile/delete",
+		middleware.SetupCORS(userHandler.DeleteUserHandler, configMux.addrOrigin, configMux.schema))
 
 	router.Handle("/product/add",
 		middleware.SetupCORS(productHandler.AddProductHandler, configMux.addrOrigin, configMux.schema))
@@ -119,6 +158,7 @@ func NewMux(ctx context.Context, configMux *ConfigMux, userService userdelivery.
 		middleware.SetupCORS(productHandler.AddPremiumHandler, configMux.addrOrigin, configMux.schema))
 	router.Handle("/premium/check",
 		middleware.SetupCORS(productHandler.CheckPremiumStatus, configMux.addrOrigin, configMux.schema))
+	router.HandleFunc("/payment/notification", productHandler.PaymentNotificationHandler)
 
 	router.Handle("/order/add",
 		middleware.SetupCORS(productHandler.AddOrderHandler, configMux.addrOrigin, configMux.schema))
@@ -128,6 +168,8 @@ func NewMux(ctx context.Context, configMux *ConfigMux, userService userdelivery.
 		middleware.SetupCORS(productHandler.GetOrdersNotInBasketHandler, configMux.addrOrigin, configMux.schema))
 	router.Handle("/order/sold",
 		middleware.SetupCORS(productHandler.GetOrdersSoldHandler, configMux.addrOrigin, configMux.schema))
+	router.Handle("/order/history",
+		middleware.SetupCORS(productHandler.GetOrderStatusHistoryHandler, configMux.addrOrigin, configMux.schema))
 	router.Handle("/order/update_count",
 		middleware.SetupCORS(productHandler.UpdateOrderCountHandler, configMux.addrOrigin, configMux.schema))
 	router.Handle("/order/update_status",
@@ -146,6 +188,19 @@ func NewMux(ctx context.Context, configMux *ConfigMux, userService userdelivery.
 	router.Handle("/comment/get_list",
 		middleware.SetupCORS(productHandler.GetCommentListHandler, configMux.addrOrigin, configMux.schema))
 
+	router.Handle("/saved_search/add",
+		middleware.SetupCORS(productHandler.AddSavedSearchHandler, configMux.addrOrigin, configMux.schema))
+	router.Handle("/saved_search/get_list",
+		middleware.SetupCORS(productHandler.GetSavedSearchesHandler, configMux.addrOrigin, configMux.schema))
+	router.Handle("/saved_search/update",
+		middleware.SetupCORS(productHandler.UpdateSavedSearchHandler, configMux.addrOrigin, configMux.schema))
+	router.Handle("/saved_search/delete",
+		middleware.SetupCORS(productHandler.DeleteSavedSearchHandler, configMux.addrOrigin, configMux.schema))
+	router.Handle("/notification/get_list",
+		middleware.SetupCORS(productHandler.GetNotificationsHandler, configMux.addrOrigin, configMux.schema))
+	router.Handle("/notification/read",
+		middleware.SetupCORS(productHandler.ReadNotificationsHandler, configMux.addrOrigin, configMux.schema))
+
 	router.Handle("/category/get_full",
 		middleware.SetupCORS(categoryHandler.GetFullCategories, configMux.addrOrigin, configMux.schema))
 	router.Handle("/category/search",
@@ -155,6 +210,29 @@ func NewMux(ctx context.Context, configMux *ConfigMux, userService userdelivery.
 		middleware.SetupCORS(cityHandler.GetFullCitiesHandler, configMux.addrOrigin, configMux.schema))
 	router.Handle("/city/search",
 		middleware.SetupCORS(cityHandler.SearchCityHandler, configMux.addrOrigin, configMux.schema))
+
+	requireAdmin := func(next http.HandlerFunc) http.HandlerFunc {
+		return serverdelivery.RequireRole(authGrpcService, auth.RoleAdmin, next)
+	}
+	requireModerator := func(next http.HandlerFunc) http.HandlerFunc {
+		return serverdelivery.RequireRole(authGrpcService, auth.RoleModerator, next)
+	}
+
+	router.Handle("/admin/user/get_list", middleware.SetupCORS(
+		requireAdmin(adminHandler.GetUsersHandler), configMux.addrOrigin, configMux.schema))
+	router.Handle("/admin/user/ban", middleware.SetupCORS(
+		requireAdmin(adminHandler.BanUserHandler), configMux.addrOrigin, configMux.schema))
+	router.Handle("/admin/user/unban", middleware.SetupCORS(
+		requireAdmin(adminHandler.UnbanUserHandler), configMux.addrOrigin, configMux.schema))
+	router.Handle("/admin/product/close", middleware.SetupCORS(
+		requireModerator(adminHandler.CloseProductHandler), configMux.addrOrigin, configMux.schema))
+	router.Handle("/admin/product/premium", middleware.SetupCORS(
+		requireAdmin(adminHandler.SetPremiumHandler), configMux.addrOrigin, configMux.schema))
+	router.Handle("/admin/comment/delete", middleware.SetupCORS(
+		requireModerator(adminHandler.DeleteCommentHandler), configMux.addrOrigin, configMux.schema))
+	router.Handle("/admin/audit/get_list", middleware.SetupCORS(
+		requireAdmin(adminHandler.GetAuditLogHandler), configMux.addrOrigin, configMux.schema))
+
 	router.Handle("/metrics", promhttp.Handler())
 	router.HandleFunc("/healthcheck", delivery.HealthCheckHandler)
 
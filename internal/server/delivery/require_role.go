@@ -0,0 +1,59 @@
+package delivery
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/auth"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses"
+)
+
+var ErrForbidden = myerrors.NewErrorForbidden("Недостаточно прав")
+
+type userIDInCtx struct{}
+
+var keyUserIDCtx = userIDInCtx{} //nolint:gochecknoglobals
+
+// GetUserIDCtx returns id of user, which was checked by RequireRole.
+func GetUserIDCtx(ctx context.Context) (uint64, bool) {
+	userID, ok := ctx.Value(keyUserIDCtx).(uint64)
+
+	return userID, ok
+}
+
+// RequireRole passes request to next only if owner of jwt has requiredRole or higher,
+// id of user is put into context of request and can be got by GetUserIDCtx.
+func RequireRole(sessionManager auth.SessionMangerClient, requiredRole string,
+	next http.HandlerFunc,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		logger, err := mylogger.Get()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		logger = logger.LogReqID(ctx)
+
+		userID, role, err := GetUserIDAndRole(ctx, r, sessionManager)
+		if err != nil {
+			responses.HandleErr(w, r, logger, err)
+
+			return
+		}
+
+		if !auth.HasRole(role, requiredRole) {
+			logger.Infof("user id=%d with role %s tried to access %s", userID, role, r.URL.Path)
+			responses.HandleErr(w, r, logger, ErrForbidden)
+
+			return
+		}
+
+		next(w, r.WithContext(context.WithValue(ctx, keyUserIDCtx, userID)))
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"strings"
 	"time"
 
+	adminrepo "github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/admin/repository"
+	adminusecases "github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/admin/usecases"
 	categoryrepo "github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/category/repository"
 	categoryusecases "github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/category/usecases"
 	cityrepo "github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/city/repository"
@@ -79,7 +81,7 @@ func (s *Server) Run(config *config.Config) error { //nolint:cyclop
 		return err //nolint:wrapcheck
 	}
 
-	basketService, err := usecases.NewBasketService(productStorage)
+	basketService, err := usecases.NewBasketService(productStorage, config.OrderReservationTimeout)
 	if err != nil {
 		return err //nolint:wrapcheck
 	}
@@ -99,8 +101,13 @@ func (s *Server) Run(config *config.Config) error { //nolint:cyclop
 		return err //nolint:wrapcheck
 	}
 
+	savedSearchService, err := usecases.NewSavedSearchService(productStorage)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
 	productService, err := usecases.NewProductService(productStorage, basketService, favouriteService,
-		premiumService, commentService, fileServiceClient)
+		premiumService, commentService, savedSearchService, fileServiceClient)
 	if err != nil {
 		return err //nolint:wrapcheck
 	}
@@ -110,7 +117,7 @@ func (s *Server) Run(config *config.Config) error { //nolint:cyclop
 		return err //nolint:wrapcheck
 	}
 
-	userService, err := userusecases.NewUserService(userStorage)
+	userService, err := userusecases.NewUserService(userStorage, fileServiceClient)
 	if err != nil {
 		return err //nolint:wrapcheck
 	}
@@ -135,10 +142,21 @@ func (s *Server) Run(config *config.Config) error { //nolint:cyclop
 		return err //nolint:wrapcheck
 	}
 
+	adminStorage, err := adminrepo.NewAdminStorage(pool)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	adminService, err := adminusecases.NewAdminService(adminStorage)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
 	handler, err := mux.NewMux(baseCtx, mux.NewConfigMux(config.AllowOrigin,
 		config.Schema, config.PortServer, config.MainServiceName,
-		config.PremiumShopID, config.PremiumShopSecret, config.PathCertFile),
-		userService, productService, categoryService, cityService, authGrpcService, logger)
+		config.PremiumShopID, config.PremiumShopSecret, config.PaymentsURL,
+		config.NotificationIPs, config.PathCertFile, config.ProductionMode),
+		userService, productService, categoryService, cityService, adminService, authGrpcService, logger)
 	if err != nil {
 		return err //nolint:wrapcheck
 	}
@@ -2,7 +2,6 @@ package delivery
 
 import (
 	"encoding/json"
-	"errors"
 	"net/http"
 	"time"
 
@@ -12,24 +11,75 @@ import (
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
 )
 
 const (
-	timeTokenLife = 24 * time.Hour
+	timeAccessTokenLife  = 15 * time.Minute
+	timeRefreshTokenLife = 30 * 24 * time.Hour
 )
 
 type AuthHandler struct {
 	sessionManagerClient auth.SessionMangerClient
+	productionMode       bool
 	logger               *mylogger.MyLogger
 }
 
-func NewAuthHandler(sessionManagerClient auth.SessionMangerClient) (*AuthHandler, error) {
+func NewAuthHandler(sessionManagerClient auth.SessionMangerClient, productionMode bool) (*AuthHandler, error) {
 	logger, err := mylogger.Get()
 	if err != nil {
 		return nil, err //nolint:wrapcheck
 	}
 
-	return &AuthHandler{sessionManagerClient: sessionManagerClient, logger: logger}, nil
+	return &AuthHandler{
+		sessionManagerClient: sessionManagerClient,
+		productionMode:       productionMode,
+		logger:               logger,
+	}, nil
+}
+
+// setSessionCookies refresh token isn't needed for js, so its cookie is HttpOnly.
+func setSessionCookies(w http.ResponseWriter, sessionWithToken *auth.Session) {
+	now := time.Now()
+
+	http.SetCookie(w, &http.Cookie{ //nolint:exhaustruct
+		Name:     responses.CookieAuthName,
+		Value:    sessionWithToken.GetAccessToken(),
+		SameSite: http.SameSiteLaxMode,
+		Expires:  now.Add(timeAccessTokenLife),
+		Path:     "/",
+	})
+	http.SetCookie(w, &http.Cookie{ //nolint:exhaustruct
+		Name:     responses.CookieRefreshName,
+		Value:    sessionWithToken.GetRefreshToken(),
+		SameSite: http.SameSiteLaxMode,
+		Expires:  now.Add(timeRefreshTokenLife),
+		Path:     "/",
+		HttpOnly: true,
+	})
+}
+
+func deleteRefreshCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{ //nolint:exhaustruct
+		Name:     responses.CookieRefreshName,
+		Value:    "",
+		SameSite: http.SameSiteLaxMode,
+		Expires:  time.Unix(0, 0),
+		Path:     "/",
+		HttpOnly: true,
+	})
+}
+
+// deleteSessionCookies removes cookies of access and refresh tokens, e.g. after revoke of all sessions.
+func deleteSessionCookies(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{ //nolint:exhaustruct
+		Name:     responses.CookieAuthName,
+		Value:    "",
+		SameSite: http.SameSiteLaxMode,
+		Expires:  time.Unix(0, 0),
+		Path:     "/",
+	})
+	deleteRefreshCookie(w)
 }
 
 // SignUpHandler godoc
@@ -85,36 +135,53 @@ func (a *AuthHandler) SignUpHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	expire := time.Now().Add(timeTokenLife)
+	setSessionCookies(w, sessionWithToken)
+	responses.SendResponse(w, logger, responses.NewResponseSuccessful(ResponseSuccessfulSignUp))
+	logger.Infof("in SignUpHandler: added user")
+}
 
-	cookie := &http.Cookie{ //nolint:exhaustruct
-		Name:     responses.CookieAuthName,
-		Value:    sessionWithToken.GetAccessToken(),
-		SameSite: http.SameSiteLaxMode,
-		Expires:  expire,
-		Path:     "/",
+// isAllowedMethodSignIn old form of signin with GET and credentials in query is kept
+// only in development, because query gets into logs of nginx and history of browser.
+func (a *AuthHandler) isAllowedMethodSignIn(method string) bool {
+	return method == http.MethodPost || (method == http.MethodGet && !a.productionMode)
+}
+
+func parseUserSignIn(r *http.Request) (*models.UserSignIn, error) {
+	userSignIn := new(models.UserSignIn)
+
+	if r.Method == http.MethodGet {
+		userSignIn.Email = r.URL.Query().Get("email")
+		userSignIn.Password = r.URL.Query().Get("password")
+	} else {
+		decoder := json.NewDecoder(r.Body)
+		if err := decoder.Decode(userSignIn); err != nil {
+			return nil, myerrors.NewErrorBadFormatRequest(err.Error())
+		}
 	}
 
-	http.SetCookie(w, cookie)
-	responses.SendResponse(w, logger, responses.NewResponseSuccessful(ResponseSuccessfulSignUp))
-	logger.Infof("in SignUpHandler: added user")
+	_, err := govalidator.ValidateStruct(userSignIn)
+	if err != nil {
+		return nil, myerrors.NewErrorBadContentRequest(err.Error())
+	}
+
+	return userSignIn, nil
 }
 
 // SignInHandler godoc
 //
 //	@Summary    signin
-//	@Description  signin in app
+//	@Description  signin in app. Credentials in query with get method are accepted only in development
 //	@Tags auth
+//	@Accept      json
 //	@Produce    json
-//	@Param      email  query string true  "user email for signin"
-//	@Param      password  query string true  "user password for signin"
+//	@Param      user  body models.UserSignIn true  "user data for signin"
 //	@Success    200  {object} responses.ResponseSuccessful
 //	@Failure    405  {string} string
 //	@Failure    500  {string} string
-//	@Failure    222  {object} responses.ErrorResponse "Error". Внутри body статус может быть badContent(4400), badFormat(4000)//nolint:lll
-//	@Router      /signin [get]
+//	@Failure    222  {object} responses.ErrorResponse "Error". Внутри body статус может быть badContent(4400), badFormat(4000), tooManyRequests(4429)//nolint:lll
+//	@Router      /signin [post]
 func (a *AuthHandler) SignInHandler(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
+	if !a.isAllowedMethodSignIn(r.Method) {
 		http.Error(w, `Method not allowed`, http.StatusMethodNotAllowed)
 
 		return
@@ -123,10 +190,18 @@ func (a *AuthHandler) SignInHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	logger := a.logger.LogReqID(ctx)
 
-	email := r.URL.Query().Get("email")
-	password := r.URL.Query().Get("password")
+	userSignIn, err := parseUserSignIn(r)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
 
-	userForLogin := auth.User{Email: email, Password: password}
+	userForLogin := auth.User{Email: userSignIn.Email, Password: userSignIn.Password}
+
+	if clientIP := utils.GetClientIP(r); clientIP != nil {
+		userForLogin.ClientIp = clientIP.String()
+	}
 
 	sessionWithToken, err := a.sessionManagerClient.Login(ctx, &userForLogin)
 	if err != nil {
@@ -136,17 +211,7 @@ func (a *AuthHandler) SignInHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	expire := time.Now().Add(timeTokenLife)
-
-	cookie := &http.Cookie{ //nolint:exhaustruct
-		Name:     responses.CookieAuthName,
-		Value:    sessionWithToken.GetAccessToken(),
-		SameSite: http.SameSiteLaxMode,
-		Expires:  expire,
-		Path:     "/",
-	}
-
-	http.SetCookie(w, cookie)
+	setSessionCookies(w, sessionWithToken)
 	responses.SendResponse(w, logger, responses.NewResponseSuccessful(ResponseSuccessfulSignIn))
 	logger.Infof("in SignInHandler: added user")
 }
@@ -154,7 +219,7 @@ func (a *AuthHandler) SignInHandler(w http.ResponseWriter, r *http.Request) {
 // LogOutHandler godoc
 //
 //	@Summary    logout
-//	@Description  logout in app
+//	@Description  logout in app by access or refresh token from cookie, both cookies are removed
 //	@Tags auth
 //	@Produce    json
 //	@Success    200  {object} responses.ResponseSuccessful
@@ -172,34 +237,77 @@ func (a *AuthHandler) LogOutHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	logger := a.logger.LogReqID(ctx)
 
-	cookie, err := r.Cookie(responses.CookieAuthName)
+	// cookies are removed in any case, so client isn't stuck with session that can't be ended
+	deleteSessionCookies(w)
+
+	sessionUser := &auth.Session{} //nolint:exhaustruct
+
+	if cookie, err := getCookieAuth(r); err == nil {
+		sessionUser.AccessToken = cookie.Value
+	}
+
+	if cookie, err := getCookieRefresh(r); err == nil {
+		sessionUser.RefreshToken = cookie.Value
+	}
+
+	if sessionUser.GetAccessToken() == "" && sessionUser.GetRefreshToken() == "" {
+		logger.Errorln(responses.ErrCookieNotPresented)
+		responses.HandleErr(w, r, logger, responses.ErrCookieNotPresented)
+
+		return
+	}
+
+	_, err := a.sessionManagerClient.Delete(ctx, sessionUser)
 	if err != nil {
 		logger.Errorln(err)
+		responses.HandleErr(w, r, logger, err)
 
-		if errors.Is(err, http.ErrNoCookie) {
-			err = responses.ErrCookieNotPresented
-		}
+		return
+	}
 
-		responses.HandleErr(w, r, logger, err)
+	responses.SendResponse(w, logger, responses.NewResponseSuccessful(ResponseSuccessfulLogOut))
+	logger.Infof("in LogOutHandler: logout user")
+}
+
+// RefreshHandler godoc
+//
+//	@Summary    refresh tokens
+//	@Description  exchange refresh token from cookie for new access and refresh tokens.
+//	@Description  Refresh token is single-use, its reuse finishes session
+//	@Tags auth
+//	@Produce    json
+//	@Success    200  {object} responses.ResponseSuccessful
+//	@Failure    405  {string} string
+//	@Failure    500  {string} string
+//	@Failure    222  {object} responses.ErrorResponse "Error". Внутри body статус может быть badContent(4400), badFormat(4000)//nolint:lll
+//	@Router      /refresh [post]
+func (a *AuthHandler) RefreshHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, `Method not allowed`, http.StatusMethodNotAllowed)
 
 		return
 	}
 
-	sessionUser := &auth.Session{
-		AccessToken: cookie.Value,
+	ctx := r.Context()
+	logger := a.logger.LogReqID(ctx)
+
+	cookie, err := getCookieRefresh(r)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
 	}
 
-	expiredSession, err := a.sessionManagerClient.Delete(ctx, sessionUser)
+	sessionWithToken, err := a.sessionManagerClient.Refresh(ctx, &auth.Session{RefreshToken: cookie.Value})
 	if err != nil {
 		logger.Errorln(err)
+		deleteRefreshCookie(w)
 		responses.HandleErr(w, r, logger, err)
 
 		return
 	}
 
-	cookie.Value = expiredSession.GetAccessToken()
-
-	http.SetCookie(w, cookie)
-	responses.SendResponse(w, logger, responses.NewResponseSuccessful(ResponseSuccessfulLogOut))
-	logger.Infof("in LogOutHandler: logout user with cookie: %+v", cookie)
+	setSessionCookies(w, sessionWithToken)
+	responses.SendResponse(w, logger, responses.NewResponseSuccessful(ResponseSuccessfulRefresh))
+	logger.Infof("in RefreshHandler: refreshed tokens")
 }
@@ -6,6 +6,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/user/delivery"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/auth"
@@ -20,12 +21,18 @@ import (
 
 func NewAuthHandler(ctrl *gomock.Controller,
 	behaviorSessionManagerClient func(m *mocks.MockSessionMangerClient),
+) (*delivery.AuthHandler, error) {
+	return NewAuthHandlerWithMode(ctrl, behaviorSessionManagerClient, true)
+}
+
+func NewAuthHandlerWithMode(ctrl *gomock.Controller,
+	behaviorSessionManagerClient func(m *mocks.MockSessionMangerClient), productionMode bool,
 ) (*delivery.AuthHandler, error) {
 	mockSessionManagerClient := mocks.NewMockSessionMangerClient(ctrl)
 
 	behaviorSessionManagerClient(mockSessionManagerClient)
 
-	authHandler, err := delivery.NewAuthHandler(mockSessionManagerClient)
+	authHandler, err := delivery.NewAuthHandler(mockSessionManagerClient, productionMode)
 	if err != nil {
 		return nil, fmt.Errorf("unexpected err=%w", err)
 	}
@@ -151,38 +158,69 @@ func TestSignIn(t *testing.T) {
 
 	type TestCase struct {
 		name                         string
+		productionMode               bool
 		behaviorSessionManagerClient func(m *mocks.MockSessionMangerClient)
 		request                      *http.Request
 		checkHeader                  func(recorder *httptest.ResponseRecorder) error
 		expectedResponse             any
 	}
 
+	checkCookie := func(recorder *httptest.ResponseRecorder) error {
+		cookieRaw := recorder.Header().Get("Set-Cookie")
+		if !strings.Contains(cookieRaw, test.AccessToken) {
+			return fmt.Errorf("cookie not contain jwt token. Cookie: %s", cookieRaw) //nolint
+		}
+
+		return nil
+	}
+
 	testCases := [...]TestCase{
 		{
-			name:    "test basic work",
-			request: httptest.NewRequest(http.MethodGet, "/api/v1/signin?email=ivn-tyt@mail.ru&password=strong", nil),
+			name:           "test basic work",
+			productionMode: true,
+			request: httptest.NewRequest(http.MethodPost, "/api/v1/signin", strings.NewReader(
+				`{"email":"ivn-tyt@mail.ru", "password": "strong"}`)),
 			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {
 				m.EXPECT().Login(gomock.Any(),
-					&auth.User{Email: "ivn-tyt@mail.ru", Password: "strong"}).Return(
+					&auth.User{Email: "ivn-tyt@mail.ru", Password: "strong", ClientIp: "192.0.2.1"}).Return(
 					&auth.Session{AccessToken: test.AccessToken}, nil)
 			},
-			checkHeader: func(recorder *httptest.ResponseRecorder) error {
-				cookieRaw := recorder.Header().Get("Set-Cookie")
-				if !strings.Contains(cookieRaw, test.AccessToken) {
-					return fmt.Errorf("cookie not contain jwt token. Cookie: %s", cookieRaw) //nolint
-				}
-
-				return nil
+			checkHeader:      checkCookie,
+			expectedResponse: responses.NewResponseSuccessful(delivery.ResponseSuccessfulSignIn),
+		},
+		{
+			name:           "test query in development",
+			productionMode: false,
+			request: httptest.NewRequest(http.MethodGet,
+				"/api/v1/signin?email=ivn-tyt@mail.ru&password=strong", nil),
+			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {
+				m.EXPECT().Login(gomock.Any(),
+					&auth.User{Email: "ivn-tyt@mail.ru", Password: "strong", ClientIp: "192.0.2.1"}).Return(
+					&auth.Session{AccessToken: test.AccessToken}, nil)
 			},
+			checkHeader:      checkCookie,
 			expectedResponse: responses.NewResponseSuccessful(delivery.ResponseSuccessfulSignIn),
 		},
 		{
-			name: "test internal error",
+			name:           "test query rejected in production",
+			productionMode: true,
 			request: httptest.NewRequest(http.MethodGet,
 				"/api/v1/signin?email=ivn-tyt@mail.ru&password=strong", nil),
+			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {},
+			checkHeader: func(recorder *httptest.ResponseRecorder) error {
+				return nil
+			},
+			expectedResponse: `Method not allowed
+`,
+		},
+		{
+			name:           "test internal error",
+			productionMode: true,
+			request: httptest.NewRequest(http.MethodPost, "/api/v1/signin", strings.NewReader(
+				`{"email":"ivn-tyt@mail.ru", "password": "strong"}`)),
 			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {
 				m.EXPECT().Login(gomock.Any(),
-					&auth.User{Email: "ivn-tyt@mail.ru", Password: "strong"}).Return(
+					&auth.User{Email: "ivn-tyt@mail.ru", Password: "strong", ClientIp: "192.0.2.1"}).Return(
 					nil, myerrors.NewErrorInternal("Test error"))
 			},
 			checkHeader: func(recorder *httptest.ResponseRecorder) error {
@@ -191,7 +229,45 @@ func TestSignIn(t *testing.T) {
 			expectedResponse: responses.NewErrResponse(statuses.StatusInternalServer, responses.ErrInternalServer),
 		},
 		{
-			name: "test wrong method",
+			name:           "test login locked",
+			productionMode: true,
+			request: httptest.NewRequest(http.MethodPost, "/api/v1/signin", strings.NewReader(
+				`{"email":"ivn-tyt@mail.ru", "password": "strong"}`)),
+			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {
+				m.EXPECT().Login(gomock.Any(),
+					&auth.User{Email: "ivn-tyt@mail.ru", Password: "strong", ClientIp: "192.0.2.1"}).Return(
+					nil, myerrors.NewErrorTooManyRequests("Слишком много неудачных попыток входа, повторите через 30 сек."))
+			},
+			checkHeader: func(recorder *httptest.ResponseRecorder) error {
+				return nil
+			},
+			expectedResponse: responses.NewErrResponse(statuses.StatusTooManyRequests,
+				"Слишком много неудачных попыток входа, повторите через 30 сек."),
+		},
+		{
+			name:                         "test wrong format",
+			productionMode:               true,
+			request:                      httptest.NewRequest(http.MethodPost, "/api/v1/signin", strings.NewReader(`{`)),
+			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {},
+			checkHeader: func(recorder *httptest.ResponseRecorder) error {
+				return nil
+			},
+			expectedResponse: responses.NewErrResponse(statuses.StatusBadFormatRequest, "unexpected EOF"),
+		},
+		{
+			name:           "test wrong content",
+			productionMode: true,
+			request: httptest.NewRequest(http.MethodPost, "/api/v1/signin", strings.NewReader(
+				`{"email":"wrong_email", "password":"strong"}`)),
+			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {},
+			checkHeader: func(recorder *httptest.ResponseRecorder) error {
+				return nil
+			},
+			expectedResponse: responses.NewErrResponse(statuses.StatusBadContentRequest, "Некорректный формат email"),
+		},
+		{
+			name:           "test wrong method",
+			productionMode: true,
 			request: httptest.NewRequest(http.MethodDelete,
 				"/api/v1/signin", nil),
 			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {},
@@ -212,7 +288,8 @@ func TestSignIn(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			authHandler, err := NewAuthHandler(ctrl, testCase.behaviorSessionManagerClient)
+			authHandler, err := NewAuthHandlerWithMode(ctrl,
+				testCase.behaviorSessionManagerClient, testCase.productionMode)
 			if err != nil {
 				t.Fatalf("Failed create authHandler %s", err.Error())
 			}
@@ -239,6 +316,8 @@ func TestLogOut(t *testing.T) {
 
 	_ = mylogger.NewNop()
 
+	const testRefreshToken = "test_refresh_token"
+
 	type TestCase struct {
 		name                         string
 		behaviorSessionManagerClient func(m *mocks.MockSessionMangerClient)
@@ -247,28 +326,57 @@ func TestLogOut(t *testing.T) {
 		expectedResponse             any
 	}
 
+	refreshCookie := http.Cookie{ //nolint:exhaustruct
+		Name:  responses.CookieRefreshName,
+		Value: testRefreshToken,
+	}
+
+	checkCookiesDeleted := func(recorder *httptest.ResponseRecorder) error {
+		cookies := recorder.Result().Cookies()
+		if len(cookies) != 2 { //nolint:gomnd
+			return fmt.Errorf("expected removal of two cookies, got %+v", cookies) //nolint
+		}
+
+		for _, cookie := range cookies {
+			if cookie.Value != "" || cookie.Expires.After(time.Unix(0, 0)) {
+				return fmt.Errorf("cookie not removed: %+v", cookie) //nolint
+			}
+		}
+
+		return nil
+	}
+
 	testCases := [...]TestCase{
 		{
 			name: "test basic work",
 			request: func() *http.Request {
 				req := httptest.NewRequest(http.MethodPost, "/api/v1/logout", nil)
 				req.AddCookie(&test.Cookie)
+				req.AddCookie(&refreshCookie)
 
 				return req
 			}(),
 			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {
 				m.EXPECT().Delete(gomock.Any(),
-					&auth.Session{AccessToken: test.AccessToken}).Return(
+					&auth.Session{AccessToken: test.AccessToken, RefreshToken: testRefreshToken}).Return(
 					&auth.Session{AccessToken: "jwt_test_token"}, nil)
 			},
-			checkHeader: func(recorder *httptest.ResponseRecorder) error {
-				cookieRaw := recorder.Header().Get("Set-Cookie")
-				if !strings.Contains(cookieRaw, "jwt_test_token") {
-					return fmt.Errorf("cookie not contain jwt token. Cookie: %s", cookieRaw) //nolint
-				}
+			checkHeader:      checkCookiesDeleted,
+			expectedResponse: responses.NewResponseSuccessful(delivery.ResponseSuccessfulLogOut),
+		},
+		{
+			name: "test only refresh cookie",
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/api/v1/logout", nil)
+				req.AddCookie(&refreshCookie)
 
-				return nil
+				return req
+			}(),
+			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {
+				m.EXPECT().Delete(gomock.Any(),
+					&auth.Session{RefreshToken: testRefreshToken}).Return(&auth.Session{}, nil)
 			},
+			checkHeader:      checkCookiesDeleted,
 			expectedResponse: responses.NewResponseSuccessful(delivery.ResponseSuccessfulLogOut),
 		},
 		{
@@ -284,18 +392,14 @@ func TestLogOut(t *testing.T) {
 					&auth.Session{AccessToken: test.AccessToken}).Return(nil,
 					myerrors.NewErrorInternal("Test error"))
 			},
-			checkHeader: func(recorder *httptest.ResponseRecorder) error {
-				return nil
-			},
+			checkHeader:      checkCookiesDeleted,
 			expectedResponse: responses.NewErrResponse(statuses.StatusInternalServer, responses.ErrInternalServer),
 		},
 		{
 			name:                         "test cookie not presented",
 			request:                      httptest.NewRequest(http.MethodPost, "/api/v1/logout", nil),
 			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {},
-			checkHeader: func(recorder *httptest.ResponseRecorder) error {
-				return nil
-			},
+			checkHeader:                  checkCookiesDeleted,
 			expectedResponse: responses.NewErrResponse(
 				responses.ErrCookieNotPresented.Status(), responses.ErrCookieNotPresented.Error()),
 		},
@@ -341,3 +445,116 @@ func TestLogOut(t *testing.T) {
 		})
 	}
 }
+
+func TestRefresh(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	const (
+		testRefreshToken    = "test_refresh_token"
+		testNewRefreshToken = "test_new_refresh_token"
+	)
+
+	refreshCookie := http.Cookie{ //nolint:exhaustruct
+		Name:  responses.CookieRefreshName,
+		Value: testRefreshToken,
+	}
+
+	type TestCase struct {
+		name                         string
+		behaviorSessionManagerClient func(m *mocks.MockSessionMangerClient)
+		request                      *http.Request
+		expectedCookies              map[string]string
+		expectedResponse             any
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/api/v1/refresh", nil)
+				req.AddCookie(&refreshCookie)
+
+				return req
+			}(),
+			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {
+				m.EXPECT().Refresh(gomock.Any(), &auth.Session{RefreshToken: testRefreshToken}).Return(
+					&auth.Session{AccessToken: test.AccessToken, RefreshToken: testNewRefreshToken}, nil)
+			},
+			expectedCookies: map[string]string{
+				responses.CookieAuthName:    test.AccessToken,
+				responses.CookieRefreshName: testNewRefreshToken,
+			},
+			expectedResponse: responses.NewResponseSuccessful(delivery.ResponseSuccessfulRefresh),
+		},
+		{
+			name: "test reused refresh token",
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/api/v1/refresh", nil)
+				req.AddCookie(&refreshCookie)
+
+				return req
+			}(),
+			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {
+				m.EXPECT().Refresh(gomock.Any(), &auth.Session{RefreshToken: testRefreshToken}).Return(
+					nil, myerrors.NewErrorBadContentRequest("Refresh токен уже был использован, сессия завершена"))
+			},
+			expectedCookies: map[string]string{responses.CookieRefreshName: ""},
+			expectedResponse: responses.NewErrResponse(statuses.StatusBadContentRequest,
+				"Refresh токен уже был использован, сессия завершена"),
+		},
+		{
+			name:                         "test cookie not presented",
+			request:                      httptest.NewRequest(http.MethodPost, "/api/v1/refresh", nil),
+			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {},
+			expectedCookies:              map[string]string{},
+			expectedResponse: responses.NewErrResponse(
+				responses.ErrCookieNotPresented.Status(), responses.ErrCookieNotPresented.Error()),
+		},
+		{
+			name:                         "test wrong method",
+			request:                      httptest.NewRequest(http.MethodGet, "/api/v1/refresh", nil),
+			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {},
+			expectedCookies:              map[string]string{},
+			expectedResponse: `Method not allowed
+`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			authHandler, err := NewAuthHandler(ctrl, testCase.behaviorSessionManagerClient)
+			if err != nil {
+				t.Fatalf("Failed create authHandler %s", err.Error())
+			}
+
+			recorder := httptest.NewRecorder()
+
+			authHandler.RefreshHandler(recorder, testCase.request)
+
+			err = test.CompareHTTPTestResult(recorder, testCase.expectedResponse)
+			if err != nil {
+				t.Fatalf("Failed CompareHTTPTestResult %+v", err)
+			}
+
+			cookies := recorder.Result().Cookies()
+			if len(cookies) != len(testCase.expectedCookies) {
+				t.Fatalf("cookies: got %+v, expected %+v", cookies, testCase.expectedCookies)
+			}
+
+			for _, cookie := range cookies {
+				if value, ok := testCase.expectedCookies[cookie.Name]; !ok || value != cookie.Value {
+					t.Fatalf("cookie: got %+v, expected %+v", cookie, testCase.expectedCookies)
+				}
+			}
+		})
+	}
+}
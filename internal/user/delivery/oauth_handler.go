@@ -0,0 +1,138 @@
+package delivery
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/auth"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+)
+
+const (
+	CookieOAuthStateName        = "oauth_state"
+	CookieOAuthCodeVerifierName = "oauth_code_verifier"
+
+	timeOAuthCookieLife = 10 * time.Minute
+)
+
+var ErrWrongOAuthState = myerrors.NewErrorBadContentRequest("Вход через внешний сервис устарел, начните заново")
+
+// setOAuthCookies state binds login to browser, which started it,
+// code verifier is needed for exchange of code. Both aren't needed for js.
+func setOAuthCookies(w http.ResponseWriter, state string, codeVerifier string, maxAge int) {
+	for name, value := range map[string]string{
+		CookieOAuthStateName:        state,
+		CookieOAuthCodeVerifierName: codeVerifier,
+	} {
+		http.SetCookie(w, &http.Cookie{ //nolint:exhaustruct
+			Name:     name,
+			Value:    value,
+			SameSite: http.SameSiteLaxMode,
+			MaxAge:   maxAge,
+			Path:     "/",
+			HttpOnly: true,
+		})
+	}
+}
+
+// OAuthURLHandler godoc
+//
+//	@Summary    get link of login by external service
+//	@Description  get link to login page of provider, e.g. vk. Provider redirects user back to frontend with code and state
+//	@Tags auth
+//	@Produce    json
+//	@Param      provider  query string true  "name of provider"
+//	@Success    200  {object} responses.ResponseRedirect
+//	@Failure    405  {string} string
+//	@Failure    500  {string} string
+//	@Failure    222  {object} responses.ErrorResponse "Error". Внутри body статус может быть badContent(4400), badFormat(4000)//nolint:lll
+//	@Router      /oauth/url [get]
+func (a *AuthHandler) OAuthURLHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, `Method not allowed`, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	ctx := r.Context()
+	logger := a.logger.LogReqID(ctx)
+
+	provider := utils.ParseStringFromRequest(r, "provider")
+
+	oauthURL, err := a.sessionManagerClient.GetOAuthURL(ctx, &auth.OAuthProvider{Provider: provider})
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	setOAuthCookies(w, oauthURL.GetState(), oauthURL.GetCodeVerifier(), int(timeOAuthCookieLife.Seconds()))
+	responses.SendResponse(w, logger, responses.NewResponseRedirect(oauthURL.GetUrl()))
+	logger.Infof("in OAuthURLHandler: got url of %s", provider)
+}
+
+// OAuthLoginHandler godoc
+//
+//	@Summary    signin by external service
+//	@Description  signin by code from redirect of provider. Account is linked by email, which is verified by provider
+//	@Tags auth
+//	@Accept      json
+//	@Produce    json
+//	@Param      login  body models.OAuthLogin true  "provider and query params from redirect"
+//	@Success    200  {object} responses.ResponseSuccessful
+//	@Failure    405  {string} string
+//	@Failure    500  {string} string
+//	@Failure    222  {object} responses.ErrorResponse "Error". Внутри body статус может быть badContent(4400), badFormat(4000)//nolint:lll
+//	@Router      /oauth/login [post]
+func (a *AuthHandler) OAuthLoginHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, `Method not allowed`, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	ctx := r.Context()
+	logger := a.logger.LogReqID(ctx)
+
+	oauthLogin := new(models.OAuthLogin)
+
+	err := decodeAndValidate(r, oauthLogin)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	cookieState, errState := r.Cookie(CookieOAuthStateName)
+	cookieCodeVerifier, errCodeVerifier := r.Cookie(CookieOAuthCodeVerifierName)
+
+	if errState != nil || errCodeVerifier != nil ||
+		subtle.ConstantTimeCompare([]byte(cookieState.Value), []byte(oauthLogin.State)) != 1 {
+		responses.HandleErr(w, r, logger, ErrWrongOAuthState)
+
+		return
+	}
+
+	// state is single-use
+	setOAuthCookies(w, "", "", -1)
+
+	sessionWithToken, err := a.sessionManagerClient.LoginOAuth(ctx, &auth.OAuthCode{
+		Provider:     oauthLogin.Provider,
+		Code:         oauthLogin.Code,
+		CodeVerifier: cookieCodeVerifier.Value,
+		DeviceId:     oauthLogin.DeviceID,
+	})
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	setSessionCookies(w, sessionWithToken)
+	responses.SendResponse(w, logger, responses.NewResponseSuccessful(ResponseSuccessfulSignIn))
+	logger.Infof("in OAuthLoginHandler: signed in by %s", oauthLogin.Provider)
+}
@@ -0,0 +1,143 @@
+package delivery_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/user/delivery"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/auth"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/auth/mocks"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses/statuses"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils/test"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOAuthURL(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	authHandler, err := NewAuthHandler(ctrl, func(m *mocks.MockSessionMangerClient) {
+		m.EXPECT().GetOAuthURL(gomock.Any(), &auth.OAuthProvider{Provider: "vk"}).Return(&auth.OAuthURL{
+			Url:          "https://id.vk.com/authorize?state=test_state",
+			State:        "test_state",
+			CodeVerifier: "test_verifier",
+		}, nil)
+	})
+	if err != nil {
+		t.Fatalf("Failed create authHandler %s", err.Error())
+	}
+
+	recorder := httptest.NewRecorder()
+
+	authHandler.OAuthURLHandler(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/oauth/url?provider=vk", nil))
+
+	err = test.CompareHTTPTestResult(recorder,
+		responses.NewResponseRedirect("https://id.vk.com/authorize?state=test_state"))
+	if err != nil {
+		t.Fatalf("Failed CompareHTTPTestResult %+v", err)
+	}
+
+	cookies := make(map[string]string)
+	for _, cookie := range recorder.Result().Cookies() {
+		cookies[cookie.Name] = cookie.Value
+	}
+
+	if cookies[delivery.CookieOAuthStateName] != "test_state" ||
+		cookies[delivery.CookieOAuthCodeVerifierName] != "test_verifier" {
+		t.Fatalf("wrong oauth cookies %+v", cookies)
+	}
+}
+
+func TestOAuthLogin(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	newRequest := func(body string, state string) *http.Request {
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/oauth/login", strings.NewReader(body))
+
+		if state != "" {
+			request.AddCookie(&http.Cookie{Name: delivery.CookieOAuthStateName, Value: state})
+			request.AddCookie(&http.Cookie{Name: delivery.CookieOAuthCodeVerifierName, Value: "test_verifier"})
+		}
+
+		return request
+	}
+
+	type TestCase struct {
+		name                         string
+		behaviorSessionManagerClient func(m *mocks.MockSessionMangerClient)
+		request                      *http.Request
+		expectedResponse             any
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			request: newRequest(
+				`{"provider":"vk", "code":"test_code", "state":"test_state", "device_id":"test_device"}`, "test_state"),
+			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {
+				m.EXPECT().LoginOAuth(gomock.Any(), &auth.OAuthCode{
+					Provider: "vk", Code: "test_code", CodeVerifier: "test_verifier", DeviceId: "test_device",
+				}).Return(&auth.Session{AccessToken: test.AccessToken, RefreshToken: "refresh"}, nil)
+			},
+			expectedResponse: responses.NewResponseSuccessful(delivery.ResponseSuccessfulSignIn),
+		},
+		{
+			name: "test wrong state",
+			request: newRequest(
+				`{"provider":"vk", "code":"test_code", "state":"other_state"}`, "test_state"),
+			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {},
+			expectedResponse: responses.NewErrResponse(statuses.StatusBadContentRequest,
+				delivery.ErrWrongOAuthState.Error()),
+		},
+		{
+			name: "test without cookies",
+			request: newRequest(
+				`{"provider":"vk", "code":"test_code", "state":"test_state"}`, ""),
+			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {},
+			expectedResponse: responses.NewErrResponse(statuses.StatusBadContentRequest,
+				delivery.ErrWrongOAuthState.Error()),
+		},
+		{
+			name:                         "test empty code",
+			request:                      newRequest(`{"provider":"vk", "state":"test_state"}`, "test_state"),
+			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {},
+			expectedResponse: responses.NewErrResponse(statuses.StatusBadContentRequest,
+				"Код не может быть пустым"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			authHandler, err := NewAuthHandler(ctrl, testCase.behaviorSessionManagerClient)
+			if err != nil {
+				t.Fatalf("Failed create authHandler %s", err.Error())
+			}
+
+			recorder := httptest.NewRecorder()
+
+			authHandler.OAuthLoginHandler(recorder, testCase.request)
+
+			err = test.CompareHTTPTestResult(recorder, testCase.expectedResponse)
+			if err != nil {
+				t.Fatalf("Failed CompareHTTPTestResult %+v", err)
+			}
+		})
+	}
+}
@@ -18,7 +18,9 @@ var _ IUserService = (*userusecases.UserService)(nil)
 
 type IUserService interface {
 	GetUserWithoutPasswordByID(ctx context.Context, userID uint64) (*models.UserWithoutPassword, error)
-	UpdateUser(ctx context.Context, r io.Reader, isPartialUpdate bool, userID uint64) (*models.UserWithoutPassword, error)
+	UpdateUser(ctx context.Context, r io.Reader, isPartialUpdate bool,
+		userID uint64) (*models.UserWithoutPassword, bool, error)
+	DeleteUser(ctx context.Context, userID uint64) error
 }
 
 type ProfileHandler struct {
@@ -87,7 +89,7 @@ func (u *ProfileHandler) GetUserHandler(w http.ResponseWriter, r *http.Request)
 // PartiallyUpdateUserHandler godoc
 //
 //	@Summary    update profile
-//	@Description  update some fields of profile
+//	@Description  update some fields of profile, changed email becomes not verified and letter for its verification is sent
 //
 // @Tags profile
 //
@@ -119,11 +121,15 @@ func (u *ProfileHandler) PartiallyUpdateUserHandler(w http.ResponseWriter, r *ht
 		return
 	}
 
-	var updatedUser *models.UserWithoutPassword
+	var (
+		updatedUser  *models.UserWithoutPassword
+		emailChanged bool
+	)
+
 	if r.Method == http.MethodPatch {
-		updatedUser, err = u.service.UpdateUser(ctx, r.Body, true, userID)
+		updatedUser, emailChanged, err = u.service.UpdateUser(ctx, r.Body, true, userID)
 	} else {
-		updatedUser, err = u.service.UpdateUser(ctx, r.Body, false, userID)
+		updatedUser, emailChanged, err = u.service.UpdateUser(ctx, r.Body, false, userID)
 	}
 
 	if err != nil {
@@ -133,6 +139,168 @@ func (u *ProfileHandler) PartiallyUpdateUserHandler(w http.ResponseWriter, r *ht
 		return
 	}
 
+	if emailChanged {
+		u.sendVerifyEmail(ctx, r)
+	}
+
 	responses.SendResponse(w, logger, NewProfileResponse(updatedUser))
 	logger.Infof("Successfully updated: %+v", userID)
 }
+
+// sendVerifyEmail sends letter for verification of new email. Profile is already updated,
+// so error is only logged, user can request letter again.
+func (u *ProfileHandler) sendVerifyEmail(ctx context.Context, r *http.Request) {
+	logger := u.logger.LogReqID(ctx)
+
+	cookie, err := getCookieAuth(r)
+	if err != nil {
+		logger.Errorln(err)
+
+		return
+	}
+
+	_, err = u.sessionManagerClient.SendVerifyEmail(ctx, &auth.Session{AccessToken: cookie.Value})
+	if err != nil {
+		logger.Errorf("failed send letter for verification of new email: %+v", err)
+	}
+}
+
+// ChangePasswordHandler godoc
+//
+//	@Summary    change password
+//	@Description  change password by current password, other sessions of user are revoked
+//
+// @Tags profile
+//
+//	@Accept      json
+//	@Produce    json
+//	@Param      password  body models.PasswordChange true  "current and new password"
+//	@Success    200  {object} responses.ResponseSuccessful
+//	@Failure    405  {string} string
+//	@Failure    500  {string} string
+//	@Failure    222  {object} responses.ErrorResponse "Error". Внутри body статус может быть badContent(4400), badFormat(4000)
+//	@Router      /profile/change_password [post]
+func (u *ProfileHandler) ChangePasswordHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, `Method not allowed`, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	ctx := r.Context()
+	logger := u.logger.LogReqID(ctx)
+
+	cookie, err := getCookieAuth(r)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	passwordChange := new(models.PasswordChange)
+
+	err = decodeAndValidate(r, passwordChange)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	passwordChangeGrpc := &auth.PasswordChange{ //nolint:exhaustruct
+		Session:     &auth.Session{AccessToken: cookie.Value},
+		OldPassword: passwordChange.OldPassword,
+		NewPassword: passwordChange.NewPassword,
+	}
+
+	if clientIP := utils.GetClientIP(r); clientIP != nil {
+		passwordChangeGrpc.ClientIp = clientIP.String()
+	}
+
+	_, err = u.sessionManagerClient.ChangePassword(ctx, passwordChangeGrpc)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	responses.SendResponse(w, logger, responses.NewResponseSuccessful(ResponseSuccessfulChangePassword))
+	logger.Infof("in ChangePasswordHandler: changed password")
+}
+
+// DeleteUserHandler godoc
+//
+//	@Summary    delete profile
+//	@Description  delete profile of current user: personal data is anonymised, products are deactivated,
+//	@Description  open orders as buyer and seller are cancelled or refunded, completed orders are kept,
+//	@Description  all sessions are revoked. Current password is required
+//
+// @Tags profile
+//
+//	@Accept      json
+//	@Produce    json
+//	@Param      password  body models.PasswordConfirm true  "current password"
+//	@Success    200  {object} responses.ResponseSuccessful
+//	@Failure    405  {string} string
+//	@Failure    500  {string} string
+//	@Failure    222  {object} responses.ErrorResponse "Error". Внутри body статус может быть badContent(4400), badFormat(4000)
+//	@Router      /profile/delete [delete]
+func (u *ProfileHandler) DeleteUserHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, `Method not allowed`, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	ctx := r.Context()
+	logger := u.logger.LogReqID(ctx)
+
+	userID, err := delivery.GetUserID(ctx, r, u.sessionManagerClient)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	cookie, err := getCookieAuth(r)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	passwordConfirm := new(models.PasswordConfirm)
+
+	err = decodeAndValidate(r, passwordConfirm)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	passwordCheck := &auth.PasswordCheck{ //nolint:exhaustruct
+		Session:  &auth.Session{AccessToken: cookie.Value},
+		Password: passwordConfirm.Password,
+	}
+
+	if clientIP := utils.GetClientIP(r); clientIP != nil {
+		passwordCheck.ClientIp = clientIP.String()
+	}
+
+	_, err = u.sessionManagerClient.CheckPassword(ctx, passwordCheck)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	err = u.service.DeleteUser(ctx, userID)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	deleteSessionCookies(w)
+	responses.SendResponse(w, logger, responses.NewResponseSuccessful(ResponseSuccessfulDeleteUser))
+	logger.Infof("in DeleteUserHandler: deleted user id=%d", userID)
+}
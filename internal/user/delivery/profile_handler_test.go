@@ -149,11 +149,13 @@ func TestPartiallyUpdateUser(t *testing.T) {
 					&models.UserWithoutPassword{ //nolint:exhaustruct
 						ID:    test.UserID,
 						Email: "new_email@mail.ru", Name: sql.NullString{Valid: true, String: "test_name"},
-					}, nil)
+					}, true, nil)
 			},
 			behaviorSessionManagerClient: func(m *mocksauth.MockSessionMangerClient) {
 				m.EXPECT().Check(gomock.Any(), &auth.Session{AccessToken: test.AccessToken}).Return(
 					&auth.UserID{UserId: test.UserID}, nil)
+				m.EXPECT().SendVerifyEmail(gomock.Any(), &auth.Session{AccessToken: test.AccessToken}).Return(
+					&auth.Nothing{}, nil)
 			},
 			expectedResponse: delivery.NewProfileResponse(&models.UserWithoutPassword{ //nolint:exhaustruct
 				ID:    test.UserID,
@@ -203,7 +205,7 @@ func TestPartiallyUpdateUser(t *testing.T) {
 			behaviorUserService: func(m *mocks.MockIUserService) {
 				m.EXPECT().UpdateUser(gomock.Any(), io.NopCloser(strings.NewReader(
 					`{"email":"new_email@mail.ru, "name":"test"}`)), false, test.UserID).Return(
-					nil, myerrors.NewErrorInternal("Test error"))
+					nil, false, myerrors.NewErrorInternal("Test error"))
 			},
 			behaviorSessionManagerClient: func(m *mocksauth.MockSessionMangerClient) {
 				m.EXPECT().Check(gomock.Any(), &auth.Session{AccessToken: test.AccessToken}).Return(
@@ -238,3 +240,200 @@ func TestPartiallyUpdateUser(t *testing.T) {
 		})
 	}
 }
+
+func TestChangePassword(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	type TestCase struct {
+		name                         string
+		behaviorSessionManagerClient func(m *mocksauth.MockSessionMangerClient)
+		request                      *http.Request
+		expectedResponse             any
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/api/v1/profile/change_password", strings.NewReader(
+					`{"old_password":"strong", "new_password":"stronger"}`))
+				req.AddCookie(&test.Cookie)
+
+				return req
+			}(),
+			behaviorSessionManagerClient: func(m *mocksauth.MockSessionMangerClient) {
+				m.EXPECT().ChangePassword(gomock.Any(), &auth.PasswordChange{
+					Session:     &auth.Session{AccessToken: test.AccessToken},
+					OldPassword: "strong",
+					NewPassword: "stronger",
+
+					ClientIp: "192.0.2.1",
+				}).Return(&auth.Nothing{}, nil)
+			},
+			expectedResponse: responses.NewResponseSuccessful(delivery.ResponseSuccessfulChangePassword),
+		},
+		{
+			name: "test wrong old password",
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/api/v1/profile/change_password", strings.NewReader(
+					`{"old_password":"wrong", "new_password":"stronger"}`))
+				req.AddCookie(&test.Cookie)
+
+				return req
+			}(),
+			behaviorSessionManagerClient: func(m *mocksauth.MockSessionMangerClient) {
+				m.EXPECT().ChangePassword(gomock.Any(), &auth.PasswordChange{
+					Session:     &auth.Session{AccessToken: test.AccessToken},
+					OldPassword: "wrong",
+					NewPassword: "stronger",
+
+					ClientIp: "192.0.2.1",
+				}).Return(nil, myerrors.NewErrorBadContentRequest("Неверный текущий пароль"))
+			},
+			expectedResponse: responses.NewErrResponse(statuses.StatusBadContentRequest, "Неверный текущий пароль"),
+		},
+		{
+			name: "test short new password",
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/api/v1/profile/change_password", strings.NewReader(
+					`{"old_password":"strong", "new_password":"weak"}`))
+				req.AddCookie(&test.Cookie)
+
+				return req
+			}(),
+			behaviorSessionManagerClient: func(m *mocksauth.MockSessionMangerClient) {},
+			expectedResponse: responses.NewErrResponse(statuses.StatusBadContentRequest,
+				"Пароль должен быть минимум 6 символов"),
+		},
+		{
+			name: "test cookie not presented",
+			request: httptest.NewRequest(http.MethodPost, "/api/v1/profile/change_password", strings.NewReader(
+				`{"old_password":"strong", "new_password":"stronger"}`)),
+			behaviorSessionManagerClient: func(m *mocksauth.MockSessionMangerClient) {},
+			expectedResponse: responses.NewErrResponse(
+				responses.ErrCookieNotPresented.Status(), responses.ErrCookieNotPresented.Error()),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			profileHandler, err := NewProfileHandler(ctrl, func(m *mocks.MockIUserService) {},
+				testCase.behaviorSessionManagerClient)
+			if err != nil {
+				t.Fatalf("Failed create profileHandler %s", err.Error())
+			}
+
+			w := httptest.NewRecorder()
+
+			profileHandler.ChangePasswordHandler(w, testCase.request)
+
+			err = test.CompareHTTPTestResult(w, testCase.expectedResponse)
+			if err != nil {
+				t.Fatalf("Failed CompareHTTPTestResult %+v", err)
+			}
+		})
+	}
+}
+
+func TestDeleteUser(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	type TestCase struct {
+		name                         string
+		body                         string
+		behaviorUserService          func(m *mocks.MockIUserService)
+		behaviorSessionManagerClient func(m *mocksauth.MockSessionMangerClient)
+		expectedResponse             any
+		expectedCountCookies         int
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			body: `{"password":"strong"}`,
+			behaviorUserService: func(m *mocks.MockIUserService) {
+				m.EXPECT().DeleteUser(gomock.Any(), test.UserID).Return(nil)
+			},
+			behaviorSessionManagerClient: func(m *mocksauth.MockSessionMangerClient) {
+				m.EXPECT().Check(gomock.Any(), &auth.Session{AccessToken: test.AccessToken}).Return(
+					&auth.UserID{UserId: test.UserID}, nil)
+				m.EXPECT().CheckPassword(gomock.Any(), &auth.PasswordCheck{
+					Session:  &auth.Session{AccessToken: test.AccessToken},
+					Password: "strong",
+					ClientIp: "192.0.2.1",
+				}).Return(&auth.Nothing{}, nil)
+			},
+			expectedResponse: responses.NewResponseSuccessful(delivery.ResponseSuccessfulDeleteUser),
+			// all sessions are revoked, so cookies of access and refresh tokens are removed
+			expectedCountCookies: 2,
+		},
+		{
+			name:                "test wrong password",
+			body:                `{"password":"wrong"}`,
+			behaviorUserService: func(m *mocks.MockIUserService) {},
+			behaviorSessionManagerClient: func(m *mocksauth.MockSessionMangerClient) {
+				m.EXPECT().Check(gomock.Any(), &auth.Session{AccessToken: test.AccessToken}).Return(
+					&auth.UserID{UserId: test.UserID}, nil)
+				m.EXPECT().CheckPassword(gomock.Any(), gomock.Any()).Return(
+					nil, myerrors.NewErrorBadContentRequest("Неверный текущий пароль"))
+			},
+			expectedResponse:     responses.NewErrResponse(statuses.StatusBadContentRequest, "Неверный текущий пароль"),
+			expectedCountCookies: 0,
+		},
+		{
+			name:                "test empty password",
+			body:                `{"password":""}`,
+			behaviorUserService: func(m *mocks.MockIUserService) {},
+			behaviorSessionManagerClient: func(m *mocksauth.MockSessionMangerClient) {
+				m.EXPECT().Check(gomock.Any(), &auth.Session{AccessToken: test.AccessToken}).Return(
+					&auth.UserID{UserId: test.UserID}, nil)
+			},
+			expectedResponse: responses.NewErrResponse(statuses.StatusBadContentRequest,
+				"Текущий пароль не может быть пустым"),
+			expectedCountCookies: 0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			profileHandler, err := NewProfileHandler(ctrl, testCase.behaviorUserService,
+				testCase.behaviorSessionManagerClient)
+			if err != nil {
+				t.Fatalf("Failed create profileHandler %s", err.Error())
+			}
+
+			w := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/profile/delete", strings.NewReader(testCase.body))
+			req.AddCookie(&test.Cookie)
+			profileHandler.DeleteUserHandler(w, req)
+
+			err = test.CompareHTTPTestResult(w, testCase.expectedResponse)
+			if err != nil {
+				t.Fatalf("Failed CompareHTTPTestResult %+v", err)
+			}
+
+			if cookies := w.Result().Cookies(); len(cookies) != testCase.expectedCountCookies {
+				t.Fatalf("got cookies %+v, expected %d", cookies, testCase.expectedCountCookies)
+			}
+		})
+	}
+}
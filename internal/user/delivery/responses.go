@@ -1,6 +1,9 @@
 package delivery
 
 import (
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/auth"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses/statuses"
 )
@@ -8,9 +11,20 @@ import (
 const (
 	StatusUnauthorized = 401
 
-	ResponseSuccessfulSignUp = "Successful sign up"
-	ResponseSuccessfulSignIn = "Successful sign in"
-	ResponseSuccessfulLogOut = "Successful log out"
+	ResponseSuccessfulSignUp  = "Successful sign up"
+	ResponseSuccessfulSignIn  = "Successful sign in"
+	ResponseSuccessfulLogOut  = "Successful log out"
+	ResponseSuccessfulRefresh = "Successful refresh"
+
+	ResponseSuccessfulRevokeSession     = "Сессия завершена"
+	ResponseSuccessfulRevokeAllSessions = "Все сессии завершены"
+
+	ResponseSuccessfulSendVerifyEmail      = "Письмо для подтверждения email отправлено"
+	ResponseSuccessfulVerifyEmail          = "Email подтверждён"
+	ResponseSuccessfulRequestPasswordReset = "Если email зарегистрирован, на него отправлено письмо для смены пароля"
+	ResponseSuccessfulResetPassword        = "Пароль изменён, войдите заново"
+	ResponseSuccessfulChangePassword       = "Пароль изменён, остальные сессии завершены"
+	ResponseSuccessfulDeleteUser           = "Аккаунт удалён"
 
 	ErrUnauthorized = "Вы не авторизованны"
 )
@@ -27,3 +41,35 @@ func NewProfileResponse(body *models.UserWithoutPassword) *ProfileResponse {
 		Body:   body,
 	}
 }
+
+//easyjson:json
+type SessionResponse struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	Expire    time.Time `json:"expire"`
+	Current   bool      `json:"current"`
+}
+
+//easyjson:json
+type SessionListResponse struct {
+	Status int                `json:"status"`
+	Body   []*SessionResponse `json:"body"`
+}
+
+func NewSessionListResponse(sessionList *auth.SessionList) *SessionListResponse {
+	body := make([]*SessionResponse, 0, len(sessionList.GetSessions()))
+
+	for _, session := range sessionList.GetSessions() {
+		body = append(body, &SessionResponse{
+			ID:        session.GetSessionId(),
+			CreatedAt: time.Unix(session.GetCreatedAt(), 0),
+			Expire:    time.Unix(session.GetExpire(), 0),
+			Current:   session.GetCurrent(),
+		})
+	}
+
+	return &SessionListResponse{
+		Status: statuses.StatusResponseSuccessful,
+		Body:   body,
+	}
+}
@@ -0,0 +1,168 @@
+package delivery
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/auth"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+)
+
+var ErrEmptySessionID = myerrors.NewErrorBadFormatRequest("Не передан session_id")
+
+func getCookieAuth(r *http.Request) (*http.Cookie, error) {
+	cookie, err := r.Cookie(responses.CookieAuthName)
+	if err != nil {
+		if errors.Is(err, http.ErrNoCookie) {
+			return nil, responses.ErrCookieNotPresented
+		}
+
+		return nil, err //nolint:wrapcheck
+	}
+
+	return cookie, nil
+}
+
+func getCookieRefresh(r *http.Request) (*http.Cookie, error) {
+	cookie, err := r.Cookie(responses.CookieRefreshName)
+	if err != nil {
+		if errors.Is(err, http.ErrNoCookie) {
+			return nil, responses.ErrCookieNotPresented
+		}
+
+		return nil, err //nolint:wrapcheck
+	}
+
+	return cookie, nil
+}
+
+// GetSessionsHandler godoc
+//
+//	@Summary    get active sessions
+//	@Description  get active sessions of user, current is session of this request
+//	@Tags auth
+//	@Produce    json
+//	@Success    200  {object} SessionListResponse
+//	@Failure    405  {string} string
+//	@Failure    500  {string} string
+//	@Failure    222  {object} responses.ErrorResponse "Error". Внутри body статус может быть badContent(4400), badFormat(4000)//nolint:lll
+//	@Router      /session/list [get]
+func (a *AuthHandler) GetSessionsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, `Method not allowed`, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	ctx := r.Context()
+	logger := a.logger.LogReqID(ctx)
+
+	cookie, err := getCookieAuth(r)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	sessionList, err := a.sessionManagerClient.ListSessions(ctx, &auth.Session{AccessToken: cookie.Value})
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	responses.SendResponse(w, logger, NewSessionListResponse(sessionList))
+	logger.Infof("in GetSessionsHandler: got %d sessions", len(sessionList.GetSessions()))
+}
+
+// RevokeSessionHandler godoc
+//
+//	@Summary    revoke session
+//	@Description  revoke one of active sessions of user, e.g. on lost device
+//	@Tags auth
+//	@Produce    json
+//	@Param      session_id  query string true  "id of session"
+//	@Success    200  {object} responses.ResponseSuccessful
+//	@Failure    405  {string} string
+//	@Failure    500  {string} string
+//	@Failure    222  {object} responses.ErrorResponse "Error". Внутри body статус может быть badContent(4400), badFormat(4000)//nolint:lll
+//	@Router      /session/revoke [post]
+func (a *AuthHandler) RevokeSessionHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, `Method not allowed`, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	ctx := r.Context()
+	logger := a.logger.LogReqID(ctx)
+
+	cookie, err := getCookieAuth(r)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	sessionID := utils.ParseStringFromRequest(r, "session_id")
+	if sessionID == "" {
+		responses.HandleErr(w, r, logger, ErrEmptySessionID)
+
+		return
+	}
+
+	_, err = a.sessionManagerClient.RevokeSession(ctx, &auth.RevokeSessionRequest{
+		Session:   &auth.Session{AccessToken: cookie.Value},
+		SessionId: sessionID,
+	})
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	responses.SendResponse(w, logger, responses.NewResponseSuccessful(ResponseSuccessfulRevokeSession))
+	logger.Infof("in RevokeSessionHandler: revoked session id=%s", sessionID)
+}
+
+// RevokeAllSessionsHandler godoc
+//
+//	@Summary    revoke all sessions
+//	@Description  revoke all sessions of user including current, so it is logout on all devices
+//	@Tags auth
+//	@Produce    json
+//	@Success    200  {object} responses.ResponseSuccessful
+//	@Failure    405  {string} string
+//	@Failure    500  {string} string
+//	@Failure    222  {object} responses.ErrorResponse "Error". Внутри body статус может быть badContent(4400), badFormat(4000)//nolint:lll
+//	@Router      /session/revoke_all [post]
+func (a *AuthHandler) RevokeAllSessionsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, `Method not allowed`, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	ctx := r.Context()
+	logger := a.logger.LogReqID(ctx)
+
+	cookie, err := getCookieAuth(r)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	_, err = a.sessionManagerClient.RevokeAllSessions(ctx, &auth.Session{AccessToken: cookie.Value})
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	deleteSessionCookies(w)
+	responses.SendResponse(w, logger, responses.NewResponseSuccessful(ResponseSuccessfulRevokeAllSessions))
+	logger.Infof("in RevokeAllSessionsHandler: revoked all sessions")
+}
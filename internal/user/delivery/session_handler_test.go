@@ -0,0 +1,226 @@
+package delivery_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/user/delivery"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/auth"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/auth/mocks"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses/statuses"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils/test"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetSessions(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	type TestCase struct {
+		name                         string
+		behaviorSessionManagerClient func(m *mocks.MockSessionMangerClient)
+		request                      *http.Request
+		expectedResponse             any
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/session/list", nil)
+				req.AddCookie(&test.Cookie)
+
+				return req
+			}(),
+			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {
+				m.EXPECT().ListSessions(gomock.Any(), &auth.Session{AccessToken: test.AccessToken}).Return(
+					&auth.SessionList{Sessions: []*auth.SessionInfo{
+						{SessionId: "current", CreatedAt: 100, Expire: 200, Current: true},
+						{SessionId: "other", CreatedAt: 50, Expire: 150, Current: false},
+					}}, nil)
+			},
+			expectedResponse: &delivery.SessionListResponse{
+				Status: statuses.StatusResponseSuccessful,
+				Body: []*delivery.SessionResponse{
+					{ID: "current", CreatedAt: time.Unix(100, 0), Expire: time.Unix(200, 0), Current: true},
+					{ID: "other", CreatedAt: time.Unix(50, 0), Expire: time.Unix(150, 0), Current: false},
+				},
+			},
+		},
+		{
+			name: "test revoked session",
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/session/list", nil)
+				req.AddCookie(&test.Cookie)
+
+				return req
+			}(),
+			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {
+				m.EXPECT().ListSessions(gomock.Any(), &auth.Session{AccessToken: test.AccessToken}).Return(
+					nil, myerrors.NewErrorBadContentRequest("Сессия завершена, войдите заново"))
+			},
+			expectedResponse: responses.NewErrResponse(statuses.StatusBadContentRequest,
+				"Сессия завершена, войдите заново"),
+		},
+		{
+			name:                         "test cookie not presented",
+			request:                      httptest.NewRequest(http.MethodGet, "/api/v1/session/list", nil),
+			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {},
+			expectedResponse: responses.NewErrResponse(
+				responses.ErrCookieNotPresented.Status(), responses.ErrCookieNotPresented.Error()),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			authHandler, err := NewAuthHandler(ctrl, testCase.behaviorSessionManagerClient)
+			if err != nil {
+				t.Fatalf("Failed create authHandler %s", err.Error())
+			}
+
+			recorder := httptest.NewRecorder()
+
+			authHandler.GetSessionsHandler(recorder, testCase.request)
+
+			err = test.CompareHTTPTestResult(recorder, testCase.expectedResponse)
+			if err != nil {
+				t.Fatalf("Failed CompareHTTPTestResult %+v", err)
+			}
+		})
+	}
+}
+
+func TestRevokeSession(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	type TestCase struct {
+		name                         string
+		behaviorSessionManagerClient func(m *mocks.MockSessionMangerClient)
+		request                      *http.Request
+		expectedResponse             any
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/api/v1/session/revoke?session_id=other", nil)
+				req.AddCookie(&test.Cookie)
+
+				return req
+			}(),
+			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {
+				m.EXPECT().RevokeSession(gomock.Any(), &auth.RevokeSessionRequest{
+					Session:   &auth.Session{AccessToken: test.AccessToken},
+					SessionId: "other",
+				}).Return(&auth.Nothing{}, nil)
+			},
+			expectedResponse: responses.NewResponseSuccessful(delivery.ResponseSuccessfulRevokeSession),
+		},
+		{
+			name: "test empty session id",
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/api/v1/session/revoke", nil)
+				req.AddCookie(&test.Cookie)
+
+				return req
+			}(),
+			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {},
+			expectedResponse: responses.NewErrResponse(statuses.StatusBadFormatRequest,
+				delivery.ErrEmptySessionID.Error()),
+		},
+		{
+			name: "test wrong method",
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/session/revoke?session_id=other", nil)
+				req.AddCookie(&test.Cookie)
+
+				return req
+			}(),
+			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {},
+			expectedResponse: `Method not allowed
+`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			authHandler, err := NewAuthHandler(ctrl, testCase.behaviorSessionManagerClient)
+			if err != nil {
+				t.Fatalf("Failed create authHandler %s", err.Error())
+			}
+
+			recorder := httptest.NewRecorder()
+
+			authHandler.RevokeSessionHandler(recorder, testCase.request)
+
+			err = test.CompareHTTPTestResult(recorder, testCase.expectedResponse)
+			if err != nil {
+				t.Fatalf("Failed CompareHTTPTestResult %+v", err)
+			}
+		})
+	}
+}
+
+func TestRevokeAllSessions(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	authHandler, err := NewAuthHandler(ctrl, func(m *mocks.MockSessionMangerClient) {
+		m.EXPECT().RevokeAllSessions(gomock.Any(), &auth.Session{AccessToken: test.AccessToken}).
+			Return(&auth.Nothing{}, nil)
+	})
+	if err != nil {
+		t.Fatalf("Failed create authHandler %s", err.Error())
+	}
+
+	recorder := httptest.NewRecorder()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/session/revoke_all", nil)
+	req.AddCookie(&test.Cookie)
+	authHandler.RevokeAllSessionsHandler(recorder, req)
+
+	err = test.CompareHTTPTestResult(recorder,
+		responses.NewResponseSuccessful(delivery.ResponseSuccessfulRevokeAllSessions))
+	if err != nil {
+		t.Fatalf("Failed CompareHTTPTestResult %+v", err)
+	}
+
+	// current session is revoked too, so cookies of access and refresh tokens are removed
+	cookies := recorder.Result().Cookies()
+	if len(cookies) != 2 {
+		t.Fatalf("cookies aren't removed: %+v", cookies)
+	}
+
+	for _, cookie := range cookies {
+		if cookie.Value != "" || cookie.Expires.After(time.Now()) {
+			t.Fatalf("cookie isn't removed: %+v", cookie)
+		}
+	}
+}
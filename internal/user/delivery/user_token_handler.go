@@ -0,0 +1,197 @@
+package delivery
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/auth"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses"
+)
+
+func decodeAndValidate(r *http.Request, value any) error {
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(value); err != nil {
+		return myerrors.NewErrorBadFormatRequest(err.Error())
+	}
+
+	_, err := govalidator.ValidateStruct(value)
+	if err != nil {
+		return myerrors.NewErrorBadContentRequest(err.Error())
+	}
+
+	return nil
+}
+
+// SendVerifyEmailHandler godoc
+//
+//	@Summary    send letter for verification of email
+//	@Description  send letter with link for verification of email again, previous links stop working
+//	@Tags auth
+//	@Produce    json
+//	@Success    200  {object} responses.ResponseSuccessful
+//	@Failure    405  {string} string
+//	@Failure    500  {string} string
+//	@Failure    222  {object} responses.ErrorResponse "Error". Внутри body статус может быть badContent(4400), badFormat(4000)//nolint:lll
+//	@Router      /verify_email/send [post]
+func (a *AuthHandler) SendVerifyEmailHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, `Method not allowed`, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	ctx := r.Context()
+	logger := a.logger.LogReqID(ctx)
+
+	cookie, err := getCookieAuth(r)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	_, err = a.sessionManagerClient.SendVerifyEmail(ctx, &auth.Session{AccessToken: cookie.Value})
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	responses.SendResponse(w, logger, responses.NewResponseSuccessful(ResponseSuccessfulSendVerifyEmail))
+	logger.Infof("in SendVerifyEmailHandler: sent letter")
+}
+
+// VerifyEmailHandler godoc
+//
+//	@Summary    verify email
+//	@Description  verify email by token from link in letter
+//	@Tags auth
+//	@Accept      json
+//	@Produce    json
+//	@Param      token  body models.EmailVerification true  "token from letter"
+//	@Success    200  {object} responses.ResponseSuccessful
+//	@Failure    405  {string} string
+//	@Failure    500  {string} string
+//	@Failure    222  {object} responses.ErrorResponse "Error". Внутри body статус может быть badContent(4400), badFormat(4000)//nolint:lll
+//	@Router      /verify_email [post]
+func (a *AuthHandler) VerifyEmailHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, `Method not allowed`, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	ctx := r.Context()
+	logger := a.logger.LogReqID(ctx)
+
+	emailVerification := new(models.EmailVerification)
+
+	err := decodeAndValidate(r, emailVerification)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	_, err = a.sessionManagerClient.VerifyEmail(ctx, &auth.Token{Token: emailVerification.Token})
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	responses.SendResponse(w, logger, responses.NewResponseSuccessful(ResponseSuccessfulVerifyEmail))
+	logger.Infof("in VerifyEmailHandler: verified email")
+}
+
+// RequestPasswordResetHandler godoc
+//
+//	@Summary    request reset of password
+//	@Description  send letter with link for reset of password. Response is the same for unknown email
+//	@Tags auth
+//	@Accept      json
+//	@Produce    json
+//	@Param      email  body models.PasswordResetRequest true  "email of user"
+//	@Success    200  {object} responses.ResponseSuccessful
+//	@Failure    405  {string} string
+//	@Failure    500  {string} string
+//	@Failure    222  {object} responses.ErrorResponse "Error". Внутри body статус может быть badContent(4400), badFormat(4000)//nolint:lll
+//	@Router      /password/reset_request [post]
+func (a *AuthHandler) RequestPasswordResetHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, `Method not allowed`, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	ctx := r.Context()
+	logger := a.logger.LogReqID(ctx)
+
+	passwordResetRequest := new(models.PasswordResetRequest)
+
+	err := decodeAndValidate(r, passwordResetRequest)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	_, err = a.sessionManagerClient.RequestPasswordReset(ctx, &auth.Email{Email: passwordResetRequest.Email})
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	responses.SendResponse(w, logger, responses.NewResponseSuccessful(ResponseSuccessfulRequestPasswordReset))
+	logger.Infof("in RequestPasswordResetHandler: requested reset")
+}
+
+// ResetPasswordHandler godoc
+//
+//	@Summary    reset password
+//	@Description  set new password by token from letter, all sessions of user are revoked
+//	@Tags auth
+//	@Accept      json
+//	@Produce    json
+//	@Param      reset  body models.PasswordReset true  "token from letter and new password"
+//	@Success    200  {object} responses.ResponseSuccessful
+//	@Failure    405  {string} string
+//	@Failure    500  {string} string
+//	@Failure    222  {object} responses.ErrorResponse "Error". Внутри body статус может быть badContent(4400), badFormat(4000)//nolint:lll
+//	@Router      /password/reset [post]
+func (a *AuthHandler) ResetPasswordHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, `Method not allowed`, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	ctx := r.Context()
+	logger := a.logger.LogReqID(ctx)
+
+	passwordReset := new(models.PasswordReset)
+
+	err := decodeAndValidate(r, passwordReset)
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	_, err = a.sessionManagerClient.ResetPassword(ctx, &auth.PasswordReset{
+		Token:    passwordReset.Token,
+		Password: passwordReset.Password,
+	})
+	if err != nil {
+		responses.HandleErr(w, r, logger, err)
+
+		return
+	}
+
+	responses.SendResponse(w, logger, responses.NewResponseSuccessful(ResponseSuccessfulResetPassword))
+	logger.Infof("in ResetPasswordHandler: reset password")
+}
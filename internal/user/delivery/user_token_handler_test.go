@@ -0,0 +1,167 @@
+package delivery_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/user/delivery"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/auth"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/auth/mocks"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses/statuses"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils/test"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVerifyEmail(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	type TestCase struct {
+		name                         string
+		behaviorSessionManagerClient func(m *mocks.MockSessionMangerClient)
+		request                      *http.Request
+		expectedResponse             any
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			request: httptest.NewRequest(http.MethodPost, "/api/v1/verify_email",
+				strings.NewReader(`{"token":"test_token"}`)),
+			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {
+				m.EXPECT().VerifyEmail(gomock.Any(), &auth.Token{Token: "test_token"}).Return(&auth.Nothing{}, nil)
+			},
+			expectedResponse: responses.NewResponseSuccessful(delivery.ResponseSuccessfulVerifyEmail),
+		},
+		{
+			name: "test used token",
+			request: httptest.NewRequest(http.MethodPost, "/api/v1/verify_email",
+				strings.NewReader(`{"token":"test_token"}`)),
+			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {
+				m.EXPECT().VerifyEmail(gomock.Any(), &auth.Token{Token: "test_token"}).Return(
+					nil, myerrors.NewErrorBadContentRequest("Ссылка недействительна или устарела"))
+			},
+			expectedResponse: responses.NewErrResponse(statuses.StatusBadContentRequest,
+				"Ссылка недействительна или устарела"),
+		},
+		{
+			name: "test empty token",
+			request: httptest.NewRequest(http.MethodPost, "/api/v1/verify_email",
+				strings.NewReader(`{"token":""}`)),
+			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {},
+			expectedResponse: responses.NewErrResponse(statuses.StatusBadContentRequest,
+				"Токен не может быть пустым"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			authHandler, err := NewAuthHandler(ctrl, testCase.behaviorSessionManagerClient)
+			if err != nil {
+				t.Fatalf("Failed create authHandler %s", err.Error())
+			}
+
+			recorder := httptest.NewRecorder()
+
+			authHandler.VerifyEmailHandler(recorder, testCase.request)
+
+			err = test.CompareHTTPTestResult(recorder, testCase.expectedResponse)
+			if err != nil {
+				t.Fatalf("Failed CompareHTTPTestResult %+v", err)
+			}
+		})
+	}
+}
+
+func TestResetPassword(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	type TestCase struct {
+		name                         string
+		behaviorSessionManagerClient func(m *mocks.MockSessionMangerClient)
+		request                      *http.Request
+		expectedResponse             any
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test request reset",
+			request: httptest.NewRequest(http.MethodPost, "/api/v1/password/reset_request",
+				strings.NewReader(`{"email":"ivn-tyt@mail.ru"}`)),
+			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {
+				m.EXPECT().RequestPasswordReset(gomock.Any(), &auth.Email{Email: "ivn-tyt@mail.ru"}).
+					Return(&auth.Nothing{}, nil)
+			},
+			expectedResponse: responses.NewResponseSuccessful(delivery.ResponseSuccessfulRequestPasswordReset),
+		},
+		{
+			name: "test reset",
+			request: httptest.NewRequest(http.MethodPost, "/api/v1/password/reset",
+				strings.NewReader(`{"token":"test_token", "password":"new_strong"}`)),
+			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {
+				m.EXPECT().ResetPassword(gomock.Any(),
+					&auth.PasswordReset{Token: "test_token", Password: "new_strong"}).Return(&auth.Nothing{}, nil)
+			},
+			expectedResponse: responses.NewResponseSuccessful(delivery.ResponseSuccessfulResetPassword),
+		},
+		{
+			name: "test reset with short password",
+			request: httptest.NewRequest(http.MethodPost, "/api/v1/password/reset",
+				strings.NewReader(`{"token":"test_token", "password":"weak"}`)),
+			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {},
+			expectedResponse: responses.NewErrResponse(statuses.StatusBadContentRequest,
+				"Пароль должен быть минимум 6 символов"),
+		},
+		{
+			name: "test reset wrong format",
+			request: httptest.NewRequest(http.MethodPost, "/api/v1/password/reset",
+				strings.NewReader(`{`)),
+			behaviorSessionManagerClient: func(m *mocks.MockSessionMangerClient) {},
+			expectedResponse:             responses.NewErrResponse(statuses.StatusBadFormatRequest, "unexpected EOF"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			authHandler, err := NewAuthHandler(ctrl, testCase.behaviorSessionManagerClient)
+			if err != nil {
+				t.Fatalf("Failed create authHandler %s", err.Error())
+			}
+
+			recorder := httptest.NewRecorder()
+
+			if strings.HasSuffix(testCase.request.URL.Path, "reset_request") {
+				authHandler.RequestPasswordResetHandler(recorder, testCase.request)
+			} else {
+				authHandler.ResetPasswordHandler(recorder, testCase.request)
+			}
+
+			err = test.CompareHTTPTestResult(recorder, testCase.expectedResponse)
+			if err != nil {
+				t.Fatalf("Failed CompareHTTPTestResult %+v", err)
+			}
+		})
+	}
+}
@@ -40,34 +40,18 @@ func (m *MockIUserService) EXPECT() *MockIUserServiceMockRecorder {
 	return m.recorder
 }
 
-// AddUser mocks base method.
-func (m *MockIUserService) AddUser(ctx context.Context, r io.Reader) (*models.User, error) {
+// DeleteUser mocks base method.
+func (m *MockIUserService) DeleteUser(ctx context.Context, userID uint64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddUser", ctx, r)
-	ret0, _ := ret[0].(*models.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// AddUser indicates an expected call of AddUser.
-func (mr *MockIUserServiceMockRecorder) AddUser(ctx, r any) *gomock.Call {
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockIUserServiceMockRecorder) DeleteUser(ctx, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUser", reflect.TypeOf((*MockIUserService)(nil).AddUser), ctx, r)
-}
-
-// GetUser mocks base method.
-func (m *MockIUserService) GetUser(ctx context.Context, email, password string) (*models.UserWithoutPassword, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", ctx, email, password)
-	ret0, _ := ret[0].(*models.UserWithoutPassword)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetUser indicates an expected call of GetUser.
-func (mr *MockIUserServiceMockRecorder) GetUser(ctx, email, password any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockIUserService)(nil).GetUser), ctx, email, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockIUserService)(nil).DeleteUser), ctx, userID)
 }
 
 // GetUserWithoutPasswordByID mocks base method.
@@ -86,12 +70,13 @@ func (mr *MockIUserServiceMockRecorder) GetUserWithoutPasswordByID(ctx, userID a
 }
 
 // UpdateUser mocks base method.
-func (m *MockIUserService) UpdateUser(ctx context.Context, r io.Reader, isPartialUpdate bool, userID uint64) (*models.UserWithoutPassword, error) {
+func (m *MockIUserService) UpdateUser(ctx context.Context, r io.Reader, isPartialUpdate bool, userID uint64) (*models.UserWithoutPassword, bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpdateUser", ctx, r, isPartialUpdate, userID)
 	ret0, _ := ret[0].(*models.UserWithoutPassword)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // UpdateUser indicates an expected call of UpdateUser.
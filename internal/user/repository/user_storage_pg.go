@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/Masterminds/squirrel"
@@ -10,6 +11,7 @@ import (
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/pgxpool"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses/statuses"
 	"github.com/jackc/pgx/v5"
 )
 
@@ -20,6 +22,7 @@ var (
 	ErrWrongCredentials   = myerrors.NewErrorBadContentRequest("Некорректный логин или пароль")
 	ErrNoUpdateFields     = myerrors.NewErrorBadFormatRequest("Вы пытаетесь обновить пустое количество полей")
 	ErrNoAffectedUserRows = myerrors.NewErrorBadFormatRequest("Не получилось обновить данные пользователя")
+	ErrUserAlreadyDeleted = myerrors.NewErrorBadContentRequest("Пользователь не найден или уже удалён")
 
 	NameSeqUser = pgx.Identifier{"public", "user_id_seq"} //nolint:gochecknoglobals
 )
@@ -139,13 +142,78 @@ func (u *UserStorage) updateUser(ctx context.Context,
 	return nil
 }
 
+// isEmailChanged compares emails case-insensitively, row of user is locked until end of tx.
+func (u *UserStorage) isEmailChanged(ctx context.Context, tx pgx.Tx, userID uint64, email any) (bool, error) {
+	logger := u.logger.LogReqID(ctx)
+
+	SQLGetEmail := `SELECT LOWER(email)<>LOWER($2) FROM public."user" WHERE id=$1 FOR UPDATE;`
+
+	var emailChanged bool
+
+	err := tx.QueryRow(ctx, SQLGetEmail, userID, email).Scan(&emailChanged)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, fmt.Errorf(myerrors.ErrTemplate, ErrNoAffectedUserRows)
+		}
+
+		logger.Errorln(err)
+
+		return false, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return emailChanged, nil
+}
+
+// purposeVerifyEmail is purpose of user_token for verification of email, it's set by auth service.
+const purposeVerifyEmail = 1
+
+// invalidateVerifyEmailTokens marks unused tokens of verification as used,
+// so link sent to previous email can't verify new one.
+func (u *UserStorage) invalidateVerifyEmailTokens(ctx context.Context, tx pgx.Tx, userID uint64) error {
+	logger := u.logger.LogReqID(ctx)
+
+	SQLInvalidateVerifyEmailTokens := `UPDATE public."user_token" SET used_at=NOW()
+		 WHERE user_id=$1 AND purpose=$2 AND used_at IS NULL;`
+
+	_, err := tx.Exec(ctx, SQLInvalidateVerifyEmailTokens, userID, purposeVerifyEmail)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// UpdateUser returns also whether email was changed. Verification of previous email
+// isn't valid for new one, so new email is saved not verified and links of verification
+// sent before are invalidated in the same transaction.
 func (u *UserStorage) UpdateUser(ctx context.Context,
 	userID uint64, updateData map[string]interface{},
-) (*models.UserWithoutPassword, error) {
+) (*models.UserWithoutPassword, bool, error) {
 	userWithoutPass := &models.UserWithoutPassword{} //nolint:exhaustruct
+	emailChanged := false
 
 	err := pgx.BeginFunc(ctx, u.pool, func(tx pgx.Tx) error {
-		err := u.updateUser(ctx, tx, userID, updateData)
+		var err error
+
+		if email, ok := updateData["email"]; ok {
+			emailChanged, err = u.isEmailChanged(ctx, tx, userID, email)
+			if err != nil {
+				return fmt.Errorf(myerrors.ErrTemplate, err)
+			}
+
+			if emailChanged {
+				updateData["email_verified_at"] = nil
+
+				err = u.invalidateVerifyEmailTokens(ctx, tx, userID)
+				if err != nil {
+					return fmt.Errorf(myerrors.ErrTemplate, err)
+				}
+			}
+		}
+
+		err = u.updateUser(ctx, tx, userID, updateData)
 		if err != nil {
 			return fmt.Errorf(myerrors.ErrTemplate, err)
 		}
@@ -158,8 +226,231 @@ func (u *UserStorage) UpdateUser(ctx context.Context,
 		return nil
 	})
 	if err != nil {
+		return nil, false, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return userWithoutPass, emailChanged, nil
+}
+
+// anonymiseUser replaces personal data of user, email stays unique and not valid for login.
+func (u *UserStorage) anonymiseUser(ctx context.Context, tx pgx.Tx, userID uint64) error {
+	logger := u.logger.LogReqID(ctx)
+
+	SQLAnonymiseUser := `UPDATE public."user"
+		 SET email='deleted_' || id || '@deleted.invalid', password='deleted', phone=NULL, name=NULL,
+		 birthday=NULL, avatar=NULL, email_verified_at=NULL, deleted_at=NOW()
+		 WHERE id=$1 AND deleted_at IS NULL;`
+
+	result, err := tx.Exec(ctx, SQLAnonymiseUser, userID)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrUserAlreadyDeleted
+	}
+
+	return nil
+}
+
+type openOrder struct {
+	id         uint64
+	count      uint32
+	fromStatus uint8
+	toStatus   uint8
+}
+
+// cancelOpenOrders finishes not completed orders where user is buyer or seller: orders in processing
+// are cancelled, paid and shipped orders are refunded, so held money of safe deal are released.
+func (u *UserStorage) cancelOpenOrders(ctx context.Context, tx pgx.Tx, userID uint64) ([]openOrder, error) {
+	logger := u.logger.LogReqID(ctx)
+
+	SQLCancelOpenOrders := `WITH open_order AS (
+			SELECT "order".id, "order".status
+			FROM public."order" INNER JOIN public."product" ON "product".id = "order".product_id
+			WHERE ("order".owner_id=$1 OR "product".saler_id=$1) AND "order".status IN ($2, $3, $4)
+			FOR UPDATE OF "order"
+		 )
+		 UPDATE public."order"
+		 SET status=CASE WHEN open_order.status=$2 THEN $5 ELSE $6 END, reserved_until=NULL
+		 FROM open_order
+		 WHERE "order".id = open_order.id
+		 RETURNING "order".id, "order".count, open_order.status, "order".status`
+
+	openOrdersRows, err := tx.Query(ctx, SQLCancelOpenOrders, userID,
+		models.OrderStatusInProcessing, models.OrderStatusPaid, models.OrderStatusShipped,
+		models.OrderStatusCancelled, models.OrderStatusRefunded)
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	var openOrders []openOrder
+
+	var curOrder openOrder
+
+	_, err = pgx.ForEachRow(openOrdersRows,
+		[]any{&curOrder.id, &curOrder.count, &curOrder.fromStatus, &curOrder.toStatus}, func() error {
+			openOrders = append(openOrders, curOrder)
+
+			return nil
+		})
+	if err != nil {
+		logger.Errorln(err)
+
 		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	return userWithoutPass, nil
+	return openOrders, nil
+}
+
+func (u *UserStorage) returnProductsByOrderID(ctx context.Context,
+	tx pgx.Tx, orderID uint64, count uint32,
+) error {
+	logger := u.logger.LogReqID(ctx)
+
+	SQLIncreaseAvailableCountByOrderID := `UPDATE public."product"
+		 SET available_count = available_count + $1
+		 WHERE id = (
+			SELECT product_id
+			FROM public."order"
+			WHERE id = $2
+		 )`
+
+	_, err := tx.Exec(ctx, SQLIncreaseAvailableCountByOrderID, count, orderID)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+func (u *UserStorage) insertOrderStatusHistory(ctx context.Context, tx pgx.Tx, order openOrder) error {
+	logger := u.logger.LogReqID(ctx)
+
+	SQLInsertOrderStatusHistory := `INSERT INTO public."order_status_history"
+		 (order_id, actor_id, actor_role, from_status, to_status) VALUES ($1, NULL, $2, $3, $4)`
+
+	_, err := tx.Exec(ctx, SQLInsertOrderStatusHistory,
+		order.id, models.OrderActorSystem, order.fromStatus, order.toStatus)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// cancelPendingPaymentsOfOrders marks pending payments of cancelled orders canceled, if they have
+// no order left in processing. If buyer pays such payment later, money is returned,
+// because payment has no orders.
+func (u *UserStorage) cancelPendingPaymentsOfOrders(ctx context.Context, tx pgx.Tx, orderIDs []uint64) error {
+	logger := u.logger.LogReqID(ctx)
+
+	SQLCancelPendingPayments := `UPDATE public."payment"
+		 SET status=$1
+		 WHERE status=$2 AND id IN (
+			SELECT payment_id FROM public."order" WHERE id = ANY($3)
+		 ) AND NOT EXISTS (
+			SELECT 1 FROM public."order" WHERE "order".payment_id = "payment".id AND "order".status=$4
+		 )`
+
+	_, err := tx.Exec(ctx, SQLCancelPendingPayments, statuses.IntStatusPaymentCanceled,
+		statuses.IntStatusPaymentPending, orderIDs, models.OrderStatusInProcessing)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// finishOpenOrders cancels or refunds open orders of user, returns their products to stock
+// and cancels pending payments left without orders.
+func (u *UserStorage) finishOpenOrders(ctx context.Context, tx pgx.Tx, userID uint64) error {
+	openOrders, err := u.cancelOpenOrders(ctx, tx, userID)
+	if err != nil {
+		return err
+	}
+
+	if len(openOrders) == 0 {
+		return nil
+	}
+
+	orderIDs := make([]uint64, len(openOrders))
+
+	for i, order := range openOrders {
+		orderIDs[i] = order.id
+
+		err = u.returnProductsByOrderID(ctx, tx, order.id, order.count)
+		if err != nil {
+			return err
+		}
+
+		err = u.insertOrderStatusHistory(ctx, tx, order)
+		if err != nil {
+			return err
+		}
+	}
+
+	return u.cancelPendingPaymentsOfOrders(ctx, tx, orderIDs)
+}
+
+// DeleteUser anonymises user, deactivates his products and removes his basket, favourites,
+// saved searches, linked accounts of external services and sessions in one transaction.
+// His open orders as buyer and as seller are cancelled or refunded, only completed orders are kept.
+func (u *UserStorage) DeleteUser(ctx context.Context, userID uint64) error {
+	logger := u.logger.LogReqID(ctx)
+
+	SQLDeactivateProducts := `UPDATE public."product" SET is_active=false WHERE saler_id=$1 AND is_active;`
+	SQLDeleteBasket := `DELETE FROM public."order" WHERE owner_id=$1 AND status=$2;`
+	SQLDeleteFavourites := `DELETE FROM public."favourite" WHERE owner_id=$1;`
+	SQLDeleteSavedSearches := `DELETE FROM public."saved_search" WHERE owner_id=$1;`
+	SQLDeleteIdentities := `DELETE FROM public."user_identity" WHERE user_id=$1;`
+	SQLRevokeAllSessions := `UPDATE public."session" SET revoked_at=NOW() WHERE user_id=$1 AND revoked_at IS NULL;`
+
+	err := pgx.BeginFunc(ctx, u.pool, func(tx pgx.Tx) error {
+		err := u.anonymiseUser(ctx, tx, userID)
+		if err != nil {
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		err = u.finishOpenOrders(ctx, tx, userID)
+		if err != nil {
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		for _, query := range []struct {
+			sql  string
+			args []any
+		}{
+			{sql: SQLDeactivateProducts, args: []any{userID}},
+			{sql: SQLDeleteBasket, args: []any{userID, models.OrderStatusInBasket}},
+			{sql: SQLDeleteFavourites, args: []any{userID}},
+			{sql: SQLDeleteSavedSearches, args: []any{userID}},
+			{sql: SQLDeleteIdentities, args: []any{userID}},
+			{sql: SQLRevokeAllSessions, args: []any{userID}},
+		} {
+			_, err = tx.Exec(ctx, query.sql, query.args...)
+			if err != nil {
+				logger.Errorln(err)
+
+				return fmt.Errorf(myerrors.ErrTemplate, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/user/repository"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses/statuses"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
 	"github.com/pashagolub/pgxmock/v3"
 )
@@ -138,11 +139,12 @@ func TestUpdateUser(t *testing.T) {
 	_ = mylogger.NewNop()
 
 	type TestCase struct {
-		name                string
-		behaviorCityStorage func(m *repository.UserStorage, mockPool pgxmock.PgxPoolIface)
-		userID              uint64
-		updDataMap          map[string]interface{}
-		expectedResponse    *models.UserWithoutPassword
+		name                 string
+		behaviorCityStorage  func(m *repository.UserStorage, mockPool pgxmock.PgxPoolIface)
+		userID               uint64
+		updDataMap           map[string]interface{}
+		expectedResponse     *models.UserWithoutPassword
+		expectedEmailChanged bool
 	}
 
 	testCases := [...]TestCase{
@@ -176,19 +178,56 @@ func TestUpdateUser(t *testing.T) {
 				CreatedAt: testTime,
 			},
 		},
+		{
+			name: "test changed email isn't verified",
+			behaviorCityStorage: func(m *repository.UserStorage, mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+
+				mockPool.ExpectQuery(`SELECT LOWER\(email\)<>LOWER\(\$2\) FROM public."user"`).
+					WithArgs(uint64(1), "updated@mail.ru").
+					WillReturnRows(pgxmock.NewRows([]string{"changed"}).AddRow(true))
+
+				mockPool.ExpectExec(`UPDATE public."user_token" SET used_at=NOW\(\)`).WithArgs(uint64(1), 1).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+				mockPool.ExpectExec(`UPDATE public."user" SET email = \$1, email_verified_at = \$2`).WithArgs(
+					"updated@mail.ru", nil, uint64(1)).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+				mockPool.ExpectQuery(`SELECT email, phone, name, birthday, avatar, created_at FROM public."user"`).
+					WithArgs(uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"email", "phone", "name", "birthday", "avatar", "created_at"}).
+						AddRow("updated@mail.ru", sql.NullString{}, sql.NullString{},
+							sql.NullTime{}, sql.NullString{}, testTime))
+
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			userID:     1,
+			updDataMap: map[string]interface{}{"email": "updated@mail.ru"},
+			expectedResponse: &models.UserWithoutPassword{ //nolint:exhaustruct
+				ID:        1,
+				Email:     "updated@mail.ru",
+				CreatedAt: testTime,
+			},
+			expectedEmailChanged: true,
+		},
 		{
 			name: "test err no rows",
 			behaviorCityStorage: func(m *repository.UserStorage, mockPool pgxmock.PgxPoolIface) {
 				mockPool.ExpectBegin()
 
-				mockPool.ExpectExec(`UPDATE public."user"`).WithArgs(
-					"updated@mail.ru", uint64(1)).WillReturnError(sql.ErrNoRows)
+				mockPool.ExpectQuery(`SELECT LOWER\(email\)<>LOWER\(\$2\) FROM public."user"`).
+					WithArgs(uint64(1), "Test@gmail.com").
+					WillReturnRows(pgxmock.NewRows([]string{"changed"}).AddRow(false))
+
+				mockPool.ExpectExec(`UPDATE public."user" SET email = \$1 WHERE`).WithArgs(
+					"Test@gmail.com", uint64(1)).WillReturnError(sql.ErrNoRows)
 
 				mockPool.ExpectRollback()
 				mockPool.ExpectRollback()
 			},
 			userID:           1,
-			updDataMap:       map[string]interface{}{"email": "updated@mail.ru"},
+			updDataMap:       map[string]interface{}{"email": "Test@gmail.com"},
 			expectedResponse: nil,
 		},
 	}
@@ -213,7 +252,7 @@ func TestUpdateUser(t *testing.T) {
 
 			testCase.behaviorCityStorage(userStorage, mockPool)
 
-			response, _ := userStorage.UpdateUser(ctx, testCase.userID, testCase.updDataMap)
+			response, emailChanged, _ := userStorage.UpdateUser(ctx, testCase.userID, testCase.updDataMap)
 
 			if err := mockPool.ExpectationsWereMet(); err != nil {
 				t.Errorf("there were unfulfilled expectations: %s", err)
@@ -223,6 +262,141 @@ func TestUpdateUser(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
+
+			if emailChanged != testCase.expectedEmailChanged {
+				t.Fatalf("emailChanged: got %t, expected %t", emailChanged, testCase.expectedEmailChanged)
+			}
+		})
+	}
+}
+
+func TestDeleteUser(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	expectCancelOpenOrders := func(mockPool pgxmock.PgxPoolIface) *pgxmock.ExpectedQuery {
+		return mockPool.ExpectQuery(`WITH open_order AS`).WithArgs(uint64(1),
+			models.OrderStatusInProcessing, models.OrderStatusPaid, models.OrderStatusShipped,
+			models.OrderStatusCancelled, models.OrderStatusRefunded)
+	}
+
+	type TestCase struct {
+		name             string
+		behaviorMockPool func(mockPool pgxmock.PgxPoolIface)
+		expectedError    error
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+				mockPool.ExpectExec(`UPDATE public."user"\s+SET email='deleted_'`).WithArgs(uint64(1)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				expectCancelOpenOrders(mockPool).WillReturnRows(
+					pgxmock.NewRows([]string{"id", "count", "from_status", "to_status"}))
+				mockPool.ExpectExec(`UPDATE public."product" SET is_active=false`).WithArgs(uint64(1)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+				mockPool.ExpectExec(`DELETE FROM public."order" WHERE owner_id=\$1 AND status=\$2`).
+					WithArgs(uint64(1), models.OrderStatusInBasket).WillReturnResult(pgxmock.NewResult("DELETE", 1))
+				mockPool.ExpectExec(`DELETE FROM public."favourite"`).WithArgs(uint64(1)).
+					WillReturnResult(pgxmock.NewResult("DELETE", 0))
+				mockPool.ExpectExec(`DELETE FROM public."saved_search"`).WithArgs(uint64(1)).
+					WillReturnResult(pgxmock.NewResult("DELETE", 0))
+				mockPool.ExpectExec(`DELETE FROM public."user_identity"`).WithArgs(uint64(1)).
+					WillReturnResult(pgxmock.NewResult("DELETE", 1))
+				mockPool.ExpectExec(`UPDATE public."session" SET revoked_at=NOW\(\)`).WithArgs(uint64(1)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			expectedError: nil,
+		},
+		{
+			name: "test open orders as buyer and seller",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+				mockPool.ExpectExec(`UPDATE public."user"\s+SET email='deleted_'`).WithArgs(uint64(1)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				expectCancelOpenOrders(mockPool).WillReturnRows(
+					pgxmock.NewRows([]string{"id", "count", "from_status", "to_status"}).
+						AddRow(uint64(10), uint32(2), uint8(models.OrderStatusInProcessing),
+							uint8(models.OrderStatusCancelled)).
+						AddRow(uint64(11), uint32(1), uint8(models.OrderStatusPaid),
+							uint8(models.OrderStatusRefunded)))
+				mockPool.ExpectExec(`UPDATE public."product"\s+SET available_count = available_count \+ \$1`).
+					WithArgs(uint32(2), uint64(10)).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				mockPool.ExpectExec(`INSERT INTO public."order_status_history"`).
+					WithArgs(uint64(10), models.OrderActorSystem, uint8(models.OrderStatusInProcessing),
+						uint8(models.OrderStatusCancelled)).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mockPool.ExpectExec(`UPDATE public."product"\s+SET available_count = available_count \+ \$1`).
+					WithArgs(uint32(1), uint64(11)).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				mockPool.ExpectExec(`INSERT INTO public."order_status_history"`).
+					WithArgs(uint64(11), models.OrderActorSystem, uint8(models.OrderStatusPaid),
+						uint8(models.OrderStatusRefunded)).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mockPool.ExpectExec(`UPDATE public."payment"\s+SET status=\$1`).
+					WithArgs(statuses.IntStatusPaymentCanceled, statuses.IntStatusPaymentPending,
+						[]uint64{10, 11}, models.OrderStatusInProcessing).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				mockPool.ExpectExec(`UPDATE public."product" SET is_active=false`).WithArgs(uint64(1)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+				mockPool.ExpectExec(`DELETE FROM public."order" WHERE owner_id=\$1 AND status=\$2`).
+					WithArgs(uint64(1), models.OrderStatusInBasket).WillReturnResult(pgxmock.NewResult("DELETE", 0))
+				mockPool.ExpectExec(`DELETE FROM public."favourite"`).WithArgs(uint64(1)).
+					WillReturnResult(pgxmock.NewResult("DELETE", 0))
+				mockPool.ExpectExec(`DELETE FROM public."saved_search"`).WithArgs(uint64(1)).
+					WillReturnResult(pgxmock.NewResult("DELETE", 0))
+				mockPool.ExpectExec(`DELETE FROM public."user_identity"`).WithArgs(uint64(1)).
+					WillReturnResult(pgxmock.NewResult("DELETE", 0))
+				mockPool.ExpectExec(`UPDATE public."session" SET revoked_at=NOW\(\)`).WithArgs(uint64(1)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			expectedError: nil,
+		},
+		{
+			name: "test already deleted",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+				mockPool.ExpectExec(`UPDATE public."user"\s+SET email='deleted_'`).WithArgs(uint64(1)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+				mockPool.ExpectRollback()
+				mockPool.ExpectRollback()
+			},
+			expectedError: repository.ErrUserAlreadyDeleted,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			userStorage, err := repository.NewUserStorage(mockPool)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			testCase.behaviorMockPool(mockPool)
+
+			err = userStorage.DeleteUser(context.Background(), 1)
+			if errInner := utils.EqualError(err, testCase.expectedError); errInner != nil {
+				t.Fatalf("Failed EqualError: %+v", errInner)
+			}
+
+			if err := mockPool.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
 		})
 	}
 }
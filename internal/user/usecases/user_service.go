@@ -2,10 +2,12 @@ package usecases
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"io"
 
 	userrepo "github.com/go-park-mail-ru/2023_2_Rabotyagi/internal/user/repository"
+	fileservice "github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/file_service"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/models"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
@@ -18,21 +20,43 @@ var _ IUserStorage = (*userrepo.UserStorage)(nil)
 
 type IUserStorage interface {
 	GetUserWithoutPasswordByID(ctx context.Context, id uint64) (*models.UserWithoutPassword, error)
-	UpdateUser(ctx context.Context, userID uint64, updateData map[string]interface{}) (*models.UserWithoutPassword, error)
+	UpdateUser(ctx context.Context, userID uint64,
+		updateData map[string]interface{}) (*models.UserWithoutPassword, bool, error)
+	DeleteUser(ctx context.Context, userID uint64) error
 }
 
 type UserService struct {
-	storage IUserStorage
-	logger  *mylogger.MyLogger
+	storage           IUserStorage
+	fileServiceClient fileservice.FileServiceClient
+	logger            *mylogger.MyLogger
 }
 
-func NewUserService(userStorage IUserStorage) (*UserService, error) {
+func NewUserService(userStorage IUserStorage, fileServiceClient fileservice.FileServiceClient) (*UserService, error) {
 	logger, err := mylogger.Get()
 	if err != nil {
 		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	return &UserService{storage: userStorage, logger: logger}, nil
+	return &UserService{storage: userStorage, fileServiceClient: fileServiceClient, logger: logger}, nil
+}
+
+// attachAvatar reports avatar to file service, so it isn't deleted as image without owner.
+// User is already updated, so error is only logged.
+func (u *UserService) attachAvatar(ctx context.Context, userID uint64, avatar sql.NullString) {
+	logger := u.logger.LogReqID(ctx)
+
+	var urls []string
+	if avatar.Valid && avatar.String != "" {
+		urls = []string{avatar.String}
+	}
+
+	_, err := u.fileServiceClient.Attach(ctx, &fileservice.ImgRefs{
+		Owner: &fileservice.ImgOwner{Type: fileservice.OwnerUser, Id: userID},
+		Url:   urls,
+	})
+	if err != nil {
+		logger.Errorf("failed attach avatar of user id=%d: %+v", userID, err)
+	}
 }
 
 func (u *UserService) GetUserWithoutPasswordByID(ctx context.Context,
@@ -48,9 +72,10 @@ func (u *UserService) GetUserWithoutPasswordByID(ctx context.Context,
 	return user, nil
 }
 
+// UpdateUser returns also whether email was changed, new email should be verified again.
 func (u *UserService) UpdateUser(ctx context.Context, r io.Reader,
 	isPartialUpdate bool, userID uint64,
-) (*models.UserWithoutPassword, error) {
+) (*models.UserWithoutPassword, bool, error) {
 	logger := u.logger.LogReqID(ctx)
 
 	var userWithoutPassword *models.UserWithoutPassword
@@ -60,31 +85,54 @@ func (u *UserService) UpdateUser(ctx context.Context, r io.Reader,
 	if isPartialUpdate {
 		userWithoutPassword, err = ValidatePartOfUserWithoutPassword(r)
 		if err != nil {
-			return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+			return nil, false, fmt.Errorf(myerrors.ErrTemplate, err)
 		}
 	} else {
 		userWithoutPassword, err = ValidateUserWithoutPassword(r)
 		if err != nil {
-			return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+			return nil, false, fmt.Errorf(myerrors.ErrTemplate, err)
 		}
 	}
 
 	if userWithoutPassword.ID != userID && userWithoutPassword.ID != 0 {
 		logger.Errorln(ErrWrongUserID)
 
-		return nil, fmt.Errorf(myerrors.ErrTemplate, ErrWrongUserID)
+		return nil, false, fmt.Errorf(myerrors.ErrTemplate, ErrWrongUserID)
 	}
 
 	updateDataMap := utils.StructToMap(userWithoutPassword)
 
 	delete(updateDataMap, "ID")
 
-	updatedUser, err := u.storage.UpdateUser(ctx, userID, updateDataMap)
+	updatedUser, emailChanged, err := u.storage.UpdateUser(ctx, userID, updateDataMap)
 	if err != nil {
-		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+		return nil, false, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if _, ok := updateDataMap["avatar"]; ok {
+		u.attachAvatar(ctx, userID, updatedUser.Avatar)
 	}
 
 	updatedUser.Sanitize()
 
-	return updatedUser, nil
+	return updatedUser, emailChanged, nil
+}
+
+func (u *UserService) DeleteUser(ctx context.Context, userID uint64) error {
+	logger := u.logger.LogReqID(ctx)
+
+	err := u.storage.DeleteUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	// avatar is removed at anonymising, products are kept with their images
+	_, err = u.fileServiceClient.Detach(ctx, &fileservice.ImgOwner{Type: fileservice.OwnerUser, Id: userID})
+	if err != nil {
+		logger.Errorf("failed detach avatar of user id=%d: %+v", userID, err)
+	}
+
+	logger.Infof("deleted user id=%d", userID)
+
+	return nil
 }
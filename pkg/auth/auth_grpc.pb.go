@@ -19,10 +19,22 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	SessionManger_Login_FullMethodName  = "/auth.SessionManger/Login"
-	SessionManger_Check_FullMethodName  = "/auth.SessionManger/Check"
-	SessionManger_Create_FullMethodName = "/auth.SessionManger/Create"
-	SessionManger_Delete_FullMethodName = "/auth.SessionManger/Delete"
+	SessionManger_Login_FullMethodName                = "/auth.SessionManger/Login"
+	SessionManger_Check_FullMethodName                = "/auth.SessionManger/Check"
+	SessionManger_Create_FullMethodName               = "/auth.SessionManger/Create"
+	SessionManger_Delete_FullMethodName               = "/auth.SessionManger/Delete"
+	SessionManger_Refresh_FullMethodName              = "/auth.SessionManger/Refresh"
+	SessionManger_ListSessions_FullMethodName         = "/auth.SessionManger/ListSessions"
+	SessionManger_RevokeSession_FullMethodName        = "/auth.SessionManger/RevokeSession"
+	SessionManger_RevokeAllSessions_FullMethodName    = "/auth.SessionManger/RevokeAllSessions"
+	SessionManger_SendVerifyEmail_FullMethodName      = "/auth.SessionManger/SendVerifyEmail"
+	SessionManger_VerifyEmail_FullMethodName          = "/auth.SessionManger/VerifyEmail"
+	SessionManger_RequestPasswordReset_FullMethodName = "/auth.SessionManger/RequestPasswordReset"
+	SessionManger_ResetPassword_FullMethodName        = "/auth.SessionManger/ResetPassword"
+	SessionManger_ChangePassword_FullMethodName       = "/auth.SessionManger/ChangePassword"
+	SessionManger_CheckPassword_FullMethodName        = "/auth.SessionManger/CheckPassword"
+	SessionManger_GetOAuthURL_FullMethodName          = "/auth.SessionManger/GetOAuthURL"
+	SessionManger_LoginOAuth_FullMethodName           = "/auth.SessionManger/LoginOAuth"
 )
 
 // SessionMangerClient is the client API for SessionManger service.
@@ -33,6 +45,18 @@ type SessionMangerClient interface {
 	Check(ctx context.Context, in *Session, opts ...grpc.CallOption) (*UserID, error)
 	Create(ctx context.Context, in *User, opts ...grpc.CallOption) (*Session, error)
 	Delete(ctx context.Context, in *Session, opts ...grpc.CallOption) (*Session, error)
+	Refresh(ctx context.Context, in *Session, opts ...grpc.CallOption) (*Session, error)
+	ListSessions(ctx context.Context, in *Session, opts ...grpc.CallOption) (*SessionList, error)
+	RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*Nothing, error)
+	RevokeAllSessions(ctx context.Context, in *Session, opts ...grpc.CallOption) (*Nothing, error)
+	SendVerifyEmail(ctx context.Context, in *Session, opts ...grpc.CallOption) (*Nothing, error)
+	VerifyEmail(ctx context.Context, in *Token, opts ...grpc.CallOption) (*Nothing, error)
+	RequestPasswordReset(ctx context.Context, in *Email, opts ...grpc.CallOption) (*Nothing, error)
+	ResetPassword(ctx context.Context, in *PasswordReset, opts ...grpc.CallOption) (*Nothing, error)
+	ChangePassword(ctx context.Context, in *PasswordChange, opts ...grpc.CallOption) (*Nothing, error)
+	CheckPassword(ctx context.Context, in *PasswordCheck, opts ...grpc.CallOption) (*Nothing, error)
+	GetOAuthURL(ctx context.Context, in *OAuthProvider, opts ...grpc.CallOption) (*OAuthURL, error)
+	LoginOAuth(ctx context.Context, in *OAuthCode, opts ...grpc.CallOption) (*Session, error)
 }
 
 type sessionMangerClient struct {
@@ -79,6 +103,114 @@ func (c *sessionMangerClient) Delete(ctx context.Context, in *Session, opts ...g
 	return out, nil
 }
 
+func (c *sessionMangerClient) Refresh(ctx context.Context, in *Session, opts ...grpc.CallOption) (*Session, error) {
+	out := new(Session)
+	err := c.cc.Invoke(ctx, SessionManger_Refresh_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionMangerClient) ListSessions(ctx context.Context, in *Session, opts ...grpc.CallOption) (*SessionList, error) {
+	out := new(SessionList)
+	err := c.cc.Invoke(ctx, SessionManger_ListSessions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionMangerClient) RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*Nothing, error) {
+	out := new(Nothing)
+	err := c.cc.Invoke(ctx, SessionManger_RevokeSession_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionMangerClient) RevokeAllSessions(ctx context.Context, in *Session, opts ...grpc.CallOption) (*Nothing, error) {
+	out := new(Nothing)
+	err := c.cc.Invoke(ctx, SessionManger_RevokeAllSessions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionMangerClient) SendVerifyEmail(ctx context.Context, in *Session, opts ...grpc.CallOption) (*Nothing, error) {
+	out := new(Nothing)
+	err := c.cc.Invoke(ctx, SessionManger_SendVerifyEmail_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionMangerClient) VerifyEmail(ctx context.Context, in *Token, opts ...grpc.CallOption) (*Nothing, error) {
+	out := new(Nothing)
+	err := c.cc.Invoke(ctx, SessionManger_VerifyEmail_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionMangerClient) RequestPasswordReset(ctx context.Context, in *Email, opts ...grpc.CallOption) (*Nothing, error) {
+	out := new(Nothing)
+	err := c.cc.Invoke(ctx, SessionManger_RequestPasswordReset_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionMangerClient) ResetPassword(ctx context.Context, in *PasswordReset, opts ...grpc.CallOption) (*Nothing, error) {
+	out := new(Nothing)
+	err := c.cc.Invoke(ctx, SessionManger_ResetPassword_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionMangerClient) ChangePassword(ctx context.Context, in *PasswordChange, opts ...grpc.CallOption) (*Nothing, error) {
+	out := new(Nothing)
+	err := c.cc.Invoke(ctx, SessionManger_ChangePassword_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionMangerClient) CheckPassword(ctx context.Context, in *PasswordCheck, opts ...grpc.CallOption) (*Nothing, error) {
+	out := new(Nothing)
+	err := c.cc.Invoke(ctx, SessionManger_CheckPassword_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionMangerClient) GetOAuthURL(ctx context.Context, in *OAuthProvider, opts ...grpc.CallOption) (*OAuthURL, error) {
+	out := new(OAuthURL)
+	err := c.cc.Invoke(ctx, SessionManger_GetOAuthURL_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionMangerClient) LoginOAuth(ctx context.Context, in *OAuthCode, opts ...grpc.CallOption) (*Session, error) {
+	out := new(Session)
+	err := c.cc.Invoke(ctx, SessionManger_LoginOAuth_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SessionMangerServer is the server API for SessionManger service.
 // All implementations must embed UnimplementedSessionMangerServer
 // for forward compatibility
@@ -87,6 +219,18 @@ type SessionMangerServer interface {
 	Check(context.Context, *Session) (*UserID, error)
 	Create(context.Context, *User) (*Session, error)
 	Delete(context.Context, *Session) (*Session, error)
+	Refresh(context.Context, *Session) (*Session, error)
+	ListSessions(context.Context, *Session) (*SessionList, error)
+	RevokeSession(context.Context, *RevokeSessionRequest) (*Nothing, error)
+	RevokeAllSessions(context.Context, *Session) (*Nothing, error)
+	SendVerifyEmail(context.Context, *Session) (*Nothing, error)
+	VerifyEmail(context.Context, *Token) (*Nothing, error)
+	RequestPasswordReset(context.Context, *Email) (*Nothing, error)
+	ResetPassword(context.Context, *PasswordReset) (*Nothing, error)
+	ChangePassword(context.Context, *PasswordChange) (*Nothing, error)
+	CheckPassword(context.Context, *PasswordCheck) (*Nothing, error)
+	GetOAuthURL(context.Context, *OAuthProvider) (*OAuthURL, error)
+	LoginOAuth(context.Context, *OAuthCode) (*Session, error)
 	mustEmbedUnimplementedSessionMangerServer()
 }
 
@@ -106,6 +250,42 @@ func (UnimplementedSessionMangerServer) Create(context.Context, *User) (*Session
 func (UnimplementedSessionMangerServer) Delete(context.Context, *Session) (*Session, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedSessionMangerServer) Refresh(context.Context, *Session) (*Session, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Refresh not implemented")
+}
+func (UnimplementedSessionMangerServer) ListSessions(context.Context, *Session) (*SessionList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (UnimplementedSessionMangerServer) RevokeSession(context.Context, *RevokeSessionRequest) (*Nothing, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeSession not implemented")
+}
+func (UnimplementedSessionMangerServer) RevokeAllSessions(context.Context, *Session) (*Nothing, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAllSessions not implemented")
+}
+func (UnimplementedSessionMangerServer) SendVerifyEmail(context.Context, *Session) (*Nothing, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendVerifyEmail not implemented")
+}
+func (UnimplementedSessionMangerServer) VerifyEmail(context.Context, *Token) (*Nothing, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyEmail not implemented")
+}
+func (UnimplementedSessionMangerServer) RequestPasswordReset(context.Context, *Email) (*Nothing, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestPasswordReset not implemented")
+}
+func (UnimplementedSessionMangerServer) ResetPassword(context.Context, *PasswordReset) (*Nothing, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetPassword not implemented")
+}
+func (UnimplementedSessionMangerServer) ChangePassword(context.Context, *PasswordChange) (*Nothing, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
+}
+func (UnimplementedSessionMangerServer) CheckPassword(context.Context, *PasswordCheck) (*Nothing, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckPassword not implemented")
+}
+func (UnimplementedSessionMangerServer) GetOAuthURL(context.Context, *OAuthProvider) (*OAuthURL, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOAuthURL not implemented")
+}
+func (UnimplementedSessionMangerServer) LoginOAuth(context.Context, *OAuthCode) (*Session, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoginOAuth not implemented")
+}
 func (UnimplementedSessionMangerServer) mustEmbedUnimplementedSessionMangerServer() {}
 
 // UnsafeSessionMangerServer may be embedded to opt out of forward compatibility for this service.
@@ -191,6 +371,222 @@ func _SessionManger_Delete_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SessionManger_Refresh_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Session)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionMangerServer).Refresh(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SessionManger_Refresh_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionMangerServer).Refresh(ctx, req.(*Session))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionManger_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Session)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionMangerServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SessionManger_ListSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionMangerServer).ListSessions(ctx, req.(*Session))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionManger_RevokeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionMangerServer).RevokeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SessionManger_RevokeSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionMangerServer).RevokeSession(ctx, req.(*RevokeSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionManger_RevokeAllSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Session)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionMangerServer).RevokeAllSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SessionManger_RevokeAllSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionMangerServer).RevokeAllSessions(ctx, req.(*Session))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionManger_SendVerifyEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Session)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionMangerServer).SendVerifyEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SessionManger_SendVerifyEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionMangerServer).SendVerifyEmail(ctx, req.(*Session))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionManger_VerifyEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Token)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionMangerServer).VerifyEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SessionManger_VerifyEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionMangerServer).VerifyEmail(ctx, req.(*Token))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionManger_RequestPasswordReset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Email)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionMangerServer).RequestPasswordReset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SessionManger_RequestPasswordReset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionMangerServer).RequestPasswordReset(ctx, req.(*Email))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionManger_ResetPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PasswordReset)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionMangerServer).ResetPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SessionManger_ResetPassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionMangerServer).ResetPassword(ctx, req.(*PasswordReset))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionManger_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PasswordChange)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionMangerServer).ChangePassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SessionManger_ChangePassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionMangerServer).ChangePassword(ctx, req.(*PasswordChange))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionManger_CheckPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PasswordCheck)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionMangerServer).CheckPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SessionManger_CheckPassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionMangerServer).CheckPassword(ctx, req.(*PasswordCheck))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionManger_GetOAuthURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OAuthProvider)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionMangerServer).GetOAuthURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SessionManger_GetOAuthURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionMangerServer).GetOAuthURL(ctx, req.(*OAuthProvider))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionManger_LoginOAuth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OAuthCode)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionMangerServer).LoginOAuth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SessionManger_LoginOAuth_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionMangerServer).LoginOAuth(ctx, req.(*OAuthCode))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SessionManger_ServiceDesc is the grpc.ServiceDesc for SessionManger service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -214,6 +610,54 @@ var SessionManger_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _SessionManger_Delete_Handler,
 		},
+		{
+			MethodName: "Refresh",
+			Handler:    _SessionManger_Refresh_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _SessionManger_ListSessions_Handler,
+		},
+		{
+			MethodName: "RevokeSession",
+			Handler:    _SessionManger_RevokeSession_Handler,
+		},
+		{
+			MethodName: "RevokeAllSessions",
+			Handler:    _SessionManger_RevokeAllSessions_Handler,
+		},
+		{
+			MethodName: "SendVerifyEmail",
+			Handler:    _SessionManger_SendVerifyEmail_Handler,
+		},
+		{
+			MethodName: "VerifyEmail",
+			Handler:    _SessionManger_VerifyEmail_Handler,
+		},
+		{
+			MethodName: "RequestPasswordReset",
+			Handler:    _SessionManger_RequestPasswordReset_Handler,
+		},
+		{
+			MethodName: "ResetPassword",
+			Handler:    _SessionManger_ResetPassword_Handler,
+		},
+		{
+			MethodName: "ChangePassword",
+			Handler:    _SessionManger_ChangePassword_Handler,
+		},
+		{
+			MethodName: "CheckPassword",
+			Handler:    _SessionManger_CheckPassword_Handler,
+		},
+		{
+			MethodName: "GetOAuthURL",
+			Handler:    _SessionManger_GetOAuthURL_Handler,
+		},
+		{
+			MethodName: "LoginOAuth",
+			Handler:    _SessionManger_LoginOAuth_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/auth/auth.proto",
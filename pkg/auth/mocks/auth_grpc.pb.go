@@ -40,6 +40,26 @@ func (m *MockSessionMangerClient) EXPECT() *MockSessionMangerClientMockRecorder
 	return m.recorder
 }
 
+// ChangePassword mocks base method.
+func (m *MockSessionMangerClient) ChangePassword(ctx context.Context, in *auth.PasswordChange, opts ...grpc.CallOption) (*auth.Nothing, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ChangePassword", varargs...)
+	ret0, _ := ret[0].(*auth.Nothing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockSessionMangerClientMockRecorder) ChangePassword(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockSessionMangerClient)(nil).ChangePassword), varargs...)
+}
+
 // Check mocks base method.
 func (m *MockSessionMangerClient) Check(ctx context.Context, in *auth.Session, opts ...grpc.CallOption) (*auth.UserID, error) {
 	m.ctrl.T.Helper()
@@ -60,6 +80,26 @@ func (mr *MockSessionMangerClientMockRecorder) Check(ctx, in any, opts ...any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockSessionMangerClient)(nil).Check), varargs...)
 }
 
+// CheckPassword mocks base method.
+func (m *MockSessionMangerClient) CheckPassword(ctx context.Context, in *auth.PasswordCheck, opts ...grpc.CallOption) (*auth.Nothing, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CheckPassword", varargs...)
+	ret0, _ := ret[0].(*auth.Nothing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckPassword indicates an expected call of CheckPassword.
+func (mr *MockSessionMangerClientMockRecorder) CheckPassword(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPassword", reflect.TypeOf((*MockSessionMangerClient)(nil).CheckPassword), varargs...)
+}
+
 // Create mocks base method.
 func (m *MockSessionMangerClient) Create(ctx context.Context, in *auth.User, opts ...grpc.CallOption) (*auth.Session, error) {
 	m.ctrl.T.Helper()
@@ -100,6 +140,46 @@ func (mr *MockSessionMangerClientMockRecorder) Delete(ctx, in any, opts ...any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSessionMangerClient)(nil).Delete), varargs...)
 }
 
+// GetOAuthURL mocks base method.
+func (m *MockSessionMangerClient) GetOAuthURL(ctx context.Context, in *auth.OAuthProvider, opts ...grpc.CallOption) (*auth.OAuthURL, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetOAuthURL", varargs...)
+	ret0, _ := ret[0].(*auth.OAuthURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOAuthURL indicates an expected call of GetOAuthURL.
+func (mr *MockSessionMangerClientMockRecorder) GetOAuthURL(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOAuthURL", reflect.TypeOf((*MockSessionMangerClient)(nil).GetOAuthURL), varargs...)
+}
+
+// ListSessions mocks base method.
+func (m *MockSessionMangerClient) ListSessions(ctx context.Context, in *auth.Session, opts ...grpc.CallOption) (*auth.SessionList, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListSessions", varargs...)
+	ret0, _ := ret[0].(*auth.SessionList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockSessionMangerClientMockRecorder) ListSessions(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockSessionMangerClient)(nil).ListSessions), varargs...)
+}
+
 // Login mocks base method.
 func (m *MockSessionMangerClient) Login(ctx context.Context, in *auth.User, opts ...grpc.CallOption) (*auth.Session, error) {
 	m.ctrl.T.Helper()
@@ -120,6 +200,166 @@ func (mr *MockSessionMangerClientMockRecorder) Login(ctx, in any, opts ...any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockSessionMangerClient)(nil).Login), varargs...)
 }
 
+// LoginOAuth mocks base method.
+func (m *MockSessionMangerClient) LoginOAuth(ctx context.Context, in *auth.OAuthCode, opts ...grpc.CallOption) (*auth.Session, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "LoginOAuth", varargs...)
+	ret0, _ := ret[0].(*auth.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginOAuth indicates an expected call of LoginOAuth.
+func (mr *MockSessionMangerClientMockRecorder) LoginOAuth(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginOAuth", reflect.TypeOf((*MockSessionMangerClient)(nil).LoginOAuth), varargs...)
+}
+
+// Refresh mocks base method.
+func (m *MockSessionMangerClient) Refresh(ctx context.Context, in *auth.Session, opts ...grpc.CallOption) (*auth.Session, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Refresh", varargs...)
+	ret0, _ := ret[0].(*auth.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockSessionMangerClientMockRecorder) Refresh(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockSessionMangerClient)(nil).Refresh), varargs...)
+}
+
+// RequestPasswordReset mocks base method.
+func (m *MockSessionMangerClient) RequestPasswordReset(ctx context.Context, in *auth.Email, opts ...grpc.CallOption) (*auth.Nothing, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RequestPasswordReset", varargs...)
+	ret0, _ := ret[0].(*auth.Nothing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestPasswordReset indicates an expected call of RequestPasswordReset.
+func (mr *MockSessionMangerClientMockRecorder) RequestPasswordReset(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*MockSessionMangerClient)(nil).RequestPasswordReset), varargs...)
+}
+
+// ResetPassword mocks base method.
+func (m *MockSessionMangerClient) ResetPassword(ctx context.Context, in *auth.PasswordReset, opts ...grpc.CallOption) (*auth.Nothing, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ResetPassword", varargs...)
+	ret0, _ := ret[0].(*auth.Nothing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockSessionMangerClientMockRecorder) ResetPassword(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockSessionMangerClient)(nil).ResetPassword), varargs...)
+}
+
+// RevokeAllSessions mocks base method.
+func (m *MockSessionMangerClient) RevokeAllSessions(ctx context.Context, in *auth.Session, opts ...grpc.CallOption) (*auth.Nothing, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RevokeAllSessions", varargs...)
+	ret0, _ := ret[0].(*auth.Nothing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAllSessions indicates an expected call of RevokeAllSessions.
+func (mr *MockSessionMangerClientMockRecorder) RevokeAllSessions(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllSessions", reflect.TypeOf((*MockSessionMangerClient)(nil).RevokeAllSessions), varargs...)
+}
+
+// RevokeSession mocks base method.
+func (m *MockSessionMangerClient) RevokeSession(ctx context.Context, in *auth.RevokeSessionRequest, opts ...grpc.CallOption) (*auth.Nothing, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RevokeSession", varargs...)
+	ret0, _ := ret[0].(*auth.Nothing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockSessionMangerClientMockRecorder) RevokeSession(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockSessionMangerClient)(nil).RevokeSession), varargs...)
+}
+
+// SendVerifyEmail mocks base method.
+func (m *MockSessionMangerClient) SendVerifyEmail(ctx context.Context, in *auth.Session, opts ...grpc.CallOption) (*auth.Nothing, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SendVerifyEmail", varargs...)
+	ret0, _ := ret[0].(*auth.Nothing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendVerifyEmail indicates an expected call of SendVerifyEmail.
+func (mr *MockSessionMangerClientMockRecorder) SendVerifyEmail(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendVerifyEmail", reflect.TypeOf((*MockSessionMangerClient)(nil).SendVerifyEmail), varargs...)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockSessionMangerClient) VerifyEmail(ctx context.Context, in *auth.Token, opts ...grpc.CallOption) (*auth.Nothing, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "VerifyEmail", varargs...)
+	ret0, _ := ret[0].(*auth.Nothing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockSessionMangerClientMockRecorder) VerifyEmail(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockSessionMangerClient)(nil).VerifyEmail), varargs...)
+}
+
 // MockSessionMangerServer is a mock of SessionMangerServer interface.
 type MockSessionMangerServer struct {
 	ctrl     *gomock.Controller
@@ -143,6 +383,21 @@ func (m *MockSessionMangerServer) EXPECT() *MockSessionMangerServerMockRecorder
 	return m.recorder
 }
 
+// ChangePassword mocks base method.
+func (m *MockSessionMangerServer) ChangePassword(arg0 context.Context, arg1 *auth.PasswordChange) (*auth.Nothing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", arg0, arg1)
+	ret0, _ := ret[0].(*auth.Nothing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockSessionMangerServerMockRecorder) ChangePassword(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockSessionMangerServer)(nil).ChangePassword), arg0, arg1)
+}
+
 // Check mocks base method.
 func (m *MockSessionMangerServer) Check(arg0 context.Context, arg1 *auth.Session) (*auth.UserID, error) {
 	m.ctrl.T.Helper()
@@ -158,6 +413,21 @@ func (mr *MockSessionMangerServerMockRecorder) Check(arg0, arg1 any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockSessionMangerServer)(nil).Check), arg0, arg1)
 }
 
+// CheckPassword mocks base method.
+func (m *MockSessionMangerServer) CheckPassword(arg0 context.Context, arg1 *auth.PasswordCheck) (*auth.Nothing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckPassword", arg0, arg1)
+	ret0, _ := ret[0].(*auth.Nothing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckPassword indicates an expected call of CheckPassword.
+func (mr *MockSessionMangerServerMockRecorder) CheckPassword(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPassword", reflect.TypeOf((*MockSessionMangerServer)(nil).CheckPassword), arg0, arg1)
+}
+
 // Create mocks base method.
 func (m *MockSessionMangerServer) Create(arg0 context.Context, arg1 *auth.User) (*auth.Session, error) {
 	m.ctrl.T.Helper()
@@ -188,6 +458,36 @@ func (mr *MockSessionMangerServerMockRecorder) Delete(arg0, arg1 any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSessionMangerServer)(nil).Delete), arg0, arg1)
 }
 
+// GetOAuthURL mocks base method.
+func (m *MockSessionMangerServer) GetOAuthURL(arg0 context.Context, arg1 *auth.OAuthProvider) (*auth.OAuthURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOAuthURL", arg0, arg1)
+	ret0, _ := ret[0].(*auth.OAuthURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOAuthURL indicates an expected call of GetOAuthURL.
+func (mr *MockSessionMangerServerMockRecorder) GetOAuthURL(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOAuthURL", reflect.TypeOf((*MockSessionMangerServer)(nil).GetOAuthURL), arg0, arg1)
+}
+
+// ListSessions mocks base method.
+func (m *MockSessionMangerServer) ListSessions(arg0 context.Context, arg1 *auth.Session) (*auth.SessionList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", arg0, arg1)
+	ret0, _ := ret[0].(*auth.SessionList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockSessionMangerServerMockRecorder) ListSessions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockSessionMangerServer)(nil).ListSessions), arg0, arg1)
+}
+
 // Login mocks base method.
 func (m *MockSessionMangerServer) Login(arg0 context.Context, arg1 *auth.User) (*auth.Session, error) {
 	m.ctrl.T.Helper()
@@ -203,6 +503,126 @@ func (mr *MockSessionMangerServerMockRecorder) Login(arg0, arg1 any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockSessionMangerServer)(nil).Login), arg0, arg1)
 }
 
+// LoginOAuth mocks base method.
+func (m *MockSessionMangerServer) LoginOAuth(arg0 context.Context, arg1 *auth.OAuthCode) (*auth.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginOAuth", arg0, arg1)
+	ret0, _ := ret[0].(*auth.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginOAuth indicates an expected call of LoginOAuth.
+func (mr *MockSessionMangerServerMockRecorder) LoginOAuth(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginOAuth", reflect.TypeOf((*MockSessionMangerServer)(nil).LoginOAuth), arg0, arg1)
+}
+
+// Refresh mocks base method.
+func (m *MockSessionMangerServer) Refresh(arg0 context.Context, arg1 *auth.Session) (*auth.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", arg0, arg1)
+	ret0, _ := ret[0].(*auth.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockSessionMangerServerMockRecorder) Refresh(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockSessionMangerServer)(nil).Refresh), arg0, arg1)
+}
+
+// RequestPasswordReset mocks base method.
+func (m *MockSessionMangerServer) RequestPasswordReset(arg0 context.Context, arg1 *auth.Email) (*auth.Nothing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPasswordReset", arg0, arg1)
+	ret0, _ := ret[0].(*auth.Nothing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestPasswordReset indicates an expected call of RequestPasswordReset.
+func (mr *MockSessionMangerServerMockRecorder) RequestPasswordReset(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*MockSessionMangerServer)(nil).RequestPasswordReset), arg0, arg1)
+}
+
+// ResetPassword mocks base method.
+func (m *MockSessionMangerServer) ResetPassword(arg0 context.Context, arg1 *auth.PasswordReset) (*auth.Nothing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", arg0, arg1)
+	ret0, _ := ret[0].(*auth.Nothing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockSessionMangerServerMockRecorder) ResetPassword(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockSessionMangerServer)(nil).ResetPassword), arg0, arg1)
+}
+
+// RevokeAllSessions mocks base method.
+func (m *MockSessionMangerServer) RevokeAllSessions(arg0 context.Context, arg1 *auth.Session) (*auth.Nothing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAllSessions", arg0, arg1)
+	ret0, _ := ret[0].(*auth.Nothing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAllSessions indicates an expected call of RevokeAllSessions.
+func (mr *MockSessionMangerServerMockRecorder) RevokeAllSessions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllSessions", reflect.TypeOf((*MockSessionMangerServer)(nil).RevokeAllSessions), arg0, arg1)
+}
+
+// RevokeSession mocks base method.
+func (m *MockSessionMangerServer) RevokeSession(arg0 context.Context, arg1 *auth.RevokeSessionRequest) (*auth.Nothing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", arg0, arg1)
+	ret0, _ := ret[0].(*auth.Nothing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockSessionMangerServerMockRecorder) RevokeSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockSessionMangerServer)(nil).RevokeSession), arg0, arg1)
+}
+
+// SendVerifyEmail mocks base method.
+func (m *MockSessionMangerServer) SendVerifyEmail(arg0 context.Context, arg1 *auth.Session) (*auth.Nothing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendVerifyEmail", arg0, arg1)
+	ret0, _ := ret[0].(*auth.Nothing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendVerifyEmail indicates an expected call of SendVerifyEmail.
+func (mr *MockSessionMangerServerMockRecorder) SendVerifyEmail(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendVerifyEmail", reflect.TypeOf((*MockSessionMangerServer)(nil).SendVerifyEmail), arg0, arg1)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockSessionMangerServer) VerifyEmail(arg0 context.Context, arg1 *auth.Token) (*auth.Nothing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", arg0, arg1)
+	ret0, _ := ret[0].(*auth.Nothing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockSessionMangerServerMockRecorder) VerifyEmail(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockSessionMangerServer)(nil).VerifyEmail), arg0, arg1)
+}
+
 // mustEmbedUnimplementedSessionMangerServer mocks base method.
 func (m *MockSessionMangerServer) mustEmbedUnimplementedSessionMangerServer() {
 	m.ctrl.T.Helper()
@@ -0,0 +1,25 @@
+package auth
+
+// Roles of users, role is carried in jwt and returned by Check.
+// Token without role belongs to RoleUser.
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+func rankRole(role string) int {
+	switch role {
+	case RoleAdmin:
+		return 2 //nolint:gomnd
+	case RoleModerator:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// HasRole admin has all rights of moderator and moderator has all rights of user.
+func HasRole(role string, requiredRole string) bool {
+	return rankRole(role) >= rankRole(requiredRole)
+}
@@ -1,6 +1,10 @@
 package config
 
-import "os"
+import (
+	"os"
+	"strconv"
+	"time"
+)
 
 const (
 	// Main .env.
@@ -59,3 +63,33 @@ func GetEnvStr(name string, defaultValue string) string {
 
 	return result
 }
+
+// GetEnvDuration parses value like "30m" or "1h30m". Invalid value is replaced with defaultValue.
+func GetEnvDuration(name string, defaultValue time.Duration) time.Duration {
+	result, ok := os.LookupEnv(name)
+	if !ok {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(result)
+	if err != nil || duration <= 0 {
+		return defaultValue
+	}
+
+	return duration
+}
+
+// GetEnvUint parses not negative integer. Invalid value is replaced with defaultValue.
+func GetEnvUint(name string, defaultValue uint64, bitSize int) uint64 {
+	result, ok := os.LookupEnv(name)
+	if !ok {
+		return defaultValue
+	}
+
+	value, err := strconv.ParseUint(result, 10, bitSize)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
@@ -152,6 +152,230 @@ func (x *CheckedURLs) GetCorrect() []bool {
 	return nil
 }
 
+// ImgVariants urls of reduced copies of one image. If image was uploaded before
+// generation of variants, all urls are equal to url of original.
+type ImgVariants struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Thumbnail string `protobuf:"bytes,1,opt,name=thumbnail,proto3" json:"thumbnail,omitempty"`
+	Card      string `protobuf:"bytes,2,opt,name=card,proto3" json:"card,omitempty"`
+	Full      string `protobuf:"bytes,3,opt,name=full,proto3" json:"full,omitempty"`
+}
+
+func (x *ImgVariants) Reset() {
+	*x = ImgVariants{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_file_service_file_service_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImgVariants) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImgVariants) ProtoMessage() {}
+
+func (x *ImgVariants) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_file_service_file_service_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImgVariants.ProtoReflect.Descriptor instead.
+func (*ImgVariants) Descriptor() ([]byte, []int) {
+	return file_pkg_file_service_file_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ImgVariants) GetThumbnail() string {
+	if x != nil {
+		return x.Thumbnail
+	}
+	return ""
+}
+
+func (x *ImgVariants) GetCard() string {
+	if x != nil {
+		return x.Card
+	}
+	return ""
+}
+
+func (x *ImgVariants) GetFull() string {
+	if x != nil {
+		return x.Full
+	}
+	return ""
+}
+
+type ImgVariantsList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Variants []*ImgVariants `protobuf:"bytes,1,rep,name=variants,proto3" json:"variants,omitempty"`
+}
+
+func (x *ImgVariantsList) Reset() {
+	*x = ImgVariantsList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_file_service_file_service_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImgVariantsList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImgVariantsList) ProtoMessage() {}
+
+func (x *ImgVariantsList) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_file_service_file_service_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImgVariantsList.ProtoReflect.Descriptor instead.
+func (*ImgVariantsList) Descriptor() ([]byte, []int) {
+	return file_pkg_file_service_file_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ImgVariantsList) GetVariants() []*ImgVariants {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+// ImgOwner entity, which uses images: product or user with avatar.
+type ImgOwner struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Id   uint64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *ImgOwner) Reset() {
+	*x = ImgOwner{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_file_service_file_service_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImgOwner) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImgOwner) ProtoMessage() {}
+
+func (x *ImgOwner) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_file_service_file_service_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImgOwner.ProtoReflect.Descriptor instead.
+func (*ImgOwner) Descriptor() ([]byte, []int) {
+	return file_pkg_file_service_file_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ImgOwner) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ImgOwner) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// ImgRefs all images used by owner.
+type ImgRefs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Owner *ImgOwner `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	Url   []string  `protobuf:"bytes,2,rep,name=url,proto3" json:"url,omitempty"`
+}
+
+func (x *ImgRefs) Reset() {
+	*x = ImgRefs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_file_service_file_service_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImgRefs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImgRefs) ProtoMessage() {}
+
+func (x *ImgRefs) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_file_service_file_service_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImgRefs.ProtoReflect.Descriptor instead.
+func (*ImgRefs) Descriptor() ([]byte, []int) {
+	return file_pkg_file_service_file_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ImgRefs) GetOwner() *ImgOwner {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+func (x *ImgRefs) GetUrl() []string {
+	if x != nil {
+		return x.Url
+	}
+	return nil
+}
+
 var File_pkg_file_service_file_service_proto protoreflect.FileDescriptor
 
 var file_pkg_file_service_file_service_proto_rawDesc = []byte{
@@ -163,13 +387,42 @@ var file_pkg_file_service_file_service_proto_rawDesc = []byte{
 	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0x27, 0x0a, 0x0b, 0x43, 0x68,
 	0x65, 0x63, 0x6b, 0x65, 0x64, 0x55, 0x52, 0x4c, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x72,
 	0x72, 0x65, 0x63, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x08, 0x52, 0x07, 0x63, 0x6f, 0x72, 0x72,
-	0x65, 0x63, 0x74, 0x32, 0x48, 0x0a, 0x0b, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x12, 0x39, 0x0a, 0x05, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x14, 0x2e, 0x66, 0x69,
-	0x6c, 0x65, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x49, 0x6d, 0x67, 0x55, 0x52, 0x4c,
-	0x73, 0x1a, 0x18, 0x2e, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
-	0x43, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x55, 0x52, 0x4c, 0x73, 0x22, 0x00, 0x42, 0x10, 0x5a,
-	0x0e, 0x2e, 0x2f, 0x3b, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x63, 0x74, 0x22, 0x53, 0x0a, 0x0b, 0x49, 0x6d, 0x67, 0x56, 0x61, 0x72, 0x69, 0x61, 0x6e,
+	0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68, 0x75, 0x6d, 0x62, 0x6e, 0x61, 0x69, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x68, 0x75, 0x6d, 0x62, 0x6e, 0x61, 0x69, 0x6c,
+	0x12, 0x12, 0x0a, 0x04, 0x63, 0x61, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x63, 0x61, 0x72, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x75, 0x6c, 0x6c, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x66, 0x75, 0x6c, 0x6c, 0x22, 0x47, 0x0a, 0x0f, 0x49, 0x6d, 0x67, 0x56,
+	0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x08, 0x76,
+	0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x66, 0x69, 0x6c, 0x65, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x49, 0x6d, 0x67, 0x56,
+	0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x73, 0x52, 0x08, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74,
+	0x73, 0x22, 0x2e, 0x0a, 0x08, 0x49, 0x6d, 0x67, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69,
+	0x64, 0x22, 0x48, 0x0a, 0x07, 0x49, 0x6d, 0x67, 0x52, 0x65, 0x66, 0x73, 0x12, 0x2b, 0x0a, 0x05,
+	0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x66, 0x69,
+	0x6c, 0x65, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x49, 0x6d, 0x67, 0x4f, 0x77, 0x6e,
+	0x65, 0x72, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x32, 0xfe, 0x01, 0x0a, 0x0b,
+	0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x39, 0x0a, 0x05, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x12, 0x14, 0x2e, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x49, 0x6d, 0x67, 0x55, 0x52, 0x4c, 0x73, 0x1a, 0x18, 0x2e, 0x66, 0x69, 0x6c,
+	0x65, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64,
+	0x55, 0x52, 0x4c, 0x73, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x56, 0x61, 0x72,
+	0x69, 0x61, 0x6e, 0x74, 0x73, 0x12, 0x14, 0x2e, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x49, 0x6d, 0x67, 0x55, 0x52, 0x4c, 0x73, 0x1a, 0x1c, 0x2e, 0x66, 0x69,
+	0x6c, 0x65, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x49, 0x6d, 0x67, 0x56, 0x61, 0x72,
+	0x69, 0x61, 0x6e, 0x74, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x06, 0x41,
+	0x74, 0x74, 0x61, 0x63, 0x68, 0x12, 0x14, 0x2e, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x49, 0x6d, 0x67, 0x52, 0x65, 0x66, 0x73, 0x1a, 0x14, 0x2e, 0x66, 0x69,
+	0x6c, 0x65, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x4e, 0x6f, 0x74, 0x68, 0x69, 0x6e,
+	0x67, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x06, 0x44, 0x65, 0x74, 0x61, 0x63, 0x68, 0x12, 0x15, 0x2e,
+	0x66, 0x69, 0x6c, 0x65, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x49, 0x6d, 0x67, 0x4f,
+	0x77, 0x6e, 0x65, 0x72, 0x1a, 0x14, 0x2e, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x4e, 0x6f, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x22, 0x00, 0x42, 0x10, 0x5a, 0x0e,
+	0x2e, 0x2f, 0x3b, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -184,20 +437,32 @@ func file_pkg_file_service_file_service_proto_rawDescGZIP() []byte {
 	return file_pkg_file_service_file_service_proto_rawDescData
 }
 
-var file_pkg_file_service_file_service_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_pkg_file_service_file_service_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_pkg_file_service_file_service_proto_goTypes = []interface{}{
-	(*Nothing)(nil),     // 0: fileservice.Nothing
-	(*ImgURLs)(nil),     // 1: fileservice.ImgURLs
-	(*CheckedURLs)(nil), // 2: fileservice.CheckedURLs
+	(*Nothing)(nil),         // 0: fileservice.Nothing
+	(*ImgURLs)(nil),         // 1: fileservice.ImgURLs
+	(*CheckedURLs)(nil),     // 2: fileservice.CheckedURLs
+	(*ImgVariants)(nil),     // 3: fileservice.ImgVariants
+	(*ImgVariantsList)(nil), // 4: fileservice.ImgVariantsList
+	(*ImgOwner)(nil),        // 5: fileservice.ImgOwner
+	(*ImgRefs)(nil),         // 6: fileservice.ImgRefs
 }
 var file_pkg_file_service_file_service_proto_depIdxs = []int32{
-	1, // 0: fileservice.FileService.Check:input_type -> fileservice.ImgURLs
-	2, // 1: fileservice.FileService.Check:output_type -> fileservice.CheckedURLs
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	3, // 0: fileservice.ImgVariantsList.variants:type_name -> fileservice.ImgVariants
+	5, // 1: fileservice.ImgRefs.owner:type_name -> fileservice.ImgOwner
+	1, // 2: fileservice.FileService.Check:input_type -> fileservice.ImgURLs
+	1, // 3: fileservice.FileService.GetVariants:input_type -> fileservice.ImgURLs
+	6, // 4: fileservice.FileService.Attach:input_type -> fileservice.ImgRefs
+	5, // 5: fileservice.FileService.Detach:input_type -> fileservice.ImgOwner
+	2, // 6: fileservice.FileService.Check:output_type -> fileservice.CheckedURLs
+	4, // 7: fileservice.FileService.GetVariants:output_type -> fileservice.ImgVariantsList
+	0, // 8: fileservice.FileService.Attach:output_type -> fileservice.Nothing
+	0, // 9: fileservice.FileService.Detach:output_type -> fileservice.Nothing
+	6, // [6:10] is the sub-list for method output_type
+	2, // [2:6] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_pkg_file_service_file_service_proto_init() }
@@ -242,6 +507,54 @@ func file_pkg_file_service_file_service_proto_init() {
 				return nil
 			}
 		}
+		file_pkg_file_service_file_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImgVariants); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_file_service_file_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImgVariantsList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_file_service_file_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImgOwner); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_file_service_file_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImgRefs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -249,7 +562,7 @@ func file_pkg_file_service_file_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pkg_file_service_file_service_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
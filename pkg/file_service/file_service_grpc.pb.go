@@ -19,7 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	FileService_Check_FullMethodName = "/fileservice.FileService/Check"
+	FileService_Check_FullMethodName       = "/fileservice.FileService/Check"
+	FileService_GetVariants_FullMethodName = "/fileservice.FileService/GetVariants"
+	FileService_Attach_FullMethodName      = "/fileservice.FileService/Attach"
+	FileService_Detach_FullMethodName      = "/fileservice.FileService/Detach"
 )
 
 // FileServiceClient is the client API for FileService service.
@@ -27,6 +30,10 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type FileServiceClient interface {
 	Check(ctx context.Context, in *ImgURLs, opts ...grpc.CallOption) (*CheckedURLs, error)
+	GetVariants(ctx context.Context, in *ImgURLs, opts ...grpc.CallOption) (*ImgVariantsList, error)
+	// Attach replaces images of owner, images without owners are deleted by garbage collector.
+	Attach(ctx context.Context, in *ImgRefs, opts ...grpc.CallOption) (*Nothing, error)
+	Detach(ctx context.Context, in *ImgOwner, opts ...grpc.CallOption) (*Nothing, error)
 }
 
 type fileServiceClient struct {
@@ -46,11 +53,42 @@ func (c *fileServiceClient) Check(ctx context.Context, in *ImgURLs, opts ...grpc
 	return out, nil
 }
 
+func (c *fileServiceClient) GetVariants(ctx context.Context, in *ImgURLs, opts ...grpc.CallOption) (*ImgVariantsList, error) {
+	out := new(ImgVariantsList)
+	err := c.cc.Invoke(ctx, FileService_GetVariants_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) Attach(ctx context.Context, in *ImgRefs, opts ...grpc.CallOption) (*Nothing, error) {
+	out := new(Nothing)
+	err := c.cc.Invoke(ctx, FileService_Attach_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) Detach(ctx context.Context, in *ImgOwner, opts ...grpc.CallOption) (*Nothing, error) {
+	out := new(Nothing)
+	err := c.cc.Invoke(ctx, FileService_Detach_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FileServiceServer is the server API for FileService service.
 // All implementations must embed UnimplementedFileServiceServer
 // for forward compatibility
 type FileServiceServer interface {
 	Check(context.Context, *ImgURLs) (*CheckedURLs, error)
+	GetVariants(context.Context, *ImgURLs) (*ImgVariantsList, error)
+	// Attach replaces images of owner, images without owners are deleted by garbage collector.
+	Attach(context.Context, *ImgRefs) (*Nothing, error)
+	Detach(context.Context, *ImgOwner) (*Nothing, error)
 	mustEmbedUnimplementedFileServiceServer()
 }
 
@@ -61,6 +99,15 @@ type UnimplementedFileServiceServer struct {
 func (UnimplementedFileServiceServer) Check(context.Context, *ImgURLs) (*CheckedURLs, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Check not implemented")
 }
+func (UnimplementedFileServiceServer) GetVariants(context.Context, *ImgURLs) (*ImgVariantsList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVariants not implemented")
+}
+func (UnimplementedFileServiceServer) Attach(context.Context, *ImgRefs) (*Nothing, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Attach not implemented")
+}
+func (UnimplementedFileServiceServer) Detach(context.Context, *ImgOwner) (*Nothing, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Detach not implemented")
+}
 func (UnimplementedFileServiceServer) mustEmbedUnimplementedFileServiceServer() {}
 
 // UnsafeFileServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -92,6 +139,60 @@ func _FileService_Check_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FileService_GetVariants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImgURLs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).GetVariants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_GetVariants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).GetVariants(ctx, req.(*ImgURLs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_Attach_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImgRefs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).Attach(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_Attach_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).Attach(ctx, req.(*ImgRefs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_Detach_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImgOwner)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).Detach(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_Detach_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).Detach(ctx, req.(*ImgOwner))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FileService_ServiceDesc is the grpc.ServiceDesc for FileService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -103,6 +204,18 @@ var FileService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Check",
 			Handler:    _FileService_Check_Handler,
 		},
+		{
+			MethodName: "GetVariants",
+			Handler:    _FileService_GetVariants_Handler,
+		},
+		{
+			MethodName: "Attach",
+			Handler:    _FileService_Attach_Handler,
+		},
+		{
+			MethodName: "Detach",
+			Handler:    _FileService_Detach_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/file_service/file_service.proto",
@@ -40,6 +40,26 @@ func (m *MockFileServiceClient) EXPECT() *MockFileServiceClientMockRecorder {
 	return m.recorder
 }
 
+// Attach mocks base method.
+func (m *MockFileServiceClient) Attach(ctx context.Context, in *fileservice.ImgRefs, opts ...grpc.CallOption) (*fileservice.Nothing, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Attach", varargs...)
+	ret0, _ := ret[0].(*fileservice.Nothing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Attach indicates an expected call of Attach.
+func (mr *MockFileServiceClientMockRecorder) Attach(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attach", reflect.TypeOf((*MockFileServiceClient)(nil).Attach), varargs...)
+}
+
 // Check mocks base method.
 func (m *MockFileServiceClient) Check(ctx context.Context, in *fileservice.ImgURLs, opts ...grpc.CallOption) (*fileservice.CheckedURLs, error) {
 	m.ctrl.T.Helper()
@@ -60,6 +80,46 @@ func (mr *MockFileServiceClientMockRecorder) Check(ctx, in any, opts ...any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockFileServiceClient)(nil).Check), varargs...)
 }
 
+// Detach mocks base method.
+func (m *MockFileServiceClient) Detach(ctx context.Context, in *fileservice.ImgOwner, opts ...grpc.CallOption) (*fileservice.Nothing, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Detach", varargs...)
+	ret0, _ := ret[0].(*fileservice.Nothing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Detach indicates an expected call of Detach.
+func (mr *MockFileServiceClientMockRecorder) Detach(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Detach", reflect.TypeOf((*MockFileServiceClient)(nil).Detach), varargs...)
+}
+
+// GetVariants mocks base method.
+func (m *MockFileServiceClient) GetVariants(ctx context.Context, in *fileservice.ImgURLs, opts ...grpc.CallOption) (*fileservice.ImgVariantsList, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetVariants", varargs...)
+	ret0, _ := ret[0].(*fileservice.ImgVariantsList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVariants indicates an expected call of GetVariants.
+func (mr *MockFileServiceClientMockRecorder) GetVariants(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVariants", reflect.TypeOf((*MockFileServiceClient)(nil).GetVariants), varargs...)
+}
+
 // MockFileServiceServer is a mock of FileServiceServer interface.
 type MockFileServiceServer struct {
 	ctrl     *gomock.Controller
@@ -83,6 +143,21 @@ func (m *MockFileServiceServer) EXPECT() *MockFileServiceServerMockRecorder {
 	return m.recorder
 }
 
+// Attach mocks base method.
+func (m *MockFileServiceServer) Attach(arg0 context.Context, arg1 *fileservice.ImgRefs) (*fileservice.Nothing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attach", arg0, arg1)
+	ret0, _ := ret[0].(*fileservice.Nothing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Attach indicates an expected call of Attach.
+func (mr *MockFileServiceServerMockRecorder) Attach(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attach", reflect.TypeOf((*MockFileServiceServer)(nil).Attach), arg0, arg1)
+}
+
 // Check mocks base method.
 func (m *MockFileServiceServer) Check(arg0 context.Context, arg1 *fileservice.ImgURLs) (*fileservice.CheckedURLs, error) {
 	m.ctrl.T.Helper()
@@ -98,6 +173,36 @@ func (mr *MockFileServiceServerMockRecorder) Check(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockFileServiceServer)(nil).Check), arg0, arg1)
 }
 
+// Detach mocks base method.
+func (m *MockFileServiceServer) Detach(arg0 context.Context, arg1 *fileservice.ImgOwner) (*fileservice.Nothing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Detach", arg0, arg1)
+	ret0, _ := ret[0].(*fileservice.Nothing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Detach indicates an expected call of Detach.
+func (mr *MockFileServiceServerMockRecorder) Detach(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Detach", reflect.TypeOf((*MockFileServiceServer)(nil).Detach), arg0, arg1)
+}
+
+// GetVariants mocks base method.
+func (m *MockFileServiceServer) GetVariants(arg0 context.Context, arg1 *fileservice.ImgURLs) (*fileservice.ImgVariantsList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVariants", arg0, arg1)
+	ret0, _ := ret[0].(*fileservice.ImgVariantsList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVariants indicates an expected call of GetVariants.
+func (mr *MockFileServiceServerMockRecorder) GetVariants(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVariants", reflect.TypeOf((*MockFileServiceServer)(nil).GetVariants), arg0, arg1)
+}
+
 // mustEmbedUnimplementedFileServiceServer mocks base method.
 func (m *MockFileServiceServer) mustEmbedUnimplementedFileServiceServer() {
 	m.ctrl.T.Helper()
@@ -0,0 +1,11 @@
+package fileservice
+
+// Types of ImgOwner. Image is kept by garbage collector while it has at least one owner.
+const (
+	OwnerProduct = "product"
+	OwnerUser    = "user"
+)
+
+func IsKnownOwner(ownerType string) bool {
+	return ownerType == OwnerProduct || ownerType == OwnerUser
+}
@@ -15,10 +15,11 @@ type IMetricManagerGrpc interface {
 var _ IMetricManagerGrpc = (*MetricManagerGrpc)(nil)
 
 type MetricManagerGrpc struct {
-	serviceName     string
-	total           *prometheus.CounterVec
-	totalErr        *prometheus.CounterVec
-	durationSummary *prometheus.HistogramVec
+	serviceName       string
+	total             *prometheus.CounterVec
+	totalErr          *prometheus.CounterVec
+	durationSummary   *prometheus.HistogramVec
+	totalLoginFailure *prometheus.CounterVec
 }
 
 func NewMetricManagerGrpc(serviceName string) *MetricManagerGrpc {
@@ -50,11 +51,20 @@ func NewMetricManagerGrpc(serviceName string) *MetricManagerGrpc {
 	)
 	prometheus.MustRegister(durationSummary)
 
+	labelLoginFailureTotal := []string{"service", "reason"}
+	totalLoginFailure := prometheus.NewCounterVec(
+		prometheus.CounterOpts{ //nolint:exhaustruct
+			Name: "login_failure_total",
+			Help: "count_of_failed_login_by_reason",
+		}, labelLoginFailureTotal)
+	prometheus.MustRegister(totalLoginFailure)
+
 	return &MetricManagerGrpc{
-		serviceName:     serviceName,
-		total:           total,
-		totalErr:        totalErr,
-		durationSummary: durationSummary,
+		serviceName:       serviceName,
+		total:             total,
+		totalErr:          totalErr,
+		durationSummary:   durationSummary,
+		totalLoginFailure: totalLoginFailure,
 	}
 }
 
@@ -69,3 +79,8 @@ func (m *MetricManagerGrpc) IncTotalErr(method string) {
 func (m *MetricManagerGrpc) AddDuration(method string, duration time.Duration) {
 	m.durationSummary.WithLabelValues(m.serviceName, method).Observe(duration.Seconds())
 }
+
+// IncTotalLoginFailure reason is e.g. wrong credentials or locked login.
+func (m *MetricManagerGrpc) IncTotalLoginFailure(reason string) {
+	m.totalLoginFailure.WithLabelValues(m.serviceName, reason).Inc()
+}
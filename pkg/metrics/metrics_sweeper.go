@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type IMetricManagerSweeper interface {
+	AddScanned(count int)
+	AddDeleted(count int, bytes int64, dryRun bool)
+	IncErr()
+	SetLastRun(duration time.Duration)
+}
+
+var _ IMetricManagerSweeper = (*MetricManagerSweeper)(nil)
+
+// MetricManagerSweeper metrics of garbage collector of files. In dry run
+// files aren't deleted, but counted with label dry_run="true".
+type MetricManagerSweeper struct {
+	serviceName     string
+	totalScanned    *prometheus.CounterVec
+	totalDeleted    *prometheus.CounterVec
+	totalBytes      *prometheus.CounterVec
+	totalErr        *prometheus.CounterVec
+	lastRunDuration *prometheus.GaugeVec
+	lastRunTime     *prometheus.GaugeVec
+}
+
+func NewMetricManagerSweeper(serviceName string) *MetricManagerSweeper {
+	totalScanned := prometheus.NewCounterVec(
+		prometheus.CounterOpts{ //nolint:exhaustruct
+			Name: "gc_files_scanned_total",
+			Help: "count_of_files_checked_by_gc",
+		}, []string{"service"})
+	prometheus.MustRegister(totalScanned)
+
+	totalDeleted := prometheus.NewCounterVec(
+		prometheus.CounterOpts{ //nolint:exhaustruct
+			Name: "gc_files_deleted_total",
+			Help: "count_of_files_without_owner_deleted_by_gc",
+		}, []string{"service", "dry_run"})
+	prometheus.MustRegister(totalDeleted)
+
+	totalBytes := prometheus.NewCounterVec(
+		prometheus.CounterOpts{ //nolint:exhaustruct
+			Name: "gc_bytes_deleted_total",
+			Help: "size_of_files_deleted_by_gc",
+		}, []string{"service", "dry_run"})
+	prometheus.MustRegister(totalBytes)
+
+	totalErr := prometheus.NewCounterVec(
+		prometheus.CounterOpts{ //nolint:exhaustruct
+			Name: "gc_error_total",
+			Help: "count_of_errors_of_gc",
+		}, []string{"service"})
+	prometheus.MustRegister(totalErr)
+
+	lastRunDuration := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{ //nolint:exhaustruct
+			Name: "gc_last_run_duration_seconds",
+			Help: "duration_of_last_run_of_gc",
+		}, []string{"service"})
+	prometheus.MustRegister(lastRunDuration)
+
+	lastRunTime := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{ //nolint:exhaustruct
+			Name: "gc_last_run_timestamp_seconds",
+			Help: "unix_time_of_end_of_last_run_of_gc",
+		}, []string{"service"})
+	prometheus.MustRegister(lastRunTime)
+
+	return &MetricManagerSweeper{
+		serviceName:     serviceName,
+		totalScanned:    totalScanned,
+		totalDeleted:    totalDeleted,
+		totalBytes:      totalBytes,
+		totalErr:        totalErr,
+		lastRunDuration: lastRunDuration,
+		lastRunTime:     lastRunTime,
+	}
+}
+
+func (m *MetricManagerSweeper) AddScanned(count int) {
+	m.totalScanned.WithLabelValues(m.serviceName).Add(float64(count))
+}
+
+func (m *MetricManagerSweeper) AddDeleted(count int, bytes int64, dryRun bool) {
+	labelDryRun := "false"
+	if dryRun {
+		labelDryRun = "true"
+	}
+
+	m.totalDeleted.WithLabelValues(m.serviceName, labelDryRun).Add(float64(count))
+	m.totalBytes.WithLabelValues(m.serviceName, labelDryRun).Add(float64(bytes))
+}
+
+func (m *MetricManagerSweeper) IncErr() {
+	m.totalErr.WithLabelValues(m.serviceName).Inc()
+}
+
+func (m *MetricManagerSweeper) SetLastRun(duration time.Duration) {
+	m.lastRunDuration.WithLabelValues(m.serviceName).Set(duration.Seconds())
+	m.lastRunTime.WithLabelValues(m.serviceName).SetToCurrentTime()
+}
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+// Actions of admins and moderators, which are written to audit log.
+const (
+	AuditActionBanUser       = "ban_user"
+	AuditActionUnbanUser     = "unban_user"
+	AuditActionCloseProduct  = "close_product"
+	AuditActionDeleteComment = "delete_comment"
+	AuditActionSetPremium    = "set_premium"
+)
+
+// AdminUser is user in list for admins, BannedAt and DeletedAt are nil if user isn't banned or deleted.
+//
+//easyjson:json
+type AdminUser struct {
+	ID        uint64     `json:"id"`
+	Email     string     `json:"email"`
+	Name      string     `json:"name"`
+	Role      string     `json:"role"`
+	CreatedAt time.Time  `json:"created_at"`
+	BannedAt  *time.Time `json:"banned_at"`
+	DeletedAt *time.Time `json:"deleted_at"`
+}
+
+func (a *AdminUser) Sanitize() {
+	sanitizer := bluemonday.UGCPolicy()
+
+	a.Email = sanitizer.Sanitize(a.Email)
+	a.Name = sanitizer.Sanitize(a.Name)
+}
+
+// AdminUserList users are sorted from newest to oldest, NextCursor is empty on the last page.
+//
+//easyjson:json
+type AdminUserList struct {
+	Users      []*AdminUser `json:"users"`
+	NextCursor string       `json:"next_cursor"`
+}
+
+// AdminAction TargetID is id of user, product or comment depending on action.
+// Reason is written to audit log.
+//
+//easyjson:json
+type AdminAction struct {
+	TargetID uint64 `json:"target_id" valid:"required~Не указан id"`
+	Reason   string `json:"reason"    valid:"required~Укажите причину,length(1|1000)~Причина должна быть не длиннее 1000 символов"` //nolint:lll
+}
+
+func (a *AdminAction) Trim() {
+	a.Reason = strings.TrimFunc(a.Reason, unicode.IsSpace)
+}
+
+// AdminPremium Days is count of days of premium since now, zero days removes premium.
+//
+//easyjson:json
+type AdminPremium struct {
+	ProductID uint64 `json:"product_id" valid:"required~Не указан id объявления"`
+	Days      uint64 `json:"days"       valid:"range(0|365)~Премиум можно выдать не больше чем на 365 дней"`
+	Reason    string `json:"reason"     valid:"required~Укажите причину,length(1|900)~Причина должна быть не длиннее 900 символов"` //nolint:lll
+}
+
+func (a *AdminPremium) Trim() {
+	a.Reason = strings.TrimFunc(a.Reason, unicode.IsSpace)
+}
+
+//easyjson:json
+type AuditRecord struct {
+	ID        uint64    `json:"id"`
+	AdminID   uint64    `json:"admin_id"`
+	Action    string    `json:"action"`
+	TargetID  uint64    `json:"target_id"`
+	Details   string    `json:"details"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (a *AuditRecord) Sanitize() {
+	sanitizer := bluemonday.UGCPolicy()
+
+	a.Details = sanitizer.Sanitize(a.Details)
+}
+
+// AuditLog records are sorted from newest to oldest, NextCursor is empty on the last page.
+//
+//easyjson:json
+type AuditLog struct {
+	Records    []*AuditRecord `json:"records"`
+	NextCursor string         `json:"next_cursor"`
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+)
+
+const cursorSeparator = "_"
+
+var ErrWrongCursor = myerrors.NewErrorBadFormatRequest("Получили некорректный курсор") //nolint:gochecknoglobals
+
+// Cursor points to the last element of the page in keyset pagination.
+// Lists are sorted by (Score DESC, ID DESC), so the next page contains
+// elements strictly after the pair. Clients get it as an opaque string.
+type Cursor struct {
+	Score float64
+	ID    uint64
+}
+
+func NewCursor(score float64, id uint64) *Cursor {
+	return &Cursor{Score: score, ID: id}
+}
+
+// String returns opaque representation of cursor, empty string for nil cursor.
+func (c *Cursor) String() string {
+	if c == nil {
+		return ""
+	}
+
+	raw := strconv.FormatFloat(c.Score, 'g', -1, 64) + cursorSeparator + strconv.FormatUint(c.ID, 10)
+
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseCursor returns nil cursor for empty string, it means the first page.
+func ParseCursor(cursorStr string) (*Cursor, error) {
+	if cursorStr == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursorStr)
+	if err != nil {
+		return nil, ErrWrongCursor
+	}
+
+	scoreStr, idStr, found := strings.Cut(string(raw), cursorSeparator)
+	if !found {
+		return nil, ErrWrongCursor
+	}
+
+	score, err := strconv.ParseFloat(scoreStr, 64)
+	if err != nil {
+		return nil, ErrWrongCursor
+	}
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return nil, ErrWrongCursor
+	}
+
+	return &Cursor{Score: score, ID: id}, nil
+}
+
+//easyjson:json
+type ProductList struct {
+	Products   []*ProductInFeed `json:"products"`
+	NextCursor string           `json:"next_cursor"`
+}
+
+//easyjson:json
+type CommentList struct {
+	Comments   []*CommentInFeed `json:"comments"`
+	NextCursor string           `json:"next_cursor"`
+}
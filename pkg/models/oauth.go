@@ -0,0 +1,12 @@
+package models
+
+// OAuthLogin code, state and device_id are from query of redirect by provider.
+// device_id is sent only by VK ID.
+//
+//easyjson:json
+type OAuthLogin struct {
+	Provider string `json:"provider"  valid:"required~Провайдер не может быть пустым"`
+	Code     string `json:"code"      valid:"required~Код не может быть пустым"`
+	State    string `json:"state"     valid:"required~State не может быть пустым"`
+	DeviceID string `json:"device_id"`
+}
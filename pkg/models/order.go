@@ -54,14 +54,54 @@ type OrderNotInBasket struct {
 	Status int `json:"status"`
 }
 
+type PreOrderStatusHistory struct {
+	OrderID    uint64 `json:"order_id"     valid:"required"`
+	ActorID    uint64 `json:"actor_id"     valid:"required"`
+	ActorRole  string `json:"actor_role"   valid:"required"`
+	FromStatus uint8  `json:"from_status"`
+	ToStatus   uint8  `json:"to_status"    valid:"required"`
+}
+
+//easyjson:json
+type OrderStatusHistory struct {
+	ID         uint64    `json:"id"           valid:"required"`
+	OrderID    uint64    `json:"order_id"     valid:"required"`
+	ActorID    uint64    `json:"actor_id"     valid:"required"`
+	ActorRole  string    `json:"actor_role"   valid:"required"`
+	FromStatus uint8     `json:"from_status"`
+	ToStatus   uint8     `json:"to_status"    valid:"required"`
+	CreatedAt  time.Time `json:"created_at"   valid:"required"`
+}
+
+// OrderParticipants is current state of order with buyer and seller,
+// which is needed to check transition of order status.
+type OrderParticipants struct {
+	OrderID uint64
+	OwnerID uint64
+	SalerID uint64
+	Status  uint8
+}
+
+// Values of statuses stored in db, so new statuses are only appended.
 const (
 	OrderStatusInBasket = iota
 	OrderStatusInProcessing
 	OrderStatusPaid
 	OrderStatusClosed
+	OrderStatusShipped
+	OrderStatusDelivered
+	OrderStatusCancelled
+	OrderStatusRefunded
 	OrderStatusError = 255
 )
 
+const (
+	OrderActorBuyer  = "buyer"
+	OrderActorSeller = "seller"
+	// OrderActorSystem used for transitions without user, for example expiration of reservation.
+	OrderActorSystem = "system"
+)
+
 func (o *OrderInBasket) Sanitize() {
 	sanitizer := bluemonday.UGCPolicy()
 
@@ -90,7 +90,117 @@ func (v *PreOrder) UnmarshalJSON(data []byte) error {
 func (v *PreOrder) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels(l, v)
 }
-func easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels1(in *jlexer.Lexer, out *OrderNotInBasket) {
+func easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels1(in *jlexer.Lexer, out *OrderStatusHistory) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint64(in.Uint64())
+		case "order_id":
+			out.OrderID = uint64(in.Uint64())
+		case "actor_id":
+			out.ActorID = uint64(in.Uint64())
+		case "actor_role":
+			out.ActorRole = string(in.String())
+		case "from_status":
+			out.FromStatus = uint8(in.Uint8())
+		case "to_status":
+			out.ToStatus = uint8(in.Uint8())
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels1(out *jwriter.Writer, in OrderStatusHistory) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.ID))
+	}
+	{
+		const prefix string = ",\"order_id\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.OrderID))
+	}
+	{
+		const prefix string = ",\"actor_id\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.ActorID))
+	}
+	{
+		const prefix string = ",\"actor_role\":"
+		out.RawString(prefix)
+		out.String(string(in.ActorRole))
+	}
+	{
+		const prefix string = ",\"from_status\":"
+		out.RawString(prefix)
+		out.Uint8(uint8(in.FromStatus))
+	}
+	{
+		const prefix string = ",\"to_status\":"
+		out.RawString(prefix)
+		out.Uint8(uint8(in.ToStatus))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v OrderStatusHistory) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v OrderStatusHistory) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *OrderStatusHistory) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *OrderStatusHistory) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels1(l, v)
+}
+func easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels2(in *jlexer.Lexer, out *OrderNotInBasket) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -143,7 +253,7 @@ func easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels1(in *jle
 				in.Delim('[')
 				if out.Images == nil {
 					if !in.IsDelim(']') {
-						out.Images = make([]Image, 0, 4)
+						out.Images = make([]Image, 0, 1)
 					} else {
 						out.Images = []Image{}
 					}
@@ -152,7 +262,7 @@ func easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels1(in *jle
 				}
 				for !in.IsDelim(']') {
 					var v1 Image
-					easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels2(in, &v1)
+					easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels3(in, &v1)
 					out.Images = append(out.Images, v1)
 					in.WantComma()
 				}
@@ -168,7 +278,7 @@ func easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels1(in *jle
 		in.Consumed()
 	}
 }
-func easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels1(out *jwriter.Writer, in OrderNotInBasket) {
+func easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels2(out *jwriter.Writer, in OrderNotInBasket) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -248,7 +358,7 @@ func easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels1(out *jw
 				if v2 > 0 {
 					out.RawByte(',')
 				}
-				easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels2(out, v3)
+				easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels3(out, v3)
 			}
 			out.RawByte(']')
 		}
@@ -259,27 +369,27 @@ func easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels1(out *jw
 // MarshalJSON supports json.Marshaler interface
 func (v OrderNotInBasket) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels1(&w, v)
+	easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v OrderNotInBasket) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels1(w, v)
+	easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *OrderNotInBasket) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels1(&r, v)
+	easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *OrderNotInBasket) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels1(l, v)
+	easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels2(l, v)
 }
-func easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels2(in *jlexer.Lexer, out *Image) {
+func easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels3(in *jlexer.Lexer, out *Image) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -300,6 +410,10 @@ func easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels2(in *jle
 		switch key {
 		case "url":
 			out.URL = string(in.String())
+		case "thumbnail":
+			out.Thumbnail = string(in.String())
+		case "card":
+			out.Card = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -310,7 +424,7 @@ func easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels2(in *jle
 		in.Consumed()
 	}
 }
-func easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels2(out *jwriter.Writer, in Image) {
+func easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels3(out *jwriter.Writer, in Image) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -319,9 +433,19 @@ func easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels2(out *jw
 		out.RawString(prefix[1:])
 		out.String(string(in.URL))
 	}
+	if in.Thumbnail != "" {
+		const prefix string = ",\"thumbnail\":"
+		out.RawString(prefix)
+		out.String(string(in.Thumbnail))
+	}
+	if in.Card != "" {
+		const prefix string = ",\"card\":"
+		out.RawString(prefix)
+		out.String(string(in.Card))
+	}
 	out.RawByte('}')
 }
-func easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels3(in *jlexer.Lexer, out *OrderInBasket) {
+func easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels4(in *jlexer.Lexer, out *OrderInBasket) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -372,7 +496,7 @@ func easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels3(in *jle
 				in.Delim('[')
 				if out.Images == nil {
 					if !in.IsDelim(']') {
-						out.Images = make([]Image, 0, 4)
+						out.Images = make([]Image, 0, 1)
 					} else {
 						out.Images = []Image{}
 					}
@@ -381,7 +505,7 @@ func easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels3(in *jle
 				}
 				for !in.IsDelim(']') {
 					var v4 Image
-					easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels2(in, &v4)
+					easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels3(in, &v4)
 					out.Images = append(out.Images, v4)
 					in.WantComma()
 				}
@@ -397,7 +521,7 @@ func easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels3(in *jle
 		in.Consumed()
 	}
 }
-func easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels3(out *jwriter.Writer, in OrderInBasket) {
+func easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels4(out *jwriter.Writer, in OrderInBasket) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -472,7 +596,7 @@ func easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels3(out *jw
 				if v5 > 0 {
 					out.RawByte(',')
 				}
-				easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels2(out, v6)
+				easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels3(out, v6)
 			}
 			out.RawByte(']')
 		}
@@ -483,27 +607,27 @@ func easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels3(out *jw
 // MarshalJSON supports json.Marshaler interface
 func (v OrderInBasket) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels3(&w, v)
+	easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels4(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v OrderInBasket) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels3(w, v)
+	easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels4(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *OrderInBasket) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels3(&r, v)
+	easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels4(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *OrderInBasket) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels3(l, v)
+	easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels4(l, v)
 }
-func easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels4(in *jlexer.Lexer, out *OrderChanges) {
+func easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels5(in *jlexer.Lexer, out *OrderChanges) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -538,7 +662,7 @@ func easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels4(in *jle
 		in.Consumed()
 	}
 }
-func easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels4(out *jwriter.Writer, in OrderChanges) {
+func easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels5(out *jwriter.Writer, in OrderChanges) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -563,23 +687,23 @@ func easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels4(out *jw
 // MarshalJSON supports json.Marshaler interface
 func (v OrderChanges) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels4(&w, v)
+	easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels5(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v OrderChanges) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels4(w, v)
+	easyjson120d1ca2EncodeGithubComGoParkMailRu20232RabotyagiPkgModels5(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *OrderChanges) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels4(&r, v)
+	easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels5(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *OrderChanges) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels4(l, v)
+	easyjson120d1ca2DecodeGithubComGoParkMailRu20232RabotyagiPkgModels5(l, v)
 }
@@ -0,0 +1,16 @@
+package models
+
+// PasswordChange old password is checked only by matching, because rules of signup can change.
+//
+//easyjson:json
+type PasswordChange struct {
+	OldPassword string `json:"old_password" valid:"required~Текущий пароль не может быть пустым"`
+	NewPassword string `json:"new_password" valid:"required,password~Пароль должен быть минимум 6 символов"`
+}
+
+// PasswordConfirm current password is asked before dangerous actions, such as deletion of account.
+//
+//easyjson:json
+type PasswordConfirm struct {
+	Password string `json:"password" valid:"required~Текущий пароль не может быть пустым"`
+}
@@ -0,0 +1,30 @@
+package models
+
+// BasketPayment is payment for all orders of basket. Amount is in rubles.
+// Capture = false means two-stage payment: money are held until orders are delivered,
+// it is used if basket contains products with safe deal.
+type BasketPayment struct {
+	ID             uint64 `json:"id"               valid:"required"`
+	OwnerID        uint64 `json:"owner_id"         valid:"required"`
+	YookassaID     string `json:"yookassa_id"`
+	IdempotencyKey string `json:"idempotency_key"  valid:"required"`
+	Amount         uint64 `json:"amount"           valid:"required"`
+	Capture        bool   `json:"capture"`
+	Status         uint8  `json:"status"           valid:"required"`
+}
+
+// HeldPayment is two-stage payment which orders are all finished.
+// CaptureAmount is sum of delivered orders, zero means that payment should be cancelled.
+type HeldPayment struct {
+	ID             uint64 `json:"id"               valid:"required"`
+	YookassaID     string `json:"yookassa_id"      valid:"required"`
+	IdempotencyKey string `json:"idempotency_key"  valid:"required"`
+	CaptureAmount  uint64 `json:"capture_amount"`
+}
+
+// PaymentEvent is status of payment in yookassa. It's saved in the same transaction
+// which applies status, so each event is applied once and isn't lost if applying failed.
+type PaymentEvent struct {
+	YookassaID string `json:"yookassa_id"  valid:"required"`
+	Status     uint8  `json:"status"       valid:"required"`
+}
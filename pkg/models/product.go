@@ -9,8 +9,11 @@ import (
 	"github.com/microcosm-cc/bluemonday"
 )
 
+// Image Thumbnail and Card are urls of reduced copies, they are filled only in feeds of products.
 type Image struct {
-	URL string `json:"url" valid:"required"`
+	URL       string `json:"url"                 valid:"required"`
+	Thumbnail string `json:"thumbnail,omitempty" valid:"optional"`
+	Card      string `json:"card,omitempty"      valid:"optional"`
 }
 
 type Product struct {
@@ -64,7 +64,7 @@ func easyjsonCf3f67efDecodeGithubComGoParkMailRu20232RabotyagiPkgModels(in *jlex
 				in.Delim('[')
 				if out.Images == nil {
 					if !in.IsDelim(']') {
-						out.Images = make([]Image, 0, 4)
+						out.Images = make([]Image, 0, 1)
 					} else {
 						out.Images = []Image{}
 					}
@@ -213,6 +213,10 @@ func easyjsonCf3f67efDecodeGithubComGoParkMailRu20232RabotyagiPkgModels1(in *jle
 		switch key {
 		case "url":
 			out.URL = string(in.String())
+		case "thumbnail":
+			out.Thumbnail = string(in.String())
+		case "card":
+			out.Card = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -232,6 +236,16 @@ func easyjsonCf3f67efEncodeGithubComGoParkMailRu20232RabotyagiPkgModels1(out *jw
 		out.RawString(prefix[1:])
 		out.String(string(in.URL))
 	}
+	if in.Thumbnail != "" {
+		const prefix string = ",\"thumbnail\":"
+		out.RawString(prefix)
+		out.String(string(in.Thumbnail))
+	}
+	if in.Card != "" {
+		const prefix string = ",\"card\":"
+		out.RawString(prefix)
+		out.String(string(in.Card))
+	}
 	out.RawByte('}')
 }
 func easyjsonCf3f67efDecodeGithubComGoParkMailRu20232RabotyagiPkgModels2(in *jlexer.Lexer, out *ProductID) {
@@ -347,7 +361,7 @@ func easyjsonCf3f67efDecodeGithubComGoParkMailRu20232RabotyagiPkgModels3(in *jle
 				in.Delim('[')
 				if out.Images == nil {
 					if !in.IsDelim(']') {
-						out.Images = make([]Image, 0, 4)
+						out.Images = make([]Image, 0, 1)
 					} else {
 						out.Images = []Image{}
 					}
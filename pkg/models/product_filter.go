@@ -0,0 +1,37 @@
+package models
+
+// ProductFilter narrows product feeds. Zero value of every field means
+// that the corresponding filter is not applied.
+type ProductFilter struct {
+	// CategoryID filter includes all descendants of the category.
+	CategoryID  uint64 `json:"category_id"`
+	CityID      uint64 `json:"city_id"`
+	MinPrice    uint64 `json:"min_price"`
+	MaxPrice    uint64 `json:"max_price"`
+	Delivery    bool   `json:"delivery"`
+	SafeDeal    bool   `json:"safe_deal"`
+	PremiumOnly bool   `json:"premium"`
+}
+
+func (p *ProductFilter) IsEmpty() bool {
+	return p == nil || *p == ProductFilter{} //nolint:exhaustruct
+}
+
+//easyjson:json
+type FacetCount struct {
+	ID    uint64 `json:"id"`
+	Count uint64 `json:"count"`
+}
+
+//easyjson:json
+type ProductFacets struct {
+	Categories []FacetCount `json:"categories"`
+	Cities     []FacetCount `json:"cities"`
+}
+
+//easyjson:json
+type ProductFeed struct {
+	Products   []*ProductInFeed `json:"products"`
+	Facets     *ProductFacets   `json:"facets"`
+	NextCursor string           `json:"next_cursor"`
+}
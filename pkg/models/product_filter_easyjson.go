@@ -0,0 +1,365 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package models
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson401c58aeDecodeGithubComGoParkMailRu20232RabotyagiPkgModels(in *jlexer.Lexer, out *ProductFeed) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "products":
+			if in.IsNull() {
+				in.Skip()
+				out.Products = nil
+			} else {
+				in.Delim('[')
+				if out.Products == nil {
+					if !in.IsDelim(']') {
+						out.Products = make([]*ProductInFeed, 0, 8)
+					} else {
+						out.Products = []*ProductInFeed{}
+					}
+				} else {
+					out.Products = (out.Products)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 *ProductInFeed
+					if in.IsNull() {
+						in.Skip()
+						v1 = nil
+					} else {
+						if v1 == nil {
+							v1 = new(ProductInFeed)
+						}
+						(*v1).UnmarshalEasyJSON(in)
+					}
+					out.Products = append(out.Products, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "facets":
+			if in.IsNull() {
+				in.Skip()
+				out.Facets = nil
+			} else {
+				if out.Facets == nil {
+					out.Facets = new(ProductFacets)
+				}
+				(*out.Facets).UnmarshalEasyJSON(in)
+			}
+		case "next_cursor":
+			out.NextCursor = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson401c58aeEncodeGithubComGoParkMailRu20232RabotyagiPkgModels(out *jwriter.Writer, in ProductFeed) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"products\":"
+		out.RawString(prefix[1:])
+		if in.Products == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Products {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				if v3 == nil {
+					out.RawString("null")
+				} else {
+					(*v3).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"facets\":"
+		out.RawString(prefix)
+		if in.Facets == nil {
+			out.RawString("null")
+		} else {
+			(*in.Facets).MarshalEasyJSON(out)
+		}
+	}
+	{
+		const prefix string = ",\"next_cursor\":"
+		out.RawString(prefix)
+		out.String(string(in.NextCursor))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ProductFeed) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson401c58aeEncodeGithubComGoParkMailRu20232RabotyagiPkgModels(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ProductFeed) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson401c58aeEncodeGithubComGoParkMailRu20232RabotyagiPkgModels(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ProductFeed) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson401c58aeDecodeGithubComGoParkMailRu20232RabotyagiPkgModels(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ProductFeed) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson401c58aeDecodeGithubComGoParkMailRu20232RabotyagiPkgModels(l, v)
+}
+func easyjson401c58aeDecodeGithubComGoParkMailRu20232RabotyagiPkgModels1(in *jlexer.Lexer, out *ProductFacets) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "categories":
+			if in.IsNull() {
+				in.Skip()
+				out.Categories = nil
+			} else {
+				in.Delim('[')
+				if out.Categories == nil {
+					if !in.IsDelim(']') {
+						out.Categories = make([]FacetCount, 0, 4)
+					} else {
+						out.Categories = []FacetCount{}
+					}
+				} else {
+					out.Categories = (out.Categories)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 FacetCount
+					(v4).UnmarshalEasyJSON(in)
+					out.Categories = append(out.Categories, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "cities":
+			if in.IsNull() {
+				in.Skip()
+				out.Cities = nil
+			} else {
+				in.Delim('[')
+				if out.Cities == nil {
+					if !in.IsDelim(']') {
+						out.Cities = make([]FacetCount, 0, 4)
+					} else {
+						out.Cities = []FacetCount{}
+					}
+				} else {
+					out.Cities = (out.Cities)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v5 FacetCount
+					(v5).UnmarshalEasyJSON(in)
+					out.Cities = append(out.Cities, v5)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson401c58aeEncodeGithubComGoParkMailRu20232RabotyagiPkgModels1(out *jwriter.Writer, in ProductFacets) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"categories\":"
+		out.RawString(prefix[1:])
+		if in.Categories == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v6, v7 := range in.Categories {
+				if v6 > 0 {
+					out.RawByte(',')
+				}
+				(v7).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"cities\":"
+		out.RawString(prefix)
+		if in.Cities == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v8, v9 := range in.Cities {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				(v9).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ProductFacets) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson401c58aeEncodeGithubComGoParkMailRu20232RabotyagiPkgModels1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ProductFacets) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson401c58aeEncodeGithubComGoParkMailRu20232RabotyagiPkgModels1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ProductFacets) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson401c58aeDecodeGithubComGoParkMailRu20232RabotyagiPkgModels1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ProductFacets) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson401c58aeDecodeGithubComGoParkMailRu20232RabotyagiPkgModels1(l, v)
+}
+func easyjson401c58aeDecodeGithubComGoParkMailRu20232RabotyagiPkgModels2(in *jlexer.Lexer, out *FacetCount) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint64(in.Uint64())
+		case "count":
+			out.Count = uint64(in.Uint64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson401c58aeEncodeGithubComGoParkMailRu20232RabotyagiPkgModels2(out *jwriter.Writer, in FacetCount) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.ID))
+	}
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.Count))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FacetCount) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson401c58aeEncodeGithubComGoParkMailRu20232RabotyagiPkgModels2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FacetCount) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson401c58aeEncodeGithubComGoParkMailRu20232RabotyagiPkgModels2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FacetCount) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson401c58aeDecodeGithubComGoParkMailRu20232RabotyagiPkgModels2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FacetCount) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson401c58aeDecodeGithubComGoParkMailRu20232RabotyagiPkgModels2(l, v)
+}
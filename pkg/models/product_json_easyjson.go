@@ -89,7 +89,7 @@ func easyjsonB0091c22DecodeGithubComGoParkMailRu20232RabotyagiPkgModels(in *jlex
 				in.Delim('[')
 				if out.Images == nil {
 					if !in.IsDelim(']') {
-						out.Images = make([]Image, 0, 4)
+						out.Images = make([]Image, 0, 1)
 					} else {
 						out.Images = []Image{}
 					}
@@ -381,6 +381,10 @@ func easyjsonB0091c22DecodeGithubComGoParkMailRu20232RabotyagiPkgModels1(in *jle
 		switch key {
 		case "url":
 			out.URL = string(in.String())
+		case "thumbnail":
+			out.Thumbnail = string(in.String())
+		case "card":
+			out.Card = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -400,5 +404,15 @@ func easyjsonB0091c22EncodeGithubComGoParkMailRu20232RabotyagiPkgModels1(out *jw
 		out.RawString(prefix[1:])
 		out.String(string(in.URL))
 	}
+	if in.Thumbnail != "" {
+		const prefix string = ",\"thumbnail\":"
+		out.RawString(prefix)
+		out.String(string(in.Thumbnail))
+	}
+	if in.Card != "" {
+		const prefix string = ",\"card\":"
+		out.RawString(prefix)
+		out.String(string(in.Card))
+	}
 	out.RawByte('}')
 }
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+//easyjson:json
+type PreSavedSearch struct {
+	OwnerID uint64        `json:"owner_id" valid:"required"`
+	Query   string        `json:"query"    valid:"required, length(1|256)~Запрос должен быть длинной от 1 до 256 символов"` //nolint:nolintlint
+	Filter  ProductFilter `json:"filter"`
+}
+
+// SavedSearch is query with filters of get_search_feed which is periodically
+// checked for new products. LastCheckedAt is moment of the last check.
+//
+//easyjson:json
+type SavedSearch struct {
+	ID            uint64        `json:"id"              valid:"required"`
+	OwnerID       uint64        `json:"owner_id"        valid:"required"`
+	Query         string        `json:"query"           valid:"required, length(1|256)~Запрос должен быть длинной от 1 до 256 символов"` //nolint:nolintlint
+	Filter        ProductFilter `json:"filter"`
+	CreatedAt     time.Time     `json:"created_at"      valid:"required"`
+	LastCheckedAt time.Time     `json:"last_checked_at" valid:"required"`
+}
+
+// Notification about new product matching saved search of user.
+//
+//easyjson:json
+type Notification struct {
+	ID            uint64    `json:"id"              valid:"required"`
+	SavedSearchID uint64    `json:"saved_search_id" valid:"required"`
+	Query         string    `json:"query"           valid:"required"`
+	ProductID     uint64    `json:"product_id"      valid:"required"`
+	Title         string    `json:"title"           valid:"required"`
+	Price         uint64    `json:"price"`
+	IsRead        bool      `json:"is_read"`
+	CreatedAt     time.Time `json:"created_at"      valid:"required"`
+}
+
+func (p *PreSavedSearch) Trim() {
+	p.Query = strings.TrimFunc(p.Query, unicode.IsSpace)
+}
+
+func (s *SavedSearch) Sanitize() {
+	sanitizer := bluemonday.UGCPolicy()
+
+	s.Query = sanitizer.Sanitize(s.Query)
+}
+
+func (n *Notification) Sanitize() {
+	sanitizer := bluemonday.UGCPolicy()
+
+	n.Query = sanitizer.Sanitize(n.Query)
+	n.Title = sanitizer.Sanitize(n.Title)
+}
@@ -0,0 +1,405 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package models
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonD15b35c8DecodeGithubComGoParkMailRu20232RabotyagiPkgModels(in *jlexer.Lexer, out *SavedSearch) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint64(in.Uint64())
+		case "owner_id":
+			out.OwnerID = uint64(in.Uint64())
+		case "query":
+			out.Query = string(in.String())
+		case "filter":
+			easyjsonD15b35c8DecodeGithubComGoParkMailRu20232RabotyagiPkgModels1(in, &out.Filter)
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		case "last_checked_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.LastCheckedAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD15b35c8EncodeGithubComGoParkMailRu20232RabotyagiPkgModels(out *jwriter.Writer, in SavedSearch) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.ID))
+	}
+	{
+		const prefix string = ",\"owner_id\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.OwnerID))
+	}
+	{
+		const prefix string = ",\"query\":"
+		out.RawString(prefix)
+		out.String(string(in.Query))
+	}
+	{
+		const prefix string = ",\"filter\":"
+		out.RawString(prefix)
+		easyjsonD15b35c8EncodeGithubComGoParkMailRu20232RabotyagiPkgModels1(out, in.Filter)
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"last_checked_at\":"
+		out.RawString(prefix)
+		out.Raw((in.LastCheckedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SavedSearch) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD15b35c8EncodeGithubComGoParkMailRu20232RabotyagiPkgModels(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SavedSearch) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD15b35c8EncodeGithubComGoParkMailRu20232RabotyagiPkgModels(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SavedSearch) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD15b35c8DecodeGithubComGoParkMailRu20232RabotyagiPkgModels(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SavedSearch) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD15b35c8DecodeGithubComGoParkMailRu20232RabotyagiPkgModels(l, v)
+}
+func easyjsonD15b35c8DecodeGithubComGoParkMailRu20232RabotyagiPkgModels1(in *jlexer.Lexer, out *ProductFilter) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "category_id":
+			out.CategoryID = uint64(in.Uint64())
+		case "city_id":
+			out.CityID = uint64(in.Uint64())
+		case "min_price":
+			out.MinPrice = uint64(in.Uint64())
+		case "max_price":
+			out.MaxPrice = uint64(in.Uint64())
+		case "delivery":
+			out.Delivery = bool(in.Bool())
+		case "safe_deal":
+			out.SafeDeal = bool(in.Bool())
+		case "premium":
+			out.PremiumOnly = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD15b35c8EncodeGithubComGoParkMailRu20232RabotyagiPkgModels1(out *jwriter.Writer, in ProductFilter) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"category_id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.CategoryID))
+	}
+	{
+		const prefix string = ",\"city_id\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.CityID))
+	}
+	{
+		const prefix string = ",\"min_price\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.MinPrice))
+	}
+	{
+		const prefix string = ",\"max_price\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.MaxPrice))
+	}
+	{
+		const prefix string = ",\"delivery\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Delivery))
+	}
+	{
+		const prefix string = ",\"safe_deal\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.SafeDeal))
+	}
+	{
+		const prefix string = ",\"premium\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.PremiumOnly))
+	}
+	out.RawByte('}')
+}
+func easyjsonD15b35c8DecodeGithubComGoParkMailRu20232RabotyagiPkgModels2(in *jlexer.Lexer, out *PreSavedSearch) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "owner_id":
+			out.OwnerID = uint64(in.Uint64())
+		case "query":
+			out.Query = string(in.String())
+		case "filter":
+			easyjsonD15b35c8DecodeGithubComGoParkMailRu20232RabotyagiPkgModels1(in, &out.Filter)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD15b35c8EncodeGithubComGoParkMailRu20232RabotyagiPkgModels2(out *jwriter.Writer, in PreSavedSearch) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"owner_id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.OwnerID))
+	}
+	{
+		const prefix string = ",\"query\":"
+		out.RawString(prefix)
+		out.String(string(in.Query))
+	}
+	{
+		const prefix string = ",\"filter\":"
+		out.RawString(prefix)
+		easyjsonD15b35c8EncodeGithubComGoParkMailRu20232RabotyagiPkgModels1(out, in.Filter)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PreSavedSearch) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD15b35c8EncodeGithubComGoParkMailRu20232RabotyagiPkgModels2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PreSavedSearch) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD15b35c8EncodeGithubComGoParkMailRu20232RabotyagiPkgModels2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PreSavedSearch) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD15b35c8DecodeGithubComGoParkMailRu20232RabotyagiPkgModels2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PreSavedSearch) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD15b35c8DecodeGithubComGoParkMailRu20232RabotyagiPkgModels2(l, v)
+}
+func easyjsonD15b35c8DecodeGithubComGoParkMailRu20232RabotyagiPkgModels3(in *jlexer.Lexer, out *Notification) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint64(in.Uint64())
+		case "saved_search_id":
+			out.SavedSearchID = uint64(in.Uint64())
+		case "query":
+			out.Query = string(in.String())
+		case "product_id":
+			out.ProductID = uint64(in.Uint64())
+		case "title":
+			out.Title = string(in.String())
+		case "price":
+			out.Price = uint64(in.Uint64())
+		case "is_read":
+			out.IsRead = bool(in.Bool())
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD15b35c8EncodeGithubComGoParkMailRu20232RabotyagiPkgModels3(out *jwriter.Writer, in Notification) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.ID))
+	}
+	{
+		const prefix string = ",\"saved_search_id\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.SavedSearchID))
+	}
+	{
+		const prefix string = ",\"query\":"
+		out.RawString(prefix)
+		out.String(string(in.Query))
+	}
+	{
+		const prefix string = ",\"product_id\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.ProductID))
+	}
+	{
+		const prefix string = ",\"title\":"
+		out.RawString(prefix)
+		out.String(string(in.Title))
+	}
+	{
+		const prefix string = ",\"price\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.Price))
+	}
+	{
+		const prefix string = ",\"is_read\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsRead))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Notification) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD15b35c8EncodeGithubComGoParkMailRu20232RabotyagiPkgModels3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Notification) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD15b35c8EncodeGithubComGoParkMailRu20232RabotyagiPkgModels3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Notification) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD15b35c8DecodeGithubComGoParkMailRu20232RabotyagiPkgModels3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Notification) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD15b35c8DecodeGithubComGoParkMailRu20232RabotyagiPkgModels3(l, v)
+}
@@ -0,0 +1,9 @@
+package models
+
+// UserSignIn password isn't checked by rules of signup, because they can change after signup.
+//
+//easyjson:json
+type UserSignIn struct {
+	Email    string `json:"email"    valid:"required,email_with_rus~Некорректный формат email"`
+	Password string `json:"password" valid:"required~Пароль не может быть пустым"`
+}
@@ -0,0 +1,19 @@
+package models
+
+// EmailVerification token is from link in letter.
+//
+//easyjson:json
+type EmailVerification struct {
+	Token string `json:"token" valid:"required~Токен не может быть пустым"`
+}
+
+//easyjson:json
+type PasswordResetRequest struct {
+	Email string `json:"email" valid:"required,email_with_rus~Некорректный формат email"`
+}
+
+//easyjson:json
+type PasswordReset struct {
+	Token    string `json:"token"    valid:"required~Токен не может быть пустым"`
+	Password string `json:"password" valid:"required,password~Пароль должен быть минимум 6 символов"`
+}
@@ -48,6 +48,18 @@ func NewErrorBadContentRequest(format string, args ...any) *Error {
 	return &Error{err: fmt.Sprintf(format, args...), status: statuses.StatusBadContentRequest}
 }
 
+// NewErrorTooManyRequests error with status =
+// StatusTooManyRequests uses when user is temporarily blocked and needs to show him wait time.
+func NewErrorTooManyRequests(format string, args ...any) *Error {
+	return &Error{err: fmt.Sprintf(format, args...), status: statuses.StatusTooManyRequests}
+}
+
+// NewErrorForbidden error with status =
+// StatusForbidden uses when user is authorized, but his role hasn't rights for action.
+func NewErrorForbidden(format string, args ...any) *Error {
+	return &Error{err: fmt.Sprintf(format, args...), status: statuses.StatusForbidden}
+}
+
 // NewErrorInternal error with status =
 // StatusInternalServer uses for indicates internal error status in server.
 func NewErrorInternal(format string, args ...any) *Error {
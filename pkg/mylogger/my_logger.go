@@ -28,6 +28,7 @@ func NewNop() *MyLogger {
 	return &MyLogger{logger}
 }
 
+// New sensitive values like passwords and tokens are always masked in logs.
 func New(outputPaths []string, errorOutputPaths []string, options ...zap.Option) (*MyLogger, error) {
 	var err error
 
@@ -35,7 +36,7 @@ func New(outputPaths []string, errorOutputPaths []string, options ...zap.Option)
 		cfg := zap.NewProductionConfig()
 		cfg.OutputPaths = outputPaths
 		cfg.ErrorOutputPaths = errorOutputPaths
-		zapLogger, innerErr := cfg.Build(options...)
+		zapLogger, innerErr := cfg.Build(append([]zap.Option{WithScrubbing()}, options...)...)
 		if innerErr != nil {
 			err = innerErr
 
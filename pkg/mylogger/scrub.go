@@ -0,0 +1,98 @@
+package mylogger
+
+import (
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const MaskSensitive = "***"
+
+// sensitiveKeys are names of fields and parameters, values of which mustn't get into logs.
+var sensitiveKeys = []string{ //nolint:gochecknoglobals
+	"password",
+	"new_password",
+	"old_password",
+	"access_token",
+	"refresh_token",
+	"secret",
+	"authorization",
+	"cookie",
+	"set-cookie",
+}
+
+// rxSensitive matches value after sensitive key in query (password=...), json ("password":"...")
+// and struct printed by %+v (Password:...). Quoted value is matched up to closing not escaped quote,
+// so spaces, commas and escaped quotes inside it don't stop masking. Value of http.Header printed by %v
+// (Authorization:[Basic ...]) is matched up to closing bracket and auth scheme is masked together with its token.
+var rxSensitive = regexp.MustCompile( //nolint:gochecknoglobals
+	`(?i)("?\b(?:` + strings.Join(sensitiveKeys, "|") + `)\b"?\s*[:=]\s*)` +
+		`(?:(")(?:[^"\\]|\\.)*"|(\[)[^\]]*(\])|(?:(?:basic|bearer)\s+)?"?[^"&\s,;}]+)`)
+
+// ScrubString masks values of sensitive keys in s, quotes and brackets of value are kept.
+func ScrubString(s string) string {
+	return rxSensitive.ReplaceAllString(s, "${1}${2}${3}"+MaskSensitive+"${2}${4}")
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+
+	for _, sensitiveKey := range sensitiveKeys {
+		if key == sensitiveKey {
+			return true
+		}
+	}
+
+	return false
+}
+
+func scrubFields(fields []zapcore.Field) []zapcore.Field {
+	result := make([]zapcore.Field, len(fields))
+
+	for i, field := range fields {
+		switch {
+		case isSensitiveKey(field.Key):
+			result[i] = zap.String(field.Key, MaskSensitive)
+		case field.Type == zapcore.StringType:
+			result[i] = zap.String(field.Key, ScrubString(field.String))
+		default:
+			result[i] = field
+		}
+	}
+
+	return result
+}
+
+// scrubCore masks sensitive values in message and fields before writing to wrapped core.
+type scrubCore struct {
+	zapcore.Core
+}
+
+func NewScrubCore(core zapcore.Core) zapcore.Core {
+	return &scrubCore{Core: core}
+}
+
+func (s *scrubCore) With(fields []zapcore.Field) zapcore.Core {
+	return &scrubCore{Core: s.Core.With(scrubFields(fields))}
+}
+
+func (s *scrubCore) Check(entry zapcore.Entry, checkedEntry *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if s.Enabled(entry.Level) {
+		return checkedEntry.AddCore(entry, s)
+	}
+
+	return checkedEntry
+}
+
+func (s *scrubCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	entry.Message = ScrubString(entry.Message)
+
+	return s.Core.Write(entry, scrubFields(fields)) //nolint:wrapcheck
+}
+
+// WithScrubbing is option of zap logger, which masks sensitive values.
+func WithScrubbing() zap.Option {
+	return zap.WrapCore(NewScrubCore)
+}
@@ -15,7 +15,8 @@ const (
 var ErrCookieNotPresented = myerrors.NewErrorBadFormatRequest("Должна быть выставлена cookie, а её нет")
 
 const (
-	CookieAuthName = "access_token"
+	CookieAuthName    = "access_token"
+	CookieRefreshName = "refresh_token"
 )
 
 type Marshaller interface {
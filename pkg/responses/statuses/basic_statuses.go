@@ -34,7 +34,13 @@ const (
 
 	// StatusBadContentRequest uses when user has entered incorrect data and needs to show him this error.
 	StatusBadContentRequest = 4400
-	MaxValueClientError     = 4999
+
+	// StatusForbidden uses when user is authorized, but his role hasn't rights for action.
+	StatusForbidden = 4403
+
+	// StatusTooManyRequests uses when user has to wait before next attempt, message of error contains wait time.
+	StatusTooManyRequests = 4429
+	MaxValueClientError   = 4999
 
 	// StatusInternalServer uses for indicates internal error status in server.
 	StatusInternalServer = 5000
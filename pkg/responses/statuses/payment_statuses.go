@@ -0,0 +1,9 @@
+package statuses
+
+// Payments of basket are stored with the same int statuses as premium payments.
+const (
+	IntStatusPaymentPending   = IntStatusPremiumPending
+	IntStatusPaymentWaiting   = IntStatusPremiumWaiting
+	IntStatusPaymentSucceeded = IntStatusPremiumSucceeded
+	IntStatusPaymentCanceled  = IntStatusPremiumCanceled
+)
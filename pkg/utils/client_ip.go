@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+)
+
+const headerRealIP = "X-Real-IP"
+
+// ParseIPNets parses list of ips and subnets separated by commas like "185.71.76.0/27,77.75.156.11".
+// Single ip is converted to subnet with only this ip.
+func ParseIPNets(list string) ([]*net.IPNet, error) {
+	var ipNets []*net.IPNet
+
+	for _, value := range strings.Split(list, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		if !strings.Contains(value, "/") {
+			ip := net.ParseIP(value)
+			if ip == nil {
+				return nil, fmt.Errorf(myerrors.ErrTemplate, &net.ParseError{Type: "IP address", Text: value})
+			}
+
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 8 * net.IPv4len
+			}
+
+			ipNets = append(ipNets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+
+			continue
+		}
+
+		_, ipNet, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		ipNets = append(ipNets, ipNet)
+	}
+
+	return ipNets, nil
+}
+
+func ContainsIP(ipNets []*net.IPNet, ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+
+	for _, ipNet := range ipNets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetClientIP returns ip of client. X-Real-IP is used only if request came from
+// private network or loopback, e.g. from nginx, otherwise client could forge it.
+func GetClientIP(r *http.Request) net.IP {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	remoteIP := net.ParseIP(host)
+	if remoteIP == nil {
+		return nil
+	}
+
+	if remoteIP.IsPrivate() || remoteIP.IsLoopback() {
+		if realIP := net.ParseIP(strings.TrimSpace(r.Header.Get(headerRealIP))); realIP != nil {
+			return realIP
+		}
+	}
+
+	return remoteIP
+}
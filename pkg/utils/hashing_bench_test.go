@@ -0,0 +1,52 @@
+package utils_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+)
+
+// BenchmarkHashPassParams measures cost of hashing with several candidates for DefaultHashParams.
+// Run with: go test ./pkg/utils -run=^$ -bench=HashPassParams -benchmem
+func BenchmarkHashPassParams(b *testing.B) {
+	_ = mylogger.NewNop()
+
+	candidates := []utils.HashParams{
+		{Time: 1, Memory: 19 * 1024, Threads: 1, SaltLen: 16, KeyLen: 32},
+		{Time: 2, Memory: 19 * 1024, Threads: 1, SaltLen: 16, KeyLen: 32},
+		{Time: 1, Memory: 64 * 1024, Threads: 4, SaltLen: 16, KeyLen: 32},
+		utils.DefaultHashParams(),
+		{Time: 3, Memory: 64 * 1024, Threads: 4, SaltLen: 16, KeyLen: 32},
+		{Time: 1, Memory: 256 * 1024, Threads: 4, SaltLen: 16, KeyLen: 32},
+	}
+
+	for _, params := range candidates {
+		params := params
+
+		b.Run(fmt.Sprintf("m=%d,t=%d,p=%d", params.Memory, params.Time, params.Threads), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, err := utils.HashPassWithParams("password", params)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkVerifyPassLegacy measures verification of hashes made before PHC format.
+func BenchmarkVerifyPassLegacy(b *testing.B) {
+	_ = mylogger.NewNop()
+
+	hashLegacy := "73616c7473616c74" + "00000000000000000000000000000000" + "00000000000000000000000000000000"
+	currentParams := utils.DefaultHashParams()
+
+	for i := 0; i < b.N; i++ {
+		_, _, err := utils.VerifyPass(hashLegacy, "password", currentParams)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -9,8 +9,12 @@ import (
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
 )
 
-var MessageErrWrongNumberParam = "Получили некорректный числовой параметр. " + //nolint:gochecknoglobals
-	"Он должен быть целым"
+var (
+	MessageErrWrongNumberParam = "Получили некорректный числовой параметр. " + //nolint:gochecknoglobals
+		"Он должен быть целым"
+	MessageErrWrongBoolParam = "Получили некорректный логический параметр. " + //nolint:gochecknoglobals
+		"Он должен быть true или false"
+)
 
 func ParseUint64FromRequest(r *http.Request, paramName string) (uint64, error) {
 	logger, err := mylogger.Get()
@@ -32,6 +36,39 @@ func ParseUint64FromRequest(r *http.Request, paramName string) (uint64, error) {
 	return number, nil
 }
 
+// ParseOptionalUint64FromRequest returns 0 if param not presented in request.
+func ParseOptionalUint64FromRequest(r *http.Request, paramName string) (uint64, error) {
+	if !r.URL.Query().Has(paramName) {
+		return 0, nil
+	}
+
+	return ParseUint64FromRequest(r, paramName)
+}
+
+// ParseBoolFromRequest returns false if param not presented in request.
+func ParseBoolFromRequest(r *http.Request, paramName string) (bool, error) {
+	logger, err := mylogger.Get()
+	if err != nil {
+		return false, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	boolStr := r.URL.Query().Get(paramName)
+	if boolStr == "" {
+		return false, nil
+	}
+
+	value, err := strconv.ParseBool(boolStr)
+	if err != nil {
+		err := myerrors.NewErrorBadFormatRequest("%s %s=%s", MessageErrWrongBoolParam, paramName, boolStr)
+
+		logger.Errorln(err)
+
+		return false, err
+	}
+
+	return value, nil
+}
+
 func ParseStringFromRequest(r *http.Request, paramName string) string {
 	return r.URL.Query().Get(paramName)
 }
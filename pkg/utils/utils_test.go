@@ -3,14 +3,18 @@ package utils_test
 import (
 	"database/sql"
 	"encoding/hex"
+	"errors"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+	"golang.org/x/crypto/argon2"
 )
 
 func TestSnakeToCamelCase(t *testing.T) {
@@ -113,31 +117,108 @@ func TestHashPass(t *testing.T) {
 				t.Errorf("Error hashing password: %s", err)
 			}
 
-			if hashedPass == "" {
-				t.Errorf("Empty hashed password")
+			if !strings.HasPrefix(hashedPass, "$argon2id$v=19$m=65536,t=2,p=4$") {
+				t.Errorf("Hashed password %s isn't PHC string with default params", hashedPass)
 			}
 		})
 	}
 }
 
-func TestComparePassAndHash(t *testing.T) {
+func TestVerifyPass(t *testing.T) {
 	t.Parallel()
 
 	_ = mylogger.NewNop()
 
+	currentParams := utils.DefaultHashParams()
+	weakParams := utils.HashParams{Time: 1, Memory: 8 * 1024, Threads: 1, SaltLen: 16, KeyLen: 32}
+
+	hashCurrent, err := utils.HashPassWithParams("password", currentParams)
+	if err != nil {
+		t.Fatalf("Error hashing password: %s", err)
+	}
+
+	hashWeak, err := utils.HashPassWithParams("password", weakParams)
+	if err != nil {
+		t.Fatalf("Error hashing password: %s", err)
+	}
+
+	// legacy hex(salt || argon2id) with t=1, m=64MiB, p=4
+	salt := []byte("saltsalt")
+	hashLegacy := hex.EncodeToString(append(salt, argon2.IDKey([]byte("password"), salt, 1, 64*1024, 4, 32)...))
+
 	type TestCase struct {
-		name          string
-		plainPassword string
+		name                string
+		encodedHash         string
+		plainPassword       string
+		expectedOk          bool
+		expectedNeedsRehash bool
+		expectedError       error
 	}
 
 	testCases := [...]TestCase{
 		{
-			name:          "test basic work",
-			plainPassword: "password",
+			name:                "test current params",
+			encodedHash:         hashCurrent,
+			plainPassword:       "password",
+			expectedOk:          true,
+			expectedNeedsRehash: false,
+			expectedError:       nil,
 		},
 		{
-			name:          "test empty",
-			plainPassword: "",
+			name:                "test wrong password",
+			encodedHash:         hashCurrent,
+			plainPassword:       "wrongpassword",
+			expectedOk:          false,
+			expectedNeedsRehash: false,
+			expectedError:       nil,
+		},
+		{
+			name:                "test outdated params",
+			encodedHash:         hashWeak,
+			plainPassword:       "password",
+			expectedOk:          true,
+			expectedNeedsRehash: true,
+			expectedError:       nil,
+		},
+		{
+			name:                "test legacy hash",
+			encodedHash:         hashLegacy,
+			plainPassword:       "password",
+			expectedOk:          true,
+			expectedNeedsRehash: true,
+			expectedError:       nil,
+		},
+		{
+			name:                "test legacy hash wrong password",
+			encodedHash:         hashLegacy,
+			plainPassword:       "wrongpassword",
+			expectedOk:          false,
+			expectedNeedsRehash: false,
+			expectedError:       nil,
+		},
+		{
+			name:                "test unsupported algorithm",
+			encodedHash:         "$bcrypt$v=19$m=65536,t=2,p=4$c2FsdA$aGFzaA",
+			plainPassword:       "password",
+			expectedOk:          false,
+			expectedNeedsRehash: false,
+			expectedError:       utils.ErrUnsupportedHash,
+		},
+		{
+			name:                "test wrong params",
+			encodedHash:         "$argon2id$v=19$m=65536,t=0,p=4$c2FsdA$aGFzaA",
+			plainPassword:       "password",
+			expectedOk:          false,
+			expectedNeedsRehash: false,
+			expectedError:       utils.ErrWrongHashParams,
+		},
+		{
+			name:                "test wrong format",
+			encodedHash:         "not_hash",
+			plainPassword:       "password",
+			expectedOk:          false,
+			expectedNeedsRehash: false,
+			expectedError:       utils.ErrWrongFormatHash,
 		},
 	}
 
@@ -147,22 +228,14 @@ func TestComparePassAndHash(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			t.Parallel()
 
-			hashedPass, err := utils.HashPass(testCase.plainPassword)
-			if err != nil {
-				t.Errorf("Error hashing password: %s", err)
+			ok, needsRehash, err := utils.VerifyPass(testCase.encodedHash, testCase.plainPassword, currentParams)
+			if !errors.Is(err, testCase.expectedError) {
+				t.Fatalf("err: got %+v, expected %+v", err, testCase.expectedError)
 			}
 
-			hashedPassByteSl, err := hex.DecodeString(hashedPass)
-			if err != nil {
-				t.Errorf("Error hashing password: %s", err)
-			}
-
-			if !utils.ComparePassAndHash(hashedPassByteSl, testCase.plainPassword) {
-				t.Errorf("Password and hash do not match")
-			}
-
-			if utils.ComparePassAndHash(hashedPassByteSl, "wrongpassword") {
-				t.Errorf("Password and hash should not match")
+			if ok != testCase.expectedOk || needsRehash != testCase.expectedNeedsRehash {
+				t.Errorf("got ok=%t needsRehash=%t, expected ok=%t needsRehash=%t",
+					ok, needsRehash, testCase.expectedOk, testCase.expectedNeedsRehash)
 			}
 		})
 	}
@@ -425,6 +498,58 @@ func TestParseUint64FromRequest(t *testing.T) {
 	}
 }
 
+func TestParseBoolFromRequest(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	type TestCase struct {
+		name          string
+		request       *http.Request
+		paramName     string
+		expectedValue bool
+		expectedError bool
+	}
+
+	testCases := [...]TestCase{
+		{
+			name:          "test basic work",
+			request:       httptest.NewRequest(http.MethodGet, "/api/v1/product/get_list?delivery=true", nil),
+			paramName:     "delivery",
+			expectedValue: true,
+		},
+		{
+			name:          "test missing param",
+			request:       httptest.NewRequest(http.MethodGet, "/api/v1/product/get_list", nil),
+			paramName:     "delivery",
+			expectedValue: false,
+		},
+		{
+			name:          "test wrong param",
+			request:       httptest.NewRequest(http.MethodGet, "/api/v1/product/get_list?delivery=yes", nil),
+			paramName:     "delivery",
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			value, err := utils.ParseBoolFromRequest(testCase.request, testCase.paramName)
+			if (err != nil) != testCase.expectedError {
+				t.Fatalf("Expected error: %t, got: %v", testCase.expectedError, err)
+			}
+
+			if value != testCase.expectedValue {
+				t.Errorf("Expected: %t, got: %t", testCase.expectedValue, value)
+			}
+		})
+	}
+}
+
 func TestParseStringFromRequest(t *testing.T) {
 	t.Parallel()
 
@@ -488,3 +613,78 @@ func TestStructToMap(t *testing.T) {
 		t.Errorf("Unexpected result: got %v, want %v", result, expectedResult)
 	}
 }
+
+func TestGetClientIP(t *testing.T) {
+	t.Parallel()
+
+	type TestCase struct {
+		name       string
+		remoteAddr string
+		realIP     string
+		expectedIP string
+	}
+
+	testCases := [...]TestCase{
+		{
+			name:       "test without proxy",
+			remoteAddr: "185.71.76.5:1234",
+			realIP:     "",
+			expectedIP: "185.71.76.5",
+		},
+		{
+			name:       "test real ip from proxy",
+			remoteAddr: "172.18.0.3:1234",
+			realIP:     "185.71.76.5",
+			expectedIP: "185.71.76.5",
+		},
+		{
+			name:       "test forged real ip",
+			remoteAddr: "8.8.8.8:1234",
+			realIP:     "185.71.76.5",
+			expectedIP: "8.8.8.8",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			request := httptest.NewRequest(http.MethodPost, "/api/v1/payment/notification", nil)
+			request.RemoteAddr = testCase.remoteAddr
+
+			if testCase.realIP != "" {
+				request.Header.Set("X-Real-IP", testCase.realIP)
+			}
+
+			ip := utils.GetClientIP(request)
+			if ip.String() != testCase.expectedIP {
+				t.Errorf("Expected: %s, got: %s", testCase.expectedIP, ip)
+			}
+		})
+	}
+}
+
+func TestParseIPNets(t *testing.T) {
+	t.Parallel()
+
+	ipNets, err := utils.ParseIPNets("185.71.76.0/27, 77.75.156.11,2a02:5180::/32")
+	if err != nil {
+		t.Fatalf("unexpected err=%+v", err)
+	}
+
+	for ip, expected := range map[string]bool{
+		"185.71.76.31": true, "185.71.76.32": false, "77.75.156.11": true,
+		"77.75.156.12": false, "2a02:5180::1": true, "::1": false,
+	} {
+		if utils.ContainsIP(ipNets, net.ParseIP(ip)) != expected {
+			t.Errorf("ip %s: expected contains=%t", ip, expected)
+		}
+	}
+
+	_, err = utils.ParseIPNets("not_ip")
+	if err == nil {
+		t.Errorf("expected error for wrong ip")
+	}
+}
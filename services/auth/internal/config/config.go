@@ -2,18 +2,53 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/config"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/jwt"
 )
 
+const (
+	MailSenderSMTP = "smtp"
+	MailSenderFile = "file"
+	MailSenderLog  = "log"
+)
+
 const (
 	standardDevelopmentMode        = "development"
 	standardProductionMode         = "production"
 	envEnvironmentMode             = "ENVIRONMENT"
 	envStandardSecret              = "JWT_SECRET"
 	standardSecret                 = ""
+	envPathJwtKeysFile             = "JWT_KEYS_FILE"
+	standardPathJwtKeysFile        = ""
+	envJwtKeyGracePeriod           = "JWT_KEY_GRACE_PERIOD"
+	standardJwtKeyGracePeriod      = jwt.TimeAccessTokenLife
+	envMailSender                  = "MAIL_SENDER"
+	standardMailSender             = MailSenderLog
+	envMailFrom                    = "MAIL_FROM"
+	standardMailFrom               = "noreply@goods-galaxy.ru"
+	envMailFilePath                = "MAIL_FILE_PATH"
+	standardMailFilePath           = "mails.txt"
+	envMailLinkBaseURL             = "MAIL_LINK_BASE_URL"
+	standardMailLinkBaseURL        = "http://localhost:3000"
+	envSMTPAddress                 = "SMTP_ADDRESS"
+	standardSMTPAddress            = "localhost:25"
+	envSMTPUsername                = "SMTP_USERNAME"
+	envSMTPPassword                = "SMTP_PASSWORD"
+	envPasswordHashTime            = "PASSWORD_HASH_TIME"
+	envPasswordHashMemory          = "PASSWORD_HASH_MEMORY"
+	envPasswordHashThreads         = "PASSWORD_HASH_THREADS"
+	envOAuthRedirectBaseURL        = "OAUTH_REDIRECT_BASE_URL"
+	standardOAuthRedirectBaseURL   = "http://localhost:3000/oauth"
+	envVKClientID                  = "VK_CLIENT_ID"
+	envOIDCName                    = "OIDC_NAME"
+	standardOIDCName               = "oidc"
+	envOIDCIssuer                  = "OIDC_ISSUER"
+	envOIDCClientID                = "OIDC_CLIENT_ID"
+	envOIDCClientSecret            = "OIDC_CLIENT_SECRET"
 	standardOutputLogPathAuth      = "stdout /var/log/backend/logs_auth.json"
 	standardErrorOutputLogPathAuth = "stderr /var/log/backend/err_logs_auth.json"
 )
@@ -26,24 +61,43 @@ type Config struct {
 	URLDataBase            string
 	PathCertFile           string
 	PathKeyFile            string
+	PathJwtKeysFile        string
+	JwtSecret              string
+	JwtKeyGracePeriod      time.Duration
+	MailSender             string
+	MailFrom               string
+	MailFilePath           string
+	MailLinkBaseURL        string
+	SMTPAddress            string
+	SMTPUsername           string
+	SMTPPassword           string
+	// PasswordHashParams changing of them causes rehash of passwords on next login of users
+	PasswordHashParams utils.HashParams
+	// OAuthRedirectBaseURL page of frontend, provider redirects user to it + "/" + name of provider.
+	// Provider is enabled, if its client id is set
+	OAuthRedirectBaseURL string
+	VKClientID           string
+	OIDCName             string
+	OIDCIssuer           string
+	OIDCClientID         string
+	OIDCClientSecret     string
 }
 
 func New() (*Config, error) {
-	secret := config.GetEnvStr(envStandardSecret, standardSecret)
-	if secret != standardSecret {
-		jwt.SetSecret([]byte(secret))
-	} else {
-		_, err := jwt.GetSecret()
-		if err != nil {
-			return nil, fmt.Errorf(myerrors.ErrTemplate, err)
-		}
-	}
-
 	productionMode := false
 	if config.GetEnvStr(envEnvironmentMode, standardDevelopmentMode) == standardProductionMode {
 		productionMode = true
 	}
 
+	passwordHashParams := utils.DefaultHashParams()
+	passwordHashParams.Time = uint32(config.GetEnvUint(envPasswordHashTime, uint64(passwordHashParams.Time), 32))
+	passwordHashParams.Memory = uint32(config.GetEnvUint(envPasswordHashMemory, uint64(passwordHashParams.Memory), 32))
+	passwordHashParams.Threads = uint8(config.GetEnvUint(envPasswordHashThreads, uint64(passwordHashParams.Threads), 8))
+
+	if err := passwordHashParams.Validate(); err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
 	return &Config{
 		ProductionMode:         productionMode,
 		AuthServiceName:        config.GetEnvStr(config.EnvServiceName, config.StandardAuthServiceName),
@@ -52,6 +106,23 @@ func New() (*Config, error) {
 		URLDataBase:            config.GetEnvStr(config.EnvURLDataBase, config.StandardURLDataBase),
 		PathCertFile:           config.GetEnvStr(config.EnvPathCertFile, config.StandardPathCertFile),
 		PathKeyFile:            config.GetEnvStr(config.EnvPathKeyFile, config.StandardPathKeyFile),
+		PathJwtKeysFile:        config.GetEnvStr(envPathJwtKeysFile, standardPathJwtKeysFile),
+		JwtSecret:              config.GetEnvStr(envStandardSecret, standardSecret),
+		JwtKeyGracePeriod:      config.GetEnvDuration(envJwtKeyGracePeriod, standardJwtKeyGracePeriod),
+		MailSender:             config.GetEnvStr(envMailSender, standardMailSender),
+		MailFrom:               config.GetEnvStr(envMailFrom, standardMailFrom),
+		MailFilePath:           config.GetEnvStr(envMailFilePath, standardMailFilePath),
+		MailLinkBaseURL:        config.GetEnvStr(envMailLinkBaseURL, standardMailLinkBaseURL),
+		SMTPAddress:            config.GetEnvStr(envSMTPAddress, standardSMTPAddress),
+		SMTPUsername:           config.GetEnvStr(envSMTPUsername, ""),
+		SMTPPassword:           config.GetEnvStr(envSMTPPassword, ""),
+		PasswordHashParams:     passwordHashParams,
+		OAuthRedirectBaseURL:   config.GetEnvStr(envOAuthRedirectBaseURL, standardOAuthRedirectBaseURL),
+		VKClientID:             config.GetEnvStr(envVKClientID, ""),
+		OIDCName:               config.GetEnvStr(envOIDCName, standardOIDCName),
+		OIDCIssuer:             config.GetEnvStr(envOIDCIssuer, ""),
+		OIDCClientID:           config.GetEnvStr(envOIDCClientID, ""),
+		OIDCClientSecret:       config.GetEnvStr(envOIDCClientSecret, ""),
 	}, nil
 }
 
@@ -0,0 +1,75 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"time"
+)
+
+const (
+	keyTypeOKP = "OKP"
+	keyTypeRSA = "RSA"
+	curveEd    = "Ed25519"
+	useSig     = "sig"
+)
+
+//easyjson:json
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	ID        string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+}
+
+//easyjson:json
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// newJSONWebKey returns false for HS256 key, because its secret can't be published.
+func newJSONWebKey(key *SigningKey) (JSONWebKey, bool) {
+	jsonWebKey := JSONWebKey{ID: key.ID, Algorithm: key.Algorithm(), Use: useSig} //nolint:exhaustruct
+
+	switch verifyKey := key.verifyKey.(type) {
+	case ed25519.PublicKey:
+		jsonWebKey.KeyType = keyTypeOKP
+		jsonWebKey.Curve = curveEd
+		jsonWebKey.X = base64.RawURLEncoding.EncodeToString(verifyKey)
+	case *rsa.PublicKey:
+		jsonWebKey.KeyType = keyTypeRSA
+		jsonWebKey.N = base64.RawURLEncoding.EncodeToString(verifyKey.N.Bytes())
+		jsonWebKey.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(verifyKey.E)).Bytes())
+	default:
+		return jsonWebKey, false
+	}
+
+	return jsonWebKey, true
+}
+
+// JSONWebKeySet returns public keys which verify jwt now, so other services
+// can verify jwt signed by EdDSA or RS256 without request to auth service.
+func (k *Keyring) JSONWebKeySet() *JSONWebKeySet {
+	now := time.Now()
+	jsonWebKeySet := &JSONWebKeySet{Keys: make([]JSONWebKey, 0)}
+
+	k.rwMu.RLock()
+	defer k.rwMu.RUnlock()
+
+	for _, key := range k.keys {
+		if !key.isVerifying(now, k.gracePeriod) {
+			continue
+		}
+
+		if jsonWebKey, ok := newJSONWebKey(key); ok {
+			jsonWebKeySet.Keys = append(jsonWebKeySet.Keys, jsonWebKey)
+		}
+	}
+
+	return jsonWebKeySet
+}
@@ -0,0 +1,232 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package jwt
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonA454815fDecodeGithubComGoParkMailRu20232RabotyagiServicesAuthInternalJwt(in *jlexer.Lexer, out *JSONWebKeySet) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "keys":
+			if in.IsNull() {
+				in.Skip()
+				out.Keys = nil
+			} else {
+				in.Delim('[')
+				if out.Keys == nil {
+					if !in.IsDelim(']') {
+						out.Keys = make([]JSONWebKey, 0, 0)
+					} else {
+						out.Keys = []JSONWebKey{}
+					}
+				} else {
+					out.Keys = (out.Keys)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 JSONWebKey
+					(v1).UnmarshalEasyJSON(in)
+					out.Keys = append(out.Keys, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA454815fEncodeGithubComGoParkMailRu20232RabotyagiServicesAuthInternalJwt(out *jwriter.Writer, in JSONWebKeySet) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"keys\":"
+		out.RawString(prefix[1:])
+		if in.Keys == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Keys {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v JSONWebKeySet) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA454815fEncodeGithubComGoParkMailRu20232RabotyagiServicesAuthInternalJwt(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v JSONWebKeySet) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA454815fEncodeGithubComGoParkMailRu20232RabotyagiServicesAuthInternalJwt(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *JSONWebKeySet) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA454815fDecodeGithubComGoParkMailRu20232RabotyagiServicesAuthInternalJwt(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *JSONWebKeySet) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA454815fDecodeGithubComGoParkMailRu20232RabotyagiServicesAuthInternalJwt(l, v)
+}
+func easyjsonA454815fDecodeGithubComGoParkMailRu20232RabotyagiServicesAuthInternalJwt1(in *jlexer.Lexer, out *JSONWebKey) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "kty":
+			out.KeyType = string(in.String())
+		case "kid":
+			out.ID = string(in.String())
+		case "alg":
+			out.Algorithm = string(in.String())
+		case "use":
+			out.Use = string(in.String())
+		case "crv":
+			out.Curve = string(in.String())
+		case "x":
+			out.X = string(in.String())
+		case "n":
+			out.N = string(in.String())
+		case "e":
+			out.E = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA454815fEncodeGithubComGoParkMailRu20232RabotyagiServicesAuthInternalJwt1(out *jwriter.Writer, in JSONWebKey) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"kty\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.KeyType))
+	}
+	{
+		const prefix string = ",\"kid\":"
+		out.RawString(prefix)
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"alg\":"
+		out.RawString(prefix)
+		out.String(string(in.Algorithm))
+	}
+	{
+		const prefix string = ",\"use\":"
+		out.RawString(prefix)
+		out.String(string(in.Use))
+	}
+	if in.Curve != "" {
+		const prefix string = ",\"crv\":"
+		out.RawString(prefix)
+		out.String(string(in.Curve))
+	}
+	if in.X != "" {
+		const prefix string = ",\"x\":"
+		out.RawString(prefix)
+		out.String(string(in.X))
+	}
+	if in.N != "" {
+		const prefix string = ",\"n\":"
+		out.RawString(prefix)
+		out.String(string(in.N))
+	}
+	if in.E != "" {
+		const prefix string = ",\"e\":"
+		out.RawString(prefix)
+		out.String(string(in.E))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v JSONWebKey) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA454815fEncodeGithubComGoParkMailRu20232RabotyagiServicesAuthInternalJwt1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v JSONWebKey) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA454815fEncodeGithubComGoParkMailRu20232RabotyagiServicesAuthInternalJwt1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *JSONWebKey) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA454815fDecodeGithubComGoParkMailRu20232RabotyagiServicesAuthInternalJwt1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *JSONWebKey) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA454815fDecodeGithubComGoParkMailRu20232RabotyagiServicesAuthInternalJwt1(l, v)
+}
@@ -1,124 +1,61 @@
 package jwt
 
 import (
-	"crypto/rand"
 	"fmt"
-	"sync"
 	"time"
 
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/auth"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
 	"github.com/golang-jwt/jwt"
 )
 
 const (
-	lenSecret     = 64
-	TimeTokenLife = 24 * time.Hour
+	// TimeAccessTokenLife is short, because access token can't be revoked without request to database
+	TimeAccessTokenLife = 15 * time.Minute
+	// TimeRefreshTokenLife is life of session, it is extended by every refresh
+	TimeRefreshTokenLife = 30 * 24 * time.Hour
 )
 
-var ErrGenerateSecret = myerrors.NewErrorInternal("Не получилось сгенерить секрет")
-
-var (
-	globalSecret []byte       //nolint:gochecknoglobals
-	rwMu         sync.RWMutex //nolint:gochecknoglobals
-)
-
-func StartRefreshingSecret(period time.Duration, chClose <-chan struct{}) error {
-	logger, err := mylogger.Get()
-	if err != nil {
-		return fmt.Errorf(myerrors.ErrTemplate, err)
-	}
-
-	go func() {
-		for {
-			select {
-			case <-chClose:
-				return
-			default:
-				time.Sleep(period)
-
-				err = refreshSecret()
-				if err != nil {
-					logger.Errorln(err)
-				}
-			}
-		}
-	}()
-
-	return nil
-}
-
-func SetSecret(secret []byte) {
-	rwMu.Lock()
-	globalSecret = secret
-	rwMu.Unlock()
-}
-
-func refreshSecret() error {
-	rwMu.Lock()
-	globalSecret = make([]byte, lenSecret)
-
-	logger, err := mylogger.Get()
-	if err != nil {
-		return fmt.Errorf(myerrors.ErrTemplate, err)
-	}
-
-	_, err = rand.Read(globalSecret)
-	if err != nil {
-		err = fmt.Errorf("%w %v", ErrGenerateSecret, err) //nolint:errorlint
-		logger.Errorln(err)
-
-		return err
-	}
-	rwMu.Unlock()
-
-	return nil
-}
-
-// GetSecret return secret for jwt if it exists
-// and additionally generate secret if not exist
-func GetSecret() ([]byte, error) {
-	var result []byte
-
-	if globalSecret == nil {
-		err := refreshSecret()
-		if err != nil {
-			return nil, fmt.Errorf(myerrors.ErrTemplate, err)
-		}
-	}
-
-	rwMu.RLock()
-	result = globalSecret
-	rwMu.RUnlock()
-
-	return result, nil
-}
-
 var (
 	ErrNilToken           = myerrors.NewErrorInternal("Получили токен = nil")
+	ErrNilSigningKey      = myerrors.NewErrorInternal("Получили ключ подписи = nil")
 	ErrWrongSigningMethod = myerrors.NewErrorBadFormatRequest("Неожиданный signing метод jwt токена")
 	ErrInvalidToken       = myerrors.NewErrorBadFormatRequest("Некорректный токен")
 )
 
+// UserJwtPayload SessionID is jti of token, token without jti isn't bound to session.
+// Token without role belongs to auth.RoleUser
 type UserJwtPayload struct {
-	UserID uint64
-	Expire int64
+	UserID    uint64
+	Expire    int64
+	SessionID string
+	Role      string
 }
 
-func NewUserJwtPayload(rawJwt string, secret []byte) (*UserJwtPayload, error) {
+// NewUserJwtPayload verifies jwt by key of keyring with kid from header of jwt.
+func NewUserJwtPayload(rawJwt string, keyring *Keyring) (*UserJwtPayload, error) {
 	logger, err := mylogger.Get()
 	if err != nil {
 		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
 	tokenDuplicity, err := jwt.Parse(rawJwt, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			logger.Errorf("method == %+v %w", token.Header["alg"], ErrWrongSigningMethod)
+		keyID, _ := token.Header[headerKeyID].(string)
+
+		key, err := keyring.VerifyingKey(keyID)
+		if err != nil {
+			return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		// alg of header must be the same as alg of key, else public key can be used as hmac secret
+		if token.Method.Alg() != key.method.Alg() {
+			logger.Errorf("method == %+v %+v", token.Header["alg"], ErrWrongSigningMethod)
 
 			return nil, fmt.Errorf(myerrors.ErrTemplate, ErrInvalidToken)
 		}
 
-		return secret, nil
+		return key.verifyKey, nil
 	})
 	if err != nil {
 		logger.Errorf("%s", err.Error())
@@ -145,7 +82,29 @@ func NewUserJwtPayload(rawJwt string, secret []byte) (*UserJwtPayload, error) {
 			return nil, fmt.Errorf(myerrors.ErrTemplate, ErrInvalidToken)
 		}
 
-		return &UserJwtPayload{UserID: uint64(userID), Expire: int64(expire)}, nil
+		var sessionID string
+
+		if interfaceSessionID, ok := claims["jti"]; ok {
+			sessionID, ok = interfaceSessionID.(string)
+			if !ok {
+				logger.Errorf("error with casting jti: %+v", claims)
+
+				return nil, fmt.Errorf(myerrors.ErrTemplate, ErrInvalidToken)
+			}
+		}
+
+		role := auth.RoleUser
+
+		if interfaceRole, ok := claims["role"]; ok {
+			role, ok = interfaceRole.(string)
+			if !ok {
+				logger.Errorf("error with casting role: %+v", claims)
+
+				return nil, fmt.Errorf(myerrors.ErrTemplate, ErrInvalidToken)
+			}
+		}
+
+		return &UserJwtPayload{UserID: uint64(userID), Expire: int64(expire), SessionID: sessionID, Role: role}, nil
 	}
 
 	return nil, fmt.Errorf(myerrors.ErrTemplate, ErrInvalidToken)
@@ -157,10 +116,19 @@ func (u *UserJwtPayload) getMapClaims() jwt.MapClaims {
 	result["userID"] = u.UserID
 	result["expire"] = u.Expire
 
+	if u.SessionID != "" {
+		result["jti"] = u.SessionID
+	}
+
+	if u.Role != "" {
+		result["role"] = u.Role
+	}
+
 	return result
 }
 
-func GenerateJwtToken(userToken *UserJwtPayload, secret []byte) (string, error) {
+// GenerateJwtToken signs jwt by key and puts id of key in kid header.
+func GenerateJwtToken(userToken *UserJwtPayload, key *SigningKey) (string, error) {
 	logger, err := mylogger.Get()
 	if err != nil {
 		return "", err //nolint:wrapcheck
@@ -172,9 +140,16 @@ func GenerateJwtToken(userToken *UserJwtPayload, secret []byte) (string, error)
 		return "", fmt.Errorf(myerrors.ErrTemplate, ErrInvalidToken)
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, userToken.getMapClaims())
+	if key == nil {
+		logger.Errorln(ErrNilSigningKey)
+
+		return "", fmt.Errorf(myerrors.ErrTemplate, ErrInvalidToken)
+	}
+
+	token := jwt.NewWithClaims(key.method, userToken.getMapClaims())
+	token.Header[headerKeyID] = key.ID
 
-	tokenString, err := token.SignedString(secret)
+	tokenString, err := token.SignedString(key.signKey)
 	if err != nil {
 		logger.Errorln(err)
 
@@ -4,9 +4,10 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/auth"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
-	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/jwt"
 )
 
@@ -24,14 +25,14 @@ func TestGenerateJwtToken(t *testing.T) {
 
 	var expire int64
 
-	secret := []byte("thisIsTestSecretItCanBeWeak")
+	key := jwt.NewSigningKeyHS256("test_kid", []byte("thisIsTestSecretItCanBeWeak"))
 	testCases := [...]TestCase{
 		{
 			name:                "test basic work",
 			inputUserJwtPayload: &jwt.UserJwtPayload{UserID: 1, Expire: expire},
-			expectedJwt: "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9." +
+			expectedJwt: "eyJhbGciOiJIUzI1NiIsImtpZCI6InRlc3Rfa2lkIiwidHlwIjoiSldUIn0." +
 				"eyJleHBpcmUiOjAsInVzZXJJRCI6MX0." +
-				"AxRUwsu1l_QGC2guNzeTYYOdIglczlCEyO2WUDKwfFw",
+				"BecsJ8DlkJFQNG0qqY3ejDAFy5Lsmqkh-QKlyuo0K5k",
 			expectedError: nil,
 		},
 		{
@@ -40,9 +41,9 @@ func TestGenerateJwtToken(t *testing.T) {
 				UserID: 100000,
 				Expire: expire,
 			},
-			expectedJwt: "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9." +
+			expectedJwt: "eyJhbGciOiJIUzI1NiIsImtpZCI6InRlc3Rfa2lkIiwidHlwIjoiSldUIn0." +
 				"eyJleHBpcmUiOjAsInVzZXJJRCI6MTAwMDAwfQ." +
-				"TzJp8AoDA8hX1vQGc-m6OSAGO_w_n2gXu5J0ePJkK_k",
+				"RH7CFaqOFLSdTh0epTzIZCErBqsttgUhuE84CZzlScI",
 			expectedError: nil,
 		},
 		{
@@ -59,7 +60,7 @@ func TestGenerateJwtToken(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			t.Parallel()
 
-			receivedJwt, err := jwt.GenerateJwtToken(testCase.inputUserJwtPayload, secret)
+			receivedJwt, err := jwt.GenerateJwtToken(testCase.inputUserJwtPayload, key)
 			if !errors.Is(err, testCase.expectedError) {
 				t.Errorf("EXPECTED error: %v\n RECEIVED error: %v\n", testCase.expectedError, err)
 			}
@@ -83,28 +84,42 @@ func TestNewUserJwtPayload(t *testing.T) {
 		expectedError          error
 	}
 
-	secret := []byte("thisIsTestSecretItCanBeWeak")
+	keyring, err := jwt.NewKeyring([]*jwt.SigningKey{
+		jwt.NewSigningKeyHS256("test_kid", []byte("thisIsTestSecretItCanBeWeak")),
+	}, time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
 	testCases := [...]TestCase{
 		{
 			name: "test basic work",
-			inputRawJwt: "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9." +
-				"eyJlbWFpbCI6ImV4YW1wbGVAbWFpbC5ydSIsImV4cGlyZSI6MCwidXNlcklEIjoxfQ." +
-				"GBCEb3XJ6aHTsyl8jC3lxSWK6byjbYN0kg2e3NH2i9s",
-			expectedUserJwtPayload: &jwt.UserJwtPayload{UserID: 1, Expire: 0},
+			inputRawJwt: "eyJhbGciOiJIUzI1NiIsImtpZCI6InRlc3Rfa2lkIiwidHlwIjoiSldUIn0." +
+				"eyJleHBpcmUiOjAsInVzZXJJRCI6MX0." +
+				"BecsJ8DlkJFQNG0qqY3ejDAFy5Lsmqkh-QKlyuo0K5k",
+			expectedUserJwtPayload: &jwt.UserJwtPayload{UserID: 1, Expire: 0, Role: auth.RoleUser},
 			expectedError:          nil,
 		},
 		{
-			name: "test long email and big userID",
-			inputRawJwt: "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9." +
-				"eyJlbWFpbCI6ImV4YW1wbGVhYWFhYWFhYWFhYWFhYWF" +
-				"hYWFhYWFhYWFhYWFhYWFhYWFhYWFhQG1haWwucnUiLCJleHBpcmUiOjAsInVzZXJJRCI6MTAwMDAwfQ." +
-				"CQIXkeDEW3Y0ffLm9efgsozkWvLK1sg4ArmYBReHjsE",
+			name: "test big userID",
+			inputRawJwt: "eyJhbGciOiJIUzI1NiIsImtpZCI6InRlc3Rfa2lkIiwidHlwIjoiSldUIn0." +
+				"eyJleHBpcmUiOjAsInVzZXJJRCI6MTAwMDAwfQ." +
+				"RH7CFaqOFLSdTh0epTzIZCErBqsttgUhuE84CZzlScI",
 			expectedUserJwtPayload: &jwt.UserJwtPayload{
 				UserID: 100000,
 				Expire: 0,
+				Role:   auth.RoleUser,
 			},
 			expectedError: nil,
 		},
+		{
+			name: "test token without kid",
+			inputRawJwt: "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9." +
+				"eyJleHBpcmUiOjAsInVzZXJJRCI6MX0." +
+				"AxRUwsu1l_QGC2guNzeTYYOdIglczlCEyO2WUDKwfFw",
+			expectedUserJwtPayload: nil,
+			expectedError:          jwt.ErrInvalidToken,
+		},
 		{
 			name:                   "test parsing invalid token",
 			inputRawJwt:            "",
@@ -119,7 +134,7 @@ func TestNewUserJwtPayload(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			t.Parallel()
 
-			receivedUserJwtPayload, err := jwt.NewUserJwtPayload(testCase.inputRawJwt, secret)
+			receivedUserJwtPayload, err := jwt.NewUserJwtPayload(testCase.inputRawJwt, keyring)
 			if !errors.Is(err, testCase.expectedError) {
 				t.Errorf("EXPECTED error: %v\n RECEIVED error: %v\n", testCase.expectedError, err)
 			}
@@ -132,19 +147,33 @@ func TestNewUserJwtPayload(t *testing.T) {
 	}
 }
 
-func TestSetSecret(t *testing.T) {
+// TestSessionIDInJwt session id and role are carried in jwt.
+func TestSessionIDInJwt(t *testing.T) {
 	t.Parallel()
 
-	secret := []byte("test secret")
+	mylogger.NewNop()
+
+	key := jwt.NewSigningKeyHS256("test_kid", []byte("thisIsTestSecretItCanBeWeak"))
 
-	jwt.SetSecret(secret)
+	keyring, err := jwt.NewKeyring([]*jwt.SigningKey{key}, time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	userJwtPayload := &jwt.UserJwtPayload{UserID: 1, Expire: 0, SessionID: "test_session", Role: auth.RoleAdmin}
+
+	rawJwt, err := jwt.GenerateJwtToken(userJwtPayload, key)
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
 
-	receivedSecret, err := jwt.GetSecret()
+	receivedUserJwtPayload, err := jwt.NewUserJwtPayload(rawJwt, keyring)
 	if err != nil {
-		t.Errorf("неожиданная ошибка %+v", err)
+		t.Fatalf("unexpected error %+v", err)
 	}
 
-	if err := utils.CompareSameType(string(receivedSecret), string(secret)); err != nil {
-		t.Errorf("ошибка сравнения секретов %+v", err)
+	if !reflect.DeepEqual(receivedUserJwtPayload, userJwtPayload) {
+		t.Errorf("EXPECTED UserJwtPayload: %v\n RECEIVED UserJwtPayload: %v\n",
+			userJwtPayload, receivedUserJwtPayload)
 	}
 }
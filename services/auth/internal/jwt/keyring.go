@@ -0,0 +1,308 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	headerKeyID = "kid"
+
+	AlgorithmHS256 = "HS256"
+	AlgorithmEdDSA = "EdDSA"
+	AlgorithmRS256 = "RS256"
+
+	lenGeneratedSecret     = 64
+	lenGeneratedKeyIDBytes = 8
+)
+
+var (
+	ErrUnknownKeyID     = myerrors.NewErrorBadFormatRequest("Неизвестный ключ подписи jwt токена")
+	ErrNoActiveKey      = myerrors.NewErrorInternal("Нет действующего ключа подписи jwt")
+	ErrEmptyKeyID       = myerrors.NewErrorInternal("У ключа подписи jwt пустой kid")
+	ErrDuplicateKeyID   = myerrors.NewErrorInternal("Несколько ключей подписи jwt с одинаковым kid")
+	ErrUnknownAlgorithm = myerrors.NewErrorInternal("Неизвестный алгоритм подписи jwt")
+	ErrWrongKey         = myerrors.NewErrorInternal("Некорректный ключ подписи jwt")
+)
+
+// SigningKey RetiredAt is zero for active key. Retired key doesn't sign new jwt,
+// but verifies already signed jwt during grace period of keyring.
+type SigningKey struct {
+	ID        string
+	RetiredAt time.Time
+	method    jwt.SigningMethod
+	signKey   any
+	verifyKey any
+}
+
+func NewSigningKeyHS256(keyID string, secret []byte) *SigningKey {
+	return &SigningKey{ //nolint:exhaustruct
+		ID:        keyID,
+		method:    jwt.SigningMethodHS256,
+		signKey:   secret,
+		verifyKey: secret,
+	}
+}
+
+func NewSigningKeyEdDSA(keyID string, privateKey ed25519.PrivateKey) *SigningKey {
+	return &SigningKey{ //nolint:exhaustruct
+		ID:        keyID,
+		method:    jwt.SigningMethodEdDSA,
+		signKey:   privateKey,
+		verifyKey: privateKey.Public(),
+	}
+}
+
+func NewSigningKeyRS256(keyID string, privateKey *rsa.PrivateKey) *SigningKey {
+	return &SigningKey{ //nolint:exhaustruct
+		ID:        keyID,
+		method:    jwt.SigningMethodRS256,
+		signKey:   privateKey,
+		verifyKey: &privateKey.PublicKey,
+	}
+}
+
+// GenerateSigningKey generates random HS256 key. It is for development only,
+// because jwt signed by it aren't valid after restart and on other replicas.
+func GenerateSigningKey() (*SigningKey, error) {
+	secret := make([]byte, lenGeneratedSecret)
+
+	_, err := rand.Read(secret)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	keyID := make([]byte, lenGeneratedKeyIDBytes)
+
+	_, err = rand.Read(keyID)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return NewSigningKeyHS256(hex.EncodeToString(keyID), secret), nil
+}
+
+func (s *SigningKey) Algorithm() string {
+	return s.method.Alg()
+}
+
+func (s *SigningKey) isActive(now time.Time) bool {
+	return s.RetiredAt.IsZero() || now.Before(s.RetiredAt)
+}
+
+func (s *SigningKey) isVerifying(now time.Time, gracePeriod time.Duration) bool {
+	return s.RetiredAt.IsZero() || now.Before(s.RetiredAt.Add(gracePeriod))
+}
+
+// KeyConfig is key in file of keys. Secret of HS256 is base64,
+// PrivateKey of EdDSA and RS256 is PEM.
+type KeyConfig struct {
+	ID         string     `json:"kid"`
+	Algorithm  string     `json:"alg"`
+	Secret     string     `json:"secret,omitempty"`
+	PrivateKey string     `json:"private_key,omitempty"`
+	RetiredAt  *time.Time `json:"retired_at,omitempty"`
+}
+
+// KeysConfig first active key signs new jwt, so new key is added before old one
+// and old one gets retired_at.
+type KeysConfig struct {
+	Keys []KeyConfig `json:"keys"`
+}
+
+func NewSigningKey(keyConfig *KeyConfig) (*SigningKey, error) {
+	var signingKey *SigningKey
+
+	switch keyConfig.Algorithm {
+	case AlgorithmHS256:
+		secret, err := base64.StdEncoding.DecodeString(keyConfig.Secret)
+		if err != nil || len(secret) == 0 {
+			return nil, fmt.Errorf("%w kid=%s", ErrWrongKey, keyConfig.ID)
+		}
+
+		signingKey = NewSigningKeyHS256(keyConfig.ID, secret)
+	case AlgorithmEdDSA:
+		privateKey, err := jwt.ParseEdPrivateKeyFromPEM([]byte(keyConfig.PrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("%w kid=%s: %v", ErrWrongKey, keyConfig.ID, err) //nolint:errorlint
+		}
+
+		edPrivateKey, ok := privateKey.(ed25519.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("%w kid=%s", ErrWrongKey, keyConfig.ID)
+		}
+
+		signingKey = NewSigningKeyEdDSA(keyConfig.ID, edPrivateKey)
+	case AlgorithmRS256:
+		privateKey, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(keyConfig.PrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("%w kid=%s: %v", ErrWrongKey, keyConfig.ID, err) //nolint:errorlint
+		}
+
+		signingKey = NewSigningKeyRS256(keyConfig.ID, privateKey)
+	default:
+		return nil, fmt.Errorf("%w alg=%s kid=%s", ErrUnknownAlgorithm, keyConfig.Algorithm, keyConfig.ID)
+	}
+
+	if keyConfig.RetiredAt != nil {
+		signingKey.RetiredAt = *keyConfig.RetiredAt
+	}
+
+	return signingKey, nil
+}
+
+// LoadSigningKeys reads keys from json file in format of KeysConfig.
+func LoadSigningKeys(path string) ([]*SigningKey, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	var keysConfig KeysConfig
+
+	err = json.Unmarshal(content, &keysConfig)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	signingKeys := make([]*SigningKey, 0, len(keysConfig.Keys))
+
+	for i := range keysConfig.Keys {
+		signingKey, err := NewSigningKey(&keysConfig.Keys[i])
+		if err != nil {
+			return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		signingKeys = append(signingKeys, signingKey)
+	}
+
+	return signingKeys, nil
+}
+
+// Keyring keys are in order of priority, first active key signs new jwt.
+type Keyring struct {
+	gracePeriod time.Duration
+	rwMu        sync.RWMutex
+	keys        []*SigningKey
+	keysByID    map[string]*SigningKey
+}
+
+func NewKeyring(keys []*SigningKey, gracePeriod time.Duration) (*Keyring, error) {
+	keyring := &Keyring{gracePeriod: gracePeriod} //nolint:exhaustruct
+
+	err := keyring.SetKeys(keys)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return keyring, nil
+}
+
+// SetKeys replaces keys of keyring. Keys are checked before replacing,
+// so wrong keys don't break already working keyring.
+func (k *Keyring) SetKeys(keys []*SigningKey) error {
+	now := time.Now()
+	hasActiveKey := false
+	keysByID := make(map[string]*SigningKey, len(keys))
+
+	for _, key := range keys {
+		if key.ID == "" {
+			return fmt.Errorf(myerrors.ErrTemplate, ErrEmptyKeyID)
+		}
+
+		if _, ok := keysByID[key.ID]; ok {
+			return fmt.Errorf("%w kid=%s", ErrDuplicateKeyID, key.ID)
+		}
+
+		keysByID[key.ID] = key
+		hasActiveKey = hasActiveKey || key.isActive(now)
+	}
+
+	if !hasActiveKey {
+		return fmt.Errorf(myerrors.ErrTemplate, ErrNoActiveKey)
+	}
+
+	k.rwMu.Lock()
+	k.keys = keys
+	k.keysByID = keysByID
+	k.rwMu.Unlock()
+
+	return nil
+}
+
+// SigningKey returns first active key. Key with retired_at in future
+// stops signing at that moment without reload of keys.
+func (k *Keyring) SigningKey() (*SigningKey, error) {
+	now := time.Now()
+
+	k.rwMu.RLock()
+	defer k.rwMu.RUnlock()
+
+	for _, key := range k.keys {
+		if key.isActive(now) {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf(myerrors.ErrTemplate, ErrNoActiveKey)
+}
+
+// VerifyingKey returns key by kid if it is active or retired less than grace period ago.
+func (k *Keyring) VerifyingKey(keyID string) (*SigningKey, error) {
+	k.rwMu.RLock()
+	key, ok := k.keysByID[keyID]
+	k.rwMu.RUnlock()
+
+	if !ok || !key.isVerifying(time.Now(), k.gracePeriod) {
+		return nil, fmt.Errorf("%w kid=%s", ErrUnknownKeyID, keyID)
+	}
+
+	return key, nil
+}
+
+// StartReloadingKeys periodically reads keys from path until chClose is closed,
+// so rotation of keys doesn't need restart. On error old keys are kept.
+func (k *Keyring) StartReloadingKeys(path string, period time.Duration, chClose <-chan struct{}) error {
+	logger, err := mylogger.Get()
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	go func() {
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-chClose:
+				return
+			case <-ticker.C:
+				keys, err := LoadSigningKeys(path)
+				if err != nil {
+					logger.Errorln(err)
+
+					continue
+				}
+
+				err = k.SetKeys(keys)
+				if err != nil {
+					logger.Errorln(err)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,233 @@
+package jwt_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/auth"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/jwt"
+	jwtlib "github.com/golang-jwt/jwt"
+)
+
+const lenTestRSAKeyBits = 2048
+
+func TestKeyringRotation(t *testing.T) {
+	t.Parallel()
+
+	mylogger.NewNop()
+
+	now := time.Now()
+	userJwtPayload := &jwt.UserJwtPayload{UserID: 1, Expire: 0, SessionID: "test_session"}
+
+	newKey := jwt.NewSigningKeyHS256("new", []byte("newSecret"))
+	graceKey := jwt.NewSigningKeyHS256("grace", []byte("graceSecret"))
+	graceKey.RetiredAt = now.Add(-time.Minute)
+	expiredKey := jwt.NewSigningKeyHS256("expired", []byte("expiredSecret"))
+	expiredKey.RetiredAt = now.Add(-time.Hour)
+
+	keyring, err := jwt.NewKeyring([]*jwt.SigningKey{graceKey, newKey, expiredKey}, 10*time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	signingKey, err := keyring.SigningKey()
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	if signingKey.ID != newKey.ID {
+		t.Fatalf("signing key: got %s, expected %s", signingKey.ID, newKey.ID)
+	}
+
+	type TestCase struct {
+		name          string
+		key           *jwt.SigningKey
+		expectedError error
+	}
+
+	testCases := [...]TestCase{
+		{name: "test active key", key: newKey, expectedError: nil},
+		{name: "test retired key in grace period", key: graceKey, expectedError: nil},
+		{name: "test retired key after grace period", key: expiredKey, expectedError: jwt.ErrInvalidToken},
+		{
+			name:          "test unknown key",
+			key:           jwt.NewSigningKeyHS256("unknown", []byte("newSecret")),
+			expectedError: jwt.ErrInvalidToken,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			rawJwt, err := jwt.GenerateJwtToken(userJwtPayload, testCase.key)
+			if err != nil {
+				t.Fatalf("unexpected error %+v", err)
+			}
+
+			_, err = jwt.NewUserJwtPayload(rawJwt, keyring)
+			if !errors.Is(err, testCase.expectedError) {
+				t.Errorf("EXPECTED error: %v\n RECEIVED error: %v\n", testCase.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestKeyringWithoutActiveKey(t *testing.T) {
+	t.Parallel()
+
+	retiredKey := jwt.NewSigningKeyHS256("retired", []byte("secret"))
+	retiredKey.RetiredAt = time.Now().Add(-time.Minute)
+
+	_, err := jwt.NewKeyring([]*jwt.SigningKey{retiredKey}, time.Hour)
+	if !errors.Is(err, jwt.ErrNoActiveKey) {
+		t.Errorf("EXPECTED error: %v\n RECEIVED error: %v\n", jwt.ErrNoActiveKey, err)
+	}
+
+	_, err = jwt.NewKeyring([]*jwt.SigningKey{
+		jwt.NewSigningKeyHS256("same", []byte("secret")),
+		jwt.NewSigningKeyHS256("same", []byte("other secret")),
+	}, time.Hour)
+	if !errors.Is(err, jwt.ErrDuplicateKeyID) {
+		t.Errorf("EXPECTED error: %v\n RECEIVED error: %v\n", jwt.ErrDuplicateKeyID, err)
+	}
+}
+
+func newTestAsymmetricKeys(t *testing.T) (*jwt.SigningKey, *jwt.SigningKey) {
+	t.Helper()
+
+	_, edPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	rsaPrivateKey, err := rsa.GenerateKey(rand.Reader, lenTestRSAKeyBits)
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	return jwt.NewSigningKeyEdDSA("ed", edPrivateKey), jwt.NewSigningKeyRS256("rsa", rsaPrivateKey)
+}
+
+func TestAsymmetricKeys(t *testing.T) {
+	t.Parallel()
+
+	mylogger.NewNop()
+
+	edKey, rsaKey := newTestAsymmetricKeys(t)
+	userJwtPayload := &jwt.UserJwtPayload{UserID: 1, Expire: 0, SessionID: "test_session", Role: auth.RoleUser}
+
+	keyring, err := jwt.NewKeyring([]*jwt.SigningKey{edKey, rsaKey}, time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	for _, key := range []*jwt.SigningKey{edKey, rsaKey} {
+		rawJwt, err := jwt.GenerateJwtToken(userJwtPayload, key)
+		if err != nil {
+			t.Fatalf("unexpected error %+v", err)
+		}
+
+		receivedUserJwtPayload, err := jwt.NewUserJwtPayload(rawJwt, keyring)
+		if err != nil {
+			t.Fatalf("unexpected error %s %+v", key.Algorithm(), err)
+		}
+
+		if !reflect.DeepEqual(receivedUserJwtPayload, userJwtPayload) {
+			t.Errorf("EXPECTED UserJwtPayload: %v\n RECEIVED UserJwtPayload: %v\n",
+				userJwtPayload, receivedUserJwtPayload)
+		}
+	}
+
+	jsonWebKeySet := keyring.JSONWebKeySet()
+	if len(jsonWebKeySet.Keys) != 2 ||
+		jsonWebKeySet.Keys[0].KeyType != "OKP" || jsonWebKeySet.Keys[0].ID != "ed" ||
+		jsonWebKeySet.Keys[1].KeyType != "RSA" || jsonWebKeySet.Keys[1].ID != "rsa" {
+		t.Errorf("wrong json web key set: %+v", jsonWebKeySet)
+	}
+}
+
+// TestAlgorithmConfusion public key of EdDSA key mustn't be accepted as secret of HS256.
+func TestAlgorithmConfusion(t *testing.T) {
+	t.Parallel()
+
+	mylogger.NewNop()
+
+	edKey, _ := newTestAsymmetricKeys(t)
+
+	keyring, err := jwt.NewKeyring([]*jwt.SigningKey{edKey}, time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	jsonWebKeySet := keyring.JSONWebKeySet()
+
+	token := jwtlib.NewWithClaims(jwtlib.SigningMethodHS256, jwtlib.MapClaims{"userID": 1, "expire": 0})
+	token.Header["kid"] = edKey.ID
+
+	rawJwt, err := token.SignedString([]byte(jsonWebKeySet.Keys[0].X))
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	_, err = jwt.NewUserJwtPayload(rawJwt, keyring)
+	if !errors.Is(err, jwt.ErrInvalidToken) {
+		t.Errorf("EXPECTED error: %v\n RECEIVED error: %v\n", jwt.ErrInvalidToken, err)
+	}
+}
+
+func TestLoadSigningKeys(t *testing.T) {
+	t.Parallel()
+
+	_, edPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	derPrivateKey, err := x509.MarshalPKCS8PrivateKey(edPrivateKey)
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	pemPrivateKey := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: derPrivateKey})
+
+	content := fmt.Sprintf(`{"keys":[
+{"kid":"ed","alg":"EdDSA","private_key":%q},
+{"kid":"old","alg":"HS256","secret":"dGVzdCBzZWNyZXQ=","retired_at":"2024-02-01T00:00:00Z"}]}`, pemPrivateKey)
+
+	path := filepath.Join(t.TempDir(), "keys.json")
+
+	err = os.WriteFile(path, []byte(content), 0o600)
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	keys, err := jwt.LoadSigningKeys(path)
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	if len(keys) != 2 || keys[0].ID != "ed" || keys[0].Algorithm() != jwt.AlgorithmEdDSA ||
+		keys[1].ID != "old" || keys[1].Algorithm() != jwt.AlgorithmHS256 ||
+		!keys[1].RetiredAt.Equal(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("wrong keys: %+v", keys)
+	}
+
+	_, err = jwt.NewSigningKey(&jwt.KeyConfig{ID: "wrong", Algorithm: "none"}) //nolint:exhaustruct
+	if !errors.Is(err, jwt.ErrUnknownAlgorithm) {
+		t.Errorf("EXPECTED error: %v\n RECEIVED error: %v\n", jwt.ErrUnknownAlgorithm, err)
+	}
+}
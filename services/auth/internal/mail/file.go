@@ -0,0 +1,73 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+)
+
+const permMailFile = 0o600
+
+// FileSender appends letters to file instead of sending, so links from them can be
+// opened in local development and checked in tests.
+type FileSender struct {
+	path string
+	from string
+	mu   sync.Mutex
+}
+
+func NewFileSender(path string, from string) *FileSender {
+	return &FileSender{path: path, from: from} //nolint:exhaustruct
+}
+
+func (f *FileSender) Send(_ context.Context, message *Message) error {
+	if err := message.validate(); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, permMailFile)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	_, err = fmt.Fprintf(file, "Date: %s\r\n%s\r\n", time.Now().Format(time.RFC1123Z), buildMessage(f.from, message))
+	if err != nil {
+		_ = file.Close()
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return file.Close() //nolint:wrapcheck
+}
+
+// LogSender writes letters to log. It is default sender in development.
+type LogSender struct {
+	logger *mylogger.MyLogger
+}
+
+func NewLogSender() (*LogSender, error) {
+	logger, err := mylogger.Get()
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return &LogSender{logger: logger}, nil
+}
+
+func (l *LogSender) Send(ctx context.Context, message *Message) error {
+	if err := message.validate(); err != nil {
+		return err
+	}
+
+	l.logger.LogReqID(ctx).Infof("letter to %s with subject %q:\n%s", message.To, message.Subject, message.Body)
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package mail_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/mail"
+)
+
+func TestFileSender(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "mails.txt")
+	sender := mail.NewFileSender(path, "noreply@goods-galaxy.ru")
+
+	err := sender.Send(context.Background(), &mail.Message{
+		To:      "ivn-tyt@mail.ru",
+		Subject: "Восстановление пароля",
+		Body:    "http://localhost:3000/reset_password?token=test_token",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	for _, expected := range []string{
+		"From: noreply@goods-galaxy.ru\r\n",
+		"To: ivn-tyt@mail.ru\r\n",
+		"Subject: =?utf-8?q?",
+		"http://localhost:3000/reset_password?token=test_token",
+	} {
+		if !strings.Contains(string(content), expected) {
+			t.Fatalf("letter doesn't contain %q:\n%s", expected, content)
+		}
+	}
+}
+
+func TestFileSenderHeaderInjection(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "mails.txt")
+	sender := mail.NewFileSender(path, "noreply@goods-galaxy.ru")
+
+	err := sender.Send(context.Background(), &mail.Message{
+		To:      "ivn-tyt@mail.ru\r\nBcc: victim@mail.ru",
+		Subject: "Подтверждение email",
+		Body:    "",
+	})
+	if !errors.Is(err, mail.ErrWrongHeader) {
+		t.Fatalf("got error %+v, expected %+v", err, mail.ErrWrongHeader)
+	}
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("letter with injected header is written")
+	}
+}
@@ -0,0 +1,26 @@
+package mail
+
+import (
+	"strings"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+)
+
+var ErrWrongHeader = myerrors.NewErrorInternal("Заголовок письма содержит перевод строки")
+
+// Message is letter to user. SMTPSender sends it in production,
+// FileSender and LogSender are for local development and tests.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// validate rejects line breaks in headers, otherwise they can inject other headers.
+func (m *Message) validate() error {
+	if strings.ContainsAny(m.To, "\r\n") || strings.ContainsAny(m.Subject, "\r\n") {
+		return ErrWrongHeader
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+)
+
+const timeoutSMTP = 10 * time.Second
+
+type SMTPSender struct {
+	address string
+	host    string
+	from    string
+	auth    smtp.Auth
+}
+
+// NewSMTPSender address is host:port of smtp server. Without username letters are sent
+// without authentication, e.g. to local relay.
+func NewSMTPSender(address string, username string, password string, from string) (*SMTPSender, error) {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &SMTPSender{address: address, host: host, from: from, auth: auth}, nil
+}
+
+// buildMessage returns letter in format of RFC 5322, subject is encoded because it is in russian.
+func buildMessage(from string, message *Message) []byte {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", message.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&buf, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	fmt.Fprintf(&buf, "\r\n%s\r\n", message.Body)
+
+	return buf.Bytes()
+}
+
+func (s *SMTPSender) Send(ctx context.Context, message *Message) error {
+	if err := message.validate(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeoutSMTP)
+	defer cancel()
+
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", s.address) //nolint:exhaustruct
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	deadline, _ := ctx.Deadline()
+	_ = conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		_ = conn.Close()
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		err = client.StartTLS(&tls.Config{ServerName: s.host, MinVersion: tls.VersionTLS12}) //nolint:exhaustruct
+		if err != nil {
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+	}
+
+	if s.auth != nil {
+		if err = client.Auth(s.auth); err != nil {
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+	}
+
+	if err = client.Mail(s.from); err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if err = client.Rcpt(message.To); err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if _, err = writer.Write(buildMessage(s.from, message)); err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if err = writer.Close(); err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return client.Quit() //nolint:wrapcheck
+}
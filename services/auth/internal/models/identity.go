@@ -0,0 +1,10 @@
+package models
+
+// Identity is account of user in external OAuth provider.
+// Subject is id of user inside provider, EmailVerified is told by provider.
+type Identity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+type Session struct {
+	ID        string
+	UserID    uint64
+	CreatedAt time.Time
+	ExpireAt  time.Time
+}
+
+// Tokens AccessToken is jwt, RefreshToken is random single-use string
+type Tokens struct {
+	AccessToken  string
+	RefreshToken string
+}
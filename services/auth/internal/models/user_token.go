@@ -0,0 +1,9 @@
+package models
+
+// UserTokenPurpose is purpose of single-use token sent by email, values are stored in db.
+type UserTokenPurpose uint8
+
+const (
+	PurposeVerifyEmail   UserTokenPurpose = 1
+	PurposeResetPassword UserTokenPurpose = 2
+)
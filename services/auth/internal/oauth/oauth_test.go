@@ -0,0 +1,154 @@
+package oauth_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/oauth"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/oauth/oauthtest"
+)
+
+type provider interface {
+	AuthCodeURL(state string, codeChallenge string) string
+	Exchange(ctx context.Context, code string, codeVerifier string, deviceID string) (*models.Identity, error)
+}
+
+func TestProviders(t *testing.T) {
+	t.Parallel()
+
+	fake := oauthtest.NewFakeProvider()
+	t.Cleanup(fake.Close)
+
+	providerOIDC, err := oauth.NewOIDCProvider(context.Background(), "oidc", fake.URL(),
+		"client_id", "client_secret", "http://localhost:3000/oauth/oidc", oauth.NewHTTPClient())
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	providerVK := oauth.NewVKProvider("client_id", "http://localhost:3000/oauth/vk",
+		fake.URL(), oauth.NewHTTPClient())
+
+	type TestCase struct {
+		name             string
+		provider         provider
+		code             string
+		registeredUser   oauthtest.FakeUser
+		codeVerifier     string
+		deviceID         string
+		expectedIdentity *models.Identity
+		expectedError    error
+	}
+
+	testCases := [...]TestCase{
+		{
+			name:           "test oidc basic work",
+			provider:       providerOIDC,
+			code:           "code_oidc_1",
+			registeredUser: oauthtest.FakeUser{Subject: "sub_1", Email: "ivn-tyt@mail.ru", EmailVerified: true},
+			codeVerifier:   "verifier",
+			expectedIdentity: &models.Identity{
+				Provider: "oidc", Subject: "sub_1", Email: "ivn-tyt@mail.ru", EmailVerified: true,
+			},
+		},
+		{
+			name:           "test oidc not verified email",
+			provider:       providerOIDC,
+			code:           "code_oidc_2",
+			registeredUser: oauthtest.FakeUser{Subject: "sub_2", Email: "ivn-tyt@mail.ru", EmailVerified: false},
+			codeVerifier:   "verifier",
+			expectedIdentity: &models.Identity{
+				Provider: "oidc", Subject: "sub_2", Email: "ivn-tyt@mail.ru", EmailVerified: false,
+			},
+		},
+		{
+			name:           "test oidc wrong code verifier",
+			provider:       providerOIDC,
+			code:           "code_oidc_3",
+			registeredUser: oauthtest.FakeUser{Subject: "sub_3", Email: "ivn-tyt@mail.ru", EmailVerified: true},
+			codeVerifier:   "other_verifier",
+			expectedError:  oauth.ErrExchangeCode,
+		},
+		{
+			name:           "test vk basic work",
+			provider:       providerVK,
+			code:           "code_vk_1",
+			registeredUser: oauthtest.FakeUser{Subject: "1234567", Email: "ivn-tyt@mail.ru", EmailVerified: true},
+			codeVerifier:   "verifier",
+			deviceID:       "device",
+			expectedIdentity: &models.Identity{
+				Provider: "vk", Subject: "1234567", Email: "ivn-tyt@mail.ru", EmailVerified: true,
+			},
+		},
+		{
+			name:           "test vk without email",
+			provider:       providerVK,
+			code:           "code_vk_2",
+			registeredUser: oauthtest.FakeUser{Subject: "7654321", Email: "ivn-tyt@mail.ru", EmailVerified: false},
+			codeVerifier:   "verifier",
+			deviceID:       "device",
+			expectedIdentity: &models.Identity{
+				Provider: "vk", Subject: "7654321", Email: "", EmailVerified: false,
+			},
+		},
+		{
+			name:           "test vk without device id",
+			provider:       providerVK,
+			code:           "code_vk_3",
+			registeredUser: oauthtest.FakeUser{Subject: "1234567", Email: "ivn-tyt@mail.ru", EmailVerified: true},
+			codeVerifier:   "verifier",
+			expectedError:  oauth.ErrEmptyDeviceID,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			fake.AddCode(testCase.code, "verifier", testCase.registeredUser)
+
+			identity, err := testCase.provider.Exchange(context.Background(),
+				testCase.code, testCase.codeVerifier, testCase.deviceID)
+			if !errors.Is(err, testCase.expectedError) {
+				t.Fatalf("err: got %+v, expected %+v", err, testCase.expectedError)
+			}
+
+			if testCase.expectedIdentity != nil && *identity != *testCase.expectedIdentity {
+				t.Fatalf("identity: got %+v, expected %+v", identity, testCase.expectedIdentity)
+			}
+		})
+	}
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	t.Parallel()
+
+	providerVK := oauth.NewVKProvider("client_id", "http://localhost:3000/oauth/vk",
+		oauth.VKIDURL, oauth.NewHTTPClient())
+
+	authCodeURL, err := url.Parse(providerVK.AuthCodeURL("state", oauth.CodeChallengeS256("verifier")))
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	if !strings.HasPrefix(authCodeURL.String(), oauth.VKIDURL+"/authorize?") {
+		t.Fatalf("wrong url %s", authCodeURL)
+	}
+
+	query := authCodeURL.Query()
+	for key, expected := range map[string]string{
+		"state":                 "state",
+		"redirect_uri":          "http://localhost:3000/oauth/vk",
+		"code_challenge":        "iMnq5o6zALKXGivsnlom_0F5_WYda32GHkxlV7mq7hQ",
+		"code_challenge_method": "S256",
+	} {
+		if query.Get(key) != expected {
+			t.Errorf("%s: got %s, expected %s", key, query.Get(key), expected)
+		}
+	}
+}
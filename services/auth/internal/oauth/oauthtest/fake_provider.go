@@ -0,0 +1,185 @@
+// Package oauthtest contains fake OAuth provider for tests, it serves
+// endpoints of both VK ID and OpenID Connect on one httptest server.
+package oauthtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+const AccessTokenPrefix = "access_"
+
+type FakeUser struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+}
+
+type fakeGrant struct {
+	user         FakeUser
+	codeVerifier string
+}
+
+type FakeProvider struct {
+	server *httptest.Server
+
+	mu     sync.Mutex
+	grants map[string]fakeGrant
+	users  map[string]FakeUser
+}
+
+// NewFakeProvider should be closed after test.
+func NewFakeProvider() *FakeProvider {
+	fake := &FakeProvider{ //nolint:exhaustruct
+		grants: make(map[string]fakeGrant),
+		users:  make(map[string]FakeUser),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", fake.handleDiscovery)
+	mux.HandleFunc("/token", fake.handleOIDCToken)
+	mux.HandleFunc("/userinfo", fake.handleOIDCUserInfo)
+	mux.HandleFunc("/oauth2/auth", fake.handleVKToken)
+	mux.HandleFunc("/oauth2/user_info", fake.handleVKUserInfo)
+
+	fake.server = httptest.NewServer(mux)
+
+	return fake
+}
+
+func (f *FakeProvider) URL() string {
+	return f.server.URL
+}
+
+func (f *FakeProvider) Close() {
+	f.server.Close()
+}
+
+// AddCode registers single-use authorization code, which is exchanged only with codeVerifier.
+func (f *FakeProvider) AddCode(code string, codeVerifier string, user FakeUser) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.grants[code] = fakeGrant{user: user, codeVerifier: codeVerifier}
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, status int, description string) {
+	writeJSON(w, status, map[string]string{"error": "invalid_grant", "error_description": description})
+}
+
+// exchange returns access token for code, code is deleted even if verifier is wrong.
+func (f *FakeProvider) exchange(code string, codeVerifier string) (string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	grant, ok := f.grants[code]
+	if !ok {
+		return "", false
+	}
+
+	delete(f.grants, code)
+
+	if grant.codeVerifier != codeVerifier {
+		return "", false
+	}
+
+	accessToken := AccessTokenPrefix + code
+	f.users[accessToken] = grant.user
+
+	return accessToken, true
+}
+
+func (f *FakeProvider) getUser(accessToken string) (FakeUser, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	user, ok := f.users[accessToken]
+
+	return user, ok
+}
+
+func (f *FakeProvider) handleDiscovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 f.URL(),
+		"authorization_endpoint": f.URL() + "/authorize",
+		"token_endpoint":         f.URL() + "/token",
+		"userinfo_endpoint":      f.URL() + "/userinfo",
+	})
+}
+
+func (f *FakeProvider) handleOIDCToken(w http.ResponseWriter, r *http.Request) {
+	if _, _, ok := r.BasicAuth(); !ok || r.Method != http.MethodPost {
+		writeError(w, http.StatusUnauthorized, "client isn't authenticated")
+
+		return
+	}
+
+	accessToken, ok := f.exchange(r.PostFormValue("code"), r.PostFormValue("code_verifier"))
+	if !ok {
+		writeError(w, http.StatusBadRequest, "wrong code or code_verifier")
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"access_token": accessToken, "token_type": "Bearer"})
+}
+
+func (f *FakeProvider) handleOIDCUserInfo(w http.ResponseWriter, r *http.Request) {
+	user, ok := f.getUser(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "wrong access token")
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"sub":            user.Subject,
+		"email":          user.Email,
+		"email_verified": user.EmailVerified,
+	})
+}
+
+func (f *FakeProvider) handleVKToken(w http.ResponseWriter, r *http.Request) {
+	if r.PostFormValue("device_id") == "" || r.PostFormValue("client_id") == "" {
+		writeError(w, http.StatusBadRequest, "device_id and client_id are required")
+
+		return
+	}
+
+	accessToken, ok := f.exchange(r.PostFormValue("code"), r.PostFormValue("code_verifier"))
+	if !ok {
+		writeError(w, http.StatusBadRequest, "wrong code or code_verifier")
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"access_token": accessToken, "token_type": "Bearer"})
+}
+
+// handleVKUserInfo VK ID doesn't send email, which isn't confirmed.
+func (f *FakeProvider) handleVKUserInfo(w http.ResponseWriter, r *http.Request) {
+	user, ok := f.getUser(r.PostFormValue("access_token"))
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "wrong access token")
+
+		return
+	}
+
+	email := user.Email
+	if !user.EmailVerified {
+		email = ""
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"user": map[string]string{"user_id": user.Subject, "email": email},
+	})
+}
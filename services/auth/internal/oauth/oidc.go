@@ -0,0 +1,162 @@
+package oauth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/models"
+)
+
+const pathDiscovery = "/.well-known/openid-configuration"
+
+var ErrWrongIssuer = myerrors.NewErrorInternal("Issuer в настройках OIDC провайдера не совпадает с заявленным")
+
+// OIDCProvider login by any OpenID Connect provider, its endpoints are found by discovery.
+// Identity is taken from userinfo endpoint, so id_token isn't needed to be verified.
+type OIDCProvider struct {
+	name                  string
+	clientID              string
+	clientSecret          string
+	redirectURI           string
+	authorizationEndpoint string
+	tokenEndpoint         string
+	userinfoEndpoint      string
+	client                *http.Client
+}
+
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+}
+
+// NewOIDCProvider name is used in links of login and stored with identities, so it shouldn't change.
+func NewOIDCProvider(ctx context.Context, name string, issuer string, clientID string, clientSecret string,
+	redirectURI string, client *http.Client,
+) (*OIDCProvider, error) {
+	issuer = strings.TrimSuffix(issuer, "/")
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+pathDiscovery, nil)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	discovery := new(oidcDiscovery)
+
+	err = doJSON(client, request, discovery)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if strings.TrimSuffix(discovery.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("%w: expected %s, got %s", ErrWrongIssuer, issuer, discovery.Issuer)
+	}
+
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.UserinfoEndpoint == "" {
+		return nil, fmt.Errorf("%w: discovery hasn't required endpoints", ErrProviderResponse)
+	}
+
+	return &OIDCProvider{
+		name:                  name,
+		clientID:              clientID,
+		clientSecret:          clientSecret,
+		redirectURI:           redirectURI,
+		authorizationEndpoint: discovery.AuthorizationEndpoint,
+		tokenEndpoint:         discovery.TokenEndpoint,
+		userinfoEndpoint:      discovery.UserinfoEndpoint,
+		client:                client,
+	}, nil
+}
+
+func (o *OIDCProvider) Name() string {
+	return o.name
+}
+
+func (o *OIDCProvider) AuthCodeURL(state string, codeChallenge string) string {
+	separator := "?"
+	if strings.Contains(o.authorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return o.authorizationEndpoint + separator + url.Values{
+		"response_type":         []string{"code"},
+		"client_id":             []string{o.clientID},
+		"redirect_uri":          []string{o.redirectURI},
+		"scope":                 []string{"openid email"},
+		"state":                 []string{state},
+		"code_challenge":        []string{codeChallenge},
+		"code_challenge_method": []string{"S256"},
+	}.Encode()
+}
+
+type oidcTokenResponse struct {
+	AccessToken string `json:"access_token"`
+}
+
+type oidcUserInfo struct {
+	Subject       string       `json:"sub"`
+	Email         string       `json:"email"`
+	EmailVerified flexibleBool `json:"email_verified"`
+}
+
+// Exchange deviceID isn't used by OIDC.
+func (o *OIDCProvider) Exchange(ctx context.Context, code string, codeVerifier string,
+	_ string,
+) (*models.Identity, error) {
+	form := url.Values{
+		"grant_type":    []string{"authorization_code"},
+		"code":          []string{code},
+		"code_verifier": []string{codeVerifier},
+		"redirect_uri":  []string{o.redirectURI},
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, o.tokenEndpoint,
+		strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.SetBasicAuth(url.QueryEscape(o.clientID), url.QueryEscape(o.clientSecret))
+
+	tokenResponse := new(oidcTokenResponse)
+
+	err = doJSON(o.client, request, tokenResponse)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if tokenResponse.AccessToken == "" {
+		return nil, fmt.Errorf("%w: empty access_token", ErrProviderResponse)
+	}
+
+	request, err = http.NewRequestWithContext(ctx, http.MethodGet, o.userinfoEndpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	request.Header.Set("Authorization", "Bearer "+tokenResponse.AccessToken)
+
+	userInfo := new(oidcUserInfo)
+
+	err = doJSON(o.client, request, userInfo)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if userInfo.Subject == "" {
+		return nil, fmt.Errorf("%w: empty sub", ErrProviderResponse)
+	}
+
+	return &models.Identity{
+		Provider:      o.name,
+		Subject:       userInfo.Subject,
+		Email:         userInfo.Email,
+		EmailVerified: bool(userInfo.EmailVerified),
+	}, nil
+}
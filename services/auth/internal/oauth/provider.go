@@ -0,0 +1,122 @@
+package oauth
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+)
+
+const (
+	TimeoutRequest = 10 * time.Second
+
+	maxLenResponse = 1 << 20
+)
+
+var (
+	ErrExchangeCode = myerrors.NewErrorBadContentRequest(
+		"Не удалось войти через внешний сервис, попробуйте ещё раз")
+	ErrProviderResponse = myerrors.NewErrorInternal("Некорректный ответ провайдера входа")
+)
+
+// NewHTTPClient returns client for requests to providers, default client has no timeout.
+func NewHTTPClient() *http.Client {
+	return &http.Client{Timeout: TimeoutRequest} //nolint:exhaustruct
+}
+
+// CodeChallengeS256 returns PKCE challenge for codeVerifier, which is sent to provider
+// in link of login. Then provider accepts code only with codeVerifier itself.
+func CodeChallengeS256(codeVerifier string) string {
+	hash := sha256.Sum256([]byte(codeVerifier))
+
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+// doJSON sends request and decodes json of response. Client error of provider
+// means that code is wrong or expired, so it is returned as ErrExchangeCode.
+func doJSON(client *http.Client, request *http.Request, result any) error {
+	request.Header.Set("Accept", "application/json")
+
+	response, err := client.Do(request)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxLenResponse))
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if response.StatusCode >= http.StatusBadRequest && response.StatusCode < http.StatusInternalServerError {
+		return fmt.Errorf("%w: status=%d body=%s", ErrExchangeCode, response.StatusCode, body)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: status=%d body=%s", ErrProviderResponse, response.StatusCode, body)
+	}
+
+	err = json.Unmarshal(body, result)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrProviderResponse, err.Error())
+	}
+
+	return nil
+}
+
+// flexibleString some providers send ids as numbers, others as strings.
+type flexibleString string
+
+func (f *flexibleString) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var value string
+
+		err := json.Unmarshal(data, &value)
+		if err != nil {
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		*f = flexibleString(value)
+
+		return nil
+	}
+
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	var value json.Number
+
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	*f = flexibleString(value)
+
+	return nil
+}
+
+// flexibleBool some providers send email_verified as "true" string.
+type flexibleBool bool
+
+func (f *flexibleBool) UnmarshalJSON(data []byte) error {
+	value, err := strconv.ParseBool(string(bytes.Trim(data, `"`)))
+	if err != nil {
+		*f = false
+
+		return nil //nolint:nilerr
+	}
+
+	*f = flexibleBool(value)
+
+	return nil
+}
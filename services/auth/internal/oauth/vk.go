@@ -0,0 +1,125 @@
+package oauth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/models"
+)
+
+const (
+	ProviderVK = "vk"
+	VKIDURL    = "https://id.vk.com"
+)
+
+var ErrEmptyDeviceID = myerrors.NewErrorBadContentRequest("VK ID не передал device_id")
+
+// VKProvider login by VK ID with authorization code and PKCE.
+type VKProvider struct {
+	clientID    string
+	redirectURI string
+	baseURL     string
+	client      *http.Client
+}
+
+// NewVKProvider baseURL is VKIDURL, other one is used in tests.
+func NewVKProvider(clientID string, redirectURI string, baseURL string, client *http.Client) *VKProvider {
+	return &VKProvider{
+		clientID:    clientID,
+		redirectURI: redirectURI,
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+		client:      client,
+	}
+}
+
+func (v *VKProvider) Name() string {
+	return ProviderVK
+}
+
+func (v *VKProvider) AuthCodeURL(state string, codeChallenge string) string {
+	return v.baseURL + "/authorize?" + url.Values{
+		"response_type":         []string{"code"},
+		"client_id":             []string{v.clientID},
+		"redirect_uri":          []string{v.redirectURI},
+		"state":                 []string{state},
+		"code_challenge":        []string{codeChallenge},
+		"code_challenge_method": []string{"S256"},
+		"scope":                 []string{"email"},
+	}.Encode()
+}
+
+type vkTokenResponse struct {
+	AccessToken string `json:"access_token"`
+}
+
+type vkUserInfoResponse struct {
+	User struct {
+		UserID flexibleString `json:"user_id"`
+		Email  string         `json:"email"`
+	} `json:"user"`
+}
+
+func (v *VKProvider) postForm(ctx context.Context, path string, form url.Values, result any) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, v.baseURL+path,
+		strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return doJSON(v.client, request, result)
+}
+
+// Exchange deviceID is sent by VK ID to redirect uri together with code.
+// VK ID gives email only if it is confirmed, so such email is considered verified.
+func (v *VKProvider) Exchange(ctx context.Context, code string, codeVerifier string,
+	deviceID string,
+) (*models.Identity, error) {
+	if deviceID == "" {
+		return nil, ErrEmptyDeviceID
+	}
+
+	tokenResponse := new(vkTokenResponse)
+
+	err := v.postForm(ctx, "/oauth2/auth", url.Values{
+		"grant_type":    []string{"authorization_code"},
+		"code":          []string{code},
+		"code_verifier": []string{codeVerifier},
+		"client_id":     []string{v.clientID},
+		"device_id":     []string{deviceID},
+		"redirect_uri":  []string{v.redirectURI},
+	}, tokenResponse)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if tokenResponse.AccessToken == "" {
+		return nil, fmt.Errorf("%w: empty access_token", ErrProviderResponse)
+	}
+
+	userInfo := new(vkUserInfoResponse)
+
+	err = v.postForm(ctx, "/oauth2/user_info", url.Values{
+		"client_id":    []string{v.clientID},
+		"access_token": []string{tokenResponse.AccessToken},
+	}, userInfo)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if userInfo.User.UserID == "" {
+		return nil, fmt.Errorf("%w: empty user_id", ErrProviderResponse)
+	}
+
+	return &models.Identity{
+		Provider:      ProviderVK,
+		Subject:       string(userInfo.User.UserID),
+		Email:         userInfo.User.Email,
+		EmailVerified: userInfo.User.Email != "",
+	}, nil
+}
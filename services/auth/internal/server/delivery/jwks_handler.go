@@ -0,0 +1,23 @@
+package delivery
+
+import (
+	"net/http"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/jwt"
+)
+
+// NewJWKSHandler returns handler of public keys of keyring in format of JWK Set.
+// Keys of HS256 are secret, so they are never returned.
+func NewJWKSHandler(keyring *jwt.Keyring, logger *mylogger.MyLogger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, `Method not allowed`, http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		responses.SendResponse(w, logger.LogReqID(r.Context()), keyring.JSONWebKeySet())
+	}
+}
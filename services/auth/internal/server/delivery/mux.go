@@ -8,16 +8,18 @@ import (
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/metrics"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/middleware"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/jwt"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-func NewMux(ctx context.Context, fileServiceName string,
+func NewMux(ctx context.Context, fileServiceName string, keyring *jwt.Keyring,
 	logger *mylogger.MyLogger,
 ) (http.Handler, error) {
 	router := http.NewServeMux()
 
 	router.Handle("/metrics", promhttp.Handler())
 	router.HandleFunc("/healthcheck", delivery.HealthCheckHandler)
+	router.Handle("/jwks", NewJWKSHandler(keyring, logger))
 
 	metricsManager := metrics.NewMetricManagerHTTP(fileServiceName)
 	mux := http.NewServeMux()
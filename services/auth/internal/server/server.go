@@ -2,17 +2,22 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/auth"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/interceptors"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/metrics"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
 	reposhare "github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/repository"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/config"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/jwt"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/mail"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/oauth"
 	deliverymux "github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/server/delivery"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/session_manager/delivery"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/session_manager/repository"
@@ -21,9 +26,109 @@ import (
 )
 
 const (
-	basicTimeout = 10 * time.Second
+	basicTimeout                = 10 * time.Second
+	periodDeleteExpiredSessions = time.Hour
+	periodReloadJwtKeys         = time.Minute
+	keyIDJwtSecret              = "secret"
 )
 
+var (
+	ErrNoJwtKeys         = myerrors.NewErrorInternal("В production должны быть заданы ключи jwt: JWT_KEYS_FILE или JWT_SECRET")
+	ErrUnknownMailSender = myerrors.NewErrorInternal("Неизвестный MAIL_SENDER, допустимы smtp, file, log")
+)
+
+// newKeyring loads keys of jwt from file of keys and reloads them, so all replicas
+// use the same keys and rotation doesn't need restart. Without file JWT_SECRET is single key,
+// in development without both keys are random and jwt don't survive restart.
+func newKeyring(config *config.Config, logger *mylogger.MyLogger,
+	chClose <-chan struct{},
+) (*jwt.Keyring, error) {
+	var (
+		keys []*jwt.SigningKey
+		err  error
+	)
+
+	switch {
+	case config.PathJwtKeysFile != "":
+		keys, err = jwt.LoadSigningKeys(config.PathJwtKeysFile)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+	case config.JwtSecret != "":
+		keys = []*jwt.SigningKey{jwt.NewSigningKeyHS256(keyIDJwtSecret, []byte(config.JwtSecret))}
+	case config.ProductionMode:
+		return nil, ErrNoJwtKeys
+	default:
+		logger.Warnln("keys of jwt aren't set, random key is used")
+
+		key, err := jwt.GenerateSigningKey()
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		keys = []*jwt.SigningKey{key}
+	}
+
+	keyring, err := jwt.NewKeyring(keys, config.JwtKeyGracePeriod)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	if config.PathJwtKeysFile != "" {
+		err = keyring.StartReloadingKeys(config.PathJwtKeysFile, periodReloadJwtKeys, chClose)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+	}
+
+	return keyring, nil
+}
+
+// newMailSender in production letters should be sent by smtp,
+// in development they are written to file or log.
+func newMailSender(configAuth *config.Config, logger *mylogger.MyLogger) (usecases.IMailSender, error) {
+	switch configAuth.MailSender {
+	case config.MailSenderSMTP:
+		return mail.NewSMTPSender(configAuth.SMTPAddress, configAuth.SMTPUsername, configAuth.SMTPPassword, //nolint:wrapcheck
+			configAuth.MailFrom)
+	case config.MailSenderFile:
+		return mail.NewFileSender(configAuth.MailFilePath, configAuth.MailFrom), nil
+	case config.MailSenderLog:
+		if configAuth.ProductionMode {
+			logger.Warnln("letters are written to log instead of sending in production")
+		}
+
+		return mail.NewLogSender() //nolint:wrapcheck
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownMailSender, configAuth.MailSender)
+	}
+}
+
+// newOAuthProviders returns providers, which client id is set in config.
+// Discovery of OIDC provider is done once at start.
+func newOAuthProviders(ctx context.Context, configAuth *config.Config) ([]usecases.IOAuthProvider, error) {
+	redirectBaseURL := strings.TrimSuffix(configAuth.OAuthRedirectBaseURL, "/")
+	httpClient := oauth.NewHTTPClient()
+	providers := make([]usecases.IOAuthProvider, 0)
+
+	if configAuth.VKClientID != "" {
+		providers = append(providers, oauth.NewVKProvider(configAuth.VKClientID,
+			redirectBaseURL+"/"+oauth.ProviderVK, oauth.VKIDURL, httpClient))
+	}
+
+	if configAuth.OIDCClientID != "" {
+		providerOIDC, err := oauth.NewOIDCProvider(ctx, configAuth.OIDCName, configAuth.OIDCIssuer,
+			configAuth.OIDCClientID, configAuth.OIDCClientSecret, redirectBaseURL+"/"+configAuth.OIDCName, httpClient)
+		if err != nil {
+			return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		providers = append(providers, providerOIDC)
+	}
+
+	return providers, nil
+}
+
 type Server struct {
 	httpServer *http.Server
 	grpcServer *grpc.Server
@@ -33,7 +138,14 @@ type Server struct {
 func (s *Server) RunFull(config *config.Config, logger *mylogger.MyLogger, chErrHTTP chan<- error) error { //nolint:funlen
 	baseCtx := context.Background()
 
-	handler, err := deliverymux.NewMux(baseCtx, config.AuthServiceName, logger)
+	chCloseRefreshing := make(chan struct{})
+
+	keyring, err := newKeyring(config, logger, chCloseRefreshing)
+	if err != nil {
+		return err
+	}
+
+	handler, err := deliverymux.NewMux(baseCtx, config.AuthServiceName, keyring, logger)
 	if err != nil {
 		return err //nolint:wrapcheck
 	}
@@ -82,7 +194,18 @@ func (s *Server) RunFull(config *config.Config, logger *mylogger.MyLogger, chErr
 		return err //nolint:wrapcheck
 	}
 
-	service, err := usecases.NewAuthService(storage)
+	mailSender, err := newMailSender(config, logger)
+	if err != nil {
+		return err
+	}
+
+	oauthProviders, err := newOAuthProviders(baseCtx, config)
+	if err != nil {
+		return err
+	}
+
+	service, err := usecases.NewAuthService(storage, keyring, metricManager, mailSender,
+		config.MailLinkBaseURL, config.PasswordHashParams, oauthProviders)
 	if err != nil {
 		return err //nolint:wrapcheck
 	}
@@ -94,13 +217,7 @@ func (s *Server) RunFull(config *config.Config, logger *mylogger.MyLogger, chErr
 
 	auth.RegisterSessionMangerServer(server, sessionManager)
 
-	chCloseRefreshing := make(chan struct{})
-
-	// don`t want use chCloseRefreshing secret now
-	err = jwt.StartRefreshingSecret(jwt.TimeTokenLife, chCloseRefreshing)
-	if err != nil {
-		return err //nolint:wrapcheck
-	}
+	service.StartDeletingExpiredSessions(baseCtx, periodDeleteExpiredSessions, chCloseRefreshing)
 
 	s.grpcServer = server
 
@@ -7,6 +7,7 @@ import (
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/auth"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/models"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/session_manager/usecases"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -14,10 +15,23 @@ import (
 var _ IAuthService = (*usecases.AuthService)(nil)
 
 type IAuthService interface {
-	AddUser(ctx context.Context, email string, password string) (string, error)
-	LoginUser(ctx context.Context, email string, password string) (string, error)
-	Delete(ctx context.Context, rawJwt string) (string, error)
-	Check(ctx context.Context, rawJwt string) (uint64, error)
+	AddUser(ctx context.Context, email string, password string) (*models.Tokens, error)
+	LoginUser(ctx context.Context, email string, password string, clientIP string) (*models.Tokens, error)
+	Refresh(ctx context.Context, refreshToken string) (*models.Tokens, error)
+	Delete(ctx context.Context, rawJwt string, refreshToken string) (string, error)
+	Check(ctx context.Context, rawJwt string) (uint64, string, error)
+	ListSessions(ctx context.Context, rawJwt string) ([]*models.Session, string, error)
+	RevokeSession(ctx context.Context, rawJwt string, sessionID string) error
+	RevokeAllSessions(ctx context.Context, rawJwt string) error
+	SendVerifyEmail(ctx context.Context, rawJwt string) error
+	VerifyEmail(ctx context.Context, token string) error
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token string, password string) error
+	ChangePassword(ctx context.Context, rawJwt string, oldPassword string, newPassword string, clientIP string) error
+	CheckPassword(ctx context.Context, rawJwt string, password string, clientIP string) error
+	GetOAuthURL(ctx context.Context, providerName string) (string, string, string, error)
+	LoginOAuth(ctx context.Context, providerName string, code string,
+		codeVerifier string, deviceID string) (*models.Tokens, error)
 }
 
 type SessionManager struct {
@@ -46,12 +60,12 @@ func (s *SessionManager) Check(ctx context.Context, sessionUser *auth.Session) (
 		return nil, myerrors.NewErrorInternal("sessionUser == nil")
 	}
 
-	userID, err := s.service.Check(ctx, sessionUser.GetAccessToken())
+	userID, role, err := s.service.Check(ctx, sessionUser.GetAccessToken())
 	if err != nil {
 		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	return &auth.UserID{UserId: userID}, nil
+	return &auth.UserID{UserId: userID, Role: role}, nil
 }
 
 func (s *SessionManager) Create(ctx context.Context, user *auth.User) (*auth.Session, error) {
@@ -59,12 +73,12 @@ func (s *SessionManager) Create(ctx context.Context, user *auth.User) (*auth.Ses
 		return nil, myerrors.NewErrorInternal("user == nil")
 	}
 
-	rawJWT, err := s.service.AddUser(ctx, user.GetEmail(), user.GetPassword())
+	tokens, err := s.service.AddUser(ctx, user.GetEmail(), user.GetPassword())
 	if err != nil {
 		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	return &auth.Session{AccessToken: rawJWT}, nil
+	return &auth.Session{AccessToken: tokens.AccessToken, RefreshToken: tokens.RefreshToken}, nil
 }
 
 func (s *SessionManager) Login(ctx context.Context, user *auth.User) (*auth.Session, error) {
@@ -72,12 +86,25 @@ func (s *SessionManager) Login(ctx context.Context, user *auth.User) (*auth.Sess
 		return nil, myerrors.NewErrorInternal("user == nil")
 	}
 
-	rawJWT, err := s.service.LoginUser(ctx, user.GetEmail(), user.GetPassword())
+	tokens, err := s.service.LoginUser(ctx, user.GetEmail(), user.GetPassword(), user.GetClientIp())
 	if err != nil {
 		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	return &auth.Session{AccessToken: rawJWT}, nil
+	return &auth.Session{AccessToken: tokens.AccessToken, RefreshToken: tokens.RefreshToken}, nil
+}
+
+func (s *SessionManager) Refresh(ctx context.Context, sessionUser *auth.Session) (*auth.Session, error) {
+	if sessionUser == nil {
+		return nil, myerrors.NewErrorInternal("sessionUser == nil")
+	}
+
+	tokens, err := s.service.Refresh(ctx, sessionUser.GetRefreshToken())
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return &auth.Session{AccessToken: tokens.AccessToken, RefreshToken: tokens.RefreshToken}, nil
 }
 
 func (s *SessionManager) Delete(ctx context.Context, sessionUser *auth.Session) (*auth.Session, error) {
@@ -85,10 +112,177 @@ func (s *SessionManager) Delete(ctx context.Context, sessionUser *auth.Session)
 		return nil, myerrors.NewErrorInternal("sessionUser == nil")
 	}
 
-	rawJwt, err := s.service.Delete(ctx, sessionUser.GetAccessToken())
+	rawJwt, err := s.service.Delete(ctx, sessionUser.GetAccessToken(), sessionUser.GetRefreshToken())
 	if err != nil {
 		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
 	return &auth.Session{AccessToken: rawJwt}, nil
 }
+
+func (s *SessionManager) ListSessions(ctx context.Context, sessionUser *auth.Session) (*auth.SessionList, error) {
+	if sessionUser == nil {
+		return nil, myerrors.NewErrorInternal("sessionUser == nil")
+	}
+
+	sessions, currentSessionID, err := s.service.ListSessions(ctx, sessionUser.GetAccessToken())
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	sessionList := &auth.SessionList{Sessions: make([]*auth.SessionInfo, 0, len(sessions))}
+
+	for _, session := range sessions {
+		sessionList.Sessions = append(sessionList.Sessions, &auth.SessionInfo{
+			SessionId: session.ID,
+			CreatedAt: session.CreatedAt.Unix(),
+			Expire:    session.ExpireAt.Unix(),
+			Current:   session.ID == currentSessionID,
+		})
+	}
+
+	return sessionList, nil
+}
+
+func (s *SessionManager) RevokeSession(ctx context.Context,
+	request *auth.RevokeSessionRequest,
+) (*auth.Nothing, error) {
+	if request == nil {
+		return nil, myerrors.NewErrorInternal("request == nil")
+	}
+
+	err := s.service.RevokeSession(ctx, request.GetSession().GetAccessToken(), request.GetSessionId())
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return &auth.Nothing{}, nil
+}
+
+func (s *SessionManager) RevokeAllSessions(ctx context.Context, sessionUser *auth.Session) (*auth.Nothing, error) {
+	if sessionUser == nil {
+		return nil, myerrors.NewErrorInternal("sessionUser == nil")
+	}
+
+	err := s.service.RevokeAllSessions(ctx, sessionUser.GetAccessToken())
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return &auth.Nothing{}, nil
+}
+
+func (s *SessionManager) SendVerifyEmail(ctx context.Context, sessionUser *auth.Session) (*auth.Nothing, error) {
+	if sessionUser == nil {
+		return nil, myerrors.NewErrorInternal("sessionUser == nil")
+	}
+
+	err := s.service.SendVerifyEmail(ctx, sessionUser.GetAccessToken())
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return &auth.Nothing{}, nil
+}
+
+func (s *SessionManager) VerifyEmail(ctx context.Context, token *auth.Token) (*auth.Nothing, error) {
+	if token == nil {
+		return nil, myerrors.NewErrorInternal("token == nil")
+	}
+
+	err := s.service.VerifyEmail(ctx, token.GetToken())
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return &auth.Nothing{}, nil
+}
+
+func (s *SessionManager) RequestPasswordReset(ctx context.Context, email *auth.Email) (*auth.Nothing, error) {
+	if email == nil {
+		return nil, myerrors.NewErrorInternal("email == nil")
+	}
+
+	err := s.service.RequestPasswordReset(ctx, email.GetEmail())
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return &auth.Nothing{}, nil
+}
+
+func (s *SessionManager) ResetPassword(ctx context.Context,
+	passwordReset *auth.PasswordReset,
+) (*auth.Nothing, error) {
+	if passwordReset == nil {
+		return nil, myerrors.NewErrorInternal("passwordReset == nil")
+	}
+
+	err := s.service.ResetPassword(ctx, passwordReset.GetToken(), passwordReset.GetPassword())
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return &auth.Nothing{}, nil
+}
+
+func (s *SessionManager) ChangePassword(ctx context.Context,
+	passwordChange *auth.PasswordChange,
+) (*auth.Nothing, error) {
+	if passwordChange == nil {
+		return nil, myerrors.NewErrorInternal("passwordChange == nil")
+	}
+
+	err := s.service.ChangePassword(ctx, passwordChange.GetSession().GetAccessToken(),
+		passwordChange.GetOldPassword(), passwordChange.GetNewPassword(), passwordChange.GetClientIp())
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return &auth.Nothing{}, nil
+}
+
+func (s *SessionManager) CheckPassword(ctx context.Context,
+	passwordCheck *auth.PasswordCheck,
+) (*auth.Nothing, error) {
+	if passwordCheck == nil {
+		return nil, myerrors.NewErrorInternal("passwordCheck == nil")
+	}
+
+	err := s.service.CheckPassword(ctx, passwordCheck.GetSession().GetAccessToken(),
+		passwordCheck.GetPassword(), passwordCheck.GetClientIp())
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return &auth.Nothing{}, nil
+}
+
+func (s *SessionManager) GetOAuthURL(ctx context.Context,
+	oauthProvider *auth.OAuthProvider,
+) (*auth.OAuthURL, error) {
+	if oauthProvider == nil {
+		return nil, myerrors.NewErrorInternal("oauthProvider == nil")
+	}
+
+	url, state, codeVerifier, err := s.service.GetOAuthURL(ctx, oauthProvider.GetProvider())
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return &auth.OAuthURL{Url: url, State: state, CodeVerifier: codeVerifier}, nil
+}
+
+func (s *SessionManager) LoginOAuth(ctx context.Context, oauthCode *auth.OAuthCode) (*auth.Session, error) {
+	if oauthCode == nil {
+		return nil, myerrors.NewErrorInternal("oauthCode == nil")
+	}
+
+	tokens, err := s.service.LoginOAuth(ctx, oauthCode.GetProvider(), oauthCode.GetCode(),
+		oauthCode.GetCodeVerifier(), oauthCode.GetDeviceId())
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return &auth.Session{AccessToken: tokens.AccessToken, RefreshToken: tokens.RefreshToken}, nil
+}
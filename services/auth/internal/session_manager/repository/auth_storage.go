@@ -176,3 +176,112 @@ func (a *AuthStorage) AddUser(ctx context.Context, email string, password string
 
 	return &user, nil
 }
+
+func (a *AuthStorage) GetUserByID(ctx context.Context, userID uint64) (*models.User, error) {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLGetUserByID := `SELECT id, email, password FROM public."user" WHERE id=$1;`
+
+	user := models.User{} //nolint:exhaustruct
+
+	err := a.pool.QueryRow(ctx, SQLGetUserByID, userID).Scan(&user.ID, &user.Email, &user.Password)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return &user, nil
+}
+
+// ChangePassword replaces hash of password and revokes all sessions of user except keepSessionID,
+// so who knew old password loses access. Returns count of revoked sessions.
+func (a *AuthStorage) ChangePassword(ctx context.Context, userID uint64,
+	passwordHash string, keepSessionID string,
+) (uint64, error) {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLUpdatePassword := `UPDATE public."user" SET password=$1 WHERE id=$2;`
+	SQLRevokeOtherSessions := `UPDATE public."session" SET revoked_at=NOW()
+		 WHERE user_id=$1 AND id<>$2 AND revoked_at IS NULL;`
+
+	var countRevoked uint64
+
+	err := pgx.BeginFunc(ctx, a.pool, func(tx pgx.Tx) error {
+		result, err := tx.Exec(ctx, SQLUpdatePassword, passwordHash, userID)
+		if err != nil {
+			logger.Errorln(err)
+
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		if result.RowsAffected() == 0 {
+			return ErrUserNotFound
+		}
+
+		result, err = tx.Exec(ctx, SQLRevokeOtherSessions, userID, keepSessionID)
+		if err != nil {
+			logger.Errorln(err)
+
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		countRevoked = uint64(result.RowsAffected())
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return countRevoked, nil
+}
+
+// RehashPassword replaces hash of the same password made with outdated parameters.
+// Hash is replaced only if it wasn't changed since oldPasswordHash was read,
+// so concurrent change of password isn't overwritten. Returns whether hash was replaced.
+func (a *AuthStorage) RehashPassword(ctx context.Context, userID uint64,
+	oldPasswordHash string, newPasswordHash string,
+) (bool, error) {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLRehashPassword := `UPDATE public."user" SET password=$1 WHERE id=$2 AND password=$3;`
+
+	result, err := a.pool.Exec(ctx, SQLRehashPassword, newPasswordHash, userID, oldPasswordHash)
+	if err != nil {
+		logger.Errorln(err)
+
+		return false, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return result.RowsAffected() != 0, nil
+}
+
+// GetUserRole returns role of user for jwt and whether he is banned.
+func (a *AuthStorage) GetUserRole(ctx context.Context, userID uint64) (string, bool, error) {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLGetUserRole := `SELECT role, banned_at IS NOT NULL FROM public."user" WHERE id=$1;`
+
+	var (
+		role   string
+		banned bool
+	)
+
+	err := a.pool.QueryRow(ctx, SQLGetUserRole, userID).Scan(&role, &banned)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", false, ErrUserNotFound
+		}
+
+		logger.Errorln(err)
+
+		return "", false, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return role, banned, nil
+}
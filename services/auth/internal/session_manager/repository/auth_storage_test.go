@@ -140,3 +140,67 @@ func TestAddUser(t *testing.T) {
 		})
 	}
 }
+
+func TestRehashPassword(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	type TestCase struct {
+		name             string
+		behaviorMockPool func(mockPool pgxmock.PgxPoolIface)
+		expectedReplaced bool
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectExec(`UPDATE public."user" SET password=\$1 WHERE id=\$2 AND password=\$3`).
+					WithArgs("new_hash", uint64(1), "old_hash").WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			},
+			expectedReplaced: true,
+		},
+		{
+			name: "test password changed concurrently",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectExec(`UPDATE public."user" SET password=\$1 WHERE id=\$2 AND password=\$3`).
+					WithArgs("new_hash", uint64(1), "old_hash").WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+			},
+			expectedReplaced: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			authStorage, err := repository.NewAuthStorage(mockPool)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			testCase.behaviorMockPool(mockPool)
+
+			replaced, err := authStorage.RehashPassword(context.Background(), 1, "old_hash", "new_hash")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if replaced != testCase.expectedReplaced {
+				t.Fatalf("replaced: got %t, expected %t", replaced, testCase.expectedReplaced)
+			}
+
+			if err := mockPool.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
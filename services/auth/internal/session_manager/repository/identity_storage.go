@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrIdentityNotFound       = myerrors.NewErrorBadContentRequest("Аккаунт внешнего сервиса не привязан")
+	ErrIdentityEmailNotLinked = myerrors.NewErrorBadContentRequest(
+		"Почта существующего аккаунта не подтверждена, войдите по паролю и подтвердите почту")
+)
+
+// GetUserIDByIdentity returns id of not deleted user, who has logged in by this identity before.
+func (a *AuthStorage) GetUserIDByIdentity(ctx context.Context, provider string, subject string) (uint64, error) {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLGetUserIDByIdentity := `SELECT i.user_id FROM public."user_identity" i
+		 INNER JOIN public."user" u ON u.id=i.user_id
+		 WHERE i.provider=$1 AND i.subject=$2 AND u.deleted_at IS NULL;`
+
+	var userID uint64
+
+	err := a.pool.QueryRow(ctx, SQLGetUserIDByIdentity, provider, subject).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ErrIdentityNotFound
+		}
+
+		logger.Errorln(err)
+
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return userID, nil
+}
+
+// LinkIdentity links identity with verified email to user with the same email, emails are compared
+// case-insensitively. User, who hasn't verified email, isn't linked: anyone could register with
+// foreign email and keep access by password after owner of email logs in by provider.
+// If there is no such user, he is created with passwordHash, which nobody knows,
+// he can set password later by reset. Email is marked verified, because provider verified it.
+func (a *AuthStorage) LinkIdentity(ctx context.Context, identity *models.Identity,
+	passwordHash string,
+) (uint64, error) {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLGetUserIDByEmail := `SELECT id, email_verified_at IS NOT NULL FROM public."user"
+		 WHERE LOWER(email)=LOWER($1);`
+	SQLCreateVerifiedUser := `INSERT INTO public."user" (email, password, email_verified_at)
+		 VALUES ($1, $2, NOW()) RETURNING id;`
+	SQLUpsertIdentity := `INSERT INTO public."user_identity" (provider, subject, user_id, email)
+		 VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (provider, subject) DO UPDATE SET user_id=EXCLUDED.user_id, email=EXCLUDED.email;`
+
+	var (
+		userID        uint64
+		emailVerified bool
+	)
+
+	err := pgx.BeginFunc(ctx, a.pool, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, SQLGetUserIDByEmail, identity.Email).Scan(&userID, &emailVerified)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			logger.Errorln(err)
+
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = tx.QueryRow(ctx, SQLCreateVerifiedUser, identity.Email, passwordHash).Scan(&userID)
+			if err != nil {
+				logger.Errorln(err)
+
+				return fmt.Errorf(myerrors.ErrTemplate, err)
+			}
+		} else if !emailVerified {
+			logger.Infof("refused to link %s identity to user id=%d with not verified email",
+				identity.Provider, userID)
+
+			return fmt.Errorf(myerrors.ErrTemplate, ErrIdentityEmailNotLinked)
+		}
+
+		_, err = tx.Exec(ctx, SQLUpsertIdentity, identity.Provider, identity.Subject, userID, identity.Email)
+		if err != nil {
+			logger.Errorln(err)
+
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return userID, nil
+}
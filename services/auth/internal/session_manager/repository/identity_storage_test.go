@@ -0,0 +1,141 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/session_manager/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v3"
+)
+
+func TestLinkIdentity(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	identity := &models.Identity{
+		Provider: "vk", Subject: "1234567", Email: "ivn-tyt@mail.ru", EmailVerified: true,
+	}
+
+	type TestCase struct {
+		name             string
+		behaviorMockPool func(mockPool pgxmock.PgxPoolIface)
+		expectedUserID   uint64
+		expectedError    error
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test link to existing user",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+				mockPool.ExpectQuery(`SELECT id, email_verified_at IS NOT NULL FROM public."user"
+		 WHERE LOWER\(email\)=LOWER\(\$1\)`).WithArgs("ivn-tyt@mail.ru").
+					WillReturnRows(pgxmock.NewRows([]string{"id", "verified"}).AddRow(uint64(2), true))
+				mockPool.ExpectExec(`INSERT INTO public."user_identity"`).
+					WithArgs("vk", "1234567", uint64(2), "ivn-tyt@mail.ru").
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			expectedUserID: 2,
+			expectedError:  nil,
+		},
+		{
+			name: "test create new user",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+				mockPool.ExpectQuery(`SELECT id, email_verified_at IS NOT NULL FROM public."user"`).
+					WithArgs("ivn-tyt@mail.ru").WillReturnError(pgx.ErrNoRows)
+				mockPool.ExpectQuery(`INSERT INTO public."user" \(email, password, email_verified_at\)`).
+					WithArgs("ivn-tyt@mail.ru", "password_hash").
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint64(3)))
+				mockPool.ExpectExec(`INSERT INTO public."user_identity"`).
+					WithArgs("vk", "1234567", uint64(3), "ivn-tyt@mail.ru").
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			expectedUserID: 3,
+			expectedError:  nil,
+		},
+		{
+			name: "test refuse link to user with not verified email",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+				mockPool.ExpectQuery(`SELECT id, email_verified_at IS NOT NULL FROM public."user"`).
+					WithArgs("ivn-tyt@mail.ru").
+					WillReturnRows(pgxmock.NewRows([]string{"id", "verified"}).AddRow(uint64(2), false))
+				mockPool.ExpectRollback()
+				mockPool.ExpectRollback()
+			},
+			expectedUserID: 0,
+			expectedError:  repository.ErrIdentityEmailNotLinked,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			authStorage, err := repository.NewAuthStorage(mockPool)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			testCase.behaviorMockPool(mockPool)
+
+			userID, err := authStorage.LinkIdentity(context.Background(), identity, "password_hash")
+			if errInner := utils.EqualError(err, testCase.expectedError); errInner != nil {
+				t.Fatalf("Failed EqualError: %+v", errInner)
+			}
+
+			if userID != testCase.expectedUserID {
+				t.Fatalf("userID: got %d, expected %d", userID, testCase.expectedUserID)
+			}
+
+			if err := mockPool.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestGetUserIDByIdentity(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	mockPool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	authStorage, err := repository.NewAuthStorage(mockPool)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	mockPool.ExpectQuery(`SELECT i.user_id FROM public."user_identity" i`).WithArgs("vk", "1234567").
+		WillReturnError(pgx.ErrNoRows)
+
+	_, err = authStorage.GetUserIDByIdentity(context.Background(), "vk", "1234567")
+	if errInner := utils.EqualError(err, repository.ErrIdentityNotFound); errInner != nil {
+		t.Fatalf("Failed EqualError: %+v", errInner)
+	}
+
+	if err := mockPool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+)
+
+// GetLoginLockedUntil returns the latest lock of keys or zero time if none of keys is locked now.
+func (a *AuthStorage) GetLoginLockedUntil(ctx context.Context, keys []string) (time.Time, error) {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLGetLoginLockedUntil := `SELECT MAX(locked_until) FROM public."login_attempt"
+		 WHERE key = ANY($1) AND locked_until > NOW();`
+
+	var lockedUntil sql.NullTime
+
+	err := a.pool.QueryRow(ctx, SQLGetLoginLockedUntil, keys).Scan(&lockedUntil)
+	if err != nil {
+		logger.Errorln(err)
+
+		return time.Time{}, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return lockedUntil.Time, nil
+}
+
+// AddLoginFailure increments count of failures of key and returns it.
+// Failures are counted from scratch if last of them was before resetBefore.
+func (a *AuthStorage) AddLoginFailure(ctx context.Context, key string, resetBefore time.Time) (uint32, error) {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLAddLoginFailure := `INSERT INTO public."login_attempt" (key, count_failures) VALUES ($1, 1)
+		 ON CONFLICT (key) DO UPDATE SET
+		 count_failures = CASE WHEN login_attempt.last_failure_at < $2 THEN 1
+		 ELSE login_attempt.count_failures + 1 END,
+		 last_failure_at = NOW()
+		 RETURNING count_failures;`
+
+	var countFailures uint32
+
+	err := a.pool.QueryRow(ctx, SQLAddLoginFailure, key, resetBefore).Scan(&countFailures)
+	if err != nil {
+		logger.Errorln(err)
+
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return countFailures, nil
+}
+
+func (a *AuthStorage) LockLogin(ctx context.Context, key string, lockedUntil time.Time) error {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLLockLogin := `UPDATE public."login_attempt" SET locked_until=$1 WHERE key=$2;`
+
+	_, err := a.pool.Exec(ctx, SQLLockLogin, lockedUntil, key)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// DeleteLoginAttempts forgets failures of key after successful login.
+func (a *AuthStorage) DeleteLoginAttempts(ctx context.Context, key string) error {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLDeleteLoginAttempts := `DELETE FROM public."login_attempt" WHERE key=$1;`
+
+	_, err := a.pool.Exec(ctx, SQLDeleteLoginAttempts, key)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// DeleteOutdatedLoginAttempts deletes not locked keys with last failure before outdatedBefore.
+func (a *AuthStorage) DeleteOutdatedLoginAttempts(ctx context.Context, outdatedBefore time.Time) (uint64, error) {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLDeleteOutdatedLoginAttempts := `DELETE FROM public."login_attempt"
+		 WHERE last_failure_at < $1 AND (locked_until IS NULL OR locked_until < NOW());`
+
+	result, err := a.pool.Exec(ctx, SQLDeleteOutdatedLoginAttempts, outdatedBefore)
+	if err != nil {
+		logger.Errorln(err)
+
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return uint64(result.RowsAffected()), nil
+}
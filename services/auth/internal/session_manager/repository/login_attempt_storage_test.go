@@ -0,0 +1,128 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/session_manager/repository"
+	"github.com/pashagolub/pgxmock/v3"
+)
+
+func TestGetLoginLockedUntil(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	lockedUntil := time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC)
+	testError := myerrors.NewErrorInternal("test error")
+	keys := []string{"email:ivn-tyt@mail.ru", "ip:192.0.2.1"}
+
+	type TestCase struct {
+		name                string
+		behaviorMockPool    func(mockPool pgxmock.PgxPoolIface)
+		expectedLockedUntil time.Time
+		expectedError       error
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test locked",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectQuery(`SELECT MAX\(locked_until\) FROM public."login_attempt"`).WithArgs(keys).
+					WillReturnRows(pgxmock.NewRows([]string{"max"}).AddRow(lockedUntil))
+			},
+			expectedLockedUntil: lockedUntil,
+			expectedError:       nil,
+		},
+		{
+			name: "test not locked",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectQuery(`SELECT MAX\(locked_until\) FROM public."login_attempt"`).WithArgs(keys).
+					WillReturnRows(pgxmock.NewRows([]string{"max"}).AddRow(nil))
+			},
+			expectedLockedUntil: time.Time{},
+			expectedError:       nil,
+		},
+		{
+			name: "test internal error",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectQuery(`SELECT MAX\(locked_until\) FROM public."login_attempt"`).WithArgs(keys).
+					WillReturnError(testError)
+			},
+			expectedLockedUntil: time.Time{},
+			expectedError:       testError,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			authStorage, err := repository.NewAuthStorage(mockPool)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			testCase.behaviorMockPool(mockPool)
+
+			lockedUntil, err := authStorage.GetLoginLockedUntil(context.Background(), keys)
+			if errInner := utils.EqualError(err, testCase.expectedError); errInner != nil {
+				t.Fatalf("Failed EqualError: %+v", errInner)
+			}
+
+			if !lockedUntil.Equal(testCase.expectedLockedUntil) {
+				t.Fatalf("lockedUntil: got %v, expected %v", lockedUntil, testCase.expectedLockedUntil)
+			}
+
+			if err := mockPool.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestAddLoginFailure(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	resetBefore := time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC)
+
+	mockPool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	authStorage, err := repository.NewAuthStorage(mockPool)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	mockPool.ExpectQuery(`INSERT INTO public."login_attempt" \(key, count_failures\) VALUES \(\$1, 1\)`).
+		WithArgs("email:ivn-tyt@mail.ru", resetBefore).
+		WillReturnRows(pgxmock.NewRows([]string{"count_failures"}).AddRow(uint32(3)))
+
+	countFailures, err := authStorage.AddLoginFailure(context.Background(), "email:ivn-tyt@mail.ru", resetBefore)
+	if err != nil {
+		t.Fatalf("unexpected error %+v", err)
+	}
+
+	if countFailures != 3 {
+		t.Fatalf("countFailures: got %d, expected 3", countFailures)
+	}
+
+	if err := mockPool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
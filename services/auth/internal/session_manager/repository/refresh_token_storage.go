@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrRefreshTokenNotFound = myerrors.NewErrorBadContentRequest("Некорректный refresh токен")
+	ErrRefreshTokenReused   = myerrors.NewErrorBadContentRequest(
+		"Refresh токен уже был использован, сессия завершена")
+)
+
+func (a *AuthStorage) insertRefreshToken(ctx context.Context, tx pgx.Tx,
+	refreshTokenHash string, sessionID string,
+) error {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLInsertRefreshToken := `INSERT INTO public."refresh_token" (token_hash, session_id) VALUES ($1, $2);`
+
+	_, err := tx.Exec(ctx, SQLInsertRefreshToken, refreshTokenHash, sessionID)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+type refreshTokenState struct {
+	session  models.Session
+	isUsed   bool
+	isActive bool
+}
+
+// selectRefreshToken locks refresh token, so concurrent refreshes with the same token
+// are serialized and the second one is detected as reuse.
+func (a *AuthStorage) selectRefreshToken(ctx context.Context, tx pgx.Tx,
+	refreshTokenHash string,
+) (*refreshTokenState, error) {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLSelectRefreshToken := `SELECT s.id, s.user_id, r.used_at IS NOT NULL,
+       s.revoked_at IS NULL AND s.expire_at > NOW()
+		 FROM public."refresh_token" r INNER JOIN public."session" s ON s.id = r.session_id
+		 WHERE r.token_hash=$1 FOR UPDATE OF r;`
+
+	var state refreshTokenState
+
+	err := tx.QueryRow(ctx, SQLSelectRefreshToken, refreshTokenHash).Scan(
+		&state.session.ID, &state.session.UserID, &state.isUsed, &state.isActive)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf(myerrors.ErrTemplate, ErrRefreshTokenNotFound)
+		}
+
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return &state, nil
+}
+
+func (a *AuthStorage) revokeSessionByID(ctx context.Context, tx pgx.Tx, sessionID string) error {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLRevokeSessionByID := `UPDATE public."session" SET revoked_at=NOW() WHERE id=$1 AND revoked_at IS NULL;`
+
+	_, err := tx.Exec(ctx, SQLRevokeSessionByID, sessionID)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+func (a *AuthStorage) useRefreshToken(ctx context.Context, tx pgx.Tx,
+	refreshTokenHash string, sessionID string, expireAt time.Time,
+) error {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLUseRefreshToken := `UPDATE public."refresh_token" SET used_at=NOW() WHERE token_hash=$1;`
+
+	_, err := tx.Exec(ctx, SQLUseRefreshToken, refreshTokenHash)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	SQLExtendSession := `UPDATE public."session" SET expire_at=$1 WHERE id=$2;`
+
+	_, err = tx.Exec(ctx, SQLExtendSession, expireAt, sessionID)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// RotateRefreshToken replaces used refresh token by new one and extends session up to expireAt.
+// If refresh token was already used, it is considered stolen and the whole session
+// with all its refresh tokens is revoked.
+func (a *AuthStorage) RotateRefreshToken(ctx context.Context, refreshTokenHash string,
+	newRefreshTokenHash string, expireAt time.Time,
+) (*models.Session, error) {
+	var state *refreshTokenState
+
+	err := pgx.BeginFunc(ctx, a.pool, func(tx pgx.Tx) error {
+		var err error
+
+		state, err = a.selectRefreshToken(ctx, tx, refreshTokenHash)
+		if err != nil {
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		// revocation must be committed, so reuse error is returned after transaction
+		if state.isUsed {
+			return a.revokeSessionByID(ctx, tx, state.session.ID)
+		}
+
+		if !state.isActive {
+			return fmt.Errorf(myerrors.ErrTemplate, ErrSessionNotFound)
+		}
+
+		err = a.useRefreshToken(ctx, tx, refreshTokenHash, state.session.ID, expireAt)
+		if err != nil {
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		err = a.insertRefreshToken(ctx, tx, newRefreshTokenHash, state.session.ID)
+		if err != nil {
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if state.isUsed {
+		a.logger.LogReqID(ctx).Warnf("reuse of refresh token of session id=%s user id=%d, session is revoked",
+			state.session.ID, state.session.UserID)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, ErrRefreshTokenReused)
+	}
+
+	state.session.ExpireAt = expireAt
+
+	return &state.session, nil
+}
+
+// RevokeSessionByRefreshToken revokes session of refresh token, e.g. on logout with expired access token.
+// Unknown token or already revoked session isn't error, so repeated logout is harmless.
+func (a *AuthStorage) RevokeSessionByRefreshToken(ctx context.Context, refreshTokenHash string) error {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLRevokeSessionByRefreshToken := `UPDATE public."session" SET revoked_at=NOW()
+		 WHERE id=(SELECT session_id FROM public."refresh_token" WHERE token_hash=$1) AND revoked_at IS NULL;`
+
+	_, err := a.pool.Exec(ctx, SQLRevokeSessionByRefreshToken, refreshTokenHash)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/session_manager/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v3"
+)
+
+func TestRotateRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	expireAt := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	type TestCase struct {
+		name             string
+		behaviorMockPool func(mockPool pgxmock.PgxPoolIface)
+		expectedSession  *models.Session
+		expectedError    error
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+				mockPool.ExpectQuery(`SELECT s.id, s.user_id, r.used_at IS NOT NULL`).WithArgs("old_hash").
+					WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "used", "active"}).
+						AddRow("test_session", uint64(1), false, true))
+				mockPool.ExpectExec(`UPDATE public."refresh_token" SET used_at=NOW\(\) WHERE token_hash=\$1`).
+					WithArgs("old_hash").WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				mockPool.ExpectExec(`UPDATE public."session" SET expire_at=\$1 WHERE id=\$2`).
+					WithArgs(expireAt, "test_session").WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				mockPool.ExpectExec(`INSERT INTO public."refresh_token" \(token_hash, session_id\)`).
+					WithArgs("new_hash", "test_session").WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			expectedSession: &models.Session{ID: "test_session", UserID: 1, ExpireAt: expireAt},
+			expectedError:   nil,
+		},
+		{
+			name: "test reuse revokes session",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+				mockPool.ExpectQuery(`SELECT s.id, s.user_id, r.used_at IS NOT NULL`).WithArgs("old_hash").
+					WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "used", "active"}).
+						AddRow("test_session", uint64(1), true, true))
+				mockPool.ExpectExec(`UPDATE public."session" SET revoked_at=NOW\(\) WHERE id=\$1`).
+					WithArgs("test_session").WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			expectedSession: nil,
+			expectedError:   repository.ErrRefreshTokenReused,
+		},
+		{
+			name: "test revoked session",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+				mockPool.ExpectQuery(`SELECT s.id, s.user_id, r.used_at IS NOT NULL`).WithArgs("old_hash").
+					WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "used", "active"}).
+						AddRow("test_session", uint64(1), false, false))
+				mockPool.ExpectRollback()
+				mockPool.ExpectRollback()
+			},
+			expectedSession: nil,
+			expectedError:   repository.ErrSessionNotFound,
+		},
+		{
+			name: "test unknown refresh token",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+				mockPool.ExpectQuery(`SELECT s.id, s.user_id, r.used_at IS NOT NULL`).WithArgs("old_hash").
+					WillReturnError(pgx.ErrNoRows)
+				mockPool.ExpectRollback()
+				mockPool.ExpectRollback()
+			},
+			expectedSession: nil,
+			expectedError:   repository.ErrRefreshTokenNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			authStorage, err := repository.NewAuthStorage(mockPool)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			testCase.behaviorMockPool(mockPool)
+
+			session, err := authStorage.RotateRefreshToken(context.Background(), "old_hash", "new_hash", expireAt)
+			if errInner := utils.EqualError(err, testCase.expectedError); errInner != nil {
+				t.Fatalf("Failed EqualError: %+v", errInner)
+			}
+
+			if (session == nil) != (testCase.expectedSession == nil) ||
+				(session != nil && *session != *testCase.expectedSession) {
+				t.Fatalf("session: got %+v, expected %+v", session, testCase.expectedSession)
+			}
+
+			if err := mockPool.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestRevokeSessionByRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	mockPool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	authStorage, err := repository.NewAuthStorage(mockPool)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// already revoked session isn't error
+	mockPool.ExpectExec(`UPDATE public."session" SET revoked_at=NOW\(\)`).WithArgs("refresh_hash").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	err = authStorage.RevokeSessionByRefreshToken(context.Background(), "refresh_hash")
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	if err := mockPool.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrSessionNotFound = myerrors.NewErrorBadContentRequest("Такой активной сессии не существует")
+
+func (a *AuthStorage) insertSession(ctx context.Context, tx pgx.Tx, session *models.Session) error {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLInsertSession := `INSERT INTO public."session" (id, user_id, created_at, expire_at) VALUES ($1, $2, $3, $4);`
+
+	_, err := tx.Exec(ctx, SQLInsertSession, session.ID, session.UserID, session.CreatedAt, session.ExpireAt)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// AddSession saves session with its first refresh token.
+func (a *AuthStorage) AddSession(ctx context.Context, session *models.Session, refreshTokenHash string) error {
+	err := pgx.BeginFunc(ctx, a.pool, func(tx pgx.Tx) error {
+		err := a.insertSession(ctx, tx, session)
+		if err != nil {
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		err = a.insertRefreshToken(ctx, tx, refreshTokenHash, session.ID)
+		if err != nil {
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// IsSessionActive returns false if session of user is revoked, expired or unknown.
+func (a *AuthStorage) IsSessionActive(ctx context.Context, sessionID string, userID uint64) (bool, error) {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLIsSessionActive := `SELECT EXISTS(SELECT 1 FROM public."session"
+		 WHERE id=$1 AND user_id=$2 AND revoked_at IS NULL AND expire_at > NOW());`
+
+	var isActive bool
+
+	err := a.pool.QueryRow(ctx, SQLIsSessionActive, sessionID, userID).Scan(&isActive)
+	if err != nil {
+		logger.Errorln(err)
+
+		return false, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return isActive, nil
+}
+
+func (a *AuthStorage) GetActiveSessions(ctx context.Context, userID uint64) ([]*models.Session, error) {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLGetActiveSessions := `SELECT id, created_at, expire_at FROM public."session"
+		 WHERE user_id=$1 AND revoked_at IS NULL AND expire_at > NOW() ORDER BY created_at DESC;`
+
+	sessionRows, err := a.pool.Query(ctx, SQLGetActiveSessions, userID)
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	var sessions []*models.Session
+
+	curSession := new(models.Session)
+
+	_, err = pgx.ForEachRow(sessionRows, []any{
+		&curSession.ID, &curSession.CreatedAt, &curSession.ExpireAt,
+	}, func() error {
+		sessions = append(sessions, &models.Session{
+			ID:        curSession.ID,
+			UserID:    userID,
+			CreatedAt: curSession.CreatedAt,
+			ExpireAt:  curSession.ExpireAt,
+		})
+
+		return nil
+	})
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession revokes only not revoked session of this user.
+func (a *AuthStorage) RevokeSession(ctx context.Context, sessionID string, userID uint64) error {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLRevokeSession := `UPDATE public."session" SET revoked_at=NOW()
+		 WHERE id=$1 AND user_id=$2 AND revoked_at IS NULL;`
+
+	result, err := a.pool.Exec(ctx, SQLRevokeSession, sessionID, userID)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf(myerrors.ErrTemplate, ErrSessionNotFound)
+	}
+
+	return nil
+}
+
+// RevokeAllSessions returns count of revoked sessions.
+func (a *AuthStorage) RevokeAllSessions(ctx context.Context, userID uint64) (uint64, error) {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLRevokeAllSessions := `UPDATE public."session" SET revoked_at=NOW()
+		 WHERE user_id=$1 AND revoked_at IS NULL;`
+
+	result, err := a.pool.Exec(ctx, SQLRevokeAllSessions, userID)
+	if err != nil {
+		logger.Errorln(err)
+
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return uint64(result.RowsAffected()), nil
+}
+
+// DeleteExpiredSessions deletes sessions expired before expiredBefore, their tokens
+// are rejected by expire anyway, so revoked sessions aren't needed after expire too.
+func (a *AuthStorage) DeleteExpiredSessions(ctx context.Context, expiredBefore time.Time) (uint64, error) {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLDeleteExpiredSessions := `DELETE FROM public."session" WHERE expire_at < $1;`
+
+	result, err := a.pool.Exec(ctx, SQLDeleteExpiredSessions, expiredBefore)
+	if err != nil {
+		logger.Errorln(err)
+
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return uint64(result.RowsAffected()), nil
+}
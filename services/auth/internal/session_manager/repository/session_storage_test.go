@@ -0,0 +1,155 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/session_manager/repository"
+	"github.com/pashagolub/pgxmock/v3"
+)
+
+func TestIsSessionActive(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	testError := myerrors.NewErrorInternal("test error")
+
+	type TestCase struct {
+		name             string
+		behaviorMockPool func(mockPool pgxmock.PgxPoolIface)
+		expectedIsActive bool
+		expectedError    error
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test active session",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM public."session"`).
+					WithArgs("test_session", uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
+			},
+			expectedIsActive: true,
+			expectedError:    nil,
+		},
+		{
+			name: "test revoked session",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM public."session"`).
+					WithArgs("test_session", uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(false))
+			},
+			expectedIsActive: false,
+			expectedError:    nil,
+		},
+		{
+			name: "test internal error",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM public."session"`).
+					WithArgs("test_session", uint64(1)).
+					WillReturnError(testError)
+			},
+			expectedIsActive: false,
+			expectedError:    testError,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			authStorage, err := repository.NewAuthStorage(mockPool)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			testCase.behaviorMockPool(mockPool)
+
+			isActive, err := authStorage.IsSessionActive(context.Background(), "test_session", 1)
+			if errInner := utils.EqualError(err, testCase.expectedError); errInner != nil {
+				t.Fatalf("Failed EqualError: %+v", errInner)
+			}
+
+			if isActive != testCase.expectedIsActive {
+				t.Fatalf("isActive: got %t, expected %t", isActive, testCase.expectedIsActive)
+			}
+
+			if err := mockPool.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestRevokeSession(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	type TestCase struct {
+		name             string
+		behaviorMockPool func(mockPool pgxmock.PgxPoolIface)
+		expectedError    error
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectExec(`UPDATE public."session" SET revoked_at=NOW\(\)`).
+					WithArgs("test_session", uint64(1)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			},
+			expectedError: nil,
+		},
+		{
+			name: "test session of other user or already revoked",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectExec(`UPDATE public."session" SET revoked_at=NOW\(\)`).
+					WithArgs("test_session", uint64(1)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+			},
+			expectedError: repository.ErrSessionNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			authStorage, err := repository.NewAuthStorage(mockPool)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			testCase.behaviorMockPool(mockPool)
+
+			err = authStorage.RevokeSession(context.Background(), "test_session", 1)
+			if errInner := utils.EqualError(err, testCase.expectedError); errInner != nil {
+				t.Fatalf("Failed EqualError: %+v", errInner)
+			}
+
+			if err := mockPool.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
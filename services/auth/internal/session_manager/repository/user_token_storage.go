@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrUserTokenNotFound = myerrors.NewErrorBadContentRequest("Ссылка недействительна или устарела")
+	ErrUserNotFound      = myerrors.NewErrorBadContentRequest("Пользователь не найден")
+)
+
+// GetUserEmail returns email of user and whether it is verified.
+func (a *AuthStorage) GetUserEmail(ctx context.Context, userID uint64) (string, bool, error) {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLGetUserEmail := `SELECT email, email_verified_at IS NOT NULL FROM public."user" WHERE id=$1;`
+
+	var (
+		email         string
+		emailVerified bool
+	)
+
+	err := a.pool.QueryRow(ctx, SQLGetUserEmail, userID).Scan(&email, &emailVerified)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", false, ErrUserNotFound
+		}
+
+		logger.Errorln(err)
+
+		return "", false, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return email, emailVerified, nil
+}
+
+// AddUserToken saves new token and invalidates previous unused tokens of user
+// with the same purpose, so only the last sent link works.
+func (a *AuthStorage) AddUserToken(ctx context.Context, userID uint64, purpose models.UserTokenPurpose,
+	tokenHash string, expireAt time.Time,
+) error {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLInvalidateUserTokens := `UPDATE public."user_token" SET used_at=NOW()
+		 WHERE user_id=$1 AND purpose=$2 AND used_at IS NULL;`
+	SQLInsertUserToken := `INSERT INTO public."user_token" (token_hash, user_id, purpose, expire_at)
+		 VALUES ($1, $2, $3, $4);`
+
+	err := pgx.BeginFunc(ctx, a.pool, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, SQLInvalidateUserTokens, userID, purpose)
+		if err != nil {
+			logger.Errorln(err)
+
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		_, err = tx.Exec(ctx, SQLInsertUserToken, tokenHash, userID, purpose, expireAt)
+		if err != nil {
+			logger.Errorln(err)
+
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// useUserToken marks token as used and returns id of its user.
+// Used, expired and unknown tokens are indistinguishable for client.
+func (a *AuthStorage) useUserToken(ctx context.Context, tx pgx.Tx,
+	tokenHash string, purpose models.UserTokenPurpose,
+) (uint64, error) {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLUseUserToken := `UPDATE public."user_token" SET used_at=NOW()
+		 WHERE token_hash=$1 AND purpose=$2 AND used_at IS NULL AND expire_at > NOW()
+		 RETURNING user_id;`
+
+	var userID uint64
+
+	err := tx.QueryRow(ctx, SQLUseUserToken, tokenHash, purpose).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ErrUserTokenNotFound
+		}
+
+		logger.Errorln(err)
+
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return userID, nil
+}
+
+// VerifyEmail uses token of verification and returns id of user with verified email.
+func (a *AuthStorage) VerifyEmail(ctx context.Context, tokenHash string) (uint64, error) {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLVerifyEmail := `UPDATE public."user" SET email_verified_at=NOW()
+		 WHERE id=$1 AND email_verified_at IS NULL;`
+
+	var userID uint64
+
+	err := pgx.BeginFunc(ctx, a.pool, func(tx pgx.Tx) error {
+		var err error
+
+		userID, err = a.useUserToken(ctx, tx, tokenHash, models.PurposeVerifyEmail)
+		if err != nil {
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		_, err = tx.Exec(ctx, SQLVerifyEmail, userID)
+		if err != nil {
+			logger.Errorln(err)
+
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return userID, nil
+}
+
+// ResetPassword uses token of reset, replaces hash of password and revokes all sessions of user,
+// because password could be reset after theft of account. Returns id of user.
+func (a *AuthStorage) ResetPassword(ctx context.Context, tokenHash string, passwordHash string) (uint64, error) {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLUpdatePassword := `UPDATE public."user" SET password=$1 WHERE id=$2;`
+	SQLRevokeAllSessions := `UPDATE public."session" SET revoked_at=NOW()
+		 WHERE user_id=$1 AND revoked_at IS NULL;`
+
+	var userID uint64
+
+	err := pgx.BeginFunc(ctx, a.pool, func(tx pgx.Tx) error {
+		var err error
+
+		userID, err = a.useUserToken(ctx, tx, tokenHash, models.PurposeResetPassword)
+		if err != nil {
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		_, err = tx.Exec(ctx, SQLUpdatePassword, passwordHash, userID)
+		if err != nil {
+			logger.Errorln(err)
+
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		_, err = tx.Exec(ctx, SQLRevokeAllSessions, userID)
+		if err != nil {
+			logger.Errorln(err)
+
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return userID, nil
+}
+
+// DeleteExpiredUserTokens deletes tokens expired before expiredBefore, they are rejected anyway.
+func (a *AuthStorage) DeleteExpiredUserTokens(ctx context.Context, expiredBefore time.Time) (uint64, error) {
+	logger := a.logger.LogReqID(ctx)
+
+	SQLDeleteExpiredUserTokens := `DELETE FROM public."user_token" WHERE expire_at < $1;`
+
+	result, err := a.pool.Exec(ctx, SQLDeleteExpiredUserTokens, expiredBefore)
+	if err != nil {
+		logger.Errorln(err)
+
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return uint64(result.RowsAffected()), nil
+}
@@ -0,0 +1,92 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/session_manager/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v3"
+)
+
+func TestResetPassword(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	type TestCase struct {
+		name             string
+		behaviorMockPool func(mockPool pgxmock.PgxPoolIface)
+		expectedUserID   uint64
+		expectedError    error
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+				mockPool.ExpectQuery(`UPDATE public."user_token" SET used_at=NOW\(\)`).
+					WithArgs("token_hash", models.PurposeResetPassword).
+					WillReturnRows(pgxmock.NewRows([]string{"user_id"}).AddRow(uint64(1)))
+				mockPool.ExpectExec(`UPDATE public."user" SET password=\$1 WHERE id=\$2`).
+					WithArgs("password_hash", uint64(1)).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				mockPool.ExpectExec(`UPDATE public."session" SET revoked_at=NOW\(\)`).
+					WithArgs(uint64(1)).WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			expectedUserID: 1,
+			expectedError:  nil,
+		},
+		{
+			name: "test used or expired token",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+				mockPool.ExpectQuery(`UPDATE public."user_token" SET used_at=NOW\(\)`).
+					WithArgs("token_hash", models.PurposeResetPassword).
+					WillReturnError(pgx.ErrNoRows)
+				mockPool.ExpectRollback()
+				mockPool.ExpectRollback()
+			},
+			expectedUserID: 0,
+			expectedError:  repository.ErrUserTokenNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			authStorage, err := repository.NewAuthStorage(mockPool)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			testCase.behaviorMockPool(mockPool)
+
+			userID, err := authStorage.ResetPassword(context.Background(), "token_hash", "password_hash")
+			if errInner := utils.EqualError(err, testCase.expectedError); errInner != nil {
+				t.Fatalf("Failed EqualError: %+v", errInner)
+			}
+
+			if userID != testCase.expectedUserID {
+				t.Fatalf("userID: got %d, expected %d", userID, testCase.expectedUserID)
+			}
+
+			if err := mockPool.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -2,8 +2,13 @@ package usecases
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
@@ -14,125 +19,386 @@ import (
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/session_manager/repository"
 )
 
-var ErrWrongCredentials = myerrors.NewErrorBadContentRequest("Некорректный логин или пароль")
+var (
+	ErrWrongCredentials = myerrors.NewErrorBadContentRequest("Некорректный логин или пароль")
+	ErrSessionNotActive = myerrors.NewErrorBadContentRequest("Сессия завершена, войдите заново")
+	ErrTokenExpired     = myerrors.NewErrorBadContentRequest("Срок действия токена истёк, обновите его")
+	ErrUserBanned       = myerrors.NewErrorBadContentRequest("Аккаунт заблокирован")
+)
+
+const (
+	lenSessionIDBytes = 16
+	lenTokenBytes     = 32
+)
 
 var _ IAuthStorage = (*repository.AuthStorage)(nil)
 
 type IAuthStorage interface {
 	AddUser(ctx context.Context, email string, password string) (*models.User, error)
 	GetUser(ctx context.Context, email string) (*models.User, error)
+	GetUserByID(ctx context.Context, userID uint64) (*models.User, error)
+	GetUserRole(ctx context.Context, userID uint64) (string, bool, error)
+	ChangePassword(ctx context.Context, userID uint64, passwordHash string, keepSessionID string) (uint64, error)
+	RehashPassword(ctx context.Context, userID uint64, oldPasswordHash string, newPasswordHash string) (bool, error)
+	AddSession(ctx context.Context, session *models.Session, refreshTokenHash string) error
+	RotateRefreshToken(ctx context.Context, refreshTokenHash string,
+		newRefreshTokenHash string, expireAt time.Time) (*models.Session, error)
+	IsSessionActive(ctx context.Context, sessionID string, userID uint64) (bool, error)
+	GetActiveSessions(ctx context.Context, userID uint64) ([]*models.Session, error)
+	RevokeSession(ctx context.Context, sessionID string, userID uint64) error
+	RevokeSessionByRefreshToken(ctx context.Context, refreshTokenHash string) error
+	RevokeAllSessions(ctx context.Context, userID uint64) (uint64, error)
+	DeleteExpiredSessions(ctx context.Context, expiredBefore time.Time) (uint64, error)
+	GetLoginLockedUntil(ctx context.Context, keys []string) (time.Time, error)
+	AddLoginFailure(ctx context.Context, key string, resetBefore time.Time) (uint32, error)
+	LockLogin(ctx context.Context, key string, lockedUntil time.Time) error
+	DeleteLoginAttempts(ctx context.Context, key string) error
+	DeleteOutdatedLoginAttempts(ctx context.Context, outdatedBefore time.Time) (uint64, error)
+	GetUserEmail(ctx context.Context, userID uint64) (string, bool, error)
+	AddUserToken(ctx context.Context, userID uint64, purpose models.UserTokenPurpose,
+		tokenHash string, expireAt time.Time) error
+	VerifyEmail(ctx context.Context, tokenHash string) (uint64, error)
+	ResetPassword(ctx context.Context, tokenHash string, passwordHash string) (uint64, error)
+	DeleteExpiredUserTokens(ctx context.Context, expiredBefore time.Time) (uint64, error)
+	GetUserIDByIdentity(ctx context.Context, provider string, subject string) (uint64, error)
+	LinkIdentity(ctx context.Context, identity *models.Identity, passwordHash string) (uint64, error)
 }
 
 type AuthService struct {
-	storage IAuthStorage
-	logger  *mylogger.MyLogger
+	storage         IAuthStorage
+	keyring         *jwt.Keyring
+	loginMetrics    ILoginMetrics
+	mailSender      IMailSender
+	mailLinkBaseURL string
+	hashParams      utils.HashParams
+	oauthProviders  map[string]IOAuthProvider
+	logger          *mylogger.MyLogger
 }
 
-func NewAuthService(authStorage IAuthStorage) (*AuthService, error) {
+// NewAuthService mailLinkBaseURL is address of frontend, links in letters lead to its pages.
+// New passwords are hashed with hashParams, old ones are rehashed with them on login.
+// oauthProviders are available for login by their names.
+func NewAuthService(authStorage IAuthStorage, keyring *jwt.Keyring,
+	loginMetrics ILoginMetrics, mailSender IMailSender, mailLinkBaseURL string,
+	hashParams utils.HashParams, oauthProviders []IOAuthProvider,
+) (*AuthService, error) {
 	logger, err := mylogger.Get()
 	if err != nil {
 		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	return &AuthService{storage: authStorage, logger: logger}, nil
+	mapOAuthProviders := make(map[string]IOAuthProvider, len(oauthProviders))
+	for _, provider := range oauthProviders {
+		mapOAuthProviders[provider.Name()] = provider
+	}
+
+	return &AuthService{
+		storage:         authStorage,
+		keyring:         keyring,
+		loginMetrics:    loginMetrics,
+		mailSender:      mailSender,
+		mailLinkBaseURL: strings.TrimSuffix(mailLinkBaseURL, "/"),
+		hashParams:      hashParams,
+		oauthProviders:  mapOAuthProviders,
+		logger:          logger,
+	}, nil
 }
 
-func (a *AuthService) LoginUser(ctx context.Context, email string, password string) (string, error) {
+// LoginUser failures are counted by email and ip of client,
+// after too many of them login is locked with exponential backoff.
+func (a *AuthService) LoginUser(ctx context.Context, email string, password string,
+	clientIP string,
+) (*models.Tokens, error) {
 	logger := a.logger.LogReqID(ctx)
 
+	loginAttemptKeys := newLoginAttemptKeys(email, clientIP)
+
+	err := a.checkLoginLock(ctx, loginAttemptKeys)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
 	user, err := a.storage.GetUser(ctx, email)
 	if err != nil {
+		if errors.Is(err, repository.ErrEmailNotExist) {
+			errFailure := a.addLoginFailure(ctx, loginAttemptKeys)
+			if errFailure != nil {
+				logger.Errorln(errFailure)
+			}
+		}
+
 		logger.Errorln(err)
 
-		return "", fmt.Errorf(myerrors.ErrTemplate, err)
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	ok, needsRehash, err := utils.VerifyPass(user.Password, password, a.hashParams)
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if !ok {
+		err = a.addLoginFailure(ctx, loginAttemptKeys)
+		if err != nil {
+			logger.Errorln(err)
+		}
+
+		return nil, ErrWrongCredentials
+	}
+
+	err = a.storage.DeleteLoginAttempts(ctx, loginAttemptKeys[0].key)
+	if err != nil {
+		logger.Errorln(err)
+	}
+
+	// failed rehash doesn't fail login, it will be retried next time
+	if needsRehash {
+		err = a.rehashPassword(ctx, user, password)
+		if err != nil {
+			logger.Errorln(err)
+		}
+	}
+
+	tokens, err := a.createSession(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return tokens, nil
+}
+
+func (a *AuthService) AddUser(ctx context.Context, email string, password string) (*models.Tokens, error) {
+	logger := a.logger.LogReqID(ctx)
+
+	password, err := utils.HashPassWithParams(password, a.hashParams)
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	user, err := a.storage.AddUser(ctx, email, password)
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	hashPass, err := hex.DecodeString(user.Password)
+	// user can request letter again, so failed sending doesn't fail signup
+	err = a.sendUserToken(ctx, user.ID, user.Email, models.PurposeVerifyEmail)
 	if err != nil {
 		logger.Errorln(err)
+	}
 
+	tokens, err := a.createSession(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return tokens, nil
+}
+
+func generateSessionID() (string, error) {
+	sessionIDBytes := make([]byte, lenSessionIDBytes)
+
+	_, err := rand.Read(sessionIDBytes)
+	if err != nil {
 		return "", fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	if !utils.ComparePassAndHash(hashPass, password) {
-		return "", ErrWrongCredentials
+	return hex.EncodeToString(sessionIDBytes), nil
+}
+
+// generateToken returns random token for refresh and for links sent by email.
+func generateToken() (string, error) {
+	tokenBytes := make([]byte, lenTokenBytes)
+
+	_, err := rand.Read(tokenBytes)
+	if err != nil {
+		return "", fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	jwtPayload := jwt.UserJwtPayload{} //nolint:exhaustruct
+	return base64.RawURLEncoding.EncodeToString(tokenBytes), nil
+}
+
+// hashToken token has enough entropy, so salt and slow hash aren't needed.
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
 
-	jwtPayload.UserID = user.ID
-	jwtPayload.Expire = time.Now().Add(jwt.TimeTokenLife).Unix()
+	return hex.EncodeToString(hash[:])
+}
 
-	secret, err := jwt.GetSecret()
+// generateAccessToken role is read from storage, so its change takes effect since next refresh.
+// Banned user doesn't get token.
+func (a *AuthService) generateAccessToken(ctx context.Context, userID uint64, sessionID string,
+	now time.Time,
+) (string, error) {
+	role, banned, err := a.storage.GetUserRole(ctx, userID)
 	if err != nil {
 		return "", fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	rawJwt, err := jwt.GenerateJwtToken(&jwtPayload, secret)
+	if banned {
+		return "", ErrUserBanned
+	}
+
+	signingKey, err := a.keyring.SigningKey()
 	if err != nil {
-		logger.Errorln(err)
+		return "", fmt.Errorf(myerrors.ErrTemplate, err)
+	}
 
+	rawJwt, err := jwt.GenerateJwtToken(&jwt.UserJwtPayload{
+		UserID:    userID,
+		Expire:    now.Add(jwt.TimeAccessTokenLife).Unix(),
+		SessionID: sessionID,
+		Role:      role,
+	}, signingKey)
+	if err != nil {
 		return "", fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
 	return rawJwt, nil
 }
 
-func (a *AuthService) AddUser(ctx context.Context, email string, password string) (string, error) {
+// createSession saves new session of user with its first refresh token
+// and returns short-lived jwt with id of session as jti.
+func (a *AuthService) createSession(ctx context.Context, userID uint64) (*models.Tokens, error) {
 	logger := a.logger.LogReqID(ctx)
 
-	password, err := utils.HashPass(password)
+	sessionID, err := generateSessionID()
 	if err != nil {
 		logger.Errorln(err)
 
-		return "", fmt.Errorf(myerrors.ErrTemplate, err)
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	user, err := a.storage.AddUser(ctx, email, password)
+	refreshToken, err := generateToken()
 	if err != nil {
 		logger.Errorln(err)
 
-		return "", fmt.Errorf(myerrors.ErrTemplate, err)
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	jwtPayload := jwt.UserJwtPayload{} //nolint:exhaustruct
+	now := time.Now()
+	session := &models.Session{
+		ID:        sessionID,
+		UserID:    userID,
+		CreatedAt: now,
+		ExpireAt:  now.Add(jwt.TimeRefreshTokenLife),
+	}
 
-	jwtPayload.UserID = user.ID
-	jwtPayload.Expire = time.Now().Add(jwt.TimeTokenLife).Unix()
+	// jwt is generated before saving of session, so banned user doesn't get session
+	accessToken, err := a.generateAccessToken(ctx, userID, sessionID, now)
+	if err != nil {
+		logger.Errorln(err)
 
-	secret, err := jwt.GetSecret()
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	err = a.storage.AddSession(ctx, session, hashToken(refreshToken))
 	if err != nil {
-		return "", fmt.Errorf(myerrors.ErrTemplate, err)
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	rawJwt, err := jwt.GenerateJwtToken(&jwtPayload, secret)
+	return &models.Tokens{AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+// Refresh exchanges single-use refresh token for new pair of tokens of the same session.
+// Reuse of refresh token revokes session, because token was stolen by someone.
+func (a *AuthService) Refresh(ctx context.Context, refreshToken string) (*models.Tokens, error) {
+	logger := a.logger.LogReqID(ctx)
+
+	newRefreshToken, err := generateToken()
 	if err != nil {
 		logger.Errorln(err)
 
-		return "", fmt.Errorf(myerrors.ErrTemplate, err)
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	return rawJwt, nil
+	now := time.Now()
+
+	session, err := a.storage.RotateRefreshToken(ctx, hashToken(refreshToken),
+		hashToken(newRefreshToken), now.Add(jwt.TimeRefreshTokenLife))
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	accessToken, err := a.generateAccessToken(ctx, session.UserID, session.ID, now)
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return &models.Tokens{AccessToken: accessToken, RefreshToken: newRefreshToken}, nil
 }
 
-func (a *AuthService) Delete(ctx context.Context, rawJwt string) (string, error) {
-	logger := a.logger.LogReqID(ctx)
+// checkSession returns payload of jwt only if jwt isn't expired and its session is active.
+func (a *AuthService) checkSession(ctx context.Context, rawJwt string) (*jwt.UserJwtPayload, error) {
+	userPayload, err := jwt.NewUserJwtPayload(rawJwt, a.keyring)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if userPayload.SessionID == "" {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, ErrSessionNotActive)
+	}
+
+	if userPayload.Expire < time.Now().Unix() {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, ErrTokenExpired)
+	}
 
-	secret, err := jwt.GetSecret()
+	isActive, err := a.storage.IsSessionActive(ctx, userPayload.SessionID, userPayload.UserID)
 	if err != nil {
-		return "", fmt.Errorf(myerrors.ErrTemplate, err)
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if !isActive {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, ErrSessionNotActive)
 	}
 
-	jwtPayload, err := jwt.NewUserJwtPayload(rawJwt, secret)
+	return userPayload, nil
+}
+
+// Delete revokes session of jwt, so jwt isn't accepted anymore even before its expire.
+// Returned jwt is expired copy of jwt for cookie. Without valid jwt, e.g. after its expire,
+// session is revoked by refresh token and returned jwt is empty.
+func (a *AuthService) Delete(ctx context.Context, rawJwt string, refreshToken string) (string, error) {
+	logger := a.logger.LogReqID(ctx)
+
+	if rawJwt == "" && refreshToken != "" {
+		return "", a.deleteByRefreshToken(ctx, refreshToken)
+	}
+
+	jwtPayload, err := jwt.NewUserJwtPayload(rawJwt, a.keyring)
 	if err != nil {
 		logger.Errorln(err)
 
+		if refreshToken != "" {
+			return "", a.deleteByRefreshToken(ctx, refreshToken)
+		}
+
 		return "", fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
+	// repeated logout with already revoked session isn't error
+	if jwtPayload.SessionID != "" {
+		err = a.storage.RevokeSession(ctx, jwtPayload.SessionID, jwtPayload.UserID)
+		if err != nil && !errors.Is(err, repository.ErrSessionNotFound) {
+			return "", fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+	}
+
 	jwtPayload.Expire = time.Now().Unix()
 
-	newRawJwt, err := jwt.GenerateJwtToken(jwtPayload, secret)
+	signingKey, err := a.keyring.SigningKey()
+	if err != nil {
+		return "", fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	newRawJwt, err := jwt.GenerateJwtToken(jwtPayload, signingKey)
 	if err != nil {
 		logger.Errorln(err)
 
@@ -142,16 +408,116 @@ func (a *AuthService) Delete(ctx context.Context, rawJwt string) (string, error)
 	return newRawJwt, nil
 }
 
-func (a *AuthService) Check(_ context.Context, rawJwt string) (uint64, error) {
-	secret, err := jwt.GetSecret()
+// Check returns id and role of owner of jwt.
+func (a *AuthService) Check(ctx context.Context, rawJwt string) (uint64, string, error) {
+	userPayload, err := a.checkSession(ctx, rawJwt)
+	if err != nil {
+		return 0, "", fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return userPayload.UserID, userPayload.Role, nil
+}
+
+// ListSessions returns active sessions of owner of jwt and id of session of this jwt.
+func (a *AuthService) ListSessions(ctx context.Context, rawJwt string) ([]*models.Session, string, error) {
+	userPayload, err := a.checkSession(ctx, rawJwt)
+	if err != nil {
+		return nil, "", fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	sessions, err := a.storage.GetActiveSessions(ctx, userPayload.UserID)
 	if err != nil {
-		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+		return nil, "", fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	userPayload, err := jwt.NewUserJwtPayload(rawJwt, secret)
+	return sessions, userPayload.SessionID, nil
+}
+
+func (a *AuthService) deleteByRefreshToken(ctx context.Context, refreshToken string) error {
+	err := a.storage.RevokeSessionByRefreshToken(ctx, hashToken(refreshToken))
 	if err != nil {
-		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+		return fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	return userPayload.UserID, nil
+	return nil
+}
+
+// RevokeSession revokes other session of the same user, e.g. on stolen device.
+func (a *AuthService) RevokeSession(ctx context.Context, rawJwt string, sessionID string) error {
+	userPayload, err := a.checkSession(ctx, rawJwt)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	err = a.storage.RevokeSession(ctx, sessionID, userPayload.UserID)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// RevokeAllSessions revokes all sessions of user including session of jwt.
+func (a *AuthService) RevokeAllSessions(ctx context.Context, rawJwt string) error {
+	logger := a.logger.LogReqID(ctx)
+
+	userPayload, err := a.checkSession(ctx, rawJwt)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	countRevoked, err := a.storage.RevokeAllSessions(ctx, userPayload.UserID)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	logger.Infof("revoked %d sessions of user id=%d", countRevoked, userPayload.UserID)
+
+	return nil
+}
+
+// StartDeletingExpiredSessions periodically deletes expired sessions, outdated
+// login attempts and expired tokens from letters until chClose is closed.
+func (a *AuthService) StartDeletingExpiredSessions(ctx context.Context,
+	period time.Duration, chClose <-chan struct{},
+) {
+	go func() {
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-chClose:
+				return
+			case <-ticker.C:
+				countDeleted, err := a.storage.DeleteExpiredSessions(ctx, time.Now())
+				if err != nil {
+					a.logger.Errorln(err)
+
+					continue
+				}
+
+				a.logger.Infof("deleted %d expired sessions", countDeleted)
+
+				countDeleted, err = a.storage.DeleteOutdatedLoginAttempts(ctx,
+					time.Now().Add(-PeriodResetLoginFailures))
+				if err != nil {
+					a.logger.Errorln(err)
+
+					continue
+				}
+
+				a.logger.Infof("deleted %d outdated login attempts", countDeleted)
+
+				countDeleted, err = a.storage.DeleteExpiredUserTokens(ctx, time.Now())
+				if err != nil {
+					a.logger.Errorln(err)
+
+					continue
+				}
+
+				a.logger.Infof("deleted %d expired user tokens", countDeleted)
+			}
+		}
+	}()
 }
@@ -0,0 +1,122 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+)
+
+const (
+	// MaxLoginFailuresEmail after this count of failures login of email is locked
+	MaxLoginFailuresEmail = 5
+	// MaxLoginFailuresIP is bigger, because many users can be behind one ip of NAT
+	MaxLoginFailuresIP = 20
+	// BaseLoginLock is first lock, every next failure during lock doubles it up to MaxLoginLock
+	BaseLoginLock = 30 * time.Second
+	MaxLoginLock  = time.Hour
+	// PeriodResetLoginFailures failures older than this period are forgotten
+	PeriodResetLoginFailures = time.Hour
+
+	ReasonLoginWrongCredentials = "wrong_credentials"
+	ReasonLoginLocked           = "locked"
+
+	prefixLoginAttemptEmail = "email:"
+	prefixLoginAttemptIP    = "ip:"
+)
+
+type ILoginMetrics interface {
+	IncTotalLoginFailure(reason string)
+}
+
+func NewErrLoginLocked(wait time.Duration) *myerrors.Error {
+	return myerrors.NewErrorTooManyRequests(
+		"Слишком много неудачных попыток входа, повторите через %d сек.", int(math.Ceil(wait.Seconds())))
+}
+
+type loginAttemptKey struct {
+	key         string
+	maxFailures uint32
+}
+
+func newLoginAttemptKeys(email string, clientIP string) []loginAttemptKey {
+	keys := []loginAttemptKey{{
+		key:         prefixLoginAttemptEmail + strings.ToLower(strings.TrimSpace(email)),
+		maxFailures: MaxLoginFailuresEmail,
+	}}
+
+	if clientIP != "" {
+		keys = append(keys, loginAttemptKey{key: prefixLoginAttemptIP + clientIP, maxFailures: MaxLoginFailuresIP})
+	}
+
+	return keys
+}
+
+// LoginLockDuration returns zero before maxFailures and exponential backoff after.
+func LoginLockDuration(countFailures uint32, maxFailures uint32) time.Duration {
+	if countFailures < maxFailures {
+		return 0
+	}
+
+	lock := BaseLoginLock
+
+	for i := maxFailures; i < countFailures && lock < MaxLoginLock; i++ {
+		lock *= 2
+	}
+
+	return min(lock, MaxLoginLock)
+}
+
+// checkLoginLock returns error with wait time if email or ip is locked.
+func (a *AuthService) checkLoginLock(ctx context.Context, keys []loginAttemptKey) error {
+	rawKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		rawKeys = append(rawKeys, key.key)
+	}
+
+	lockedUntil, err := a.storage.GetLoginLockedUntil(ctx, rawKeys)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if wait := time.Until(lockedUntil); wait > 0 {
+		a.loginMetrics.IncTotalLoginFailure(ReasonLoginLocked)
+
+		return NewErrLoginLocked(wait)
+	}
+
+	return nil
+}
+
+// addLoginFailure counts failure for email and ip and locks them if there are too many failures.
+func (a *AuthService) addLoginFailure(ctx context.Context, keys []loginAttemptKey) error {
+	logger := a.logger.LogReqID(ctx)
+
+	a.loginMetrics.IncTotalLoginFailure(ReasonLoginWrongCredentials)
+
+	now := time.Now()
+
+	for _, key := range keys {
+		countFailures, err := a.storage.AddLoginFailure(ctx, key.key, now.Add(-PeriodResetLoginFailures))
+		if err != nil {
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		lock := LoginLockDuration(countFailures, key.maxFailures)
+		if lock == 0 {
+			continue
+		}
+
+		err = a.storage.LockLogin(ctx, key.key, now.Add(lock))
+		if err != nil {
+			return fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		logger.Infof("login of %s is locked for %v after %d failures", key.key, lock, countFailures)
+	}
+
+	return nil
+}
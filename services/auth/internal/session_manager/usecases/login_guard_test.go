@@ -0,0 +1,70 @@
+package usecases_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/session_manager/usecases"
+)
+
+func TestLoginLockDuration(t *testing.T) {
+	t.Parallel()
+
+	type TestCase struct {
+		name          string
+		countFailures uint32
+		maxFailures   uint32
+		expectedLock  time.Duration
+	}
+
+	testCases := [...]TestCase{
+		{
+			name:          "test before max",
+			countFailures: usecases.MaxLoginFailuresEmail - 1,
+			maxFailures:   usecases.MaxLoginFailuresEmail,
+			expectedLock:  0,
+		},
+		{
+			name:          "test first lock",
+			countFailures: usecases.MaxLoginFailuresEmail,
+			maxFailures:   usecases.MaxLoginFailuresEmail,
+			expectedLock:  usecases.BaseLoginLock,
+		},
+		{
+			name:          "test backoff",
+			countFailures: usecases.MaxLoginFailuresEmail + 2,
+			maxFailures:   usecases.MaxLoginFailuresEmail,
+			expectedLock:  4 * usecases.BaseLoginLock,
+		},
+		{
+			name:          "test max lock",
+			countFailures: usecases.MaxLoginFailuresEmail + 100,
+			maxFailures:   usecases.MaxLoginFailuresEmail,
+			expectedLock:  usecases.MaxLoginLock,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			lock := usecases.LoginLockDuration(testCase.countFailures, testCase.maxFailures)
+			if lock != testCase.expectedLock {
+				t.Fatalf("lock: got %v, expected %v", lock, testCase.expectedLock)
+			}
+		})
+	}
+}
+
+func TestNewErrLoginLocked(t *testing.T) {
+	t.Parallel()
+
+	err := usecases.NewErrLoginLocked(1500 * time.Millisecond)
+
+	expected := "Слишком много неудачных попыток входа, повторите через 2 сек."
+	if err.Error() != expected {
+		t.Fatalf("message: got %s, expected %s", err.Error(), expected)
+	}
+}
@@ -0,0 +1,130 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/oauth"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/session_manager/repository"
+)
+
+var (
+	ErrUnknownOAuthProvider  = myerrors.NewErrorBadContentRequest("Вход через этот сервис недоступен")
+	ErrOAuthEmailNotVerified = myerrors.NewErrorBadContentRequest(
+		"Подтвердите email в аккаунте внешнего сервиса, чтобы войти через него")
+)
+
+var (
+	_ IOAuthProvider = (*oauth.VKProvider)(nil)
+	_ IOAuthProvider = (*oauth.OIDCProvider)(nil)
+)
+
+// IOAuthProvider login by authorization code with PKCE.
+type IOAuthProvider interface {
+	Name() string
+	AuthCodeURL(state string, codeChallenge string) string
+	Exchange(ctx context.Context, code string, codeVerifier string, deviceID string) (*models.Identity, error)
+}
+
+func (a *AuthService) getOAuthProvider(name string) (IOAuthProvider, error) {
+	provider, ok := a.oauthProviders[name]
+	if !ok {
+		return nil, ErrUnknownOAuthProvider
+	}
+
+	return provider, nil
+}
+
+// GetOAuthURL returns link to login page of provider. State and code verifier
+// should be kept by client until provider redirects user back with code.
+func (a *AuthService) GetOAuthURL(_ context.Context, providerName string) (string, string, string, error) {
+	provider, err := a.getOAuthProvider(providerName)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	state, err := generateToken()
+	if err != nil {
+		return "", "", "", fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	codeVerifier, err := generateToken()
+	if err != nil {
+		return "", "", "", fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return provider.AuthCodeURL(state, oauth.CodeChallengeS256(codeVerifier)), state, codeVerifier, nil
+}
+
+// LoginOAuth exchanges code for identity and creates session of its user.
+// Identity seen first time is linked to user with the same email, only if provider verified email.
+func (a *AuthService) LoginOAuth(ctx context.Context, providerName string, code string,
+	codeVerifier string, deviceID string,
+) (*models.Tokens, error) {
+	logger := a.logger.LogReqID(ctx)
+
+	provider, err := a.getOAuthProvider(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := provider.Exchange(ctx, code, codeVerifier, deviceID)
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	userID, err := a.storage.GetUserIDByIdentity(ctx, identity.Provider, identity.Subject)
+	if err != nil && !errors.Is(err, repository.ErrIdentityNotFound) {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if errors.Is(err, repository.ErrIdentityNotFound) {
+		if identity.Email == "" || !identity.EmailVerified {
+			return nil, ErrOAuthEmailNotVerified
+		}
+
+		userID, err = a.linkIdentity(ctx, identity)
+		if err != nil {
+			if !errors.Is(err, repository.ErrIdentityEmailNotLinked) {
+				logger.Errorln(err)
+			}
+
+			return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		logger.Infof("linked %s identity to user id=%d", identity.Provider, userID)
+	}
+
+	tokens, err := a.createSession(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return tokens, nil
+}
+
+// linkIdentity new user gets random password, which nobody knows.
+func (a *AuthService) linkIdentity(ctx context.Context, identity *models.Identity) (uint64, error) {
+	password, err := generateToken()
+	if err != nil {
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	passwordHash, err := utils.HashPassWithParams(password, a.hashParams)
+	if err != nil {
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	userID, err := a.storage.LinkIdentity(ctx, identity, passwordHash)
+	if err != nil {
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,136 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/models"
+)
+
+var (
+	ErrWrongOldPassword = myerrors.NewErrorBadContentRequest("Неверный текущий пароль")
+	ErrSamePassword     = myerrors.NewErrorBadContentRequest("Новый пароль совпадает с текущим")
+)
+
+// verifyCurrentPassword wrong password is counted as failure of login and locks email and ip
+// like login does, so stolen session can't be used for guessing of password.
+func (a *AuthService) verifyCurrentPassword(ctx context.Context, userID uint64,
+	password string, clientIP string,
+) error {
+	logger := a.logger.LogReqID(ctx)
+
+	user, err := a.storage.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	loginAttemptKeys := newLoginAttemptKeys(user.Email, clientIP)
+
+	err = a.checkLoginLock(ctx, loginAttemptKeys)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	ok, _, err := utils.VerifyPass(user.Password, password, a.hashParams)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if !ok {
+		err = a.addLoginFailure(ctx, loginAttemptKeys)
+		if err != nil {
+			logger.Errorln(err)
+		}
+
+		return ErrWrongOldPassword
+	}
+
+	err = a.storage.DeleteLoginAttempts(ctx, loginAttemptKeys[0].key)
+	if err != nil {
+		logger.Errorln(err)
+	}
+
+	return nil
+}
+
+// CheckPassword checks current password of owner of jwt before dangerous actions.
+func (a *AuthService) CheckPassword(ctx context.Context, rawJwt string, password string, clientIP string) error {
+	userPayload, err := a.checkSession(ctx, rawJwt)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	err = a.verifyCurrentPassword(ctx, userPayload.UserID, password, clientIP)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// ChangePassword checks current password of owner of jwt, sets new one
+// and revokes all other sessions of user.
+func (a *AuthService) ChangePassword(ctx context.Context, rawJwt string,
+	oldPassword string, newPassword string, clientIP string,
+) error {
+	logger := a.logger.LogReqID(ctx)
+
+	userPayload, err := a.checkSession(ctx, rawJwt)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if len(newPassword) < models.MinLenPassword {
+		return ErrShortPassword
+	}
+
+	if oldPassword == newPassword {
+		return ErrSamePassword
+	}
+
+	err = a.verifyCurrentPassword(ctx, userPayload.UserID, oldPassword, clientIP)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	newPasswordHash, err := utils.HashPassWithParams(newPassword, a.hashParams)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	countRevoked, err := a.storage.ChangePassword(ctx, userPayload.UserID, newPasswordHash, userPayload.SessionID)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	logger.Infof("changed password of user id=%d, revoked %d other sessions", userPayload.UserID, countRevoked)
+
+	return nil
+}
+
+// rehashPassword saves hash of right password made with current parameters instead of outdated one.
+func (a *AuthService) rehashPassword(ctx context.Context, user *models.User, password string) error {
+	logger := a.logger.LogReqID(ctx)
+
+	newPasswordHash, err := utils.HashPassWithParams(password, a.hashParams)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	replaced, err := a.storage.RehashPassword(ctx, user.ID, user.Password, newPasswordHash)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if replaced {
+		logger.Infof("rehashed password of user id=%d", user.ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/mail"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/models"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/auth/internal/session_manager/repository"
+)
+
+const (
+	TimeVerifyEmailTokenLife   = 24 * time.Hour
+	TimeResetPasswordTokenLife = time.Hour
+
+	PathLinkVerifyEmail   = "/verify_email"
+	PathLinkResetPassword = "/reset_password"
+
+	subjectVerifyEmail   = "Подтверждение email"
+	subjectResetPassword = "Восстановление пароля"
+	templateVerifyEmail  = "Для подтверждения email перейдите по ссылке:\n%s\n\n" +
+		"Ссылка действует %d ч. Если вы не регистрировались, просто проигнорируйте письмо."
+	templateResetPassword = "Для смены пароля перейдите по ссылке:\n%s\n\n" +
+		"Ссылка действует %d мин. Если вы не запрашивали смену пароля, просто проигнорируйте письмо."
+)
+
+var (
+	ErrEmailAlreadyVerified = myerrors.NewErrorBadContentRequest("Email уже подтверждён")
+	ErrShortPassword        = myerrors.NewErrorBadContentRequest(
+		"Пароль должен быть минимум %d символов", models.MinLenPassword)
+)
+
+type IMailSender interface {
+	Send(ctx context.Context, message *mail.Message) error
+}
+
+// buildLink returns link to page of frontend, which sends token back to api.
+func (a *AuthService) buildLink(path string, token string) string {
+	return a.mailLinkBaseURL + path + "?" + url.Values{"token": []string{token}}.Encode()
+}
+
+// sendUserToken saves hash of new token and sends letter with link containing token,
+// token itself isn't stored anywhere.
+func (a *AuthService) sendUserToken(ctx context.Context, userID uint64, email string,
+	purpose models.UserTokenPurpose,
+) error {
+	token, err := generateToken()
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	message := &mail.Message{To: email} //nolint:exhaustruct
+	tokenLife := TimeVerifyEmailTokenLife
+
+	switch purpose {
+	case models.PurposeVerifyEmail:
+		message.Subject = subjectVerifyEmail
+		message.Body = fmt.Sprintf(templateVerifyEmail,
+			a.buildLink(PathLinkVerifyEmail, token), int(tokenLife.Hours()))
+	case models.PurposeResetPassword:
+		tokenLife = TimeResetPasswordTokenLife
+		message.Subject = subjectResetPassword
+		message.Body = fmt.Sprintf(templateResetPassword,
+			a.buildLink(PathLinkResetPassword, token), int(tokenLife.Minutes()))
+	}
+
+	err = a.storage.AddUserToken(ctx, userID, purpose, hashToken(token), time.Now().Add(tokenLife))
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	err = a.mailSender.Send(ctx, message)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// SendVerifyEmail sends link of verification to email of owner of jwt again.
+func (a *AuthService) SendVerifyEmail(ctx context.Context, rawJwt string) error {
+	logger := a.logger.LogReqID(ctx)
+
+	userPayload, err := a.checkSession(ctx, rawJwt)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	email, emailVerified, err := a.storage.GetUserEmail(ctx, userPayload.UserID)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	if emailVerified {
+		return ErrEmailAlreadyVerified
+	}
+
+	err = a.sendUserToken(ctx, userPayload.UserID, email, models.PurposeVerifyEmail)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+func (a *AuthService) VerifyEmail(ctx context.Context, token string) error {
+	logger := a.logger.LogReqID(ctx)
+
+	userID, err := a.storage.VerifyEmail(ctx, hashToken(token))
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	logger.Infof("verified email of user id=%d", userID)
+
+	return nil
+}
+
+// RequestPasswordReset sends link of reset if user with email exists. Unknown email
+// isn't error, so response doesn't tell whether email is registered.
+func (a *AuthService) RequestPasswordReset(ctx context.Context, email string) error {
+	logger := a.logger.LogReqID(ctx)
+
+	user, err := a.storage.GetUser(ctx, email)
+	if err != nil {
+		if errors.Is(err, repository.ErrEmailNotExist) {
+			logger.Infof("reset of password is requested for not existing email")
+
+			return nil
+		}
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	err = a.sendUserToken(ctx, user.ID, user.Email, models.PurposeResetPassword)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// ResetPassword sets new password by token from letter and revokes all sessions of user.
+func (a *AuthService) ResetPassword(ctx context.Context, token string, password string) error {
+	logger := a.logger.LogReqID(ctx)
+
+	if len(password) < models.MinLenPassword {
+		return ErrShortPassword
+	}
+
+	passwordHash, err := utils.HashPassWithParams(password, a.hashParams)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	userID, err := a.storage.ResetPassword(ctx, hashToken(token), passwordHash)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	logger.Infof("reset password of user id=%d", userID)
+
+	return nil
+}
@@ -1,10 +1,39 @@
 package config
 
-import "github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/config"
+import (
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/config"
+)
+
+const (
+	FileStorageFS = "fs"
+	FileStorageS3 = "s3"
+)
 
 const (
 	standardOutputLogPathFS      = "stdout /var/log/backend/logs_fs.json"
 	standardErrorOutputLogPathFS = "stderr /var/log/backend/err_logs_fs.json"
+	envFileStorage               = "FILE_STORAGE"
+	standardFileStorage          = FileStorageFS
+	envS3Endpoint                = "S3_ENDPOINT"
+	standardS3Endpoint           = "http://localhost:9000"
+	envS3Region                  = "S3_REGION"
+	standardS3Region             = "us-east-1"
+	envS3Bucket                  = "S3_BUCKET"
+	standardS3Bucket             = "images"
+	envS3AccessKey               = "S3_ACCESS_KEY"
+	envS3SecretKey               = "S3_SECRET_KEY"
+	envImageGCPeriod             = "IMAGE_GC_PERIOD"
+	standardImageGCPeriod        = time.Hour
+	envImageGCGracePeriod        = "IMAGE_GC_GRACE_PERIOD"
+	standardImageGCGracePeriod   = 24 * time.Hour
+	envImageGCDryRun             = "IMAGE_GC_DRY_RUN"
+	standardImageGCDryRun        = "false"
+	envUploadQuotaBytes          = "UPLOAD_QUOTA_BYTES"
+	standardUploadQuotaBytes     = 200 * 1024 * 1024
+	envUploadQuotaFiles          = "UPLOAD_QUOTA_FILES"
+	standardUploadQuotaFiles     = 100
 )
 
 type Config struct {
@@ -20,6 +49,23 @@ type Config struct {
 	PathKeyFile            string
 	OutputLogPath          string
 	ErrorOutputLogPath     string
+	// FileStorage is fs for local directory FileServiceDir or s3 for bucket of S3-compatible storage
+	FileStorage string
+	S3Endpoint  string
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+	URLDataBase string
+	// ImageGCGracePeriod uploaded image without owner is kept during it, so user can attach it to product
+	ImageGCPeriod      time.Duration
+	ImageGCGracePeriod time.Duration
+	ImageGCDryRun      bool
+	// AddressAuthServiceGrpc auth service checks sessions of users, who upload files
+	AddressAuthServiceGrpc string
+	// UploadQuotaBytes and UploadQuotaFiles limit uploads of one user per day
+	UploadQuotaBytes int64
+	UploadQuotaFiles int64
 }
 
 func New() *Config {
@@ -41,5 +87,18 @@ func New() *Config {
 		PathKeyFile:            config.GetEnvStr(config.EnvPathKeyFile, config.StandardPathKeyFile),
 		OutputLogPath:          config.GetEnvStr(config.EnvOutputLogPath, standardOutputLogPathFS),
 		ErrorOutputLogPath:     config.GetEnvStr(config.EnvErrorOutputLogPath, standardErrorOutputLogPathFS),
+		FileStorage:            config.GetEnvStr(envFileStorage, standardFileStorage),
+		S3Endpoint:             config.GetEnvStr(envS3Endpoint, standardS3Endpoint),
+		S3Region:               config.GetEnvStr(envS3Region, standardS3Region),
+		S3Bucket:               config.GetEnvStr(envS3Bucket, standardS3Bucket),
+		S3AccessKey:            config.GetEnvStr(envS3AccessKey, ""),
+		S3SecretKey:            config.GetEnvStr(envS3SecretKey, ""),
+		URLDataBase:            config.GetEnvStr(config.EnvURLDataBase, config.StandardURLDataBase),
+		ImageGCPeriod:          config.GetEnvDuration(envImageGCPeriod, standardImageGCPeriod),
+		ImageGCGracePeriod:     config.GetEnvDuration(envImageGCGracePeriod, standardImageGCGracePeriod),
+		ImageGCDryRun:          config.GetEnvStr(envImageGCDryRun, standardImageGCDryRun) == "true",
+		AddressAuthServiceGrpc: config.GetEnvStr(config.EnvAddressAuthServiceGrpc, config.StandardAddressAuthGrpc),
+		UploadQuotaBytes:       int64(config.GetEnvUint(envUploadQuotaBytes, standardUploadQuotaBytes, 63)), //nolint:gomnd
+		UploadQuotaFiles:       int64(config.GetEnvUint(envUploadQuotaFiles, standardUploadQuotaFiles, 63)), //nolint:gomnd
 	}
 }
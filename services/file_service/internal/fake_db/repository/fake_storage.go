@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/file_service/internal/fake_db/usecases"
+	"strings"
 	"time"
 
 	"github.com/brianvoe/gofakeit/v6"
@@ -270,6 +271,12 @@ func (f *FakeStorage) InsertImages(ctx context.Context,
 		"url", "product_id",
 	}
 
+	// images without references are deleted by garbage collector of file service
+	slReference := [][]any{}
+	columnsReference := []string{
+		"file_name", "owner_type", "owner_id",
+	}
+
 	f.Logger.Infof("start filling images")
 
 	for i := 1; i < int(maxCountProducts); i++ {
@@ -281,6 +288,7 @@ func (f *FakeStorage) InsertImages(ctx context.Context,
 
 		for _, url := range URLs {
 			slImg = append(slImg, []any{url, i})
+			slReference = append(slReference, []any{strings.TrimPrefix(url, prefixURL), "product", i})
 		}
 	}
 
@@ -296,6 +304,18 @@ func (f *FakeStorage) InsertImages(ctx context.Context,
 		return err
 	}
 
+	_, err = tx.CopyFrom(
+		ctx,
+		pgx.Identifier{"public", "image_reference"},
+		columnsReference,
+		pgx.CopyFromRows(slReference),
+	)
+	if err != nil {
+		f.Logger.Error(err)
+
+		return err
+	}
+
 	f.Logger.Infof("end filling images\n")
 
 	return nil
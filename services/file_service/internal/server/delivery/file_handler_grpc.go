@@ -10,12 +10,18 @@ import (
 	fileusecases "github.com/go-park-mail-ru/2023_2_Rabotyagi/services/file_service/internal/server/usecases"
 )
 
-var ErrImgUrlsNil = myerrors.NewErrorInternal("imgURLs == nil")
+var (
+	ErrImgUrlsNil  = myerrors.NewErrorInternal("imgURLs == nil")
+	ErrImgOwnerNil = myerrors.NewErrorInternal("imgOwner == nil")
+)
 
 var _ IFileServiceGrpc = (*fileusecases.FileServiceGrpc)(nil)
 
 type IFileServiceGrpc interface {
 	Check(ctx context.Context, files []string) ([]bool, error)
+	GetVariants(ctx context.Context, urls []string) ([]fileusecases.ImageVariantsURLs, error)
+	Attach(ctx context.Context, ownerType string, ownerID uint64, urls []string) error
+	Detach(ctx context.Context, ownerType string, ownerID uint64) error
 }
 
 type FileHandlerGrpc struct {
@@ -49,3 +55,81 @@ func (f *FileHandlerGrpc) Check(ctx context.Context, imgURLs *fileservice.ImgURL
 
 	return &fileservice.CheckedURLs{Correct: result}, nil
 }
+
+func (f *FileHandlerGrpc) GetVariants(ctx context.Context,
+	imgURLs *fileservice.ImgURLs,
+) (*fileservice.ImgVariantsList, error) {
+	logger, err := mylogger.Get()
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	logger = logger.LogReqID(ctx)
+
+	if imgURLs == nil {
+		logger.Errorln(ErrImgUrlsNil)
+
+		return nil, ErrImgUrlsNil
+	}
+
+	variantsURLs, err := f.fileService.GetVariants(ctx, imgURLs.GetUrl())
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	result := &fileservice.ImgVariantsList{Variants: make([]*fileservice.ImgVariants, len(variantsURLs))}
+
+	for i, variantURLs := range variantsURLs {
+		result.Variants[i] = &fileservice.ImgVariants{
+			Thumbnail: variantURLs.Thumbnail,
+			Card:      variantURLs.Card,
+			Full:      variantURLs.Full,
+		}
+	}
+
+	return result, nil
+}
+
+func (f *FileHandlerGrpc) Attach(ctx context.Context, imgRefs *fileservice.ImgRefs) (*fileservice.Nothing, error) {
+	logger, err := mylogger.Get()
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	logger = logger.LogReqID(ctx)
+
+	if imgRefs.GetOwner() == nil {
+		logger.Errorln(ErrImgOwnerNil)
+
+		return nil, ErrImgOwnerNil
+	}
+
+	err = f.fileService.Attach(ctx, imgRefs.GetOwner().GetType(), imgRefs.GetOwner().GetId(), imgRefs.GetUrl())
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return &fileservice.Nothing{}, nil
+}
+
+func (f *FileHandlerGrpc) Detach(ctx context.Context, imgOwner *fileservice.ImgOwner) (*fileservice.Nothing, error) {
+	logger, err := mylogger.Get()
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	logger = logger.LogReqID(ctx)
+
+	if imgOwner == nil {
+		logger.Errorln(ErrImgOwnerNil)
+
+		return nil, ErrImgOwnerNil
+	}
+
+	err = f.fileService.Detach(ctx, imgOwner.GetType(), imgOwner.GetId())
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return &fileservice.Nothing{}, nil
+}
@@ -2,21 +2,23 @@ package delivery
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"path"
+	"strings"
 
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/auth"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses/statuses"
 	fileusecases "github.com/go-park-mail-ru/2023_2_Rabotyagi/services/file_service/internal/server/usecases"
-	"google.golang.org/grpc/metadata"
 )
 
 const (
-	MaxSizePhotoBytes = 5 * 1024 * 1024
-	MaxCountPhoto     = 4
+	MaxCountPhoto = 4
 
 	NameImagesInForm = "images"
 
@@ -28,8 +30,6 @@ type keyCtx string
 const keyCtxHandler keyCtx = "handler"
 
 var (
-	ErrToBigFile = myerrors.NewErrorBadContentRequest("Максимальный размер фото %d Мбайт",
-		MaxSizePhotoBytes%10204%1024) //nolint:gomnd
 	ErrToManyCountFiles   = myerrors.NewErrorBadContentRequest("Максимальное количество фото = %d", MaxCountPhoto)
 	ErrForbiddenRootPath  = myerrors.NewErrorBadContentRequest("Нельзя вызывать корневой путь")
 	ErrWrongNameMultipart = myerrors.NewErrorBadFormatRequest("в multipart/form нет нужного имени: %s", NameImagesInForm)
@@ -38,28 +38,59 @@ var (
 var _ IFileServiceHTTP = (*fileusecases.FileServiceHTTP)(nil)
 
 type IFileServiceHTTP interface {
-	SaveImage(ctx context.Context, r io.Reader) (string, error)
+	SaveImage(ctx context.Context, userID uint64, r io.Reader) (string, error)
 }
 
 type FileHandlerHTTP struct {
-	fileServiceDir string
+	fileSystem     http.FileSystem
 	fileService    IFileServiceHTTP
+	sessionManager auth.SessionMangerClient
 	logger         *mylogger.MyLogger
 }
 
+// NewFileHandlerHTTP fileSystem gives access to files of storage, local directory or bucket of S3.
+// sessionManager checks sessions of users, who upload files.
 func NewFileHandlerHTTP(fileService IFileServiceHTTP,
-	logger *mylogger.MyLogger, fileServiceDir string,
+	logger *mylogger.MyLogger, fileSystem http.FileSystem, sessionManager auth.SessionMangerClient,
 ) *FileHandlerHTTP {
 	return &FileHandlerHTTP{
 		fileService: fileService, logger: logger,
-		fileServiceDir: fileServiceDir,
+		fileSystem: fileSystem, sessionManager: sessionManager,
 	}
 }
 
+// getUserID checks session in cookie by auth service.
+func (f *FileHandlerHTTP) getUserID(r *http.Request) (uint64, error) {
+	ctx := r.Context()
+	logger := f.logger.LogReqID(ctx)
+
+	cookie, err := r.Cookie(responses.CookieAuthName)
+	if err != nil {
+		logger.Errorln(err)
+
+		if errors.Is(err, http.ErrNoCookie) {
+			err = responses.ErrCookieNotPresented
+		}
+
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	userID, err := f.sessionManager.Check(ctx, &auth.Session{AccessToken: cookie.Value})
+	if err != nil {
+		logger.Errorln(err)
+
+		return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return userID.GetUserId(), nil
+}
+
 // UploadFileHandler godoc
 //
 //	@Summary    upload photo
-//	@Description  upload photo to file service and return its url
+//	@Description  upload photo to file service and return its url. Only for authorized users,
+//	@Description  bytes and count of photos uploaded by user per day are limited.
+//	@Description  Files are read from body one by one without buffering of whole form
 //
 //	@Tags fileService
 //
@@ -70,8 +101,8 @@ func NewFileHandlerHTTP(fileService IFileServiceHTTP,
 //	@Failure    500  {string} string
 //	@Failure    222  {object} responses.ErrorResponse "Тут статус http статус 200. Внутри body статус может быть badContent(4400), badFormat(4000)"//nolint:lll
 //	@Router      /img/upload [post]
-func (f *FileHandlerHTTP) UploadFileHandler(w http.ResponseWriter, r *http.Request) { //nolint:funlen
-	r.Body = http.MaxBytesReader(w, r.Body, MaxSizePhotoBytes*MaxCountPhoto)
+func (f *FileHandlerHTTP) UploadFileHandler(w http.ResponseWriter, r *http.Request) { //nolint:funlen,cyclop
+	r.Body = http.MaxBytesReader(w, r.Body, fileusecases.MaxSizePhotoBytes*MaxCountPhoto)
 	if r.Method != http.MethodPost {
 		http.Error(w, `Method not allowed`, http.StatusMethodNotAllowed)
 
@@ -81,62 +112,69 @@ func (f *FileHandlerHTTP) UploadFileHandler(w http.ResponseWriter, r *http.Reque
 	ctx := r.Context()
 	logger := f.logger.LogReqID(ctx)
 
-	err := r.ParseMultipartForm(MaxSizePhotoBytes)
+	userID, err := f.getUserID(r)
 	if err != nil {
-		logger.Errorln(err)
-		responses.HandleErr(w, r, logger, myerrors.NewErrorBadFormatRequest(err.Error()))
+		responses.HandleErr(w, r, logger, err)
 
 		return
 	}
 
-	slFiles, ok := r.MultipartForm.File[NameImagesInForm]
-	if !ok {
+	multipartReader, err := r.MultipartReader()
+	if err != nil {
 		logger.Errorln(err)
-		responses.HandleErr(w, r, logger, ErrWrongNameMultipart)
-
-		return
-	}
-
-	if len(slFiles) > MaxCountPhoto {
-		logger.Errorln(ErrToManyCountFiles)
-		responses.HandleErr(w, r, logger, ErrToManyCountFiles)
+		responses.HandleErr(w, r, logger, myerrors.NewErrorBadFormatRequest(err.Error()))
 
 		return
 	}
 
-	slURL := make([]string, len(slFiles))
+	slURL := make([]string, 0, MaxCountPhoto)
 
-	for idxFile, file := range slFiles {
-		if file.Size > MaxSizePhotoBytes {
-			err := myerrors.NewErrorBadContentRequest(
-				"файл: %s весит %d Мбайт. %+v\n", file.Filename, file.Size/1024/1024, ErrToBigFile.Error()) //nolint:gomnd
+	for {
+		part, err := multipartReader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
 
+		if err != nil {
 			logger.Errorln(err)
-			responses.HandleErr(w, r, logger, err)
+			responses.HandleErr(w, r, logger, myerrors.NewErrorBadFormatRequest(err.Error()))
 
 			return
 		}
 
-		fileBody, err := file.Open()
-		if err != nil {
-			logger.Errorln(err)
-			responses.SendResponse(w, logger,
-				responses.NewErrResponse(statuses.StatusInternalServer, responses.ErrInternalServer))
+		if part.FormName() != NameImagesInForm {
+			part.Close()
+
+			continue
+		}
+
+		// count of files is known only after reading of previous ones, they are already saved
+		if len(slURL) == MaxCountPhoto {
+			logger.Errorln(ErrToManyCountFiles)
+			responses.HandleErr(w, r, logger, ErrToManyCountFiles)
 
 			return
 		}
 
-		metadata.NewOutgoingContext(ctx, metadata.Pairs())
+		URLToFile, err := f.fileService.SaveImage(ctx, userID, part)
+		part.Close()
 
-		URLToFile, err := f.fileService.SaveImage(ctx, fileBody)
 		if err != nil {
 			logger.Errorln(err)
-			responses.HandleErr(w, r, logger, err)
+			responses.HandleErr(w, r, logger,
+				fmt.Errorf("файл %s: %w", part.FileName(), err))
 
 			return
 		}
 
-		slURL[idxFile] = URLToFile
+		slURL = append(slURL, URLToFile)
+	}
+
+	if len(slURL) == 0 {
+		logger.Errorln(ErrWrongNameMultipart)
+		responses.HandleErr(w, r, logger, ErrWrongNameMultipart)
+
+		return
 	}
 
 	responses.SendResponse(w, logger, NewResponseURLs(slURL))
@@ -149,7 +187,8 @@ func (f *FileHandlerHTTP) UploadFileHandler(w http.ResponseWriter, r *http.Reque
 // fileServerHandler godoc
 //
 //	@Summary    download photo
-//	@Description  download photo of file by its name
+//	@Description  download photo of file by its name. Reduced copies are available by suffix
+//	@Description  _thumbnail (200px) and _card (600px), original is returned if copy doesn't exist
 //
 //	@Tags fileService
 //
@@ -194,10 +233,37 @@ func (f *FileHandlerHTTP) fileServerHandler(w http.ResponseWriter, r *http.Reque
 	fileServer.ServeHTTP(w, r)
 }
 
+// originalIfNoVariant replaces path to variant, which doesn't exist, by path to original.
+// So variants can be requested for images uploaded before generation of variants.
+func (f *FileHandlerHTTP) originalIfNoVariant(r *http.Request) *http.Request {
+	name := path.Base(r.URL.Path)
+
+	originalName := fileusecases.OriginalName(name)
+	if originalName == name {
+		return r
+	}
+
+	if file, err := f.fileSystem.Open("/" + name); err == nil {
+		file.Close()
+
+		return r
+	}
+
+	originalURL := *r.URL
+	originalURL.Path = strings.TrimSuffix(r.URL.Path, name) + originalName
+	originalURL.RawPath = ""
+
+	r = r.WithContext(r.Context())
+	r.URL = &originalURL
+
+	return r
+}
+
 func (f *FileHandlerHTTP) DocFileServerHandler() http.Handler {
-	fileServer := http.StripPrefix("/img/", http.FileServer(http.Dir(f.fileServiceDir)))
+	fileServer := http.StripPrefix("/img/", http.FileServer(f.fileSystem))
 
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r = f.originalIfNoVariant(r)
 		r = r.WithContext(context.WithValue(r.Context(), keyCtxHandler, fileServer))
 
 		f.fileServerHandler(w, r)
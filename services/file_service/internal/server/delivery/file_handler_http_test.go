@@ -1,6 +1,7 @@
 package delivery_test
 
 import (
+	"context"
 	"image"
 	"image/png"
 	"io"
@@ -9,6 +10,8 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/auth"
+	authmocks "github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/auth/mocks"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/responses"
@@ -16,136 +19,157 @@ import (
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils/test"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/file_service/internal/server/delivery"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/file_service/internal/server/mocks"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/file_service/internal/server/repository"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/file_service/internal/server/repository/fakes3"
 	"go.uber.org/mock/gomock"
 )
 
 func NewFileHandlerHTTP(ctrl *gomock.Controller,
-	behaviorSessionManagerClient func(m *mocks.MockIFileServiceHTTP),
+	behaviorFileServiceHTTP func(m *mocks.MockIFileServiceHTTP),
+	behaviorSessionManagerClient func(m *authmocks.MockSessionMangerClient),
 ) *delivery.FileHandlerHTTP {
 	mockFileServiceHTTP := mocks.NewMockIFileServiceHTTP(ctrl)
+	mockSessionManagerClient := authmocks.NewMockSessionMangerClient(ctrl)
 
-	behaviorSessionManagerClient(mockFileServiceHTTP)
+	behaviorFileServiceHTTP(mockFileServiceHTTP)
+	behaviorSessionManagerClient(mockSessionManagerClient)
 
-	fileHandler := delivery.NewFileHandlerHTTP(mockFileServiceHTTP, mylogger.NewNop(), ".")
+	fileHandler := delivery.NewFileHandlerHTTP(mockFileServiceHTTP, mylogger.NewNop(), http.Dir("."),
+		mockSessionManagerClient)
 
 	return fileHandler
 }
 
+// newUploadRequest returns request with countFiles png images in form field fieldName.
+func newUploadRequest(t *testing.T, fieldName string, countFiles int) *http.Request {
+	t.Helper()
+
+	pipeReader, pipeWriter := io.Pipe()
+	formWriter := multipart.NewWriter(pipeWriter)
+
+	go func() {
+		defer pipeWriter.Close()
+		defer formWriter.Close()
+
+		for i := 0; i < countFiles; i++ {
+			part, err := formWriter.CreateFormFile(fieldName, "test.png")
+			if err != nil {
+				t.Error(err)
+
+				return
+			}
+
+			img := image.NewNRGBA(image.Rect(0, 0, 10, 10))
+
+			err = png.Encode(part, img)
+			if err != nil {
+				t.Error(err)
+
+				return
+			}
+		}
+	}()
+
+	req := httptest.NewRequest(http.MethodPost, "/img/upload", pipeReader)
+	req.Header.Set("Content-Type", formWriter.FormDataContentType())
+	req.AddCookie(&http.Cookie{Name: responses.CookieAuthName, Value: test.AccessToken}) //nolint:exhaustruct
+
+	return req
+}
+
+func behaviorCorrectSession(m *authmocks.MockSessionMangerClient) {
+	m.EXPECT().Check(gomock.Any(), &auth.Session{AccessToken: test.AccessToken}).
+		Return(&auth.UserID{UserId: test.UserID}, nil)
+}
+
 func TestUploadFile(t *testing.T) {
 	t.Parallel()
 
 	_ = mylogger.NewNop()
 
 	type TestCase struct {
-		name                    string
-		behaviorFileServiceHTTP func(m *mocks.MockIFileServiceHTTP)
-		request                 *http.Request
-		expectedResponse        any
+		name                         string
+		behaviorFileServiceHTTP      func(m *mocks.MockIFileServiceHTTP)
+		behaviorSessionManagerClient func(m *authmocks.MockSessionMangerClient)
+		request                      *http.Request
+		expectedResponse             any
 	}
 
 	testCases := [...]TestCase{
 		{
-			name:                    "method not allowed",
-			request:                 httptest.NewRequest(http.MethodGet, "/img/upload", nil),
-			behaviorFileServiceHTTP: func(m *mocks.MockIFileServiceHTTP) {},
-			expectedResponse:        "Method not allowed\n",
+			name:                         "method not allowed",
+			request:                      httptest.NewRequest(http.MethodGet, "/img/upload", nil),
+			behaviorFileServiceHTTP:      func(m *mocks.MockIFileServiceHTTP) {},
+			behaviorSessionManagerClient: func(m *authmocks.MockSessionMangerClient) {},
+			expectedResponse:             "Method not allowed\n",
 		},
 		{
-			name:                    "wrong content type",
-			request:                 httptest.NewRequest(http.MethodPost, "/img/upload", nil),
-			behaviorFileServiceHTTP: func(m *mocks.MockIFileServiceHTTP) {},
-			expectedResponse: responses.NewErrResponse(statuses.StatusBadFormatRequest,
-				"request Content-Type isn't multipart/form-data"),
+			name:                         "without cookie",
+			request:                      httptest.NewRequest(http.MethodPost, "/img/upload", nil),
+			behaviorFileServiceHTTP:      func(m *mocks.MockIFileServiceHTTP) {},
+			behaviorSessionManagerClient: func(m *authmocks.MockSessionMangerClient) {},
+			expectedResponse: responses.NewErrResponse(responses.ErrCookieNotPresented.Status(),
+				responses.ErrCookieNotPresented.Error()),
 		},
 		{
-			name: "upload test image",
+			name: "wrong content type",
 			request: func() *http.Request {
-				pipeReader, pipeWriter := io.Pipe()
-				formWriter := multipart.NewWriter(pipeWriter)
-
-				go func() {
-					defer formWriter.Close()
-					part, err := formWriter.CreateFormFile(delivery.NameImagesInForm, "test.png")
-					if err != nil {
-						t.Error(err)
-					}
-
-					img := image.NewNRGBA(image.Rect(0, 0, 10, 10))
-
-					err = png.Encode(part, img)
-					if err != nil {
-						t.Error(err)
-					}
-				}()
-
-				req := httptest.NewRequest(http.MethodPost, "/img/upload", pipeReader)
-				req.Header.Set("Content-Type", formWriter.FormDataContentType())
+				req := httptest.NewRequest(http.MethodPost, "/img/upload", nil)
+				req.AddCookie(&http.Cookie{Name: responses.CookieAuthName, Value: test.AccessToken}) //nolint:exhaustruct
 
 				return req
 			}(),
+			behaviorFileServiceHTTP:      func(m *mocks.MockIFileServiceHTTP) {},
+			behaviorSessionManagerClient: behaviorCorrectSession,
+			expectedResponse: responses.NewErrResponse(statuses.StatusBadFormatRequest,
+				"request Content-Type isn't multipart/form-data"),
+		},
+		{
+			name:    "upload test image",
+			request: newUploadRequest(t, delivery.NameImagesInForm, 1),
 			behaviorFileServiceHTTP: func(m *mocks.MockIFileServiceHTTP) {
-				m.EXPECT().SaveImage(gomock.Any(), gomock.Not(nil)).Return("test_url", nil)
+				m.EXPECT().SaveImage(gomock.Any(), test.UserID, gomock.Not(nil)).Return("test_url", nil)
 			},
-			expectedResponse: delivery.NewResponseURLs([]string{"test_url"}),
+			behaviorSessionManagerClient: behaviorCorrectSession,
+			expectedResponse:             delivery.NewResponseURLs([]string{"test_url"}),
 		},
 		{
-			name: "internal error",
-			request: func() *http.Request {
-				pipeReader, pipeWriter := io.Pipe()
-				formWriter := multipart.NewWriter(pipeWriter)
-
-				go func() {
-					defer formWriter.Close()
-					part, err := formWriter.CreateFormFile(delivery.NameImagesInForm, "test.png")
-					if err != nil {
-						t.Error(err)
-					}
-
-					img := image.NewNRGBA(image.Rect(0, 0, 10, 10))
-
-					err = png.Encode(part, img)
-					if err != nil {
-						t.Error(err)
-					}
-				}()
-
-				req := httptest.NewRequest(http.MethodPost, "/img/upload", pipeReader)
-				req.Header.Set("Content-Type", formWriter.FormDataContentType())
-
-				return req
-			}(),
+			name:    "internal error",
+			request: newUploadRequest(t, delivery.NameImagesInForm, 1),
 			behaviorFileServiceHTTP: func(m *mocks.MockIFileServiceHTTP) {
-				m.EXPECT().SaveImage(gomock.Any(), gomock.Not(nil)).Return("", myerrors.NewErrorInternal("Test err"))
+				m.EXPECT().SaveImage(gomock.Any(), test.UserID, gomock.Not(nil)).
+					Return("", myerrors.NewErrorInternal("Test err"))
 			},
-			expectedResponse: responses.NewErrResponse(statuses.StatusInternalServer, responses.ErrInternalServer),
+			behaviorSessionManagerClient: behaviorCorrectSession,
+			expectedResponse:             responses.NewErrResponse(statuses.StatusInternalServer, responses.ErrInternalServer),
 		},
 		{
-			name: "form with wrong field-name",
-			request: func() *http.Request {
-				pipeReader, pipeWriter := io.Pipe()
-				formWriter := multipart.NewWriter(pipeWriter)
-
-				go func() {
-					defer formWriter.Close()
-					part, err := formWriter.CreateFormFile("wrong field-name", "test.png")
-					if err != nil {
-						t.Error(err)
-					}
-
-					img := image.NewNRGBA(image.Rect(0, 0, 10, 10))
-
-					err = png.Encode(part, img)
-					if err != nil {
-						t.Error(err)
-					}
-				}()
-
-				req := httptest.NewRequest(http.MethodPost, "/img/upload", pipeReader)
-				req.Header.Set("Content-Type", formWriter.FormDataContentType())
-
-				return req
-			}(),
-			behaviorFileServiceHTTP: func(m *mocks.MockIFileServiceHTTP) {},
+			name:    "quota exceeded",
+			request: newUploadRequest(t, delivery.NameImagesInForm, 1),
+			behaviorFileServiceHTTP: func(m *mocks.MockIFileServiceHTTP) {
+				m.EXPECT().SaveImage(gomock.Any(), test.UserID, gomock.Not(nil)).
+					Return("", repository.ErrUploadQuotaExceeded)
+			},
+			behaviorSessionManagerClient: behaviorCorrectSession,
+			expectedResponse: responses.NewErrResponse(repository.ErrUploadQuotaExceeded.Status(),
+				"файл test.png: "+repository.ErrUploadQuotaExceeded.Error()),
+		},
+		{
+			name:    "too many files",
+			request: newUploadRequest(t, delivery.NameImagesInForm, delivery.MaxCountPhoto+1),
+			behaviorFileServiceHTTP: func(m *mocks.MockIFileServiceHTTP) {
+				m.EXPECT().SaveImage(gomock.Any(), test.UserID, gomock.Not(nil)).
+					Return("test_url", nil).Times(delivery.MaxCountPhoto)
+			},
+			behaviorSessionManagerClient: behaviorCorrectSession,
+			expectedResponse: responses.NewErrResponse(delivery.ErrToManyCountFiles.Status(),
+				delivery.ErrToManyCountFiles.Error()),
+		},
+		{
+			name:                         "form with wrong field-name",
+			request:                      newUploadRequest(t, "wrong field-name", 1),
+			behaviorFileServiceHTTP:      func(m *mocks.MockIFileServiceHTTP) {},
+			behaviorSessionManagerClient: behaviorCorrectSession,
 			expectedResponse: responses.NewErrResponse(delivery.ErrWrongNameMultipart.Status(),
 				delivery.ErrWrongNameMultipart.Error()),
 		},
@@ -160,7 +184,8 @@ func TestUploadFile(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			profileHandler := NewFileHandlerHTTP(ctrl, testCase.behaviorFileServiceHTTP)
+			profileHandler := NewFileHandlerHTTP(ctrl, testCase.behaviorFileServiceHTTP,
+				testCase.behaviorSessionManagerClient)
 
 			w := httptest.NewRecorder()
 
@@ -213,7 +238,8 @@ func TestDocFileHandler(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			profileHandler := NewFileHandlerHTTP(ctrl, func(m *mocks.MockIFileServiceHTTP) {})
+			profileHandler := NewFileHandlerHTTP(ctrl, func(m *mocks.MockIFileServiceHTTP) {},
+				func(m *authmocks.MockSessionMangerClient) {})
 			docFileServer := profileHandler.DocFileServerHandler()
 
 			w := httptest.NewRecorder()
@@ -227,3 +253,96 @@ func TestDocFileHandler(t *testing.T) {
 		})
 	}
 }
+
+// newFileSystems returns both backends of storage with the same files.
+func newFileSystems(t *testing.T, files map[string]string) map[string]http.FileSystem {
+	t.Helper()
+
+	fileSystemStorage, err := repository.NewFileSystemStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected err=%+v", err)
+	}
+
+	fakeS3 := fakes3.NewServer("images", "access")
+	t.Cleanup(fakeS3.Close)
+
+	s3Storage, err := repository.NewS3Storage(fakeS3.URL, "images", "us-east-1",
+		repository.S3Credentials{AccessKey: "access", SecretKey: "secret"})
+	if err != nil {
+		t.Fatalf("unexpected err=%+v", err)
+	}
+
+	for name, content := range files {
+		for _, storage := range []interface {
+			SaveFile(ctx context.Context, content []byte, fileName string) error
+		}{fileSystemStorage, s3Storage} {
+			if err := storage.SaveFile(context.Background(), []byte(content), name); err != nil {
+				t.Fatalf("unexpected err=%+v", err)
+			}
+		}
+	}
+
+	return map[string]http.FileSystem{"fs": fileSystemStorage.FileSystem(), "s3": s3Storage.FileSystem()}
+}
+
+func TestDocFileHandlerVariant(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	fileSystems := newFileSystems(t, map[string]string{
+		"with_variants":           "full",
+		"with_variants_thumbnail": "thumbnail",
+		"without_variants":        "original",
+	})
+
+	type TestCase struct {
+		name           string
+		path           string
+		expectedStatus int
+		expectedBody   string
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test existing variant", path: "/img/with_variants_thumbnail",
+			expectedStatus: http.StatusOK, expectedBody: "thumbnail",
+		},
+		{name: "test full variant", path: "/img/with_variants", expectedStatus: http.StatusOK, expectedBody: "full"},
+		{
+			name: "test original instead of variant", path: "/img/without_variants_card",
+			expectedStatus: http.StatusOK, expectedBody: "original",
+		},
+		{
+			name: "test not found", path: "/img/not_exist",
+			expectedStatus: http.StatusNotFound, expectedBody: "404 page not found\n",
+		},
+	}
+
+	for backend, fileSystem := range fileSystems {
+		for _, testCase := range testCases {
+			testCase := testCase
+			fileSystem := fileSystem
+
+			t.Run(backend+" "+testCase.name, func(t *testing.T) {
+				t.Parallel()
+
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				fileHandler := delivery.NewFileHandlerHTTP(mocks.NewMockIFileServiceHTTP(ctrl),
+					mylogger.NewNop(), fileSystem, authmocks.NewMockSessionMangerClient(ctrl))
+
+				w := httptest.NewRecorder()
+
+				fileHandler.DocFileServerHandler().ServeHTTP(w,
+					httptest.NewRequest(http.MethodGet, testCase.path, nil))
+
+				if w.Code != testCase.expectedStatus || w.Body.String() != testCase.expectedBody {
+					t.Fatalf("got %d %q, expected %d %q", w.Code, w.Body.String(),
+						testCase.expectedStatus, testCase.expectedBody)
+				}
+			})
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/auth"
 	pkgdelivery "github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/delivery"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/metrics"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/middleware"
@@ -16,29 +17,27 @@ type ConfigMux struct {
 	allowOrigin     string
 	schema          string
 	portServer      string
-	fileServiceDir  string
 	fileServiceName string
 }
 
 func NewConfigMux(allowOrigin string,
-	schema string, portServer string, fileServiceDir string, fileServiceName string,
+	schema string, portServer string, fileServiceName string,
 ) *ConfigMux {
 	return &ConfigMux{
 		allowOrigin:     allowOrigin,
 		schema:          schema,
 		portServer:      portServer,
-		fileServiceDir:  fileServiceDir,
 		fileServiceName: fileServiceName,
 	}
 }
 
 func NewMux(ctx context.Context, configMux *ConfigMux,
-	fileServiceHTTP delivery.IFileServiceHTTP,
-	logger *mylogger.MyLogger,
+	fileServiceHTTP delivery.IFileServiceHTTP, fileSystem http.FileSystem,
+	sessionManager auth.SessionMangerClient, logger *mylogger.MyLogger,
 ) (http.Handler, error) {
 	router := http.NewServeMux()
 
-	fileHandler := delivery.NewFileHandlerHTTP(fileServiceHTTP, logger, configMux.fileServiceDir)
+	fileHandler := delivery.NewFileHandlerHTTP(fileServiceHTTP, logger, fileSystem, sessionManager)
 
 	router.Handle("/img/", fileHandler.DocFileServerHandler())
 	router.Handle("/img/upload", middleware.Context(ctx,
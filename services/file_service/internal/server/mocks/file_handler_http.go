@@ -40,16 +40,16 @@ func (m *MockIFileServiceHTTP) EXPECT() *MockIFileServiceHTTPMockRecorder {
 }
 
 // SaveImage mocks base method.
-func (m *MockIFileServiceHTTP) SaveImage(ctx context.Context, r io.Reader) (string, error) {
+func (m *MockIFileServiceHTTP) SaveImage(ctx context.Context, userID uint64, r io.Reader) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveImage", ctx, r)
+	ret := m.ctrl.Call(m, "SaveImage", ctx, userID, r)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SaveImage indicates an expected call of SaveImage.
-func (mr *MockIFileServiceHTTPMockRecorder) SaveImage(ctx, r any) *gomock.Call {
+func (mr *MockIFileServiceHTTPMockRecorder) SaveImage(ctx, userID, r any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveImage", reflect.TypeOf((*MockIFileServiceHTTP)(nil).SaveImage), ctx, r)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveImage", reflect.TypeOf((*MockIFileServiceHTTP)(nil).SaveImage), ctx, userID, r)
 }
@@ -0,0 +1,105 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/file_service/internal/server/usecases/file_service_grpc.go
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -source=services/file_service/internal/server/usecases/file_service_grpc.go -destination=services/file_service/internal/server/mocks/file_service_grpc.go
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIFileStorageGrpc is a mock of IFileStorageGrpc interface.
+type MockIFileStorageGrpc struct {
+	ctrl     *gomock.Controller
+	recorder *MockIFileStorageGrpcMockRecorder
+}
+
+// MockIFileStorageGrpcMockRecorder is the mock recorder for MockIFileStorageGrpc.
+type MockIFileStorageGrpcMockRecorder struct {
+	mock *MockIFileStorageGrpc
+}
+
+// NewMockIFileStorageGrpc creates a new mock instance.
+func NewMockIFileStorageGrpc(ctrl *gomock.Controller) *MockIFileStorageGrpc {
+	mock := &MockIFileStorageGrpc{ctrl: ctrl}
+	mock.recorder = &MockIFileStorageGrpcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIFileStorageGrpc) EXPECT() *MockIFileStorageGrpcMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockIFileStorageGrpc) Check(ctx context.Context, files []string) ([]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, files)
+	ret0, _ := ret[0].([]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockIFileStorageGrpcMockRecorder) Check(ctx, files any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockIFileStorageGrpc)(nil).Check), ctx, files)
+}
+
+// MockIImageReferenceStorage is a mock of IImageReferenceStorage interface.
+type MockIImageReferenceStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockIImageReferenceStorageMockRecorder
+}
+
+// MockIImageReferenceStorageMockRecorder is the mock recorder for MockIImageReferenceStorage.
+type MockIImageReferenceStorageMockRecorder struct {
+	mock *MockIImageReferenceStorage
+}
+
+// NewMockIImageReferenceStorage creates a new mock instance.
+func NewMockIImageReferenceStorage(ctrl *gomock.Controller) *MockIImageReferenceStorage {
+	mock := &MockIImageReferenceStorage{ctrl: ctrl}
+	mock.recorder = &MockIImageReferenceStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIImageReferenceStorage) EXPECT() *MockIImageReferenceStorageMockRecorder {
+	return m.recorder
+}
+
+// Attach mocks base method.
+func (m *MockIImageReferenceStorage) Attach(ctx context.Context, ownerType string, ownerID uint64, fileNames []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attach", ctx, ownerType, ownerID, fileNames)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Attach indicates an expected call of Attach.
+func (mr *MockIImageReferenceStorageMockRecorder) Attach(ctx, ownerType, ownerID, fileNames any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attach", reflect.TypeOf((*MockIImageReferenceStorage)(nil).Attach), ctx, ownerType, ownerID, fileNames)
+}
+
+// Detach mocks base method.
+func (m *MockIImageReferenceStorage) Detach(ctx context.Context, ownerType string, ownerID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Detach", ctx, ownerType, ownerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Detach indicates an expected call of Detach.
+func (mr *MockIImageReferenceStorageMockRecorder) Detach(ctx, ownerType, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Detach", reflect.TypeOf((*MockIImageReferenceStorage)(nil).Detach), ctx, ownerType, ownerID)
+}
@@ -0,0 +1,104 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/file_service/internal/server/usecases/file_service_http.go
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -source=services/file_service/internal/server/usecases/file_service_http.go -destination=services/file_service/internal/server/mocks/file_service_http.go
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIFileStorageHTTP is a mock of IFileStorageHTTP interface.
+type MockIFileStorageHTTP struct {
+	ctrl     *gomock.Controller
+	recorder *MockIFileStorageHTTPMockRecorder
+}
+
+// MockIFileStorageHTTPMockRecorder is the mock recorder for MockIFileStorageHTTP.
+type MockIFileStorageHTTPMockRecorder struct {
+	mock *MockIFileStorageHTTP
+}
+
+// NewMockIFileStorageHTTP creates a new mock instance.
+func NewMockIFileStorageHTTP(ctrl *gomock.Controller) *MockIFileStorageHTTP {
+	mock := &MockIFileStorageHTTP{ctrl: ctrl}
+	mock.recorder = &MockIFileStorageHTTPMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIFileStorageHTTP) EXPECT() *MockIFileStorageHTTPMockRecorder {
+	return m.recorder
+}
+
+// SaveFile mocks base method.
+func (m *MockIFileStorageHTTP) SaveFile(ctx context.Context, content []byte, fileName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveFile", ctx, content, fileName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveFile indicates an expected call of SaveFile.
+func (mr *MockIFileStorageHTTPMockRecorder) SaveFile(ctx, content, fileName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveFile", reflect.TypeOf((*MockIFileStorageHTTP)(nil).SaveFile), ctx, content, fileName)
+}
+
+// MockIUploadQuotaStorage is a mock of IUploadQuotaStorage interface.
+type MockIUploadQuotaStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUploadQuotaStorageMockRecorder
+}
+
+// MockIUploadQuotaStorageMockRecorder is the mock recorder for MockIUploadQuotaStorage.
+type MockIUploadQuotaStorageMockRecorder struct {
+	mock *MockIUploadQuotaStorage
+}
+
+// NewMockIUploadQuotaStorage creates a new mock instance.
+func NewMockIUploadQuotaStorage(ctrl *gomock.Controller) *MockIUploadQuotaStorage {
+	mock := &MockIUploadQuotaStorage{ctrl: ctrl}
+	mock.recorder = &MockIUploadQuotaStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUploadQuotaStorage) EXPECT() *MockIUploadQuotaStorageMockRecorder {
+	return m.recorder
+}
+
+// Release mocks base method.
+func (m *MockIUploadQuotaStorage) Release(ctx context.Context, userID uint64, size int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, userID, size)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockIUploadQuotaStorageMockRecorder) Release(ctx, userID, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockIUploadQuotaStorage)(nil).Release), ctx, userID, size)
+}
+
+// Reserve mocks base method.
+func (m *MockIUploadQuotaStorage) Reserve(ctx context.Context, userID uint64, size, maxBytes, maxFiles int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, userID, size, maxBytes, maxFiles)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockIUploadQuotaStorageMockRecorder) Reserve(ctx, userID, size, maxBytes, maxFiles any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockIUploadQuotaStorage)(nil).Reserve), ctx, userID, size, maxBytes, maxFiles)
+}
@@ -0,0 +1,107 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/file_service/internal/server/usecases/image_sweeper.go
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -source=services/file_service/internal/server/usecases/image_sweeper.go -destination=services/file_service/internal/server/mocks/image_sweeper.go
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	repository "github.com/go-park-mail-ru/2023_2_Rabotyagi/services/file_service/internal/server/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIFileStorageSweeper is a mock of IFileStorageSweeper interface.
+type MockIFileStorageSweeper struct {
+	ctrl     *gomock.Controller
+	recorder *MockIFileStorageSweeperMockRecorder
+}
+
+// MockIFileStorageSweeperMockRecorder is the mock recorder for MockIFileStorageSweeper.
+type MockIFileStorageSweeperMockRecorder struct {
+	mock *MockIFileStorageSweeper
+}
+
+// NewMockIFileStorageSweeper creates a new mock instance.
+func NewMockIFileStorageSweeper(ctrl *gomock.Controller) *MockIFileStorageSweeper {
+	mock := &MockIFileStorageSweeper{ctrl: ctrl}
+	mock.recorder = &MockIFileStorageSweeperMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIFileStorageSweeper) EXPECT() *MockIFileStorageSweeperMockRecorder {
+	return m.recorder
+}
+
+// DeleteFile mocks base method.
+func (m *MockIFileStorageSweeper) DeleteFile(ctx context.Context, fileName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFile", ctx, fileName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFile indicates an expected call of DeleteFile.
+func (mr *MockIFileStorageSweeperMockRecorder) DeleteFile(ctx, fileName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFile", reflect.TypeOf((*MockIFileStorageSweeper)(nil).DeleteFile), ctx, fileName)
+}
+
+// List mocks base method.
+func (m *MockIFileStorageSweeper) List(ctx context.Context) ([]repository.StoredFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]repository.StoredFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockIFileStorageSweeperMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIFileStorageSweeper)(nil).List), ctx)
+}
+
+// MockIImageReferenceStorageSweeper is a mock of IImageReferenceStorageSweeper interface.
+type MockIImageReferenceStorageSweeper struct {
+	ctrl     *gomock.Controller
+	recorder *MockIImageReferenceStorageSweeperMockRecorder
+}
+
+// MockIImageReferenceStorageSweeperMockRecorder is the mock recorder for MockIImageReferenceStorageSweeper.
+type MockIImageReferenceStorageSweeperMockRecorder struct {
+	mock *MockIImageReferenceStorageSweeper
+}
+
+// NewMockIImageReferenceStorageSweeper creates a new mock instance.
+func NewMockIImageReferenceStorageSweeper(ctrl *gomock.Controller) *MockIImageReferenceStorageSweeper {
+	mock := &MockIImageReferenceStorageSweeper{ctrl: ctrl}
+	mock.recorder = &MockIImageReferenceStorageSweeperMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIImageReferenceStorageSweeper) EXPECT() *MockIImageReferenceStorageSweeperMockRecorder {
+	return m.recorder
+}
+
+// GetReferenced mocks base method.
+func (m *MockIImageReferenceStorageSweeper) GetReferenced(ctx context.Context, fileNames []string) (map[string]struct{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReferenced", ctx, fileNames)
+	ret0, _ := ret[0].(map[string]struct{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReferenced indicates an expected call of GetReferenced.
+func (mr *MockIImageReferenceStorageSweeperMockRecorder) GetReferenced(ctx, fileNames any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReferenced", reflect.TypeOf((*MockIImageReferenceStorageSweeper)(nil).GetReferenced), ctx, fileNames)
+}
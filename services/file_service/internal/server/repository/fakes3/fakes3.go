@@ -0,0 +1,219 @@
+// Package fakes3 is in-process stand-in for S3-compatible storage, it's used in tests instead of MinIO.
+package fakes3
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type object struct {
+	content []byte
+	modTime time.Time
+}
+
+// Server supports PUT, HEAD, GET and DELETE of objects by path-style urls: /bucket/key
+// and ListObjectsV2 by /bucket/?list-type=2. Listing returns MaxKeys objects per page.
+// Requests without signature of AccessKey or with wrong hash of payload are rejected with 403.
+type Server struct {
+	*httptest.Server
+
+	Bucket    string
+	AccessKey string
+	MaxKeys   int
+	// Latency delays every response, so parallel requests overlap.
+	Latency time.Duration
+
+	mu          sync.RWMutex
+	objects     map[string]object
+	inFlight    int
+	maxInFlight int
+}
+
+func NewServer(bucket string, accessKey string) *Server {
+	server := &Server{ //nolint:exhaustruct
+		Bucket: bucket, AccessKey: accessKey, MaxKeys: 1000, //nolint:gomnd
+		objects: make(map[string]object),
+	}
+	server.Server = httptest.NewServer(http.HandlerFunc(server.handle))
+
+	return server
+}
+
+// Keys returns sorted names of all stored objects.
+func (s *Server) Keys() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]string, 0, len(s.objects))
+	for key := range s.objects {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// MaxInFlight returns the biggest count of requests, which were handled at the same time.
+func (s *Server) MaxInFlight() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.maxInFlight
+}
+
+func (s *Server) trackInFlight(delta int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.inFlight += delta
+	s.maxInFlight = max(s.maxInFlight, s.inFlight)
+}
+
+// SetModTime is used for imitation of old objects.
+func (s *Server) SetModTime(key string, modTime time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if obj, ok := s.objects[key]; ok {
+		obj.modTime = modTime
+		s.objects[key] = obj
+	}
+}
+
+type listContent struct {
+	Key          string    `xml:"Key"`
+	LastModified time.Time `xml:"LastModified"`
+	Size         int       `xml:"Size"`
+}
+
+type listBucketResult struct {
+	XMLName               xml.Name      `xml:"ListBucketResult"`
+	Contents              []listContent `xml:"Contents"`
+	IsTruncated           bool          `xml:"IsTruncated"`
+	NextContinuationToken string        `xml:"NextContinuationToken,omitempty"`
+}
+
+// list continuation token is key of last returned object.
+func (s *Server) list(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("list-type") != "2" {
+		http.Error(w, "NotImplemented", http.StatusNotImplemented)
+
+		return
+	}
+
+	after := r.URL.Query().Get("continuation-token")
+	result := listBucketResult{} //nolint:exhaustruct
+
+	for _, key := range s.Keys() {
+		if key <= after {
+			continue
+		}
+
+		if len(result.Contents) == s.MaxKeys {
+			result.IsTruncated = true
+			result.NextContinuationToken = result.Contents[len(result.Contents)-1].Key
+
+			break
+		}
+
+		s.mu.RLock()
+		obj, ok := s.objects[key]
+		s.mu.RUnlock()
+
+		if ok {
+			result.Contents = append(result.Contents,
+				listContent{Key: key, LastModified: obj.modTime, Size: len(obj.content)})
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(result)
+}
+
+func (s *Server) Object(key string) ([]byte, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	obj, ok := s.objects[key]
+
+	return obj.content, ok
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.trackInFlight(1)
+	defer s.trackInFlight(-1)
+
+	time.Sleep(s.Latency)
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	hash := sha256.Sum256(body)
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential="+s.AccessKey+"/") ||
+		r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(hash[:]) ||
+		r.Header.Get("X-Amz-Date") == "" {
+		http.Error(w, "SignatureDoesNotMatch", http.StatusForbidden)
+
+		return
+	}
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket != s.Bucket {
+		http.Error(w, "NoSuchBucket", http.StatusNotFound)
+
+		return
+	}
+
+	if key == "" {
+		s.list(w, r)
+
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		s.mu.Lock()
+		s.objects[key] = object{content: body, modTime: time.Now().UTC()}
+		s.mu.Unlock()
+	case http.MethodDelete:
+		s.mu.Lock()
+		delete(s.objects, key)
+		s.mu.Unlock()
+
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodHead, http.MethodGet:
+		s.mu.RLock()
+		obj, ok := s.objects[key]
+		s.mu.RUnlock()
+
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+
+			return
+		}
+
+		w.Header().Set("Content-Length", strconv.Itoa(len(obj.content)))
+		w.Header().Set("Last-Modified", obj.modTime.Format(http.TimeFormat))
+
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(obj.content)
+		}
+	default:
+		http.Error(w, "MethodNotAllowed", http.StatusMethodNotAllowed)
+	}
+}
@@ -2,14 +2,25 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
 )
 
+// StoredFile is used by garbage collector for search of old files.
+type StoredFile struct {
+	Name    string
+	ModTime time.Time
+	Size    int64
+}
+
 type FileSystemStorage struct {
 	baseDir    string
 	mapFiles   map[string]struct{}
@@ -84,6 +95,8 @@ func (f *FileSystemStorage) SaveFile(ctx context.Context, content []byte, fileNa
 		return myerrors.NewErrorInternal(err.Error())
 	}
 
+	defer file.Close()
+
 	_, err = file.Write(content)
 	if err != nil {
 		logger.Infoln(err)
@@ -97,3 +110,53 @@ func (f *FileSystemStorage) SaveFile(ctx context.Context, content []byte, fileNa
 
 	return nil
 }
+
+// FileSystem is used for serving of files by http.FileServer.
+func (f *FileSystemStorage) FileSystem() http.FileSystem {
+	return http.Dir(f.baseDir)
+}
+
+func (f *FileSystemStorage) List(ctx context.Context) ([]StoredFile, error) {
+	logger := f.logger.LogReqID(ctx)
+
+	entries, err := os.ReadDir(f.baseDir)
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, myerrors.NewErrorInternal(err.Error())
+	}
+
+	files := make([]StoredFile, 0, len(entries))
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil { // file was deleted after reading of directory
+			continue
+		}
+
+		files = append(files, StoredFile{Name: entry.Name(), ModTime: info.ModTime(), Size: info.Size()})
+	}
+
+	return files, nil
+}
+
+func (f *FileSystemStorage) DeleteFile(ctx context.Context, fileName string) error {
+	logger := f.logger.LogReqID(ctx)
+
+	f.muMapFiles.Lock()
+	delete(f.mapFiles, fileName)
+	f.muMapFiles.Unlock()
+
+	err := os.Remove(filepath.Join(f.baseDir, filepath.Base(fileName)))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		logger.Errorln(err)
+
+		return myerrors.NewErrorInternal(err.Error())
+	}
+
+	return nil
+}
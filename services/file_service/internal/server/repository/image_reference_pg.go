@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/pgxpool"
+	"github.com/jackc/pgx/v5"
+)
+
+// ImageReferenceStorage keeps owners of files, files without owners are garbage.
+type ImageReferenceStorage struct {
+	pool   pgxpool.IPgxPool
+	logger *mylogger.MyLogger
+}
+
+func NewImageReferenceStorage(pool pgxpool.IPgxPool) (*ImageReferenceStorage, error) {
+	logger, err := mylogger.Get()
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return &ImageReferenceStorage{pool: pool, logger: logger}, nil
+}
+
+func (i *ImageReferenceStorage) deleteReferences(ctx context.Context, tx pgx.Tx,
+	ownerType string, ownerID uint64,
+) error {
+	logger := i.logger.LogReqID(ctx)
+
+	SQLDeleteReferences := `DELETE FROM public."image_reference" WHERE owner_type=$1 AND owner_id=$2;`
+
+	_, err := tx.Exec(ctx, SQLDeleteReferences, ownerType, ownerID)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// Attach replaces files of owner in one transaction, so files used before
+// and after replacing are never left without owner.
+func (i *ImageReferenceStorage) Attach(ctx context.Context,
+	ownerType string, ownerID uint64, fileNames []string,
+) error {
+	logger := i.logger.LogReqID(ctx)
+
+	SQLInsertReference := `INSERT INTO public."image_reference" (file_name, owner_type, owner_id)
+		VALUES ($1, $2, $3) ON CONFLICT DO NOTHING;`
+
+	err := pgx.BeginFunc(ctx, i.pool, func(tx pgx.Tx) error {
+		err := i.deleteReferences(ctx, tx, ownerType, ownerID)
+		if err != nil {
+			return err
+		}
+
+		for _, fileName := range fileNames {
+			_, err = tx.Exec(ctx, SQLInsertReference, fileName, ownerType, ownerID)
+			if err != nil {
+				logger.Errorln(err)
+
+				return fmt.Errorf(myerrors.ErrTemplate, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+func (i *ImageReferenceStorage) Detach(ctx context.Context, ownerType string, ownerID uint64) error {
+	logger := i.logger.LogReqID(ctx)
+
+	err := pgx.BeginFunc(ctx, i.pool, func(tx pgx.Tx) error {
+		return i.deleteReferences(ctx, tx, ownerType, ownerID)
+	})
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// GetReferenced returns files, which have at least one owner, among fileNames.
+// Images of products and avatars are also owners, so file isn't deleted
+// even if attaching of reference after saving of product or user failed.
+func (i *ImageReferenceStorage) GetReferenced(ctx context.Context, fileNames []string) (map[string]struct{}, error) {
+	logger := i.logger.LogReqID(ctx)
+
+	SQLGetReferenced := `SELECT file_name FROM public."image_reference" WHERE file_name = ANY($1)
+		UNION
+		SELECT REGEXP_REPLACE(url, '^img/', '') FROM public."image"
+		WHERE REGEXP_REPLACE(url, '^img/', '') = ANY($1)
+		UNION
+		SELECT REGEXP_REPLACE(avatar, '^img/', '') FROM public."user"
+		WHERE REGEXP_REPLACE(avatar, '^img/', '') = ANY($1);`
+
+	rows, err := i.pool.Query(ctx, SQLGetReferenced, fileNames)
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	referenced := make(map[string]struct{})
+
+	var fileName string
+
+	_, err = pgx.ForEachRow(rows, []any{&fileName}, func() error {
+		referenced[fileName] = struct{}{}
+
+		return nil
+	})
+	if err != nil {
+		logger.Errorln(err)
+
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return referenced, nil
+}
@@ -0,0 +1,121 @@
+package repository_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	fileservice "github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/file_service"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/file_service/internal/server/repository"
+	"github.com/pashagolub/pgxmock/v3"
+)
+
+func TestAttach(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	testInternalErr := myerrors.NewErrorInternal("Test error")
+
+	type TestCase struct {
+		name             string
+		behaviorMockPool func(mockPool pgxmock.PgxPoolIface)
+		inputFileNames   []string
+		expectedError    error
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+				mockPool.ExpectExec(`DELETE FROM public."image_reference"`).
+					WithArgs(fileservice.OwnerProduct, uint64(1)).WillReturnResult(pgxmock.NewResult("DELETE", 1))
+				mockPool.ExpectExec(`INSERT INTO public."image_reference"`).
+					WithArgs("a", fileservice.OwnerProduct, uint64(1)).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mockPool.ExpectExec(`INSERT INTO public."image_reference"`).
+					WithArgs("b", fileservice.OwnerProduct, uint64(1)).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mockPool.ExpectCommit()
+				mockPool.ExpectRollback()
+			},
+			inputFileNames: []string{"a", "b"},
+			expectedError:  nil,
+		},
+		{
+			name: "test internal error insert",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectBegin()
+				mockPool.ExpectExec(`DELETE FROM public."image_reference"`).
+					WithArgs(fileservice.OwnerProduct, uint64(1)).WillReturnResult(pgxmock.NewResult("DELETE", 0))
+				mockPool.ExpectExec(`INSERT INTO public."image_reference"`).
+					WithArgs("a", fileservice.OwnerProduct, uint64(1)).WillReturnError(testInternalErr)
+				mockPool.ExpectRollback()
+				mockPool.ExpectRollback()
+			},
+			inputFileNames: []string{"a"},
+			expectedError:  testInternalErr,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			referenceStorage, err := repository.NewImageReferenceStorage(mockPool)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			testCase.behaviorMockPool(mockPool)
+
+			err = referenceStorage.Attach(context.Background(), fileservice.OwnerProduct, 1, testCase.inputFileNames)
+			if errInner := utils.EqualError(err, testCase.expectedError); errInner != nil {
+				t.Fatalf("Failed EqualError: %+v", errInner)
+			}
+
+			if err := mockPool.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestGetReferenced(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	mockPool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	referenceStorage, err := repository.NewImageReferenceStorage(mockPool)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	mockPool.ExpectQuery(`SELECT file_name FROM public."image_reference"(.|\n)+FROM public."image"(.|\n)+FROM public."user"`).
+		WithArgs([]string{"a", "b", "c"}).
+		WillReturnRows(pgxmock.NewRows([]string{"file_name"}).AddRow("a").AddRow("c"))
+
+	referenced, err := referenceStorage.GetReferenced(context.Background(), []string{"a", "b", "c"})
+	if err != nil {
+		t.Fatalf("unexpected err=%+v", err)
+	}
+
+	expected := map[string]struct{}{"a": {}, "c": {}}
+	if !reflect.DeepEqual(referenced, expected) {
+		t.Fatalf("got %v, expected %v", referenced, expected)
+	}
+}
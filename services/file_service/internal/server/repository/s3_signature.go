@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	algorithmSigV4 = "AWS4-HMAC-SHA256"
+	serviceS3      = "s3"
+	requestSigV4   = "aws4_request"
+
+	formatAmzDate   = "20060102T150405Z"
+	formatAmzDateV4 = "20060102"
+
+	HeaderAmzDate          = "X-Amz-Date"
+	HeaderAmzContentSHA256 = "X-Amz-Content-Sha256"
+)
+
+type S3Credentials struct {
+	AccessKey string
+	SecretKey string
+}
+
+func hashSHA256Hex(content []byte) string {
+	hash := sha256.Sum256(content)
+
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
+
+// escapePath encodes every segment of path as S3 requires, slashes are kept.
+func escapePath(rawPath string) string {
+	segments := strings.Split(rawPath, "/")
+	for i, segment := range segments {
+		segments[i] = strings.ReplaceAll(url.PathEscape(segment), "+", "%2B")
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// signV4 adds headers X-Amz-Date, X-Amz-Content-Sha256 and Authorization
+// by AWS Signature Version 4. All headers of request and host are signed.
+func signV4(r *http.Request, payloadHash string, credentials S3Credentials, region string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format(formatAmzDate)
+	scope := strings.Join([]string{now.Format(formatAmzDateV4), region, serviceS3, requestSigV4}, "/")
+
+	r.Header.Set(HeaderAmzDate, amzDate)
+	r.Header.Set(HeaderAmzContentSHA256, payloadHash)
+
+	headers := map[string]string{"host": r.Host}
+	if r.Host == "" {
+		headers["host"] = r.URL.Host
+	}
+
+	for name, values := range r.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	canonicalHeaders := new(strings.Builder)
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+
+	signedHeaders := strings.Join(names, ";")
+
+	// url.Values.Encode sorts by key, that is required for canonical query
+	canonicalQuery := strings.ReplaceAll(r.URL.Query().Encode(), "+", "%20")
+
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		escapePath(r.URL.Path),
+		canonicalQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	stringToSign := strings.Join([]string{
+		algorithmSigV4,
+		amzDate,
+		scope,
+		hashSHA256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+credentials.SecretKey), now.Format(formatAmzDateV4))
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, serviceS3)
+	signingKey = hmacSHA256(signingKey, requestSigV4)
+
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	r.Header.Set("Authorization", algorithmSigV4+" Credential="+credentials.AccessKey+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
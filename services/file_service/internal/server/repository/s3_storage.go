@@ -0,0 +1,368 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+)
+
+const (
+	timeoutS3Request = 10 * time.Second
+
+	// maxParallelS3Requests limits requests of one Check, so big list of files doesn't open
+	// hundreds of connections to storage.
+	maxParallelS3Requests = 8
+)
+
+var ErrS3Response = myerrors.NewErrorInternal("Хранилище файлов вернуло ошибку")
+
+// S3Storage keeps files as objects of bucket in S3-compatible storage (AWS S3, MinIO, Yandex Object Storage).
+// Path-style addressing is used: endpoint/bucket/fileName. It doesn't keep any state,
+// so several instances of file service can use the same bucket.
+type S3Storage struct {
+	endpoint    string
+	bucket      string
+	region      string
+	credentials S3Credentials
+	client      *http.Client
+	logger      *mylogger.MyLogger
+}
+
+func NewS3Storage(endpoint string, bucket string, region string, credentials S3Credentials) (*S3Storage, error) {
+	logger, err := mylogger.Get()
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return &S3Storage{
+		endpoint:    strings.TrimSuffix(endpoint, "/"),
+		bucket:      bucket,
+		region:      region,
+		credentials: credentials,
+		client:      &http.Client{Timeout: timeoutS3Request}, //nolint:exhaustruct
+		logger:      logger,
+	}, nil
+}
+
+// doRequest objectPath is escaped key of object or query for bucket.
+func (s *S3Storage) doRequest(ctx context.Context, method string, objectPath string, content []byte,
+) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, method,
+		s.endpoint+"/"+s.bucket+"/"+objectPath, bytes.NewReader(content))
+	if err != nil {
+		return nil, myerrors.NewErrorInternal(err.Error())
+	}
+
+	if content == nil {
+		request.Body = http.NoBody
+	}
+
+	signV4(request, hashSHA256Hex(content), s.credentials, s.region, time.Now())
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, myerrors.NewErrorInternal(err.Error())
+	}
+
+	return response, nil
+}
+
+// headObject returns size and time of modification of object. Not found object gives os.ErrNotExist.
+func (s *S3Storage) headObject(ctx context.Context, fileName string) (int64, time.Time, error) {
+	response, err := s.doRequest(ctx, http.MethodHead, escapePath(fileName), nil)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return 0, time.Time{}, os.ErrNotExist
+	default:
+		return 0, time.Time{}, fmt.Errorf("%w: HEAD %s status %d", ErrS3Response, fileName, response.StatusCode)
+	}
+
+	modTime, err := http.ParseTime(response.Header.Get("Last-Modified"))
+	if err != nil {
+		modTime = time.Time{}
+	}
+
+	return response.ContentLength, modTime, nil
+}
+
+func (s *S3Storage) getObject(ctx context.Context, fileName string) ([]byte, error) {
+	response, err := s.doRequest(ctx, http.MethodGet, escapePath(fileName), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: GET %s status %d", ErrS3Response, fileName, response.StatusCode)
+	}
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, myerrors.NewErrorInternal(err.Error())
+	}
+
+	return content, nil
+}
+
+// Check bool in return slice means file exist if it's true.
+// Not more than maxParallelS3Requests objects are checked at the same time.
+func (s *S3Storage) Check(ctx context.Context, files []string) ([]bool, error) {
+	logger := s.logger.LogReqID(ctx)
+
+	result := make([]bool, len(files))
+	errs := make([]error, len(files))
+
+	waitGroup := &sync.WaitGroup{}
+	semaphore := make(chan struct{}, maxParallelS3Requests)
+
+	for i, fileName := range files {
+		waitGroup.Add(1)
+
+		semaphore <- struct{}{}
+
+		go func(i int, fileName string) {
+			defer func() {
+				<-semaphore
+				waitGroup.Done()
+			}()
+
+			_, _, err := s.headObject(ctx, fileName)
+
+			result[i] = err == nil
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				errs[i] = err
+			}
+		}(i, fileName)
+	}
+
+	waitGroup.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			logger.Errorln(err)
+
+			return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *S3Storage) SaveFile(ctx context.Context, content []byte, fileName string) error {
+	logger := s.logger.LogReqID(ctx)
+
+	response, err := s.doRequest(ctx, http.MethodPut, escapePath(fileName), content)
+	if err != nil {
+		logger.Errorln(err)
+
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		err = fmt.Errorf("%w: PUT %s status %d", ErrS3Response, fileName, response.StatusCode)
+		logger.Errorln(err)
+
+		return err
+	}
+
+	return nil
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		LastModified time.Time `xml:"LastModified"`
+		Size         int64     `xml:"Size"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// List returns all objects of bucket by ListObjectsV2, which returns up to 1000 objects per request.
+func (s *S3Storage) List(ctx context.Context) ([]StoredFile, error) {
+	logger := s.logger.LogReqID(ctx)
+
+	var files []StoredFile
+
+	query := url.Values{"list-type": {"2"}}
+
+	for {
+		result, err := s.listObjects(ctx, query)
+		if err != nil {
+			logger.Errorln(err)
+
+			return nil, err
+		}
+
+		for _, content := range result.Contents {
+			files = append(files, StoredFile{Name: content.Key, ModTime: content.LastModified, Size: content.Size})
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return files, nil
+		}
+
+		query.Set("continuation-token", result.NextContinuationToken)
+	}
+}
+
+func (s *S3Storage) listObjects(ctx context.Context, query url.Values) (*listBucketResult, error) {
+	response, err := s.doRequest(ctx, http.MethodGet, "?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: LIST status %d", ErrS3Response, response.StatusCode)
+	}
+
+	result := &listBucketResult{} //nolint:exhaustruct
+
+	err = xml.NewDecoder(response.Body).Decode(result)
+	if err != nil {
+		return nil, myerrors.NewErrorInternal(err.Error())
+	}
+
+	return result, nil
+}
+
+// DeleteFile S3 returns 204 for both deleted and not existing object.
+func (s *S3Storage) DeleteFile(ctx context.Context, fileName string) error {
+	logger := s.logger.LogReqID(ctx)
+
+	response, err := s.doRequest(ctx, http.MethodDelete, escapePath(fileName), nil)
+	if err != nil {
+		logger.Errorln(err)
+
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusOK {
+		err = fmt.Errorf("%w: DELETE %s status %d", ErrS3Response, fileName, response.StatusCode)
+		logger.Errorln(err)
+
+		return err
+	}
+
+	return nil
+}
+
+// FileSystem is used for serving of files by http.FileServer.
+func (s *S3Storage) FileSystem() http.FileSystem {
+	return &s3FileSystem{storage: s}
+}
+
+type s3FileSystem struct {
+	storage *S3Storage
+}
+
+// Open checks existence of object, content is loaded on first read.
+func (s *s3FileSystem) Open(name string) (http.File, error) {
+	fileName := strings.TrimPrefix(path.Clean("/"+name), "/")
+	if fileName == "" {
+		return nil, os.ErrNotExist
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeoutS3Request)
+	defer cancel()
+
+	size, modTime, err := s.storage.headObject(ctx, fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3File{storage: s.storage, info: s3FileInfo{name: fileName, size: size, modTime: modTime}}, nil
+}
+
+type s3File struct {
+	storage *S3Storage
+	info    s3FileInfo
+	reader  *bytes.Reader
+}
+
+func (s *s3File) load() error {
+	if s.reader != nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeoutS3Request)
+	defer cancel()
+
+	content, err := s.storage.getObject(ctx, s.info.name)
+	if err != nil {
+		return err
+	}
+
+	s.reader = bytes.NewReader(content)
+
+	return nil
+}
+
+func (s *s3File) Read(p []byte) (int, error) {
+	if err := s.load(); err != nil {
+		return 0, err
+	}
+
+	return s.reader.Read(p) //nolint:wrapcheck
+}
+
+func (s *s3File) Seek(offset int64, whence int) (int64, error) {
+	if err := s.load(); err != nil {
+		return 0, err
+	}
+
+	return s.reader.Seek(offset, whence) //nolint:wrapcheck
+}
+
+func (s *s3File) Close() error {
+	return nil
+}
+
+func (s *s3File) Readdir(_ int) ([]fs.FileInfo, error) {
+	return nil, fs.ErrInvalid
+}
+
+func (s *s3File) Stat() (fs.FileInfo, error) {
+	return s.info, nil
+}
+
+type s3FileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+}
+
+func (s s3FileInfo) Name() string       { return path.Base(s.name) }
+func (s s3FileInfo) Size() int64        { return s.size }
+func (s s3FileInfo) Mode() fs.FileMode  { return 0o444 } //nolint:gomnd
+func (s s3FileInfo) ModTime() time.Time { return s.modTime }
+func (s s3FileInfo) IsDir() bool        { return false }
+func (s s3FileInfo) Sys() any           { return nil }
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/pgxpool"
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrUploadQuotaExceeded = myerrors.NewErrorBadContentRequest("Превышен дневной лимит загрузки фото")
+
+// UploadQuotaStorage counts bytes and files uploaded by user per day.
+type UploadQuotaStorage struct {
+	pool   pgxpool.IPgxPool
+	logger *mylogger.MyLogger
+}
+
+func NewUploadQuotaStorage(pool pgxpool.IPgxPool) (*UploadQuotaStorage, error) {
+	logger, err := mylogger.Get()
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return &UploadQuotaStorage{pool: pool, logger: logger}, nil
+}
+
+// Reserve adds one file of size to today uploads of user, if limits maxBytes and maxFiles aren't exceeded.
+// Check and adding are done by single statement, so parallel uploads can't exceed limits together.
+func (u *UploadQuotaStorage) Reserve(ctx context.Context, userID uint64, size int64,
+	maxBytes int64, maxFiles int64,
+) error {
+	logger := u.logger.LogReqID(ctx)
+
+	if size > maxBytes {
+		return fmt.Errorf(myerrors.ErrTemplate, ErrUploadQuotaExceeded)
+	}
+
+	SQLReserve := `INSERT INTO public."upload_quota" (user_id, day, bytes, files) VALUES ($1, CURRENT_DATE, $2, 1)
+		ON CONFLICT (user_id, day) DO UPDATE
+		SET bytes = upload_quota.bytes + EXCLUDED.bytes, files = upload_quota.files + EXCLUDED.files
+		WHERE upload_quota.bytes + EXCLUDED.bytes <= $3 AND upload_quota.files + EXCLUDED.files <= $4
+		RETURNING files;`
+
+	var files int64
+
+	err := u.pool.QueryRow(ctx, SQLReserve, userID, size, maxBytes, maxFiles).Scan(&files)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Infof("user id=%d exceeded upload quota", userID)
+
+			return fmt.Errorf(myerrors.ErrTemplate, ErrUploadQuotaExceeded)
+		}
+
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+// Release returns file of size reserved today, when it wasn't saved.
+func (u *UploadQuotaStorage) Release(ctx context.Context, userID uint64, size int64) error {
+	logger := u.logger.LogReqID(ctx)
+
+	SQLRelease := `UPDATE public."upload_quota"
+		SET bytes = GREATEST(bytes - $2, 0), files = GREATEST(files - 1, 0)
+		WHERE user_id=$1 AND day=CURRENT_DATE;`
+
+	_, err := u.pool.Exec(ctx, SQLRelease, userID, size)
+	if err != nil {
+		logger.Errorln(err)
+
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
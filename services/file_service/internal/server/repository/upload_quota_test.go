@@ -0,0 +1,125 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils/test"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/file_service/internal/server/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v3"
+)
+
+func TestReserve(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	testInternalErr := myerrors.NewErrorInternal("Test error")
+
+	type TestCase struct {
+		name             string
+		behaviorMockPool func(mockPool pgxmock.PgxPoolIface)
+		inputSize        int64
+		expectedError    error
+	}
+
+	testCases := [...]TestCase{
+		{
+			name: "test basic work",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectQuery(`INSERT INTO public."upload_quota"`).
+					WithArgs(test.UserID, int64(100), int64(1000), int64(10)).
+					WillReturnRows(pgxmock.NewRows([]string{"files"}).AddRow(int64(1)))
+			},
+			inputSize:     100,
+			expectedError: nil,
+		},
+		{
+			name: "test quota exceeded",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectQuery(`INSERT INTO public."upload_quota"`).
+					WithArgs(test.UserID, int64(100), int64(1000), int64(10)).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			inputSize:     100,
+			expectedError: repository.ErrUploadQuotaExceeded,
+		},
+		{
+			name:             "test file bigger than quota",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {},
+			inputSize:        1001,
+			expectedError:    repository.ErrUploadQuotaExceeded,
+		},
+		{
+			name: "test internal error",
+			behaviorMockPool: func(mockPool pgxmock.PgxPoolIface) {
+				mockPool.ExpectQuery(`INSERT INTO public."upload_quota"`).
+					WithArgs(test.UserID, int64(100), int64(1000), int64(10)).
+					WillReturnError(testInternalErr)
+			},
+			inputSize:     100,
+			expectedError: testInternalErr,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockPool, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			quotaStorage, err := repository.NewUploadQuotaStorage(mockPool)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			testCase.behaviorMockPool(mockPool)
+
+			err = quotaStorage.Reserve(context.Background(), test.UserID, testCase.inputSize, 1000, 10)
+			if errInner := utils.EqualError(err, testCase.expectedError); errInner != nil {
+				t.Fatalf("Failed EqualError: %+v", errInner)
+			}
+
+			if err := mockPool.ExpectationsWereMet(); err != nil {
+				t.Fatalf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestRelease(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	mockPool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	quotaStorage, err := repository.NewUploadQuotaStorage(mockPool)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	mockPool.ExpectExec(`UPDATE public."upload_quota"`).WithArgs(test.UserID, int64(100)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	err = quotaStorage.Release(context.Background(), test.UserID, 100)
+	if err != nil {
+		t.Fatalf("unexpected err=%+v", err)
+	}
+
+	if err := mockPool.ExpectationsWereMet(); err != nil {
+		t.Fatalf("there were unfulfilled expectations: %s", err)
+	}
+}
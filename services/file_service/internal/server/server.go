@@ -2,21 +2,26 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/auth"
 	fileservice "github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/file_service"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/interceptors"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/metrics"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	reposhare "github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/repository"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/file_service/internal/config"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/file_service/internal/server/delivery"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/file_service/internal/server/delivery/mux"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/file_service/internal/server/repository"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/file_service/internal/server/usecases"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
 const (
@@ -25,6 +30,34 @@ const (
 	basicTimeout = 10 * time.Second
 )
 
+var ErrUnknownFileStorage = myerrors.NewErrorInternal("Неизвестный FILE_STORAGE, допустимы fs, s3")
+
+var (
+	_ IFileStorage = (*repository.FileSystemStorage)(nil)
+	_ IFileStorage = (*repository.S3Storage)(nil)
+)
+
+type IFileStorage interface {
+	usecases.IFileStorageHTTP
+	usecases.IFileStorageGrpc
+	usecases.IFileStorageSweeper
+	FileSystem() http.FileSystem
+}
+
+// newFileStorage local directory can be used only by single instance of file service,
+// bucket of S3 can be shared by several ones.
+func newFileStorage(configFS *config.Config) (IFileStorage, error) {
+	switch configFS.FileStorage {
+	case config.FileStorageFS:
+		return repository.NewFileSystemStorage(configFS.FileServiceDir) //nolint:wrapcheck
+	case config.FileStorageS3:
+		return repository.NewS3Storage(configFS.S3Endpoint, configFS.S3Bucket, configFS.S3Region, //nolint:wrapcheck
+			repository.S3Credentials{AccessKey: configFS.S3AccessKey, SecretKey: configFS.S3SecretKey})
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFileStorage, configFS.FileStorage)
+	}
+}
+
 type Server struct {
 	httpServer *http.Server
 	grpcServer *grpc.Server
@@ -42,19 +75,57 @@ func (s *Server) RunFull(config *config.Config, chErrHTTP chan<- error) error {
 
 	baseCtx := context.Background()
 
-	fileStorage, err := repository.NewFileSystemStorage(config.FileServiceDir)
+	fileStorage, err := newFileStorage(config)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	pool, err := reposhare.NewPgxPool(baseCtx, config.URLDataBase)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	defer pool.Close()
+
+	referenceStorage, err := repository.NewImageReferenceStorage(pool)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	imageSweeper, err := usecases.NewImageSweeper(fileStorage, referenceStorage,
+		metrics.NewMetricManagerSweeper(config.ServiceName), config.ImageGCGracePeriod, config.ImageGCDryRun)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	chCloseSweeping := make(chan struct{})
+	defer close(chCloseSweeping)
+
+	imageSweeper.StartSweeping(baseCtx, config.ImageGCPeriod, chCloseSweeping)
+
+	grpcConnAuth, err := grpc.Dial(
+		config.AddressAuthServiceGrpc,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	defer grpcConnAuth.Close()
+
+	quotaStorage, err := repository.NewUploadQuotaStorage(pool)
 	if err != nil {
 		return err //nolint:wrapcheck
 	}
 
-	fileServiceHTTP, err := usecases.NewFileServiceHTTP(fileStorage, urlPrefixPathFS)
+	fileServiceHTTP, err := usecases.NewFileServiceHTTP(fileStorage, urlPrefixPathFS, quotaStorage,
+		usecases.UploadQuota{Bytes: config.UploadQuotaBytes, Files: config.UploadQuotaFiles})
 	if err != nil {
 		return err //nolint:wrapcheck
 	}
 
 	handler, err := mux.NewMux(baseCtx,
-		mux.NewConfigMux(config.AllowOrigin, config.Schema, config.Port, config.FileServiceDir, config.ServiceName),
-		fileServiceHTTP, logger)
+		mux.NewConfigMux(config.AllowOrigin, config.Schema, config.Port, config.ServiceName),
+		fileServiceHTTP, fileStorage.FileSystem(), auth.NewSessionMangerClient(grpcConnAuth), logger)
 	if err != nil {
 		return err //nolint:wrapcheck
 	}
@@ -93,7 +164,7 @@ func (s *Server) RunFull(config *config.Config, chErrHTTP chan<- error) error {
 	server := grpc.NewServer(
 		grpc.ChainUnaryInterceptor(grpcAccessInterceptor.AccessInterceptor, interceptors.ErrConvertInterceptor))
 
-	fileServiceGrpc := usecases.NewFileServiceGrpc(urlPrefixPathFS, fileStorage)
+	fileServiceGrpc := usecases.NewFileServiceGrpc(urlPrefixPathFS, fileStorage, referenceStorage)
 	fileHandlerGrpc := delivery.NewFileHandlerGrpc(fileServiceGrpc)
 
 	fileservice.RegisterFileServiceServer(server, fileHandlerGrpc)
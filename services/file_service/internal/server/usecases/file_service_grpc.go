@@ -4,24 +4,45 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	fileservice "github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/file_service"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/file_service/internal/server/repository"
 )
 
-var _ IFileStorageGrpc = (*repository.FileSystemStorage)(nil)
+var ErrUnknownOwner = myerrors.NewErrorBadFormatRequest("Неизвестный тип владельца изображений")
+
+var (
+	_ IFileStorageGrpc = (*repository.FileSystemStorage)(nil)
+	_ IFileStorageGrpc = (*repository.S3Storage)(nil)
+)
 
 type IFileStorageGrpc interface {
 	Check(ctx context.Context, files []string) ([]bool, error)
 }
 
+var _ IImageReferenceStorage = (*repository.ImageReferenceStorage)(nil)
+
+type IImageReferenceStorage interface {
+	Attach(ctx context.Context, ownerType string, ownerID uint64, fileNames []string) error
+	Detach(ctx context.Context, ownerType string, ownerID uint64) error
+}
+
 type FileServiceGrpc struct {
-	urlPrefixPath string
-	fileStorage   IFileStorageGrpc
+	urlPrefixPath    string
+	fileStorage      IFileStorageGrpc
+	referenceStorage IImageReferenceStorage
+	variantsCache    *variantsCache
 }
 
-func NewFileServiceGrpc(urlPrefixPath string, fileStorage IFileStorageGrpc) *FileServiceGrpc {
-	return &FileServiceGrpc{urlPrefixPath: urlPrefixPath, fileStorage: fileStorage}
+func NewFileServiceGrpc(urlPrefixPath string, fileStorage IFileStorageGrpc,
+	referenceStorage IImageReferenceStorage,
+) *FileServiceGrpc {
+	return &FileServiceGrpc{
+		urlPrefixPath: urlPrefixPath, fileStorage: fileStorage, referenceStorage: referenceStorage,
+		variantsCache: newVariantsCache(TTLVariantsCache),
+	}
 }
 
 func (f *FileServiceGrpc) Check(ctx context.Context, urls []string) ([]bool, error) {
@@ -36,3 +57,113 @@ func (f *FileServiceGrpc) Check(ctx context.Context, urls []string) ([]bool, err
 
 	return result, nil
 }
+
+type ImageVariantsURLs struct {
+	Thumbnail string
+	Card      string
+	Full      string
+}
+
+// checkVariants asks storage only about variants, which aren't in cache.
+func (f *FileServiceGrpc) checkVariants(ctx context.Context, fileNames []string) ([]bool, error) {
+	now := time.Now()
+	exist := make([]bool, len(fileNames))
+
+	var notCachedIndexes []int
+
+	var notCachedNames []string
+
+	for i, fileName := range fileNames {
+		existCached, ok := f.variantsCache.get(fileName, now)
+		if ok {
+			exist[i] = existCached
+
+			continue
+		}
+
+		notCachedIndexes = append(notCachedIndexes, i)
+		notCachedNames = append(notCachedNames, fileName)
+	}
+
+	if len(notCachedNames) == 0 {
+		return exist, nil
+	}
+
+	existNotCached, err := f.fileStorage.Check(ctx, notCachedNames)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	f.variantsCache.set(notCachedNames, existNotCached, now)
+
+	for j, i := range notCachedIndexes {
+		exist[i] = existNotCached[j]
+	}
+
+	return exist, nil
+}
+
+// GetVariants returns urls of variants for every url. Url of original is used
+// instead of variant, which doesn't exist in storage. Existence of variants is cached for TTLVariantsCache.
+func (f *FileServiceGrpc) GetVariants(ctx context.Context, urls []string) ([]ImageVariantsURLs, error) {
+	fileNames := make([]string, 0, len(urls)*2) //nolint:gomnd
+
+	for _, url := range urls {
+		fileName := strings.TrimPrefix(url, f.urlPrefixPath)
+		fileNames = append(fileNames, fileName+VariantSeparator+VariantThumbnail,
+			fileName+VariantSeparator+VariantCard)
+	}
+
+	exist, err := f.checkVariants(ctx, fileNames)
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	result := make([]ImageVariantsURLs, len(urls))
+
+	for i, url := range urls {
+		result[i] = ImageVariantsURLs{Thumbnail: url, Card: url, Full: url}
+
+		if exist[2*i] {
+			result[i].Thumbnail = url + VariantSeparator + VariantThumbnail
+		}
+
+		if exist[2*i+1] {
+			result[i].Card = url + VariantSeparator + VariantCard
+		}
+	}
+
+	return result, nil
+}
+
+// Attach replaces images of owner by urls, empty urls detach all images of owner.
+func (f *FileServiceGrpc) Attach(ctx context.Context, ownerType string, ownerID uint64, urls []string) error {
+	if !fileservice.IsKnownOwner(ownerType) {
+		return fmt.Errorf("%w: %s", ErrUnknownOwner, ownerType)
+	}
+
+	fileNames := make([]string, len(urls))
+	for i, url := range urls {
+		fileNames[i] = strings.TrimPrefix(url, f.urlPrefixPath)
+	}
+
+	err := f.referenceStorage.Attach(ctx, ownerType, ownerID, fileNames)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
+
+func (f *FileServiceGrpc) Detach(ctx context.Context, ownerType string, ownerID uint64) error {
+	if !fileservice.IsKnownOwner(ownerType) {
+		return fmt.Errorf("%w: %s", ErrUnknownOwner, ownerType)
+	}
+
+	err := f.referenceStorage.Detach(ctx, ownerType, ownerID)
+	if err != nil {
+		return fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package usecases_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/file_service/internal/server/mocks"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/file_service/internal/server/usecases"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetVariantsCached(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockFileStorage := mocks.NewMockIFileStorageGrpc(ctrl)
+
+	// second read of the same images doesn't ask storage
+	mockFileStorage.EXPECT().Check(gomock.Any(), []string{"hash_thumbnail", "hash_card"}).
+		Return([]bool{true, false}, nil).Times(1)
+	mockFileStorage.EXPECT().Check(gomock.Any(), []string{"other_thumbnail", "other_card"}).
+		Return([]bool{true, true}, nil).Times(1)
+
+	fileService := usecases.NewFileServiceGrpc("img/", mockFileStorage, mocks.NewMockIImageReferenceStorage(ctrl))
+
+	expected := []usecases.ImageVariantsURLs{
+		{Thumbnail: "img/hash_thumbnail", Card: "img/hash", Full: "img/hash"},
+	}
+
+	for i := 0; i < 2; i++ {
+		result, err := fileService.GetVariants(context.Background(), []string{"img/hash"})
+		if err != nil {
+			t.Fatalf("unexpected err=%+v", err)
+		}
+
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("got %+v, expected %+v", result, expected)
+		}
+	}
+
+	result, err := fileService.GetVariants(context.Background(), []string{"img/hash", "img/other"})
+	if err != nil {
+		t.Fatalf("unexpected err=%+v", err)
+	}
+
+	expected = append(expected, usecases.ImageVariantsURLs{
+		Thumbnail: "img/other_thumbnail", Card: "img/other_card", Full: "img/other",
+	})
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("got %+v, expected %+v", result, expected)
+	}
+}
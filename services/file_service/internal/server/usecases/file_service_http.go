@@ -1,75 +1,199 @@
 package usecases
 
 import (
-	"bytes"
+	"bufio"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
-	"image"
-	_ "image/jpeg" // Add jpeg format for image
-	_ "image/png"  // Add png format for image
 	"io"
+	"os"
 
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
 	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
 	fileservicerepo "github.com/go-park-mail-ru/2023_2_Rabotyagi/services/file_service/internal/server/repository"
 )
 
+const MaxSizePhotoBytes = 5 * 1024 * 1024
+
 var (
 	ErrCantRead    = myerrors.NewErrorBadFormatRequest("Не получилось считать содержимое файла из тела запроса")
 	ErrWrongFormat = myerrors.NewErrorBadContentRequest("Формат файла должен быть png, jpeg")
+	ErrToBigFile   = myerrors.NewErrorBadContentRequest("Максимальный размер фото %d Мбайт",
+		MaxSizePhotoBytes/1024/1024) //nolint:gomnd
 )
 
-var _ IFileStorageHTTP = (*fileservicerepo.FileSystemStorage)(nil)
+var (
+	_ IFileStorageHTTP = (*fileservicerepo.FileSystemStorage)(nil)
+	_ IFileStorageHTTP = (*fileservicerepo.S3Storage)(nil)
+)
 
 type IFileStorageHTTP interface {
 	SaveFile(ctx context.Context, content []byte, fileName string) error
 }
 
+var _ IUploadQuotaStorage = (*fileservicerepo.UploadQuotaStorage)(nil)
+
+type IUploadQuotaStorage interface {
+	Reserve(ctx context.Context, userID uint64, size int64, maxBytes int64, maxFiles int64) error
+	Release(ctx context.Context, userID uint64, size int64) error
+}
+
+// UploadQuota limits of uploads of one user per day.
+type UploadQuota struct {
+	Bytes int64
+	Files int64
+}
+
 type FileServiceHTTP struct {
 	urlPrefixPath string
 	fileStorage   IFileStorageHTTP
+	quotaStorage  IUploadQuotaStorage
+	uploadQuota   UploadQuota
 	logger        *mylogger.MyLogger
 }
 
-func NewFileServiceHTTP(fileStorage IFileStorageHTTP, urlPrefixPath string) (*FileServiceHTTP, error) {
+func NewFileServiceHTTP(fileStorage IFileStorageHTTP, urlPrefixPath string,
+	quotaStorage IUploadQuotaStorage, uploadQuota UploadQuota,
+) (*FileServiceHTTP, error) {
 	logger, err := mylogger.Get()
 	if err != nil {
 		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	return &FileServiceHTTP{fileStorage: fileStorage, urlPrefixPath: urlPrefixPath, logger: logger}, nil
+	return &FileServiceHTTP{
+		fileStorage: fileStorage, urlPrefixPath: urlPrefixPath,
+		quotaStorage: quotaStorage, uploadQuota: uploadQuota, logger: logger,
+	}, nil
 }
 
-func (f *FileServiceHTTP) SaveImage(ctx context.Context, reader io.Reader) (string, error) {
+// writeStripped writes content of reader without metadata to file and returns its hash, EXIF orientation
+// and size of read content. Not more than MaxSizePhotoBytes are read, so memory doesn't depend on size of upload.
+func writeStripped(file io.Writer, reader io.Reader) (string, int, int64, error) {
+	limitedReader := &io.LimitedReader{R: reader, N: MaxSizePhotoBytes + 1}
+	bufReader := bufio.NewReader(limitedReader)
+
+	header, _ := bufReader.Peek(LenSniff)
+	if format := SniffFormat(header); format == "" {
+		return "", 0, 0, fmt.Errorf(myerrors.ErrTemplate, ErrWrongFormat)
+	}
+
+	hash := sha256.New()
+
+	orientation, err := StripMetadataStream(io.MultiWriter(file, hash), bufReader)
+	if err == nil {
+		// content after end of image isn't stored, but it's counted in size
+		_, err = io.Copy(io.Discard, bufReader)
+	}
+
+	if limitedReader.N <= 0 {
+		return "", 0, 0, fmt.Errorf(myerrors.ErrTemplate, ErrToBigFile)
+	}
+
+	if err != nil {
+		if !errors.Is(err, ErrBrokenImage) {
+			err = fmt.Errorf("%w %s", ErrCantRead, err.Error())
+		}
+
+		return "", 0, 0, err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), orientation, MaxSizePhotoBytes + 1 - limitedReader.N, nil
+}
+
+// SaveImage streams image to temporary file, while metadata is removed and hash is calculated,
+// so name of file doesn't depend on metadata. Image is decoded only after checks of format
+// and dimensions. Upload quota of user is reserved by size of received file before decoding,
+// so user out of quota can't load server with decoding, and it's released, if variants aren't saved.
+// Returned url points to full variant, others are available by adding Variant.Suffix to it.
+func (f *FileServiceHTTP) SaveImage(ctx context.Context, userID uint64, reader io.Reader) (string, error) { //nolint:funlen
 	logger := f.logger.LogReqID(ctx)
 
-	content, err := io.ReadAll(reader)
+	tempFile, err := os.CreateTemp("", "upload-*")
 	if err != nil {
-		logger.Infoln(err)
+		logger.Errorln(err)
 
-		return "", fmt.Errorf(myerrors.ErrTemplate, ErrCantRead)
+		return "", fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	_, format, err := image.Decode(bytes.NewReader(content))
+	defer func() {
+		tempFile.Close()
+		os.Remove(tempFile.Name())
+	}()
+
+	fileName, orientation, size, err := writeStripped(tempFile, reader)
 	if err != nil {
 		logger.Infoln(err)
 
-		return "", fmt.Errorf("вы используете формат %s %w", format, ErrWrongFormat)
+		return "", fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	err = f.quotaStorage.Reserve(ctx, userID, size, f.uploadQuota.Bytes, f.uploadQuota.Files)
+	if err != nil {
+		return "", fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	isSaved := false
+
+	defer func() {
+		if isSaved {
+			return
+		}
+
+		// saved variants aren't referenced, so they are removed by sweeper
+		if errRelease := f.quotaStorage.Release(ctx, userID, size); errRelease != nil {
+			logger.Errorf("failed release upload quota of user id=%d: %+v", userID, errRelease)
+		}
+	}()
+
+	_, err = tempFile.Seek(0, io.SeekStart)
+	if err != nil {
+		logger.Errorln(err)
+
+		return "", fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	fileName, err := HashContent(ctx, content)
+	_, _, err = DecodeImageConfig(tempFile)
 	if err != nil {
 		logger.Infoln(err)
 
-		return "", myerrors.NewErrorInternal(err.Error())
+		return "", fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
-	err = f.fileStorage.SaveFile(ctx, content, fileName)
+	_, err = tempFile.Seek(0, io.SeekStart)
+	if err != nil {
+		logger.Errorln(err)
+
+		return "", fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	stripped, err := io.ReadAll(tempFile)
+	if err != nil {
+		logger.Errorln(err)
+
+		return "", fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	imageVariants, err := MakeStrippedImageVariants(stripped, orientation)
 	if err != nil {
 		logger.Infoln(err)
 
 		return "", fmt.Errorf(myerrors.ErrTemplate, err)
 	}
 
+	for _, imageVariant := range imageVariants {
+		err = f.fileStorage.SaveFile(ctx, imageVariant.Content, fileName+imageVariant.Variant.Suffix())
+		if err != nil {
+			logger.Infoln(err)
+
+			return "", fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+	}
+
+	isSaved = true
+
+	logger.Infof("user id=%d uploaded %d bytes as %s", userID, size, fileName)
+
 	return f.urlPrefixPath + fileName, nil
 }
@@ -0,0 +1,173 @@
+package usecases_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils/test"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/file_service/internal/server/mocks"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/file_service/internal/server/repository"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/file_service/internal/server/usecases"
+	"go.uber.org/mock/gomock"
+)
+
+// newTestPNGBomb returns only header of png with huge dimensions, which is enough for DecodeConfig.
+func newTestPNGBomb(width, height uint32) []byte {
+	ihdr := binary.BigEndian.AppendUint32(nil, width)
+	ihdr = binary.BigEndian.AppendUint32(ihdr, height)
+	ihdr = append(ihdr, 8, 6, 0, 0, 0) // 8 bit RGBA
+
+	return append([]byte("\x89PNG\r\n\x1a\n"), pngChunk("IHDR", ihdr)...)
+}
+
+func TestSaveImage(t *testing.T) { //nolint:funlen
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	testInternalErr := myerrors.NewErrorInternal("Test error")
+	uploadQuota := usecases.UploadQuota{Bytes: 1024 * 1024, Files: 10}
+
+	contentJPEG := newTestJPEGWithMetadata(t, 30, 20, 1)
+
+	stripped, err := usecases.StripMetadata(contentJPEG)
+	if err != nil {
+		t.Fatalf("unexpected err=%+v", err)
+	}
+
+	hash := sha256.Sum256(stripped)
+	expectedName := hex.EncodeToString(hash[:])
+
+	bomb := newTestPNGBomb(50000, 50000)
+
+	type TestCase struct {
+		name                 string
+		content              []byte
+		behaviorFileStorage  func(m *mocks.MockIFileStorageHTTP)
+		behaviorQuotaStorage func(m *mocks.MockIUploadQuotaStorage)
+		expectedURL          string
+		expectedError        error
+	}
+
+	testCases := [...]TestCase{
+		{
+			name:    "test basic work",
+			content: contentJPEG,
+			behaviorFileStorage: func(m *mocks.MockIFileStorageHTTP) {
+				m.EXPECT().SaveFile(gomock.Any(), gomock.Any(), expectedName+"_thumbnail").Return(nil)
+				m.EXPECT().SaveFile(gomock.Any(), gomock.Any(), expectedName+"_card").Return(nil)
+				m.EXPECT().SaveFile(gomock.Any(), stripped, expectedName).Return(nil)
+			},
+			behaviorQuotaStorage: func(m *mocks.MockIUploadQuotaStorage) {
+				m.EXPECT().Reserve(gomock.Any(), test.UserID, int64(len(contentJPEG)),
+					uploadQuota.Bytes, uploadQuota.Files).Return(nil)
+			},
+			expectedURL:   "img/" + expectedName,
+			expectedError: nil,
+		},
+		{
+			name:                 "test wrong format",
+			content:              []byte("GIF89a not supported"),
+			behaviorFileStorage:  func(m *mocks.MockIFileStorageHTTP) {},
+			behaviorQuotaStorage: func(m *mocks.MockIUploadQuotaStorage) {},
+			expectedURL:          "",
+			expectedError:        usecases.ErrWrongFormat,
+		},
+		{
+			name:                 "test too big file",
+			content:              append(newTestPNGBomb(10, 10), make([]byte, usecases.MaxSizePhotoBytes)...),
+			behaviorFileStorage:  func(m *mocks.MockIFileStorageHTTP) {},
+			behaviorQuotaStorage: func(m *mocks.MockIUploadQuotaStorage) {},
+			expectedURL:          "",
+			expectedError:        usecases.ErrToBigFile,
+		},
+		{
+			name:                "test decompression bomb",
+			content:             bomb,
+			behaviorFileStorage: func(m *mocks.MockIFileStorageHTTP) {},
+			behaviorQuotaStorage: func(m *mocks.MockIUploadQuotaStorage) {
+				gomock.InOrder(
+					m.EXPECT().Reserve(gomock.Any(), test.UserID, int64(len(bomb)),
+						gomock.Any(), gomock.Any()).Return(nil),
+					m.EXPECT().Release(gomock.Any(), test.UserID, int64(len(bomb))).Return(nil),
+				)
+			},
+			expectedURL:   "",
+			expectedError: usecases.ErrTooBigImage,
+		},
+		{
+			name:                "test quota exceeded before decoding",
+			content:             bomb,
+			behaviorFileStorage: func(m *mocks.MockIFileStorageHTTP) {},
+			behaviorQuotaStorage: func(m *mocks.MockIUploadQuotaStorage) {
+				m.EXPECT().Reserve(gomock.Any(), test.UserID, int64(len(bomb)), gomock.Any(), gomock.Any()).
+					Return(repository.ErrUploadQuotaExceeded)
+			},
+			expectedURL:   "",
+			expectedError: repository.ErrUploadQuotaExceeded,
+		},
+		{
+			name:                "test quota exceeded",
+			content:             contentJPEG,
+			behaviorFileStorage: func(m *mocks.MockIFileStorageHTTP) {},
+			behaviorQuotaStorage: func(m *mocks.MockIUploadQuotaStorage) {
+				m.EXPECT().Reserve(gomock.Any(), test.UserID, gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(repository.ErrUploadQuotaExceeded)
+			},
+			expectedURL:   "",
+			expectedError: repository.ErrUploadQuotaExceeded,
+		},
+		{
+			name:    "test internal error of storage",
+			content: contentJPEG,
+			behaviorFileStorage: func(m *mocks.MockIFileStorageHTTP) {
+				m.EXPECT().SaveFile(gomock.Any(), gomock.Any(), gomock.Any()).Return(testInternalErr)
+			},
+			behaviorQuotaStorage: func(m *mocks.MockIUploadQuotaStorage) {
+				gomock.InOrder(
+					m.EXPECT().Reserve(gomock.Any(), test.UserID, int64(len(contentJPEG)),
+						gomock.Any(), gomock.Any()).Return(nil),
+					m.EXPECT().Release(gomock.Any(), test.UserID, int64(len(contentJPEG))).Return(nil),
+				)
+			},
+			expectedURL:   "",
+			expectedError: testInternalErr,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			mockFileStorage := mocks.NewMockIFileStorageHTTP(ctrl)
+			mockQuotaStorage := mocks.NewMockIUploadQuotaStorage(ctrl)
+
+			testCase.behaviorFileStorage(mockFileStorage)
+			testCase.behaviorQuotaStorage(mockQuotaStorage)
+
+			fileService, err := usecases.NewFileServiceHTTP(mockFileStorage, "img/", mockQuotaStorage, uploadQuota)
+			if err != nil {
+				t.Fatalf("unexpected err=%+v", err)
+			}
+
+			url, err := fileService.SaveImage(context.Background(), test.UserID, bytes.NewReader(testCase.content))
+			if errInner := utils.EqualError(err, testCase.expectedError); errInner != nil {
+				t.Fatalf("Failed EqualError: %+v", errInner)
+			}
+
+			if url != testCase.expectedURL {
+				t.Fatalf("got url %s, expected %s", url, testCase.expectedURL)
+			}
+		})
+	}
+}
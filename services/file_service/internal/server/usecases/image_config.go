@@ -0,0 +1,58 @@
+package usecases
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"io"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+)
+
+const (
+	// MaxSideImage and MaxPixelsImage protect from decompression bombs: small file,
+	// which takes gigabytes of memory after decoding. 50 megapixels are about 200 Мбайт in RGBA.
+	MaxSideImage   = 12000
+	MaxPixelsImage = 50 * 1000 * 1000
+
+	// LenSniff is count of first bytes enough for SniffFormat.
+	LenSniff = 8
+)
+
+var ErrTooBigImage = myerrors.NewErrorBadContentRequest(
+	"Максимальное разрешение фото %d мегапикселей, сторона не больше %d пикселей",
+	MaxPixelsImage/1000/1000, MaxSideImage) //nolint:gomnd
+
+var magicJPEG = []byte{0xFF, markerJPEGStart, 0xFF} //nolint:gochecknoglobals
+
+// SniffFormat detects format by magic bytes of start of content, empty string means not supported format.
+// Unlike http.DetectContentType it doesn't accept other formats of images.
+func SniffFormat(header []byte) string {
+	switch {
+	case bytes.HasPrefix(header, magicJPEG):
+		return formatJPEG
+	case bytes.HasPrefix(header, pngHeader):
+		return formatPNG
+	}
+
+	return ""
+}
+
+// DecodeImageConfig reads only header of image to check format and dimensions before decoding of pixels.
+func DecodeImageConfig(reader io.Reader) (image.Config, string, error) {
+	config, format, err := image.DecodeConfig(reader)
+	if err != nil || (format != formatJPEG && format != formatPNG) {
+		return config, format, fmt.Errorf("вы используете формат %s %w", format, ErrWrongFormat)
+	}
+
+	if config.Width <= 0 || config.Height <= 0 {
+		return config, format, fmt.Errorf(myerrors.ErrTemplate, ErrBrokenImage)
+	}
+
+	if config.Width > MaxSideImage || config.Height > MaxSideImage ||
+		config.Width*config.Height > MaxPixelsImage {
+		return config, format, fmt.Errorf("фото %dx%d %w", config.Width, config.Height, ErrTooBigImage)
+	}
+
+	return config, format, nil
+}
@@ -0,0 +1,312 @@
+package usecases
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+)
+
+const (
+	markerJPEGAPP13 = 0xED
+	markerJPEGCOM   = 0xFE
+
+	lenPNGChunkHeader = 8
+	lenPNGChunkCRC    = 4
+)
+
+var ErrBrokenImage = myerrors.NewErrorBadContentRequest("Файл изображения поврежден")
+
+// pngMetadataChunks contains chunks with text, time of creation and EXIF. XMP is stored in iTXt.
+var pngMetadataChunks = map[string]struct{}{ //nolint:gochecknoglobals
+	"eXIf": {},
+	"tEXt": {},
+	"zTXt": {},
+	"iTXt": {},
+	"tIME": {},
+}
+
+// isJPEGMetadataMarker reports whether segment can contain personal data:
+// APP1 (EXIF with GPS, XMP), APP13 (IPTC) and comments.
+func isJPEGMetadataMarker(marker byte) bool {
+	return marker == markerJPEGAPP1 || marker == markerJPEGAPP13 || marker == markerJPEGCOM
+}
+
+// StripMetadata returns jpeg or png content without EXIF, XMP, IPTC and text metadata,
+// pixels aren't re-encoded. Content of other formats is returned as is.
+func StripMetadata(content []byte) ([]byte, error) {
+	result := bytes.NewBuffer(make([]byte, 0, len(content)))
+
+	_, err := StripMetadataStream(result, bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Bytes(), nil
+}
+
+// StripMetadataStream copies content from reader to writer like StripMetadata, but doesn't keep
+// whole file in memory. EXIF is dropped, so its orientation is returned, OrientationNormal if there is no one.
+// Errors of reader are returned as is, truncated content is ErrBrokenImage.
+func StripMetadataStream(writer io.Writer, reader io.Reader) (int, error) {
+	bufReader := bufio.NewReader(reader)
+
+	header, _ := bufReader.Peek(len(pngHeader))
+
+	switch {
+	case len(header) > 2 && header[0] == 0xFF && header[1] == markerJPEGStart:
+		return stripJPEGMetadata(writer, bufReader)
+	case bytes.Equal(header, pngHeader):
+		return stripPNGMetadata(writer, bufReader)
+	}
+
+	_, err := io.Copy(writer, bufReader)
+	if err != nil {
+		return OrientationNormal, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return OrientationNormal, nil
+}
+
+// wrapErrRead converts unexpected end of content to ErrBrokenImage.
+func wrapErrRead(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf(myerrors.ErrTemplate, ErrBrokenImage)
+	}
+
+	return fmt.Errorf(myerrors.ErrTemplate, err)
+}
+
+// isJPEGRestartMarker reports whether marker is RSTn, which is part of entropy-coded data.
+func isJPEGRestartMarker(marker byte) bool {
+	return marker >= 0xD0 && marker <= 0xD7
+}
+
+// copyEntropyData copies entropy-coded data after start of scan and returns marker which ends it:
+// EOI or next segment of progressive jpeg. Stuffed 0xFF00 and restart markers are part of data.
+func copyEntropyData(writer io.Writer, reader *bufio.Reader) (byte, error) {
+	for {
+		chunk, err := reader.ReadSlice(0xFF)
+		if errors.Is(err, bufio.ErrBufferFull) {
+			_, err = writer.Write(chunk)
+			if err != nil {
+				return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			return 0, wrapErrRead(err)
+		}
+
+		// 0xFF is written only after it's known that it doesn't start marker
+		_, err = writer.Write(chunk[:len(chunk)-1])
+		if err != nil {
+			return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		marker, err := reader.ReadByte()
+		if err != nil {
+			return 0, wrapErrRead(err)
+		}
+
+		switch {
+		case marker == 0x00 || isJPEGRestartMarker(marker):
+			_, err = writer.Write([]byte{0xFF, marker})
+			if err != nil {
+				return 0, fmt.Errorf(myerrors.ErrTemplate, err)
+			}
+		case marker == 0xFF: // fill byte
+			_ = reader.UnreadByte()
+		default:
+			return marker, nil
+		}
+	}
+}
+
+// stripJPEGMetadata drops metadata segments, scans are copied as is. Content after EOI is dropped.
+func stripJPEGMetadata(writer io.Writer, reader *bufio.Reader) (int, error) { //nolint:cyclop,funlen
+	orientation := OrientationNormal
+
+	head := make([]byte, 2) //nolint:gomnd
+
+	_, err := io.ReadFull(reader, head)
+	if err != nil {
+		return orientation, wrapErrRead(err)
+	}
+
+	_, err = writer.Write(head)
+	if err != nil {
+		return orientation, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	// marker which ended entropy-coded data is already read
+	var markerAfterScan byte
+
+	for {
+		marker := markerAfterScan
+		markerAfterScan = 0
+
+		if marker == 0 {
+			start, err := reader.ReadByte()
+			if errors.Is(err, io.EOF) {
+				return orientation, nil
+			}
+
+			if err != nil {
+				return orientation, wrapErrRead(err)
+			}
+
+			if start != 0xFF {
+				return orientation, fmt.Errorf(myerrors.ErrTemplate, ErrBrokenImage)
+			}
+
+			marker, err = reader.ReadByte()
+			if err != nil {
+				return orientation, wrapErrRead(err)
+			}
+		}
+
+		switch {
+		case marker == 0xFF: // fill byte
+			_ = reader.UnreadByte()
+
+			continue
+		case marker == markerJPEGEnd: // content after end of image is dropped, it may contain metadata
+			_, err = writer.Write([]byte{0xFF, marker})
+			if err != nil {
+				return orientation, fmt.Errorf(myerrors.ErrTemplate, err)
+			}
+
+			return orientation, nil
+		case marker == 0x01 || isJPEGRestartMarker(marker): // markers without length
+			_, err = writer.Write([]byte{0xFF, marker})
+			if err != nil {
+				return orientation, fmt.Errorf(myerrors.ErrTemplate, err)
+			}
+
+			continue
+		}
+
+		segment := []byte{0xFF, marker, 0, 0}
+
+		_, err = io.ReadFull(reader, segment[2:])
+		if err != nil {
+			return orientation, wrapErrRead(err)
+		}
+
+		length := int(binary.BigEndian.Uint16(segment[2:]))
+		if length < 2 { //nolint:gomnd
+			return orientation, fmt.Errorf(myerrors.ErrTemplate, ErrBrokenImage)
+		}
+
+		segment = append(segment, make([]byte, length-2)...)
+
+		_, err = io.ReadFull(reader, segment[4:])
+		if err != nil {
+			return orientation, wrapErrRead(err)
+		}
+
+		// after start of scan goes entropy-coded data, it's copied until the next marker
+		if marker == markerJPEGSOS {
+			_, err = writer.Write(segment)
+			if err != nil {
+				return orientation, fmt.Errorf(myerrors.ErrTemplate, err)
+			}
+
+			markerAfterScan, err = copyEntropyData(writer, reader)
+			if err != nil {
+				return orientation, err
+			}
+
+			continue
+		}
+
+		if isJPEGMetadataMarker(marker) {
+			data := segment[4:]
+			if marker == markerJPEGAPP1 && bytes.HasPrefix(data, exifHeader) && orientation == OrientationNormal {
+				orientation = validOrientation(parseTIFFOrientation(data[len(exifHeader):]))
+			}
+
+			continue
+		}
+
+		_, err = writer.Write(segment)
+		if err != nil {
+			return orientation, fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+	}
+}
+
+// stripPNGMetadata chunks are copied without buffering, only eXIf is read to get orientation.
+// Content after IEND is dropped.
+func stripPNGMetadata(writer io.Writer, reader *bufio.Reader) (int, error) { //nolint:cyclop
+	orientation := OrientationNormal
+	seenData := false
+
+	_, err := reader.Discard(len(pngHeader))
+	if err != nil {
+		return orientation, wrapErrRead(err)
+	}
+
+	_, err = writer.Write(pngHeader)
+	if err != nil {
+		return orientation, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	chunkHeader := make([]byte, lenPNGChunkHeader)
+
+	for {
+		_, err = io.ReadFull(reader, chunkHeader)
+		if errors.Is(err, io.EOF) {
+			return orientation, nil
+		}
+
+		if err != nil {
+			return orientation, wrapErrRead(err)
+		}
+
+		length := int64(binary.BigEndian.Uint32(chunkHeader))
+		chunkType := string(chunkHeader[4:])
+
+		if _, ok := pngMetadataChunks[chunkType]; ok {
+			data, err := io.ReadAll(io.LimitReader(reader, length+lenPNGChunkCRC))
+			if err != nil {
+				return orientation, wrapErrRead(err)
+			}
+
+			if int64(len(data)) != length+lenPNGChunkCRC {
+				return orientation, fmt.Errorf(myerrors.ErrTemplate, ErrBrokenImage)
+			}
+
+			if chunkType == "eXIf" && !seenData && orientation == OrientationNormal {
+				orientation = validOrientation(parseTIFFOrientation(data[:length]))
+			}
+
+			continue
+		}
+
+		_, err = writer.Write(chunkHeader)
+		if err != nil {
+			return orientation, fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		_, err = io.CopyN(writer, reader, length+lenPNGChunkCRC)
+		if err != nil {
+			return orientation, wrapErrRead(err)
+		}
+
+		if chunkType == "IDAT" {
+			seenData = true
+		}
+
+		if chunkType == "IEND" {
+			return orientation, nil
+		}
+	}
+}
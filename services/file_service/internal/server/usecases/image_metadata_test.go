@@ -0,0 +1,221 @@
+package usecases_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/file_service/internal/server/usecases"
+)
+
+const (
+	testGPS = "GPSLatitude 55.7558 GPSLongitude 37.6173"
+	testXMP = "http://ns.adobe.com/xap/1.0/\x00<x:xmpmeta>" + testGPS + "</x:xmpmeta>"
+)
+
+// markersOfMetadata mustn't be found in content after stripping.
+var markersOfMetadata = [...]string{"Exif\x00\x00", "http://ns.adobe.com/xap/1.0/", "xmpmeta", "GPS", //nolint:gochecknoglobals
+	"eXIf", "tEXt", "iTXt", "Photoshop 3.0"}
+
+func jpegSegment(marker byte, data []byte) []byte {
+	segment := []byte{0xFF, marker, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(data)+2))
+
+	return append(segment, data...)
+}
+
+// newTestJPEGWithMetadata returns jpeg with EXIF (orientation and GPS), XMP, IPTC and comment.
+func newTestJPEGWithMetadata(t *testing.T, width, height int, orientation uint16) []byte {
+	t.Helper()
+
+	content := newTestJPEG(t, width, height, 0)
+
+	exif := append([]byte("Exif\x00\x00"), exifWithOrientation(orientation)...)
+	exif = append(exif, testGPS...)
+
+	metadata := jpegSegment(0xE1, exif)
+	metadata = append(metadata, jpegSegment(0xE1, []byte(testXMP))...)
+	metadata = append(metadata, jpegSegment(0xED, []byte("Photoshop 3.0\x00"+testGPS))...)
+	metadata = append(metadata, jpegSegment(0xFE, []byte(testGPS))...)
+
+	return append(append([]byte{0xFF, 0xD8}, metadata...), content[2:]...)
+}
+
+func pngChunk(chunkType string, data []byte) []byte {
+	chunk := make([]byte, 4, 12+len(data))
+	binary.BigEndian.PutUint32(chunk, uint32(len(data)))
+	chunk = append(chunk, chunkType...)
+	chunk = append(chunk, data...)
+
+	return binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+}
+
+// newTestPNGWithMetadata returns png with eXIf, XMP in iTXt and tEXt after IHDR.
+func newTestPNGWithMetadata(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	content := newTestPNG(t, width, height)
+
+	const endIHDR = 8 + 8 + 13 + 4
+
+	metadata := pngChunk("eXIf", append(exifWithOrientation(1), testGPS...))
+	metadata = append(metadata, pngChunk("iTXt", []byte("XML:com.adobe.xmp\x00\x00\x00\x00\x00"+testXMP))...)
+	metadata = append(metadata, pngChunk("tEXt", []byte("Comment\x00"+testGPS))...)
+
+	result := append([]byte{}, content[:endIHDR]...)
+	result = append(result, metadata...)
+
+	return append(result, content[endIHDR:]...)
+}
+
+func checkNoMetadata(t *testing.T, content []byte) {
+	t.Helper()
+
+	for _, marker := range markersOfMetadata {
+		if bytes.Contains(content, []byte(marker)) {
+			t.Fatalf("found metadata %q in content", marker)
+		}
+	}
+}
+
+func TestStripMetadata(t *testing.T) {
+	t.Parallel()
+
+	type TestCase struct {
+		name    string
+		content []byte
+	}
+
+	testCases := [...]TestCase{
+		{name: "test jpeg", content: newTestJPEGWithMetadata(t, 30, 20, 1)},
+		{
+			name: "test jpeg with exif after end of image",
+			content: append(newTestJPEG(t, 30, 20, 0),
+				jpegSegment(0xE1, append([]byte("Exif\x00\x00"), testGPS...))...),
+		},
+		{name: "test png", content: newTestPNGWithMetadata(t, 30, 20)},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if !bytes.Contains(testCase.content, []byte(testGPS)) {
+				t.Fatalf("test content doesn't contain metadata")
+			}
+
+			stripped, err := usecases.StripMetadata(testCase.content)
+			if err != nil {
+				t.Fatalf("unexpected err=%+v", err)
+			}
+
+			checkNoMetadata(t, stripped)
+
+			original, _, err := image.Decode(bytes.NewReader(testCase.content))
+			if err != nil {
+				t.Fatalf("unexpected err=%+v", err)
+			}
+
+			result, _, err := image.Decode(bytes.NewReader(stripped))
+			if err != nil {
+				t.Fatalf("stripped content isn't image: %+v", err)
+			}
+
+			if original.Bounds() != result.Bounds() || original.At(10, 10) != result.At(10, 10) {
+				t.Fatalf("stripping changed pixels")
+			}
+		})
+	}
+}
+
+func TestStripMetadataBroken(t *testing.T) {
+	t.Parallel()
+
+	content := newTestJPEGWithMetadata(t, 30, 20, 1)
+
+	_, err := usecases.StripMetadata(content[:10])
+	if errInner := utils.EqualError(err, usecases.ErrBrokenImage); errInner != nil {
+		t.Fatalf("Failed EqualError: %+v", errInner)
+	}
+}
+
+func TestMakeImageVariantsWithoutMetadata(t *testing.T) {
+	t.Parallel()
+
+	type TestCase struct {
+		name    string
+		content []byte
+	}
+
+	testCases := [...]TestCase{
+		{name: "test small jpeg kept as is", content: newTestJPEGWithMetadata(t, 30, 20, 1)},
+		{name: "test rotated jpeg", content: newTestJPEGWithMetadata(t, 300, 200, 6)},
+		{name: "test png", content: newTestPNGWithMetadata(t, 300, 200)},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			imageVariants, err := usecases.MakeImageVariants(testCase.content)
+			if err != nil {
+				t.Fatalf("unexpected err=%+v", err)
+			}
+
+			for _, imageVariant := range imageVariants {
+				checkNoMetadata(t, imageVariant.Content)
+			}
+		})
+	}
+}
+
+func TestStripMetadataStreamOrientation(t *testing.T) {
+	t.Parallel()
+
+	type TestCase struct {
+		name                string
+		content             []byte
+		expectedOrientation int
+	}
+
+	testCases := [...]TestCase{
+		{
+			name:                "test jpeg rotated",
+			content:             newTestJPEGWithMetadata(t, 30, 20, usecases.OrientationRotate90),
+			expectedOrientation: usecases.OrientationRotate90,
+		},
+		{name: "test png", content: newTestPNGWithMetadata(t, 30, 20), expectedOrientation: usecases.OrientationNormal},
+		{name: "test not image", content: []byte("text"), expectedOrientation: usecases.OrientationNormal},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			stripped := new(bytes.Buffer)
+
+			orientation, err := usecases.StripMetadataStream(stripped, bytes.NewReader(testCase.content))
+			if err != nil {
+				t.Fatalf("unexpected err=%+v", err)
+			}
+
+			if orientation != testCase.expectedOrientation {
+				t.Fatalf("got orientation %d, expected %d", orientation, testCase.expectedOrientation)
+			}
+
+			expected, err := usecases.StripMetadata(testCase.content)
+			if err != nil || !bytes.Equal(stripped.Bytes(), expected) {
+				t.Fatalf("content of stream differs from StripMetadata, err=%+v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package usecases
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/draw"
+)
+
+// Values of EXIF tag Orientation, 1 means image is stored as it must be shown.
+const (
+	OrientationNormal     = 1
+	OrientationFlipH      = 2
+	OrientationRotate180  = 3
+	OrientationFlipV      = 4
+	OrientationTranspose  = 5
+	OrientationRotate90   = 6
+	OrientationTransverse = 7
+	OrientationRotate270  = 8
+
+	exifTagOrientation = 0x0112
+	exifTypeShort      = 3
+
+	markerJPEGStart = 0xD8
+	markerJPEGEnd   = 0xD9
+	markerJPEGSOS   = 0xDA
+	markerJPEGAPP1  = 0xE1
+)
+
+var (
+	exifHeader = []byte("Exif\x00\x00")      //nolint:gochecknoglobals
+	pngHeader  = []byte("\x89PNG\r\n\x1a\n") //nolint:gochecknoglobals
+)
+
+// ReadOrientation returns EXIF orientation of jpeg or png (chunk eXIf) content,
+// OrientationNormal is returned if there is no EXIF or it is broken.
+func ReadOrientation(content []byte) int {
+	var tiff []byte
+
+	switch {
+	case len(content) > 2 && content[0] == 0xFF && content[1] == markerJPEGStart:
+		tiff = findJPEGExif(content)
+	case bytes.HasPrefix(content, pngHeader):
+		tiff = findPNGExif(content)
+	}
+
+	return validOrientation(parseTIFFOrientation(tiff))
+}
+
+// validOrientation replaces unknown values of orientation by OrientationNormal.
+func validOrientation(orientation int) int {
+	if orientation < OrientationNormal || orientation > OrientationRotate270 {
+		return OrientationNormal
+	}
+
+	return orientation
+}
+
+// findJPEGExif returns TIFF data of first APP1 Exif segment.
+func findJPEGExif(content []byte) []byte {
+	for i := 2; i+4 <= len(content); {
+		if content[i] != 0xFF {
+			return nil
+		}
+
+		marker := content[i+1]
+
+		switch {
+		case marker == 0xFF: // fill byte
+			i++
+
+			continue
+		case marker == markerJPEGEnd || marker == markerJPEGSOS:
+			return nil
+		case marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7): // markers without length
+			i += 2
+
+			continue
+		}
+
+		length := int(binary.BigEndian.Uint16(content[i+2:]))
+		if length < 2 || i+2+length > len(content) {
+			return nil
+		}
+
+		segment := content[i+4 : i+2+length]
+		if marker == markerJPEGAPP1 && bytes.HasPrefix(segment, exifHeader) {
+			return segment[len(exifHeader):]
+		}
+
+		i += 2 + length
+	}
+
+	return nil
+}
+
+// findPNGExif returns data of chunk eXIf, which contains TIFF without Exif header.
+func findPNGExif(content []byte) []byte {
+	for i := len(pngHeader); i+8 <= len(content); {
+		length := int(binary.BigEndian.Uint32(content[i:]))
+		chunkType := string(content[i+4 : i+8])
+
+		if length < 0 || i+12+length > len(content) {
+			return nil
+		}
+
+		if chunkType == "eXIf" {
+			return content[i+8 : i+8+length]
+		}
+
+		if chunkType == "IDAT" || chunkType == "IEND" {
+			return nil
+		}
+
+		i += 12 + length
+	}
+
+	return nil
+}
+
+func parseTIFFOrientation(tiff []byte) int {
+	if len(tiff) < 8 { //nolint:gomnd
+		return OrientationNormal
+	}
+
+	var order binary.ByteOrder
+
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return OrientationNormal
+	}
+
+	offsetIFD := int(order.Uint32(tiff[4:]))
+	if offsetIFD+2 > len(tiff) || offsetIFD < 8 { //nolint:gomnd
+		return OrientationNormal
+	}
+
+	countEntries := int(order.Uint16(tiff[offsetIFD:]))
+
+	for i := 0; i < countEntries; i++ {
+		entry := offsetIFD + 2 + i*12 //nolint:gomnd
+		if entry+12 > len(tiff) {
+			return OrientationNormal
+		}
+
+		if order.Uint16(tiff[entry:]) == exifTagOrientation &&
+			order.Uint16(tiff[entry+2:]) == exifTypeShort {
+			return int(order.Uint16(tiff[entry+8:]))
+		}
+	}
+
+	return OrientationNormal
+}
+
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok && rgba.Rect.Min == (image.Point{}) {
+		return rgba
+	}
+
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+
+	return rgba
+}
+
+// ApplyOrientation returns image, which looks like img shown by viewer respecting EXIF orientation.
+func ApplyOrientation(img image.Image, orientation int) *image.RGBA {
+	src := toRGBA(img)
+	if orientation <= OrientationNormal || orientation > OrientationRotate270 {
+		return src
+	}
+
+	width, height := src.Rect.Dx(), src.Rect.Dy()
+
+	dstWidth, dstHeight := width, height
+	if orientation >= OrientationTranspose {
+		dstWidth, dstHeight = height, width
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+
+	for dstY := 0; dstY < dstHeight; dstY++ {
+		for dstX := 0; dstX < dstWidth; dstX++ {
+			var srcX, srcY int
+
+			switch orientation {
+			case OrientationFlipH:
+				srcX, srcY = width-1-dstX, dstY
+			case OrientationRotate180:
+				srcX, srcY = width-1-dstX, height-1-dstY
+			case OrientationFlipV:
+				srcX, srcY = dstX, height-1-dstY
+			case OrientationTranspose:
+				srcX, srcY = dstY, dstX
+			case OrientationRotate90:
+				srcX, srcY = dstY, height-1-dstX
+			case OrientationTransverse:
+				srcX, srcY = width-1-dstY, height-1-dstX
+			case OrientationRotate270:
+				srcX, srcY = width-1-dstY, dstX
+			}
+
+			copy(dst.Pix[dst.PixOffset(dstX, dstY):dst.PixOffset(dstX, dstY)+4],
+				src.Pix[src.PixOffset(srcX, srcY):src.PixOffset(srcX, srcY)+4])
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,180 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/metrics"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/file_service/internal/server/repository"
+)
+
+// maxCountReferencedQuery limits count of file names in one query to storage of references.
+const maxCountReferencedQuery = 1000
+
+var (
+	_ IFileStorageSweeper = (*repository.FileSystemStorage)(nil)
+	_ IFileStorageSweeper = (*repository.S3Storage)(nil)
+)
+
+type IFileStorageSweeper interface {
+	List(ctx context.Context) ([]repository.StoredFile, error)
+	DeleteFile(ctx context.Context, fileName string) error
+}
+
+var _ IImageReferenceStorageSweeper = (*repository.ImageReferenceStorage)(nil)
+
+type IImageReferenceStorageSweeper interface {
+	GetReferenced(ctx context.Context, fileNames []string) (map[string]struct{}, error)
+}
+
+type SweepResult struct {
+	Scanned      int
+	Deleted      int
+	DeletedBytes int64
+}
+
+// ImageSweeper deletes files, which original has no owners and which are older than gracePeriod.
+// Grace period gives time to attach uploaded image to product. In dryRun files are only logged.
+type ImageSweeper struct {
+	fileStorage      IFileStorageSweeper
+	referenceStorage IImageReferenceStorageSweeper
+	metricManager    metrics.IMetricManagerSweeper
+	gracePeriod      time.Duration
+	dryRun           bool
+	logger           *mylogger.MyLogger
+}
+
+func NewImageSweeper(fileStorage IFileStorageSweeper, referenceStorage IImageReferenceStorageSweeper,
+	metricManager metrics.IMetricManagerSweeper, gracePeriod time.Duration, dryRun bool,
+) (*ImageSweeper, error) {
+	logger, err := mylogger.Get()
+	if err != nil {
+		return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	return &ImageSweeper{
+		fileStorage:      fileStorage,
+		referenceStorage: referenceStorage,
+		metricManager:    metricManager,
+		gracePeriod:      gracePeriod,
+		dryRun:           dryRun,
+		logger:           logger,
+	}, nil
+}
+
+func (i *ImageSweeper) getReferenced(ctx context.Context, originals []string) (map[string]struct{}, error) {
+	referenced := make(map[string]struct{}, len(originals))
+
+	for start := 0; start < len(originals); start += maxCountReferencedQuery {
+		end := min(start+maxCountReferencedQuery, len(originals))
+
+		part, err := i.referenceStorage.GetReferenced(ctx, originals[start:end])
+		if err != nil {
+			return nil, fmt.Errorf(myerrors.ErrTemplate, err)
+		}
+
+		for fileName := range part {
+			referenced[fileName] = struct{}{}
+		}
+	}
+
+	return referenced, nil
+}
+
+// Sweep variants are deleted together with original, because they have the same owners.
+func (i *ImageSweeper) Sweep(ctx context.Context, now time.Time) (SweepResult, error) {
+	logger := i.logger.LogReqID(ctx)
+
+	var result SweepResult
+
+	files, err := i.fileStorage.List(ctx)
+	if err != nil {
+		return result, fmt.Errorf(myerrors.ErrTemplate, err)
+	}
+
+	result.Scanned = len(files)
+
+	deadline := now.Add(-i.gracePeriod)
+	oldFiles := make([]repository.StoredFile, 0)
+	originals := make([]string, 0)
+	setOriginals := make(map[string]struct{})
+
+	for _, file := range files {
+		if !file.ModTime.Before(deadline) {
+			continue
+		}
+
+		oldFiles = append(oldFiles, file)
+
+		original := OriginalName(file.Name)
+		if _, ok := setOriginals[original]; !ok {
+			setOriginals[original] = struct{}{}
+			originals = append(originals, original)
+		}
+	}
+
+	referenced, err := i.getReferenced(ctx, originals)
+	if err != nil {
+		return result, err
+	}
+
+	for _, file := range oldFiles {
+		if _, ok := referenced[OriginalName(file.Name)]; ok {
+			continue
+		}
+
+		if i.dryRun {
+			logger.Infof("gc dry run: file %s without owner would be deleted", file.Name)
+		} else {
+			err = i.fileStorage.DeleteFile(ctx, file.Name)
+			if err != nil {
+				return result, fmt.Errorf(myerrors.ErrTemplate, err)
+			}
+
+			logger.Infof("gc: deleted file %s without owner", file.Name)
+		}
+
+		result.Deleted++
+		result.DeletedBytes += file.Size
+	}
+
+	return result, nil
+}
+
+// StartSweeping periodically deletes files without owners until chClose is closed.
+func (i *ImageSweeper) StartSweeping(ctx context.Context, period time.Duration, chClose <-chan struct{}) {
+	go func() {
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-chClose:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				start := time.Now()
+
+				result, err := i.Sweep(ctx, start)
+
+				i.metricManager.AddScanned(result.Scanned)
+				i.metricManager.AddDeleted(result.Deleted, result.DeletedBytes, i.dryRun)
+				i.metricManager.SetLastRun(time.Since(start))
+
+				if err != nil {
+					i.metricManager.IncErr()
+					i.logger.Errorln(err)
+
+					continue
+				}
+
+				i.logger.Infof("gc: scanned %d files, deleted %d files %d bytes, dry run %t",
+					result.Scanned, result.Deleted, result.DeletedBytes, i.dryRun)
+			}
+		}
+	}()
+}
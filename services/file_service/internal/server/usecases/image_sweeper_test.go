@@ -0,0 +1,116 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/mylogger"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/utils"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/file_service/internal/server/mocks"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/file_service/internal/server/repository"
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/file_service/internal/server/usecases"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSweep(t *testing.T) {
+	t.Parallel()
+
+	_ = mylogger.NewNop()
+
+	now := time.Date(2024, 2, 15, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-48 * time.Hour)
+	testInternalErr := myerrors.NewErrorInternal("Test error")
+
+	// orphan and its variant are old and without owner, used is referenced and young was uploaded recently.
+	files := []repository.StoredFile{
+		{Name: "orphan", ModTime: old, Size: 10},
+		{Name: "orphan_thumbnail", ModTime: old, Size: 1},
+		{Name: "used", ModTime: old, Size: 100},
+		{Name: "used_card", ModTime: old, Size: 50},
+		{Name: "young", ModTime: now.Add(-time.Hour), Size: 1000},
+	}
+
+	type TestCase struct {
+		name                     string
+		dryRun                   bool
+		behaviorFileStorage      func(m *mocks.MockIFileStorageSweeper)
+		behaviorReferenceStorage func(m *mocks.MockIImageReferenceStorageSweeper)
+		expectedResult           usecases.SweepResult
+		expectedError            error
+	}
+
+	testCases := [...]TestCase{
+		{
+			name:   "test basic work",
+			dryRun: false,
+			behaviorFileStorage: func(m *mocks.MockIFileStorageSweeper) {
+				m.EXPECT().List(gomock.Any()).Return(files, nil)
+				m.EXPECT().DeleteFile(gomock.Any(), "orphan").Return(nil)
+				m.EXPECT().DeleteFile(gomock.Any(), "orphan_thumbnail").Return(nil)
+			},
+			behaviorReferenceStorage: func(m *mocks.MockIImageReferenceStorageSweeper) {
+				m.EXPECT().GetReferenced(gomock.Any(), []string{"orphan", "used"}).
+					Return(map[string]struct{}{"used": {}}, nil)
+			},
+			expectedResult: usecases.SweepResult{Scanned: 5, Deleted: 2, DeletedBytes: 11},
+			expectedError:  nil,
+		},
+		{
+			name:   "test dry run",
+			dryRun: true,
+			behaviorFileStorage: func(m *mocks.MockIFileStorageSweeper) {
+				m.EXPECT().List(gomock.Any()).Return(files, nil)
+			},
+			behaviorReferenceStorage: func(m *mocks.MockIImageReferenceStorageSweeper) {
+				m.EXPECT().GetReferenced(gomock.Any(), []string{"orphan", "used"}).
+					Return(map[string]struct{}{"used": {}}, nil)
+			},
+			expectedResult: usecases.SweepResult{Scanned: 5, Deleted: 2, DeletedBytes: 11},
+			expectedError:  nil,
+		},
+		{
+			name:   "test internal error of references",
+			dryRun: false,
+			behaviorFileStorage: func(m *mocks.MockIFileStorageSweeper) {
+				m.EXPECT().List(gomock.Any()).Return(files, nil)
+			},
+			behaviorReferenceStorage: func(m *mocks.MockIImageReferenceStorageSweeper) {
+				m.EXPECT().GetReferenced(gomock.Any(), gomock.Any()).Return(nil, testInternalErr)
+			},
+			expectedResult: usecases.SweepResult{Scanned: 5, Deleted: 0, DeletedBytes: 0},
+			expectedError:  testInternalErr,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			mockFileStorage := mocks.NewMockIFileStorageSweeper(ctrl)
+			mockReferenceStorage := mocks.NewMockIImageReferenceStorageSweeper(ctrl)
+
+			testCase.behaviorFileStorage(mockFileStorage)
+			testCase.behaviorReferenceStorage(mockReferenceStorage)
+
+			imageSweeper, err := usecases.NewImageSweeper(mockFileStorage, mockReferenceStorage,
+				nil, 24*time.Hour, testCase.dryRun)
+			if err != nil {
+				t.Fatalf("unexpected err=%+v", err)
+			}
+
+			result, err := imageSweeper.Sweep(context.Background(), now)
+			if errInner := utils.EqualError(err, testCase.expectedError); errInner != nil {
+				t.Fatalf("Failed EqualError: %+v", errInner)
+			}
+
+			if result != testCase.expectedResult {
+				t.Fatalf("got result %+v, expected %+v", result, testCase.expectedResult)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package usecases
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"strings"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/pkg/myerrors"
+)
+
+const (
+	VariantThumbnail = "thumbnail"
+	VariantCard      = "card"
+	VariantFull      = "full"
+
+	// VariantSeparator separates name of original file and name of variant: <hash>_thumbnail.
+	// Full variant is stored without suffix, so urls of images uploaded before variants keep working.
+	VariantSeparator = "_"
+
+	MaxSideThumbnail = 200
+	MaxSideCard      = 600
+	MaxSideFull      = 1920
+
+	qualityJPEG = 85
+
+	formatJPEG = "jpeg"
+	formatPNG  = "png"
+)
+
+var ErrEncodeVariant = myerrors.NewErrorInternal("Не получилось сохранить уменьшенную копию фото")
+
+type Variant struct {
+	Name    string
+	MaxSide int
+}
+
+// Suffix is added to name of original file to get name of variant.
+func (v Variant) Suffix() string {
+	if v.Name == VariantFull {
+		return ""
+	}
+
+	return VariantSeparator + v.Name
+}
+
+// Variants is fixed set of sizes, which are generated for every uploaded image.
+// Full is last, so it's saved after others and existence of full means existence of all variants.
+var Variants = [...]Variant{ //nolint:gochecknoglobals
+	{Name: VariantThumbnail, MaxSide: MaxSideThumbnail},
+	{Name: VariantCard, MaxSide: MaxSideCard},
+	{Name: VariantFull, MaxSide: MaxSideFull},
+}
+
+// IsVariantSuffix reports whether suffix (without separator) is name of generated variant.
+func IsVariantSuffix(suffix string) bool {
+	for _, variant := range Variants {
+		if variant.Name != VariantFull && variant.Name == suffix {
+			return true
+		}
+	}
+
+	return false
+}
+
+// OriginalName returns name of original for name of variant and fileName itself for original.
+func OriginalName(fileName string) string {
+	idxSeparator := strings.LastIndex(fileName, VariantSeparator)
+	if idxSeparator == -1 || !IsVariantSuffix(fileName[idxSeparator+len(VariantSeparator):]) {
+		return fileName
+	}
+
+	return fileName[:idxSeparator]
+}
+
+type ImageVariant struct {
+	Variant Variant
+	Content []byte
+}
+
+// scaledSize keeps proportions, the bigger side becomes maxSide. Smaller images aren't enlarged.
+func scaledSize(width, height, maxSide int) (int, int) {
+	if width <= maxSide && height <= maxSide {
+		return width, height
+	}
+
+	if width >= height {
+		return maxSide, max(1, (height*maxSide+width/2)/width) //nolint:gomnd
+	}
+
+	return max(1, (width*maxSide+height/2)/height), maxSide //nolint:gomnd
+}
+
+// Resize reduces src to fit into maxSide x maxSide averaging areas of source pixels.
+func Resize(src *image.RGBA, maxSide int) *image.RGBA {
+	width, height := src.Rect.Dx(), src.Rect.Dy()
+
+	dstWidth, dstHeight := scaledSize(width, height, maxSide)
+	if dstWidth == width && dstHeight == height {
+		return src
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+
+	for dstY := 0; dstY < dstHeight; dstY++ {
+		srcY0, srcY1 := dstY*height/dstHeight, max((dstY+1)*height/dstHeight, dstY*height/dstHeight+1)
+
+		for dstX := 0; dstX < dstWidth; dstX++ {
+			srcX0, srcX1 := dstX*width/dstWidth, max((dstX+1)*width/dstWidth, dstX*width/dstWidth+1)
+
+			var sum [4]uint64
+
+			for srcY := srcY0; srcY < srcY1; srcY++ {
+				offset := src.PixOffset(srcX0, srcY)
+				for srcX := srcX0; srcX < srcX1; srcX++ {
+					sum[0] += uint64(src.Pix[offset])
+					sum[1] += uint64(src.Pix[offset+1])
+					sum[2] += uint64(src.Pix[offset+2])
+					sum[3] += uint64(src.Pix[offset+3])
+					offset += 4
+				}
+			}
+
+			count := uint64((srcY1 - srcY0) * (srcX1 - srcX0))
+			offset := dst.PixOffset(dstX, dstY)
+
+			for i := range sum {
+				dst.Pix[offset+i] = uint8((sum[i] + count/2) / count) //nolint:gomnd
+			}
+		}
+	}
+
+	return dst
+}
+
+func encodeImage(img image.Image, format string) ([]byte, error) {
+	buf := new(bytes.Buffer)
+
+	var err error
+
+	if format == formatPNG {
+		err = png.Encode(buf, img)
+	} else {
+		err = jpeg.Encode(buf, img, &jpeg.Options{Quality: qualityJPEG})
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w %s", ErrEncodeVariant, err.Error())
+	}
+
+	return buf.Bytes(), nil
+}
+
+// MakeImageVariants returns all Variants of image with applied EXIF orientation in format of original.
+// Full variant is original content without metadata, if it doesn't need neither rotation nor reducing.
+// Re-encoded variants don't contain metadata at all.
+func MakeImageVariants(content []byte) ([]ImageVariant, error) {
+	stripped := bytes.NewBuffer(make([]byte, 0, len(content)))
+
+	orientation, err := StripMetadataStream(stripped, bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+
+	return MakeStrippedImageVariants(stripped.Bytes(), orientation)
+}
+
+// MakeStrippedImageVariants is MakeImageVariants for content, which metadata is already stripped
+// by StripMetadataStream, orientation is returned by it.
+func MakeStrippedImageVariants(stripped []byte, orientation int) ([]ImageVariant, error) {
+	img, format, err := image.Decode(bytes.NewReader(stripped))
+	if err != nil || (format != formatJPEG && format != formatPNG) {
+		return nil, fmt.Errorf("вы используете формат %s %w", format, ErrWrongFormat)
+	}
+
+	oriented := ApplyOrientation(img, orientation)
+
+	result := make([]ImageVariant, 0, len(Variants))
+
+	for _, variant := range Variants {
+		bounds := oriented.Bounds()
+		if variant.Name == VariantFull && orientation == OrientationNormal &&
+			bounds.Dx() <= variant.MaxSide && bounds.Dy() <= variant.MaxSide {
+			result = append(result, ImageVariant{Variant: variant, Content: stripped})
+
+			continue
+		}
+
+		variantContent, err := encodeImage(Resize(oriented, variant.MaxSide), format)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, ImageVariant{Variant: variant, Content: variantContent})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,210 @@
+package usecases_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_2_Rabotyagi/services/file_service/internal/server/usecases"
+)
+
+// exifWithOrientation returns TIFF in big endian with single tag Orientation.
+func exifWithOrientation(orientation uint16) []byte {
+	tiff := new(bytes.Buffer)
+
+	tiff.WriteString("MM")
+	_ = binary.Write(tiff, binary.BigEndian, uint16(42))
+	_ = binary.Write(tiff, binary.BigEndian, uint32(8))
+	_ = binary.Write(tiff, binary.BigEndian, uint16(1))
+	_ = binary.Write(tiff, binary.BigEndian, []uint16{0x0112, 3})
+	_ = binary.Write(tiff, binary.BigEndian, uint32(1))
+	_ = binary.Write(tiff, binary.BigEndian, []uint16{orientation, 0})
+	_ = binary.Write(tiff, binary.BigEndian, uint32(0))
+
+	return tiff.Bytes()
+}
+
+func newTestImage(width, height int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 100, A: 255}) //nolint:gosec
+		}
+	}
+
+	return img
+}
+
+func newTestJPEG(t *testing.T, width, height int, orientation uint16) []byte {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+
+	err := jpeg.Encode(buf, newTestImage(width, height), nil)
+	if err != nil {
+		t.Fatalf("unexpected err=%+v", err)
+	}
+
+	content := buf.Bytes()
+	if orientation == 0 {
+		return content
+	}
+
+	segment := append([]byte("Exif\x00\x00"), exifWithOrientation(orientation)...)
+	app1 := append([]byte{0xFF, 0xE1, 0, 0}, segment...)
+	binary.BigEndian.PutUint16(app1[2:], uint16(len(segment)+2))
+
+	return append(append([]byte{0xFF, 0xD8}, app1...), content[2:]...)
+}
+
+func newTestPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+
+	err := png.Encode(buf, newTestImage(width, height))
+	if err != nil {
+		t.Fatalf("unexpected err=%+v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestReadOrientation(t *testing.T) {
+	t.Parallel()
+
+	if orientation := usecases.ReadOrientation(newTestJPEG(t, 4, 2, 6)); orientation != usecases.OrientationRotate90 {
+		t.Fatalf("got orientation %d, expected %d", orientation, usecases.OrientationRotate90)
+	}
+
+	if orientation := usecases.ReadOrientation(newTestJPEG(t, 4, 2, 0)); orientation != usecases.OrientationNormal {
+		t.Fatalf("got orientation %d, expected %d", orientation, usecases.OrientationNormal)
+	}
+
+	if orientation := usecases.ReadOrientation([]byte{0xFF, 0xD8, 0xFF}); orientation != usecases.OrientationNormal {
+		t.Fatalf("got orientation %d for broken jpeg, expected %d", orientation, usecases.OrientationNormal)
+	}
+}
+
+func TestApplyOrientation(t *testing.T) {
+	t.Parallel()
+
+	// 3x2 image, pixel in top left corner is marked.
+	src := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	src.Set(0, 0, color.RGBA{R: 255, A: 255})
+
+	type TestCase struct {
+		orientation    int
+		expectedSize   image.Point
+		expectedMarked image.Point
+	}
+
+	testCases := [...]TestCase{
+		{orientation: usecases.OrientationNormal, expectedSize: image.Pt(3, 2), expectedMarked: image.Pt(0, 0)},
+		{orientation: usecases.OrientationFlipH, expectedSize: image.Pt(3, 2), expectedMarked: image.Pt(2, 0)},
+		{orientation: usecases.OrientationRotate180, expectedSize: image.Pt(3, 2), expectedMarked: image.Pt(2, 1)},
+		{orientation: usecases.OrientationFlipV, expectedSize: image.Pt(3, 2), expectedMarked: image.Pt(0, 1)},
+		{orientation: usecases.OrientationTranspose, expectedSize: image.Pt(2, 3), expectedMarked: image.Pt(0, 0)},
+		{orientation: usecases.OrientationRotate90, expectedSize: image.Pt(2, 3), expectedMarked: image.Pt(1, 0)},
+		{orientation: usecases.OrientationTransverse, expectedSize: image.Pt(2, 3), expectedMarked: image.Pt(1, 2)},
+		{orientation: usecases.OrientationRotate270, expectedSize: image.Pt(2, 3), expectedMarked: image.Pt(0, 2)},
+	}
+
+	for _, testCase := range testCases {
+		dst := usecases.ApplyOrientation(src, testCase.orientation)
+
+		if size := dst.Bounds().Size(); size != testCase.expectedSize {
+			t.Fatalf("orientation %d: got size %v, expected %v", testCase.orientation, size, testCase.expectedSize)
+		}
+
+		if marked := dst.RGBAAt(testCase.expectedMarked.X, testCase.expectedMarked.Y); marked.R != 255 {
+			t.Fatalf("orientation %d: pixel %v isn't marked", testCase.orientation, testCase.expectedMarked)
+		}
+	}
+}
+
+func TestMakeImageVariants(t *testing.T) {
+	t.Parallel()
+
+	type TestCase struct {
+		name          string
+		content       []byte
+		expectedSizes map[string]image.Point
+		expectedSame  bool
+	}
+
+	smallPNG := newTestPNG(t, 150, 100)
+
+	testCases := [...]TestCase{
+		{
+			name:    "test rotated jpeg",
+			content: newTestJPEG(t, 800, 400, 6),
+			expectedSizes: map[string]image.Point{
+				usecases.VariantThumbnail: image.Pt(100, 200),
+				usecases.VariantCard:      image.Pt(300, 600),
+				usecases.VariantFull:      image.Pt(400, 800),
+			},
+			expectedSame: false,
+		},
+		{
+			name:    "test small png",
+			content: smallPNG,
+			expectedSizes: map[string]image.Point{
+				usecases.VariantThumbnail: image.Pt(150, 100),
+				usecases.VariantCard:      image.Pt(150, 100),
+				usecases.VariantFull:      image.Pt(150, 100),
+			},
+			expectedSame: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			imageVariants, err := usecases.MakeImageVariants(testCase.content)
+			if err != nil {
+				t.Fatalf("unexpected err=%+v", err)
+			}
+
+			if len(imageVariants) != len(usecases.Variants) {
+				t.Fatalf("got %d variants, expected %d", len(imageVariants), len(usecases.Variants))
+			}
+
+			for _, imageVariant := range imageVariants {
+				config, _, err := image.DecodeConfig(bytes.NewReader(imageVariant.Content))
+				if err != nil {
+					t.Fatalf("variant %s: unexpected err=%+v", imageVariant.Variant.Name, err)
+				}
+
+				size := image.Pt(config.Width, config.Height)
+				if size != testCase.expectedSizes[imageVariant.Variant.Name] {
+					t.Fatalf("variant %s: got size %v, expected %v", imageVariant.Variant.Name,
+						size, testCase.expectedSizes[imageVariant.Variant.Name])
+				}
+
+				if imageVariant.Variant.Name == usecases.VariantFull &&
+					bytes.Equal(imageVariant.Content, testCase.content) != testCase.expectedSame {
+					t.Fatalf("full variant is same as original: %t, expected %t",
+						!testCase.expectedSame, testCase.expectedSame)
+				}
+			}
+		})
+	}
+}
+
+func TestMakeImageVariantsWrongFormat(t *testing.T) {
+	t.Parallel()
+
+	_, err := usecases.MakeImageVariants([]byte("GIF89a"))
+	if err == nil {
+		t.Fatalf("expected error for wrong format")
+	}
+}
@@ -0,0 +1,57 @@
+package usecases
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// TTLVariantsCache missing variant appears after upload of the same image again,
+	// existing one disappears only with unreferenced image, so results can be kept for a while.
+	TTLVariantsCache = 10 * time.Minute
+
+	maxSizeVariantsCache = 100000
+)
+
+type variantsCacheItem struct {
+	exist     bool
+	expiresAt time.Time
+}
+
+// variantsCache keeps results of checks of variants in storage, so reads of images
+// don't make requests to storage every time.
+type variantsCache struct {
+	mu    sync.Mutex
+	ttl   time.Duration
+	items map[string]variantsCacheItem
+}
+
+func newVariantsCache(ttl time.Duration) *variantsCache {
+	return &variantsCache{ttl: ttl, items: make(map[string]variantsCacheItem)}
+}
+
+func (v *variantsCache) get(fileName string, now time.Time) (bool, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	item, ok := v.items[fileName]
+	if !ok || now.After(item.expiresAt) {
+		return false, false
+	}
+
+	return item.exist, true
+}
+
+// set cache is cleared, when it's full, instead of eviction of single items.
+func (v *variantsCache) set(fileNames []string, exist []bool, now time.Time) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if len(v.items)+len(fileNames) > maxSizeVariantsCache {
+		v.items = make(map[string]variantsCacheItem)
+	}
+
+	for i, fileName := range fileNames {
+		v.items[fileName] = variantsCacheItem{exist: exist[i], expiresAt: now.Add(v.ttl)}
+	}
+}